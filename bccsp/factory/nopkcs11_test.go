@@ -25,4 +25,9 @@ func TestInitFactories(t *testing.T) {
 		Default: "PKCS11",
 	})
 	require.EqualError(t, err, "Could not find default `PKCS11` BCCSP")
+
+	err = initFactories(&FactoryOpts{
+		Default: "VAULT",
+	})
+	require.EqualError(t, err, "Could not find default `VAULT` BCCSP")
 }
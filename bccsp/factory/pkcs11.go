@@ -11,6 +11,7 @@ package factory
 import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/bccsp/vault"
 	"github.com/pkg/errors"
 )
 
@@ -21,6 +22,7 @@ type FactoryOpts struct {
 	Default string             `json:"default" yaml:"Default"`
 	SW      *SwOpts            `json:"SW,omitempty" yaml:"SW,omitempty"`
 	PKCS11  *pkcs11.PKCS11Opts `json:"PKCS11,omitempty" yaml:"PKCS11"`
+	Vault   *vault.VaultOpts   `json:"VAULT,omitempty" yaml:"VAULT,omitempty"`
 }
 
 // InitFactories must be called before using factory interfaces
@@ -69,6 +71,16 @@ func initFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// Vault-Based BCCSP
+	if config.Default == "VAULT" && config.Vault != nil {
+		f := &VaultFactory{}
+		var err error
+		defaultBCCSP, err = initBCCSP(f, config)
+		if err != nil {
+			return errors.Wrapf(err, "Failed initializing VAULT.BCCSP")
+		}
+	}
+
 	if defaultBCCSP == nil {
 		return errors.Errorf("Could not find default `%s` BCCSP", config.Default)
 	}
@@ -84,6 +96,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 		f = &SWFactory{}
 	case "PKCS11":
 		f = &PKCS11Factory{}
+	case "VAULT":
+		f = &VaultFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.Default)
 	}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/vault"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultFactoryName(t *testing.T) {
+	f := &VaultFactory{}
+	require.Equal(t, f.Name(), VaultBasedFactoryName)
+}
+
+func TestVaultFactoryGetInvalidArgs(t *testing.T) {
+	f := &VaultFactory{}
+
+	_, err := f.Get(nil)
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{Vault: &vault.VaultOpts{}})
+	require.EqualError(t, err, "Failed initializing configuration: Hash Family not supported []")
+}
+
+func TestVaultFactoryGet(t *testing.T) {
+	f := &VaultFactory{}
+
+	csp, err := f.Get(&FactoryOpts{
+		Vault: &vault.VaultOpts{
+			Security: 256,
+			Hash:     "SHA2",
+			Address:  "http://localhost:8200",
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, csp)
+
+	csp, err = GetBCCSPFromOpts(&FactoryOpts{
+		Default: "VAULT",
+		Vault: &vault.VaultOpts{
+			Security: 256,
+			Hash:     "SHA2",
+			Address:  "http://localhost:8200",
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, csp)
+}
@@ -19,6 +19,10 @@ const (
 	// ECDSA Elliptic Curve Digital Signature Algorithm over P-384 curve
 	ECDSAP384 = "ECDSAP384"
 
+	// ED25519 Edwards-curve Digital Signature Algorithm over Curve25519 (key gen, import, sign, verify).
+	// Ed25519 signs the message itself rather than a digest of it.
+	ED25519 = "ED25519"
+
 	// ECDSAReRand ECDSA key re-randomization
 	ECDSAReRand = "ECDSA_RERAND"
 
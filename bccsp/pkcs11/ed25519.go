@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"crypto/ed25519"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// Ed25519 hashes the message as part of the signature scheme, hence the
+// message itself is passed in place of the digest.
+
+func (csp *impl) signED25519(k ed25519PrivateKey, msg []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	return csp.signP11ED25519(k.ski, msg)
+}
+
+func (csp *impl) verifyED25519(k ed25519PublicKey, signature, msg []byte, opts bccsp.SignerOpts) (bool, error) {
+	if csp.softVerify {
+		return ed25519.Verify(k.pub, msg, signature), nil
+	}
+	return csp.verifyP11ED25519(k.ski, msg, signature)
+}
@@ -0,0 +1,104 @@
+// +build pkcs11
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestED25519KeyGenSignVerify(t *testing.T) {
+	k, err := currentBCCSP.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	require.True(t, k.Private())
+	require.False(t, k.Symmetric())
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	raw, err := pk.Bytes()
+	require.NoError(t, err)
+	pub, err := x509.ParsePKIXPublicKey(raw)
+	require.NoError(t, err)
+	expectedSKI := sha256.Sum256(pub.(ed25519.PublicKey))
+	require.Equal(t, expectedSKI[:], k.SKI())
+
+	// Ed25519 signs the message itself
+	msg := []byte("Hello World")
+	signature, err := currentBCCSP.Sign(k, msg, nil)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(pub.(ed25519.PublicKey), msg, signature))
+
+	valid, err := currentBCCSP.Verify(k, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = currentBCCSP.Verify(pk, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = currentBCCSP.Verify(pk, signature, []byte("Hello World!"), nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	// the key is found by SKI
+	k2, err := currentBCCSP.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.Equal(t, k, k2)
+
+	// certificates with an Ed25519 public key are imported as Ed25519 public keys
+	cert := &x509.Certificate{PublicKey: pub}
+	importedPK, err := currentBCCSP.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, k.SKI(), importedPK.SKI())
+	valid, err = currentBCCSP.Verify(importedPK, signature, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestParseEdPoint(t *testing.T) {
+	point := make([]byte, ed25519.PublicKeySize)
+	point[0] = 0x04
+
+	parsed, err := parseEdPoint(point)
+	require.NoError(t, err)
+	require.Equal(t, ed25519.PublicKey(point), parsed)
+
+	encoded, err := asn1.Marshal(point)
+	require.NoError(t, err)
+	parsed, err = parseEdPoint(encoded)
+	require.NoError(t, err)
+	require.Equal(t, ed25519.PublicKey(point), parsed)
+
+	encoded[0] = 0x03
+	_, err = parseEdPoint(encoded)
+	require.EqualError(t, err, fmt.Sprintf("Failed Unmarshaling Ed25519 point [%x]", encoded))
+
+	_, err = parseEdPoint(point[:16])
+	require.EqualError(t, err, "Invalid Ed25519 point length [16]")
+}
+
+func TestIsEd25519Params(t *testing.T) {
+	oid, err := asn1.Marshal(oidEd25519)
+	require.NoError(t, err)
+	require.True(t, isEd25519Params(oid))
+
+	curveName, err := asn1.MarshalWithParams(edwards25519CurveName, "printable")
+	require.NoError(t, err)
+	require.True(t, isEd25519Params(curveName))
+
+	oid, err = asn1.Marshal(oidNamedCurveP256)
+	require.NoError(t, err)
+	require.False(t, isEd25519Params(oid))
+	require.False(t, isEd25519Params([]byte{0x01}))
+}
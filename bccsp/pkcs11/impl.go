@@ -8,6 +8,7 @@ package pkcs11
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"os"
 
@@ -116,6 +117,14 @@ func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
 
 		k = &ecdsaPrivateKey{ski, ecdsaPublicKey{ski, pub}}
 
+	case *bccsp.ED25519KeyGenOpts:
+		ski, pub, err := csp.generateEdKey(opts.Ephemeral())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed generating Ed25519 key")
+		}
+
+		k = &ed25519PrivateKey{ski, ed25519PublicKey{ski, pub}}
+
 	default:
 		return csp.BCCSP.KeyGen(opts)
 	}
@@ -148,8 +157,10 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		switch pk.(type) {
 		case *ecdsa.PublicKey:
 			return csp.KeyImport(pk, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
+		case ed25519.PublicKey:
+			return csp.KeyImport(pk, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 		default:
-			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA]")
+			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, ED25519]")
 		}
 
 	default:
@@ -168,6 +179,13 @@ func (csp *impl) GetKey(ski []byte) (bccsp.Key, error) {
 		}
 		return &ecdsaPublicKey{ski, pubKey}, nil
 	}
+	edPubKey, isPriv, err := csp.getEdKey(ski)
+	if err == nil {
+		if isPriv {
+			return &ed25519PrivateKey{ski, ed25519PublicKey{ski, edPubKey}}, nil
+		}
+		return &ed25519PublicKey{ski, edPubKey}, nil
+	}
 	return csp.BCCSP.GetKey(ski)
 }
 
@@ -190,6 +208,8 @@ func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte
 	switch key := k.(type) {
 	case *ecdsaPrivateKey:
 		return csp.signECDSA(*key, digest, opts)
+	case *ed25519PrivateKey:
+		return csp.signED25519(*key, digest, opts)
 	default:
 		return csp.BCCSP.Sign(key, digest, opts)
 	}
@@ -214,6 +234,10 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 		return csp.verifyECDSA(key.pub, signature, digest, opts)
 	case *ecdsaPublicKey:
 		return csp.verifyECDSA(*key, signature, digest, opts)
+	case *ed25519PrivateKey:
+		return csp.verifyED25519(key.pub, signature, digest, opts)
+	case *ed25519PublicKey:
+		return csp.verifyED25519(*key, signature, digest, opts)
 	default:
 		return csp.BCCSP.Verify(k, signature, digest, opts)
 	}
@@ -8,6 +8,7 @@ package pkcs11
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/asn1"
@@ -163,6 +164,37 @@ func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err
 	return pubKey, isPriv, nil
 }
 
+// Look for an Ed25519 key by SKI, stored in CKA_ID
+func (csp *impl) getEdKey(ski []byte) (pubKey ed25519.PublicKey, isPriv bool, err error) {
+	p11lib := csp.ctx
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, false, err
+	}
+	defer csp.returnSession(session)
+	isPriv = true
+	_, err = findKeyPairFromSKI(p11lib, session, ski, privateKeyType)
+	if err != nil {
+		isPriv = false
+		logger.Debugf("Private key not found [%s] for SKI [%s], looking for Public key", err, hex.EncodeToString(ski))
+	}
+
+	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyType)
+	if err != nil {
+		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+	}
+
+	pubKey, params, err := edPoint(p11lib, session, *publicKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("Public key not found [%s] for SKI [%s]", err, hex.EncodeToString(ski))
+	}
+	if !isEd25519Params(params) {
+		return nil, false, fmt.Errorf("Cound not recognize Curve from params [%x]", params)
+	}
+
+	return pubKey, isPriv, nil
+}
+
 // RFC 5480, 2.1.1.1. Named Curve
 //
 // secp224r1 OBJECT IDENTIFIER ::= {
@@ -199,6 +231,35 @@ func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 	return nil
 }
 
+// Edwards-curve key type and mechanisms of PKCS#11 v3.0, which are not
+// defined by the PKCS#11 bindings
+const (
+	ckkECEdwards            = 0x00000040 // CKK_EC_EDWARDS
+	ckmECEdwardsKeyPairGen  = 0x00001055 // CKM_EC_EDWARDS_KEY_PAIR_GEN
+	ckmEdDSA                = 0x00001057 // CKM_EDDSA
+	edwards25519CurveName   = "edwards25519"
+	ed25519PublicKeyASN1Len = 2 + ed25519.PublicKeySize
+)
+
+// RFC 8410, 3. Curve25519 and Curve448 Algorithm Identifiers
+//
+// id-Ed25519 OBJECT IDENTIFIER ::= { 1 3 101 112 }
+var oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+// isEd25519Params returns true if the CKA_EC_PARAMS value identifies the
+// edwards25519 curve, either by OID or by curve name as per PKCS#11 v3.0
+func isEd25519Params(marshaledParams []byte) bool {
+	oid := asn1.ObjectIdentifier{}
+	if _, err := asn1.Unmarshal(marshaledParams, &oid); err == nil {
+		return oid.Equal(oidEd25519)
+	}
+	var curveName string
+	if _, err := asn1.UnmarshalWithParams(marshaledParams, &curveName, "printable"); err == nil {
+		return curveName == edwards25519CurveName
+	}
+	return false
+}
+
 func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
 	p11lib := csp.ctx
 	session, err := csp.getSession()
@@ -256,21 +317,119 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 	hash := sha256.Sum256(ecpt)
 	ski = hash[:]
 
+	logger.Infof("Generated new P11 key, SKI %x\n", ski)
+	err = csp.setKeyPairSKI(p11lib, session, pub, prv, ski)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nistCurve := namedCurveFromOID(curve)
+	if curve == nil {
+		return nil, nil, fmt.Errorf("Cound not recognize Curve from OID")
+	}
+	x, y := elliptic.Unmarshal(nistCurve, ecpt)
+	if x == nil {
+		return nil, nil, fmt.Errorf("Failed Unmarshaling Public Key")
+	}
+
+	pubGoKey := &ecdsa.PublicKey{Curve: nistCurve, X: x, Y: y}
+
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		listAttrs(p11lib, session, prv)
+		listAttrs(p11lib, session, pub)
+	}
+
+	return ski, pubGoKey, nil
+}
+
+func (csp *impl) generateEdKey(ephemeral bool) (ski []byte, pubKey ed25519.PublicKey, err error) {
+	p11lib := csp.ctx
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer csp.returnSession(session)
+
+	id := nextIDCtr()
+	publabel := fmt.Sprintf("BCPUB%s", id.Text(16))
+	prvlabel := fmt.Sprintf("BCPRV%s", id.Text(16))
+
+	marshaledOID, err := asn1.Marshal(oidEd25519)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not marshal OID [%s]", err.Error())
+	}
+
+	pubkeyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, ckkECEdwards),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, marshaledOID),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, publabel),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, publabel),
+	}
+
+	prvkeyT := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, ckkECEdwards),
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, !ephemeral),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+
+		pkcs11.NewAttribute(pkcs11.CKA_ID, prvlabel),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, prvlabel),
+
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+	}
+
+	pub, prv, err := p11lib.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(ckmECEdwardsKeyPairGen, nil)},
+		pubkeyT, prvkeyT)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("P11: keypair generate failed [%s]", err)
+	}
+
+	pubKey, _, err = edPoint(p11lib, session, pub)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error querying EC-point: [%s]", err)
+	}
+	hash := sha256.Sum256(pubKey)
+	ski = hash[:]
+
+	logger.Infof("Generated new P11 key, SKI %x\n", ski)
+	err = csp.setKeyPairSKI(p11lib, session, pub, prv, ski)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		listAttrs(p11lib, session, prv)
+		listAttrs(p11lib, session, pub)
+	}
+
+	return ski, pubKey, nil
+}
+
+// setKeyPairSKI labels both keys of a newly generated key pair with the SKI
+// and makes them immutable if so configured
+func (csp *impl) setKeyPairSKI(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, pub, prv pkcs11.ObjectHandle, ski []byte) error {
 	// set CKA_ID of the both keys to SKI(public key) and CKA_LABEL to hex string of SKI
 	setskiT := []*pkcs11.Attribute{
 		pkcs11.NewAttribute(pkcs11.CKA_ID, ski),
 		pkcs11.NewAttribute(pkcs11.CKA_LABEL, hex.EncodeToString(ski)),
 	}
 
-	logger.Infof("Generated new P11 key, SKI %x\n", ski)
-	err = p11lib.SetAttributeValue(session, pub, setskiT)
+	err := p11lib.SetAttributeValue(session, pub, setskiT)
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[public] failed [%s]", err)
+		return fmt.Errorf("P11: set-ID-to-SKI[public] failed [%s]", err)
 	}
 
 	err = p11lib.SetAttributeValue(session, prv, setskiT)
 	if err != nil {
-		return nil, nil, fmt.Errorf("P11: set-ID-to-SKI[private] failed [%s]", err)
+		return fmt.Errorf("P11: set-ID-to-SKI[private] failed [%s]", err)
 	}
 
 	//Set CKA_Modifible to false for both public key and private keys
@@ -281,41 +440,25 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 
 		_, pubCopyerror := p11lib.CopyObject(session, pub, setCKAModifiable)
 		if pubCopyerror != nil {
-			return nil, nil, fmt.Errorf("P11: Public Key copy failed with error [%s] . Please contact your HSM vendor", pubCopyerror)
+			return fmt.Errorf("P11: Public Key copy failed with error [%s] . Please contact your HSM vendor", pubCopyerror)
 		}
 
 		pubKeyDestroyError := p11lib.DestroyObject(session, pub)
 		if pubKeyDestroyError != nil {
-			return nil, nil, fmt.Errorf("P11: Public Key destroy failed with error [%s]. Please contact your HSM vendor", pubCopyerror)
+			return fmt.Errorf("P11: Public Key destroy failed with error [%s]. Please contact your HSM vendor", pubCopyerror)
 		}
 
 		_, prvCopyerror := p11lib.CopyObject(session, prv, setCKAModifiable)
 		if prvCopyerror != nil {
-			return nil, nil, fmt.Errorf("P11: Private Key copy failed with error [%s]. Please contact your HSM vendor", prvCopyerror)
+			return fmt.Errorf("P11: Private Key copy failed with error [%s]. Please contact your HSM vendor", prvCopyerror)
 		}
 		prvKeyDestroyError := p11lib.DestroyObject(session, prv)
 		if pubKeyDestroyError != nil {
-			return nil, nil, fmt.Errorf("P11: Private Key destroy failed with error [%s]. Please contact your HSM vendor", prvKeyDestroyError)
+			return fmt.Errorf("P11: Private Key destroy failed with error [%s]. Please contact your HSM vendor", prvKeyDestroyError)
 		}
 	}
 
-	nistCurve := namedCurveFromOID(curve)
-	if curve == nil {
-		return nil, nil, fmt.Errorf("Cound not recognize Curve from OID")
-	}
-	x, y := elliptic.Unmarshal(nistCurve, ecpt)
-	if x == nil {
-		return nil, nil, fmt.Errorf("Failed Unmarshaling Public Key")
-	}
-
-	pubGoKey := &ecdsa.PublicKey{Curve: nistCurve, X: x, Y: y}
-
-	if logger.IsEnabledFor(zapcore.DebugLevel) {
-		listAttrs(p11lib, session, prv)
-		listAttrs(p11lib, session, pub)
-	}
-
-	return ski, pubGoKey, nil
+	return nil
 }
 
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
@@ -390,6 +533,63 @@ func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize
 	return true, nil
 }
 
+func (csp *impl) signP11ED25519(ski []byte, msg []byte) ([]byte, error) {
+	p11lib := csp.ctx
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, err
+	}
+	defer csp.returnSession(session)
+
+	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyType)
+	if err != nil {
+		return nil, fmt.Errorf("Private key not found [%s]", err)
+	}
+
+	err = p11lib.SignInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(ckmEdDSA, nil)}, *privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Sign-initialize  failed [%s]", err)
+	}
+
+	sig, err := p11lib.Sign(session, msg)
+	if err != nil {
+		return nil, fmt.Errorf("P11: sign failed [%s]", err)
+	}
+
+	return sig, nil
+}
+
+func (csp *impl) verifyP11ED25519(ski []byte, msg []byte, sig []byte) (bool, error) {
+	p11lib := csp.ctx
+	session, err := csp.getSession()
+	if err != nil {
+		return false, err
+	}
+	defer csp.returnSession(session)
+
+	logger.Debugf("Verify Ed25519\n")
+
+	publicKey, err := findKeyPairFromSKI(p11lib, session, ski, publicKeyType)
+	if err != nil {
+		return false, fmt.Errorf("Public key not found [%s]", err)
+	}
+
+	err = p11lib.VerifyInit(session, []*pkcs11.Mechanism{pkcs11.NewMechanism(ckmEdDSA, nil)},
+		*publicKey)
+	if err != nil {
+		return false, fmt.Errorf("PKCS11: Verify-initialize [%s]", err)
+	}
+	err = p11lib.Verify(session, msg, sig)
+	if err == pkcs11.Error(pkcs11.CKR_SIGNATURE_INVALID) || err == pkcs11.Error(pkcs11.CKR_SIGNATURE_LEN_RANGE) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("PKCS11: Verify failed [%s]", err)
+	}
+
+	return true, nil
+}
+
 type keyType int8
 
 const (
@@ -508,6 +708,56 @@ func ecPoint(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, key pkcs11.Object
 	return ecpt, oid, nil
 }
 
+// edPoint returns the encoded point of an Edwards-curve public key along with
+// its marshaled curve parameters. PKCS#11 v3.0 mandates the point to be DER
+// encoded as an OCTET STRING, whereas some tokens return the raw point
+func edPoint(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, key pkcs11.ObjectHandle) (ecpt ed25519.PublicKey, params []byte, err error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+	}
+
+	attr, err := p11lib.GetAttributeValue(session, key, template)
+	if err != nil {
+		return nil, nil, fmt.Errorf("PKCS11: get(EC point) [%s]", err)
+	}
+
+	var raw []byte
+	for _, a := range attr {
+		if a.Type == pkcs11.CKA_EC_POINT {
+			logger.Debugf("EC point: attr type %d/0x%x, len %d\n%s\n", a.Type, a.Type, len(a.Value), hex.Dump(a.Value))
+			raw = a.Value
+		} else if a.Type == pkcs11.CKA_EC_PARAMS {
+			params = a.Value
+		}
+	}
+	if params == nil || raw == nil {
+		return nil, nil, fmt.Errorf("CKA_EC_POINT not found, perhaps not an EC Key?")
+	}
+
+	ecpt, err = parseEdPoint(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ecpt, params, nil
+}
+
+func parseEdPoint(raw []byte) (ed25519.PublicKey, error) {
+	switch len(raw) {
+	case ed25519.PublicKeySize:
+		return ed25519.PublicKey(raw), nil
+	case ed25519PublicKeyASN1Len:
+		var point []byte
+		rest, err := asn1.Unmarshal(raw, &point)
+		if err != nil || len(rest) != 0 || len(point) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("Failed Unmarshaling Ed25519 point [%x]", raw)
+		}
+		return ed25519.PublicKey(point), nil
+	default:
+		return nil, fmt.Errorf("Invalid Ed25519 point length [%d]", len(raw))
+	}
+}
+
 func listAttrs(p11lib *pkcs11.Ctx, session pkcs11.SessionHandle, obj pkcs11.ObjectHandle) {
 	var cktype, ckclass uint
 	var ckaid, cklabel []byte
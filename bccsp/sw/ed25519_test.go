@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestED25519SignerSign(t *testing.T) {
+	t.Parallel()
+
+	signer := &ed25519Signer{}
+	verifierPrivateKey := &ed25519PrivateKeyVerifier{}
+	verifierPublicKey := &ed25519PublicKeyKeyVerifier{}
+
+	// Generate a key
+	_, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := &ed25519PrivateKey{lowLevelKey}
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+
+	// Sign
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(k, msg, nil)
+	require.NoError(t, err)
+	require.Len(t, sigma, ed25519.SignatureSize)
+
+	// Verify
+	valid, err := verifyED25519(lowLevelKey.Public().(ed25519.PublicKey), sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifierPrivateKey.Verify(k, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifierPublicKey.Verify(pk, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifierPublicKey.Verify(pk, sigma, []byte("Hello World!"), nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestED25519Keys(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	expectedSKI := sha256.Sum256(pub)
+
+	k := &ed25519PrivateKey{priv}
+	require.True(t, k.Private())
+	require.False(t, k.Symmetric())
+	require.Equal(t, expectedSKI[:], k.SKI())
+	_, err = k.Bytes()
+	require.EqualError(t, err, "Not supported.")
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	require.False(t, pk.Private())
+	require.False(t, pk.Symmetric())
+	require.Equal(t, expectedSKI[:], pk.SKI())
+	raw, err := pk.Bytes()
+	require.NoError(t, err)
+	parsed, err := x509.ParsePKIXPublicKey(raw)
+	require.NoError(t, err)
+	require.Equal(t, pub, parsed)
+
+	require.Nil(t, (&ed25519PrivateKey{}).SKI())
+	require.Nil(t, (&ed25519PublicKey{}).SKI())
+}
+
+func TestED25519KeyImport(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	k, err := (&ed25519PrivateKeyImportOptsKeyImporter{}).KeyImport(der, &bccsp.ED25519PrivateKeyImportOpts{})
+	require.NoError(t, err)
+	require.Equal(t, &ed25519PrivateKey{priv}, k)
+
+	_, err = (&ed25519PrivateKeyImportOptsKeyImporter{}).KeyImport("Hello World", &bccsp.ED25519PrivateKeyImportOpts{})
+	require.EqualError(t, err, "[ED25519PrivateKeyImportOpts] Invalid raw material. Expected byte array.")
+	_, err = (&ed25519PrivateKeyImportOptsKeyImporter{}).KeyImport([]byte{}, &bccsp.ED25519PrivateKeyImportOpts{})
+	require.EqualError(t, err, "[ED25519PrivateKeyImportOpts] Invalid raw. It must not be nil.")
+	_, err = (&ed25519PrivateKeyImportOptsKeyImporter{}).KeyImport([]byte{0}, &bccsp.ED25519PrivateKeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed converting PKCS#8 to Ed25519 private key")
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaDER, err := x509.MarshalPKCS8PrivateKey(ecdsaKey)
+	require.NoError(t, err)
+	_, err = (&ed25519PrivateKeyImportOptsKeyImporter{}).KeyImport(ecdsaDER, &bccsp.ED25519PrivateKeyImportOpts{})
+	require.EqualError(t, err, "Failed casting to Ed25519 private key. Invalid raw material.")
+
+	pk, err := (&ed25519GoPublicKeyImportOptsKeyImporter{}).KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{})
+	require.NoError(t, err)
+	require.Equal(t, &ed25519PublicKey{pub}, pk)
+
+	_, err = (&ed25519GoPublicKeyImportOptsKeyImporter{}).KeyImport([]byte(pub), &bccsp.ED25519GoPublicKeyImportOpts{})
+	require.EqualError(t, err, "Invalid raw material. Expected ed25519.PublicKey.")
+	_, err = (&ed25519GoPublicKeyImportOptsKeyImporter{}).KeyImport(pub[:16], &bccsp.ED25519GoPublicKeyImportOpts{})
+	require.EqualError(t, err, "Invalid Ed25519 public key length [16]. Must be 32 bytes")
+}
+
+func TestED25519CSP(t *testing.T) {
+	t.Parallel()
+
+	csp, err := NewDefaultSecurityLevelWithKeystore(NewDummyKeyStore())
+	require.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	require.IsType(t, &ed25519PrivateKey{}, k)
+
+	// Ed25519 signs the message itself
+	msg := []byte("a message longer than any digest the other signature schemes are given to sign")
+	sigma, err := csp.Sign(k, msg, nil)
+	require.NoError(t, err)
+
+	valid, err := csp.Verify(k, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// certificates with an Ed25519 public key are imported as Ed25519 public keys
+	cert := &x509.Certificate{PublicKey: k.(*ed25519PrivateKey).privKey.Public()}
+	pk, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, k.SKI(), pk.SKI())
+	valid, err = csp.Verify(pk, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestED25519FileKeyStore(t *testing.T) {
+	t.Parallel()
+
+	for _, pwd := range [][]byte{nil, []byte("password")} {
+		tempDir, err := ioutil.TempDir("", "bccspks")
+		require.NoError(t, err)
+		defer os.RemoveAll(tempDir)
+
+		ks, err := NewFileBasedKeyStore(pwd, tempDir, false)
+		require.NoError(t, err)
+
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		k := &ed25519PrivateKey{priv}
+		pk, err := k.PublicKey()
+		require.NoError(t, err)
+
+		require.NoError(t, ks.StoreKey(k))
+		loaded, err := ks.GetKey(k.SKI())
+		require.NoError(t, err)
+		require.Equal(t, k, loaded)
+
+		// the private key is also found when stored under a file name unrelated to its SKI
+		require.NoError(t, os.Rename(filepath.Join(tempDir, hex.EncodeToString(k.SKI())+"_sk"), filepath.Join(tempDir, "priv_sk")))
+		found, err := ks.GetKey(k.SKI())
+		require.NoError(t, err)
+		require.Equal(t, k, found)
+
+		// the public key is stored on its own since both keys share the SKI
+		otherDir := filepath.Join(tempDir, "pk")
+		pks, err := NewFileBasedKeyStore(pwd, otherDir, false)
+		require.NoError(t, err)
+		require.NoError(t, pks.StoreKey(pk))
+		loadedPK, err := pks.GetKey(pk.SKI())
+		require.NoError(t, err)
+		require.Equal(t, pk, loadedPK)
+	}
+}
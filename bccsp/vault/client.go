@@ -0,0 +1,227 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const requestTimeout = 30 * time.Second
+
+// transitClient is a minimal client of the transit secrets engine of Vault
+type transitClient struct {
+	address    string
+	token      string
+	namespace  string
+	mountPath  string
+	httpClient *http.Client
+}
+
+// transitKey is the subset of the response of the read key endpoint used by the provider
+type transitKey struct {
+	Type string `json:"type"`
+	Keys map[string]struct {
+		PublicKey string `json:"public_key"`
+	} `json:"keys"`
+}
+
+func newTransitClient(opts VaultOpts) (*transitClient, error) {
+	if opts.Address == "" {
+		return nil, errors.New("the address of the Vault server must be specified")
+	}
+	if _, err := url.Parse(opts.Address); err != nil {
+		return nil, errors.Wrapf(err, "invalid address of the Vault server [%s]", opts.Address)
+	}
+	token := opts.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	mountPath := strings.Trim(opts.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.TLSCACert != "" {
+		caPEM, err := ioutil.ReadFile(opts.TLSCACert)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading the TLS CA certificate of the Vault server")
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificate found in [%s]", opts.TLSCACert)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+
+	return &transitClient{
+		address:    strings.TrimRight(opts.Address, "/"),
+		token:      token,
+		namespace:  opts.Namespace,
+		mountPath:  mountPath,
+		httpClient: &http.Client{Transport: transport, Timeout: requestTimeout},
+	}, nil
+}
+
+// createKey creates a key of the given type with the given name
+func (c *transitClient) createKey(name, keyType string) error {
+	return c.do(http.MethodPost, "keys/"+url.PathEscape(name), map[string]interface{}{"type": keyType}, nil)
+}
+
+// readKey returns the public keys of all the versions of the key with the given name
+func (c *transitClient) readKey(name string) (*transitKey, error) {
+	key := &transitKey{}
+	if err := c.do(http.MethodGet, "keys/"+url.PathEscape(name), nil, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// listKeys returns the names of all the keys in the transit engine
+func (c *transitClient) listKeys() ([]string, error) {
+	resp := struct {
+		Keys []string `json:"keys"`
+	}{}
+	err := c.do(http.MethodGet, "keys?list=true", nil, &resp)
+	if errors.Cause(err) == errNotFound {
+		// the transit engine returns 404 if no key exists
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// sign signs the digest with the given version of the key with the given name and returns
+// the ASN.1 DER encoded signature
+func (c *transitClient) sign(name string, version int, digest []byte) ([]byte, error) {
+	req := map[string]interface{}{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"key_version":          version,
+		"prehashed":            true,
+		"marshaling_algorithm": "asn1",
+	}
+	resp := struct {
+		Signature string `json:"signature"`
+	}{}
+	path := "sign/" + url.PathEscape(name) + "/" + hashAlgorithm(digest)
+	if err := c.do(http.MethodPost, path, req, &resp); err != nil {
+		return nil, err
+	}
+
+	// the signature is formatted as vault:v<version>:<base64 signature>
+	parts := strings.SplitN(resp.Signature, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, errors.Errorf("unexpected format of the signature returned by Vault [%s]", resp.Signature)
+	}
+	signature, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decoding the signature returned by Vault")
+	}
+	return signature, nil
+}
+
+var errNotFound = errors.New("not found")
+
+func (c *transitClient) do(method, path string, reqBody, respData interface{}) error {
+	var body []byte
+	if reqBody != nil {
+		var err error
+		if body, err = json.Marshal(reqBody); err != nil {
+			return errors.Wrap(err, "failed marshalling the request to Vault")
+		}
+	}
+	req, err := http.NewRequest(method, c.address+"/v1/"+c.mountPath+"/"+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed creating the request to Vault")
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	req.Header.Set("X-Vault-Request", "true")
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed sending the request %s %s to Vault", method, req.URL.Path)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading the response of Vault to %s %s", method, req.URL.Path)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errors.WithMessagef(errNotFound, "%s %s", method, req.URL.Path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		vaultErr := struct {
+			Errors []string `json:"errors"`
+		}{}
+		json.Unmarshal(respBody, &vaultErr)
+		return errors.Errorf("Vault returned status %d to %s %s: %s", resp.StatusCode, method, req.URL.Path, strings.Join(vaultErr.Errors, "; "))
+	}
+	if respData == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return errors.Wrapf(err, "failed unmarshalling the response of Vault to %s %s", method, req.URL.Path)
+	}
+	if err := json.Unmarshal(envelope.Data, respData); err != nil {
+		return errors.Wrapf(err, "failed unmarshalling the data returned by Vault to %s %s", method, req.URL.Path)
+	}
+	return nil
+}
+
+// hashAlgorithm returns the transit name of the hash algorithm that produces digests of the length
+// of the given digest. Since the digest is prehashed, the name only informs Vault of the digest size
+func hashAlgorithm(digest []byte) string {
+	switch len(digest) {
+	case 48:
+		return "sha2-384"
+	case 64:
+		return "sha2-512"
+	default:
+		return "sha2-256"
+	}
+}
+
+// keyVersions returns the versions of the key that have a public key
+func (k *transitKey) keyVersions() (map[int]string, error) {
+	versions := map[int]string{}
+	for v, key := range k.Keys {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid key version [%s]", v)
+		}
+		if key.PublicKey != "" {
+			versions[version] = key.PublicKey
+		}
+	}
+	return versions, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"fmt"
+)
+
+const (
+	defaultMountPath = "transit"
+	defaultKeyPrefix = "fabric-"
+
+	keyTypeECDSAP256 = "ecdsa-p256"
+	keyTypeECDSAP384 = "ecdsa-p384"
+)
+
+type config struct {
+	keyType string
+}
+
+func (conf *config) setSecurityLevel(securityLevel int, hashFamily string) error {
+	if hashFamily != "SHA2" && hashFamily != "SHA3" {
+		return fmt.Errorf("Hash Family not supported [%s]", hashFamily)
+	}
+	switch securityLevel {
+	case 256:
+		conf.keyType = keyTypeECDSAP256
+	case 384:
+		conf.keyType = keyTypeECDSAP384
+	default:
+		return fmt.Errorf("Security level not supported [%d]", securityLevel)
+	}
+	return nil
+}
+
+// VaultOpts contains options for the VaultFactory
+type VaultOpts struct {
+	// Default algorithms when not specified (Deprecated?)
+	Security int    `json:"security"`
+	Hash     string `json:"hash"`
+
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string `json:"address"`
+	// Token used to authenticate to the Vault server. The VAULT_TOKEN environment
+	// variable is used if unset
+	Token string `json:"token"`
+	// Namespace of the Vault Enterprise namespace the transit engine is mounted in, if any
+	Namespace string `json:"namespace,omitempty"`
+	// MountPath of the transit secrets engine, defaults to "transit"
+	MountPath string `json:"mountpath,omitempty"`
+	// KeyPrefix is prepended to the names of the keys generated in the transit engine.
+	// Only the keys with this prefix are looked up by SKI, defaults to "fabric-"
+	KeyPrefix string `json:"keyprefix,omitempty"`
+	// TLSCACert is the path of the PEM encoded CA certificate that the TLS certificate of
+	// the Vault server is verified against. The system roots are used if unset
+	TLSCACert string `json:"tlscacert,omitempty"`
+}
@@ -0,0 +1,298 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("bccsp_vault")
+
+// New returns a new instance of the BCCSP that delegates the generation of the
+// ECDSA keys and the signing with them to the transit secrets engine of Vault.
+// The other operations are performed by the software-based BCCSP set at the
+// passed security level, hash family and KeyStore.
+func New(opts VaultOpts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	// Init config
+	conf := &config{}
+	err := conf.setSecurityLevel(opts.Security, opts.Hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing configuration")
+	}
+
+	// Check KeyStore
+	if keyStore == nil {
+		return nil, errors.New("Invalid bccsp.KeyStore instance. It must be different from nil")
+	}
+
+	swCSP, err := sw.NewWithParams(opts.Security, opts.Hash, keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	client, err := newTransitClient(opts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Failed initializing Vault client")
+	}
+
+	keyPrefix := opts.KeyPrefix
+	if keyPrefix == "" {
+		keyPrefix = defaultKeyPrefix
+	}
+
+	return &impl{
+		BCCSP:     swCSP,
+		conf:      conf,
+		client:    client,
+		keyPrefix: keyPrefix,
+		keys:      map[string]*ecdsaPrivateKey{},
+	}, nil
+}
+
+type impl struct {
+	bccsp.BCCSP
+
+	conf      *config
+	client    *transitClient
+	keyPrefix string
+
+	// keys caches the keys loaded from the transit engine by SKI
+	mutex sync.Mutex
+	keys  map[string]*ecdsaPrivateKey
+}
+
+// KeyGen generates a key using opts.
+func (csp *impl) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	// Validate arguments
+	if opts == nil {
+		return nil, errors.New("Invalid Opts parameter. It must not be nil")
+	}
+
+	// Parse algorithm
+	switch opts.(type) {
+	case *bccsp.ECDSAKeyGenOpts:
+		k, err = csp.generateECKey(csp.conf.keyType)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed generating ECDSA key")
+		}
+
+	case *bccsp.ECDSAP256KeyGenOpts:
+		k, err = csp.generateECKey(keyTypeECDSAP256)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed generating ECDSA P256 key")
+		}
+
+	case *bccsp.ECDSAP384KeyGenOpts:
+		k, err = csp.generateECKey(keyTypeECDSAP384)
+		if err != nil {
+			return nil, errors.WithMessage(err, "Failed generating ECDSA P384 key")
+		}
+
+	default:
+		return csp.BCCSP.KeyGen(opts)
+	}
+
+	return k, nil
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *impl) GetKey(ski []byte) (bccsp.Key, error) {
+	k, err := csp.findKey(ski)
+	if err == nil {
+		return k, nil
+	}
+	logger.Debugf("Key not found in Vault for SKI [%x]: %s", ski, err)
+	return csp.BCCSP.GetKey(ski)
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+//
+// Note that when a signature of a hash of a larger message is needed,
+// the caller is responsible for hashing the larger message and passing
+// the hash (as digest).
+func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty")
+	}
+
+	// Check key type
+	switch key := k.(type) {
+	case *ecdsaPrivateKey:
+		return csp.signECDSA(key, digest)
+	default:
+		return csp.BCCSP.Sign(key, digest, opts)
+	}
+}
+
+// Verify verifies signature against key k and digest
+func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	// Validate arguments
+	if k == nil {
+		return false, errors.New("Invalid Key. It must not be nil")
+	}
+	if len(signature) == 0 {
+		return false, errors.New("Invalid signature. Cannot be empty")
+	}
+	if len(digest) == 0 {
+		return false, errors.New("Invalid digest. Cannot be empty")
+	}
+
+	// Check key type. The signatures are verified in software since the public keys are known
+	switch key := k.(type) {
+	case *ecdsaPrivateKey:
+		return verifyECDSA(&key.pub, signature, digest)
+	case *ecdsaPublicKey:
+		return verifyECDSA(key, signature, digest)
+	default:
+		return csp.BCCSP.Verify(k, signature, digest, opts)
+	}
+}
+
+// generateECKey creates a key of the given type in the transit engine. As the SKI is only known
+// once the key pair exists, the key is named after a random identifier and looked up by SKI later on
+func (csp *impl) generateECKey(keyType string) (*ecdsaPrivateKey, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "failed generating key name")
+	}
+	name := csp.keyPrefix + hex.EncodeToString(id)
+	if err := csp.client.createKey(name, keyType); err != nil {
+		return nil, err
+	}
+
+	csp.mutex.Lock()
+	defer csp.mutex.Unlock()
+	keys, err := csp.loadKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != 1 {
+		return nil, errors.Errorf("expected a single version of the new key [%s], found %d", name, len(keys))
+	}
+	logger.Infof("Generated new Vault key [%s], SKI %x", name, keys[0].SKI())
+	return keys[0], nil
+}
+
+// findKey returns the key with the given SKI. If the key is not cached, all the keys with the configured
+// prefix are loaded from the transit engine, which picks up the keys generated by other instances as well
+// as the new versions of the keys rotated in Vault
+func (csp *impl) findKey(ski []byte) (*ecdsaPrivateKey, error) {
+	csp.mutex.Lock()
+	defer csp.mutex.Unlock()
+
+	if k, ok := csp.keys[string(ski)]; ok {
+		return k, nil
+	}
+
+	names, err := csp.client.listKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, csp.keyPrefix) {
+			continue
+		}
+		if _, err := csp.loadKey(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if k, ok := csp.keys[string(ski)]; ok {
+		return k, nil
+	}
+	return nil, errors.Errorf("no key with SKI [%x] in Vault", ski)
+}
+
+// loadKey reads all the versions of the key with the given name and caches them by SKI.
+// The keys that are not ECDSA keys are ignored
+func (csp *impl) loadKey(name string) ([]*ecdsaPrivateKey, error) {
+	transitKey, err := csp.client.readKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if transitKey.Type != keyTypeECDSAP256 && transitKey.Type != keyTypeECDSAP384 {
+		logger.Debugf("Ignoring Vault key [%s] of type [%s]", name, transitKey.Type)
+		return nil, nil
+	}
+	versions, err := transitKey.keyVersions()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed reading the Vault key [%s]", name)
+	}
+
+	var keys []*ecdsaPrivateKey
+	for version, publicKeyPEM := range versions {
+		pub, err := parseECDSAPublicKey(publicKeyPEM)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed parsing version %d of the Vault key [%s]", version, name)
+		}
+		k := &ecdsaPrivateKey{name: name, version: version, pub: newECDSAPublicKey(pub)}
+		csp.keys[string(k.SKI())] = k
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+func (csp *impl) signECDSA(k *ecdsaPrivateKey, digest []byte) ([]byte, error) {
+	signature, err := csp.client.sign(k.name, k.version, digest)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed signing with version %d of the Vault key [%s]", k.version, k.name)
+	}
+	return utils.SignatureToLowS(k.pub.pub, signature)
+}
+
+func verifyECDSA(k *ecdsaPublicKey, signature, digest []byte) (bool, error) {
+	r, s, err := utils.UnmarshalECDSASignature(signature)
+	if err != nil {
+		return false, fmt.Errorf("Failed unmashalling signature [%s]", err)
+	}
+
+	lowS, err := utils.IsLowS(k.pub, s)
+	if err != nil {
+		return false, err
+	}
+
+	if !lowS {
+		return false, fmt.Errorf("Invalid S. Must be smaller than half the order [%s][%s]", s, utils.GetCurveHalfOrdersAt(k.pub.Curve))
+	}
+
+	return ecdsa.Verify(k.pub, digest, r, s), nil
+}
+
+func parseECDSAPublicKey(publicKeyPEM string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM block found in the public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing the public key")
+	}
+	ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("unexpected public key type [%T]", pub)
+	}
+	return ecdsaPub, nil
+}
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCSP(t *testing.T, server *transitServer) bccsp.BCCSP {
+	csp, err := New(VaultOpts{
+		Security: 256,
+		Hash:     "SHA2",
+		Address:  server.URL,
+		Token:    testToken,
+	}, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	return csp
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(VaultOpts{Security: 256, Hash: "SHA2", Address: "http://localhost:8200"}, nil)
+	require.EqualError(t, err, "Invalid bccsp.KeyStore instance. It must be different from nil")
+
+	_, err = New(VaultOpts{Security: 256, Hash: "SHA8", Address: "http://localhost:8200"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "Failed initializing configuration: Hash Family not supported [SHA8]")
+
+	_, err = New(VaultOpts{Security: 512, Hash: "SHA2", Address: "http://localhost:8200"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "Failed initializing configuration: Security level not supported [512]")
+
+	_, err = New(VaultOpts{Security: 256, Hash: "SHA2"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "Failed initializing Vault client: the address of the Vault server must be specified")
+
+	_, err = New(VaultOpts{Security: 256, Hash: "SHA2", Address: "http://localhost:8200", TLSCACert: "/does/not/exist"}, sw.NewDummyKeyStore())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed reading the TLS CA certificate of the Vault server")
+}
+
+func TestKeyGenSignVerify(t *testing.T) {
+	server := newTransitServer(t)
+	defer server.Close()
+	csp := newTestCSP(t, server)
+
+	tests := []struct {
+		opts  bccsp.KeyGenOpts
+		curve elliptic.Curve
+	}{
+		{&bccsp.ECDSAKeyGenOpts{}, elliptic.P256()},
+		{&bccsp.ECDSAP256KeyGenOpts{}, elliptic.P256()},
+		{&bccsp.ECDSAP384KeyGenOpts{}, elliptic.P384()},
+	}
+	for _, tt := range tests {
+		k, err := csp.KeyGen(tt.opts)
+		require.NoError(t, err)
+		require.True(t, k.Private())
+		require.False(t, k.Symmetric())
+		_, err = k.Bytes()
+		require.Error(t, err)
+
+		pk, err := k.PublicKey()
+		require.NoError(t, err)
+		require.False(t, pk.Private())
+		require.Equal(t, k.SKI(), pk.SKI())
+		raw, err := pk.Bytes()
+		require.NoError(t, err)
+		pub, err := x509.ParsePKIXPublicKey(raw)
+		require.NoError(t, err)
+		require.Equal(t, tt.curve, pub.(*ecdsa.PublicKey).Curve)
+		expectedSKI := sha256.Sum256(elliptic.Marshal(tt.curve, pub.(*ecdsa.PublicKey).X, pub.(*ecdsa.PublicKey).Y))
+		require.Equal(t, expectedSKI[:], k.SKI())
+
+		digest, err := csp.Hash([]byte("message"), &bccsp.SHAOpts{})
+		require.NoError(t, err)
+		signature, err := csp.Sign(k, digest, nil)
+		require.NoError(t, err)
+		_, s, err := utils.UnmarshalECDSASignature(signature)
+		require.NoError(t, err)
+		lowS, err := utils.IsLowS(pub.(*ecdsa.PublicKey), s)
+		require.NoError(t, err)
+		require.True(t, lowS)
+
+		valid, err := csp.Verify(k, signature, digest, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+		valid, err = csp.Verify(pk, signature, digest, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+		valid, err = csp.Verify(pk, signature, []byte("another digest"), nil)
+		require.NoError(t, err)
+		require.False(t, valid)
+
+		// the public key imported from its raw representation verifies the signature in software
+		importedPK, err := csp.KeyImport(pub, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+		require.NoError(t, err)
+		require.Equal(t, k.SKI(), importedPK.SKI())
+		valid, err = csp.Verify(importedPK, signature, digest, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+	}
+}
+
+func TestGetKey(t *testing.T) {
+	server := newTransitServer(t)
+	defer server.Close()
+	csp := newTestCSP(t, server)
+
+	_, err := csp.GetKey([]byte("unknown"))
+	require.Error(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	server.addKey("fabric-aes", "aes256-gcm96")
+	server.addKey("another-key", keyTypeECDSAP256)
+	server.rotate("another-key")
+
+	// another instance finds the key generated by the first one
+	otherCSP := newTestCSP(t, server)
+	numRequests := server.numRequests()
+	found, err := otherCSP.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.Equal(t, k, found)
+	require.True(t, server.numRequests() > numRequests)
+
+	// the keys are cached
+	numRequests = server.numRequests()
+	_, err = otherCSP.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.Equal(t, numRequests, server.numRequests())
+
+	// a new version of a rotated key is found, whereas the previous version remains usable
+	name := found.(*ecdsaPrivateKey).name
+	server.rotate(name)
+	vaultKey, err := otherCSP.(*impl).client.readKey(name)
+	require.NoError(t, err)
+	versions, err := vaultKey.keyVersions()
+	require.NoError(t, err)
+	pub, err := parseECDSAPublicKey(versions[2])
+	require.NoError(t, err)
+	newVersionSKI := newECDSAPublicKey(pub).ski
+	rotated, err := otherCSP.GetKey(newVersionSKI)
+	require.NoError(t, err)
+	require.Equal(t, 2, rotated.(*ecdsaPrivateKey).version)
+
+	for _, k := range []bccsp.Key{found, rotated} {
+		digest := sha256.Sum256([]byte("message"))
+		signature, err := otherCSP.Sign(k, digest[:], nil)
+		require.NoError(t, err)
+		valid, err := csp.Verify(k, signature, digest[:], nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+	}
+
+	// the keys without the configured prefix are not looked up
+	anotherKey, err := otherCSP.(*impl).client.readKey("another-key")
+	require.NoError(t, err)
+	versions, err = anotherKey.keyVersions()
+	require.NoError(t, err)
+	pub, err = parseECDSAPublicKey(versions[1])
+	require.NoError(t, err)
+	_, err = otherCSP.GetKey(newECDSAPublicKey(pub).ski)
+	require.Error(t, err)
+}
+
+func TestVaultErrors(t *testing.T) {
+	server := newTransitServer(t)
+	defer server.Close()
+
+	csp, err := New(VaultOpts{
+		Security: 256,
+		Hash:     "SHA2",
+		Address:  server.URL,
+		Token:    "wrong-token",
+	}, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	_, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed generating ECDSA P256 key: Vault returned status 403 to POST /v1/transit/keys/fabric-")
+	require.Contains(t, err.Error(), "permission denied")
+
+	k := &ecdsaPrivateKey{name: "fabric-unknown", version: 1}
+	_, err = newTestCSP(t, server).Sign(k, []byte("digest"), nil)
+	require.EqualError(t, err, "failed signing with version 1 of the Vault key [fabric-unknown]: Vault returned status 400 to POST /v1/transit/sign/fabric-unknown/sha2-256: invalid key version")
+
+	server.Close()
+	_, err = newTestCSP(t, server).KeyGen(&bccsp.ECDSAKeyGenOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed sending the request POST /v1/transit/keys/fabric-")
+}
+
+func TestFallbackToSW(t *testing.T) {
+	server := newTransitServer(t)
+	defer server.Close()
+	csp := newTestCSP(t, server)
+
+	k, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	ciphertext, err := csp.Encrypt(k, []byte("plaintext"), &bccsp.AESCBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	plaintext, err := csp.Decrypt(k, ciphertext, &bccsp.AESCBCPKCS7ModeOpts{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("plaintext"), plaintext)
+	require.Zero(t, server.numRequests())
+}
+
+func TestTokenFromEnvironment(t *testing.T) {
+	server := newTransitServer(t)
+	defer server.Close()
+
+	os.Setenv("VAULT_TOKEN", testToken)
+	defer os.Unsetenv("VAULT_TOKEN")
+	csp, err := New(VaultOpts{Security: 256, Hash: "SHA2", Address: server.URL}, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	_, err = csp.KeyGen(&bccsp.ECDSAKeyGenOpts{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "test-token"
+
+// transitServer is a stand-in for the transit secrets engine of Vault mounted at "transit"
+type transitServer struct {
+	*httptest.Server
+	t *testing.T
+
+	mutex    sync.Mutex
+	keyTypes map[string]string
+	keys     map[string][]*ecdsa.PrivateKey
+	requests []string
+}
+
+func newTransitServer(t *testing.T) *transitServer {
+	s := &transitServer{
+		t:        t,
+		keyTypes: map[string]string{},
+		keys:     map[string][]*ecdsa.PrivateKey{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// addKey adds a key of a type that is not supported for signing
+func (s *transitServer) addKey(name, keyType string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keyTypes[name] = keyType
+	s.keys[name] = nil
+}
+
+func (s *transitServer) rotate(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[name] = append(s.keys[name], s.newKey(s.keyTypes[name]))
+}
+
+func (s *transitServer) numRequests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.requests)
+}
+
+func (s *transitServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.String())
+
+	if r.Header.Get("X-Vault-Token") != testToken {
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/transit/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && path[0] == "keys" && r.URL.Query().Get("list") == "true":
+		var names []string
+		for name := range s.keys {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		sort.Strings(names)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": names}})
+
+	case r.Method == http.MethodPost && path[0] == "keys" && len(path) == 2:
+		req := struct {
+			Type string `json:"type"`
+		}{}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		if _, ok := s.keys[path[1]]; !ok {
+			s.keyTypes[path[1]] = req.Type
+			s.keys[path[1]] = []*ecdsa.PrivateKey{s.newKey(req.Type)}
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == http.MethodGet && path[0] == "keys" && len(path) == 2:
+		versions, ok := s.keys[path[1]]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		keys := map[string]interface{}{}
+		for i, k := range versions {
+			der, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+			require.NoError(s.t, err)
+			keys[strconv.Itoa(i+1)] = map[string]interface{}{
+				"name":       "P-256",
+				"public_key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"name":           path[1],
+			"type":           s.keyTypes[path[1]],
+			"keys":           keys,
+			"latest_version": len(versions),
+		}})
+
+	case r.Method == http.MethodPost && path[0] == "sign" && len(path) == 3:
+		req := struct {
+			Input               string `json:"input"`
+			KeyVersion          int    `json:"key_version"`
+			Prehashed           bool   `json:"prehashed"`
+			MarshalingAlgorithm string `json:"marshaling_algorithm"`
+		}{}
+		require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+		require.True(s.t, req.Prehashed)
+		require.Equal(s.t, "asn1", req.MarshalingAlgorithm)
+		versions := s.keys[path[1]]
+		if req.KeyVersion < 1 || req.KeyVersion > len(versions) {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"invalid key version"}})
+			return
+		}
+		digest, err := base64.StdEncoding.DecodeString(req.Input)
+		require.NoError(s.t, err)
+		signature, err := versions[req.KeyVersion-1].Sign(rand.Reader, digest, nil)
+		require.NoError(s.t, err)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"signature": fmt.Sprintf("vault:v%d:%s", req.KeyVersion, base64.StdEncoding.EncodeToString(signature)),
+		}})
+
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+	}
+}
+
+func (s *transitServer) newKey(keyType string) *ecdsa.PrivateKey {
+	curve := elliptic.P256()
+	if keyType == keyTypeECDSAP384 {
+		curve = elliptic.P384()
+	}
+	k, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(s.t, err)
+	return k
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
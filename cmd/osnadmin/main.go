@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/osnadmin"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+
+	output, exit, err := executeForArgs(os.Args[1:])
+	if err != nil {
+		kingpin.Fatalf("parsing arguments: %s. Try --help", err)
+	}
+	fmt.Println(output)
+	os.Exit(exit)
+}
+
+func executeForArgs(args []string) (output string, exit int, err error) {
+	//
+	// command line flags
+	//
+	app := kingpin.New("osnadmin", "Orderer Service Node (OSN) administration")
+	orderer := app.Flag("orderer-address", "Admin endpoint of the OSN").Short('o').Required().String()
+	caFile := app.Flag("ca-file", "Path to file containing PEM-encoded TLS CA certificate(s) for the OSN").String()
+	clientCert := app.Flag("client-cert", "Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the OSN").String()
+	clientKey := app.Flag("client-key", "Path to file containing PEM-encoded private key to use for mutual TLS communication with the OSN").String()
+
+	channel := app.Command("channel", "Channel actions")
+
+	join := channel.Command("join", "Join an Ordering Service Node (OSN) to a channel. If the channel does not yet exist, it will be created.")
+	configBlockPath := join.Flag("config-block", "Path to the file containing an up-to-date config block for the channel").Short('b').Required().String()
+
+	list := channel.Command("list", "List channel information for an Ordering Service Node (OSN). If the channelID flag is set, more detailed information will be provided for that channel.")
+	listChannelID := list.Flag("channelID", "Channel ID").Short('c').String()
+
+	remove := channel.Command("remove", "Remove an Ordering Service Node (OSN) from a channel.")
+	removeChannelID := remove.Flag("channelID", "Channel ID").Short('c').Required().String()
+
+	command, err := app.Parse(args)
+	if err != nil {
+		return "", 1, err
+	}
+
+	//
+	// flag validation
+	//
+	osnURL := "http://" + *orderer
+	var caCertPool *x509.CertPool
+	var tlsClientCert tls.Certificate
+	if *caFile != "" {
+		osnURL = "https://" + *orderer
+		caCertPool = x509.NewCertPool()
+		caFilePEM, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			return "", 1, errors.Errorf("reading orderer CA certificate: %s", err)
+		}
+		if !caCertPool.AppendCertsFromPEM(caFilePEM) {
+			return "", 1, errors.Errorf("no PEM-encoded certificates found in orderer CA file: %s", *caFile)
+		}
+	}
+	if *clientCert != "" || *clientKey != "" {
+		if *caFile == "" {
+			return "", 1, errors.New("the client certificate and key require a CA file for TLS communication")
+		}
+		tlsClientCert, err = tls.LoadX509KeyPair(*clientCert, *clientKey)
+		if err != nil {
+			return "", 1, errors.Errorf("loading client cert/key pair: %s", err)
+		}
+	}
+
+	var marshaledConfigBlock []byte
+	if *configBlockPath != "" {
+		marshaledConfigBlock, err = ioutil.ReadFile(*configBlockPath)
+		if err != nil {
+			return "", 1, errors.Errorf("reading config block: %s", err)
+		}
+		if err := validateConfigBlock(marshaledConfigBlock); err != nil {
+			return "", 1, errors.Errorf("validating config block: %s", err)
+		}
+	}
+
+	//
+	// call the underlying implementations
+	//
+	var resp *http.Response
+	var content interface{}
+
+	switch command {
+	case join.FullCommand():
+		resp, err = osnadmin.Join(osnURL, marshaledConfigBlock, caCertPool, tlsClientCert)
+		content = &types.ChannelInfo{}
+	case list.FullCommand():
+		if *listChannelID != "" {
+			resp, err = osnadmin.ListSingleChannel(osnURL, *listChannelID, caCertPool, tlsClientCert)
+			content = &types.ChannelInfo{}
+			break
+		}
+		resp, err = osnadmin.ListAllChannels(osnURL, caCertPool, tlsClientCert)
+		content = &types.ChannelList{}
+	case remove.FullCommand():
+		resp, err = osnadmin.Remove(osnURL, *removeChannelID, caCertPool, tlsClientCert)
+	}
+	if err != nil {
+		return errorOutput(err), 1, nil
+	}
+
+	output, err = responseOutput(resp, content)
+	if err != nil {
+		return errorOutput(err), 1, nil
+	}
+
+	return output, 0, nil
+}
+
+func validateConfigBlock(blockBytes []byte) error {
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return errors.Wrap(err, "unmarshaling block")
+	}
+	if !protoutil.IsConfigBlock(block) {
+		return errors.Errorf("block %d is not a config block", block.GetHeader().GetNumber())
+	}
+	return nil
+}
+
+// responseOutput renders the status code and the JSON body of a response.
+// Successful responses are decoded into the given content, and errors into
+// a types.ErrorResponse, so that only the expected fields are printed.
+func responseOutput(resp *http.Response, content interface{}) (string, error) {
+	defer resp.Body.Close()
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "reading http response body")
+	}
+
+	output := fmt.Sprintf("Status: %d\n", resp.StatusCode)
+	if len(bodyBytes) == 0 {
+		return output, nil
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest || content == nil {
+		content = &types.ErrorResponse{}
+	}
+	if err := json.Unmarshal(bodyBytes, content); err != nil {
+		return "", errors.Wrapf(err, "unmarshaling http response body: %s", string(bodyBytes))
+	}
+	prettyBody, err := json.MarshalIndent(content, "", "\t")
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling http response body")
+	}
+
+	return output + string(prettyBody) + "\n", nil
+}
+
+func errorOutput(err error) string {
+	return fmt.Sprintf("Error: %s\n", err)
+}
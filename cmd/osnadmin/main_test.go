@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testEnv struct {
+	tempDir         string
+	ordererAddress  string
+	caFile          string
+	clientCert      string
+	clientKey       string
+	mockChannelMgmt *mocks.ChannelManagement
+}
+
+func newTestEnv(t *testing.T) (*testEnv, func()) {
+	tempDir, err := ioutil.TempDir("", "osnadmin")
+	require.NoError(t, err)
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	env := &testEnv{
+		tempDir:         tempDir,
+		caFile:          filepath.Join(tempDir, "ca.pem"),
+		clientCert:      filepath.Join(tempDir, "client-cert.pem"),
+		clientKey:       filepath.Join(tempDir, "client-key.pem"),
+		mockChannelMgmt: &mocks.ChannelManagement{},
+	}
+	require.NoError(t, ioutil.WriteFile(env.caFile, ca.CertBytes(), 0600))
+	require.NoError(t, ioutil.WriteFile(env.clientCert, clientKeyPair.Cert, 0600))
+	require.NoError(t, ioutil.WriteFile(env.clientKey, clientKeyPair.Key, 0600))
+
+	serverCert, err := tls.X509KeyPair(serverKeyPair.Cert, serverKeyPair.Key)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(ca.CertBytes())
+
+	handler := channelparticipation.NewHTTPHandler(localconfig.ChannelParticipation{
+		Enabled:            true,
+		MaxRequestBodySize: 1024 * 1024,
+	}, env.mockChannelMgmt)
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	env.ordererAddress = strings.TrimPrefix(server.URL, "https://")
+
+	return env, func() {
+		server.Close()
+		os.RemoveAll(tempDir)
+	}
+}
+
+func (env *testEnv) args(args ...string) []string {
+	return append([]string{
+		"--orderer-address", env.ordererAddress,
+		"--ca-file", env.caFile,
+		"--client-cert", env.clientCert,
+		"--client-key", env.clientKey,
+	}, args...)
+}
+
+func TestChannelList(t *testing.T) {
+	env, cleanup := newTestEnv(t)
+	defer cleanup()
+
+	t.Run("all channels", func(t *testing.T) {
+		env.mockChannelMgmt.ChannelListReturns(types.ChannelList{
+			Channels: []types.ChannelInfoShort{{Name: "fight-the-system"}},
+		})
+
+		output, exit, err := executeForArgs(env.args("channel", "list"))
+		require.NoError(t, err)
+		require.Equal(t, 0, exit)
+		require.Equal(t, "Status: 200\n"+
+			"{\n"+
+			"\t\"systemChannel\": null,\n"+
+			"\t\"channels\": [\n"+
+			"\t\t{\n"+
+			"\t\t\t\"name\": \"fight-the-system\",\n"+
+			"\t\t\t\"url\": \"/participation/v1/channels/fight-the-system\"\n"+
+			"\t\t}\n"+
+			"\t]\n"+
+			"}\n", output)
+	})
+
+	t.Run("single channel", func(t *testing.T) {
+		env.mockChannelMgmt.ChannelInfoReturns(types.ChannelInfo{
+			Name:            "fight-the-system",
+			ClusterRelation: types.ClusterRelationMember,
+			Status:          types.StatusActive,
+			Height:          7,
+		}, nil)
+
+		output, exit, err := executeForArgs(env.args("channel", "list", "--channelID", "fight-the-system"))
+		require.NoError(t, err)
+		require.Equal(t, 0, exit)
+		require.Equal(t, "Status: 200\n"+
+			"{\n"+
+			"\t\"name\": \"fight-the-system\",\n"+
+			"\t\"url\": \"/participation/v1/channels/fight-the-system\",\n"+
+			"\t\"clusterRelation\": \"member\",\n"+
+			"\t\"status\": \"active\",\n"+
+			"\t\"height\": 7\n"+
+			"}\n", output)
+	})
+
+	t.Run("channel does not exist", func(t *testing.T) {
+		env.mockChannelMgmt.ChannelInfoReturns(types.ChannelInfo{}, types.ErrChannelNotExist)
+
+		output, exit, err := executeForArgs(env.args("channel", "list", "-c", "missing"))
+		require.NoError(t, err)
+		require.Equal(t, 0, exit)
+		require.Equal(t, "Status: 404\n"+
+			"{\n"+
+			"\t\"error\": \"channel does not exist\"\n"+
+			"}\n", output)
+	})
+}
+
+func TestChannelJoin(t *testing.T) {
+	env, cleanup := newTestEnv(t)
+	defer cleanup()
+
+	blockPath := filepath.Join(env.tempDir, "config.block")
+	require.NoError(t, ioutil.WriteFile(blockPath, protoutil.MarshalOrPanic(configBlock("testing123")), 0600))
+
+	env.mockChannelMgmt.JoinChannelReturns(types.ChannelInfo{
+		Name:            "testing123",
+		ClusterRelation: types.ClusterRelationMember,
+		Status:          types.StatusActive,
+		Height:          1,
+	}, nil)
+
+	output, exit, err := executeForArgs(env.args("channel", "join", "--config-block", blockPath))
+	require.NoError(t, err)
+	require.Equal(t, 0, exit)
+	require.Equal(t, "Status: 201\n"+
+		"{\n"+
+		"\t\"name\": \"testing123\",\n"+
+		"\t\"url\": \"/participation/v1/channels/testing123\",\n"+
+		"\t\"clusterRelation\": \"member\",\n"+
+		"\t\"status\": \"active\",\n"+
+		"\t\"height\": 1\n"+
+		"}\n", output)
+
+	require.Equal(t, 1, env.mockChannelMgmt.JoinChannelCallCount())
+	channelID, block, isAppChannel := env.mockChannelMgmt.JoinChannelArgsForCall(0)
+	require.Equal(t, "testing123", channelID)
+	require.True(t, protoutil.IsConfigBlock(block))
+	require.True(t, isAppChannel)
+
+	t.Run("channel already exists", func(t *testing.T) {
+		env.mockChannelMgmt.JoinChannelReturns(types.ChannelInfo{}, types.ErrChannelAlreadyExists)
+
+		output, exit, err := executeForArgs(env.args("channel", "join", "-b", blockPath))
+		require.NoError(t, err)
+		require.Equal(t, 0, exit)
+		require.Equal(t, "Status: 405\n"+
+			"{\n"+
+			"\t\"error\": \"cannot join: channel already exists\"\n"+
+			"}\n", output)
+	})
+
+	t.Run("not a config block", func(t *testing.T) {
+		dataBlockPath := filepath.Join(env.tempDir, "data.block")
+		require.NoError(t, ioutil.WriteFile(dataBlockPath, protoutil.MarshalOrPanic(protoutil.NewBlock(1, nil)), 0600))
+
+		_, exit, err := executeForArgs(env.args("channel", "join", "-b", dataBlockPath))
+		require.EqualError(t, err, "validating config block: block 1 is not a config block")
+		require.Equal(t, 1, exit)
+	})
+
+	t.Run("missing config block file", func(t *testing.T) {
+		_, exit, err := executeForArgs(env.args("channel", "join", "-b", filepath.Join(env.tempDir, "missing.block")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "reading config block")
+		require.Equal(t, 1, exit)
+	})
+}
+
+func TestChannelRemove(t *testing.T) {
+	env, cleanup := newTestEnv(t)
+	defer cleanup()
+
+	output, exit, err := executeForArgs(env.args("channel", "remove", "--channelID", "testing123"))
+	require.NoError(t, err)
+	require.Equal(t, 0, exit)
+	require.Equal(t, "Status: 204\n", output)
+	require.Equal(t, 1, env.mockChannelMgmt.RemoveChannelCallCount())
+	channelID, _ := env.mockChannelMgmt.RemoveChannelArgsForCall(0)
+	require.Equal(t, "testing123", channelID)
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		_, exit, err := executeForArgs(env.args("channel", "remove"))
+		require.EqualError(t, err, "required flag --channelID not provided")
+		require.Equal(t, 1, exit)
+	})
+}
+
+func TestTLSFlags(t *testing.T) {
+	env, cleanup := newTestEnv(t)
+	defer cleanup()
+
+	t.Run("missing client certificate", func(t *testing.T) {
+		output, exit, err := executeForArgs([]string{"-o", env.ordererAddress, "--ca-file", env.caFile, "channel", "list"})
+		require.NoError(t, err)
+		require.Equal(t, 1, exit)
+		require.Contains(t, output, "Error: Get")
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		_, exit, err := executeForArgs([]string{"-o", env.ordererAddress, "--ca-file", env.clientKey, "channel", "list"})
+		require.EqualError(t, err, "no PEM-encoded certificates found in orderer CA file: "+env.clientKey)
+		require.Equal(t, 1, exit)
+	})
+
+	t.Run("client certificate without CA file", func(t *testing.T) {
+		_, exit, err := executeForArgs([]string{"-o", env.ordererAddress, "--client-cert", env.clientCert, "--client-key", env.clientKey, "channel", "list"})
+		require.EqualError(t, err, "the client certificate and key require a CA file for TLS communication")
+		require.Equal(t, 1, exit)
+	})
+}
+
+func configBlock(channelID string) *cb.Block {
+	return &cb.Block{
+		Header: &cb.BlockHeader{},
+		Data: &cb.BlockData{
+			Data: [][]byte{
+				protoutil.MarshalOrPanic(&cb.Envelope{
+					Payload: protoutil.MarshalOrPanic(&cb.Payload{
+						Data: protoutil.MarshalOrPanic(&cb.ConfigEnvelope{
+							Config: &cb.Config{
+								ChannelGroup: &cb.ConfigGroup{
+									Groups: map[string]*cb.ConfigGroup{
+										"Application": {},
+									},
+									Values: map[string]*cb.ConfigValue{
+										"HashingAlgorithm": {
+											Value: protoutil.MarshalOrPanic(&cb.HashingAlgorithm{
+												Name: bccsp.SHA256,
+											}),
+										},
+										"BlockDataHashingStructure": {
+											Value: protoutil.MarshalOrPanic(&cb.BlockDataHashingStructure{
+												Width: math.MaxUint32,
+											}),
+										},
+										"OrdererAddresses": {
+											Value: protoutil.MarshalOrPanic(&cb.OrdererAddresses{
+												Addresses: []string{"localhost"},
+											}),
+										},
+									},
+								},
+							},
+						}),
+						Header: &cb.Header{
+							ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+								Type:      int32(cb.HeaderType_CONFIG),
+								ChannelId: channelID,
+							}),
+						},
+					}),
+				}),
+			},
+		},
+	}
+}
@@ -12,6 +12,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
@@ -109,6 +110,14 @@ type Orderer interface {
 	// MaxChannelsCount returns the maximum count of channels to allow for an ordering network
 	MaxChannelsCount() uint64
 
+	// AdaptiveBatching returns the lower bounds of the adaptive block cutting,
+	// or nil if the channel config does not set them
+	AdaptiveBatching() *ordererpb.AdaptiveBatching
+
+	// BroadcastLimits returns the rate limits and quotas of the transactions broadcast
+	// to the channel, or nil if the channel config does not set them
+	BroadcastLimits() *ordererpb.BroadcastLimits
+
 	// KafkaBrokers returns the addresses (IP:port notation) of a set of "bootstrap"
 	// Kafka brokers, i.e. this is not necessarily the entire set of Kafka brokers
 	// used for ordering
@@ -194,6 +203,14 @@ type ApplicationCapabilities interface {
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
+
+	// CrossChannelTransactions returns true if this channel supports the transactions
+	// that atomically modify the state of several channels through the xcc system chaincode
+	CrossChannelTransactions() bool
+
+	// PurgePvtData returns true if this channel supports the purge of the private data keys,
+	// which deletes the keys and purges all their historical versions
+	PurgePvtData() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -220,6 +237,10 @@ type OrdererCapabilities interface {
 	// channel creation logic using channel creation policy as the Admins policy if
 	// the creation transaction appears to support it.
 	UseChannelCreationPolicyAsAdmins() bool
+
+	// AdaptiveBlockCutting specifies whether the orderer may tune the batch size and timeout
+	// of the channel within the bounds set by AdaptiveBatching, BatchSize and BatchTimeout.
+	AdaptiveBlockCutting() bool
 }
 
 // PolicyMapper is an interface for
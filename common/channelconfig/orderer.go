@@ -16,6 +16,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/pkg/errors"
 )
 
@@ -40,6 +41,12 @@ const (
 	// KafkaBrokersKey is the cb.ConfigItem type key name for the KafkaBrokers message.
 	KafkaBrokersKey = "KafkaBrokers"
 
+	// AdaptiveBatchingKey is the cb.ConfigItem type key name for the AdaptiveBatching message.
+	AdaptiveBatchingKey = "AdaptiveBatching"
+
+	// BroadcastLimitsKey is the cb.ConfigItem type key name for the BroadcastLimits message.
+	BroadcastLimitsKey = "BroadcastLimits"
+
 	// EndpointsKey is the cb.COnfigValue key name for the Endpoints message in the OrdererOrgGroup.
 	EndpointsKey = "Endpoints"
 )
@@ -51,6 +58,8 @@ type OrdererProtos struct {
 	BatchTimeout        *ab.BatchTimeout
 	KafkaBrokers        *ab.KafkaBrokers
 	ChannelRestrictions *ab.ChannelRestrictions
+	AdaptiveBatching    *ordererpb.AdaptiveBatching
+	BroadcastLimits     *ordererpb.BroadcastLimits
 	Capabilities        *cb.Capabilities
 }
 
@@ -180,6 +189,26 @@ func (oc *OrdererConfig) MaxChannelsCount() uint64 {
 	return oc.protos.ChannelRestrictions.MaxCount
 }
 
+// AdaptiveBatching returns the lower bounds of the adaptive block cutting, or nil
+// if the channel config does not set them.
+func (oc *OrdererConfig) AdaptiveBatching() *ordererpb.AdaptiveBatching {
+	adaptive := oc.protos.AdaptiveBatching
+	if adaptive.GetMinMessageCount() == 0 && adaptive.GetMinTimeout() == "" {
+		return nil
+	}
+	return adaptive
+}
+
+// BroadcastLimits returns the rate limits and quotas of the transactions broadcast
+// to the channel, or nil if the channel config does not set them.
+func (oc *OrdererConfig) BroadcastLimits() *ordererpb.BroadcastLimits {
+	limits := oc.protos.BroadcastLimits
+	if limits.Identity == nil && limits.Organization == nil && len(limits.Organizations) == 0 {
+		return nil
+	}
+	return limits
+}
+
 // Organizations returns a map of the orgs in the channel.
 func (oc *OrdererConfig) Organizations() map[string]OrdererOrg {
 	return oc.orgs
@@ -194,7 +223,9 @@ func (oc *OrdererConfig) Validate() error {
 	for _, validator := range []func() error{
 		oc.validateBatchSize,
 		oc.validateBatchTimeout,
+		oc.validateAdaptiveBatching,
 		oc.validateKafkaBrokers,
+		oc.validateBroadcastLimits,
 	} {
 		if err := validator(); err != nil {
 			return err
@@ -232,6 +263,46 @@ func (oc *OrdererConfig) validateBatchTimeout() error {
 	return nil
 }
 
+// leaderBasedConsensusTypes are the consensus types whose blocks are cut by a
+// single leader, and can therefore be cut adaptively.
+var leaderBasedConsensusTypes = map[string]struct{}{
+	"etcdraft": {},
+	"BFT":      {},
+}
+
+func (oc *OrdererConfig) validateAdaptiveBatching() error {
+	adaptive := oc.AdaptiveBatching()
+	if adaptive == nil {
+		return nil
+	}
+	if !capabilities.NewOrdererProvider(oc.protos.Capabilities.Capabilities).V30 {
+		return fmt.Errorf("Attempted to set the adaptive batching without the orderer capability %s", capabilities.OrdererV3_0)
+	}
+	// The tuned batch size depends on the local arrival rate and commit latency
+	// of the node that cuts the blocks, so only consensus types whose blocks
+	// are cut by a single leader and replicated to the others can use it.
+	if _, ok := leaderBasedConsensusTypes[oc.protos.ConsensusType.GetType()]; !ok {
+		return fmt.Errorf("Attempted to set the adaptive batching with the consensus type %s, which does not cut blocks on a single leader", oc.protos.ConsensusType.GetType())
+	}
+	if adaptive.MinMessageCount == 0 {
+		return fmt.Errorf("Attempted to set the adaptive batching min message count to an invalid value: 0")
+	}
+	if adaptive.MinMessageCount > oc.protos.BatchSize.MaxMessageCount {
+		return fmt.Errorf("Attempted to set the adaptive batching min message count (%v) greater than the batch size max message count (%v).", adaptive.MinMessageCount, oc.protos.BatchSize.MaxMessageCount)
+	}
+	minTimeout, err := time.ParseDuration(adaptive.MinTimeout)
+	if err != nil {
+		return fmt.Errorf("Attempted to set the adaptive batching min timeout to a invalid value: %s", err)
+	}
+	if minTimeout <= 0 {
+		return fmt.Errorf("Attempted to set the adaptive batching min timeout to a non-positive value: %s", minTimeout)
+	}
+	if minTimeout > oc.batchTimeout {
+		return fmt.Errorf("Attempted to set the adaptive batching min timeout (%s) greater than the batch timeout (%s).", minTimeout, oc.batchTimeout)
+	}
+	return nil
+}
+
 func (oc *OrdererConfig) validateKafkaBrokers() error {
 	for _, broker := range oc.protos.KafkaBrokers.Brokers {
 		if !brokerEntrySeemsValid(broker) {
@@ -241,6 +312,38 @@ func (oc *OrdererConfig) validateKafkaBrokers() error {
 	return nil
 }
 
+func (oc *OrdererConfig) validateBroadcastLimits() error {
+	limits := oc.BroadcastLimits()
+	if limits == nil {
+		return nil
+	}
+	if !capabilities.NewOrdererProvider(oc.protos.Capabilities.Capabilities).V30 {
+		return fmt.Errorf("Attempted to set the broadcast limits without the orderer capability %s", capabilities.OrdererV3_0)
+	}
+	if err := validateRateLimit("identity", limits.Identity); err != nil {
+		return err
+	}
+	if err := validateRateLimit("organization", limits.Organization); err != nil {
+		return err
+	}
+	for mspID, limit := range limits.Organizations {
+		if err := validateRateLimit(fmt.Sprintf("organization %s", mspID), limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRateLimit(name string, limit *ordererpb.RateLimit) error {
+	if limit == nil {
+		return nil
+	}
+	if limit.MessagesPerSecond < 0 || limit.BytesPerSecond < 0 {
+		return fmt.Errorf("Attempted to set the broadcast limit of the %s to a negative rate", name)
+	}
+	return nil
+}
+
 // This does just a barebones sanity check.
 func brokerEntrySeemsValid(broker string) bool {
 	if !strings.Contains(broker, ":") {
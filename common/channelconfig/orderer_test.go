@@ -7,9 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,6 +47,81 @@ func TestBatchTimeout(t *testing.T) {
 	require.Error(t, oc.validateBatchTimeout(), "Zero batch timeout")
 }
 
+func TestAdaptiveBatching(t *testing.T) {
+	newConfig := func(adaptive *ordererpb.AdaptiveBatching, capabilities map[string]*cb.Capability) *OrdererConfig {
+		return &OrdererConfig{
+			protos: &OrdererProtos{
+				ConsensusType:    &ab.ConsensusType{Type: "etcdraft"},
+				BatchSize:        &ab.BatchSize{MaxMessageCount: 10},
+				AdaptiveBatching: adaptive,
+				Capabilities:     &cb.Capabilities{Capabilities: capabilities},
+			},
+			batchTimeout: 2 * time.Second,
+		}
+	}
+	v30 := map[string]*cb.Capability{capabilities.OrdererV3_0: {}}
+
+	require.NoError(t, newConfig(nil, nil).validateAdaptiveBatching(), "Adaptive batching not set")
+	require.NoError(t, newConfig(&ordererpb.AdaptiveBatching{}, nil).validateAdaptiveBatching(), "Adaptive batching empty")
+	require.NoError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "100ms"}, v30).validateAdaptiveBatching(), "Valid adaptive batching")
+	require.NoError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 10, MinTimeout: "2s"}, v30).validateAdaptiveBatching(), "Adaptive batching bounds equal to the batch size and timeout")
+
+	require.EqualError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "100ms"}, nil).validateAdaptiveBatching(),
+		"Attempted to set the adaptive batching without the orderer capability V3_0")
+	require.EqualError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 0, MinTimeout: "100ms"}, v30).validateAdaptiveBatching(),
+		"Attempted to set the adaptive batching min message count to an invalid value: 0")
+	require.EqualError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 11, MinTimeout: "100ms"}, v30).validateAdaptiveBatching(),
+		"Attempted to set the adaptive batching min message count (11) greater than the batch size max message count (10).")
+	require.Error(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "forever"}, v30).validateAdaptiveBatching(), "Invalid min timeout")
+	require.Error(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "0s"}, v30).validateAdaptiveBatching(), "Zero min timeout")
+	require.EqualError(t, newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "3s"}, v30).validateAdaptiveBatching(),
+		"Attempted to set the adaptive batching min timeout (3s) greater than the batch timeout (2s).")
+
+	for _, consensusType := range []string{"etcdraft", "BFT"} {
+		oc := newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "100ms"}, v30)
+		oc.protos.ConsensusType.Type = consensusType
+		require.NoError(t, oc.validateAdaptiveBatching(), "Adaptive batching with consensus type %s", consensusType)
+	}
+	for _, consensusType := range []string{"kafka", "solo"} {
+		oc := newConfig(&ordererpb.AdaptiveBatching{MinMessageCount: 1, MinTimeout: "100ms"}, v30)
+		oc.protos.ConsensusType.Type = consensusType
+		require.EqualError(t, oc.validateAdaptiveBatching(),
+			fmt.Sprintf("Attempted to set the adaptive batching with the consensus type %s, which does not cut blocks on a single leader", consensusType))
+	}
+}
+
+func TestBroadcastLimits(t *testing.T) {
+	newConfig := func(limits *ordererpb.BroadcastLimits, capabilities map[string]*cb.Capability) *OrdererConfig {
+		return &OrdererConfig{protos: &OrdererProtos{
+			BroadcastLimits: limits,
+			Capabilities:    &cb.Capabilities{Capabilities: capabilities},
+		}}
+	}
+	v30 := map[string]*cb.Capability{capabilities.OrdererV3_0: {}}
+
+	oc := newConfig(&ordererpb.BroadcastLimits{}, nil)
+	require.Nil(t, oc.BroadcastLimits())
+	require.NoError(t, oc.validateBroadcastLimits(), "Broadcast limits not set")
+
+	limits := &ordererpb.BroadcastLimits{
+		Identity:     &ordererpb.RateLimit{MessagesPerSecond: 10, MessageBurst: 20},
+		Organization: &ordererpb.RateLimit{BytesPerSecond: 1024, ByteBurst: 4096},
+		Organizations: map[string]*ordererpb.RateLimit{
+			"Org1MSP": {MessagesPerSecond: 100, MessageBurst: 100, BytesPerSecond: 1024, ByteBurst: 4096},
+		},
+	}
+	oc = newConfig(limits, v30)
+	require.Equal(t, limits, oc.BroadcastLimits())
+	require.NoError(t, oc.validateBroadcastLimits(), "Valid broadcast limits")
+
+	require.EqualError(t, newConfig(limits, nil).validateBroadcastLimits(),
+		"Attempted to set the broadcast limits without the orderer capability V3_0")
+	require.EqualError(t, newConfig(&ordererpb.BroadcastLimits{Identity: &ordererpb.RateLimit{MessagesPerSecond: -1}}, v30).validateBroadcastLimits(),
+		"Attempted to set the broadcast limit of the identity to a negative rate")
+	require.EqualError(t, newConfig(&ordererpb.BroadcastLimits{Organizations: map[string]*ordererpb.RateLimit{"Org1MSP": {BytesPerSecond: -1}}}, v30).validateBroadcastLimits(),
+		"Attempted to set the broadcast limit of the organization Org1MSP to a negative rate")
+}
+
 func TestKafkaBrokers(t *testing.T) {
 	oc := &OrdererConfig{protos: &OrdererProtos{KafkaBrokers: &ab.KafkaBrokers{Brokers: []string{"127.0.0.1:9092", "foo.bar:9092"}}}}
 	require.NoError(t, oc.validateKafkaBrokers(), "Valid kafka brokers")
@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: configuration.proto
+
+package ordererpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// AdaptiveBatching is encoded into the configuration transaction with key
+// AdaptiveBatching. When set, and the orderer capability V3_0 is enabled,
+// the ordering service tunes the number of messages and the timeout of each
+// batch from the observed ingress rate and block commit latency. The
+// orderer.BatchSize max message count and the orderer.BatchTimeout are the
+// upper bounds of the tuning, this message holds the lower bounds.
+type AdaptiveBatching struct {
+	// The lowest number of messages a batch is cut at.
+	MinMessageCount uint32 `protobuf:"varint,1,opt,name=min_message_count,json=minMessageCount,proto3" json:"min_message_count,omitempty"`
+	// The lowest batch timeout, any duration string parseable by ParseDuration().
+	MinTimeout           string   `protobuf:"bytes,2,opt,name=min_timeout,json=minTimeout,proto3" json:"min_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AdaptiveBatching) Reset()         { *m = AdaptiveBatching{} }
+func (m *AdaptiveBatching) String() string { return proto.CompactTextString(m) }
+func (*AdaptiveBatching) ProtoMessage()    {}
+func (*AdaptiveBatching) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{0}
+}
+
+func (m *AdaptiveBatching) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AdaptiveBatching.Unmarshal(m, b)
+}
+func (m *AdaptiveBatching) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AdaptiveBatching.Marshal(b, m, deterministic)
+}
+func (m *AdaptiveBatching) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AdaptiveBatching.Merge(m, src)
+}
+func (m *AdaptiveBatching) XXX_Size() int {
+	return xxx_messageInfo_AdaptiveBatching.Size(m)
+}
+func (m *AdaptiveBatching) XXX_DiscardUnknown() {
+	xxx_messageInfo_AdaptiveBatching.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AdaptiveBatching proto.InternalMessageInfo
+
+func (m *AdaptiveBatching) GetMinMessageCount() uint32 {
+	if m != nil {
+		return m.MinMessageCount
+	}
+	return 0
+}
+
+func (m *AdaptiveBatching) GetMinTimeout() string {
+	if m != nil {
+		return m.MinTimeout
+	}
+	return ""
+}
+
+// BroadcastLimits is encoded into the configuration transaction with key
+// BroadcastLimits. It holds the rate limits and quotas the ordering service
+// applies to the transactions broadcast to a channel, and requires the
+// orderer capability V3_0.
+type BroadcastLimits struct {
+	// The limit of each identity.
+	Identity *RateLimit `protobuf:"bytes,1,opt,name=identity,proto3" json:"identity,omitempty"`
+	// The limit shared by the identities of each organization, unless
+	// overridden in organizations.
+	Organization *RateLimit `protobuf:"bytes,2,opt,name=organization,proto3" json:"organization,omitempty"`
+	// The limits shared by the identities of the organizations, by MSP ID.
+	Organizations        map[string]*RateLimit `protobuf:"bytes,3,rep,name=organizations,proto3" json:"organizations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BroadcastLimits) Reset()         { *m = BroadcastLimits{} }
+func (m *BroadcastLimits) String() string { return proto.CompactTextString(m) }
+func (*BroadcastLimits) ProtoMessage()    {}
+func (*BroadcastLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{1}
+}
+
+func (m *BroadcastLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BroadcastLimits.Unmarshal(m, b)
+}
+func (m *BroadcastLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BroadcastLimits.Marshal(b, m, deterministic)
+}
+func (m *BroadcastLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BroadcastLimits.Merge(m, src)
+}
+func (m *BroadcastLimits) XXX_Size() int {
+	return xxx_messageInfo_BroadcastLimits.Size(m)
+}
+func (m *BroadcastLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_BroadcastLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BroadcastLimits proto.InternalMessageInfo
+
+func (m *BroadcastLimits) GetIdentity() *RateLimit {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *BroadcastLimits) GetOrganization() *RateLimit {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *BroadcastLimits) GetOrganizations() map[string]*RateLimit {
+	if m != nil {
+		return m.Organizations
+	}
+	return nil
+}
+
+// RateLimit is a token bucket that limits the rate of the messages and
+// the rate of their bytes. A rate of zero means no limit, a burst of zero
+// means the amount added to the bucket in one second.
+type RateLimit struct {
+	// The number of messages added to the bucket per second.
+	MessagesPerSecond float64 `protobuf:"fixed64,1,opt,name=messages_per_second,json=messagesPerSecond,proto3" json:"messages_per_second,omitempty"`
+	// The maximum number of messages in the bucket.
+	MessageBurst uint32 `protobuf:"varint,2,opt,name=message_burst,json=messageBurst,proto3" json:"message_burst,omitempty"`
+	// The number of bytes added to the bucket per second.
+	BytesPerSecond float64 `protobuf:"fixed64,3,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// The maximum number of bytes in the bucket.
+	ByteBurst            uint64   `protobuf:"varint,4,opt,name=byte_burst,json=byteBurst,proto3" json:"byte_burst,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{2}
+}
+
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RateLimit.Unmarshal(m, b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return xxx_messageInfo_RateLimit.Size(m)
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetMessagesPerSecond() float64 {
+	if m != nil {
+		return m.MessagesPerSecond
+	}
+	return 0
+}
+
+func (m *RateLimit) GetMessageBurst() uint32 {
+	if m != nil {
+		return m.MessageBurst
+	}
+	return 0
+}
+
+func (m *RateLimit) GetBytesPerSecond() float64 {
+	if m != nil {
+		return m.BytesPerSecond
+	}
+	return 0
+}
+
+func (m *RateLimit) GetByteBurst() uint64 {
+	if m != nil {
+		return m.ByteBurst
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*AdaptiveBatching)(nil), "ordererpb.AdaptiveBatching")
+	proto.RegisterType((*BroadcastLimits)(nil), "ordererpb.BroadcastLimits")
+	proto.RegisterMapType((map[string]*RateLimit)(nil), "ordererpb.BroadcastLimits.OrganizationsEntry")
+	proto.RegisterType((*RateLimit)(nil), "ordererpb.RateLimit")
+}
+
+func init() { proto.RegisterFile("configuration.proto", fileDescriptor_415c9e57263f32ab) }
+
+var fileDescriptor_415c9e57263f32ab = []byte{
+	// 394 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x41, 0x8b, 0xd4, 0x30,
+	0x14, 0xc7, 0xe9, 0xcc, 0x2a, 0xf6, 0xcd, 0xd6, 0x9d, 0xcd, 0x7a, 0x28, 0x82, 0x58, 0xc6, 0x4b,
+	0x59, 0xb0, 0x95, 0xf5, 0xb2, 0x88, 0x08, 0x56, 0xbc, 0x29, 0x4a, 0x56, 0x3c, 0x78, 0x29, 0x69,
+	0xfa, 0xb6, 0x0d, 0x4e, 0x92, 0x92, 0xa4, 0x0b, 0xf5, 0x1b, 0xe9, 0xa7, 0x94, 0xa6, 0xb3, 0x75,
+	0xc6, 0x85, 0xbd, 0xb5, 0xbf, 0xf7, 0xcf, 0x8f, 0x97, 0xbc, 0x07, 0x67, 0x5c, 0xab, 0x6b, 0xd1,
+	0xf4, 0x86, 0x39, 0xa1, 0x55, 0xd6, 0x19, 0xed, 0x34, 0x09, 0xb5, 0xa9, 0xd1, 0xa0, 0xe9, 0xaa,
+	0x4d, 0x09, 0xeb, 0xf7, 0x35, 0xeb, 0x9c, 0xb8, 0xc1, 0x82, 0x39, 0xde, 0x0a, 0xd5, 0x90, 0x73,
+	0x38, 0x95, 0x42, 0x95, 0x12, 0xad, 0x65, 0x0d, 0x96, 0x5c, 0xf7, 0xca, 0xc5, 0x41, 0x12, 0xa4,
+	0x11, 0x3d, 0x91, 0x42, 0x7d, 0x9e, 0xf8, 0x87, 0x11, 0x93, 0xe7, 0xb0, 0x1a, 0xb3, 0x4e, 0x48,
+	0xd4, 0xbd, 0x8b, 0x17, 0x49, 0x90, 0x86, 0x14, 0xa4, 0x50, 0xdf, 0x26, 0xb2, 0xf9, 0xb3, 0x80,
+	0x93, 0xc2, 0x68, 0x56, 0x73, 0x66, 0xdd, 0x27, 0x21, 0x85, 0xb3, 0xe4, 0x15, 0x3c, 0x12, 0x35,
+	0x2a, 0x27, 0xdc, 0xe0, 0xbd, 0xab, 0x8b, 0x27, 0xd9, 0xdc, 0x52, 0x46, 0x99, 0x43, 0x1f, 0xa4,
+	0x73, 0x8a, 0x5c, 0xc2, 0xb1, 0x36, 0x0d, 0x53, 0xe2, 0x97, 0xbf, 0x47, 0xbc, 0xb8, 0xe7, 0xd4,
+	0x41, 0x92, 0x5c, 0x41, 0xb4, 0xff, 0x6f, 0xe3, 0x65, 0xb2, 0x4c, 0x57, 0x17, 0x2f, 0xf7, 0x8e,
+	0xfe, 0xd7, 0x5e, 0xf6, 0x65, 0x3f, 0xff, 0x51, 0x39, 0x33, 0xd0, 0x43, 0xc7, 0xd3, 0xef, 0x40,
+	0xee, 0x86, 0xc8, 0x1a, 0x96, 0x3f, 0x71, 0xba, 0x51, 0x48, 0xc7, 0x4f, 0x72, 0x0e, 0x0f, 0x6e,
+	0xd8, 0xb6, 0xc7, 0x7b, 0xfb, 0x9d, 0x22, 0x6f, 0x16, 0x97, 0xc1, 0xe6, 0x77, 0x00, 0xe1, 0x5c,
+	0x20, 0x19, 0x9c, 0xed, 0x66, 0x60, 0xcb, 0x0e, 0x4d, 0x69, 0x91, 0x6b, 0x55, 0x7b, 0x7f, 0x40,
+	0x4f, 0x6f, 0x4b, 0x5f, 0xd1, 0x5c, 0xf9, 0x02, 0x79, 0x01, 0xd1, 0xed, 0xcc, 0xaa, 0xde, 0xd8,
+	0x69, 0x1a, 0x11, 0x3d, 0xde, 0xc1, 0x62, 0x64, 0x24, 0x85, 0x75, 0x35, 0xb8, 0x43, 0xe3, 0xd2,
+	0x1b, 0x1f, 0x7b, 0xfe, 0x4f, 0xf7, 0x0c, 0x60, 0x24, 0x3b, 0xd7, 0x51, 0x12, 0xa4, 0x47, 0x34,
+	0x1c, 0x89, 0x17, 0x15, 0xef, 0x7e, 0xbc, 0x6d, 0x84, 0x6b, 0xfb, 0x2a, 0xe3, 0x5a, 0xe6, 0xed,
+	0xd0, 0xa1, 0xd9, 0x62, 0xdd, 0xa0, 0xc9, 0xaf, 0x59, 0x65, 0x04, 0xcf, 0xb9, 0x96, 0x52, 0xab,
+	0x9c, 0xb7, 0x4c, 0x29, 0xdc, 0x4e, 0x7b, 0x98, 0xcf, 0x0f, 0x50, 0x3d, 0xf4, 0xbb, 0xf8, 0xfa,
+	0xef, 0x00, 0xbe, 0x35, 0xbc, 0xac, 0xa2, 0x02, 0x00, 0x00,
+}
@@ -19,6 +19,8 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -132,6 +134,18 @@ func BatchSizeValue(maxMessages, absoluteMaxBytes, preferredMaxBytes uint32) *St
 	}
 }
 
+// AdaptiveBatchingValue returns the config definition for the lower bounds of the
+// adaptive block cutting. It is a value for the /Channel/Orderer group.
+func AdaptiveBatchingValue(minMessages uint32, minTimeout string) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: AdaptiveBatchingKey,
+		value: &ordererpb.AdaptiveBatching{
+			MinMessageCount: minMessages,
+			MinTimeout:      minTimeout,
+		},
+	}
+}
+
 // BatchTimeoutValue returns the config definition for the orderer batch timeout.
 // It is a value for the /Channel/Orderer group.
 func BatchTimeoutValue(timeout string) *StandardConfigValue {
@@ -154,6 +168,15 @@ func ChannelRestrictionsValue(maxChannelCount uint64) *StandardConfigValue {
 	}
 }
 
+// BroadcastLimitsValue returns the config definition for the rate limits and quotas of the
+// transactions broadcast to the channel. It is a value for the /Channel/Orderer group.
+func BroadcastLimitsValue(limits *ordererpb.BroadcastLimits) *StandardConfigValue {
+	return &StandardConfigValue{
+		key:   BroadcastLimitsKey,
+		value: limits,
+	}
+}
+
 // KafkaBrokersValue returns the config definition for the addresses of the ordering service's Kafka brokers.
 // It is a value for the /Channel/Orderer group.
 func KafkaBrokersValue(brokers []string) *StandardConfigValue {
@@ -329,3 +352,30 @@ func MarshalEtcdRaftMetadata(md *etcdraft.ConfigMetadata) ([]byte, error) {
 	}
 	return proto.Marshal(copyMd)
 }
+
+// MarshalBFTMetadata serializes BFT metadata.
+func MarshalBFTMetadata(md *bftmsgs.ConfigMetadata) ([]byte, error) {
+	copyMd := proto.Clone(md).(*bftmsgs.ConfigMetadata)
+	for _, c := range copyMd.Consenters {
+		// Expect the user to set the config value for the certs to the
+		// path where they are persisted locally, then load these files to memory.
+		clientCert, err := ioutil.ReadFile(string(c.GetClientTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ClientTlsCert = clientCert
+
+		serverCert, err := ioutil.ReadFile(string(c.GetServerTlsCert()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load server cert for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.ServerTlsCert = serverCert
+
+		identity, err := ioutil.ReadFile(string(c.GetIdentity()))
+		if err != nil {
+			return nil, fmt.Errorf("cannot load identity for consenter %s:%d: %s", c.GetHost(), c.GetPort(), err)
+		}
+		c.Identity = identity
+	}
+	return proto.Marshal(copyMd)
+}
@@ -22,6 +22,8 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 )
@@ -45,9 +47,11 @@ func TestUtilsBasic(t *testing.T) {
 	basicTest(t, OrdererAddressesValue([]string{"foo:1", "bar:2"}))
 	basicTest(t, ConsensusTypeValue("foo", []byte("bar")))
 	basicTest(t, BatchSizeValue(1, 2, 3))
+	basicTest(t, AdaptiveBatchingValue(1, "1s"))
 	basicTest(t, BatchTimeoutValue("1s"))
 	basicTest(t, ChannelRestrictionsValue(7))
 	basicTest(t, KafkaBrokersValue([]string{"foo:1", "bar:2"}))
+	basicTest(t, BroadcastLimitsValue(&ordererpb.BroadcastLimits{Identity: &ordererpb.RateLimit{MessagesPerSecond: 10, MessageBurst: 20}}))
 	basicTest(t, MSPValue(&mspprotos.MSPConfig{}))
 	basicTest(t, CapabilitiesValue(map[string]bool{"foo": true, "bar": false}))
 	basicTest(t, AnchorPeersValue([]*pb.AnchorPeer{{}, {}}))
@@ -358,3 +362,43 @@ func TestMarshalEtcdRaftMetadata(t *testing.T) {
 		require.NotEqual(t, outputCerts[i+1], outputCerts[i], "expected extracted certs to differ from each other")
 	}
 }
+
+func TestMarshalBFTMetadata(t *testing.T) {
+	md := &bftmsgs.ConfigMetadata{
+		Options: &bftmsgs.Options{RequestTimeout: "10s"},
+	}
+	for i := 1; i <= 3; i++ {
+		md.Consenters = append(md.Consenters, &bftmsgs.Consenter{
+			Id:            uint64(i),
+			Host:          fmt.Sprintf("node-%d.example.com", i),
+			Port:          7050,
+			MspId:         "OrdererMSP",
+			ClientTlsCert: []byte(fmt.Sprintf("testdata/tls-client-%d.pem", i)),
+			ServerTlsCert: []byte(fmt.Sprintf("testdata/tls-server-%d.pem", i)),
+			Identity:      []byte(fmt.Sprintf("testdata/tls-client-%d.pem", i)),
+		})
+	}
+	packed, err := MarshalBFTMetadata(md)
+	require.NoError(t, err, "marshalling should succeed")
+
+	packedAgain, err := MarshalBFTMetadata(md)
+	require.NoError(t, err, "marshalling should succeed a second time because we did not mutate ourselves")
+	require.Equal(t, packed, packedAgain)
+
+	unpacked := &bftmsgs.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(packed, unpacked), "unmarshalling should succeed")
+	require.Equal(t, "10s", unpacked.Options.RequestTimeout)
+	for i, c := range unpacked.Consenters {
+		clientCert, err := ioutil.ReadFile(fmt.Sprintf("testdata/tls-client-%d.pem", i+1))
+		require.NoError(t, err)
+		serverCert, err := ioutil.ReadFile(fmt.Sprintf("testdata/tls-server-%d.pem", i+1))
+		require.NoError(t, err)
+		require.Equal(t, clientCert, c.ClientTlsCert)
+		require.Equal(t, serverCert, c.ServerTlsCert)
+		require.Equal(t, clientCert, c.Identity)
+	}
+
+	md.Consenters[1].Identity = []byte("testdata/missing.pem")
+	_, err = MarshalBFTMetadata(md)
+	require.EqualError(t, err, "cannot load identity for consenter node-2.example.com:7050: open testdata/missing.pem: no such file or directory")
+}
@@ -20,6 +20,7 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/deliver/deliverpb"
 	"github.com/hyperledger/fabric/common/deliver/mock"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -416,6 +417,33 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when only the headers of the blocks are requested", func() {
+			BeforeEach(func() {
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Data:     &cb.BlockData{Data: [][]byte{[]byte("tx")}},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}, cb.Status_SUCCESS)
+				seekInfoPayload = protoutil.MarshalOrPanic(&deliverpb.SeekInfo{
+					Start:       seekInfo.Start,
+					Stop:        seekInfo.Stop,
+					ContentType: deliverpb.SeekInfo_HEADER_WITH_SIG,
+				})
+			})
+
+			It("sends the blocks without their data", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				b, _, _, _ := fakeResponseSender.SendBlockResponseArgsForCall(0)
+				Expect(b).To(Equal(&cb.Block{
+					Header:   &cb.BlockHeader{Number: 100},
+					Metadata: &cb.BlockMetadata{Metadata: [][]byte{[]byte("signatures")}},
+				}))
+			})
+		})
+
 		Context("when seek info is configured to stop at the oldest block", func() {
 			BeforeEach(func() {
 				seekInfo = &ab.SeekInfo{Start: &ab.SeekPosition{}, Stop: seekOldest}
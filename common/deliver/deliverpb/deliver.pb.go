@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: deliver.proto
+
+package deliverpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SeekContentType indicates what type of content to deliver in response
+// to a request. If BLOCK is specified, the orderer will stream blocks back
+// to the client. If HEADER_WITH_SIG is specified, the orderer will stream
+// the blocks back without their data, so that the client can verify the
+// signatures of the blocks without fetching their transactions.
+type SeekInfo_SeekContentType int32
+
+const (
+	SeekInfo_BLOCK           SeekInfo_SeekContentType = 0
+	SeekInfo_HEADER_WITH_SIG SeekInfo_SeekContentType = 1
+)
+
+var SeekInfo_SeekContentType_name = map[int32]string{
+	0: "BLOCK",
+	1: "HEADER_WITH_SIG",
+}
+
+var SeekInfo_SeekContentType_value = map[string]int32{
+	"BLOCK":           0,
+	"HEADER_WITH_SIG": 1,
+}
+
+func (x SeekInfo_SeekContentType) String() string {
+	return proto.EnumName(SeekInfo_SeekContentType_name, int32(x))
+}
+
+func (SeekInfo_SeekContentType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3ebde617daa8954a, []int{0, 0}
+}
+
+// SeekInfo is an orderer.SeekInfo that also specifies the content of the
+// blocks to deliver. It is wire compatible with orderer.SeekInfo, which clients
+// that want whole blocks keep sending.
+type SeekInfo struct {
+	// The position to start the deliver from
+	Start *orderer.SeekPosition `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// The position to stop the deliver
+	Stop *orderer.SeekPosition `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	// The behavior when a missing block is encountered
+	Behavior orderer.SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	// How to respond to errors reported to the deliver service
+	ErrorResponse orderer.SeekInfo_SeekErrorResponse `protobuf:"varint,4,opt,name=error_response,json=errorResponse,proto3,enum=orderer.SeekInfo_SeekErrorResponse" json:"error_response,omitempty"`
+	// Defines what type of content to deliver in response to a request
+	ContentType          SeekInfo_SeekContentType `protobuf:"varint,5,opt,name=content_type,json=contentType,proto3,enum=deliverpb.SeekInfo_SeekContentType" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
+func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
+func (*SeekInfo) ProtoMessage()    {}
+func (*SeekInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3ebde617daa8954a, []int{0}
+}
+
+func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
+}
+func (m *SeekInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SeekInfo.Marshal(b, m, deterministic)
+}
+func (m *SeekInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeekInfo.Merge(m, src)
+}
+func (m *SeekInfo) XXX_Size() int {
+	return xxx_messageInfo_SeekInfo.Size(m)
+}
+func (m *SeekInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeekInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SeekInfo proto.InternalMessageInfo
+
+func (m *SeekInfo) GetStart() *orderer.SeekPosition {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SeekInfo) GetStop() *orderer.SeekPosition {
+	if m != nil {
+		return m.Stop
+	}
+	return nil
+}
+
+func (m *SeekInfo) GetBehavior() orderer.SeekInfo_SeekBehavior {
+	if m != nil {
+		return m.Behavior
+	}
+	return orderer.SeekInfo_BLOCK_UNTIL_READY
+}
+
+func (m *SeekInfo) GetErrorResponse() orderer.SeekInfo_SeekErrorResponse {
+	if m != nil {
+		return m.ErrorResponse
+	}
+	return orderer.SeekInfo_STRICT
+}
+
+func (m *SeekInfo) GetContentType() SeekInfo_SeekContentType {
+	if m != nil {
+		return m.ContentType
+	}
+	return SeekInfo_BLOCK
+}
+
+func init() {
+	proto.RegisterEnum("deliverpb.SeekInfo_SeekContentType", SeekInfo_SeekContentType_name, SeekInfo_SeekContentType_value)
+	proto.RegisterType((*SeekInfo)(nil), "deliverpb.SeekInfo")
+}
+
+func init() { proto.RegisterFile("deliver.proto", fileDescriptor_3ebde617daa8954a) }
+
+var fileDescriptor_3ebde617daa8954a = []byte{
+	// 301 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0xd1, 0xdf, 0x4a, 0x02, 0x41,
+	0x14, 0x06, 0xf0, 0xd6, 0x34, 0x74, 0xcc, 0x3f, 0x4c, 0x04, 0x4b, 0x17, 0x21, 0x76, 0x63, 0x04,
+	0xbb, 0x64, 0x10, 0xd1, 0x5d, 0x9a, 0xa5, 0x15, 0x14, 0xab, 0x10, 0x74, 0xb3, 0xec, 0xac, 0x47,
+	0x1d, 0xd2, 0x39, 0xc3, 0xd9, 0x49, 0xf0, 0x81, 0x7b, 0x8f, 0x70, 0x1d, 0xb6, 0x8c, 0xe8, 0x6e,
+	0x3e, 0xce, 0x8f, 0x6f, 0x0e, 0x33, 0xac, 0x32, 0x86, 0xb9, 0x5c, 0x02, 0x79, 0x9a, 0xd0, 0x20,
+	0x2f, 0xd9, 0xa8, 0xc5, 0x51, 0x1d, 0x69, 0x0c, 0x04, 0xe4, 0x47, 0x62, 0x33, 0x6c, 0x7e, 0xe6,
+	0x58, 0x71, 0x08, 0xf0, 0x3e, 0x50, 0x13, 0xe4, 0x67, 0xac, 0x90, 0x98, 0x88, 0x8c, 0xeb, 0x34,
+	0x9c, 0x56, 0xb9, 0x7d, 0xe8, 0x59, 0xee, 0xad, 0xc5, 0x0b, 0x26, 0xd2, 0x48, 0x54, 0xc1, 0xc6,
+	0xf0, 0x53, 0x96, 0x4f, 0x0c, 0x6a, 0x37, 0xf7, 0x9f, 0x4d, 0x09, 0xbf, 0x66, 0x45, 0x01, 0xb3,
+	0x68, 0x29, 0x91, 0xdc, 0xdd, 0x86, 0xd3, 0xaa, 0xb6, 0x8f, 0xb7, 0xf8, 0xfa, 0xf2, 0xf4, 0xd0,
+	0xb1, 0x2a, 0xc8, 0x3c, 0x7f, 0x60, 0x55, 0x20, 0x42, 0x0a, 0x09, 0x12, 0x8d, 0x2a, 0x01, 0x37,
+	0x9f, 0x36, 0x9c, 0xfc, 0xdd, 0xd0, 0x5b, 0xdb, 0xc0, 0xd2, 0xa0, 0x02, 0x3f, 0x23, 0xbf, 0x63,
+	0xfb, 0x31, 0x2a, 0x03, 0xca, 0x84, 0x66, 0xa5, 0xc1, 0x2d, 0xd8, 0xa6, 0xec, 0x81, 0xb6, 0xbb,
+	0xba, 0x1b, 0x3b, 0x5a, 0x69, 0x08, 0xca, 0xf1, 0x77, 0x68, 0x9e, 0xb3, 0xda, 0xaf, 0x39, 0x2f,
+	0xb1, 0x42, 0xe7, 0xe9, 0xb9, 0xfb, 0x58, 0xdf, 0xe1, 0x07, 0xac, 0xd6, 0xef, 0xdd, 0xdc, 0xf6,
+	0x82, 0xf0, 0x75, 0x30, 0xea, 0x87, 0xc3, 0xc1, 0x7d, 0xdd, 0xe9, 0x5c, 0xbd, 0x5d, 0x4e, 0xa5,
+	0x99, 0x7d, 0x08, 0x2f, 0xc6, 0x85, 0x3f, 0x5b, 0x69, 0xa0, 0x39, 0x8c, 0xa7, 0x40, 0xfe, 0x24,
+	0x12, 0x24, 0x63, 0x3f, 0xc6, 0xc5, 0x02, 0x95, 0x6f, 0x57, 0xf1, 0xb3, 0x95, 0xc4, 0x5e, 0xfa,
+	0x51, 0x17, 0x5f, 0x03, 0x00, 0xf1, 0xa6, 0x34, 0x5e, 0xd6, 0x01, 0x00, 0x00,
+}
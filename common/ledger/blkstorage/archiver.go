@@ -0,0 +1,171 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+// Archiver moves sealed blockfiles to a cold storage backend and retrieves them back on demand
+type Archiver interface {
+	// Archive stores the content of the blockfile present at localPath in the archive backend
+	// and returns the location of the archived copy. The location is recorded in the block index
+	// and is later passed to Retrieve for fetching the blockfile.
+	Archive(ledgerID string, fileNum int, localPath string) (location string, err error)
+	// Retrieve copies the blockfile archived at the given location to localPath. The content of the copy
+	// is checked against the hash of the blockfile recorded at the time of archiving
+	Retrieve(location string, localPath string) error
+}
+
+// archivedBlockfileName returns the name used for the archived copy of a blockfile
+func archivedBlockfileName(ledgerID string, fileNum int) string {
+	return path.Join(ledgerID, fmt.Sprintf("%s%06d", blockfilePrefix, fileNum))
+}
+
+// DirArchiver is an Archiver that keeps the archived blockfiles in a local directory,
+// typically a mount point of a slower or cheaper storage than the one used for the ledger
+type DirArchiver struct {
+	dir string
+}
+
+// NewDirArchiver constructs a DirArchiver that archives the blockfiles under the given directory
+func NewDirArchiver(dir string) *DirArchiver {
+	return &DirArchiver{dir: dir}
+}
+
+// Archive implements the function in the interface `Archiver`
+func (a *DirArchiver) Archive(ledgerID string, fileNum int, localPath string) (string, error) {
+	location := archivedBlockfileName(ledgerID, fileNum)
+	targetPath := filepath.Join(a.dir, filepath.FromSlash(location))
+	if err := copyFile(localPath, targetPath); err != nil {
+		return "", errors.WithMessagef(err, "error while archiving block file %s", localPath)
+	}
+	return location, nil
+}
+
+// Retrieve implements the function in the interface `Archiver`
+func (a *DirArchiver) Retrieve(location string, localPath string) error {
+	sourcePath := filepath.Join(a.dir, filepath.FromSlash(location))
+	if err := copyFile(sourcePath, localPath); err != nil {
+		return errors.WithMessagef(err, "error while retrieving archived block file %s", location)
+	}
+	return nil
+}
+
+// HTTPArchiver is an Archiver that stores the blockfiles as objects in an S3-compatible object store.
+// The objects are uploaded with a PUT and downloaded with a GET request to <endpoint>/<location>,
+// where the endpoint usually includes the bucket, e.g. https://objectstore.example.com/fabric-blocks.
+// Any authentication has to be provided by the supplied http client.
+type HTTPArchiver struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPArchiver constructs an HTTPArchiver for the given endpoint. If client is nil,
+// http.DefaultClient is used
+func NewHTTPArchiver(endpoint string, client *http.Client) *HTTPArchiver {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPArchiver{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+	}
+}
+
+// Archive implements the function in the interface `Archiver`
+func (a *HTTPArchiver) Archive(ledgerID string, fileNum int, localPath string) (string, error) {
+	content, err := ioutil.ReadFile(localPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "error while reading block file %s", localPath)
+	}
+	location := archivedBlockfileName(ledgerID, fileNum)
+	req, err := http.NewRequest(http.MethodPut, a.objectURL(location), bytes.NewReader(content))
+	if err != nil {
+		return "", errors.Wrapf(err, "error while creating upload request for block file %s", localPath)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "error while uploading block file %s", localPath)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", errors.Errorf("error while uploading block file %s: unexpected status [%s]", localPath, resp.Status)
+	}
+	return location, nil
+}
+
+// Retrieve implements the function in the interface `Archiver`
+func (a *HTTPArchiver) Retrieve(location string, localPath string) error {
+	resp, err := a.client.Get(a.objectURL(location))
+	if err != nil {
+		return errors.Wrapf(err, "error while downloading archived block file %s", location)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("error while downloading archived block file %s: unexpected status [%s]", location, resp.Status)
+	}
+	if err := writeFileAtomically(localPath, resp.Body); err != nil {
+		return errors.WithMessagef(err, "error while downloading archived block file %s", location)
+	}
+	return nil
+}
+
+func (a *HTTPArchiver) objectURL(location string) string {
+	return a.endpoint + "/" + location
+}
+
+func copyFile(sourcePath, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening file %s", sourcePath)
+	}
+	defer source.Close()
+	return writeFileAtomically(targetPath, source)
+}
+
+// writeFileAtomically writes the content to a temporary file that is renamed to the target path
+// once fully synced, so that a partially written file is never observed at the target path
+func writeFileAtomically(targetPath string, content io.Reader) error {
+	dir := filepath.Dir(targetPath)
+	if _, err := fileutil.CreateDirIfMissing(dir); err != nil {
+		return errors.WithMessagef(err, "error while creating dir %s", dir)
+	}
+	tempFile, err := ioutil.TempFile(dir, filepath.Base(targetPath)+".tmp")
+	if err != nil {
+		return errors.Wrapf(err, "error while creating temp file in dir %s", dir)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+	if _, err := io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return errors.Wrapf(err, "error while writing file %s", tempPath)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		return errors.Wrapf(err, "error while syncing file %s", tempPath)
+	}
+	if err := tempFile.Close(); err != nil {
+		return errors.Wrapf(err, "error while closing file %s", tempPath)
+	}
+	if err := os.Rename(tempPath, targetPath); err != nil {
+		return errors.Wrapf(err, "error while renaming file %s to %s", tempPath, targetPath)
+	}
+	return fileutil.SyncDir(dir)
+}
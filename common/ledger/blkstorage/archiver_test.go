@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirArchiver(t *testing.T) {
+	testDir := testPath()
+	defer os.RemoveAll(testDir)
+	blockfilePath := filepath.Join(testDir, "blockfile_000003")
+	require.NoError(t, ioutil.WriteFile(blockfilePath, []byte("blockfile-content"), 0644))
+
+	archiver := NewDirArchiver(filepath.Join(testDir, "archive"))
+	location, err := archiver.Archive("ledger1", 3, blockfilePath)
+	require.NoError(t, err)
+	require.Equal(t, "ledger1/blockfile_000003", location)
+	content, err := ioutil.ReadFile(filepath.Join(testDir, "archive", "ledger1", "blockfile_000003"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("blockfile-content"), content)
+
+	retrievedPath := filepath.Join(testDir, "cache", "blockfile_000003")
+	require.NoError(t, archiver.Retrieve(location, retrievedPath))
+	content, err = ioutil.ReadFile(retrievedPath)
+	require.NoError(t, err)
+	require.Equal(t, []byte("blockfile-content"), content)
+
+	err = archiver.Retrieve("ledger1/blockfile_000004", retrievedPath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while retrieving archived block file ledger1/blockfile_000004")
+
+	_, err = archiver.Archive("ledger1", 4, filepath.Join(testDir, "blockfile_000004"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while archiving block file")
+}
+
+func TestHTTPArchiver(t *testing.T) {
+	testDir := testPath()
+	defer os.RemoveAll(testDir)
+	blockfilePath := filepath.Join(testDir, "blockfile_000003")
+	require.NoError(t, ioutil.WriteFile(blockfilePath, []byte("blockfile-content"), 0644))
+
+	objectStore := &testObjectStore{objects: map[string][]byte{}}
+	server := httptest.NewServer(objectStore)
+	defer server.Close()
+
+	archiver := NewHTTPArchiver(server.URL+"/fabric-blocks/", nil)
+	location, err := archiver.Archive("ledger1", 3, blockfilePath)
+	require.NoError(t, err)
+	require.Equal(t, "ledger1/blockfile_000003", location)
+	require.Equal(t, map[string][]byte{
+		"/fabric-blocks/ledger1/blockfile_000003": []byte("blockfile-content"),
+	}, objectStore.objects)
+
+	retrievedPath := filepath.Join(testDir, "cache", "blockfile_000003")
+	require.NoError(t, archiver.Retrieve(location, retrievedPath))
+	content, err := ioutil.ReadFile(retrievedPath)
+	require.NoError(t, err)
+	require.Equal(t, []byte("blockfile-content"), content)
+
+	err = archiver.Retrieve("ledger1/blockfile_000004", retrievedPath)
+	require.EqualError(t, err, "error while downloading archived block file ledger1/blockfile_000004: unexpected status [404 Not Found]")
+
+	objectStore.rejectUploads = true
+	_, err = archiver.Archive("ledger1", 3, blockfilePath)
+	require.EqualError(t, err, "error while uploading block file "+blockfilePath+": unexpected status [403 Forbidden]")
+
+	_, err = NewHTTPArchiver("http://127.0.0.1:0", nil).Archive("ledger1", 3, blockfilePath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while uploading block file")
+}
+
+// testObjectStore is an in-memory stand-in of an S3-compatible object store
+type testObjectStore struct {
+	lock          sync.Mutex
+	objects       map[string][]byte
+	rejectUploads bool
+}
+
+func (s *testObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		if s.rejectUploads {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		content, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[r.URL.Path] = content
+	case http.MethodGet:
+		content, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
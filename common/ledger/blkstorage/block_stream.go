@@ -25,7 +25,7 @@ var ErrUnexpectedEndOfBlockfile = errors.New("unexpected end of blockfile")
 // It starts from the given offset and can traverse till the end of the file
 type blockfileStream struct {
 	fileNum       int
-	file          *os.File
+	file          *blockfileReader
 	reader        *bufio.Reader
 	currentOffset int64
 }
@@ -34,12 +34,38 @@ type blockfileStream struct {
 // it starts from a given file offset and continues with the next
 // file segment until the end of the last segment (`endFileNum`)
 type blockStream struct {
-	rootDir           string
+	openBlockfile     blockfileOpener
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
 }
 
+// blockfileOpener opens the blockfile with the given number for reading
+type blockfileOpener func(fileNum int) (*blockfileReader, error)
+
+// localBlockfileOpener returns a blockfileOpener that opens the blockfiles present in the rootDir.
+// The blockfiles are decrypted as per the ciphers, which are nil for the blockfiles in clear
+func localBlockfileOpener(rootDir string, ciphers *blockfileCiphers) blockfileOpener {
+	return func(fileNum int) (*blockfileReader, error) {
+		filePath := deriveBlockfilePath(rootDir, fileNum)
+		file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+		}
+		return openBlockfileReader(file, fileNum, ciphers)
+	}
+}
+
+// openBlockfileReader returns a blockfileReader that decrypts the opened blockfile as per the ciphers
+func openBlockfileReader(file *os.File, fileNum int, ciphers *blockfileCiphers) (*blockfileReader, error) {
+	stream, err := ciphers.readStream(fileNum)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return newBlockfileReader(file, stream), nil
+}
+
 // blockPlacementInfo captures the information related
 // to block's placement in the file.
 type blockPlacementInfo struct {
@@ -51,16 +77,20 @@ type blockPlacementInfo struct {
 ///////////////////////////////////
 // blockfileStream functions
 ////////////////////////////////////
-func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockfileStream, error) {
-	filePath := deriveBlockfilePath(rootDir, fileNum)
-	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
-	var file *os.File
-	var err error
-	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0600); err != nil {
-		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+func newBlockfileStream(rootDir string, ciphers *blockfileCiphers, fileNum int, startOffset int64) (*blockfileStream, error) {
+	return openBlockfileStream(localBlockfileOpener(rootDir, ciphers), fileNum, startOffset)
+}
+
+func openBlockfileStream(openBlockfile blockfileOpener, fileNum int, startOffset int64) (*blockfileStream, error) {
+	file, err := openBlockfile(fileNum)
+	if err != nil {
+		return nil, err
 	}
+	filePath := file.file.Name()
+	logger.Debugf("newBlockfileStream(): filePath=[%s], startOffset=[%d]", filePath, startOffset)
 	var newPosition int64
-	if newPosition, err = file.Seek(startOffset, 0); err != nil {
+	if newPosition, err = file.seek(startOffset); err != nil {
+		file.close()
 		return nil, errors.Wrapf(err, "error seeking block file [%s] to startOffset [%d]", filePath, startOffset)
 	}
 	if newPosition != startOffset {
@@ -86,7 +116,7 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 	var fileInfo os.FileInfo
 	moreContentAvailable := true
 
-	if fileInfo, err = s.file.Stat(); err != nil {
+	if fileInfo, err = s.file.file.Stat(); err != nil {
 		return nil, nil, errors.Wrapf(err, "error getting block file stat")
 	}
 	if s.currentOffset == fileInfo.Size() {
@@ -139,18 +169,22 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 }
 
 func (s *blockfileStream) close() error {
-	return errors.WithStack(s.file.Close())
+	return s.file.close()
 }
 
 ///////////////////////////////////
 // blockStream functions
 ////////////////////////////////////
-func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+func newBlockStream(rootDir string, ciphers *blockfileCiphers, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	return openBlockStream(localBlockfileOpener(rootDir, ciphers), startFileNum, startOffset, endFileNum)
+}
+
+func openBlockStream(openBlockfile blockfileOpener, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := openBlockfileStream(openBlockfile, startFileNum, startOffset)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{openBlockfile, startFileNum, endFileNum, startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -159,7 +193,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = openBlockfileStream(s.openBlockfile, s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
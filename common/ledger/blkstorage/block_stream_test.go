@@ -30,7 +30,7 @@ func testBlockfileStream(t *testing.T, numBlocks int) {
 	w.addBlocks(blocks)
 	w.close()
 
-	s, err := newBlockfileStream(w.blockfileMgr.rootDir, 0, 0)
+	s, err := newBlockfileStream(w.blockfileMgr.rootDir, nil, 0, 0)
 	defer s.close()
 	require.NoError(t, err, "Error in constructing blockfile stream")
 
@@ -71,7 +71,7 @@ func testBlockFileStreamUnexpectedEOF(t *testing.T, numBlocks int, partialBlockB
 	w.addBlocks(blocks)
 	blockfileMgr.currentFileWriter.append(partialBlockBytes, true)
 	w.close()
-	s, err := newBlockfileStream(blockfileMgr.rootDir, 0, 0)
+	s, err := newBlockfileStream(blockfileMgr.rootDir, nil, 0, 0)
 	defer s.close()
 	require.NoError(t, err, "Error in constructing blockfile stream")
 
@@ -112,7 +112,7 @@ func testBlockStream(t *testing.T, numFiles int) {
 		w.addBlocks(blocks)
 		blockfileMgr.moveToNextFile()
 	}
-	s, err := newBlockStream(blockfileMgr.rootDir, 0, 0, numFiles-1)
+	s, err := newBlockStream(blockfileMgr.rootDir, nil, 0, 0, numFiles-1)
 	defer s.close()
 	require.NoError(t, err, "Error in constructing new block stream")
 	blockCount := 0
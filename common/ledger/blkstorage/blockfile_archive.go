@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// archiveCacheDir is the name of the directory, within the ledger blocks dir, that caches
+// the blockfiles retrieved from the archive
+const archiveCacheDir = "archiveCache"
+
+// blockfileArchive archives the sealed blockfiles of a ledger and serves the archived
+// blockfiles via a local cache of a bounded size
+type blockfileArchive struct {
+	ledgerID string
+	rootDir  string
+	conf     *ArchiveConf
+	index    *blockIndex
+
+	cacheLock sync.Mutex
+	cached    *list.List            // file numbers of the cached blockfiles, most recently used first
+	cachedMap map[int]*list.Element // file number to its element in the cached list
+
+	archiveLock sync.Mutex
+	// nextEligibleBlock is the block number, on committing which, the oldest blockfile
+	// that is not yet archived becomes eligible for archiving
+	nextEligibleBlock uint64
+
+	trigger  chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newBlockfileArchive(ledgerID, rootDir string, conf *ArchiveConf, index *blockIndex) (*blockfileArchive, error) {
+	// the cache does not survive a restart, as it is not known which of the files were completely written
+	cacheDir := filepath.Join(rootDir, archiveCacheDir)
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return nil, errors.Wrapf(err, "error while removing archive cache dir %s", cacheDir)
+	}
+	return &blockfileArchive{
+		ledgerID:          ledgerID,
+		rootDir:           rootDir,
+		conf:              conf,
+		index:             index,
+		cached:            list.New(),
+		cachedMap:         map[int]*list.Element{},
+		trigger:           make(chan struct{}, 1),
+		nextEligibleBlock: math.MaxUint64,
+		done:              make(chan struct{}),
+		stopped:           make(chan struct{}),
+	}, nil
+}
+
+// openBlockfile opens the blockfile with the given number from the ledger blocks dir, if present there.
+// Otherwise, the blockfile is opened from the cache after retrieving it from the archive, if required
+func (a *blockfileArchive) openBlockfile(fileNum int) (*os.File, error) {
+	filePath := deriveBlockfilePath(a.rootDir, fileNum)
+	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
+	if err == nil {
+		return file, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+	}
+	info, indexErr := a.index.getArchivedBlockfileInfo(fileNum)
+	if indexErr != nil {
+		return nil, indexErr
+	}
+	if info == nil {
+		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
+	}
+	return a.openCachedBlockfile(fileNum, info)
+}
+
+// openCachedBlockfile opens the cached copy of the archived blockfile. A blockfile retrieved from the
+// archive is cached only if its content matches the hash recorded at the time of archiving
+func (a *blockfileArchive) openCachedBlockfile(fileNum int, info *ArchivedBlockfileInfo) (*os.File, error) {
+	a.cacheLock.Lock()
+	defer a.cacheLock.Unlock()
+
+	cachedPath := deriveBlockfilePath(filepath.Join(a.rootDir, archiveCacheDir), fileNum)
+	if e, ok := a.cachedMap[fileNum]; ok {
+		a.cached.MoveToFront(e)
+	} else {
+		logger.Infof("Retrieving archived block file [%d] of ledger [%s] from location [%s]", fileNum, a.ledgerID, info.Location)
+		if err := a.conf.Archiver.Retrieve(info.Location, cachedPath); err != nil {
+			return nil, err
+		}
+		hash, err := computeFileHash(cachedPath)
+		if err != nil {
+			os.Remove(cachedPath)
+			return nil, err
+		}
+		if !bytes.Equal(hash, info.Sha256) {
+			os.Remove(cachedPath)
+			return nil, errors.Errorf("archived block file [%d] of ledger [%s] retrieved from location [%s] does not match its recorded hash", fileNum, a.ledgerID, info.Location)
+		}
+		a.cachedMap[fileNum] = a.cached.PushFront(fileNum)
+		for a.cached.Len() > a.conf.MaxCachedBlockfiles {
+			// a blockfile that is still open by a reader remains readable after it is removed
+			evicted := a.cached.Remove(a.cached.Back()).(int)
+			delete(a.cachedMap, evicted)
+			evictedPath := deriveBlockfilePath(filepath.Join(a.rootDir, archiveCacheDir), evicted)
+			if err := os.Remove(evictedPath); err != nil && !os.IsNotExist(err) {
+				logger.Warningf("Could not remove the cached block file %s: %s", evictedPath, err)
+			}
+		}
+	}
+
+	file, err := os.OpenFile(cachedPath, os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening cached block file %s", cachedPath)
+	}
+	return file, nil
+}
+
+// archiveBlockfiles archives, in the increasing order of the file numbers, the blockfiles that
+// are eligible for archiving as per the archive configuration. The blockfiles with file numbers
+// below latestFileNum are sealed, i.e., no more blocks are appended to them, and the last block
+// of the blockfile latestFileNum-1 is found via lastBlockInFile
+func (a *blockfileArchive) archiveBlockfiles(
+	latestFileNum int,
+	lastPersistedBlock uint64,
+	lastBlockInFile func(fileNum int) (uint64, error),
+) error {
+	a.archiveLock.Lock()
+	defer a.archiveLock.Unlock()
+
+	// a blockfile sealed in the meantime triggers another run
+	nextEligibleBlock := uint64(math.MaxUint64)
+	defer func() {
+		atomic.StoreUint64(&a.nextEligibleBlock, nextEligibleBlock)
+	}()
+
+	for fileNum := 0; fileNum < latestFileNum; fileNum++ {
+		filePath := deriveBlockfilePath(a.rootDir, fileNum)
+		fileInfo, err := os.Stat(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error while getting stat of block file %s", filePath)
+		}
+
+		info, err := a.index.getArchivedBlockfileInfo(fileNum)
+		if err != nil {
+			return err
+		}
+		if info != nil {
+			// archived earlier but the removal of the local file did not happen because of a crash
+			if err := os.Remove(filePath); err != nil {
+				return errors.Wrapf(err, "error while removing archived block file %s", filePath)
+			}
+			continue
+		}
+
+		// the subsequent blockfiles are newer and hence the archiving stops at the first blockfile
+		// that is not eligible
+		if time.Since(fileInfo.ModTime()) < a.conf.MinAge {
+			return nil
+		}
+		lastBlockNum, err := lastBlockInFile(fileNum)
+		if err != nil {
+			return err
+		}
+		if lastPersistedBlock-lastBlockNum < a.conf.KeepRecentBlocks {
+			nextEligibleBlock = lastBlockNum + a.conf.KeepRecentBlocks
+			return nil
+		}
+
+		logger.Infof("Archiving block file [%d] of ledger [%s] containing blocks up to [%d]", fileNum, a.ledgerID, lastBlockNum)
+		hash, err := computeFileHash(filePath)
+		if err != nil {
+			return err
+		}
+		location, err := a.conf.Archiver.Archive(a.ledgerID, fileNum, filePath)
+		if err != nil {
+			return err
+		}
+		if err := a.index.recordArchivedBlockfile(fileNum, location, hash); err != nil {
+			return err
+		}
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "error while removing archived block file %s", filePath)
+		}
+	}
+	return nil
+}
+
+// computeFileHash returns the SHA-256 hash of the content of the file
+func computeFileHash(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening file %s", filePath)
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, errors.Wrapf(err, "error while computing hash of file %s", filePath)
+	}
+	return h.Sum(nil), nil
+}
+
+// start launches the background archiving, that runs once on start and then every time
+// notifyBlockfileSealed is invoked. If a MinAge is configured, the archiving also runs
+// periodically, for archiving the blockfiles that were not old enough in a previous run
+func (a *blockfileArchive) start(archive func() error) {
+	a.notifyBlockfileSealed()
+	go func() {
+		defer close(a.stopped)
+		var ticks <-chan time.Time
+		if a.conf.MinAge > 0 {
+			ticker := time.NewTicker(a.conf.MinAge)
+			defer ticker.Stop()
+			ticks = ticker.C
+		}
+		for {
+			select {
+			case <-a.done:
+				return
+			case <-ticks:
+				a.notifyBlockfileSealed()
+			case <-a.trigger:
+				if err := archive(); err != nil {
+					logger.Errorf("Error while archiving block files of ledger [%s]: %+v", a.ledgerID, err)
+				}
+			}
+		}
+	}()
+}
+
+// notifyBlockfileSealed triggers the background archiving without waiting for it
+func (a *blockfileArchive) notifyBlockfileSealed() {
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+		// an archiving run is already pending
+	}
+}
+
+// notifyBlockCommitted triggers the background archiving if the committed block makes
+// a blockfile eligible for archiving
+func (a *blockfileArchive) notifyBlockCommitted(blockNum uint64) {
+	if blockNum >= atomic.LoadUint64(&a.nextEligibleBlock) {
+		a.notifyBlockfileSealed()
+	}
+}
+
+// stop stops the background archiving and waits for an ongoing archiving run to finish
+func (a *blockfileArchive) stop() {
+	a.stopOnce.Do(func() {
+		close(a.done)
+		<-a.stopped
+	})
+}
@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockfileArchiving(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 0, &ArchiveConf{
+		Archiver:         NewDirArchiver(archiveDir),
+		KeepRecentBlocks: 5,
+	}))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(9)...)
+	addBlocksInSeparateFiles(blkfileMgrWrapper, blocks[:3], blocks[3:6], blocks[6:])
+
+	// block file 0 contains blocks [0-2] and is archived, block file 1 contains blocks [3-5] and is
+	// not archived as it contains one of the recent 5 blocks
+	require.Eventually(t, func() bool {
+		return !fileExists(deriveBlockfilePath(blkfileMgr.rootDir, 0))
+	}, time.Minute, 10*time.Millisecond)
+	require.FileExists(t, filepath.Join(archiveDir, "testLedger", "blockfile_000000"))
+	require.FileExists(t, deriveBlockfilePath(blkfileMgr.rootDir, 1))
+	archivedContent, err := ioutil.ReadFile(filepath.Join(archiveDir, "testLedger", "blockfile_000000"))
+	require.NoError(t, err)
+	expectedHash := sha256.Sum256(archivedContent)
+	info, err := blkfileMgr.index.getArchivedBlockfileInfo(0)
+	require.NoError(t, err)
+	require.Equal(t, "testLedger/blockfile_000000", info.Location)
+	require.Equal(t, expectedHash[:], info.Sha256)
+	info, err = blkfileMgr.index.getArchivedBlockfileInfo(1)
+	require.NoError(t, err)
+	require.Nil(t, info)
+
+	verifyBlocks := func(w *testBlockfileMgrWrapper) {
+		w.testGetBlockByHash(blocks, nil)
+		w.testGetBlockByNumber(blocks, 0, nil)
+		w.testGetBlockByTxID(blocks, nil)
+		itr, err := w.blockfileMgr.retrieveBlocks(0)
+		require.NoError(t, err)
+		defer itr.Close()
+		for i := 0; i < len(blocks); i++ {
+			b, err := itr.Next()
+			require.NoError(t, err)
+			require.Equal(t, blocks[i], b)
+		}
+	}
+	verifyBlocks(blkfileMgrWrapper)
+
+	// the archived blocks remain available after a restart
+	blkfileMgrWrapper.close()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	require.False(t, fileExists(filepath.Join(blkfileMgrWrapper.blockfileMgr.rootDir, archiveCacheDir)))
+	verifyBlocks(blkfileMgrWrapper)
+
+	// adding more blocks makes the block files 1 and 2 eligible for archiving
+	moreBlocks := bg.NextTestBlocks(5)
+	blkfileMgrWrapper.blockfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(moreBlocks)
+	require.Eventually(t, func() bool {
+		return !fileExists(deriveBlockfilePath(blkfileMgrWrapper.blockfileMgr.rootDir, 2))
+	}, time.Minute, 10*time.Millisecond)
+	require.FileExists(t, filepath.Join(archiveDir, "testLedger", "blockfile_000001"))
+	require.FileExists(t, filepath.Join(archiveDir, "testLedger", "blockfile_000002"))
+	require.FileExists(t, deriveBlockfilePath(blkfileMgrWrapper.blockfileMgr.rootDir, 3))
+	blkfileMgrWrapper.testGetBlockByNumber(append(blocks, moreBlocks...), 0, nil)
+}
+
+func TestBlockfileArchivingMinAge(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 0, &ArchiveConf{
+		Archiver: NewDirArchiver(archiveDir),
+		MinAge:   time.Hour,
+	}))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blocks := testutil.ConstructTestBlocks(t, 4)
+	addBlocksInSeparateFiles(blkfileMgrWrapper, blocks[:2], blocks[2:])
+
+	require.NoError(t, blkfileMgr.archiveBlockfiles())
+	require.FileExists(t, deriveBlockfilePath(blkfileMgr.rootDir, 0))
+	require.NoFileExists(t, filepath.Join(archiveDir, "testLedger", "blockfile_000000"))
+}
+
+func TestArchivedBlockfilesCache(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	archiver := &countingArchiver{Archiver: NewDirArchiver(archiveDir)}
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 0, &ArchiveConf{
+		Archiver:            archiver,
+		MaxCachedBlockfiles: 1,
+	}))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blocks := testutil.ConstructTestBlocks(t, 6)
+	addBlocksInSeparateFiles(blkfileMgrWrapper, blocks[:2], blocks[2:4], blocks[4:])
+	require.NoError(t, blkfileMgr.archiveBlockfiles())
+	require.NoFileExists(t, deriveBlockfilePath(blkfileMgr.rootDir, 0))
+	require.NoFileExists(t, deriveBlockfilePath(blkfileMgr.rootDir, 1))
+
+	cacheDir := filepath.Join(blkfileMgr.rootDir, archiveCacheDir)
+	verifyBlocks := func(blockNums ...uint64) {
+		for _, blockNum := range blockNums {
+			b, err := blkfileMgr.retrieveBlockByNumber(blockNum)
+			require.NoError(t, err)
+			require.Equal(t, blocks[blockNum], b)
+		}
+	}
+	verifyBlocks(0, 1)
+	require.Equal(t, 1, archiver.numRetrieved)
+	verifyBlocks(0, 1)
+	require.Equal(t, 1, archiver.numRetrieved)
+	require.Equal(t, []string{"blockfile_000000"}, listFiles(t, cacheDir))
+
+	// retrieving the block file 1 evicts the block file 0 from the cache
+	verifyBlocks(2, 3)
+	require.Equal(t, 2, archiver.numRetrieved)
+	require.Equal(t, []string{"blockfile_000001"}, listFiles(t, cacheDir))
+	verifyBlocks(0)
+	require.Equal(t, 3, archiver.numRetrieved)
+	require.Equal(t, []string{"blockfile_000000"}, listFiles(t, cacheDir))
+
+	// the blocks in the block file that is not archived are read locally
+	verifyBlocks(4, 5)
+	require.Equal(t, 3, archiver.numRetrieved)
+}
+
+func TestArchivedBlockfileRetrievalError(t *testing.T) {
+	archiveDir := testPath()
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 0, &ArchiveConf{
+		Archiver: NewDirArchiver(archiveDir),
+	}))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blocks := testutil.ConstructTestBlocks(t, 4)
+	addBlocksInSeparateFiles(blkfileMgrWrapper, blocks[:2], blocks[2:])
+	require.NoError(t, blkfileMgr.archiveBlockfiles())
+
+	require.NoError(t, os.RemoveAll(archiveDir))
+	_, err := blkfileMgr.retrieveBlockByNumber(0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while retrieving archived block file testLedger/blockfile_000000")
+}
+
+func TestArchivedBlockfileHashMismatch(t *testing.T) {
+	archiveDir := testPath()
+	defer os.RemoveAll(archiveDir)
+	env := newTestEnv(t, NewConfWithArchive(testPath(), 0, &ArchiveConf{
+		Archiver: NewDirArchiver(archiveDir),
+	}))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blocks := testutil.ConstructTestBlocks(t, 4)
+	addBlocksInSeparateFiles(blkfileMgrWrapper, blocks[:2], blocks[2:])
+	require.NoError(t, blkfileMgr.archiveBlockfiles())
+
+	// tamper with the archived copy of the block file 0
+	archivedPath := filepath.Join(archiveDir, "testLedger", "blockfile_000000")
+	content, err := ioutil.ReadFile(archivedPath)
+	require.NoError(t, err)
+	content[len(content)-1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(archivedPath, content, 0600))
+
+	_, err = blkfileMgr.retrieveBlockByNumber(0)
+	require.EqualError(t, err, "archived block file [0] of ledger [testLedger] retrieved from location [testLedger/blockfile_000000] does not match its recorded hash")
+	require.Empty(t, listFiles(t, filepath.Join(blkfileMgr.rootDir, archiveCacheDir)))
+}
+
+func addBlocksInSeparateFiles(w *testBlockfileMgrWrapper, blocksPerFile ...[]*common.Block) {
+	for i, blocks := range blocksPerFile {
+		if i > 0 {
+			w.blockfileMgr.moveToNextFile()
+		}
+		w.addBlocks(blocks)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func listFiles(t *testing.T, dir string) []string {
+	fileInfos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range fileInfos {
+		names = append(names, f.Name())
+	}
+	return names
+}
+
+type countingArchiver struct {
+	Archiver
+	numRetrieved int
+}
+
+func (a *countingArchiver) Retrieve(location string, localPath string) error {
+	a.numRetrieved++
+	return a.Archiver.Retrieve(location, localPath)
+}
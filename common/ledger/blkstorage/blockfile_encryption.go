@@ -0,0 +1,295 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	blockfilesEncryptionFileName    = "__blockfilesEncryption"
+	blockfilesEncryptionTmpFileName = "__blockfilesEncryption.tmp"
+	reencryptedBlockfilePrefix      = "__reencrypted_"
+)
+
+// blockfileEncryption captures the key and the nonce that a blockfile is encrypted with.
+// Pending is set while a re-encrypted copy of the blockfile is being swapped in
+type blockfileEncryption struct {
+	KeyID   []byte               `json:"keyID"`
+	Nonce   []byte               `json:"nonce"`
+	Pending *blockfileEncryption `json:"pending,omitempty"`
+}
+
+// blockfileCiphers maintains the encryption of the blockfiles of a ledger, which is persisted in the
+// ledger blocks dir along with the blockfiles. A nil blockfileCiphers stands for the blockfiles in clear
+type blockfileCiphers struct {
+	rootDir   string
+	encryptor *encryption.Encryptor
+	mutex     sync.Mutex
+	files     map[int]*blockfileEncryption
+}
+
+// loadBlockfileCiphers loads the encryption of the blockfiles present in the rootDir. An error is
+// returned if the encryption of the blockfiles does not match with the supplied encryptor
+func loadBlockfileCiphers(rootDir string, encryptor *encryption.Encryptor) (*blockfileCiphers, error) {
+	files, err := readBlockfilesEncryption(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if encryptor == nil {
+		if len(files) > 0 {
+			return nil, errors.Errorf("the blockfiles at [%s] are encrypted but no encryption is configured", rootDir)
+		}
+		return nil, nil
+	}
+	c := &blockfileCiphers{
+		rootDir:   rootDir,
+		encryptor: encryptor,
+		files:     files,
+	}
+	if err := c.completePendingReencryption(); err != nil {
+		return nil, err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+		e, ok := c.files[fileNum]
+		if !ok {
+			size, err := blockfileSize(rootDir, fileNum)
+			if err != nil {
+				return nil, err
+			}
+			if size > 0 {
+				return nil, errors.Errorf("the blockfile [%s] is not encrypted, the blockfiles need to be encrypted before the encryption is configured",
+					deriveBlockfilePath(rootDir, fileNum))
+			}
+			continue
+		}
+		if _, err := encryptor.NewStream(e.KeyID, e.Nonce); err != nil {
+			return nil, errors.WithMessagef(err, "error loading the encryption of the blockfile [%s]", deriveBlockfilePath(rootDir, fileNum))
+		}
+	}
+	return c, nil
+}
+
+// writeStream returns the stream for encrypting the data appended to the blockfile with the given number.
+// A blockfile that is empty is assigned a fresh nonce and the active key of the encryptor
+func (c *blockfileCiphers) writeStream(fileNum int, fileSize int64) (*encryption.Stream, error) {
+	if c == nil {
+		return nil, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if fileSize == 0 {
+		nonce, err := encryption.NewNonce()
+		if err != nil {
+			return nil, err
+		}
+		c.files[fileNum] = &blockfileEncryption{KeyID: c.encryptor.ActiveKeyID(), Nonce: nonce}
+		if err := c.save(); err != nil {
+			return nil, err
+		}
+	}
+	return c.stream(fileNum)
+}
+
+// readStream returns the stream for decrypting the blockfile with the given number
+func (c *blockfileCiphers) readStream(fileNum int) (*encryption.Stream, error) {
+	if c == nil {
+		return nil, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stream(fileNum)
+}
+
+func (c *blockfileCiphers) stream(fileNum int) (*encryption.Stream, error) {
+	e, ok := c.files[fileNum]
+	if !ok {
+		return nil, errors.Errorf("the encryption of the blockfile [%s] is unknown", deriveBlockfilePath(c.rootDir, fileNum))
+	}
+	return c.encryptor.NewStream(e.KeyID, e.Nonce)
+}
+
+// reencrypt re-encrypts the blockfile with the given number with the active key of the encryptor, unless it is
+// already encrypted with it. A blockfile in clear is encrypted. The re-encrypted copy of the blockfile is first
+// written to a temporary file which is then renamed over the blockfile. The rename is recorded as pending so
+// that it is completed by the function loadBlockfileCiphers in case of a crash
+func (c *blockfileCiphers) reencrypt(fileNum int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	current, encrypted := c.files[fileNum]
+	if encrypted && bytes.Equal(current.KeyID, c.encryptor.ActiveKeyID()) {
+		return nil
+	}
+	var src *encryption.Stream
+	if encrypted {
+		var err error
+		if src, err = c.encryptor.NewStream(current.KeyID, current.Nonce); err != nil {
+			return err
+		}
+	}
+	nonce, err := encryption.NewNonce()
+	if err != nil {
+		return err
+	}
+	dst, err := c.encryptor.NewStream(c.encryptor.ActiveKeyID(), nonce)
+	if err != nil {
+		return err
+	}
+	blockfilePath := deriveBlockfilePath(c.rootDir, fileNum)
+	exists, _, err := fileutil.FileExists(blockfilePath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// an archived blockfile remains encrypted with its key, which needs to be retained as a retired key
+		logger.Warnf("Skipping the encryption of the blockfile [%s] as it is not present locally", blockfilePath)
+		return nil
+	}
+	content, err := ioutil.ReadFile(blockfilePath)
+	if err != nil {
+		return errors.Wrapf(err, "error reading the blockfile [%s]", blockfilePath)
+	}
+	if src != nil {
+		if err := src.XORKeyStream(content, content, 0); err != nil {
+			return err
+		}
+	}
+	if err := dst.XORKeyStream(content, content, 0); err != nil {
+		return err
+	}
+	tmpFileName := reencryptedBlockfilePrefix + filepath.Base(blockfilePath)
+	if err := fileutil.CreateAndSyncFile(filepath.Join(c.rootDir, tmpFileName), content, 0660); err != nil {
+		return err
+	}
+
+	pending := &blockfileEncryption{KeyID: c.encryptor.ActiveKeyID(), Nonce: nonce}
+	if !encrypted {
+		current = &blockfileEncryption{}
+		c.files[fileNum] = current
+	}
+	current.Pending = pending
+	if err := c.save(); err != nil {
+		return err
+	}
+	return c.completePendingReencryption()
+}
+
+// completePendingReencryption renames the re-encrypted copies of the blockfiles, that are recorded as pending,
+// over the blockfiles
+func (c *blockfileCiphers) completePendingReencryption() error {
+	numPending := 0
+	for fileNum, e := range c.files {
+		if e.Pending == nil {
+			continue
+		}
+		blockfilePath := deriveBlockfilePath(c.rootDir, fileNum)
+		tmpFilePath := filepath.Join(c.rootDir, reencryptedBlockfilePrefix+filepath.Base(blockfilePath))
+		exists, _, err := fileutil.FileExists(tmpFilePath)
+		if err != nil {
+			return err
+		}
+		// the re-encrypted copy is absent if it has already been renamed before a crash
+		if exists {
+			if err := os.Rename(tmpFilePath, blockfilePath); err != nil {
+				return errors.Wrapf(err, "error renaming the re-encrypted blockfile [%s]", tmpFilePath)
+			}
+		}
+		c.files[fileNum] = e.Pending
+		numPending++
+	}
+	if numPending == 0 {
+		return nil
+	}
+	if err := fileutil.SyncDir(c.rootDir); err != nil {
+		return err
+	}
+	return c.save()
+}
+
+func (c *blockfileCiphers) save() error {
+	b, err := json.Marshal(c.files)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the encryption of the blockfiles")
+	}
+	return fileutil.CreateAndSyncFileAtomically(c.rootDir, blockfilesEncryptionTmpFileName, blockfilesEncryptionFileName, b, 0640)
+}
+
+func readBlockfilesEncryption(rootDir string) (map[int]*blockfileEncryption, error) {
+	files := map[int]*blockfileEncryption{}
+	b, err := ioutil.ReadFile(filepath.Join(rootDir, blockfilesEncryptionFileName))
+	if os.IsNotExist(err) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the encryption of the blockfiles at [%s]", rootDir)
+	}
+	if err := json.Unmarshal(b, &files); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling the encryption of the blockfiles at [%s]", rootDir)
+	}
+	return files, nil
+}
+
+func blockfileSize(rootDir string, fileNum int) (int64, error) {
+	_, size, err := fileutil.FileExists(deriveBlockfilePath(rootDir, fileNum))
+	return size, err
+}
+
+// ReencryptBlockfiles encrypts the blockfiles of all the ledgers with the active key of the encryptor.
+// The blockfiles that are encrypted with a retired key of the encryptor are re-encrypted, whereas the blockfiles
+// in clear are encrypted. The block storage index remains valid since the encryption preserves the offsets.
+// This function is intended to be invoked offline. In case of a failure, this function can be invoked again
+// to resume the encryption
+func ReencryptBlockfiles(blockStorageDir string, encryptor *encryption.Encryptor) error {
+	if encryptor == nil {
+		return errors.New("an encryptor is required for encrypting the blockfiles")
+	}
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	chainsDir := conf.getChainsDir()
+	chainsDirExists, err := pathExists(chainsDir)
+	if err != nil || !chainsDirExists {
+		return err
+	}
+	ledgerIDs, err := fileutil.ListSubdirs(chainsDir)
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		ledgerDir := conf.getLedgerBlockDir(ledgerID)
+		files, err := readBlockfilesEncryption(ledgerDir)
+		if err != nil {
+			return err
+		}
+		c := &blockfileCiphers{rootDir: ledgerDir, encryptor: encryptor, files: files}
+		if err := c.completePendingReencryption(); err != nil {
+			return err
+		}
+		lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+		if err != nil {
+			return err
+		}
+		for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+			if err := c.reencrypt(fileNum); err != nil {
+				return errors.WithMessagef(err, "error encrypting the blockfile [%s]", deriveBlockfilePath(ledgerDir, fileNum))
+			}
+		}
+		logger.Infof("Encrypted [%d] blockfiles of the ledger [%s] with the key [%x]", lastFileNum+1, ledgerID, encryptor.ActiveKeyID())
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	encryptiontestutil "github.com/hyperledger/fabric/common/ledger/encryption/testutil"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockfileEncryption(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	csp, cleanup := encryptiontestutil.NewCryptoProvider(t)
+	defer cleanup()
+	oldKeyID, newKeyID := encryptiontestutil.GenerateKey(t, csp), encryptiontestutil.GenerateKey(t, csp)
+	oldEncryptor := encryptiontestutil.NewEncryptor(t, csp, oldKeyID)
+	newEncryptor := encryptiontestutil.NewEncryptor(t, csp, newKeyID, oldKeyID)
+	newKeyOnlyEncryptor := encryptiontestutil.NewEncryptor(t, csp, newKeyID)
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(9)...)
+	ledgerDir := (&Conf{blockStorageDir: path}).getLedgerBlockDir("testLedger")
+
+	// populate the blockfiles in clear
+	p := newTestProvider(t, NewConf(path, 0))
+	store, err := p.Open("testLedger")
+	require.NoError(t, err)
+	addBlocksInSeparateFiles(&testBlockfileMgrWrapper{t, store.fileMgr}, blocks[:3], blocks[3:6])
+	p.Close()
+	clearBlockfile, err := ioutil.ReadFile(deriveBlockfilePath(ledgerDir, 0))
+	require.NoError(t, err)
+
+	p = newTestProvider(t, NewConfWithEncryption(path, 0, nil, oldEncryptor))
+	_, err = p.Open("testLedger")
+	require.EqualError(t, err, "the blockfile ["+deriveBlockfilePath(ledgerDir, 0)+"] is not encrypted, the blockfiles need to be encrypted before the encryption is configured")
+	p.Close()
+
+	// encrypt the blockfiles and add more blocks
+	require.NoError(t, ReencryptBlockfiles(path, oldEncryptor))
+	encryptedBlockfile, err := ioutil.ReadFile(deriveBlockfilePath(ledgerDir, 0))
+	require.NoError(t, err)
+	require.Len(t, encryptedBlockfile, len(clearBlockfile))
+	require.True(t, bytes.Contains(clearBlockfile, blocks[1].Header.DataHash))
+	require.False(t, bytes.Contains(encryptedBlockfile, blocks[1].Header.DataHash))
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, oldEncryptor), blocks[:6], blocks[6:8])
+
+	p = newTestProvider(t, NewConf(path, 0))
+	_, err = p.Open("testLedger")
+	require.EqualError(t, err, "the blockfiles at ["+ledgerDir+"] are encrypted but no encryption is configured")
+	p.Close()
+
+	// after rotating the key, the blockfiles encrypted with either key are readable
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, newEncryptor), blocks[:8], blocks[8:])
+	p = newTestProvider(t, NewConfWithEncryption(path, 0, nil, newKeyOnlyEncryptor))
+	_, err = p.Open("testLedger")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ledger data is encrypted with the unknown key")
+	p.Close()
+
+	// once re-encrypted, the retired key is not needed anymore
+	require.NoError(t, ReencryptBlockfiles(path, newEncryptor))
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, newKeyOnlyEncryptor), blocks, nil)
+
+	// the offline operations decrypt the blockfiles
+	require.NoError(t, ValidateRollbackParams(path, "testLedger", 4, newKeyOnlyEncryptor))
+	require.NoError(t, Rollback(path, "testLedger", 4, &IndexConfig{AttrsToIndex: attrsToIndex}, newKeyOnlyEncryptor))
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, newKeyOnlyEncryptor), blocks[:5], nil)
+	require.NoError(t, ResetBlockStore(path, newKeyOnlyEncryptor))
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, newKeyOnlyEncryptor), blocks[:1], nil)
+}
+
+func TestBlockfileReencryptionRecovery(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	csp, cleanup := encryptiontestutil.NewCryptoProvider(t)
+	defer cleanup()
+	oldKeyID, newKeyID := encryptiontestutil.GenerateKey(t, csp), encryptiontestutil.GenerateKey(t, csp)
+	oldEncryptor := encryptiontestutil.NewEncryptor(t, csp, oldKeyID)
+	newEncryptor := encryptiontestutil.NewEncryptor(t, csp, newKeyID, oldKeyID)
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextTestBlocks(4)...)
+	ledgerDir := (&Conf{blockStorageDir: path}).getLedgerBlockDir("testLedger")
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, oldEncryptor), nil, blocks)
+
+	// simulate a crash after the re-encrypted copy of the blockfile is recorded as pending but before it is renamed
+	blockfilePath := deriveBlockfilePath(ledgerDir, 0)
+	tmpFilePath := filepath.Join(ledgerDir, reencryptedBlockfilePrefix+"blockfile_000000")
+	originalBlockfile, err := ioutil.ReadFile(blockfilePath)
+	require.NoError(t, err)
+	c, err := loadBlockfileCiphers(ledgerDir, newEncryptor)
+	require.NoError(t, err)
+	original := c.files[0]
+	require.NoError(t, c.reencrypt(0))
+	require.NoError(t, os.Rename(blockfilePath, tmpFilePath))
+	require.NoError(t, ioutil.WriteFile(blockfilePath, originalBlockfile, 0660))
+	original.Pending = c.files[0]
+	c.files[0] = original
+	require.NoError(t, c.save())
+
+	newKeyOnlyEncryptor := encryptiontestutil.NewEncryptor(t, csp, newKeyID)
+	verifyEncryptedBlocks(t, NewConfWithEncryption(path, 0, nil, newKeyOnlyEncryptor), blocks, nil)
+	require.False(t, fileExists(tmpFilePath))
+}
+
+// verifyEncryptedBlocks verifies the blocks that are already present in the block store, appends
+// more blocks and verifies all the blocks after reopening the block store
+func verifyEncryptedBlocks(t *testing.T, conf *Conf, existingBlocks, moreBlocks []*common.Block) {
+	p := newTestProvider(t, conf)
+	store, err := p.Open("testLedger")
+	require.NoError(t, err)
+	w := &testBlockfileMgrWrapper{t, store.fileMgr}
+	if len(existingBlocks) > 0 {
+		verifyBlocksInStore(w, existingBlocks)
+	}
+	if len(moreBlocks) > 0 {
+		if len(existingBlocks) > 0 {
+			w.blockfileMgr.moveToNextFile()
+		}
+		w.addBlocks(moreBlocks)
+	}
+	p.Close()
+
+	p = newTestProvider(t, conf)
+	defer p.Close()
+	store, err = p.Open("testLedger")
+	require.NoError(t, err)
+	verifyBlocksInStore(&testBlockfileMgrWrapper{t, store.fileMgr}, append(existingBlocks, moreBlocks...))
+}
+
+func verifyBlocksInStore(w *testBlockfileMgrWrapper, blocks []*common.Block) {
+	w.testGetBlockByHash(blocks, nil)
+	w.testGetBlockByNumber(blocks, 0, nil)
+	w.testGetBlockByTxID(blocks, nil)
+	for _, block := range blocks[1:] {
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(block.Data.Data[0])
+		require.NoError(w.t, err)
+		w.testGetTransactionByTxID(txID, block.Data.Data[0], nil)
+	}
+	itr, err := w.blockfileMgr.retrieveBlocks(0)
+	require.NoError(w.t, err)
+	defer itr.Close()
+	for _, expected := range blocks {
+		b, err := itr.Next()
+		require.NoError(w.t, err)
+		require.Equal(w.t, expected, b)
+	}
+}
+
+func newTestProvider(t *testing.T, conf *Conf) *BlockStoreProvider {
+	p, err := NewProvider(conf, &IndexConfig{AttrsToIndex: attrsToIndex}, &disabled.Provider{})
+	require.NoError(t, err)
+	return p
+}
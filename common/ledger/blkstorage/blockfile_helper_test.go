@@ -25,7 +25,7 @@ func TestConstructBlockfilesInfo(t *testing.T) {
 	defer env.Cleanup()
 
 	// constructBlockfilesInfo on an empty block folder should return blockfileInfo with noBlockFiles: true
-	blkfilesInfo, err := constructBlockfilesInfo(blkStoreDir)
+	blkfilesInfo, err := constructBlockfilesInfo(blkStoreDir, nil)
 	require.NoError(t, err)
 	require.Equal(t,
 		&blockfilesInfo{
@@ -105,7 +105,7 @@ func TestBinarySearchBlockFileNum(t *testing.T) {
 	require.Len(t, files, 11)
 
 	for i := uint64(0); i < 100; i++ {
-		fileNum, err := binarySearchFileNumForBlock(ledgerDir, i)
+		fileNum, err := binarySearchFileNumForBlock(ledgerDir, nil, i)
 		require.NoError(t, err)
 		locFromIndex, err := blkfileMgr.index.getBlockLocByBlockNum(i)
 		require.NoError(t, err)
@@ -115,7 +115,7 @@ func TestBinarySearchBlockFileNum(t *testing.T) {
 }
 
 func checkBlockfilesInfoFromFS(t *testing.T, blkStoreDir string, expected *blockfilesInfo) {
-	blkfilesInfo, err := constructBlockfilesInfo(blkStoreDir)
+	blkfilesInfo, err := constructBlockfilesInfo(blkStoreDir, nil)
 	require.NoError(t, err)
 	require.Equal(t, expected, blkfilesInfo)
 }
@@ -43,6 +43,8 @@ type blockfileMgr struct {
 	blkfilesInfoCond          *sync.Cond
 	currentFileWriter         *blockfileWriter
 	bcInfo                    atomic.Value
+	archive                   *blockfileArchive
+	ciphers                   *blockfileCiphers
 }
 
 /*
@@ -93,7 +95,11 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	if err != nil {
 		panic(fmt.Sprintf("Error creating block storage root dir [%s]: %s", rootDir, err))
 	}
-	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
+	ciphers, err := loadBlockfileCiphers(rootDir, conf.encryptor)
+	if err != nil {
+		return nil, err
+	}
+	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore, ciphers: ciphers}
 
 	blockfilesInfo, err := mgr.loadBlkfilesInfo()
 	if err != nil {
@@ -101,20 +107,20 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	}
 	if blockfilesInfo == nil {
 		logger.Info(`Getting block information from block storage`)
-		if blockfilesInfo, err = constructBlockfilesInfo(rootDir); err != nil {
+		if blockfilesInfo, err = constructBlockfilesInfo(rootDir, ciphers); err != nil {
 			panic(fmt.Sprintf("Could not build blockfilesInfo info from block files: %s", err))
 		}
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(blockfilesInfo))
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
-		syncBlockfilesInfoFromFS(rootDir, blockfilesInfo)
+		syncBlockfilesInfoFromFS(rootDir, ciphers, blockfilesInfo)
 	}
 	err = mgr.saveBlkfilesInfo(blockfilesInfo, true)
 	if err != nil {
 		panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 	}
 
-	currentFileWriter, err := newBlockfileWriter(deriveBlockfilePath(rootDir, blockfilesInfo.latestFileNumber))
+	currentFileWriter, err := newBlockfileWriter(rootDir, blockfilesInfo.latestFileNumber, ciphers)
 	if err != nil {
 		panic(fmt.Sprintf("Could not open writer to current file: %s", err))
 	}
@@ -125,6 +131,11 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	if mgr.index, err = newBlockIndex(indexConfig, indexStore); err != nil {
 		panic(fmt.Sprintf("error in block index: %s", err))
 	}
+	if conf.archiveConf != nil {
+		if mgr.archive, err = newBlockfileArchive(id, rootDir, conf.archiveConf, mgr.index); err != nil {
+			return nil, err
+		}
+	}
 
 	mgr.blockfilesInfo = blockfilesInfo
 	bsi, err := loadBootstrappingSnapshotInfo(rootDir)
@@ -160,6 +171,9 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 			PreviousBlockHash: previousBlockHash}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	if mgr.archive != nil {
+		mgr.archive.start(mgr.archiveBlockfiles)
+	}
 	return mgr, nil
 }
 
@@ -207,7 +221,7 @@ func bootstrapFromSnapshottedTxIDs(
 	return nil
 }
 
-func syncBlockfilesInfoFromFS(rootDir string, blkfilesInfo *blockfilesInfo) {
+func syncBlockfilesInfoFromFS(rootDir string, ciphers *blockfileCiphers, blkfilesInfo *blockfilesInfo) {
 	logger.Debugf("Starting blockfilesInfo=%s", blkfilesInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, blkfilesInfo.latestFileNumber)
@@ -225,7 +239,7 @@ func syncBlockfilesInfoFromFS(rootDir string, blkfilesInfo *blockfilesInfo) {
 	}
 	//Scan the file system to verify that the blockfilesInfo stored in db is correct
 	_, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(
-		rootDir, blkfilesInfo.latestFileNumber, int64(blkfilesInfo.latestFileSize))
+		rootDir, ciphers, blkfilesInfo.latestFileNumber, int64(blkfilesInfo.latestFileSize))
 	if err != nil {
 		panic(fmt.Sprintf("Could not open current file for detecting last block in the file: %s", err))
 	}
@@ -248,6 +262,9 @@ func deriveBlockfilePath(rootDir string, suffixNum int) string {
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.archive != nil {
+		mgr.archive.stop()
+	}
 	mgr.currentFileWriter.close()
 }
 
@@ -257,8 +274,7 @@ func (mgr *blockfileMgr) moveToNextFile() {
 		latestFileSize:     0,
 		lastPersistedBlock: mgr.blockfilesInfo.lastPersistedBlock}
 
-	nextFileWriter, err := newBlockfileWriter(
-		deriveBlockfilePath(mgr.rootDir, blkfilesInfo.latestFileNumber))
+	nextFileWriter, err := newBlockfileWriter(mgr.rootDir, blkfilesInfo.latestFileNumber, mgr.ciphers)
 
 	if err != nil {
 		panic(fmt.Sprintf("Could not open writer to next file: %s", err))
@@ -270,6 +286,9 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	}
 	mgr.currentFileWriter = nextFileWriter
 	mgr.updateBlockfilesInfo(blkfilesInfo)
+	if mgr.archive != nil {
+		mgr.archive.notifyBlockfileSealed()
+	}
 }
 
 func (mgr *blockfileMgr) addBlock(block *common.Block) error {
@@ -357,6 +376,9 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	//update the blockfilesInfo (for storage) and the blockchain info (for APIs) in the manager
 	mgr.updateBlockfilesInfo(newBlkfilesInfo)
 	mgr.updateBlockchainInfo(blockHash, block)
+	if mgr.archive != nil {
+		mgr.archive.notifyBlockCommitted(block.Header.Number)
+	}
 	return nil
 }
 
@@ -395,7 +417,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 	skipFirstBlock := false
 	endFileNum := mgr.blockfilesInfo.latestFileNumber
 
-	firstAvailableBlkNum, err := retrieveFirstBlockNumFromFile(mgr.rootDir, 0)
+	firstAvailableBlkNum, err := mgr.firstBlockNumInFile(0)
 	if err != nil {
 		return err
 	}
@@ -415,7 +437,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = openBlockStream(mgr.openBlockfile, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -636,7 +658,7 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	stream, err := openBlockfileStream(mgr.openBlockfile, lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -649,8 +671,7 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	reader, err := mgr.openBlockfile(lp.fileSuffixNum)
 	if err != nil {
 		return nil, err
 	}
@@ -662,6 +683,58 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// openBlockfile opens the blockfile with the given number for reading. If the blockfile
+// has been archived, it is transparently retrieved from the archive
+func (mgr *blockfileMgr) openBlockfile(fileNum int) (*blockfileReader, error) {
+	if mgr.archive != nil {
+		file, err := mgr.archive.openBlockfile(fileNum)
+		if err != nil {
+			return nil, err
+		}
+		return openBlockfileReader(file, fileNum, mgr.ciphers)
+	}
+	return localBlockfileOpener(mgr.rootDir, mgr.ciphers)(fileNum)
+}
+
+func (mgr *blockfileMgr) firstBlockNumInFile(fileNum int) (uint64, error) {
+	s, err := openBlockfileStream(mgr.openBlockfile, fileNum, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer s.close()
+	return firstBlockNumInStream(s)
+}
+
+// lastBlockNumInFile returns the number of the last block in a sealed blockfile, i.e.,
+// a blockfile with a file number lower than the latest file number
+func (mgr *blockfileMgr) lastBlockNumInFile(fileNum int) (uint64, error) {
+	mgr.blkfilesInfoCond.L.Lock()
+	blkfilesInfo := mgr.blockfilesInfo
+	mgr.blkfilesInfoCond.L.Unlock()
+
+	if fileNum+1 == blkfilesInfo.latestFileNumber && blkfilesInfo.latestFileSize == 0 {
+		return blkfilesInfo.lastPersistedBlock, nil
+	}
+	nextFileFirstBlockNum, err := mgr.firstBlockNumInFile(fileNum + 1)
+	if err != nil {
+		return 0, err
+	}
+	return nextFileFirstBlockNum - 1, nil
+}
+
+// archiveBlockfiles archives the sealed blockfiles that are eligible for archiving
+func (mgr *blockfileMgr) archiveBlockfiles() error {
+	mgr.blkfilesInfoCond.L.Lock()
+	blkfilesInfo := mgr.blockfilesInfo
+	mgr.blkfilesInfoCond.L.Unlock()
+
+	return mgr.archive.archiveBlockfiles(
+		blkfilesInfo.latestFileNumber,
+		blkfilesInfo.lastPersistedBlock,
+		mgr.lastBlockNumInFile,
+	)
+}
+
 //Get the current blockfilesInfo information that is stored in the database
 func (mgr *blockfileMgr) loadBlkfilesInfo() (*blockfilesInfo, error) {
 	var b []byte
@@ -701,11 +774,11 @@ func (mgr *blockfileMgr) bootstrappedFromSnapshot() bool {
 
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
-func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
+func scanForLastCompleteBlock(rootDir string, ciphers *blockfileCiphers, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
 	//scan the passed file number suffix starting from the passed offset to find the last completed block
 	numBlocks := 0
 	var lastBlockBytes []byte
-	blockStream, errOpen := newBlockfileStream(rootDir, fileNum, startingOffset)
+	blockStream, errOpen := newBlockfileStream(rootDir, ciphers, fileNum, startingOffset)
 	if errOpen != nil {
 		return nil, 0, 0, errOpen
 	}
@@ -9,6 +9,7 @@ package blkstorage
 import (
 	"os"
 
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/internal/fileutil"
 	"github.com/pkg/errors"
 )
@@ -17,10 +18,18 @@ import (
 type blockfileWriter struct {
 	filePath string
 	file     *os.File
+	fileNum  int
+	ciphers  *blockfileCiphers
+	stream   *encryption.Stream
+	offset   int64
 }
 
-func newBlockfileWriter(filePath string) (*blockfileWriter, error) {
-	writer := &blockfileWriter{filePath: filePath}
+func newBlockfileWriter(rootDir string, fileNum int, ciphers *blockfileCiphers) (*blockfileWriter, error) {
+	writer := &blockfileWriter{
+		filePath: deriveBlockfilePath(rootDir, fileNum),
+		fileNum:  fileNum,
+		ciphers:  ciphers,
+	}
 	return writer, writer.open()
 }
 
@@ -31,12 +40,21 @@ func (w *blockfileWriter) truncateFile(targetSize int) error {
 	}
 	if fileStat.Size() > int64(targetSize) {
 		w.file.Truncate(int64(targetSize))
+		w.offset = int64(targetSize)
 	}
 	return nil
 }
 
 func (w *blockfileWriter) append(b []byte, sync bool) error {
-	_, err := w.file.Write(b)
+	if w.stream != nil {
+		encrypted := make([]byte, len(b))
+		if err := w.stream.XORKeyStream(encrypted, b, w.offset); err != nil {
+			return err
+		}
+		b = encrypted
+	}
+	n, err := w.file.Write(b)
+	w.offset += int64(n)
 	if err != nil {
 		return err
 	}
@@ -52,9 +70,20 @@ func (w *blockfileWriter) open() error {
 		return errors.Wrapf(err, "error opening block file writer for file %s", w.filePath)
 	}
 	if err := fileutil.SyncParentDir(w.filePath); err != nil {
+		file.Close()
+		return err
+	}
+	fileStat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.Wrapf(err, "error opening block file writer for file %s", w.filePath)
+	}
+	if w.stream, err = w.ciphers.writeStream(w.fileNum, fileStat.Size()); err != nil {
+		file.Close()
 		return err
 	}
 	w.file = file
+	w.offset = fileStat.Size()
 	return nil
 }
 
@@ -64,16 +93,13 @@ func (w *blockfileWriter) close() error {
 
 ////  READER ////
 type blockfileReader struct {
-	file *os.File
+	file   *os.File
+	stream *encryption.Stream
+	offset int64
 }
 
-func newBlockfileReader(filePath string) (*blockfileReader, error) {
-	file, err := os.OpenFile(filePath, os.O_RDONLY, 0600)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error opening block file reader for file %s", filePath)
-	}
-	reader := &blockfileReader{file}
-	return reader, nil
+func newBlockfileReader(file *os.File, stream *encryption.Stream) *blockfileReader {
+	return &blockfileReader{file: file, stream: stream}
 }
 
 func (r *blockfileReader) read(offset int, length int) ([]byte, error) {
@@ -82,9 +108,36 @@ func (r *blockfileReader) read(offset int, length int) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "error reading block file for offset %d and length %d", offset, length)
 	}
+	if err := r.decrypt(b, int64(offset)); err != nil {
+		return nil, err
+	}
 	return b, nil
 }
 
+// seek sets the offset for the next Read
+func (r *blockfileReader) seek(offset int64) (int64, error) {
+	newPosition, err := r.file.Seek(offset, 0)
+	r.offset = newPosition
+	return newPosition, err
+}
+
+// Read implements io.Reader for reading the blockfile sequentially
+func (r *blockfileReader) Read(b []byte) (int, error) {
+	n, err := r.file.Read(b)
+	if decryptErr := r.decrypt(b[:n], r.offset); decryptErr != nil {
+		return 0, decryptErr
+	}
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *blockfileReader) decrypt(b []byte, offset int64) error {
+	if r.stream == nil {
+		return nil
+	}
+	return r.stream.XORKeyStream(b, b, offset)
+}
+
 func (r *blockfileReader) close() error {
 	return errors.WithStack(r.file.Close())
 }
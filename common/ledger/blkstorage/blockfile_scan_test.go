@@ -33,7 +33,7 @@ func TestBlockFileScanSmallTxOnly(t *testing.T) {
 	_, fileSize, err := fileutil.FileExists(filePath)
 	require.NoError(t, err)
 
-	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(env.provider.conf.getLedgerBlockDir(ledgerid), 0, 0)
+	lastBlockBytes, endOffsetLastBlock, numBlocks, err := scanForLastCompleteBlock(env.provider.conf.getLedgerBlockDir(ledgerid), nil, 0, 0)
 	require.NoError(t, err)
 	require.Equal(t, len(blocks), numBlocks)
 	require.Equal(t, fileSize, endOffsetLastBlock)
@@ -66,7 +66,7 @@ func TestBlockFileScanSmallTxLastTxIncomplete(t *testing.T) {
 	err = file.Truncate(fileSize - 1)
 	require.NoError(t, err)
 
-	lastBlockBytes, _, numBlocks, err := scanForLastCompleteBlock(env.provider.conf.getLedgerBlockDir(ledgerid), 0, 0)
+	lastBlockBytes, _, numBlocks, err := scanForLastCompleteBlock(env.provider.conf.getLedgerBlockDir(ledgerid), nil, 0, 0)
 	require.NoError(t, err)
 	require.Equal(t, len(blocks)-1, numBlocks)
 
@@ -27,6 +27,7 @@ const (
 	blockHashIdxKeyPrefix       = 'h'
 	txIDIdxKeyPrefix            = 't'
 	blockNumTranNumIdxKeyPrefix = 'a'
+	archivedBlockfileKeyPrefix  = 'r'
 	indexSavePointKeyStr        = "indexCheckpointKey"
 
 	snapshotFileFormat       = byte(1)
@@ -268,6 +269,31 @@ func (index *blockIndex) getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint
 	return txFLP, nil
 }
 
+// recordArchivedBlockfile records the location in the archive and the SHA-256 hash of the content of
+// the blockfile with the given number. The fileLocPointers of the blocks and transactions in this blockfile
+// remain valid and, from now on, are resolved against the archived copy of the blockfile
+func (index *blockIndex) recordArchivedBlockfile(fileNum int, location string, hash []byte) error {
+	info, err := proto.Marshal(&ArchivedBlockfileInfo{Location: location, Sha256: hash})
+	if err != nil {
+		return errors.Wrap(err, "error marshaling archived blockfile info")
+	}
+	return index.db.Put(constructArchivedBlockfileKey(fileNum), info, true)
+}
+
+// getArchivedBlockfileInfo returns the location and the hash of the archived copy of the blockfile with
+// the given number. A nil info is returned if the blockfile has not been archived
+func (index *blockIndex) getArchivedBlockfileInfo(fileNum int) (*ArchivedBlockfileInfo, error) {
+	b, err := index.db.Get(constructArchivedBlockfileKey(fileNum))
+	if err != nil || b == nil {
+		return nil, err
+	}
+	info := &ArchivedBlockfileInfo{}
+	if err := proto.Unmarshal(b, info); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling archived blockfile info of block file [%d]", fileNum)
+	}
+	return info, nil
+}
+
 func (index *blockIndex) exportUniqueTxIDs(dir string, newHashFunc snapshot.NewHashFunc) (map[string][]byte, error) {
 	if !index.isAttributeIndexed(IndexableAttrTxID) {
 		return nil, ErrAttrNotIndexed
@@ -385,6 +411,10 @@ func constructBlockNumKey(blockNum uint64) []byte {
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
 }
 
+func constructArchivedBlockfileKey(fileNum int) []byte {
+	return append([]byte{archivedBlockfileKeyPrefix}, util.EncodeOrderPreservingVarUint64(uint64(fileNum))...)
+}
+
 func constructBlockHashKey(blockHash []byte) []byte {
 	return append([]byte{blockHashIdxKeyPrefix}, blockHash...)
 }
@@ -6,20 +6,47 @@ SPDX-License-Identifier: Apache-2.0
 
 package blkstorage
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+)
 
 const (
 	// ChainsDir is the name of the directory containing the channel ledgers.
 	ChainsDir = "chains"
 	// IndexDir is the name of the directory containing all block indexes across ledgers.
-	IndexDir                = "index"
-	defaultMaxBlockfileSize = 64 * 1024 * 1024 // bytes
+	IndexDir                           = "index"
+	defaultMaxBlockfileSize            = 64 * 1024 * 1024 // bytes
+	defaultMaxCachedArchivedBlockfiles = 4
 )
 
 // Conf encapsulates all the configurations for `BlockStore`
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+	encryptor        *encryption.Encryptor
+}
+
+// ArchiveConf configures the archiving of the sealed blockfiles to cold storage.
+// A blockfile is archived once all of its blocks are older than both the KeepRecentBlocks
+// and the MinAge thresholds. The archived blockfiles remain transparently available for
+// the block retrieval APIs, which fetch them from the archive on demand.
+// Note that the ledger rollback and reset operations, as well as rebuilding the block index,
+// require all the blockfiles to be present locally and hence they cannot be used for a ledger
+// with archived blockfiles.
+type ArchiveConf struct {
+	// Archiver is the backend the blockfiles are archived to
+	Archiver Archiver
+	// KeepRecentBlocks is the number of most recent blocks that are never archived
+	KeepRecentBlocks uint64
+	// MinAge is the minimum time since the last modification of a blockfile before it is archived
+	MinAge time.Duration
+	// MaxCachedBlockfiles is the maximum number of archived blockfiles kept in a local cache
+	// after being retrieved from the archive. Defaults to 4 if not positive.
+	MaxCachedBlockfiles int
 }
 
 // NewConf constructs new `Conf`.
@@ -28,7 +55,30 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir: blockStorageDir, maxBlockfileSize: maxBlockfileSize}
+}
+
+// NewConfWithArchive constructs new `Conf` that archives the sealed blockfiles as per the supplied archiveConf.
+// If archiveConf is nil, the blockfiles are never archived.
+func NewConfWithArchive(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	if archiveConf != nil && archiveConf.Archiver != nil {
+		c := *archiveConf
+		if c.MaxCachedBlockfiles <= 0 {
+			c.MaxCachedBlockfiles = defaultMaxCachedArchivedBlockfiles
+		}
+		conf.archiveConf = &c
+	}
+	return conf
+}
+
+// NewConfWithEncryption constructs new `Conf` that encrypts the blockfiles with the supplied encryptor,
+// in addition to archiving them as per the supplied archiveConf. If encryptor is nil, the blockfiles are
+// stored in clear. Note that the block storage index is not encrypted
+func NewConfWithEncryption(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf, encryptor *encryption.Encryptor) *Conf {
+	conf := NewConfWithArchive(blockStorageDir, maxBlockfileSize, archiveConf)
+	conf.encryptor = encryptor
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
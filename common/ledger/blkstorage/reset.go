@@ -13,11 +13,13 @@ import (
 	"path"
 	"strconv"
 
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/internal/fileutil"
 )
 
-// ResetBlockStore drops the block storage index and truncates the blocks files for all channels/ledgers to genesis blocks
-func ResetBlockStore(blockStorageDir string) error {
+// ResetBlockStore drops the block storage index and truncates the blocks files for all channels/ledgers to genesis blocks.
+// The encryptor is required if the blockfiles are encrypted
+func ResetBlockStore(blockStorageDir string, encryptor *encryption.Encryptor) error {
 	if err := DeleteBlockStoreIndex(blockStorageDir); err != nil {
 		return err
 	}
@@ -42,10 +44,14 @@ func ResetBlockStore(blockStorageDir string) error {
 	logger.Infof("Found ledgers - %s", ledgerIDs)
 	for _, ledgerID := range ledgerIDs {
 		ledgerDir := conf.getLedgerBlockDir(ledgerID)
-		if err := recordHeightIfGreaterThanPreviousRecording(ledgerDir); err != nil {
+		ciphers, err := loadBlockfileCiphers(ledgerDir, encryptor)
+		if err != nil {
+			return err
+		}
+		if err := recordHeightIfGreaterThanPreviousRecording(ledgerDir, ciphers); err != nil {
 			return err
 		}
-		if err := resetToGenesisBlk(ledgerDir); err != nil {
+		if err := resetToGenesisBlk(ledgerDir, ciphers); err != nil {
 			return err
 		}
 	}
@@ -60,7 +66,7 @@ func DeleteBlockStoreIndex(blockStorageDir string) error {
 	return os.RemoveAll(indexDir)
 }
 
-func resetToGenesisBlk(ledgerDir string) error {
+func resetToGenesisBlk(ledgerDir string, ciphers *blockfileCiphers) error {
 	logger.Infof("Resetting ledger [%s] to genesis block", ledgerDir)
 	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
 	logger.Infof("lastFileNum = [%d]", lastFileNum)
@@ -70,7 +76,7 @@ func resetToGenesisBlk(ledgerDir string) error {
 	if lastFileNum < 0 {
 		return nil
 	}
-	zeroFilePath, genesisBlkEndOffset, err := retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir)
+	zeroFilePath, genesisBlkEndOffset, err := retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir, ciphers)
 	if err != nil {
 		return err
 	}
@@ -86,9 +92,9 @@ func resetToGenesisBlk(ledgerDir string) error {
 	return os.Truncate(zeroFilePath, genesisBlkEndOffset)
 }
 
-func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string) (string, int64, error) {
+func retrieveGenesisBlkOffsetAndMakeACopy(ledgerDir string, ciphers *blockfileCiphers) (string, int64, error) {
 	blockfilePath := deriveBlockfilePath(ledgerDir, 0)
-	blockfileStream, err := newBlockfileStream(ledgerDir, 0, 0)
+	blockfileStream, err := newBlockfileStream(ledgerDir, ciphers, 0, 0)
 	if err != nil {
 		return "", -1, err
 	}
@@ -140,9 +146,9 @@ const (
 // directory. This file contains human readable string for the current block height. This function
 // only overwrites this information if the current block height is higher than the one recorded in
 // the existing file (if present). This helps in achieving fail-safe behviour of reset utility
-func recordHeightIfGreaterThanPreviousRecording(ledgerDir string) error {
+func recordHeightIfGreaterThanPreviousRecording(ledgerDir string, ciphers *blockfileCiphers) error {
 	logger.Infof("Preparing to record current height for ledger at [%s]", ledgerDir)
-	blkfilesInfo, err := constructBlockfilesInfo(ledgerDir)
+	blkfilesInfo, err := constructBlockfilesInfo(ledgerDir, ciphers)
 	if err != nil {
 		return err
 	}
@@ -9,6 +9,7 @@ package blkstorage
 import (
 	"os"
 
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/internal/fileutil"
 	"github.com/hyperledger/fabric/protoutil"
@@ -18,6 +19,7 @@ import (
 type rollbackMgr struct {
 	ledgerID       string
 	ledgerDir      string
+	ciphers        *blockfileCiphers
 	indexDir       string
 	dbProvider     *leveldbhelper.Provider
 	indexStore     *blockIndex
@@ -26,14 +28,15 @@ type rollbackMgr struct {
 }
 
 // Rollback reverts changes made to the block store beyond a given block number.
-func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *IndexConfig) error {
-	r, err := newRollbackMgr(blockStorageDir, ledgerID, indexConfig, targetBlockNum)
+// The encryptor is required if the blockfiles are encrypted
+func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *IndexConfig, encryptor *encryption.Encryptor) error {
+	r, err := newRollbackMgr(blockStorageDir, ledgerID, indexConfig, targetBlockNum, encryptor)
 	if err != nil {
 		return err
 	}
 	defer r.dbProvider.Close()
 
-	if err := recordHeightIfGreaterThanPreviousRecording(r.ledgerDir); err != nil {
+	if err := recordHeightIfGreaterThanPreviousRecording(r.ledgerDir, r.ciphers); err != nil {
 		return err
 	}
 
@@ -50,7 +53,7 @@ func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConf
 	return nil
 }
 
-func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *IndexConfig, targetBlockNum uint64) (*rollbackMgr, error) {
+func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *IndexConfig, targetBlockNum uint64, encryptor *encryption.Encryptor) (*rollbackMgr, error) {
 	r := &rollbackMgr{}
 
 	r.ledgerID = ledgerID
@@ -58,8 +61,11 @@ func newRollbackMgr(blockStorageDir, ledgerID string, indexConfig *IndexConfig,
 	r.ledgerDir = conf.getLedgerBlockDir(ledgerID)
 	r.targetBlockNum = targetBlockNum
 
-	r.indexDir = conf.getIndexDir()
 	var err error
+	if r.ciphers, err = loadBlockfileCiphers(r.ledgerDir, encryptor); err != nil {
+		return nil, err
+	}
+	r.indexDir = conf.getIndexDir()
 	r.dbProvider, err = leveldbhelper.NewProvider(
 		&leveldbhelper.Conf{
 			DBPath:         r.indexDir,
@@ -121,7 +127,7 @@ func (r *rollbackMgr) deleteIndexEntriesRange(startBlkNum, endBlkNum uint64) err
 		return err
 	}
 
-	stream, err := newBlockStream(r.ledgerDir, lp.fileSuffixNum, int64(lp.offset), -1)
+	stream, err := newBlockStream(r.ledgerDir, r.ciphers, lp.fileSuffixNum, int64(lp.offset), -1)
 	if err != nil {
 		return err
 	}
@@ -174,7 +180,7 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 		return err
 	}
 	// must not use index for block location search since the index can be behind the target block
-	targetFileNum, err := binarySearchFileNumForBlock(r.ledgerDir, r.targetBlockNum)
+	targetFileNum, err := binarySearchFileNumForBlock(r.ledgerDir, r.ciphers, r.targetBlockNum)
 	if err != nil {
 		return err
 	}
@@ -194,7 +200,7 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 	}
 
 	logger.Infof("Truncating block file [%d] to the end boundary of block number [%d]", targetFileNum, r.targetBlockNum)
-	endOffset, err := calculateEndOffSet(r.ledgerDir, targetFileNum, r.targetBlockNum)
+	endOffset, err := calculateEndOffSet(r.ledgerDir, r.ciphers, targetFileNum, r.targetBlockNum)
 	if err != nil {
 		return err
 	}
@@ -207,8 +213,8 @@ func (r *rollbackMgr) rollbackBlockFiles() error {
 	return nil
 }
 
-func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64) (int64, error) {
-	stream, err := newBlockfileStream(ledgerDir, targetBlkFileNum, 0)
+func calculateEndOffSet(ledgerDir string, ciphers *blockfileCiphers, targetBlkFileNum int, blockNum uint64) (int64, error) {
+	stream, err := newBlockfileStream(ledgerDir, ciphers, targetBlkFileNum, 0)
 	if err != nil {
 		return 0, err
 	}
@@ -230,8 +236,8 @@ func calculateEndOffSet(ledgerDir string, targetBlkFileNum int, blockNum uint64)
 }
 
 // ValidateRollbackParams performs necessary validation on the input given for
-// the rollback operation.
-func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64) error {
+// the rollback operation. The encryptor is required if the blockfiles are encrypted
+func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64, encryptor *encryption.Encryptor) error {
 	logger.Infof("Validating the rollback parameters: ledgerID [%s], block number [%d]",
 		ledgerID, targetBlockNum)
 	conf := &Conf{blockStorageDir: blockStorageDir}
@@ -239,7 +245,11 @@ func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uin
 	if err := validateLedgerID(ledgerDir, ledgerID); err != nil {
 		return err
 	}
-	if err := validateTargetBlkNum(ledgerDir, targetBlockNum); err != nil {
+	ciphers, err := loadBlockfileCiphers(ledgerDir, encryptor)
+	if err != nil {
+		return err
+	}
+	if err := validateTargetBlkNum(ledgerDir, ciphers, targetBlockNum); err != nil {
 		return err
 	}
 	return nil
@@ -258,9 +268,9 @@ func validateLedgerID(ledgerDir, ledgerID string) error {
 	return nil
 }
 
-func validateTargetBlkNum(ledgerDir string, targetBlockNum uint64) error {
+func validateTargetBlkNum(ledgerDir string, ciphers *blockfileCiphers, targetBlockNum uint64) error {
 	logger.Debugf("Validating the given block number [%d] against the ledger block height", targetBlockNum)
-	blkfilesInfo, err := constructBlockfilesInfo(ledgerDir)
+	blkfilesInfo, err := constructBlockfilesInfo(ledgerDir, ciphers)
 	if err != nil {
 		return err
 	}
@@ -130,30 +130,81 @@ func (m *BootstrappingSnapshotInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+type ArchivedBlockfileInfo struct {
+	Location             string   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Sha256               []byte   `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArchivedBlockfileInfo) Reset()         { *m = ArchivedBlockfileInfo{} }
+func (m *ArchivedBlockfileInfo) String() string { return proto.CompactTextString(m) }
+func (*ArchivedBlockfileInfo) ProtoMessage()    {}
+func (*ArchivedBlockfileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{2}
+}
+
+func (m *ArchivedBlockfileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArchivedBlockfileInfo.Unmarshal(m, b)
+}
+func (m *ArchivedBlockfileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArchivedBlockfileInfo.Marshal(b, m, deterministic)
+}
+func (m *ArchivedBlockfileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArchivedBlockfileInfo.Merge(m, src)
+}
+func (m *ArchivedBlockfileInfo) XXX_Size() int {
+	return xxx_messageInfo_ArchivedBlockfileInfo.Size(m)
+}
+func (m *ArchivedBlockfileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArchivedBlockfileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArchivedBlockfileInfo proto.InternalMessageInfo
+
+func (m *ArchivedBlockfileInfo) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *ArchivedBlockfileInfo) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TxIDIndexValue)(nil), "msgs.txIDIndexValue")
 	proto.RegisterType((*BootstrappingSnapshotInfo)(nil), "msgs.bootstrappingSnapshotInfo")
+	proto.RegisterType((*ArchivedBlockfileInfo)(nil), "msgs.archivedBlockfileInfo")
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 271 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x41, 0x4b, 0xf3, 0x40,
-	0x10, 0x86, 0xc9, 0xd7, 0x7e, 0x1e, 0xa6, 0xa9, 0xe8, 0x9e, 0xea, 0xc9, 0x1a, 0x3c, 0xf4, 0x50,
-	0x9a, 0x83, 0x20, 0x9e, 0xab, 0x07, 0x03, 0xe2, 0x21, 0x82, 0x07, 0x2f, 0x61, 0x77, 0xb3, 0x4d,
-	0x96, 0x6c, 0x76, 0x96, 0xdd, 0x49, 0x89, 0x57, 0xff, 0x82, 0x7f, 0x58, 0x0c, 0xa1, 0xa5, 0x78,
-	0x9c, 0xe7, 0x7d, 0x60, 0x5e, 0x5e, 0x98, 0x07, 0x42, 0xcf, 0x2b, 0xb5, 0x71, 0x1e, 0x09, 0xd9,
-	0xb4, 0x0d, 0x55, 0x48, 0xbe, 0x22, 0x38, 0xa7, 0x3e, 0x7b, 0xca, 0x6c, 0xa9, 0xfa, 0x77, 0x6e,
-	0x3a, 0xc5, 0x6e, 0x20, 0x16, 0xa6, 0x29, 0x0c, 0x4a, 0x4e, 0x1a, 0xed, 0x22, 0x5a, 0x46, 0xab,
-	0x38, 0x9f, 0x09, 0xd3, 0xbc, 0x8c, 0x88, 0x5d, 0xc3, 0x8c, 0xfa, 0xa3, 0xf1, 0x6f, 0x30, 0x80,
-	0xfa, 0x83, 0xb0, 0x06, 0x46, 0x7d, 0xb1, 0xe7, 0x46, 0x97, 0x03, 0x28, 0x24, 0x96, 0x6a, 0x31,
-	0x59, 0x46, 0xab, 0xff, 0xf9, 0x05, 0xfd, 0x3e, 0x1a, 0x83, 0x47, 0x2c, 0x55, 0xf2, 0x1d, 0xc1,
-	0x95, 0x40, 0xa4, 0x40, 0x9e, 0x3b, 0xa7, 0x6d, 0xf5, 0x66, 0xb9, 0x0b, 0x35, 0x52, 0x66, 0x77,
-	0xc8, 0x12, 0x88, 0x0d, 0x0f, 0xb4, 0x35, 0x28, 0x9b, 0xd7, 0xae, 0x1d, 0xfa, 0x4c, 0xf3, 0x13,
-	0xc6, 0x6e, 0x61, 0x7e, 0xb8, 0x9f, 0x79, 0xa8, 0xc7, 0x4a, 0xa7, 0x90, 0xad, 0xe1, 0xd2, 0x79,
-	0xb5, 0xd7, 0xd8, 0x85, 0xa3, 0x39, 0x19, 0xcc, 0xbf, 0xc1, 0xf6, 0xe1, 0xe3, 0xbe, 0xd2, 0x54,
-	0x77, 0x62, 0x23, 0xb1, 0x4d, 0xeb, 0x4f, 0xa7, 0xbc, 0x51, 0x65, 0xa5, 0x7c, 0xba, 0xe3, 0xc2,
-	0x6b, 0x99, 0x4a, 0x6c, 0x5b, 0xb4, 0xe9, 0x08, 0x85, 0x69, 0xc6, 0x81, 0xc5, 0xd9, 0xb0, 0xf0,
-	0xdd, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x44, 0x15, 0xf9, 0x72, 0x01, 0x00, 0x00,
+	// 305 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0x41, 0x4b, 0xeb, 0x40,
+	0x14, 0x85, 0xc9, 0x6b, 0x5f, 0x79, 0xef, 0xb6, 0x7d, 0x3c, 0x07, 0x94, 0xea, 0xc6, 0x1a, 0x5c,
+	0x74, 0x51, 0x1a, 0x50, 0x2c, 0xae, 0xab, 0x0b, 0x8b, 0xe2, 0x22, 0x42, 0x17, 0x6e, 0xca, 0xcc,
+	0x64, 0x9a, 0x0c, 0x99, 0xe4, 0x86, 0x99, 0x9b, 0x12, 0xb7, 0xfe, 0x05, 0xff, 0xb0, 0x30, 0x84,
+	0x94, 0xe2, 0xf2, 0x7c, 0xf7, 0x5b, 0x1c, 0xee, 0x81, 0xb1, 0x23, 0xb4, 0x3c, 0x55, 0x8b, 0xca,
+	0x22, 0x21, 0xeb, 0x17, 0x2e, 0x75, 0xe1, 0x67, 0x00, 0xff, 0xa8, 0x59, 0x3f, 0xae, 0xcb, 0x44,
+	0x35, 0x1b, 0x6e, 0x6a, 0xc5, 0xae, 0x60, 0x24, 0x4c, 0xbe, 0x35, 0x28, 0x39, 0x69, 0x2c, 0x27,
+	0xc1, 0x34, 0x98, 0x8d, 0xe2, 0xa1, 0x30, 0xf9, 0x4b, 0x8b, 0xd8, 0x25, 0x0c, 0xa9, 0x39, 0x18,
+	0xbf, 0xbc, 0x01, 0xd4, 0x74, 0xc2, 0x1c, 0x18, 0x35, 0xdb, 0x3d, 0x37, 0x3a, 0xf1, 0x60, 0x2b,
+	0x31, 0x51, 0x93, 0xde, 0x34, 0x98, 0xfd, 0x8e, 0xff, 0x53, 0xb3, 0xe9, 0x0e, 0x0f, 0x98, 0xa8,
+	0xf0, 0x2b, 0x80, 0x73, 0x81, 0x48, 0x8e, 0x2c, 0xaf, 0x2a, 0x5d, 0xa6, 0x6f, 0x25, 0xaf, 0x5c,
+	0x86, 0xb4, 0x2e, 0x77, 0xc8, 0x42, 0x18, 0x19, 0xee, 0x68, 0x65, 0x50, 0xe6, 0xaf, 0x75, 0xe1,
+	0xfb, 0xf4, 0xe3, 0x23, 0xc6, 0xae, 0x61, 0xdc, 0xe5, 0x27, 0xee, 0xb2, 0xb6, 0xd2, 0x31, 0x64,
+	0x73, 0x38, 0xa9, 0xac, 0xda, 0x6b, 0xac, 0xdd, 0xc1, 0xec, 0x79, 0xf3, 0xe7, 0x21, 0x7c, 0x86,
+	0x53, 0x6e, 0x65, 0xa6, 0xf7, 0x2a, 0xf1, 0x70, 0xa7, 0x8d, 0xf2, 0x85, 0x2e, 0xe0, 0xcf, 0xd1,
+	0x73, 0xfe, 0xc6, 0x5d, 0x66, 0x67, 0x30, 0x70, 0x19, 0xbf, 0xb9, 0x5b, 0xb6, 0x0d, 0xda, 0xb4,
+	0xba, 0x7f, 0x5f, 0xa6, 0x9a, 0xb2, 0x5a, 0x2c, 0x24, 0x16, 0x51, 0xf6, 0x51, 0x29, 0x6b, 0x54,
+	0x92, 0x2a, 0x1b, 0xed, 0xb8, 0xb0, 0x5a, 0x46, 0x12, 0x8b, 0x02, 0xcb, 0xa8, 0x85, 0xc2, 0xe4,
+	0xed, 0x5a, 0x62, 0xe0, 0xe7, 0xba, 0xfd, 0x1e, 0x00, 0xcb, 0xa7, 0x9c, 0x78, 0xbf, 0x01, 0x00,
+	0x00,
 }
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+)
+
+const (
+	// formatVersion is the first byte of an encrypted value
+	formatVersion = byte(1)
+	// NonceSize is the size of the nonce of a Stream
+	NonceSize = aes.BlockSize
+	// chunkSize is the number of bytes of the key stream that a Stream derives with a single invocation of the BCCSP
+	chunkSize = 4096
+)
+
+// Encryptor encrypts the ledger data at rest with AES keys that are held by a BCCSP and are identified by their SKIs.
+// The data is always encrypted with the active key. The retired keys are used only for decrypting the data that was
+// encrypted before a key rotation and that has not been re-encrypted with the active key yet
+type Encryptor struct {
+	csp         bccsp.BCCSP
+	activeKeyID []byte
+	keys        map[string]bccsp.Key
+}
+
+// NewEncryptor constructs an Encryptor that encrypts with the key identified by activeKeyID and
+// decrypts with any of the keys identified by activeKeyID and retiredKeyIDs
+func NewEncryptor(csp bccsp.BCCSP, activeKeyID []byte, retiredKeyIDs [][]byte) (*Encryptor, error) {
+	if csp == nil {
+		return nil, errors.New("a crypto provider is required for the ledger encryption")
+	}
+	if len(activeKeyID) == 0 {
+		return nil, errors.New("an active key is required for the ledger encryption")
+	}
+	e := &Encryptor{
+		csp:         csp,
+		activeKeyID: activeKeyID,
+		keys:        map[string]bccsp.Key{},
+	}
+	for _, keyID := range append([][]byte{activeKeyID}, retiredKeyIDs...) {
+		if len(keyID) > 255 {
+			return nil, errors.Errorf("key ID [%x] exceeds 255 bytes", keyID)
+		}
+		key, err := csp.GetKey(keyID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error retrieving the ledger encryption key [%x]", keyID)
+		}
+		if !key.Symmetric() || !key.Private() {
+			return nil, errors.Errorf("the ledger encryption key [%x] is not a symmetric key", keyID)
+		}
+		e.keys[string(keyID)] = key
+	}
+	return e, nil
+}
+
+// ActiveKeyID returns the ID of the key that is used for encrypting the data
+func (e *Encryptor) ActiveKeyID() []byte {
+	return e.activeKeyID
+}
+
+// Encrypt encrypts the plaintext with the active key. The returned ciphertext carries the ID of
+// the key so that it can be decrypted after a key rotation
+func (e *Encryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	ciphertext, err := e.csp.Encrypt(e.keys[string(e.activeKeyID)], plaintext, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error encrypting ledger data")
+	}
+	encrypted := make([]byte, 0, 2+len(e.activeKeyID)+len(ciphertext))
+	encrypted = append(encrypted, formatVersion, byte(len(e.activeKeyID)))
+	encrypted = append(encrypted, e.activeKeyID...)
+	return append(encrypted, ciphertext...), nil
+}
+
+// Decrypt decrypts a ciphertext produced by the function Encrypt with any of the keys of the encryptor
+func (e *Encryptor) Decrypt(encrypted []byte) ([]byte, error) {
+	keyID, ciphertext, err := splitEncrypted(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := e.keys[string(keyID)]
+	if !ok {
+		return nil, errors.Errorf("ledger data is encrypted with the unknown key [%x]", keyID)
+	}
+	plaintext, err := e.csp.Decrypt(key, ciphertext, &bccsp.AESCBCPKCS7ModeOpts{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error decrypting ledger data")
+	}
+	return plaintext, nil
+}
+
+// EncryptedWithActiveKey returns true if the ciphertext produced by the function Encrypt is
+// encrypted with the active key
+func (e *Encryptor) EncryptedWithActiveKey(encrypted []byte) bool {
+	keyID, _, err := splitEncrypted(encrypted)
+	return err == nil && string(keyID) == string(e.activeKeyID)
+}
+
+func splitEncrypted(encrypted []byte) ([]byte, []byte, error) {
+	if len(encrypted) < 2 || encrypted[0] != formatVersion {
+		return nil, nil, errors.New("ledger data is not in the encrypted format")
+	}
+	keyIDLen := int(encrypted[1])
+	if len(encrypted) < 2+keyIDLen {
+		return nil, nil, errors.New("ledger data is not in the encrypted format")
+	}
+	return encrypted[2 : 2+keyIDLen], encrypted[2+keyIDLen:], nil
+}
+
+// NewNonce returns a random nonce for a Stream
+func NewNonce() ([]byte, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "error generating nonce")
+	}
+	return nonce, nil
+}
+
+// NewStream returns a Stream that derives its key stream from the supplied key and nonce.
+// A nonce must never be used for encrypting different data at the same offset
+func (e *Encryptor) NewStream(keyID, nonce []byte) (*Stream, error) {
+	key, ok := e.keys[string(keyID)]
+	if !ok {
+		return nil, errors.Errorf("ledger data is encrypted with the unknown key [%x]", keyID)
+	}
+	if len(nonce) != NonceSize {
+		return nil, errors.Errorf("invalid nonce size [%d], expected [%d]", len(nonce), NonceSize)
+	}
+	return &Stream{
+		csp:        e.csp,
+		key:        key,
+		nonce:      nonce,
+		cachedNum:  -1,
+		zeroChunks: make([]byte, chunkSize),
+	}, nil
+}
+
+// Stream is a stream cipher that allows to encrypt and decrypt the data starting at any offset, which is
+// required for the files that are appended to and read at random offsets, such as the blockfiles.
+// The key stream is split in chunks. The key stream of a chunk is the AES-OFB key stream for an IV derived
+// from the nonce and the chunk number, which is computed by encrypting zeros with AES-CBC through the BCCSP.
+// A Stream is not safe for concurrent use
+type Stream struct {
+	csp        bccsp.BCCSP
+	key        bccsp.Key
+	nonce      []byte
+	cachedNum  int64
+	cached     []byte
+	zeroChunks []byte
+}
+
+// XORKeyStream XORs src with the key stream starting at the given offset and stores the result in dst.
+// The same operation encrypts the plaintext and decrypts the ciphertext. dst and src may overlap entirely
+func (s *Stream) XORKeyStream(dst, src []byte, offset int64) error {
+	if len(dst) < len(src) {
+		return errors.New("output smaller than input")
+	}
+	for i := 0; i < len(src); {
+		chunkNum, chunkOffset := (offset+int64(i))/chunkSize, int((offset+int64(i))%chunkSize)
+		keyStream, err := s.chunkKeyStream(chunkNum)
+		if err != nil {
+			return err
+		}
+		n := len(src) - i
+		if n > chunkSize-chunkOffset {
+			n = chunkSize - chunkOffset
+		}
+		for j := 0; j < n; j++ {
+			dst[i+j] = src[i+j] ^ keyStream[chunkOffset+j]
+		}
+		i += n
+	}
+	return nil
+}
+
+func (s *Stream) chunkKeyStream(chunkNum int64) ([]byte, error) {
+	if chunkNum == s.cachedNum {
+		return s.cached, nil
+	}
+	iv := make([]byte, NonceSize)
+	copy(iv, s.nonce)
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], uint64(chunkNum))
+	for i := range num {
+		iv[NonceSize-8+i] ^= num[i]
+	}
+	// the output is the IV followed by the ciphertext, which is the key stream followed by a padding block
+	out, err := s.csp.Encrypt(s.key, s.zeroChunks, &bccsp.AESCBCPKCS7ModeOpts{IV: iv})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error deriving the key stream")
+	}
+	if len(out) < aes.BlockSize+chunkSize {
+		return nil, errors.Errorf("unexpected size [%d] of the key stream", len(out))
+	}
+	s.cachedNum, s.cached = chunkNum, out[aes.BlockSize:aes.BlockSize+chunkSize]
+	return s.cached, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encryption_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/encryption/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	csp, cleanup := testutil.NewCryptoProvider(t)
+	defer cleanup()
+	oldKeyID, newKeyID := testutil.GenerateKey(t, csp), testutil.GenerateKey(t, csp)
+
+	oldEncryptor := testutil.NewEncryptor(t, csp, oldKeyID)
+	encryptedWithOldKey, err := oldEncryptor.Encrypt([]byte("value1"))
+	require.NoError(t, err)
+	require.NotContains(t, string(encryptedWithOldKey), "value1")
+	require.True(t, oldEncryptor.EncryptedWithActiveKey(encryptedWithOldKey))
+
+	// after the rotation, the data encrypted with the old key remains readable
+	newEncryptor := testutil.NewEncryptor(t, csp, newKeyID, oldKeyID)
+	require.Equal(t, newKeyID, newEncryptor.ActiveKeyID())
+	require.False(t, newEncryptor.EncryptedWithActiveKey(encryptedWithOldKey))
+	plaintext, err := newEncryptor.Decrypt(encryptedWithOldKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), plaintext)
+
+	encryptedWithNewKey, err := newEncryptor.Encrypt([]byte("value2"))
+	require.NoError(t, err)
+	require.True(t, newEncryptor.EncryptedWithActiveKey(encryptedWithNewKey))
+	_, err = oldEncryptor.Decrypt(encryptedWithNewKey)
+	require.EqualError(t, err, "ledger data is encrypted with the unknown key ["+hex.EncodeToString(newKeyID)+"]")
+
+	_, err = newEncryptor.Decrypt([]byte("value3"))
+	require.EqualError(t, err, "ledger data is not in the encrypted format")
+}
+
+func TestNewEncryptorErrors(t *testing.T) {
+	csp, cleanup := testutil.NewCryptoProvider(t)
+	defer cleanup()
+
+	_, err := encryption.NewEncryptor(nil, []byte("key1"), nil)
+	require.EqualError(t, err, "a crypto provider is required for the ledger encryption")
+
+	_, err = encryption.NewEncryptor(csp, nil, nil)
+	require.EqualError(t, err, "an active key is required for the ledger encryption")
+
+	_, err = encryption.NewEncryptor(csp, []byte("unknown-key"), nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error retrieving the ledger encryption key")
+
+	ecdsaKey, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	_, err = encryption.NewEncryptor(csp, ecdsaKey.SKI(), nil)
+	require.EqualError(t, err, "the ledger encryption key ["+hex.EncodeToString(ecdsaKey.SKI())+"] is not a symmetric key")
+}
+
+func TestStream(t *testing.T) {
+	csp, cleanup := testutil.NewCryptoProvider(t)
+	defer cleanup()
+	e := testutil.NewEncryptor(t, csp, testutil.GenerateKey(t, csp))
+	nonce, err := encryption.NewNonce()
+	require.NoError(t, err)
+
+	plaintext := make([]byte, 10000)
+	_, err = rand.Read(plaintext)
+	require.NoError(t, err)
+
+	s, err := e.NewStream(e.ActiveKeyID(), nonce)
+	require.NoError(t, err)
+	ciphertext := make([]byte, len(plaintext))
+	require.NoError(t, s.XORKeyStream(ciphertext, plaintext, 100))
+	require.False(t, bytes.Equal(plaintext, ciphertext))
+
+	// any portion of the ciphertext can be decrypted independently
+	for _, r := range [][2]int{{0, 10000}, {0, 1}, {3990, 4010}, {5000, 9000}, {9999, 10000}} {
+		s, err := e.NewStream(e.ActiveKeyID(), nonce)
+		require.NoError(t, err)
+		decrypted := make([]byte, r[1]-r[0])
+		require.NoError(t, s.XORKeyStream(decrypted, ciphertext[r[0]:r[1]], int64(100+r[0])))
+		require.Equal(t, plaintext[r[0]:r[1]], decrypted)
+	}
+
+	// a different nonce yields a different key stream
+	otherNonce, err := encryption.NewNonce()
+	require.NoError(t, err)
+	s, err = e.NewStream(e.ActiveKeyID(), otherNonce)
+	require.NoError(t, err)
+	otherCiphertext := make([]byte, len(plaintext))
+	require.NoError(t, s.XORKeyStream(otherCiphertext, plaintext, 100))
+	require.False(t, bytes.Equal(ciphertext, otherCiphertext))
+
+	_, err = e.NewStream([]byte("unknown-key"), nonce)
+	require.EqualError(t, err, "ledger data is encrypted with the unknown key [756e6b6e6f776e2d6b6579]")
+	_, err = e.NewStream(e.ActiveKeyID(), []byte("short"))
+	require.EqualError(t, err, "invalid nonce size [5], expected [16]")
+}
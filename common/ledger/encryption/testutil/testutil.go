@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+// NewCryptoProvider returns a software BCCSP that keeps its keys in a temporary directory,
+// which is removed by the returned cleanup function
+func NewCryptoProvider(t testing.TB) (bccsp.BCCSP, func()) {
+	keystoreDir, err := ioutil.TempDir("", "ledger-encryption-keystore")
+	require.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, keystoreDir, false)
+	require.NoError(t, err)
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(ks)
+	require.NoError(t, err)
+	return csp, func() { os.RemoveAll(keystoreDir) }
+}
+
+// GenerateKey generates an AES key in the keystore of the crypto provider and returns its ID
+func GenerateKey(t testing.TB, csp bccsp.BCCSP) []byte {
+	key, err := csp.KeyGen(&bccsp.AES256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	return key.SKI()
+}
+
+// NewEncryptor returns an encryptor that encrypts with the key identified by activeKeyID
+func NewEncryptor(t testing.TB, csp bccsp.BCCSP, activeKeyID []byte, retiredKeyIDs ...[]byte) *encryption.Encryptor {
+	e, err := encryption.NewEncryptor(csp, activeKeyID, retiredKeyIDs)
+	require.NoError(t, err)
+	return e
+}
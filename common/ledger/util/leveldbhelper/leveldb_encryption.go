@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+var (
+	// encryptionStateEncrypted indicates that all the values in the db are encrypted
+	encryptionStateEncrypted = []byte("encrypted")
+	// encryptionStateEncrypting indicates that an offline encryption of the values in clear is in progress
+	encryptionStateEncrypting = []byte("encrypting")
+)
+
+// checkEncryption checks that the values in the db are encrypted if, and only if, an encryptor is supplied.
+// A new db is marked as encrypted if an encryptor is supplied
+func checkEncryption(internalDB *DBHandle, dbEmpty bool, conf *Conf) error {
+	if dbEmpty {
+		if conf.Encryptor == nil {
+			return nil
+		}
+		return internalDB.Put(encryptionKey, encryptionStateEncrypted, true)
+	}
+	state, err := internalDB.Get(encryptionKey)
+	if err != nil {
+		return err
+	}
+	switch {
+	case bytes.Equal(state, encryptionStateEncrypting):
+		return errors.Errorf("the encryption of the leveldb at [%s] is incomplete, the encryption needs to be resumed", conf.DBPath)
+	case state != nil && conf.Encryptor == nil:
+		return errors.Errorf("the leveldb at [%s] is encrypted but no encryption is configured", conf.DBPath)
+	case state == nil && conf.Encryptor != nil:
+		return errors.Errorf("the leveldb at [%s] is not encrypted, it needs to be encrypted before the encryption is configured", conf.DBPath)
+	}
+	return nil
+}
+
+// Reencrypt encrypts all the values in the leveldb at dbPath with the active key of the encryptor. The values that are
+// encrypted with a retired key of the encryptor are re-encrypted, whereas the values in clear are encrypted.
+// This function is intended to be invoked offline, while the db is not opened by any other provider. In case of a
+// failure, this function can be invoked again to resume the encryption
+func Reencrypt(dbPath string, encryptor *encryption.Encryptor) error {
+	if encryptor == nil {
+		return errors.New("an encryptor is required for encrypting the leveldb")
+	}
+	db := CreateDB(&Conf{DBPath: dbPath})
+	db.Open()
+	defer db.Close()
+
+	dbEmpty, err := db.IsEmpty()
+	if err != nil || dbEmpty {
+		// an empty db is marked as encrypted when opened with the encryptor
+		return err
+	}
+	internalDB := &DBHandle{db: db, dbName: internalDBName}
+	state, err := internalDB.Get(encryptionKey)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		if err := internalDB.Put(encryptionKey, encryptionStateEncrypting, true); err != nil {
+			return err
+		}
+		state = encryptionStateEncrypting
+	}
+	encrypting := bytes.Equal(state, encryptionStateEncrypting)
+	internalDBPrefix := constructLevelKey(internalDBName, nil)
+
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	numValues := 0
+	batchSize := 0
+	batch := &leveldb.Batch{}
+	for itr.Next() {
+		key, value := itr.Key(), itr.Value()
+		if bytes.HasPrefix(key, internalDBPrefix) || encryptor.EncryptedWithActiveKey(value) {
+			continue
+		}
+		plaintext, err := encryptor.Decrypt(value)
+		if err != nil {
+			if !encrypting {
+				return errors.WithMessagef(err, "error decrypting the value of the key [%#v] in the leveldb at [%s]", key, dbPath)
+			}
+			// the values in clear are encrypted only while encrypting a db for the first time
+			plaintext = value
+		}
+		encrypted, err := encryptor.Encrypt(plaintext)
+		if err != nil {
+			return err
+		}
+		batch.Put(append([]byte{}, key...), encrypted)
+		numValues++
+		batchSize += len(key) + len(encrypted)
+		if batchSize >= maxBatchSize {
+			if err := db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batchSize = 0
+			batch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while iterating the leveldb at [%s]", dbPath)
+	}
+	if err := db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Infof("Encrypted [%d] values with the key [%x] in the leveldb at [%s]", numValues, encryptor.ActiveKeyID(), dbPath)
+	return internalDB.Put(encryptionKey, encryptionStateEncrypted, true)
+}
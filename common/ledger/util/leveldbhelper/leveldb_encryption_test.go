@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/encryption/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedValues(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "leveldbhelper-encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	csp, cleanup := testutil.NewCryptoProvider(t)
+	defer cleanup()
+	oldKeyID, newKeyID := testutil.GenerateKey(t, csp), testutil.GenerateKey(t, csp)
+
+	// populate a db in clear
+	p, err := NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0"})
+	require.NoError(t, err)
+	populateTestDB(t, p.GetDBHandle("db1"), 0, 5)
+	p.Close()
+
+	_, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0", Encryptor: testutil.NewEncryptor(t, csp, oldKeyID)})
+	require.EqualError(t, err, fmt.Sprintf("the leveldb at [%s] is not encrypted, it needs to be encrypted before the encryption is configured", testDir))
+
+	// encrypt the db and populate more data
+	oldEncryptor := testutil.NewEncryptor(t, csp, oldKeyID)
+	require.NoError(t, Reencrypt(testDir, oldEncryptor))
+	requireValuesEncryptedWith(t, testDir, oldEncryptor)
+	p, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0", Encryptor: oldEncryptor})
+	require.NoError(t, err)
+	populateTestDB(t, p.GetDBHandle("db1"), 5, 10)
+	verifyTestDB(t, p.GetDBHandle("db1"), 10)
+	p.Close()
+	requireValuesEncryptedWith(t, testDir, oldEncryptor)
+
+	_, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0"})
+	require.EqualError(t, err, fmt.Sprintf("the leveldb at [%s] is encrypted but no encryption is configured", testDir))
+
+	// after rotating the key, the values encrypted with either key are readable
+	newEncryptor := testutil.NewEncryptor(t, csp, newKeyID, oldKeyID)
+	p, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0", Encryptor: newEncryptor})
+	require.NoError(t, err)
+	populateTestDB(t, p.GetDBHandle("db1"), 10, 15)
+	verifyTestDB(t, p.GetDBHandle("db1"), 15)
+	p.Close()
+
+	// the values encrypted with the retired key are not readable without it
+	newKeyOnlyEncryptor := testutil.NewEncryptor(t, csp, newKeyID)
+	p, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0", Encryptor: newKeyOnlyEncryptor})
+	require.NoError(t, err)
+	_, err = p.GetDBHandle("db1").Get([]byte("key-0"))
+	require.Error(t, err)
+	itr, err := p.GetDBHandle("db1").GetIterator(nil, nil)
+	require.NoError(t, err)
+	require.True(t, itr.Next())
+	require.Nil(t, itr.Value())
+	require.Error(t, itr.Error())
+	itr.Release()
+	p.Close()
+
+	// once re-encrypted, the retired key is not needed anymore
+	require.NoError(t, Reencrypt(testDir, newEncryptor))
+	requireValuesEncryptedWith(t, testDir, newKeyOnlyEncryptor)
+	p, err = NewProvider(&Conf{DBPath: testDir, ExpectedFormat: "2.0", Encryptor: newKeyOnlyEncryptor})
+	require.NoError(t, err)
+	verifyTestDB(t, p.GetDBHandle("db1"), 15)
+	format, err := p.GetDataFormat()
+	require.NoError(t, err)
+	require.Equal(t, "2.0", format)
+	p.Close()
+}
+
+func TestEncryptedBatch(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "leveldbhelper-encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	csp, cleanup := testutil.NewCryptoProvider(t)
+	defer cleanup()
+	encryptor := testutil.NewEncryptor(t, csp, testutil.GenerateKey(t, csp))
+
+	p, err := NewProvider(&Conf{DBPath: testDir, Encryptor: encryptor})
+	require.NoError(t, err)
+	h := p.GetDBHandle("db1")
+	batch := h.NewUpdateBatch()
+	for i := 0; i < 5; i++ {
+		batch.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i)))
+	}
+	require.NoError(t, h.WriteBatch(batch, true))
+	verifyTestDB(t, h, 5)
+
+	itr, err := h.GetIterator([]byte("key-2"), []byte("key-4"))
+	require.NoError(t, err)
+	var values []string
+	for itr.Next() {
+		values = append(values, string(itr.Value()))
+	}
+	require.NoError(t, itr.Error())
+	itr.Release()
+	require.Equal(t, []string{"value-2", "value-3"}, values)
+	p.Close()
+	requireValuesEncryptedWith(t, testDir, encryptor)
+}
+
+func populateTestDB(t *testing.T, h *DBHandle, start, end int) {
+	for i := start; i < end; i++ {
+		require.NoError(t, h.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i)), true))
+	}
+}
+
+func verifyTestDB(t *testing.T, h *DBHandle, numKeys int) {
+	for i := 0; i < numKeys; i++ {
+		v, err := h.Get([]byte(fmt.Sprintf("key-%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value-%d", i)), v)
+	}
+}
+
+// requireValuesEncryptedWith checks that all the values of the logical dbs, excluding the internal db, are
+// encrypted with the active key of the encryptor
+func requireValuesEncryptedWith(t *testing.T, dbPath string, encryptor *encryption.Encryptor) {
+	db := CreateDB(&Conf{DBPath: dbPath})
+	db.Open()
+	defer db.Close()
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	numValues := 0
+	for itr.Next() {
+		if retrieveDBName(itr.Key()) == internalDBName {
+			continue
+		}
+		require.True(t, encryptor.EncryptedWithActiveKey(itr.Value()), "value of key [%s]", itr.Key())
+		numValues++
+	}
+	require.NoError(t, itr.Error())
+	require.NotZero(t, numValues)
+}
+
+func retrieveDBName(levelKey []byte) string {
+	return string(bytes.SplitN(levelKey, dbNameKeySep, 2)[0])
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -30,6 +31,7 @@ var (
 	dbNameKeySep     = []byte{0x00}
 	lastKeyIndicator = byte(0x01)
 	formatVersionKey = []byte{'f'} // a single key in db whose value indicates the version of the data format
+	encryptionKey    = []byte{'e'} // a single key in db whose value indicates whether the values are encrypted
 )
 
 // closeFunc closes the db handle
@@ -45,11 +47,13 @@ type closeFunc func()
 type Conf struct {
 	DBPath         string
 	ExpectedFormat string
+	Encryptor      *encryption.Encryptor
 }
 
 // Provider enables to use a single leveldb as multiple logical leveldbs
 type Provider struct {
-	db *DB
+	db        *DB
+	encryptor *encryption.Encryptor
 
 	mux       sync.Mutex
 	dbHandles map[string]*DBHandle
@@ -63,6 +67,7 @@ func NewProvider(conf *Conf) (*Provider, error) {
 	}
 	return &Provider{
 		db:        db,
+		encryptor: conf.Encryptor,
 		dbHandles: make(map[string]*DBHandle),
 	}, nil
 }
@@ -87,6 +92,10 @@ func openDBAndCheckFormat(conf *Conf) (d *DB, e error) {
 		return nil, err
 	}
 
+	if err := checkEncryption(internalDB, dbEmpty, conf); err != nil {
+		return nil, err
+	}
+
 	if dbEmpty && conf.ExpectedFormat != "" {
 		logger.Infof("DB is empty Setting db format as %s", conf.ExpectedFormat)
 		if err := internalDB.Put(formatVersionKey, []byte(conf.ExpectedFormat), true); err != nil {
@@ -131,7 +140,11 @@ func (p *Provider) GetDBHandle(dbName string) *DBHandle {
 			defer p.mux.Unlock()
 			delete(p.dbHandles, dbName)
 		}
-		dbHandle = &DBHandle{dbName, p.db, closeFunc}
+		var encryptor *encryption.Encryptor
+		if dbName != internalDBName {
+			encryptor = p.encryptor
+		}
+		dbHandle = &DBHandle{dbName, p.db, closeFunc, encryptor}
 		p.dbHandles[dbName] = dbHandle
 	}
 	return dbHandle
@@ -154,15 +167,26 @@ type DBHandle struct {
 	dbName    string
 	db        *DB
 	closeFunc closeFunc
+	encryptor *encryption.Encryptor
 }
 
 // Get returns the value for the given key
 func (h *DBHandle) Get(key []byte) ([]byte, error) {
-	return h.db.Get(constructLevelKey(h.dbName, key))
+	value, err := h.db.Get(constructLevelKey(h.dbName, key))
+	if err != nil || value == nil || h.encryptor == nil {
+		return value, err
+	}
+	return h.encryptor.Decrypt(value)
 }
 
 // Put saves the key/value
 func (h *DBHandle) Put(key []byte, value []byte, sync bool) error {
+	if h.encryptor != nil {
+		var err error
+		if value, err = h.encryptor.Encrypt(value); err != nil {
+			return err
+		}
+	}
 	return h.db.Put(constructLevelKey(h.dbName, key), value, sync)
 }
 
@@ -229,14 +253,21 @@ func (h *DBHandle) IsEmpty() (bool, error) {
 // NewUpdateBatch returns a new UpdateBatch that can be used to update the db
 func (h *DBHandle) NewUpdateBatch() *UpdateBatch {
 	return &UpdateBatch{
-		dbName: h.dbName,
-		Batch:  &leveldb.Batch{},
+		dbName:    h.dbName,
+		Batch:     &leveldb.Batch{},
+		encryptor: h.encryptor,
 	}
 }
 
 // WriteBatch writes a batch in an atomic way
 func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
-	if batch == nil || batch.Len() == 0 {
+	if batch == nil {
+		return nil
+	}
+	if batch.err != nil {
+		return batch.err
+	}
+	if batch.Len() == 0 {
 		return nil
 	}
 	if err := h.db.WriteBatch(batch.Batch, sync); err != nil {
@@ -261,7 +292,7 @@ func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) (*Iterator, error
 		itr.Release()
 		return nil, errors.Wrapf(err, "internal leveldb error while obtaining db iterator")
 	}
-	return &Iterator{h.dbName, itr}, nil
+	return &Iterator{dbName: h.dbName, Iterator: itr, encryptor: h.encryptor}, nil
 }
 
 // Close closes the DBHandle after its db data have been deleted
@@ -274,7 +305,9 @@ func (h *DBHandle) Close() {
 // UpdateBatch encloses the details of multiple `updates`
 type UpdateBatch struct {
 	*leveldb.Batch
-	dbName string
+	dbName    string
+	encryptor *encryption.Encryptor
+	err       error
 }
 
 // Put adds a KV
@@ -282,9 +315,24 @@ func (b *UpdateBatch) Put(key []byte, value []byte) {
 	if value == nil {
 		panic("Nil value not allowed")
 	}
+	if b.encryptor != nil {
+		var err error
+		if value, err = b.encryptor.Encrypt(value); err != nil {
+			if b.err == nil {
+				b.err = err
+			}
+			return
+		}
+	}
 	b.Batch.Put(constructLevelKey(b.dbName, key), value)
 }
 
+// Reset resets the batch
+func (b *UpdateBatch) Reset() {
+	b.Batch.Reset()
+	b.err = nil
+}
+
 // Delete deletes a Key and associated value
 func (b *UpdateBatch) Delete(key []byte) {
 	b.Batch.Delete(constructLevelKey(b.dbName, key))
@@ -294,6 +342,8 @@ func (b *UpdateBatch) Delete(key []byte) {
 type Iterator struct {
 	dbName string
 	iterator.Iterator
+	encryptor *encryption.Encryptor
+	err       error
 }
 
 // Key wraps actual leveldb iterator method
@@ -301,6 +351,29 @@ func (itr *Iterator) Key() []byte {
 	return retrieveAppKey(itr.Iterator.Key())
 }
 
+// Value wraps actual leveldb iterator method. If the value cannot be decrypted,
+// nil is returned and the error is returned by the function Error
+func (itr *Iterator) Value() []byte {
+	value := itr.Iterator.Value()
+	if itr.encryptor == nil || value == nil {
+		return value
+	}
+	plaintext, err := itr.encryptor.Decrypt(value)
+	if err != nil {
+		itr.err = errors.WithMessagef(err, "error decrypting the value of the key [%#v]", itr.Key())
+		return nil
+	}
+	return plaintext
+}
+
+// Error wraps actual leveldb iterator method
+func (itr *Iterator) Error() error {
+	if itr.err != nil {
+		return itr.err
+	}
+	return itr.Iterator.Error()
+}
+
 // Seek moves the iterator to the first key/value pair
 // whose key is greater than or equal to the given key.
 // It returns whether such pair exist.
@@ -52,6 +52,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincode] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_GetInstalledChaincodePackage] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincodes] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Lifecycle_UninstallChaincode] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_ApproveChaincodeDefinitionForMyOrg] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryApprovedChaincodeDefinition] = mgmt.Admins
 
@@ -87,6 +88,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Cscc_JoinChain] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Cscc_JoinChainBySnapshot] = mgmt.Admins
 	d.pResourcePolicyMap[resources.Cscc_GetChannels] = mgmt.Members
 
 	//c resources
@@ -101,6 +103,14 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Event_Block] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Event_FilteredBlock] = CHANNELREADERS
 
+	//Snapshot resources
+	d.pResourcePolicyMap[resources.Snapshot_submitrequest] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = mgmt.Admins
+	d.pResourcePolicyMap[resources.Snapshot_listpending] = mgmt.Admins
+
+	//Gateway resources
+	d.cResourcePolicyMap[resources.Gateway_CommitStatus] = CHANNELREADERS
+
 	return d
 }
 
@@ -131,11 +141,20 @@ func (d *defaultACLProviderImpl) CheckACL(resName string, channelID string, idin
 		if err != nil {
 			return err
 		}
-		return d.policyChecker.CheckPolicyBySignedData(channelID, policy, sd)
+		return d.checkPolicyBySignedData(channelID, policy, sd)
 	case []*protoutil.SignedData:
-		return d.policyChecker.CheckPolicyBySignedData(channelID, policy, typedData)
+		return d.checkPolicyBySignedData(channelID, policy, typedData)
 	default:
 		aclLogger.Errorf("Unmapped id on checkACL %s", resName)
 		return fmt.Errorf("Unknown id on checkACL %s", resName)
 	}
 }
+
+// checkPolicyBySignedData checks the signed data against the policy of the
+// channel, or against the local MSP for p type policies
+func (d *defaultACLProviderImpl) checkPolicyBySignedData(channelID, policy string, sd []*protoutil.SignedData) error {
+	if channelID == "" {
+		return d.policyChecker.CheckPolicyNoChannelBySignedData(policy, sd)
+	}
+	return d.policyChecker.CheckPolicyBySignedData(channelID, policy, sd)
+}
@@ -0,0 +1,106 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+)
+
+type PolicyMapper struct {
+	PolicyRefForAPIStub        func(string) string
+	policyRefForAPIMutex       sync.RWMutex
+	policyRefForAPIArgsForCall []struct {
+		arg1 string
+	}
+	policyRefForAPIReturns struct {
+		result1 string
+	}
+	policyRefForAPIReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PolicyMapper) PolicyRefForAPI(arg1 string) string {
+	fake.policyRefForAPIMutex.Lock()
+	ret, specificReturn := fake.policyRefForAPIReturnsOnCall[len(fake.policyRefForAPIArgsForCall)]
+	fake.policyRefForAPIArgsForCall = append(fake.policyRefForAPIArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("PolicyRefForAPI", []interface{}{arg1})
+	fake.policyRefForAPIMutex.Unlock()
+	if fake.PolicyRefForAPIStub != nil {
+		return fake.PolicyRefForAPIStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.policyRefForAPIReturns
+	return fakeReturns.result1
+}
+
+func (fake *PolicyMapper) PolicyRefForAPICallCount() int {
+	fake.policyRefForAPIMutex.RLock()
+	defer fake.policyRefForAPIMutex.RUnlock()
+	return len(fake.policyRefForAPIArgsForCall)
+}
+
+func (fake *PolicyMapper) PolicyRefForAPICalls(stub func(string) string) {
+	fake.policyRefForAPIMutex.Lock()
+	defer fake.policyRefForAPIMutex.Unlock()
+	fake.PolicyRefForAPIStub = stub
+}
+
+func (fake *PolicyMapper) PolicyRefForAPIArgsForCall(i int) string {
+	fake.policyRefForAPIMutex.RLock()
+	defer fake.policyRefForAPIMutex.RUnlock()
+	argsForCall := fake.policyRefForAPIArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PolicyMapper) PolicyRefForAPIReturns(result1 string) {
+	fake.policyRefForAPIMutex.Lock()
+	defer fake.policyRefForAPIMutex.Unlock()
+	fake.PolicyRefForAPIStub = nil
+	fake.policyRefForAPIReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *PolicyMapper) PolicyRefForAPIReturnsOnCall(i int, result1 string) {
+	fake.policyRefForAPIMutex.Lock()
+	defer fake.policyRefForAPIMutex.Unlock()
+	fake.PolicyRefForAPIStub = nil
+	if fake.policyRefForAPIReturnsOnCall == nil {
+		fake.policyRefForAPIReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.policyRefForAPIReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *PolicyMapper) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.policyRefForAPIMutex.RLock()
+	defer fake.policyRefForAPIMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PolicyMapper) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -14,10 +14,16 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,6 +61,42 @@ type signerSerializer interface {
 
 //go:generate counterfeiter -o mocks/defaultaclprovider.go --fake-name DefaultACLProvider . defaultACLProvider
 
+//go:generate counterfeiter -o mocks/resources.go --fake-name Resources . channelResources
+
+type channelResources interface {
+	channelconfig.Resources
+}
+
+//go:generate counterfeiter -o mocks/application.go --fake-name Application . application
+
+type application interface {
+	channelconfig.Application
+}
+
+//go:generate counterfeiter -o mocks/policy_mapper.go --fake-name PolicyMapper . policyMapper
+
+type policyMapper interface {
+	channelconfig.PolicyMapper
+}
+
+//go:generate counterfeiter -o mocks/policy_manager.go --fake-name PolicyManager . policyManager
+
+type policyManager interface {
+	policies.Manager
+}
+
+//go:generate counterfeiter -o mocks/policy.go --fake-name Policy . channelPolicy
+
+type channelPolicy interface {
+	policies.Policy
+}
+
+//go:generate counterfeiter -o mocks/policy_checker.go --fake-name PolicyChecker . policyChecker
+
+type policyChecker interface {
+	policy.PolicyChecker
+}
+
 func TestPolicyBase(t *testing.T) {
 	peval := &mockPolicyEvaluatorImpl{pmap: map[string]string{"res": "pol"}, peval: map[string]error{"pol": nil}}
 	pprov := newPolicyProvider(peval)
@@ -107,6 +149,87 @@ func TestForceDefaultsForPType(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestChannelDefinedPolicy(t *testing.T) {
+	fakePolicyMapper := &mocks.PolicyMapper{}
+	fakePolicyMapper.PolicyRefForAPIStub = func(resName string) string {
+		if resName == "res" {
+			return "/Channel/Application/Readers"
+		}
+		return ""
+	}
+	fakeApplication := &mocks.Application{}
+	fakeApplication.APIPolicyMapperReturns(fakePolicyMapper)
+	fakePolicy := &mocks.Policy{}
+	fakePolicyManager := &mocks.PolicyManager{}
+	fakePolicyManager.GetPolicyReturns(fakePolicy, true)
+	fakeResources := &mocks.Resources{}
+	fakeResources.ApplicationConfigReturns(fakeApplication, true)
+	fakeResources.PolicyManagerReturns(fakePolicyManager)
+
+	defAclProvider := &mocks.DefaultACLProvider{}
+	rp := newResourceProvider(func(string) channelconfig.Resources { return fakeResources }, defAclProvider)
+
+	sProp, _ := protoutil.MockSignedEndorserProposalOrPanic("A", &peer.ChaincodeSpec{}, []byte("Alice"), []byte("msg1"))
+	signer := &mocks.SignerSerializer{}
+	env, err := protoutil.CreateSignedEnvelope(common.HeaderType_CONFIG, "myc", signer, &common.ConfigEnvelope{}, 0, 0)
+	require.NoError(t, err)
+	signedData := []*protoutil.SignedData{{
+		Data:      []byte("data"),
+		Identity:  []byte("identity"),
+		Signature: []byte("signature"),
+	}}
+
+	for _, idinfo := range []interface{}{sProp, env, signedData} {
+		err := rp.CheckACL("res", "myc", idinfo)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, fakePolicy.EvaluateSignedDataCallCount())
+	require.Equal(t, signedData, fakePolicy.EvaluateSignedDataArgsForCall(2))
+	require.Equal(t, 3, fakePolicyManager.GetPolicyCallCount())
+	require.Equal(t, "/Channel/Application/Readers", fakePolicyManager.GetPolicyArgsForCall(2))
+	require.Equal(t, 0, defAclProvider.CheckACLCallCount())
+
+	fakePolicy.EvaluateSignedDataReturns(errors.New("signature set did not satisfy policy"))
+	err = rp.CheckACL("res", "myc", signedData)
+	require.EqualError(t, err, "failed evaluating policy on signed data during check policy [/Channel/Application/Readers]: [signature set did not satisfy policy]")
+
+	err = rp.CheckACL("res", "myc", []byte("not signed data"))
+	require.EqualError(t, err, InvalidIdInfo("/Channel/Application/Readers").Error())
+}
+
+func TestSnapshotResourcesWithChannelDefinedPolicy(t *testing.T) {
+	fakePolicyMapper := &mocks.PolicyMapper{}
+	fakePolicyMapper.PolicyRefForAPIReturns("/Channel/Application/Readers")
+	fakeApplication := &mocks.Application{}
+	fakeApplication.APIPolicyMapperReturns(fakePolicyMapper)
+	fakeResources := &mocks.Resources{}
+	fakeResources.ApplicationConfigReturns(fakeApplication, true)
+	fakeResources.PolicyManagerReturns(&mocks.PolicyManager{})
+
+	fakePolicyChecker := &mocks.PolicyChecker{}
+	aclProvider := NewACLProvider(func(string) channelconfig.Resources { return fakeResources }, fakePolicyChecker)
+
+	signedData := []*protoutil.SignedData{{
+		Data:      []byte("data"),
+		Identity:  []byte("identity"),
+		Signature: []byte("signature"),
+	}}
+	for i, resName := range []string{resources.Snapshot_submitrequest, resources.Snapshot_cancelrequest, resources.Snapshot_listpending} {
+		err := aclProvider.CheckACL(resName, "myc", signedData)
+		require.NoError(t, err)
+
+		require.Equal(t, i+1, fakePolicyChecker.CheckPolicyNoChannelBySignedDataCallCount())
+		policyName, sd := fakePolicyChecker.CheckPolicyNoChannelBySignedDataArgsForCall(i)
+		require.Equal(t, mgmt.Admins, policyName)
+		require.Equal(t, signedData, sd)
+	}
+	require.Equal(t, 0, fakePolicyChecker.CheckPolicyBySignedDataCallCount())
+
+	fakePolicyChecker.CheckPolicyNoChannelBySignedDataReturns(errors.New("not an admin"))
+	err := aclProvider.CheckACL(resources.Snapshot_submitrequest, "myc", signedData)
+	require.EqualError(t, err, "not an admin")
+}
+
 func init() {
 	// setup the MSP manager so that we can sign/verify
 	err := msptesttools.LoadMSPSetupForTesting()
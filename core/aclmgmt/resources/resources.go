@@ -16,6 +16,7 @@ const (
 	Lifecycle_QueryInstalledChaincode            = "_lifecycle/QueryInstalledChaincode"
 	Lifecycle_GetInstalledChaincodePackage       = "_lifecycle/GetInstalledChaincodePackage"
 	Lifecycle_QueryInstalledChaincodes           = "_lifecycle/QueryInstalledChaincodes"
+	Lifecycle_UninstallChaincode                 = "_lifecycle/UninstallChaincode"
 	Lifecycle_ApproveChaincodeDefinitionForMyOrg = "_lifecycle/ApproveChaincodeDefinitionForMyOrg"
 	Lifecycle_QueryApprovedChaincodeDefinition   = "_lifecycle/QueryApprovedChaincodeDefinition"
 	Lifecycle_CommitChaincodeDefinition          = "_lifecycle/CommitChaincodeDefinition"
@@ -42,9 +43,10 @@ const (
 	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
 
 	//Cscc resources
-	Cscc_JoinChain      = "cscc/JoinChain"
-	Cscc_GetConfigBlock = "cscc/GetConfigBlock"
-	Cscc_GetChannels    = "cscc/GetChannels"
+	Cscc_JoinChain           = "cscc/JoinChain"
+	Cscc_JoinChainBySnapshot = "cscc/JoinChainBySnapshot"
+	Cscc_GetConfigBlock      = "cscc/GetConfigBlock"
+	Cscc_GetChannels         = "cscc/GetChannels"
 
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
@@ -53,4 +55,12 @@ const (
 	//Events
 	Event_Block         = "event/Block"
 	Event_FilteredBlock = "event/FilteredBlock"
+
+	//Snapshot resources
+	Snapshot_submitrequest = "snapshot/submitrequest"
+	Snapshot_cancelrequest = "snapshot/cancelrequest"
+	Snapshot_listpending   = "snapshot/listpending"
+
+	//Gateway resources
+	Gateway_CommitStatus = "gateway/CommitStatus"
 )
@@ -56,21 +56,22 @@ type Lifecycle interface {
 
 // ChaincodeSupport responsible for providing interfacing with chaincodes from the Peer.
 type ChaincodeSupport struct {
-	ACLProvider            ACLProvider
-	AppConfig              ApplicationConfigRetriever
-	BuiltinSCCs            scc.BuiltinSCCs
-	DeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
-	ExecuteTimeout         time.Duration
-	InstallTimeout         time.Duration
-	HandlerMetrics         *HandlerMetrics
-	HandlerRegistry        *HandlerRegistry
-	Keepalive              time.Duration
-	Launcher               Launcher
-	Lifecycle              Lifecycle
-	Peer                   *peer.Peer
-	Runtime                Runtime
-	TotalQueryLimit        int
-	UserRunsCC             bool
+	ACLProvider               ACLProvider
+	AppConfig                 ApplicationConfigRetriever
+	BuiltinSCCs               scc.BuiltinSCCs
+	CrossChannelTimeoutBlocks uint64
+	DeployedCCInfoProvider    ledger.DeployedChaincodeInfoProvider
+	ExecuteTimeout            time.Duration
+	InstallTimeout            time.Duration
+	HandlerMetrics            *HandlerMetrics
+	HandlerRegistry           *HandlerRegistry
+	Keepalive                 time.Duration
+	Launcher                  Launcher
+	Lifecycle                 Lifecycle
+	Peer                      *peer.Peer
+	Runtime                   Runtime
+	TotalQueryLimit           int
+	UserRunsCC                bool
 }
 
 // Launch starts executing chaincode if it is not already running. This method
@@ -105,21 +106,29 @@ func (cs *ChaincodeSupport) LaunchInProc(ccid string) <-chan struct{} {
 
 // HandleChaincodeStream implements ccintf.HandleChaincodeStream for all vms to call with appropriate stream
 func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	return cs.HandleReplicaStream("", stream)
+}
+
+// HandleReplicaStream handles the stream to a replica of a chaincode server
+// the peer connected to. Each replica gets its own handler.
+func (cs *ChaincodeSupport) HandleReplicaStream(replica string, stream ccintf.ChaincodeStream) error {
 	handler := &Handler{
-		Invoker:                cs,
-		Keepalive:              cs.Keepalive,
-		Registry:               cs.HandlerRegistry,
-		ACLProvider:            cs.ACLProvider,
-		TXContexts:             NewTransactionContexts(),
-		ActiveTransactions:     NewActiveTransactions(),
-		BuiltinSCCs:            cs.BuiltinSCCs,
-		QueryResponseBuilder:   &QueryResponseGenerator{MaxResultLimit: 100},
-		UUIDGenerator:          UUIDGeneratorFunc(util.GenerateUUID),
-		LedgerGetter:           cs.Peer,
-		DeployedCCInfoProvider: cs.DeployedCCInfoProvider,
-		AppConfig:              cs.AppConfig,
-		Metrics:                cs.HandlerMetrics,
-		TotalQueryLimit:        cs.TotalQueryLimit,
+		Invoker:                   cs,
+		Keepalive:                 cs.Keepalive,
+		Registry:                  cs.HandlerRegistry,
+		ACLProvider:               cs.ACLProvider,
+		TXContexts:                NewTransactionContexts(),
+		ActiveTransactions:        NewActiveTransactions(),
+		BuiltinSCCs:               cs.BuiltinSCCs,
+		CrossChannelTimeoutBlocks: cs.CrossChannelTimeoutBlocks,
+		QueryResponseBuilder:      &QueryResponseGenerator{MaxResultLimit: 100},
+		UUIDGenerator:             UUIDGeneratorFunc(util.GenerateUUID),
+		LedgerGetter:              cs.Peer,
+		DeployedCCInfoProvider:    cs.DeployedCCInfoProvider,
+		AppConfig:                 cs.AppConfig,
+		Metrics:                   cs.HandlerMetrics,
+		TotalQueryLimit:           cs.TotalQueryLimit,
+		Replica:                   replica,
 	}
 
 	return handler.ProcessStream(stream)
@@ -16,21 +16,23 @@ import (
 )
 
 const (
-	defaultExecutionTimeout = 30 * time.Second
-	minimumStartupTimeout   = 5 * time.Second
+	defaultExecutionTimeout          = 30 * time.Second
+	minimumStartupTimeout            = 5 * time.Second
+	defaultCrossChannelTimeoutBlocks = 100
 )
 
 type Config struct {
-	TotalQueryLimit int
-	TLSEnabled      bool
-	Keepalive       time.Duration
-	ExecuteTimeout  time.Duration
-	InstallTimeout  time.Duration
-	StartupTimeout  time.Duration
-	LogFormat       string
-	LogLevel        string
-	ShimLogLevel    string
-	SCCAllowlist    map[string]bool
+	TotalQueryLimit           int
+	TLSEnabled                bool
+	Keepalive                 time.Duration
+	ExecuteTimeout            time.Duration
+	InstallTimeout            time.Duration
+	StartupTimeout            time.Duration
+	CrossChannelTimeoutBlocks uint64
+	LogFormat                 string
+	LogLevel                  string
+	ShimLogLevel              string
+	SCCAllowlist              map[string]bool
 }
 
 func GlobalConfig() *Config {
@@ -57,6 +59,10 @@ func (c *Config) load() {
 	if c.StartupTimeout < minimumStartupTimeout {
 		c.StartupTimeout = minimumStartupTimeout
 	}
+	c.CrossChannelTimeoutBlocks = defaultCrossChannelTimeoutBlocks
+	if blocks := viper.GetInt("chaincode.crossChannelTimeoutBlocks"); blocks > 0 {
+		c.CrossChannelTimeoutBlocks = uint64(blocks)
+	}
 
 	c.SCCAllowlist = map[string]bool{}
 	for k, v := range viper.GetStringMapString("chaincode.system") {
@@ -33,6 +33,7 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.executetimeout", "20h")
 			viper.Set("chaincode.installTimeout", "30m")
 			viper.Set("chaincode.startuptimeout", "30h")
+			viper.Set("chaincode.crossChannelTimeoutBlocks", "50")
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "warning")
 			viper.Set("chaincode.logging.shim", "warning")
@@ -43,6 +44,7 @@ var _ = Describe("Config", func() {
 			Expect(config.ExecuteTimeout).To(Equal(20 * time.Hour))
 			Expect(config.InstallTimeout).To(Equal(30 * time.Minute))
 			Expect(config.StartupTimeout).To(Equal(30 * time.Hour))
+			Expect(config.CrossChannelTimeoutBlocks).To(Equal(uint64(50)))
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("warn"))
 			Expect(config.ShimLogLevel).To(Equal("warn"))
@@ -81,6 +83,17 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("when the cross-channel timeout is not set", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.crossChannelTimeoutBlocks", "")
+			})
+
+			It("falls back to the default cross-channel timeout", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.CrossChannelTimeoutBlocks).To(Equal(uint64(100)))
+			})
+		})
+
 		Context("when an invalid log level is configured", func() {
 			BeforeEach(func() {
 				viper.Set("chaincode.logging.level", "foo")
@@ -114,13 +127,14 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":                    viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":                 viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":            viper.GetString("chaincode.executetimeout"),
+		"chaincode.startuptimeout":            viper.GetString("chaincode.startuptimeout"),
+		"chaincode.crossChannelTimeoutBlocks": viper.GetString("chaincode.crossChannelTimeoutBlocks"),
+		"chaincode.logging.format":            viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":             viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":              viper.GetString("chaincode.logging.shim"),
 	}
 
 	return func() {
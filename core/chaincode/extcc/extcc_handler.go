@@ -8,6 +8,8 @@ package extcc
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/container/ccintf"
@@ -21,53 +23,186 @@ import (
 
 var extccLogger = flogging.MustGetLogger("extcc")
 
+// DefaultReconnectInterval is the default interval between two attempts to
+// reconnect to a replica of a chaincode server.
+const DefaultReconnectInterval = 5 * time.Second
+
 // StreamHandler handles the `Chaincode` gRPC service with peer as client
 type StreamHandler interface {
-	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
+	// HandleReplicaStream handles the stream to a replica of the chaincode
+	// server, identified by its address.
+	HandleReplicaStream(replica string, stream ccintf.ChaincodeStream) error
 }
 
 type ExternalChaincodeRuntime struct {
+	// ReconnectInterval is the interval between two attempts to reconnect to
+	// a replica that is not connected while others are.
+	ReconnectInterval time.Duration
+	Metrics           *Metrics
 }
 
 // createConnection - standard grpc client creating using ClientConfig info (surprised there isn't
 // a helper method for this)
-func (i *ExternalChaincodeRuntime) createConnection(ccid string, ccinfo *ccintf.ChaincodeServerInfo) (*grpc.ClientConn, error) {
+func (i *ExternalChaincodeRuntime) createConnection(ccid, address string, ccinfo *ccintf.ChaincodeServerInfo) (*grpc.ClientConn, error) {
 	grpcClient, err := comm.NewGRPCClient(ccinfo.ClientConfig)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error creating grpc client to %s", ccid)
 	}
 
-	conn, err := grpcClient.NewConnection(ccinfo.Address)
+	conn, err := grpcClient.NewConnection(address)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "error creating grpc connection to %s", ccinfo.Address)
+		return nil, errors.WithMessagef(err, "error creating grpc connection to %s", address)
 	}
 
-	extccLogger.Debugf("Created external chaincode connection: %s", ccid)
+	extccLogger.Debugf("Created external chaincode connection: %s (%s)", ccid, address)
 
 	return conn, nil
 }
 
+// Stream connects to the replicas of the chaincode server and hands their
+// streams to the stream handler. A replica whose stream ends is reconnected to
+// as long as other replicas are connected. Stream returns once none is.
 func (i *ExternalChaincodeRuntime) Stream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler StreamHandler) error {
 	extccLogger.Debugf("Starting external chaincode connection: %s", ccid)
-	conn, err := i.createConnection(ccid, ccinfo)
-	if err != nil {
-		return errors.WithMessagef(err, "error cannot create connection for %s", ccid)
+
+	replicas := ccinfo.ReplicaAddresses()
+	conns := make([]*grpc.ClientConn, len(replicas))
+	errs := make([]error, len(replicas))
+	var wg sync.WaitGroup
+	for n, address := range replicas {
+		wg.Add(1)
+		go func(n int, address string) {
+			defer wg.Done()
+			conns[n], errs[n] = i.createConnection(ccid, address, ccinfo)
+		}(n, address)
+	}
+	wg.Wait()
+
+	rs := &replicaSet{
+		ccid:     ccid,
+		ccinfo:   ccinfo,
+		sHandler: sHandler,
+		runtime:  i,
+		doneC:    make(chan struct{}),
+	}
+	for n, address := range replicas {
+		if errs[n] != nil {
+			extccLogger.Warningf("Failed to connect to replica %s of %s: %s", address, ccid, errs[n])
+			i.Metrics.ReplicaConnectFailures.With("chaincode", ccid, "replica", address).Add(1)
+			continue
+		}
+		rs.connected++
+	}
+	if rs.connected == 0 {
+		return errors.WithMessagef(errs[0], "error cannot create connection for %s", ccid)
+	}
+	i.Metrics.ReplicasConnected.With("chaincode", ccid).Set(float64(rs.connected))
+
+	for n, address := range replicas {
+		go rs.serve(address, conns[n])
 	}
+	<-rs.doneC
 
-	defer conn.Close()
+	extccLogger.Debugf("External chaincode %s client exited", ccid)
+
+	return nil
+}
+
+func (i *ExternalChaincodeRuntime) reconnectInterval() time.Duration {
+	if i.ReconnectInterval == 0 {
+		return DefaultReconnectInterval
+	}
+	return i.ReconnectInterval
+}
 
+// replicaSet tracks the streams to the replicas of a chaincode server.
+type replicaSet struct {
+	ccid     string
+	ccinfo   *ccintf.ChaincodeServerInfo
+	sHandler StreamHandler
+	runtime  *ExternalChaincodeRuntime
+
+	mutex     sync.Mutex
+	connected int
+	doneC     chan struct{} // closed once no replica is connected
+}
+
+// serve streams to the replica until no replica is connected anymore. The
+// connection is nil if the replica is not connected yet.
+func (rs *replicaSet) serve(address string, conn *grpc.ClientConn) {
+	for {
+		if conn != nil {
+			rs.stream(address, conn)
+			conn.Close()
+			if rs.disconnected() {
+				return
+			}
+		}
+
+		select {
+		case <-rs.doneC:
+			return
+		case <-time.After(rs.runtime.reconnectInterval()):
+		}
+
+		var err error
+		conn, err = rs.runtime.createConnection(rs.ccid, address, rs.ccinfo)
+		if err != nil {
+			extccLogger.Debugf("Failed to reconnect to replica %s of %s: %s", address, rs.ccid, err)
+			rs.runtime.Metrics.ReplicaConnectFailures.With("chaincode", rs.ccid, "replica", address).Add(1)
+			continue
+		}
+		if !rs.reconnected() {
+			conn.Close()
+			return
+		}
+		extccLogger.Infof("Reconnected to replica %s of %s", address, rs.ccid)
+	}
+}
+
+func (rs *replicaSet) stream(address string, conn *grpc.ClientConn) {
 	//create the client and start streaming
 	client := pb.NewChaincodeClient(conn)
 
 	stream, err := client.Connect(context.Background())
 	if err != nil {
-		return errors.WithMessagef(err, "error creating grpc client connection to %s", ccid)
+		extccLogger.Warningf("Error creating grpc client connection to replica %s of %s: %s", address, rs.ccid, err)
+		return
 	}
 
 	//peer as client has to initiate the stream. Rest of the process is unchanged
-	sHandler.HandleChaincodeStream(stream)
+	rs.sHandler.HandleReplicaStream(address, stream)
 
-	extccLogger.Debugf("External chaincode %s client exited", ccid)
+	extccLogger.Debugf("Stream to replica %s of %s ended", address, rs.ccid)
+}
 
-	return nil
+// reconnected records that a replica is connected again, unless no replica
+// is connected anymore.
+func (rs *replicaSet) reconnected() bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	select {
+	case <-rs.doneC:
+		return false
+	default:
+	}
+	rs.connected++
+	rs.runtime.Metrics.ReplicasConnected.With("chaincode", rs.ccid).Set(float64(rs.connected))
+	return true
+}
+
+// disconnected records that a replica is not connected anymore, and returns
+// true if it was the last one.
+func (rs *replicaSet) disconnected() bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	rs.connected--
+	rs.runtime.Metrics.ReplicasConnected.With("chaincode", rs.ccid).Set(float64(rs.connected))
+	if rs.connected == 0 {
+		close(rs.doneC)
+		return true
+	}
+	return false
 }
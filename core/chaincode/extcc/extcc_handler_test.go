@@ -8,8 +8,10 @@ package extcc_test
 
 import (
 	"net"
+	"sync"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/extcc/mock"
 	"github.com/hyperledger/fabric/core/container/ccintf"
@@ -23,13 +25,25 @@ import (
 
 var _ = Describe("Extcc", func() {
 	var (
-		i        *extcc.ExternalChaincodeRuntime
-		shandler *mock.StreamHandler
+		i                          *extcc.ExternalChaincodeRuntime
+		shandler                   *mock.StreamHandler
+		fakeReplicasConnected      *metricsfakes.Gauge
+		fakeReplicaConnectFailures *metricsfakes.Counter
 	)
 
 	BeforeEach(func() {
 		shandler = &mock.StreamHandler{}
-		i = &extcc.ExternalChaincodeRuntime{}
+		fakeReplicasConnected = &metricsfakes.Gauge{}
+		fakeReplicasConnected.WithReturns(fakeReplicasConnected)
+		fakeReplicaConnectFailures = &metricsfakes.Counter{}
+		fakeReplicaConnectFailures.WithReturns(fakeReplicaConnectFailures)
+		i = &extcc.ExternalChaincodeRuntime{
+			ReconnectInterval: 50 * time.Millisecond,
+			Metrics: &extcc.Metrics{
+				ReplicasConnected:      fakeReplicasConnected,
+				ReplicaConnectFailures: fakeReplicaConnectFailures,
+			},
+		}
 	})
 
 	Context("Run", func() {
@@ -66,12 +80,116 @@ var _ = Describe("Extcc", func() {
 				}
 				err := i.Stream("ccid", ccinfo, shandler)
 				Expect(err).To(BeNil())
-				Expect(shandler.HandleChaincodeStreamCallCount()).To(Equal(1))
+				Expect(shandler.HandleReplicaStreamCallCount()).To(Equal(1))
 
-				streamArg := shandler.HandleChaincodeStreamArgsForCall(0)
+				replica, streamArg := shandler.HandleReplicaStreamArgsForCall(0)
+				Expect(replica).To(Equal(cclist.Addr().String()))
 				Expect(streamArg).To(Not(BeNil()))
 			})
 		})
+		When("chaincode is served by several replicas", func() {
+			var (
+				cclists []net.Listener
+				ccservs []*grpc.Server
+				ccinfo  *ccintf.ChaincodeServerInfo
+			)
+			BeforeEach(func() {
+				cclists, ccservs = nil, nil
+				ccinfo = &ccintf.ChaincodeServerInfo{
+					ClientConfig: comm.ClientConfig{
+						KaOpts:  comm.DefaultKeepaliveOptions,
+						Timeout: 10 * time.Second,
+					},
+				}
+				for n := 0; n < 2; n++ {
+					cclist, err := net.Listen("tcp", "127.0.0.1:0")
+					Expect(err).NotTo(HaveOccurred())
+					ccserv := grpc.NewServer()
+					go ccserv.Serve(cclist)
+					cclists = append(cclists, cclist)
+					ccservs = append(ccservs, ccserv)
+					ccinfo.Addresses = append(ccinfo.Addresses, cclist.Addr().String())
+				}
+				ccinfo.Address = ccinfo.Addresses[0]
+			})
+
+			AfterEach(func() {
+				for n := range ccservs {
+					ccservs[n].Stop()
+					cclists[n].Close()
+				}
+			})
+
+			It("streams to every replica", func() {
+				err := i.Stream("ccid", ccinfo, shandler)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(shandler.HandleReplicaStreamCallCount()).To(Equal(2))
+
+				var replicas []string
+				for n := 0; n < 2; n++ {
+					replica, _ := shandler.HandleReplicaStreamArgsForCall(n)
+					replicas = append(replicas, replica)
+				}
+				Expect(replicas).To(ConsistOf(ccinfo.Addresses))
+				Expect(fakeReplicasConnected.WithArgsForCall(0)).To(Equal([]string{"chaincode", "ccid"}))
+				Expect(fakeReplicasConnected.SetArgsForCall(0)).To(Equal(float64(2)))
+			})
+
+			When("a replica is unreachable", func() {
+				var unreachable string
+
+				BeforeEach(func() {
+					unreachable = ccinfo.Addresses[1]
+					ccservs[1].Stop()
+					cclists[1].Close()
+				})
+
+				It("reconnects to it while other replicas are connected", func() {
+					release := make(chan struct{})
+					var once sync.Once
+					shandler.HandleReplicaStreamStub = func(replica string, _ ccintf.ChaincodeStream) error {
+						if replica == unreachable {
+							once.Do(func() { close(release) })
+							return nil
+						}
+						<-release
+						return nil
+					}
+
+					errC := make(chan error, 1)
+					go func() { errC <- i.Stream("ccid", ccinfo, shandler) }()
+
+					Eventually(fakeReplicaConnectFailures.AddCallCount).Should(BeNumerically(">", 1))
+					Expect(fakeReplicaConnectFailures.WithArgsForCall(0)).To(Equal([]string{"chaincode", "ccid", "replica", unreachable}))
+					Consistently(errC).ShouldNot(Receive())
+
+					cclist, err := net.Listen("tcp", unreachable)
+					Expect(err).NotTo(HaveOccurred())
+					cclists[1] = cclist
+					ccservs[1] = grpc.NewServer()
+					go ccservs[1].Serve(cclist)
+
+					Eventually(errC, 10*time.Second).Should(Receive(BeNil()))
+					Expect(shandler.HandleReplicaStreamCallCount()).To(BeNumerically(">=", 2))
+				})
+			})
+
+			When("no replica is reachable", func() {
+				BeforeEach(func() {
+					for n := range ccservs {
+						ccservs[n].Stop()
+						cclists[n].Close()
+					}
+				})
+
+				It("returns an error", func() {
+					err := i.Stream("ccid", ccinfo, shandler)
+					Expect(err).To(MatchError(ContainSubstring("error cannot create connection for ccid")))
+					Expect(fakeReplicaConnectFailures.AddCallCount()).To(Equal(2))
+					Expect(shandler.HandleReplicaStreamCallCount()).To(Equal(0))
+				})
+			})
+		})
 		Context("chaincode info incorrect", func() {
 			var (
 				ccinfo *ccintf.ChaincodeServerInfo
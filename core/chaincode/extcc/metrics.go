@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	replicasConnected = metrics.GaugeOpts{
+		Namespace:    "chaincode",
+		Name:         "replicas_connected",
+		Help:         "The number of replicas of a chaincode server the peer is connected to.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+	replicaConnectFailures = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "replica_connect_failures",
+		Help:         "The number of failed attempts to connect to a replica of a chaincode server.",
+		LabelNames:   []string{"chaincode", "replica"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{replica}",
+	}
+)
+
+type Metrics struct {
+	ReplicasConnected      metrics.Gauge
+	ReplicaConnectFailures metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		ReplicasConnected:      p.NewGauge(replicasConnected),
+		ReplicaConnectFailures: p.NewCounter(replicaConnectFailures),
+	}
+}
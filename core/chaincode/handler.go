@@ -9,23 +9,30 @@ package chaincode
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shimpb"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -42,7 +49,7 @@ type Registry interface {
 	Register(*Handler) error
 	Ready(string)
 	Failed(string, error)
-	Deregister(string) error
+	DeregisterHandler(*Handler) error
 }
 
 // An Invoker invokes chaincode.
@@ -121,9 +128,19 @@ type Handler struct {
 	UUIDGenerator UUIDGenerator
 	// AppConfig is used to retrieve the application config for a channel
 	AppConfig ApplicationConfigRetriever
+	// CrossChannelTimeoutBlocks is the number of blocks, after the block that
+	// prepares a transaction, in which the writes it performs on other channels
+	// can be committed.
+	CrossChannelTimeoutBlocks uint64
 	// Metrics holds chaincode handler metrics
 	Metrics *HandlerMetrics
+	// Replica is the address of the replica of the chaincode server the peer
+	// connected to. It is empty when the chaincode connected to the peer.
+	Replica string
 
+	// inflight counts the transactions being executed by the chaincode. It is
+	// accessed atomically.
+	inflight int32
 	// state holds the current handler state. It will be created, established, or
 	// ready.
 	state State
@@ -200,6 +217,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandlePutStateMetadata)
+	case pb.ChaincodeMessage_Type(shimpb.ChaincodeMessageType_PURGE_PRIVATE_DATA):
+		go h.HandleTransaction(msg, h.HandlePurgePrivateData)
 	default:
 		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, msg.Type)
 	}
@@ -343,7 +362,11 @@ func (h *Handler) checkACL(signedProp *pb.SignedProposal, proposal *pb.Proposal,
 }
 
 func (h *Handler) deregister() {
-	h.Registry.Deregister(h.chaincodeID)
+	h.Registry.DeregisterHandler(h)
+}
+
+func (h *Handler) inflightCount() int32 {
+	return atomic.LoadInt32(&h.inflight)
 }
 
 func (h *Handler) streamDone() <-chan struct{} {
@@ -544,6 +567,41 @@ func (h *Handler) checkMetadataCap(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
+func (h *Handler) checkPurgeCap(msg *pb.ChaincodeMessage) error {
+	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", msg.ChannelId)
+	}
+
+	if !ac.Capabilities().PurgePvtData() {
+		return errors.New("purge of private data is not enabled, channel application capability of V3_0 or later is required")
+	}
+	return nil
+}
+
+// crossChannelTransactions returns true if the channel supports cross-channel transactions
+func (h *Handler) crossChannelTransactions(channelID string) bool {
+	ac, exists := h.AppConfig.GetApplicationConfig(channelID)
+	return exists && ac.Capabilities().CrossChannelTransactions()
+}
+
+// errorIfLockedByCrossChannelTx returns an error if the key is locked by a prepared
+// cross-channel transaction. The lock is read through the simulator so that the
+// transaction is invalidated if the key gets locked before it is committed.
+func (h *Handler) errorIfLockedByCrossChannelTx(namespaceID, key string, txContext *TransactionContext) error {
+	if namespaceID == crosschannel.Namespace || !h.crossChannelTransactions(txContext.ChannelID) {
+		return nil
+	}
+	lock, err := txContext.TXSimulator.GetState(crosschannel.Namespace, crosschannel.LockKey(namespaceID, key))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if lock != nil {
+		return errors.Errorf("key %s is locked by cross-channel transaction %s", key, lock)
+	}
+	return nil
+}
+
 func errorIfCreatorHasNoReadPermission(chaincodeName, collection string, txContext *TransactionContext) error {
 	rwPermission, err := getReadWritePermission(chaincodeName, collection, txContext)
 	if err != nil {
@@ -713,12 +771,16 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 	iterID := h.UUIDGenerator.New()
 	var rangeIter commonledger.ResultsIterator
 	isPaginated := false
+	descending := metadata.GetDescending()
 	namespaceID := txContext.NamespaceID
 	collection := getStateByRange.Collection
 	if isCollectionSet(collection) {
 		if txContext.IsInitTransaction {
 			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
 		}
+		if descending {
+			return nil, errors.New("descending range queries are not supported on private data")
+		}
 		if err := errorIfCreatorHasNoReadPermission(namespaceID, collection, txContext); err != nil {
 			return nil, err
 		}
@@ -732,8 +794,15 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 				startKey = metadata.Bookmark
 			}
 		}
-		rangeIter, err = txContext.TXSimulator.GetStateRangeScanIteratorWithPagination(namespaceID,
-			startKey, getStateByRange.EndKey, metadata.PageSize)
+		if descending {
+			rangeIter, err = txContext.TXSimulator.GetStateReverseRangeScanIteratorWithPagination(namespaceID,
+				startKey, getStateByRange.EndKey, metadata.PageSize)
+		} else {
+			rangeIter, err = txContext.TXSimulator.GetStateRangeScanIteratorWithPagination(namespaceID,
+				startKey, getStateByRange.EndKey, metadata.PageSize)
+		}
+	} else if descending {
+		rangeIter, err = txContext.TXSimulator.GetStateReverseRangeScanIterator(namespaceID, getStateByRange.StartKey, getStateByRange.EndKey)
 	} else {
 		rangeIter, err = txContext.TXSimulator.GetStateRangeScanIterator(namespaceID, getStateByRange.StartKey, getStateByRange.EndKey)
 	}
@@ -910,7 +979,7 @@ func isCollectionSet(collection string) bool {
 	return collection != ""
 }
 
-func isMetadataSetForPagination(metadata *pb.QueryMetadata) bool {
+func isMetadataSetForPagination(metadata *shimpb.QueryMetadata) bool {
 	if metadata == nil {
 		return false
 	}
@@ -922,9 +991,9 @@ func isMetadataSetForPagination(metadata *pb.QueryMetadata) bool {
 	return true
 }
 
-func getQueryMetadataFromBytes(metadataBytes []byte) (*pb.QueryMetadata, error) {
+func getQueryMetadataFromBytes(metadataBytes []byte) (*shimpb.QueryMetadata, error) {
 	if metadataBytes != nil {
-		metadata := &pb.QueryMetadata{}
+		metadata := &shimpb.QueryMetadata{}
 		err := proto.Unmarshal(metadataBytes, metadata)
 		if err != nil {
 			return nil, errors.Wrap(err, "unmarshal failed")
@@ -934,7 +1003,7 @@ func getQueryMetadataFromBytes(metadataBytes []byte) (*pb.QueryMetadata, error)
 	return nil, nil
 }
 
-func (h *Handler) calculateTotalReturnLimit(metadata *pb.QueryMetadata) int32 {
+func (h *Handler) calculateTotalReturnLimit(metadata *shimpb.QueryMetadata) int32 {
 	totalReturnLimit := int32(h.TotalQueryLimit)
 	if metadata != nil {
 		pageSize := int32(metadata.PageSize)
@@ -998,6 +1067,9 @@ func (h *Handler) HandlePutState(msg *pb.ChaincodeMessage, txContext *Transactio
 		}
 		err = txContext.TXSimulator.SetPrivateData(namespaceID, collection, putState.Key, putState.Value)
 	} else {
+		if err := h.errorIfLockedByCrossChannelTx(namespaceID, putState.Key, txContext); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.SetState(namespaceID, putState.Key, putState.Value)
 	}
 	if err != nil {
@@ -1033,6 +1105,9 @@ func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 		}
 		err = txContext.TXSimulator.SetPrivateDataMetadata(namespaceID, collection, putStateMetadata.Key, metadata)
 	} else {
+		if err := h.errorIfLockedByCrossChannelTx(namespaceID, putStateMetadata.Key, txContext); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.SetStateMetadata(namespaceID, putStateMetadata.Key, metadata)
 	}
 	if err != nil {
@@ -1060,6 +1135,9 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 		}
 		err = txContext.TXSimulator.DeletePrivateData(namespaceID, collection, delState.Key)
 	} else {
+		if err := h.errorIfLockedByCrossChannelTx(namespaceID, delState.Key, txContext); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.DeleteState(namespaceID, delState.Key)
 	}
 	if err != nil {
@@ -1070,6 +1148,38 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// HandlePurgePrivateData handles the requests from the shim to purge a private data key. The payload
+// of the request is a DelState message that identifies the key
+func (h *Handler) HandlePurgePrivateData(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	if err := h.checkPurgeCap(msg); err != nil {
+		return nil, err
+	}
+
+	delState := &pb.DelState{}
+	err := proto.Unmarshal(msg.Payload, delState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	namespaceID := txContext.NamespaceID
+	collection := delState.Collection
+	if !isCollectionSet(collection) {
+		return nil, errors.New("only private data can be purged")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	if err := errorIfCreatorHasNoWritePermission(namespaceID, collection, txContext); err != nil {
+		return nil, err
+	}
+	if err := txContext.TXSimulator.PurgePrivateData(namespaceID, collection, delState.Key); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -1113,6 +1223,7 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		Proposal:             txContext.Proposal,
 		TXSimulator:          txContext.TXSimulator,
 		HistoryQueryExecutor: txContext.HistoryQueryExecutor,
+		CrossChannelWrites:   txContext.CrossChannelWrites,
 	}
 
 	if targetInstance.ChannelID != txContext.ChannelID {
@@ -1142,6 +1253,15 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.Wrap(err, "execute failed")
 	}
 
+	// The writes of a chaincode invoked on another channel are discarded, unless
+	// both channels support cross-channel transactions. In this case the writes are
+	// prepared, as part of this transaction, to be committed through the xcc system chaincode
+	if targetInstance.ChannelID != txContext.ChannelID && responseMessage.GetType() == pb.ChaincodeMessage_COMPLETED {
+		if err := h.prepareCrossChannelWrites(msg.Txid, targetInstance.ChannelID, txParams, responseMessage); err != nil {
+			return nil, err
+		}
+	}
+
 	// payload is marshalled and sent to the calling chaincode's shim which unmarshals and
 	// sends it to chaincode
 	res, err := proto.Marshal(responseMessage)
@@ -1152,6 +1272,83 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// prepareCrossChannelWrites records the writes that the chaincode invoked on the given channel
+// performed in the record of the cross-channel transaction on the channel of the transaction
+func (h *Handler) prepareCrossChannelWrites(txID, channelID string, txParams *ccprovider.TransactionParams, responseMessage *pb.ChaincodeMessage) error {
+	ccw := txParams.CrossChannelWrites
+	if ccw == nil || !h.crossChannelTransactions(ccw.ChannelID) || !h.crossChannelTransactions(channelID) {
+		return nil
+	}
+	response := &pb.Response{}
+	if err := proto.Unmarshal(responseMessage.Payload, response); err != nil {
+		return errors.Wrap(err, "unmarshal failed")
+	}
+	if response.Status >= shim.ERRORTHRESHOLD {
+		return nil
+	}
+
+	results, err := txParams.TXSimulator.GetTxSimulationResults()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(results.PubSimulationResults)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !crosschannel.HasWrites(txRWSet) && !results.ContainsPvtWrites() {
+		return nil
+	}
+	if channelID == ccw.ChannelID {
+		return errors.Errorf("chaincodes invoked on another channel cannot modify the state of channel %s", channelID)
+	}
+	if results.ContainsPvtWrites() {
+		return errors.Errorf("private data is not supported in cross-channel transactions (channel %s)", channelID)
+	}
+	if err := crosschannel.CheckStageable(txRWSet); err != nil {
+		return errors.WithMessagef(err, "invalid writes on channel %s", channelID)
+	}
+	pubSimResults, err := results.GetPubSimulationBytes()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rwsets, err := ccw.Add(channelID, pubSimResults)
+	if err != nil {
+		return err
+	}
+
+	hdr, err := protoutil.UnmarshalHeader(txParams.Proposal.Header)
+	if err != nil {
+		return err
+	}
+	shdr, err := protoutil.UnmarshalSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return err
+	}
+
+	// the deadline is set by the validation of the transaction, from the
+	// number of the block that prepares it
+	record := &crosschannel.Transaction{
+		Id:            txID,
+		Coordinator:   ccw.ChannelID,
+		TimeoutBlocks: h.CrossChannelTimeoutBlocks,
+		Creator:       shdr.Creator,
+		State:         crosschannel.State_PREPARED,
+	}
+	for ch := range rwsets {
+		record.Participants = append(record.Participants, ch)
+	}
+	sort.Strings(record.Participants)
+	for _, ch := range record.Participants {
+		record.ParticipantRwsets = append(record.ParticipantRwsets, &crosschannel.ParticipantRwSet{Channel: ch, Rwset: rwsets[ch]})
+	}
+	recordBytes, err := proto.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	chaincodeLogger.Debugf("[%s] preparing cross-channel transaction with participants %v", shorttxid(txID), record.Participants)
+	return errors.WithStack(ccw.TXSimulator.SetState(crosschannel.Namespace, crosschannel.TxKey(txID), recordBytes))
+}
+
 func (h *Handler) Execute(txParams *ccprovider.TransactionParams, namespace string, msg *pb.ChaincodeMessage, timeout time.Duration) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("Entry")
 	defer chaincodeLogger.Debugf("Exit")
@@ -1159,6 +1356,9 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, namespace stri
 	txParams.CollectionStore = h.getCollectionStore(msg.ChannelId)
 	txParams.IsInitTransaction = (msg.Type == pb.ChaincodeMessage_INIT)
 	txParams.NamespaceID = namespace
+	if txParams.CrossChannelWrites == nil {
+		txParams.CrossChannelWrites = &ccprovider.CrossChannelWrites{ChannelID: txParams.ChannelID, TXSimulator: txParams.TXSimulator}
+	}
 
 	txctx, err := h.TXContexts.Create(txParams)
 	if err != nil {
@@ -1170,6 +1370,13 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, namespace stri
 		return nil, err
 	}
 
+	replicaLabels := []string{"chaincode", h.chaincodeID, "replica", h.Replica}
+	h.Metrics.ReplicaInflightExecutions.With(replicaLabels...).Set(float64(atomic.AddInt32(&h.inflight, 1)))
+	defer func() {
+		h.Metrics.ReplicaInflightExecutions.With(replicaLabels...).Set(float64(atomic.AddInt32(&h.inflight, -1)))
+		h.Metrics.ReplicaExecutions.With(append(replicaLabels, "success", strconv.FormatBool(err == nil))...).Add(1)
+	}()
+
 	h.serialSendAsync(msg)
 
 	var ccresp *pb.ChaincodeMessage
@@ -16,6 +16,10 @@ func SetHandlerChaincodeID(h *Handler, chaincodeID string) {
 	h.chaincodeID = chaincodeID
 }
 
+func SetHandlerInflight(h *Handler, inflight int32) {
+	h.inflight = inflight
+}
+
 func SetHandlerChatStream(h *Handler, chatStream ccintf.ChaincodeStream) {
 	h.chatStream = chatStream
 }
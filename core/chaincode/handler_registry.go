@@ -17,9 +17,10 @@ import (
 type HandlerRegistry struct {
 	allowUnsolicitedRegistration bool // from cs.userRunsCC
 
-	mutex     sync.Mutex              // lock covering handlers and launching
-	handlers  map[string]*Handler     // chaincode cname to associated handler
+	mutex     sync.Mutex              // lock covering handlers, launching and next
+	handlers  map[string][]*Handler   // chaincode cname to the handlers of its replicas
 	launching map[string]*LaunchState // launching chaincodes to LaunchState
+	next      map[string]int          // chaincode cname to the replica the next selection starts from
 }
 
 type LaunchState struct {
@@ -59,8 +60,9 @@ func (l *LaunchState) Notify(err error) {
 // NewHandlerRegistry constructs a HandlerRegistry.
 func NewHandlerRegistry(allowUnsolicitedRegistration bool) *HandlerRegistry {
 	return &HandlerRegistry{
-		handlers:                     map[string]*Handler{},
+		handlers:                     map[string][]*Handler{},
 		launching:                    map[string]*LaunchState{},
+		next:                         map[string]int{},
 		allowUnsolicitedRegistration: allowUnsolicitedRegistration,
 	}
 }
@@ -114,25 +116,50 @@ func (r *HandlerRegistry) Failed(ccid string, err error) {
 	}
 }
 
-// Handler retrieves the handler for a chaincode instance.
+// Handler retrieves the handler for a chaincode instance. When the chaincode
+// is served by several replicas, the handler with the fewest transactions in
+// flight is returned, the replicas taking turns when they are equally loaded.
 func (r *HandlerRegistry) Handler(ccid string) *Handler {
 	r.mutex.Lock()
-	h := r.handlers[ccid]
-	r.mutex.Unlock()
-	return h
+	defer r.mutex.Unlock()
+
+	handlers := r.handlers[ccid]
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	start := r.next[ccid] % len(handlers)
+	r.next[ccid] = start + 1
+	selected := handlers[start]
+	for i := 1; i < len(handlers); i++ {
+		h := handlers[(start+i)%len(handlers)]
+		if h.inflightCount() < selected.inflightCount() {
+			selected = h
+		}
+	}
+	return selected
+}
+
+// Handlers retrieves the handlers of all the replicas of a chaincode instance.
+func (r *HandlerRegistry) Handlers(ccid string) []*Handler {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*Handler(nil), r.handlers[ccid]...)
 }
 
 // Register adds a chaincode handler to the registry.
 // An error will be returned if a handler is already registered for the
-// chaincode. An error will also be returned if the chaincode has not already
-// been "launched", and unsolicited registration is not allowed.
+// chaincode replica. An error will also be returned if the chaincode has not
+// already been "launched", and unsolicited registration is not allowed.
 func (r *HandlerRegistry) Register(h *Handler) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if r.handlers[h.chaincodeID] != nil {
-		chaincodeLogger.Debugf("duplicate registered handler(key:%s) return error", h.chaincodeID)
-		return errors.Errorf("duplicate chaincodeID: %s", h.chaincodeID)
+	for _, registered := range r.handlers[h.chaincodeID] {
+		if registered.Replica == h.Replica {
+			chaincodeLogger.Debugf("duplicate registered handler(key:%s) return error", h.chaincodeID)
+			return errors.Errorf("duplicate chaincodeID: %s", h.chaincodeID)
+		}
 	}
 
 	// This chaincode was not launched by the peer but is attempting
@@ -141,47 +168,84 @@ func (r *HandlerRegistry) Register(h *Handler) error {
 		return errors.Errorf("peer will not accept external chaincode connection %s (except in dev mode)", h.chaincodeID)
 	}
 
-	r.handlers[h.chaincodeID] = h
+	r.handlers[h.chaincodeID] = append(r.handlers[h.chaincodeID], h)
 
 	chaincodeLogger.Debugf("registered handler complete for chaincode %s", h.chaincodeID)
 	return nil
 }
 
 // Deregister clears references to state associated specified chaincode.
-// As part of the cleanup, it closes the handler so it can cleanup any state.
-// If the registry does not contain the provided handler, an error is returned.
+// As part of the cleanup, it closes the handlers so they can cleanup any state.
+// If the registry does not contain a handler for the chaincode, an error is
+// returned.
 func (r *HandlerRegistry) Deregister(ccid string) error {
 	chaincodeLogger.Debugf("deregister handler: %s", ccid)
 
 	r.mutex.Lock()
-	handler := r.handlers[ccid]
+	handlers := r.handlers[ccid]
 	delete(r.handlers, ccid)
 	delete(r.launching, ccid)
+	delete(r.next, ccid)
 	r.mutex.Unlock()
 
-	if handler == nil {
+	if len(handlers) == 0 {
 		return errors.Errorf("could not find handler: %s", ccid)
 	}
 
-	handler.Close()
+	for _, handler := range handlers {
+		handler.Close()
+	}
 
 	chaincodeLogger.Debugf("deregistered handler with key: %s", ccid)
 	return nil
 }
 
+// DeregisterHandler removes the handler of a replica of a chaincode and closes
+// it. The other replicas keep serving the chaincode. The state associated with
+// the chaincode is cleared along with the handler of its last replica.
+func (r *HandlerRegistry) DeregisterHandler(h *Handler) error {
+	ccid := h.chaincodeID
+	chaincodeLogger.Debugf("deregister handler: %s (replica %q)", ccid, h.Replica)
+
+	r.mutex.Lock()
+	handlers := r.handlers[ccid]
+	found := false
+	for i, registered := range handlers {
+		if registered == h {
+			handlers = append(handlers[:i:i], handlers[i+1:]...)
+			found = true
+			break
+		}
+	}
+	if len(handlers) == 0 {
+		delete(r.handlers, ccid)
+		delete(r.launching, ccid)
+		delete(r.next, ccid)
+	} else {
+		r.handlers[ccid] = handlers
+	}
+	r.mutex.Unlock()
+
+	if !found {
+		return errors.Errorf("could not find handler: %s", ccid)
+	}
+
+	h.Close()
+
+	chaincodeLogger.Debugf("deregistered handler with key: %s (replica %q)", ccid, h.Replica)
+	return nil
+}
+
 type TxQueryExecutorGetter struct {
 	HandlerRegistry *HandlerRegistry
 	CCID            string
 }
 
 func (g *TxQueryExecutorGetter) TxQueryExecutor(chainID, txID string) ledger.SimpleQueryExecutor {
-	handler := g.HandlerRegistry.Handler(g.CCID)
-	if handler == nil {
-		return nil
+	for _, handler := range g.HandlerRegistry.Handlers(g.CCID) {
+		if txContext := handler.TXContexts.Get(chainID, txID); txContext != nil {
+			return txContext.TXSimulator
+		}
 	}
-	txContext := handler.TXContexts.Get(chainID, txID)
-	if txContext == nil {
-		return nil
-	}
-	return txContext.TXSimulator
+	return nil
 }
@@ -240,6 +240,82 @@ var _ = Describe("HandlerRegistry", func() {
 			Expect(fakeResultsIterator.CloseCallCount()).To(Equal(1))
 		})
 	})
+
+	Context("when the chaincode is served by several replicas", func() {
+		var replicas []*chaincode.Handler
+
+		BeforeEach(func() {
+			replicas = nil
+			for _, address := range []string{"replica1:7052", "replica2:7052", "replica3:7052"} {
+				h := &chaincode.Handler{Replica: address, TXContexts: chaincode.NewTransactionContexts()}
+				chaincode.SetHandlerChaincodeID(h, "chaincode-id")
+				err := hr.Register(h)
+				Expect(err).NotTo(HaveOccurred())
+				replicas = append(replicas, h)
+			}
+		})
+
+		It("registers a handler per replica", func() {
+			Expect(hr.Handlers("chaincode-id")).To(Equal(replicas))
+		})
+
+		It("rejects a duplicate replica", func() {
+			h := &chaincode.Handler{Replica: "replica2:7052"}
+			chaincode.SetHandlerChaincodeID(h, "chaincode-id")
+			err := hr.Register(h)
+			Expect(err).To(MatchError("duplicate chaincodeID: chaincode-id"))
+		})
+
+		It("takes turns between equally loaded replicas", func() {
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[0]))
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[1]))
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[2]))
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[0]))
+		})
+
+		It("selects the replica with the fewest transactions in flight", func() {
+			chaincode.SetHandlerInflight(replicas[0], 3)
+			chaincode.SetHandlerInflight(replicas[1], 1)
+			chaincode.SetHandlerInflight(replicas[2], 2)
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[1]))
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(replicas[1]))
+		})
+
+		Describe("DeregisterHandler", func() {
+			BeforeEach(func() {
+				_, started := hr.Launching("chaincode-id")
+				Expect(started).To(BeTrue())
+			})
+
+			It("removes the handler of the replica", func() {
+				err := hr.DeregisterHandler(replicas[1])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hr.Handlers("chaincode-id")).To(Equal([]*chaincode.Handler{replicas[0], replicas[2]}))
+
+				_, started := hr.Launching("chaincode-id")
+				Expect(started).To(BeTrue())
+			})
+
+			It("forgets the chaincode once the last replica is removed", func() {
+				for _, h := range replicas {
+					err := hr.DeregisterHandler(h)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(hr.Handler("chaincode-id")).To(BeNil())
+
+				_, started := hr.Launching("chaincode-id")
+				Expect(started).To(BeFalse())
+			})
+
+			It("returns an error when the handler is not registered", func() {
+				err := hr.DeregisterHandler(replicas[1])
+				Expect(err).NotTo(HaveOccurred())
+
+				err = hr.DeregisterHandler(replicas[1])
+				Expect(err).To(MatchError("could not find handler: chaincode-id"))
+			})
+		})
+	})
 })
 
 var _ = Describe("LaunchState", func() {
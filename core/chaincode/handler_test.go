@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
@@ -18,9 +19,14 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/fake"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/hyperledger/fabric/core/chaincode/shimpb"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/protoutil"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -46,6 +52,8 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeReplicaExecutions          *metricsfakes.Counter
+		fakeReplicaInflight            *metricsfakes.Gauge
 		fakeCapabilites                *mock.ApplicationCapabilities
 
 		responseNotifier chan *pb.ChaincodeMessage
@@ -100,14 +108,20 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeReplicaExecutions = &metricsfakes.Counter{}
+		fakeReplicaExecutions.WithReturns(fakeReplicaExecutions)
+		fakeReplicaInflight = &metricsfakes.Gauge{}
+		fakeReplicaInflight.WithReturns(fakeReplicaInflight)
 
 		builtinSCCs = map[string]struct{}{}
 
 		chaincodeMetrics := &chaincode.HandlerMetrics{
-			ShimRequestsReceived:  fakeShimRequestsReceived,
-			ShimRequestsCompleted: fakeShimRequestsCompleted,
-			ShimRequestDuration:   fakeShimRequestDuration,
-			ExecuteTimeouts:       fakeExecuteTimeouts,
+			ShimRequestsReceived:      fakeShimRequestsReceived,
+			ShimRequestsCompleted:     fakeShimRequestsCompleted,
+			ShimRequestDuration:       fakeShimRequestDuration,
+			ExecuteTimeouts:           fakeExecuteTimeouts,
+			ReplicaExecutions:         fakeReplicaExecutions,
+			ReplicaInflightExecutions: fakeReplicaInflight,
 		}
 
 		handler = &chaincode.Handler{
@@ -555,6 +569,35 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("king-kong"))
 				})
 			})
+
+			Context("when cross-channel transactions are enabled", func() {
+				BeforeEach(func() {
+					fakeCapabilites.CrossChannelTransactionsReturns(true)
+				})
+
+				It("checks that the key is not locked", func() {
+					_, err := handler.HandlePutState(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(1))
+					ns, key := fakeTxSimulator.GetStateArgsForCall(0)
+					Expect(ns).To(Equal("xcc"))
+					Expect(key).To(Equal("lock/cc-instance-name/put-state-key"))
+					Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+				})
+
+				Context("when the key is locked", func() {
+					BeforeEach(func() {
+						fakeTxSimulator.GetStateReturns([]byte("xtx-id"), nil)
+					})
+
+					It("returns an error", func() {
+						_, err := handler.HandlePutState(incomingMessage, txContext)
+						Expect(err).To(MatchError("key put-state-key is locked by cross-channel transaction xtx-id"))
+						Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 
 		Context("when the collection is provided", func() {
@@ -653,9 +696,12 @@ var _ = Describe("Handler", func() {
 			_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeApplicationConfigRetriever.GetApplicationConfigCallCount()).To(Equal(1))
+			// once for the key level endorsement and once for the cross-channel transactions
+			Expect(fakeApplicationConfigRetriever.GetApplicationConfigCallCount()).To(Equal(2))
 			cid := fakeApplicationConfigRetriever.GetApplicationConfigArgsForCall(0)
 			Expect(cid).To(Equal("channel-id"))
+			cid = fakeApplicationConfigRetriever.GetApplicationConfigArgsForCall(1)
+			Expect(cid).To(Equal("channel-id"))
 		})
 
 		Context("when getting the app config metadata fails", func() {
@@ -838,6 +884,19 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("orange"))
 				})
 			})
+
+			Context("when the key is locked by a cross-channel transaction", func() {
+				BeforeEach(func() {
+					fakeCapabilites.CrossChannelTransactionsReturns(true)
+					fakeTxSimulator.GetStateReturns([]byte("xtx-id"), nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleDelState(incomingMessage, txContext)
+					Expect(err).To(MatchError("key del-state-key is locked by cross-channel transaction xtx-id"))
+					Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		Context("when collection is set", func() {
@@ -897,6 +956,118 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandlePurgePrivateData", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.DelState
+
+		BeforeEach(func() {
+			request = &pb.DelState{
+				Key:        "purge-key",
+				Collection: "collection-name",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_Type(shimpb.ChaincodeMessageType_PURGE_PRIVATE_DATA),
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			fakeCollectionStore.RetrieveReadWritePermissionReturns(false, true, nil)
+			fakeCapabilites.PurgePvtDataReturns(true)
+		})
+
+		It("calls PurgePrivateData on the transaction simulator and returns a response message", func() {
+			resp, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.PurgePrivateDataArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("purge-key"))
+		})
+
+		Context("when purge of private data is not supported", func() {
+			BeforeEach(func() {
+				fakeCapabilites.PurgePvtDataReturns(false)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("purge of private data is not enabled, channel application capability of V3_0 or later is required"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is not set", func() {
+			BeforeEach(func() {
+				request.Collection = ""
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("only private data can be purged"))
+				Expect(fakeTxSimulator.PurgePrivateDataCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when PurgePrivateData fails due to ledger error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.PurgePrivateDataReturns(errors.New("mango"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("mango"))
+			})
+		})
+
+		Context("when called from an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when the creator does not have write access permission", func() {
+			BeforeEach(func() {
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePurgePrivateData(incomingMessage, txContext)
+				Expect(err).To(MatchError("tx creator does not have write access" +
+					" permission on privatedata in chaincodeName:cc-instance-name" +
+					" collectionName: collection-name"))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -1530,6 +1701,78 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when descending order is requested", func() {
+			var metadata *shimpb.QueryMetadata
+
+			BeforeEach(func() {
+				metadata = &shimpb.QueryMetadata{Descending: true}
+				fakeTxSimulator.GetStateReverseRangeScanIteratorReturns(fakeIterator, nil)
+				fakeTxSimulator.GetStateReverseRangeScanIteratorWithPaginationReturns(fakeIterator, nil)
+			})
+
+			JustBeforeEach(func() {
+				metadataBytes, err := proto.Marshal(metadata)
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadataBytes
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("calls GetStateReverseRangeScanIterator on the transaction simulator", func() {
+				resp, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(expectedResponse))
+
+				Expect(fakeTxSimulator.GetStateRangeScanIteratorCallCount()).To(Equal(0))
+				Expect(fakeTxSimulator.GetStateReverseRangeScanIteratorCallCount()).To(Equal(1))
+				ccname, startKey, endKey := fakeTxSimulator.GetStateReverseRangeScanIteratorArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(startKey).To(Equal("get-state-start-key"))
+				Expect(endKey).To(Equal("get-state-end-key"))
+			})
+
+			Context("and pagination is requested", func() {
+				BeforeEach(func() {
+					metadata = &shimpb.QueryMetadata{PageSize: 10, Bookmark: "bookmark-key", Descending: true}
+				})
+
+				It("calls GetStateReverseRangeScanIteratorWithPagination on the transaction simulator", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.GetStateReverseRangeScanIteratorWithPaginationCallCount()).To(Equal(1))
+					ccname, startKey, endKey, pageSize := fakeTxSimulator.GetStateReverseRangeScanIteratorWithPaginationArgsForCall(0)
+					Expect(ccname).To(Equal("cc-instance-name"))
+					Expect(startKey).To(Equal("bookmark-key"))
+					Expect(endKey).To(Equal("get-state-end-key"))
+					Expect(pageSize).To(Equal(int32(10)))
+				})
+			})
+
+			Context("and collection is set", func() {
+				BeforeEach(func() {
+					request.Collection = "collection-name"
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("descending range queries are not supported on private data"))
+				})
+			})
+
+			Context("and GetStateReverseRangeScanIterator fails", func() {
+				BeforeEach(func() {
+					fakeTxSimulator.GetStateReverseRangeScanIteratorReturns(nil, errors.New("potato"))
+				})
+
+				It("returns the error from GetStateReverseRangeScanIterator", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("potato"))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -2291,6 +2534,123 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("razzies"))
 				})
 			})
+
+			Context("when cross-channel transactions are enabled", func() {
+				var targetRWSet *rwsetutil.RWSetBuilder
+
+				BeforeEach(func() {
+					fakeCapabilites.CrossChannelTransactionsReturns(true)
+					handler.CrossChannelTimeoutBlocks = 10
+					txContext.CrossChannelWrites = &ccprovider.CrossChannelWrites{
+						ChannelID:   "channel-id",
+						TXSimulator: fakeTxSimulator,
+					}
+					expectedProposal.Header = protoutil.MarshalOrPanic(&cb.Header{
+						ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+							ChannelId: "channel-id",
+						}),
+						SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: []byte("creator"),
+						}),
+					})
+
+					targetRWSet = rwsetutil.NewRWSetBuilder()
+					targetRWSet.AddToWriteSet("target-chaincode-name", "key", []byte("value"))
+					newTxSimulator.GetTxSimulationResultsStub = func() (*ledger.TxSimulationResults, error) {
+						return targetRWSet.GetTxSimulationResults()
+					}
+
+					responseMessage.Type = pb.ChaincodeMessage_COMPLETED
+					responseMessage.Payload = protoutil.MarshalOrPanic(&pb.Response{Status: 200})
+				})
+
+				It("records the writes on the target channel in the cross-channel transaction", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+					ns, key, value := fakeTxSimulator.SetStateArgsForCall(0)
+					Expect(ns).To(Equal("xcc"))
+					Expect(key).To(Equal("xtx/tx-id"))
+					record, err := crosschannel.UnmarshalTransaction(value)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(record.Id).To(Equal("tx-id"))
+					Expect(record.Coordinator).To(Equal("channel-id"))
+					Expect(record.Participants).To(Equal([]string{"target-channel-id"}))
+					Expect(record.State).To(Equal(crosschannel.State_PREPARED))
+					Expect(record.Creator).To(Equal([]byte("creator")))
+					Expect(record.TimeoutBlocks).To(Equal(uint64(10)))
+					Expect(record.Deadline).To(BeZero())
+
+					results, err := targetRWSet.GetTxSimulationResults()
+					Expect(err).NotTo(HaveOccurred())
+					expectedRWSet, err := results.GetPubSimulationBytes()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(record.ParticipantRwsets).To(HaveLen(1))
+					Expect(record.ParticipantRwsets[0].Channel).To(Equal("target-channel-id"))
+					Expect(record.ParticipantRwsets[0].Rwset).To(Equal(expectedRWSet))
+				})
+
+				Context("when the target chaincode does not write", func() {
+					BeforeEach(func() {
+						targetRWSet = rwsetutil.NewRWSetBuilder()
+						targetRWSet.AddToReadSet("target-chaincode-name", "key", nil)
+					})
+
+					It("does not record a cross-channel transaction", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the target chaincode returns an error", func() {
+					BeforeEach(func() {
+						responseMessage.Payload = protoutil.MarshalOrPanic(&pb.Response{Status: 500})
+					})
+
+					It("does not record a cross-channel transaction", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(newTxSimulator.GetTxSimulationResultsCallCount()).To(Equal(0))
+						Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when the target chaincode writes private data", func() {
+					BeforeEach(func() {
+						targetRWSet.AddToPvtAndHashedWriteSet("target-chaincode-name", "collection", "key", []byte("value"))
+					})
+
+					It("returns an error", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).To(MatchError("private data is not supported in cross-channel transactions (channel target-channel-id)"))
+					})
+				})
+
+				Context("when the target channel is already modified by the transaction", func() {
+					It("returns an error", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						_, err = handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).To(MatchError("channel target-channel-id is already modified by another chaincode invocation of this transaction"))
+					})
+				})
+
+				Context("when the target channel does not support cross-channel transactions", func() {
+					BeforeEach(func() {
+						fakeCapabilites.CrossChannelTransactionsStub = func() bool {
+							return fakeCapabilites.CrossChannelTransactionsCallCount() == 1
+						}
+					})
+
+					It("discards the writes", func() {
+						_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+					})
+				})
+			})
 		})
 
 		Context("when the target is a system chaincode", func() {
@@ -2449,6 +2809,25 @@ var _ = Describe("Handler", func() {
 			Expect(resp).To(Equal(&pb.ChaincodeMessage{Txid: "a-transaction-id"}))
 		})
 
+		It("records the execution on the replica", func() {
+			handler.Replica = "replica-address"
+			close(responseNotifier)
+			handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
+
+			Expect(fakeReplicaInflight.SetCallCount()).To(Equal(2))
+			Expect(fakeReplicaInflight.WithArgsForCall(0)).To(Equal([]string{"chaincode", "test-handler-name:1.0", "replica", "replica-address"}))
+			Expect(fakeReplicaInflight.SetArgsForCall(0)).To(Equal(1.0))
+			Expect(fakeReplicaInflight.SetArgsForCall(1)).To(Equal(0.0))
+
+			Expect(fakeReplicaExecutions.WithCallCount()).To(Equal(1))
+			Expect(fakeReplicaExecutions.WithArgsForCall(0)).To(Equal([]string{
+				"chaincode", "test-handler-name:1.0",
+				"replica", "replica-address",
+				"success", "true",
+			}))
+			Expect(fakeReplicaExecutions.AddCallCount()).To(Equal(1))
+		})
+
 		It("deletes the transaction context", func() {
 			close(responseNotifier)
 			handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
@@ -2582,6 +2961,19 @@ var _ = Describe("Handler", func() {
 				Expect(fakeExecuteTimeouts.AddArgsForCall(0)).To(BeNumerically("~", 1.0))
 			})
 
+			It("records a failed execution on the replica", func() {
+				handler.Replica = "replica-address"
+				handler.Execute(txParams, "chaincode-name", incomingMessage, time.Millisecond)
+
+				Expect(fakeReplicaExecutions.WithCallCount()).To(Equal(1))
+				Expect(fakeReplicaExecutions.WithArgsForCall(0)).To(Equal([]string{
+					"chaincode", "test-handler-name:1.0",
+					"replica", "replica-address",
+					"success", "false",
+				}))
+				Expect(fakeReplicaExecutions.AddCallCount()).To(Equal(1))
+			})
+
 			It("deletes the transaction context", func() {
 				handler.Execute(txParams, "chaincode-name", incomingMessage, time.Millisecond)
 
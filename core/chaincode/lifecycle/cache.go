@@ -224,12 +224,7 @@ func (c *Cache) HandleChaincodeInstalled(md *persistence.ChaincodePackageMetadat
 }
 
 func (c *Cache) handleChaincodeInstalledWhileLocked(initializing bool, md *persistence.ChaincodePackageMetadata, packageID string) {
-	// it would be nice to get this value from the serialization package, but it was not obvious
-	// how to expose this in a nice way, so we manually compute it.
-	encodedCCHash := protoutil.MarshalOrPanic(&lb.StateData{
-		Type: &lb.StateData_String_{String_: packageID},
-	})
-	hashOfCCHash := string(util.ComputeSHA256(encodedCCHash))
+	hashOfCCHash := localChaincodeKey(packageID)
 	localChaincode, ok := c.localChaincodes[hashOfCCHash]
 	if !ok {
 		localChaincode = &LocalChaincode{
@@ -258,6 +253,44 @@ func (c *Cache) handleChaincodeInstalledWhileLocked(initializing bool, md *persi
 	}
 }
 
+// HandleChaincodeUninstalled should be invoked whenever a chaincode is uninstalled
+func (c *Cache) HandleChaincodeUninstalled(packageID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	hashOfCCHash := localChaincodeKey(packageID)
+	localChaincode, ok := c.localChaincodes[hashOfCCHash]
+	if !ok {
+		return
+	}
+
+	if len(localChaincode.References) == 0 {
+		delete(c.localChaincodes, hashOfCCHash)
+		return
+	}
+
+	// a chaincode definition referencing the package was committed concurrently,
+	// keep track of it in case the package is installed again
+	localChaincode.Info = nil
+	for _, channelCache := range localChaincode.References {
+		for _, cachedChaincode := range channelCache {
+			cachedChaincode.InstallInfo = nil
+		}
+	}
+	c.handleMetadataUpdates(localChaincode)
+}
+
+// localChaincodeKey returns the key of the local chaincode with the package ID,
+// which is the hash of the package ID as stored in the org's implicit collection.
+func localChaincodeKey(packageID string) string {
+	// it would be nice to get this value from the serialization package, but it was not obvious
+	// how to expose this in a nice way, so we manually compute it.
+	encodedCCHash := protoutil.MarshalOrPanic(&lb.StateData{
+		Type: &lb.StateData_String_{String_: packageID},
+	})
+	return string(util.ComputeSHA256(encodedCCHash))
+}
+
 // HandleStateUpdates is required to implement the ledger state listener interface.  It applies
 // any state updates to the cache.
 func (c *Cache) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
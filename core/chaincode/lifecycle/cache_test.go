@@ -337,6 +337,50 @@ var _ = Describe("Cache", func() {
 		})
 	})
 
+	Describe("HandleChaincodeUninstalled", func() {
+		It("forgets the installed chaincode", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			_, err := c.GetInstalledChaincode("packageID")
+			Expect(err).To(MatchError("could not find chaincode with package id 'packageID'"))
+			Expect(c.ListInstalledChaincodes()).To(BeEmpty())
+		})
+
+		It("keeps the chaincode definitions which reference the package", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
+			Expect(fakeMetadataHandler.UpdateMetadataCallCount()).NotTo(BeZero())
+
+			c.HandleChaincodeInstalled(&persistence.ChaincodePackageMetadata{
+				Type:  "cc-type",
+				Path:  "cc-path",
+				Label: "chaincode-label",
+			}, "packageID")
+			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).NotTo(BeNil())
+		})
+
+		Context("when the chaincode is not referenced", func() {
+			BeforeEach(func() {
+				c.HandleChaincodeInstalled(&persistence.ChaincodePackageMetadata{
+					Label: "unreferenced-label",
+				}, "unreferenced-packageID")
+			})
+
+			It("forgets the chaincode", func() {
+				c.HandleChaincodeUninstalled("unreferenced-packageID")
+				_, err := c.GetInstalledChaincode("unreferenced-packageID")
+				Expect(err).To(MatchError("could not find chaincode with package id 'unreferenced-packageID'"))
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the chaincode is not known", func() {
+			It("does nothing", func() {
+				c.HandleChaincodeUninstalled("unknown-packageID")
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+			})
+		})
+	})
+
 	Describe("InitializeLocalChaincodes", func() {
 		It("loads the already installed chaincodes into the cache", func() {
 			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
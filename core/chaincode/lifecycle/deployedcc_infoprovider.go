@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	validationState "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/protoutil"
@@ -324,6 +325,16 @@ func (vc *ValidatorCommitter) ImplicitCollectionEndorsementPolicyAsBytes(channel
 // if the unexpected error is not nil and mark the transaction as invalid if the validation
 // error is not nil.
 func (vc *ValidatorCommitter) ValidationInfo(channelID, chaincodeName string, qe ledger.SimpleQueryExecutor) (plugin string, args []byte, unexpectedErr error, validationErr error) {
+	if chaincodeName == crosschannel.Namespace && vc.CrossChannelTransactions(channelID) {
+		// the records of the cross-channel transactions are written by the xcc system
+		// chaincode and must satisfy the endorsement policy of the channel
+		return "vscc", protoutil.MarshalOrPanic(&cb.ApplicationPolicy{
+			Type: &cb.ApplicationPolicy_ChannelConfigPolicyReference{
+				ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+			},
+		}), nil, nil
+	}
+
 	// TODO, this is a bit of an overkill check, and will need to be scaled back for non-chaincode type namespaces
 	exists, definedChaincode, err := vc.Resources.ChaincodeDefinitionIfDefined(chaincodeName, &SimpleQueryExecutorShim{
 		Namespace:           LifecycleNamespace,
@@ -351,6 +362,20 @@ func (vc *ValidatorCommitter) ValidationInfo(channelID, chaincodeName string, qe
 	return definedChaincode.ValidationInfo.ValidationPlugin, definedChaincode.ValidationInfo.ValidationParameter, nil, nil
 }
 
+// CrossChannelTransactions returns true if the channel supports the transactions that
+// atomically modify the state of several channels
+func (vc *ValidatorCommitter) CrossChannelTransactions(channelID string) bool {
+	channelConfig := vc.Resources.ChannelConfigSource.GetStableChannelConfig(channelID)
+	if channelConfig == nil {
+		return false
+	}
+	ac, ok := channelConfig.ApplicationConfig()
+	if !ok {
+		return false
+	}
+	return ac.Capabilities().CrossChannelTransactions()
+}
+
 // CollectionValidationInfo returns information about collections to the validation component
 func (vc *ValidatorCommitter) CollectionValidationInfo(channelID, chaincodeName, collectionName string, state validationState.State) (args []byte, unexpectedErr, validationErr error) {
 	exists, definedChaincode, err := vc.Resources.ChaincodeDefinitionIfDefined(chaincodeName, &ValidatorStateShim{
@@ -667,6 +667,42 @@ var _ = Describe("ValidatorCommitter", func() {
 			})
 		})
 
+		Context("when the chaincode in question is xcc", func() {
+			var fakeCapabilities *mock.ApplicationCapabilities
+
+			BeforeEach(func() {
+				fakeCapabilities = &mock.ApplicationCapabilities{}
+				fakeCapabilities.CrossChannelTransactionsReturns(true)
+				fakeApplicationConfig.CapabilitiesReturns(fakeCapabilities)
+			})
+
+			It("returns the builtin plugin and the channel endorsement policy", func() {
+				vPlugin, vParm, uerr, verr := vc.ValidationInfo("channel-id", "xcc", fakeQueryExecutor)
+				Expect(uerr).NotTo(HaveOccurred())
+				Expect(verr).NotTo(HaveOccurred())
+				Expect(vPlugin).To(Equal("vscc"))
+				Expect(vParm).To(Equal(protoutil.MarshalOrPanic(&cb.ApplicationPolicy{
+					Type: &cb.ApplicationPolicy_ChannelConfigPolicyReference{
+						ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+					},
+				})))
+			})
+
+			Context("when the channel does not support cross-channel transactions", func() {
+				BeforeEach(func() {
+					fakeCapabilities.CrossChannelTransactionsReturns(false)
+				})
+
+				It("passes through to the legacy impl", func() {
+					vPlugin, vParm, uerr, verr := vc.ValidationInfo("channel-id", "xcc", fakeQueryExecutor)
+					Expect(vPlugin).To(BeEmpty())
+					Expect(vParm).To(BeNil())
+					Expect(uerr).NotTo(HaveOccurred())
+					Expect(verr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		Context("when the ledger returns an error", func() {
 			BeforeEach(func() {
 				fakeQueryExecutor.GetStateReturns(nil, fmt.Errorf("state-error"))
@@ -180,6 +180,31 @@ func (pqes *PrivateQueryExecutorShim) CollectionName() string {
 	return pqes.Collection
 }
 
+type PrivateDataQueryExecutor interface {
+	GetPrivateData(namespace, collection, key string) ([]byte, error)
+	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error)
+}
+
+// PrivateDataQueryExecutorShim implements the ReadableState and RangeableState interfaces
+// for the keys in a collection, based on an underlying PrivateDataQueryExecutor
+type PrivateDataQueryExecutorShim struct {
+	Namespace  string
+	Collection string
+	State      PrivateDataQueryExecutor
+}
+
+func (pdqes *PrivateDataQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return pdqes.State.GetPrivateData(pdqes.Namespace, pdqes.Collection, key)
+}
+
+func (pdqes *PrivateDataQueryExecutorShim) GetStateRange(prefix string) (map[string][]byte, error) {
+	itr, err := pdqes.State.GetPrivateDataRangeScanIterator(pdqes.Namespace, pdqes.Collection, prefix, prefix+"\x7f")
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not get private data iterator")
+	}
+	return StateIteratorToMap(&ResultsIteratorShim{ResultsIterator: itr})
+}
+
 // DummyQueryExecutorShim implements the ReadableState interface. It is
 // used to ensure channel-less system chaincode calls don't panic and return
 // and error when an invalid operation is attempted (i.e. an InstallChaincode
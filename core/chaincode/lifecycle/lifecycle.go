@@ -9,6 +9,9 @@ package lifecycle
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -20,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protoutil"
 
 	"github.com/golang/protobuf/proto"
@@ -223,6 +227,38 @@ type InstalledChaincodesLister interface {
 	GetInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error)
 }
 
+//go:generate counterfeiter -o mock/uninstall_listener.go --fake-name UninstallListener . UninstallListener
+type UninstallListener interface {
+	HandleChaincodeUninstalled(packageID string)
+}
+
+//go:generate counterfeiter -o mock/chaincode_purger.go --fake-name ChaincodePurger . ChaincodePurger
+
+// ChaincodePurger stops a chaincode and removes the outputs of its build.
+type ChaincodePurger interface {
+	Purge(ccid string) error
+}
+
+//go:generate counterfeiter -o mock/channel_ledger_source.go --fake-name ChannelLedgerSource . ChannelLedgerSource
+
+// ChannelLedgerSource provides access to the ledgers of the channels joined by the peer.
+type ChannelLedgerSource interface {
+	// ChannelIDs returns the IDs of the channels joined by the peer.
+	ChannelIDs() []string
+
+	// NewQueryExecutor returns a query executor for the ledger of the channel.
+	NewQueryExecutor(channelID string) (ChannelQueryExecutor, error)
+}
+
+//go:generate counterfeiter -o mock/channel_query_executor.go --fake-name ChannelQueryExecutor . ChannelQueryExecutor
+
+// ChannelQueryExecutor reads the public state and the private data of a channel.
+type ChannelQueryExecutor interface {
+	ledger.SimpleQueryExecutor
+	PrivateDataQueryExecutor
+	Done()
+}
+
 // Resources stores the common functions needed by all components of the lifecycle
 // by the SCC as well as internally.  It also has some utility methods attached to it
 // for querying the lifecycle definitions.
@@ -306,11 +342,15 @@ func (r *Resources) LifecycleEndorsementPolicyAsBytes(channelID string) ([]byte,
 type ExternalFunctions struct {
 	Resources                 *Resources
 	InstallListener           InstallListener
+	UninstallListener         UninstallListener
 	InstalledChaincodesLister InstalledChaincodesLister
 	ChaincodeBuilder          ChaincodeBuilder
+	ChaincodePurger           ChaincodePurger
 	BuildRegistry             *container.BuildRegistry
+	ChannelLedgers            ChannelLedgerSource
+	OrgMSPID                  string
 	mutex                     sync.Mutex
-	BuildLocks                map[string]sync.Mutex
+	BuildLocks                map[string]*sync.Mutex
 }
 
 // CheckCommitReadiness takes a chaincode definition, checks that
@@ -711,20 +751,149 @@ func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*
 	}, nil
 }
 
+// UninstallChaincode removes the chaincode with the given package ID from the peer's
+// chaincode store, after stopping the chaincode and purging its build outputs. It refuses
+// to remove a package referenced by a chaincode definition approved by the peer's org,
+// whether committed or not, on any of the channels joined by the peer.
+func (ef *ExternalFunctions) UninstallChaincode(packageID string) error {
+	// hold the build lock from the checks for references to the package to its
+	// deletion, so that the package is not built or uninstalled concurrently
+	buildLock := ef.getBuildLock(packageID)
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	installedChaincode, err := ef.InstalledChaincodesLister.GetInstalledChaincode(packageID)
+	if err != nil {
+		return persistence.CodePackageNotFoundErr{PackageID: packageID}
+	}
+
+	if len(installedChaincode.References) != 0 {
+		channels := make([]string, 0, len(installedChaincode.References))
+		for channelID := range installedChaincode.References {
+			channels = append(channels, channelID)
+		}
+		sort.Strings(channels)
+		return errors.Errorf("chaincode with package ID '%s' is in use by committed chaincode definitions on channels [%s]", packageID, strings.Join(channels, ", "))
+	}
+
+	approvals, err := ef.approvalsReferencingPackage(packageID)
+	if err != nil {
+		return errors.WithMessage(err, "could not check the approved chaincode definitions")
+	}
+	if len(approvals) != 0 {
+		return errors.Errorf("chaincode with package ID '%s' is in use by approved chaincode definitions [%s]", packageID, strings.Join(approvals, ", "))
+	}
+
+	if err := ef.ChaincodePurger.Purge(packageID); err != nil {
+		return errors.WithMessage(err, "could not purge chaincode")
+	}
+	ef.BuildRegistry.RemoveBuildStatus(packageID)
+
+	if err := ef.Resources.ChaincodeStore.Delete(packageID); err != nil {
+		return errors.WithMessage(err, "could not delete cc install package")
+	}
+
+	if ef.UninstallListener != nil {
+		ef.UninstallListener.HandleChaincodeUninstalled(packageID)
+	}
+
+	logger.Infof("Successfully uninstalled chaincode with package ID '%s'", packageID)
+
+	return nil
+}
+
+// approvalsReferencingPackage returns the chaincode definitions approved by the peer's
+// org with the package, on the channels joined by the peer, in the form
+// '<channel>/<chaincode>#<sequence>'. Approvals for sequences lower than the
+// committed sequence can no longer be committed and are ignored.
+func (ef *ExternalFunctions) approvalsReferencingPackage(packageID string) ([]string, error) {
+	var approvals []string
+	for _, channelID := range ef.ChannelLedgers.ChannelIDs() {
+		qe, err := ef.ChannelLedgers.NewQueryExecutor(channelID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not get query executor for channel '%s'", channelID)
+		}
+		channelApprovals, err := ef.channelApprovalsReferencingPackage(packageID, qe)
+		qe.Done()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not query approvals on channel '%s'", channelID)
+		}
+		for _, approval := range channelApprovals {
+			approvals = append(approvals, channelID+"/"+approval)
+		}
+	}
+	sort.Strings(approvals)
+	return approvals, nil
+}
+
+func (ef *ExternalFunctions) channelApprovalsReferencingPackage(packageID string, qe ChannelQueryExecutor) ([]string, error) {
+	publicState := &SimpleQueryExecutorShim{
+		Namespace:           LifecycleNamespace,
+		SimpleQueryExecutor: qe,
+	}
+
+	orgState := &PrivateDataQueryExecutorShim{
+		Namespace:  LifecycleNamespace,
+		Collection: ImplicitCollectionNameForOrg(ef.OrgMSPID),
+		State:      qe,
+	}
+
+	metadatas, err := ef.Resources.Serializer.DeserializeAllMetadata(ChaincodeSourcesName, orgState)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not query chaincode-source metadata")
+	}
+
+	var approvals []string
+	for privateName, metadata := range metadatas {
+		if metadata.Datatype != ChaincodeLocalPackageType {
+			continue
+		}
+
+		i := strings.LastIndex(privateName, "#")
+		if i < 0 {
+			continue
+		}
+		ccname := privateName[:i]
+		sequence, err := strconv.ParseInt(privateName[i+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		currentSequence, err := ef.Resources.Serializer.DeserializeFieldAsInt64(NamespacesName, ccname, "Sequence", publicState)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not get current sequence for chaincode '%s'", ccname)
+		}
+		if sequence < currentSequence {
+			continue
+		}
+
+		ccLocalPackage := &ChaincodeLocalPackage{}
+		if err := ef.Resources.Serializer.Deserialize(ChaincodeSourcesName, privateName, metadata, ccLocalPackage, orgState); err != nil {
+			return nil, errors.WithMessagef(err, "could not deserialize chaincode package for %s", privateName)
+		}
+		if ccLocalPackage.PackageID == packageID {
+			approvals = append(approvals, privateName)
+		}
+	}
+
+	return approvals, nil
+}
+
 func (ef *ExternalFunctions) getBuildLock(packageID string) *sync.Mutex {
 	ef.mutex.Lock()
 	defer ef.mutex.Unlock()
 
 	if ef.BuildLocks == nil {
-		ef.BuildLocks = map[string]sync.Mutex{}
+		ef.BuildLocks = map[string]*sync.Mutex{}
 	}
 
 	buildLock, ok := ef.BuildLocks[packageID]
 	if !ok {
-		ef.BuildLocks[packageID] = sync.Mutex{}
+		buildLock = &sync.Mutex{}
+		ef.BuildLocks[packageID] = buildLock
 	}
 
-	return &buildLock
+	return buildLock
 }
 
 // GetInstalledChaincodePackage retrieves the installed chaincode with the given package ID
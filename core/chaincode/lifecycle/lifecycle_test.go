@@ -8,13 +8,16 @@ package lifecycle_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
@@ -478,6 +481,228 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("UninstallChaincode", func() {
+		var (
+			fakePurger            *mock.ChaincodePurger
+			fakeUninstallListener *mock.UninstallListener
+			fakeChannelLedgers    *mock.ChannelLedgerSource
+			fakeQueryExecutor     *mock.ChannelQueryExecutor
+			fakePublicState       MapLedgerShim
+			fakeOrgState          MapLedgerShim
+		)
+
+		BeforeEach(func() {
+			fakePurger = &mock.ChaincodePurger{}
+			fakeUninstallListener = &mock.UninstallListener{}
+			fakeChannelLedgers = &mock.ChannelLedgerSource{}
+			fakeQueryExecutor = &mock.ChannelQueryExecutor{}
+			fakeChannelLedgers.ChannelIDsReturns([]string{"channel-id"})
+			fakeChannelLedgers.NewQueryExecutorReturns(fakeQueryExecutor, nil)
+
+			fakePublicState = MapLedgerShim(map[string][]byte{})
+			fakeOrgState = MapLedgerShim(map[string][]byte{})
+			fakeQueryExecutor.GetStateStub = func(namespace, key string) ([]byte, error) {
+				return fakePublicState.GetState(key)
+			}
+			fakeQueryExecutor.GetPrivateDataStub = func(namespace, collection, key string) ([]byte, error) {
+				Expect(collection).To(Equal("_implicit_org_my-mspid"))
+				return fakeOrgState.GetState(key)
+			}
+			fakeQueryExecutor.GetPrivateDataRangeScanIteratorStub = func(namespace, collection, begin, end string) (commonledger.ResultsIterator, error) {
+				fakeResultsIterator := &mock.ResultsIterator{}
+				i := 0
+				for key, value := range fakeOrgState {
+					if key >= begin && key < end {
+						fakeResultsIterator.NextReturnsOnCall(i, &queryresult.KV{
+							Key:   key,
+							Value: value,
+						}, nil)
+						i++
+					}
+				}
+				return fakeResultsIterator, nil
+			}
+
+			ef.ChaincodePurger = fakePurger
+			ef.UninstallListener = fakeUninstallListener
+			ef.ChannelLedgers = fakeChannelLedgers
+			ef.OrgMSPID = "my-mspid"
+
+			fakeLister.GetInstalledChaincodeReturns(&chaincode.InstalledChaincode{
+				Label:     "cc-label",
+				PackageID: "package-id",
+			}, nil)
+
+			bs, _ := ef.BuildRegistry.BuildStatus("package-id")
+			bs.Notify(nil)
+		})
+
+		It("purges and deletes the chaincode", func() {
+			err := ef.UninstallChaincode("package-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLister.GetInstalledChaincodeArgsForCall(0)).To(Equal("package-id"))
+			Expect(fakePurger.PurgeCallCount()).To(Equal(1))
+			Expect(fakePurger.PurgeArgsForCall(0)).To(Equal("package-id"))
+			Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeCCStore.DeleteArgsForCall(0)).To(Equal("package-id"))
+			Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(1))
+			Expect(fakeUninstallListener.HandleChaincodeUninstalledArgsForCall(0)).To(Equal("package-id"))
+			Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(1))
+
+			_, ok := ef.BuildRegistry.BuildStatus("package-id")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("holds the build lock of the package from the reference checks to the deletion", func() {
+			locked := func() bool {
+				buildLock := ef.BuildLocks["package-id"]
+				acquired := make(chan struct{})
+				go func() {
+					buildLock.Lock()
+					buildLock.Unlock()
+					close(acquired)
+				}()
+				select {
+				case <-acquired:
+					return false
+				case <-time.After(100 * time.Millisecond):
+					return true
+				}
+			}
+			fakeLister.GetInstalledChaincodeStub = func(packageID string) (*chaincode.InstalledChaincode, error) {
+				Expect(locked()).To(BeTrue())
+				return &chaincode.InstalledChaincode{Label: "cc-label", PackageID: "package-id"}, nil
+			}
+			fakeCCStore.DeleteStub = func(packageID string) error {
+				Expect(locked()).To(BeTrue())
+				return nil
+			}
+
+			err := ef.UninstallChaincode("package-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locked()).To(BeFalse())
+		})
+
+		Context("when the chaincode is not installed", func() {
+			BeforeEach(func() {
+				fakeLister.GetInstalledChaincodeReturns(nil, fmt.Errorf("not-found"))
+			})
+
+			It("returns a not found error", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(Equal(persistence.CodePackageNotFoundErr{PackageID: "package-id"}))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the chaincode is referenced by committed definitions", func() {
+			BeforeEach(func() {
+				fakeLister.GetInstalledChaincodeReturns(&chaincode.InstalledChaincode{
+					Label:     "cc-label",
+					PackageID: "package-id",
+					References: map[string][]*chaincode.Metadata{
+						"channel-id":      {{Name: "cc-name", Version: "1.0"}},
+						"another-channel": {{Name: "cc-name", Version: "1.0"}},
+					},
+				}, nil)
+			})
+
+			It("refuses to uninstall it", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("chaincode with package ID 'package-id' is in use by committed chaincode definitions on channels [another-channel, channel-id]"))
+				Expect(fakePurger.PurgeCallCount()).To(Equal(0))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the chaincode is referenced by approved definitions", func() {
+			BeforeEach(func() {
+				resources.Serializer.Serialize(lifecycle.NamespacesName, "cc-name", &lifecycle.ChaincodeDefinition{
+					Sequence: 2,
+				}, fakePublicState)
+				resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "cc-name#1", &lifecycle.ChaincodeLocalPackage{
+					PackageID: "package-id",
+				}, fakeOrgState)
+				resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "cc-name#3", &lifecycle.ChaincodeLocalPackage{
+					PackageID: "package-id",
+				}, fakeOrgState)
+				resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "other-cc#1", &lifecycle.ChaincodeLocalPackage{
+					PackageID: "other-package-id",
+				}, fakeOrgState)
+			})
+
+			It("refuses to uninstall it", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("chaincode with package ID 'package-id' is in use by approved chaincode definitions [channel-id/cc-name#3]"))
+				Expect(fakePurger.PurgeCallCount()).To(Equal(0))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+
+			Context("when the approvals are superseded by a committed definition", func() {
+				BeforeEach(func() {
+					resources.Serializer.Serialize(lifecycle.NamespacesName, "cc-name", &lifecycle.ChaincodeDefinition{
+						Sequence: 4,
+					}, fakePublicState)
+				})
+
+				It("uninstalls it", func() {
+					err := ef.UninstallChaincode("package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the query executor cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakeChannelLedgers.NewQueryExecutorReturns(nil, fmt.Errorf("fake-qe-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("could not check the approved chaincode definitions: could not get query executor for channel 'channel-id': fake-qe-error"))
+			})
+		})
+
+		Context("when the org state cannot be queried", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetPrivateDataRangeScanIteratorReturns(nil, fmt.Errorf("fake-range-error"))
+				fakeQueryExecutor.GetPrivateDataRangeScanIteratorStub = nil
+			})
+
+			It("wraps and returns the error", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("could not check the approved chaincode definitions: could not query approvals on channel 'channel-id': could not query chaincode-source metadata: could not get state range for namespace chaincode-sources: could not get private data iterator: fake-range-error"))
+				Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when purging the chaincode fails", func() {
+			BeforeEach(func() {
+				fakePurger.PurgeReturns(fmt.Errorf("fake-purge-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("could not purge chaincode: fake-purge-error"))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when deleting the chaincode fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.DeleteReturns(fmt.Errorf("fake-delete-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				err := ef.UninstallChaincode("package-id")
+				Expect(err).To(MatchError("could not delete cc install package: fake-delete-error"))
+				Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("QueryInstalledChaincode", func() {
 		BeforeEach(func() {
 			fakeLister.GetInstalledChaincodeReturns(&chaincode.InstalledChaincode{
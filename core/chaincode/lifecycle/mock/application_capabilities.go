@@ -26,6 +26,16 @@ type ApplicationCapabilities struct {
 	collectionUpgradeReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	CrossChannelTransactionsStub        func() bool
+	crossChannelTransactionsMutex       sync.RWMutex
+	crossChannelTransactionsArgsForCall []struct {
+	}
+	crossChannelTransactionsReturns struct {
+		result1 bool
+	}
+	crossChannelTransactionsReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ForbidDuplicateTXIdInBlockStub        func() bool
 	forbidDuplicateTXIdInBlockMutex       sync.RWMutex
 	forbidDuplicateTXIdInBlockArgsForCall []struct {
@@ -76,6 +86,16 @@ type ApplicationCapabilities struct {
 	privateChannelDataReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PurgePvtDataStub        func() bool
+	purgePvtDataMutex       sync.RWMutex
+	purgePvtDataArgsForCall []struct {
+	}
+	purgePvtDataReturns struct {
+		result1 bool
+	}
+	purgePvtDataReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	StorePvtDataOfInvalidTxStub        func() bool
 	storePvtDataOfInvalidTxMutex       sync.RWMutex
 	storePvtDataOfInvalidTxArgsForCall []struct {
@@ -244,6 +264,58 @@ func (fake *ApplicationCapabilities) CollectionUpgradeReturnsOnCall(i int, resul
 	}{result1}
 }
 
+func (fake *ApplicationCapabilities) CrossChannelTransactions() bool {
+	fake.crossChannelTransactionsMutex.Lock()
+	ret, specificReturn := fake.crossChannelTransactionsReturnsOnCall[len(fake.crossChannelTransactionsArgsForCall)]
+	fake.crossChannelTransactionsArgsForCall = append(fake.crossChannelTransactionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CrossChannelTransactions", []interface{}{})
+	fake.crossChannelTransactionsMutex.Unlock()
+	if fake.CrossChannelTransactionsStub != nil {
+		return fake.CrossChannelTransactionsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.crossChannelTransactionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplicationCapabilities) CrossChannelTransactionsCallCount() int {
+	fake.crossChannelTransactionsMutex.RLock()
+	defer fake.crossChannelTransactionsMutex.RUnlock()
+	return len(fake.crossChannelTransactionsArgsForCall)
+}
+
+func (fake *ApplicationCapabilities) CrossChannelTransactionsCalls(stub func() bool) {
+	fake.crossChannelTransactionsMutex.Lock()
+	defer fake.crossChannelTransactionsMutex.Unlock()
+	fake.CrossChannelTransactionsStub = stub
+}
+
+func (fake *ApplicationCapabilities) CrossChannelTransactionsReturns(result1 bool) {
+	fake.crossChannelTransactionsMutex.Lock()
+	defer fake.crossChannelTransactionsMutex.Unlock()
+	fake.CrossChannelTransactionsStub = nil
+	fake.crossChannelTransactionsReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ApplicationCapabilities) CrossChannelTransactionsReturnsOnCall(i int, result1 bool) {
+	fake.crossChannelTransactionsMutex.Lock()
+	defer fake.crossChannelTransactionsMutex.Unlock()
+	fake.CrossChannelTransactionsStub = nil
+	if fake.crossChannelTransactionsReturnsOnCall == nil {
+		fake.crossChannelTransactionsReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.crossChannelTransactionsReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ApplicationCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	fake.forbidDuplicateTXIdInBlockMutex.Lock()
 	ret, specificReturn := fake.forbidDuplicateTXIdInBlockReturnsOnCall[len(fake.forbidDuplicateTXIdInBlockArgsForCall)]
@@ -504,6 +576,58 @@ func (fake *ApplicationCapabilities) PrivateChannelDataReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ApplicationCapabilities) PurgePvtData() bool {
+	fake.purgePvtDataMutex.Lock()
+	ret, specificReturn := fake.purgePvtDataReturnsOnCall[len(fake.purgePvtDataArgsForCall)]
+	fake.purgePvtDataArgsForCall = append(fake.purgePvtDataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PurgePvtData", []interface{}{})
+	fake.purgePvtDataMutex.Unlock()
+	if fake.PurgePvtDataStub != nil {
+		return fake.PurgePvtDataStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePvtDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataCallCount() int {
+	fake.purgePvtDataMutex.RLock()
+	defer fake.purgePvtDataMutex.RUnlock()
+	return len(fake.purgePvtDataArgsForCall)
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataCalls(stub func() bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = stub
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataReturns(result1 bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = nil
+	fake.purgePvtDataReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ApplicationCapabilities) PurgePvtDataReturnsOnCall(i int, result1 bool) {
+	fake.purgePvtDataMutex.Lock()
+	defer fake.purgePvtDataMutex.Unlock()
+	fake.PurgePvtDataStub = nil
+	if fake.purgePvtDataReturnsOnCall == nil {
+		fake.purgePvtDataReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.purgePvtDataReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	fake.storePvtDataOfInvalidTxMutex.Lock()
 	ret, specificReturn := fake.storePvtDataOfInvalidTxReturnsOnCall[len(fake.storePvtDataOfInvalidTxArgsForCall)]
@@ -823,6 +947,8 @@ func (fake *ApplicationCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.aCLsMutex.RUnlock()
 	fake.collectionUpgradeMutex.RLock()
 	defer fake.collectionUpgradeMutex.RUnlock()
+	fake.crossChannelTransactionsMutex.RLock()
+	defer fake.crossChannelTransactionsMutex.RUnlock()
 	fake.forbidDuplicateTXIdInBlockMutex.RLock()
 	defer fake.forbidDuplicateTXIdInBlockMutex.RUnlock()
 	fake.keyLevelEndorsementMutex.RLock()
@@ -833,6 +959,8 @@ func (fake *ApplicationCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.metadataLifecycleMutex.RUnlock()
 	fake.privateChannelDataMutex.RLock()
 	defer fake.privateChannelDataMutex.RUnlock()
+	fake.purgePvtDataMutex.RLock()
+	defer fake.purgePvtDataMutex.RUnlock()
 	fake.storePvtDataOfInvalidTxMutex.RLock()
 	defer fake.storePvtDataOfInvalidTxMutex.RUnlock()
 	fake.supportedMutex.RLock()
@@ -0,0 +1,179 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+)
+
+type ChannelLedgerSource struct {
+	ChannelIDsStub        func() []string
+	channelIDsMutex       sync.RWMutex
+	channelIDsArgsForCall []struct {
+	}
+	channelIDsReturns struct {
+		result1 []string
+	}
+	channelIDsReturnsOnCall map[int]struct {
+		result1 []string
+	}
+	NewQueryExecutorStub        func(string) (lifecycle.ChannelQueryExecutor, error)
+	newQueryExecutorMutex       sync.RWMutex
+	newQueryExecutorArgsForCall []struct {
+		arg1 string
+	}
+	newQueryExecutorReturns struct {
+		result1 lifecycle.ChannelQueryExecutor
+		result2 error
+	}
+	newQueryExecutorReturnsOnCall map[int]struct {
+		result1 lifecycle.ChannelQueryExecutor
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ChannelLedgerSource) ChannelIDs() []string {
+	fake.channelIDsMutex.Lock()
+	ret, specificReturn := fake.channelIDsReturnsOnCall[len(fake.channelIDsArgsForCall)]
+	fake.channelIDsArgsForCall = append(fake.channelIDsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ChannelIDs", []interface{}{})
+	fake.channelIDsMutex.Unlock()
+	if fake.ChannelIDsStub != nil {
+		return fake.ChannelIDsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.channelIDsReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelLedgerSource) ChannelIDsCallCount() int {
+	fake.channelIDsMutex.RLock()
+	defer fake.channelIDsMutex.RUnlock()
+	return len(fake.channelIDsArgsForCall)
+}
+
+func (fake *ChannelLedgerSource) ChannelIDsCalls(stub func() []string) {
+	fake.channelIDsMutex.Lock()
+	defer fake.channelIDsMutex.Unlock()
+	fake.ChannelIDsStub = stub
+}
+
+func (fake *ChannelLedgerSource) ChannelIDsReturns(result1 []string) {
+	fake.channelIDsMutex.Lock()
+	defer fake.channelIDsMutex.Unlock()
+	fake.ChannelIDsStub = nil
+	fake.channelIDsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *ChannelLedgerSource) ChannelIDsReturnsOnCall(i int, result1 []string) {
+	fake.channelIDsMutex.Lock()
+	defer fake.channelIDsMutex.Unlock()
+	fake.ChannelIDsStub = nil
+	if fake.channelIDsReturnsOnCall == nil {
+		fake.channelIDsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.channelIDsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutor(arg1 string) (lifecycle.ChannelQueryExecutor, error) {
+	fake.newQueryExecutorMutex.Lock()
+	ret, specificReturn := fake.newQueryExecutorReturnsOnCall[len(fake.newQueryExecutorArgsForCall)]
+	fake.newQueryExecutorArgsForCall = append(fake.newQueryExecutorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("NewQueryExecutor", []interface{}{arg1})
+	fake.newQueryExecutorMutex.Unlock()
+	if fake.NewQueryExecutorStub != nil {
+		return fake.NewQueryExecutorStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.newQueryExecutorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutorCallCount() int {
+	fake.newQueryExecutorMutex.RLock()
+	defer fake.newQueryExecutorMutex.RUnlock()
+	return len(fake.newQueryExecutorArgsForCall)
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutorCalls(stub func(string) (lifecycle.ChannelQueryExecutor, error)) {
+	fake.newQueryExecutorMutex.Lock()
+	defer fake.newQueryExecutorMutex.Unlock()
+	fake.NewQueryExecutorStub = stub
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutorArgsForCall(i int) string {
+	fake.newQueryExecutorMutex.RLock()
+	defer fake.newQueryExecutorMutex.RUnlock()
+	argsForCall := fake.newQueryExecutorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutorReturns(result1 lifecycle.ChannelQueryExecutor, result2 error) {
+	fake.newQueryExecutorMutex.Lock()
+	defer fake.newQueryExecutorMutex.Unlock()
+	fake.NewQueryExecutorStub = nil
+	fake.newQueryExecutorReturns = struct {
+		result1 lifecycle.ChannelQueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelLedgerSource) NewQueryExecutorReturnsOnCall(i int, result1 lifecycle.ChannelQueryExecutor, result2 error) {
+	fake.newQueryExecutorMutex.Lock()
+	defer fake.newQueryExecutorMutex.Unlock()
+	fake.NewQueryExecutorStub = nil
+	if fake.newQueryExecutorReturnsOnCall == nil {
+		fake.newQueryExecutorReturnsOnCall = make(map[int]struct {
+			result1 lifecycle.ChannelQueryExecutor
+			result2 error
+		})
+	}
+	fake.newQueryExecutorReturnsOnCall[i] = struct {
+		result1 lifecycle.ChannelQueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelLedgerSource) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.channelIDsMutex.RLock()
+	defer fake.channelIDsMutex.RUnlock()
+	fake.newQueryExecutorMutex.RLock()
+	defer fake.newQueryExecutorMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ChannelLedgerSource) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ lifecycle.ChannelLedgerSource = new(ChannelLedgerSource)
@@ -0,0 +1,477 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+)
+
+type ChannelQueryExecutor struct {
+	DoneStub        func()
+	doneMutex       sync.RWMutex
+	doneArgsForCall []struct {
+	}
+	GetPrivateDataStub        func(string, string, string) ([]byte, error)
+	getPrivateDataMutex       sync.RWMutex
+	getPrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getPrivateDataReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPrivateDataReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetPrivateDataHashStub        func(string, string, string) ([]byte, error)
+	getPrivateDataHashMutex       sync.RWMutex
+	getPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getPrivateDataHashReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPrivateDataHashReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetPrivateDataRangeScanIteratorStub        func(string, string, string, string) (ledger.ResultsIterator, error)
+	getPrivateDataRangeScanIteratorMutex       sync.RWMutex
+	getPrivateDataRangeScanIteratorArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	getPrivateDataRangeScanIteratorReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getPrivateDataRangeScanIteratorReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	GetStateStub        func(string, string) ([]byte, error)
+	getStateMutex       sync.RWMutex
+	getStateArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getStateReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getStateReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
+	GetStateRangeScanIteratorStub        func(string, string, string) (ledger.ResultsIterator, error)
+	getStateRangeScanIteratorMutex       sync.RWMutex
+	getStateRangeScanIteratorArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getStateRangeScanIteratorReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateRangeScanIteratorReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ChannelQueryExecutor) Done() {
+	fake.doneMutex.Lock()
+	fake.doneArgsForCall = append(fake.doneArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Done", []interface{}{})
+	fake.doneMutex.Unlock()
+	if fake.DoneStub != nil {
+		fake.DoneStub()
+	}
+}
+
+func (fake *ChannelQueryExecutor) DoneCallCount() int {
+	fake.doneMutex.RLock()
+	defer fake.doneMutex.RUnlock()
+	return len(fake.doneArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) DoneCalls(stub func()) {
+	fake.doneMutex.Lock()
+	defer fake.doneMutex.Unlock()
+	fake.DoneStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateData(arg1 string, arg2 string, arg3 string) ([]byte, error) {
+	fake.getPrivateDataMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
+	fake.getPrivateDataArgsForCall = append(fake.getPrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetPrivateData", []interface{}{arg1, arg2, arg3})
+	fake.getPrivateDataMutex.Unlock()
+	if fake.GetPrivateDataStub != nil {
+		return fake.GetPrivateDataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataCallCount() int {
+	fake.getPrivateDataMutex.RLock()
+	defer fake.getPrivateDataMutex.RUnlock()
+	return len(fake.getPrivateDataArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataCalls(stub func(string, string, string) ([]byte, error)) {
+	fake.getPrivateDataMutex.Lock()
+	defer fake.getPrivateDataMutex.Unlock()
+	fake.GetPrivateDataStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataArgsForCall(i int) (string, string, string) {
+	fake.getPrivateDataMutex.RLock()
+	defer fake.getPrivateDataMutex.RUnlock()
+	argsForCall := fake.getPrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataReturns(result1 []byte, result2 error) {
+	fake.getPrivateDataMutex.Lock()
+	defer fake.getPrivateDataMutex.Unlock()
+	fake.GetPrivateDataStub = nil
+	fake.getPrivateDataReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPrivateDataMutex.Lock()
+	defer fake.getPrivateDataMutex.Unlock()
+	fake.GetPrivateDataStub = nil
+	if fake.getPrivateDataReturnsOnCall == nil {
+		fake.getPrivateDataReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPrivateDataReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHash(arg1 string, arg2 string, arg3 string) ([]byte, error) {
+	fake.getPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHashReturnsOnCall[len(fake.getPrivateDataHashArgsForCall)]
+	fake.getPrivateDataHashArgsForCall = append(fake.getPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetPrivateDataHash", []interface{}{arg1, arg2, arg3})
+	fake.getPrivateDataHashMutex.Unlock()
+	if fake.GetPrivateDataHashStub != nil {
+		return fake.GetPrivateDataHashStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHashCallCount() int {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	return len(fake.getPrivateDataHashArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHashCalls(stub func(string, string, string) ([]byte, error)) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHashArgsForCall(i int) (string, string, string) {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHashReturns(result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	fake.getPrivateDataHashReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataHashReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	if fake.getPrivateDataHashReturnsOnCall == nil {
+		fake.getPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPrivateDataHashReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIterator(arg1 string, arg2 string, arg3 string, arg4 string) (ledger.ResultsIterator, error) {
+	fake.getPrivateDataRangeScanIteratorMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataRangeScanIteratorReturnsOnCall[len(fake.getPrivateDataRangeScanIteratorArgsForCall)]
+	fake.getPrivateDataRangeScanIteratorArgsForCall = append(fake.getPrivateDataRangeScanIteratorArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetPrivateDataRangeScanIterator", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPrivateDataRangeScanIteratorMutex.Unlock()
+	if fake.GetPrivateDataRangeScanIteratorStub != nil {
+		return fake.GetPrivateDataRangeScanIteratorStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataRangeScanIteratorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIteratorCallCount() int {
+	fake.getPrivateDataRangeScanIteratorMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.RUnlock()
+	return len(fake.getPrivateDataRangeScanIteratorArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIteratorCalls(stub func(string, string, string, string) (ledger.ResultsIterator, error)) {
+	fake.getPrivateDataRangeScanIteratorMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIteratorArgsForCall(i int) (string, string, string, string) {
+	fake.getPrivateDataRangeScanIteratorMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.RUnlock()
+	argsForCall := fake.getPrivateDataRangeScanIteratorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIteratorReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataRangeScanIteratorMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorStub = nil
+	fake.getPrivateDataRangeScanIteratorReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetPrivateDataRangeScanIteratorReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getPrivateDataRangeScanIteratorMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorStub = nil
+	if fake.getPrivateDataRangeScanIteratorReturnsOnCall == nil {
+		fake.getPrivateDataRangeScanIteratorReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getPrivateDataRangeScanIteratorReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetState(arg1 string, arg2 string) ([]byte, error) {
+	fake.getStateMutex.Lock()
+	ret, specificReturn := fake.getStateReturnsOnCall[len(fake.getStateArgsForCall)]
+	fake.getStateArgsForCall = append(fake.getStateArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetState", []interface{}{arg1, arg2})
+	fake.getStateMutex.Unlock()
+	if fake.GetStateStub != nil {
+		return fake.GetStateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelQueryExecutor) GetStateCallCount() int {
+	fake.getStateMutex.RLock()
+	defer fake.getStateMutex.RUnlock()
+	return len(fake.getStateArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) GetStateCalls(stub func(string, string) ([]byte, error)) {
+	fake.getStateMutex.Lock()
+	defer fake.getStateMutex.Unlock()
+	fake.GetStateStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetStateArgsForCall(i int) (string, string) {
+	fake.getStateMutex.RLock()
+	defer fake.getStateMutex.RUnlock()
+	argsForCall := fake.getStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChannelQueryExecutor) GetStateReturns(result1 []byte, result2 error) {
+	fake.getStateMutex.Lock()
+	defer fake.getStateMutex.Unlock()
+	fake.GetStateStub = nil
+	fake.getStateReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetStateReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getStateMutex.Lock()
+	defer fake.getStateMutex.Unlock()
+	fake.GetStateStub = nil
+	if fake.getStateReturnsOnCall == nil {
+		fake.getStateReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getStateReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIterator(arg1 string, arg2 string, arg3 string) (ledger.ResultsIterator, error) {
+	fake.getStateRangeScanIteratorMutex.Lock()
+	ret, specificReturn := fake.getStateRangeScanIteratorReturnsOnCall[len(fake.getStateRangeScanIteratorArgsForCall)]
+	fake.getStateRangeScanIteratorArgsForCall = append(fake.getStateRangeScanIteratorArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateRangeScanIterator", []interface{}{arg1, arg2, arg3})
+	fake.getStateRangeScanIteratorMutex.Unlock()
+	if fake.GetStateRangeScanIteratorStub != nil {
+		return fake.GetStateRangeScanIteratorStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateRangeScanIteratorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIteratorCallCount() int {
+	fake.getStateRangeScanIteratorMutex.RLock()
+	defer fake.getStateRangeScanIteratorMutex.RUnlock()
+	return len(fake.getStateRangeScanIteratorArgsForCall)
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIteratorCalls(stub func(string, string, string) (ledger.ResultsIterator, error)) {
+	fake.getStateRangeScanIteratorMutex.Lock()
+	defer fake.getStateRangeScanIteratorMutex.Unlock()
+	fake.GetStateRangeScanIteratorStub = stub
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIteratorArgsForCall(i int) (string, string, string) {
+	fake.getStateRangeScanIteratorMutex.RLock()
+	defer fake.getStateRangeScanIteratorMutex.RUnlock()
+	argsForCall := fake.getStateRangeScanIteratorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIteratorReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorMutex.Lock()
+	defer fake.getStateRangeScanIteratorMutex.Unlock()
+	fake.GetStateRangeScanIteratorStub = nil
+	fake.getStateRangeScanIteratorReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) GetStateRangeScanIteratorReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateRangeScanIteratorMutex.Lock()
+	defer fake.getStateRangeScanIteratorMutex.Unlock()
+	fake.GetStateRangeScanIteratorStub = nil
+	if fake.getStateRangeScanIteratorReturnsOnCall == nil {
+		fake.getStateRangeScanIteratorReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateRangeScanIteratorReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChannelQueryExecutor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.doneMutex.RLock()
+	defer fake.doneMutex.RUnlock()
+	fake.getPrivateDataMutex.RLock()
+	defer fake.getPrivateDataMutex.RUnlock()
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	fake.getPrivateDataRangeScanIteratorMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorMutex.RUnlock()
+	fake.getStateMutex.RLock()
+	defer fake.getStateMutex.RUnlock()
+	fake.getStateRangeScanIteratorMutex.RLock()
+	defer fake.getStateRangeScanIteratorMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ChannelQueryExecutor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ lifecycle.ChannelQueryExecutor = new(ChannelQueryExecutor)
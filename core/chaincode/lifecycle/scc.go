@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecyclepb"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -46,6 +47,10 @@ const (
 	// query all installed chaincodes
 	QueryInstalledChaincodesFuncName = "QueryInstalledChaincodes"
 
+	// UninstallChaincodeFuncName is the chaincode function name used to
+	// uninstall a chaincode
+	UninstallChaincodeFuncName = "UninstallChaincode"
+
 	// ApproveChaincodeDefinitionForMyOrgFuncName is the chaincode function name
 	// used to approve a chaincode definition for execution by the user's own org
 	ApproveChaincodeDefinitionForMyOrgFuncName = "ApproveChaincodeDefinitionForMyOrg"
@@ -88,6 +93,9 @@ type SCCFunctions interface {
 	// QueryInstalledChaincodes returns the currently installed chaincodes
 	QueryInstalledChaincodes() []*chaincode.InstalledChaincode
 
+	// UninstallChaincode removes an installed chaincode which is not in use from the peer.
+	UninstallChaincode(packageID string) error
+
 	// ApproveChaincodeDefinitionForOrg records a chaincode definition into this org's implicit collection.
 	ApproveChaincodeDefinitionForOrg(chname, ccname string, cd *ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadWritableState) error
 
@@ -322,6 +330,21 @@ func (i *Invocation) GetInstalledChaincodePackage(input *lb.GetInstalledChaincod
 	}, nil
 }
 
+// UninstallChaincode is a SCC function that may be dispatched to which
+// routes to the underlying lifecycle implementation.
+func (i *Invocation) UninstallChaincode(input *lifecyclepb.UninstallChaincodeArgs) (proto.Message, error) {
+	logger.Debugf("received invocation of UninstallChaincode for install package ID '%s'",
+		input.PackageId,
+	)
+
+	err := i.SCC.Functions.UninstallChaincode(input.PackageId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lifecyclepb.UninstallChaincodeResult{}, nil
+}
+
 // QueryInstalledChaincodes is a SCC function that may be dispatched to which
 // routes to the underlying lifecycle implementation.
 func (i *Invocation) QueryInstalledChaincodes(input *lb.QueryInstalledChaincodesArgs) (proto.Message, error) {
@@ -650,6 +673,7 @@ var (
 		"lscc": {},
 		"qscc": {},
 		"vscc": {},
+		"xcc":  {},
 	}
 )
 
@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/lifecyclepb"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
@@ -325,6 +326,61 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("UninstallChaincode", func() {
+			var (
+				arg          *lifecyclepb.UninstallChaincodeArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lifecyclepb.UninstallChaincodeArgs{
+					PackageId: "package-id",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("UninstallChaincode"), marshaledArg})
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lifecyclepb.UninstallChaincodeResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.UninstallChaincodeCallCount()).To(Equal(1))
+				packageID := fakeSCCFuncs.UninstallChaincodeArgsForCall(0)
+				Expect(packageID).To(Equal("package-id"))
+			})
+
+			Context("when the chaincode is not installed", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(persistence.CodePackageNotFoundErr{PackageID: "package-id"})
+				})
+
+				It("returns a not found status", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(404)))
+					Expect(res.Message).To(Equal("chaincode install package 'package-id' not found"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'UninstallChaincode': underlying-error"))
+				})
+			})
+		})
+
 		Describe("QueryInstalledChaincodes", func() {
 			var (
 				arg          *lb.QueryInstalledChaincodesArgs
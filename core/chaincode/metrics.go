@@ -59,21 +59,39 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+	replicaExecutions = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "replica_executions",
+		Help:         "The number of chaincode executions (Init or Invoke) completed by each replica of a chaincode.",
+		LabelNames:   []string{"chaincode", "replica", "success"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{replica}.%{success}",
+	}
+	replicaInflightExecutions = metrics.GaugeOpts{
+		Namespace:    "chaincode",
+		Name:         "replica_inflight_executions",
+		Help:         "The number of chaincode executions (Init or Invoke) in progress on each replica of a chaincode.",
+		LabelNames:   []string{"chaincode", "replica"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{replica}",
+	}
 )
 
 type HandlerMetrics struct {
-	ShimRequestsReceived  metrics.Counter
-	ShimRequestsCompleted metrics.Counter
-	ShimRequestDuration   metrics.Histogram
-	ExecuteTimeouts       metrics.Counter
+	ShimRequestsReceived      metrics.Counter
+	ShimRequestsCompleted     metrics.Counter
+	ShimRequestDuration       metrics.Histogram
+	ExecuteTimeouts           metrics.Counter
+	ReplicaExecutions         metrics.Counter
+	ReplicaInflightExecutions metrics.Gauge
 }
 
 func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 	return &HandlerMetrics{
-		ShimRequestsReceived:  p.NewCounter(shimRequestsReceived),
-		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
-		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
-		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		ShimRequestsReceived:      p.NewCounter(shimRequestsReceived),
+		ShimRequestsCompleted:     p.NewCounter(shimRequestsCompleted),
+		ShimRequestDuration:       p.NewHistogram(shimRequestDuration),
+		ExecuteTimeouts:           p.NewCounter(executeTimeouts),
+		ReplicaExecutions:         p.NewCounter(replicaExecutions),
+		ReplicaInflightExecutions: p.NewGauge(replicaInflightExecutions),
 	}
 }
 
@@ -8,77 +8,79 @@ import (
 )
 
 type ChaincodeStreamHandler struct {
-	HandleChaincodeStreamStub        func(ccintf.ChaincodeStream) error
-	handleChaincodeStreamMutex       sync.RWMutex
-	handleChaincodeStreamArgsForCall []struct {
-		arg1 ccintf.ChaincodeStream
+	HandleReplicaStreamStub        func(string, ccintf.ChaincodeStream) error
+	handleReplicaStreamMutex       sync.RWMutex
+	handleReplicaStreamArgsForCall []struct {
+		arg1 string
+		arg2 ccintf.ChaincodeStream
 	}
-	handleChaincodeStreamReturns struct {
+	handleReplicaStreamReturns struct {
 		result1 error
 	}
-	handleChaincodeStreamReturnsOnCall map[int]struct {
+	handleReplicaStreamReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStream(arg1 ccintf.ChaincodeStream) error {
-	fake.handleChaincodeStreamMutex.Lock()
-	ret, specificReturn := fake.handleChaincodeStreamReturnsOnCall[len(fake.handleChaincodeStreamArgsForCall)]
-	fake.handleChaincodeStreamArgsForCall = append(fake.handleChaincodeStreamArgsForCall, struct {
-		arg1 ccintf.ChaincodeStream
-	}{arg1})
-	fake.recordInvocation("HandleChaincodeStream", []interface{}{arg1})
-	fake.handleChaincodeStreamMutex.Unlock()
-	if fake.HandleChaincodeStreamStub != nil {
-		return fake.HandleChaincodeStreamStub(arg1)
+func (fake *ChaincodeStreamHandler) HandleReplicaStream(arg1 string, arg2 ccintf.ChaincodeStream) error {
+	fake.handleReplicaStreamMutex.Lock()
+	ret, specificReturn := fake.handleReplicaStreamReturnsOnCall[len(fake.handleReplicaStreamArgsForCall)]
+	fake.handleReplicaStreamArgsForCall = append(fake.handleReplicaStreamArgsForCall, struct {
+		arg1 string
+		arg2 ccintf.ChaincodeStream
+	}{arg1, arg2})
+	fake.recordInvocation("HandleReplicaStream", []interface{}{arg1, arg2})
+	fake.handleReplicaStreamMutex.Unlock()
+	if fake.HandleReplicaStreamStub != nil {
+		return fake.HandleReplicaStreamStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.handleChaincodeStreamReturns
+	fakeReturns := fake.handleReplicaStreamReturns
 	return fakeReturns.result1
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStreamCallCount() int {
-	fake.handleChaincodeStreamMutex.RLock()
-	defer fake.handleChaincodeStreamMutex.RUnlock()
-	return len(fake.handleChaincodeStreamArgsForCall)
+func (fake *ChaincodeStreamHandler) HandleReplicaStreamCallCount() int {
+	fake.handleReplicaStreamMutex.RLock()
+	defer fake.handleReplicaStreamMutex.RUnlock()
+	return len(fake.handleReplicaStreamArgsForCall)
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStreamCalls(stub func(ccintf.ChaincodeStream) error) {
-	fake.handleChaincodeStreamMutex.Lock()
-	defer fake.handleChaincodeStreamMutex.Unlock()
-	fake.HandleChaincodeStreamStub = stub
+func (fake *ChaincodeStreamHandler) HandleReplicaStreamCalls(stub func(string, ccintf.ChaincodeStream) error) {
+	fake.handleReplicaStreamMutex.Lock()
+	defer fake.handleReplicaStreamMutex.Unlock()
+	fake.HandleReplicaStreamStub = stub
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStreamArgsForCall(i int) ccintf.ChaincodeStream {
-	fake.handleChaincodeStreamMutex.RLock()
-	defer fake.handleChaincodeStreamMutex.RUnlock()
-	argsForCall := fake.handleChaincodeStreamArgsForCall[i]
-	return argsForCall.arg1
+func (fake *ChaincodeStreamHandler) HandleReplicaStreamArgsForCall(i int) (string, ccintf.ChaincodeStream) {
+	fake.handleReplicaStreamMutex.RLock()
+	defer fake.handleReplicaStreamMutex.RUnlock()
+	argsForCall := fake.handleReplicaStreamArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStreamReturns(result1 error) {
-	fake.handleChaincodeStreamMutex.Lock()
-	defer fake.handleChaincodeStreamMutex.Unlock()
-	fake.HandleChaincodeStreamStub = nil
-	fake.handleChaincodeStreamReturns = struct {
+func (fake *ChaincodeStreamHandler) HandleReplicaStreamReturns(result1 error) {
+	fake.handleReplicaStreamMutex.Lock()
+	defer fake.handleReplicaStreamMutex.Unlock()
+	fake.HandleReplicaStreamStub = nil
+	fake.handleReplicaStreamReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *ChaincodeStreamHandler) HandleChaincodeStreamReturnsOnCall(i int, result1 error) {
-	fake.handleChaincodeStreamMutex.Lock()
-	defer fake.handleChaincodeStreamMutex.Unlock()
-	fake.HandleChaincodeStreamStub = nil
-	if fake.handleChaincodeStreamReturnsOnCall == nil {
-		fake.handleChaincodeStreamReturnsOnCall = make(map[int]struct {
+func (fake *ChaincodeStreamHandler) HandleReplicaStreamReturnsOnCall(i int, result1 error) {
+	fake.handleReplicaStreamMutex.Lock()
+	defer fake.handleReplicaStreamMutex.Unlock()
+	fake.HandleReplicaStreamStub = nil
+	if fake.handleReplicaStreamReturnsOnCall == nil {
+		fake.handleReplicaStreamReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.handleChaincodeStreamReturnsOnCall[i] = struct {
+	fake.handleReplicaStreamReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -86,8 +88,8 @@ func (fake *ChaincodeStreamHandler) HandleChaincodeStreamReturnsOnCall(i int, re
 func (fake *ChaincodeStreamHandler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.handleChaincodeStreamMutex.RLock()
-	defer fake.handleChaincodeStreamMutex.RUnlock()
+	fake.handleReplicaStreamMutex.RLock()
+	defer fake.handleReplicaStreamMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: chaincode_shim.proto
+
+package shimpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChaincodeMessageType lists the types of the chaincode messages that are
+// not among the protos.ChaincodeMessage.Type values yet. The values do not
+// overlap with those, so that they can be carried in the type of a
+// protos.ChaincodeMessage.
+type ChaincodeMessageType int32
+
+const (
+	ChaincodeMessageType_UNDEFINED ChaincodeMessageType = 0
+	// PURGE_PRIVATE_DATA requests that a private data key is deleted and
+	// all its historical versions are purged. The payload is a
+	// protos.DelState.
+	ChaincodeMessageType_PURGE_PRIVATE_DATA ChaincodeMessageType = 23
+)
+
+var ChaincodeMessageType_name = map[int32]string{
+	0:  "UNDEFINED",
+	23: "PURGE_PRIVATE_DATA",
+}
+
+var ChaincodeMessageType_value = map[string]int32{
+	"UNDEFINED":          0,
+	"PURGE_PRIVATE_DATA": 23,
+}
+
+func (x ChaincodeMessageType) String() string {
+	return proto.EnumName(ChaincodeMessageType_name, int32(x))
+}
+
+func (ChaincodeMessageType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_adb8e00c9c92d6c8, []int{0}
+}
+
+// QueryMetadata is a protos.QueryMetadata that also specifies the order of
+// the keys returned by a range query. It is wire compatible with
+// protos.QueryMetadata, which chaincodes that want the keys in ascending
+// order keep sending.
+type QueryMetadata struct {
+	// The number of results to return in a page
+	PageSize int32 `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// The key of the result to start the page from
+	Bookmark string `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	// Descending is true if the keys of a range query are returned in
+	// descending order
+	Descending           bool     `protobuf:"varint,3,opt,name=descending,proto3" json:"descending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
+func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
+func (*QueryMetadata) ProtoMessage()    {}
+func (*QueryMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_adb8e00c9c92d6c8, []int{0}
+}
+
+func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
+}
+func (m *QueryMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryMetadata.Marshal(b, m, deterministic)
+}
+func (m *QueryMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryMetadata.Merge(m, src)
+}
+func (m *QueryMetadata) XXX_Size() int {
+	return xxx_messageInfo_QueryMetadata.Size(m)
+}
+func (m *QueryMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryMetadata proto.InternalMessageInfo
+
+func (m *QueryMetadata) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *QueryMetadata) GetBookmark() string {
+	if m != nil {
+		return m.Bookmark
+	}
+	return ""
+}
+
+func (m *QueryMetadata) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("shimpb.ChaincodeMessageType", ChaincodeMessageType_name, ChaincodeMessageType_value)
+	proto.RegisterType((*QueryMetadata)(nil), "shimpb.QueryMetadata")
+}
+
+func init() { proto.RegisterFile("chaincode_shim.proto", fileDescriptor_adb8e00c9c92d6c8) }
+
+var fileDescriptor_adb8e00c9c92d6c8 = []byte{
+	// 233 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8f, 0xc1, 0x4b, 0xc3, 0x30,
+	0x18, 0xc5, 0xad, 0xe2, 0xd8, 0x02, 0x83, 0x11, 0x86, 0x16, 0x0f, 0x52, 0x3c, 0x15, 0x0f, 0xcb,
+	0x61, 0x78, 0xf4, 0x50, 0x6d, 0x95, 0x1d, 0x36, 0x66, 0xec, 0x3c, 0x78, 0x29, 0x5f, 0x92, 0xcf,
+	0x34, 0xcc, 0x36, 0x21, 0xcd, 0x0e, 0xf5, 0xaf, 0x97, 0x29, 0x16, 0x6f, 0xef, 0xf7, 0xde, 0xe5,
+	0xfd, 0xc8, 0x5c, 0xd6, 0x60, 0x5a, 0x69, 0x15, 0x56, 0x5d, 0x6d, 0x9a, 0x85, 0xf3, 0x36, 0x58,
+	0x3a, 0x3a, 0x66, 0x27, 0x6e, 0x34, 0x99, 0xbe, 0x1c, 0xd0, 0xf7, 0x6b, 0x0c, 0xa0, 0x20, 0x00,
+	0xbd, 0x22, 0x63, 0x07, 0x1a, 0x5f, 0xcd, 0x17, 0xc6, 0x51, 0x12, 0xa5, 0xe7, 0x7c, 0xe0, 0xe3,
+	0x26, 0xac, 0xdd, 0x37, 0xe0, 0xf7, 0xf1, 0x69, 0x12, 0xa5, 0x13, 0x3e, 0x30, 0xbd, 0x26, 0x44,
+	0x61, 0x27, 0xb1, 0x55, 0xa6, 0xd5, 0xf1, 0x59, 0x12, 0xa5, 0x63, 0xfe, 0xaf, 0xb9, 0xbd, 0x27,
+	0xf3, 0xc7, 0xbf, 0x23, 0x6b, 0xec, 0x3a, 0xd0, 0x58, 0xf6, 0x0e, 0xe9, 0x94, 0x4c, 0x76, 0x9b,
+	0xbc, 0x78, 0x5a, 0x6d, 0x8a, 0x7c, 0x76, 0x42, 0x2f, 0x08, 0xdd, 0xee, 0xf8, 0x73, 0x51, 0x6d,
+	0xf9, 0xea, 0x2d, 0x2b, 0x8b, 0x2a, 0xcf, 0xca, 0x6c, 0x76, 0xf9, 0x70, 0xf7, 0xbe, 0xd4, 0x26,
+	0xd4, 0x07, 0xb1, 0x90, 0xb6, 0x61, 0x75, 0xef, 0xd0, 0x7f, 0xa2, 0xd2, 0xe8, 0xd9, 0x07, 0x08,
+	0x6f, 0x24, 0x93, 0xd6, 0x23, 0x1b, 0x54, 0xd9, 0xaf, 0x9e, 0x18, 0xfd, 0xd8, 0x2e, 0xbf, 0x07,
+	0x00, 0x85, 0x66, 0x87, 0x15, 0x05, 0x01, 0x00, 0x00,
+}
@@ -11,6 +11,7 @@ import (
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
 )
@@ -25,6 +26,7 @@ type TransactionContext struct {
 	HistoryQueryExecutor ledger.HistoryQueryExecutor
 	CollectionStore      privdata.CollectionStore
 	IsInitTransaction    bool
+	CrossChannelWrites   *ccprovider.CrossChannelWrites
 
 	// tracks open iterators used for range queries
 	queryMutex          sync.Mutex
@@ -43,11 +43,21 @@ type PeerLedgerSupport interface {
 	Close()
 }
 
+// BlockCommitListener is notified of the blocks committed to the ledger of a
+// channel
+type BlockCommitListener interface {
+	// BlockCommitted is invoked once the block is committed to the ledger of
+	// the channel, with the validation flags of its transactions set
+	BlockCommitted(channelID string, block *common.Block)
+}
+
 // LedgerCommitter is the implementation of  Committer interface
 // it keeps the reference to the ledger to commit blocks and retrieve
 // chain information
 type LedgerCommitter struct {
 	PeerLedgerSupport
+	channelID string
+	listeners []BlockCommitListener
 }
 
 // NewLedgerCommitter is a factory function to create an instance of the committer
@@ -56,6 +66,17 @@ func NewLedgerCommitter(ledger PeerLedgerSupport) *LedgerCommitter {
 	return &LedgerCommitter{PeerLedgerSupport: ledger}
 }
 
+// NewLedgerCommitterWithListeners creates a committer which, in addition,
+// notifies the listeners of every block it commits into the ledger of the
+// channel.
+func NewLedgerCommitterWithListeners(ledger PeerLedgerSupport, channelID string, listeners ...BlockCommitListener) *LedgerCommitter {
+	return &LedgerCommitter{
+		PeerLedgerSupport: ledger,
+		channelID:         channelID,
+		listeners:         listeners,
+	}
+}
+
 // CommitLegacy commits blocks atomically with private data
 func (lc *LedgerCommitter) CommitLegacy(blockAndPvtData *ledger.BlockAndPvtData, commitOpts *ledger.CommitOptions) error {
 	// Committing new block
@@ -63,6 +84,10 @@ func (lc *LedgerCommitter) CommitLegacy(blockAndPvtData *ledger.BlockAndPvtData,
 		return err
 	}
 
+	for _, listener := range lc.listeners {
+		listener.BlockCommitted(lc.channelID, blockAndPvtData.Block)
+	}
+
 	return nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -160,3 +161,37 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	require.Equal(t, 1, len(blocks))
 	require.NoError(t, err)
 }
+
+type blockCommitListener struct {
+	channelIDs []string
+	blocks     []*common.Block
+}
+
+func (l *blockCommitListener) BlockCommitted(channelID string, block *common.Block) {
+	l.channelIDs = append(l.channelIDs, channelID)
+	l.blocks = append(l.blocks, block)
+}
+
+func TestBlockCommitListeners(t *testing.T) {
+	t.Parallel()
+	gb, ledger := createLedger("TestLedger")
+	block1 := testutil.ConstructBlock(t, 1, gb.Header.DataHash, [][]byte{{1, 2, 3, 4}}, true)
+	block2 := testutil.ConstructBlock(t, 2, block1.Header.DataHash, [][]byte{{5, 6, 7, 8}}, true)
+
+	ledger.On("CommitLegacy", &ledger2.BlockAndPvtData{Block: block1}).Return(nil)
+	ledger.On("CommitLegacy", &ledger2.BlockAndPvtData{Block: block2}).Return(errors.New("commit failed"))
+
+	listener1 := &blockCommitListener{}
+	listener2 := &blockCommitListener{}
+	committer := NewLedgerCommitterWithListeners(ledger, "TestLedger", listener1, listener2)
+
+	err := committer.CommitLegacy(&ledger2.BlockAndPvtData{Block: block1}, &ledger2.CommitOptions{})
+	require.NoError(t, err)
+	err = committer.CommitLegacy(&ledger2.BlockAndPvtData{Block: block2}, &ledger2.CommitOptions{})
+	require.EqualError(t, err, "commit failed")
+
+	for _, listener := range []*blockCommitListener{listener1, listener2} {
+		require.Equal(t, []string{"TestLedger"}, listener.channelIDs)
+		require.Equal(t, []*common.Block{block1}, listener.blocks)
+	}
+}
@@ -37,6 +37,20 @@ func (_m *ApplicationCapabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// CrossChannelTransactions provides a mock function with given fields:
+func (_m *ApplicationCapabilities) CrossChannelTransactions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // ForbidDuplicateTXIdInBlock provides a mock function with given fields:
 func (_m *ApplicationCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	ret := _m.Called()
@@ -107,6 +121,20 @@ func (_m *ApplicationCapabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// PurgePvtData provides a mock function with given fields:
+func (_m *ApplicationCapabilities) PurgePvtData() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // StorePvtDataOfInvalidTx provides a mock function with given fields:
 func (_m *ApplicationCapabilities) StorePvtDataOfInvalidTx() bool {
 	ret := _m.Called()
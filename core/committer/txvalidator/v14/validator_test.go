@@ -1505,6 +1505,26 @@ func (m *mockLedger) DoesPvtDataInfoExist(blkNum uint64) (bool, error) {
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *mockLedger) SubmitSnapshotRequest(height uint64) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
+func (m *mockLedger) CancelSnapshotRequest(height uint64) error {
+	args := m.Called(height)
+	return args.Error(0)
+}
+
+func (m *mockLedger) PendingSnapshotRequests() ([]uint64, error) {
+	args := m.Called()
+	return args.Get(0).([]uint64), args.Error(1)
+}
+
+func (m *mockLedger) SnapshotRequestFailures() (map[uint64]string, error) {
+	args := m.Called()
+	return args.Get(0).(map[uint64]string), args.Error(1)
+}
+
 func (m *mockLedger) Close() {
 
 }
@@ -1559,6 +1579,16 @@ func (exec *mockQueryExecutor) GetStateRangeScanIteratorWithPagination(namespace
 	return args.Get(0).(ledger.QueryResultsIterator), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetStateReverseRangeScanIterator(namespace, startKey, endKey string) (ledger2.ResultsIterator, error) {
+	args := exec.Called(namespace, startKey, endKey)
+	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
+}
+
+func (exec *mockQueryExecutor) GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey string, pageSize int32) (ledger.QueryResultsIterator, error) {
+	args := exec.Called(namespace, startKey, endKey, pageSize)
+	return args.Get(0).(ledger.QueryResultsIterator), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) ExecuteQuery(namespace, query string) (ledger2.ResultsIterator, error) {
 	args := exec.Called(namespace)
 	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
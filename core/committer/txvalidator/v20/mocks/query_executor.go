@@ -337,3 +337,49 @@ func (_m *QueryExecutor) GetStateRangeScanIteratorWithPagination(namespace strin
 
 	return r0, r1
 }
+
+// GetStateReverseRangeScanIterator provides a mock function with given fields: namespace, startKey, endKey
+func (_m *QueryExecutor) GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (ledger.ResultsIterator, error) {
+	ret := _m.Called(namespace, startKey, endKey)
+
+	var r0 ledger.ResultsIterator
+	if rf, ok := ret.Get(0).(func(string, string, string) ledger.ResultsIterator); ok {
+		r0 = rf(namespace, startKey, endKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ledger.ResultsIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(namespace, startKey, endKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStateReverseRangeScanIteratorWithPagination provides a mock function with given fields: namespace, startKey, endKey, pageSize
+func (_m *QueryExecutor) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (coreledger.QueryResultsIterator, error) {
+	ret := _m.Called(namespace, startKey, endKey, pageSize)
+
+	var r0 coreledger.QueryResultsIterator
+	if rf, ok := ret.Get(0).(func(string, string, string, int32) coreledger.QueryResultsIterator); ok {
+		r0 = rf(namespace, startKey, endKey, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(coreledger.QueryResultsIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, int32) error); ok {
+		r1 = rf(namespace, startKey, endKey, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
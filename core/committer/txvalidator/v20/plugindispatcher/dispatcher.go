@@ -12,11 +12,13 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	s "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -28,6 +30,9 @@ type ChannelResources interface {
 	// GetMSPIDs returns the IDs for the application MSPs
 	// that have been defined in the channel
 	GetMSPIDs() []string
+
+	// Capabilities defines the capabilities for the application portion of this channel
+	Capabilities() channelconfig.ApplicationCapabilities
 }
 
 // LedgerResources provides access to ledger artefacts or
@@ -178,11 +183,34 @@ func (v *dispatcherImpl) Dispatch(seq int, payload *common.Payload, envBytes []b
 		}
 		namespaces[ns.NameSpace] = struct{}{}
 
+		if purgesPvtData(ns) && !v.cr.Capabilities().PurgePvtData() {
+			return errors.Errorf("purge of private data in namespace '%s' is not enabled, channel application capability of V3_0 or later is required", ns.NameSpace),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+
 		if v.txWritesToNamespace(ns) {
 			wrNamespace[ns.NameSpace] = true
 		}
 	}
 
+	if wrNamespace[crosschannel.Namespace] && v.cr.Capabilities().CrossChannelTransactions() {
+		// the writes staged by a cross-channel transaction must satisfy the policies of their own namespaces
+		staged, err := crosschannel.StagedRwSet(txRWSet)
+		if err != nil {
+			return err, peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+		if staged != nil {
+			if err := crosschannel.CheckStageable(staged); err != nil {
+				return err, peer.TxValidationCode_ILLEGAL_WRITESET
+			}
+			for _, ns := range staged.NsRwSets {
+				if v.txWritesToNamespace(ns) {
+					wrNamespace[ns.NameSpace] = true
+				}
+			}
+		}
+	}
+
 	// we've gathered all the info required to proceed to validation;
 	// validation will behave differently depending on the chaincode
 
@@ -274,6 +302,17 @@ func (v *dispatcherImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID str
 	return plugin, args, nil
 }
 
+// purgesPvtData returns true if the supplied NsRwSet
+// purges private data keys
+func purgesPvtData(ns *rwsetutil.NsRwSet) bool {
+	for _, c := range ns.CollHashedRwSets {
+		if len(c.PurgedKeyHashes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // txWritesToNamespace returns true if the supplied NsRwSet
 // performs a ledger write
 func (v *dispatcherImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
@@ -37,6 +37,20 @@ func (_m *Capabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// CrossChannelTransactions provides a mock function with given fields:
+func (_m *Capabilities) CrossChannelTransactions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // ForbidDuplicateTXIdInBlock provides a mock function with given fields:
 func (_m *Capabilities) ForbidDuplicateTXIdInBlock() bool {
 	ret := _m.Called()
@@ -93,6 +107,20 @@ func (_m *Capabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// PurgePvtData provides a mock function with given fields:
+func (_m *Capabilities) PurgePvtData() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // StorePvtDataOfInvalidTx provides a mock function with given fields:
 func (_m *Capabilities) StorePvtDataOfInvalidTx() bool {
 	ret := _m.Called()
@@ -59,6 +59,7 @@ func v20Capabilities() *tmocks.ApplicationCapabilities {
 	ac.On("V2_0Validation").Return(true)
 	ac.On("PrivateChannelData").Return(true)
 	ac.On("KeyLevelEndorsement").Return(true)
+	ac.On("PurgePvtData").Return(false)
 	return ac
 }
 
@@ -689,6 +690,33 @@ func TestInvokeOKPvtDataOnly(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestInvokeNOKPurgeNotSupported(t *testing.T) {
+	ccID := "mycc"
+
+	v, mockQE, _, _ := setupValidator()
+
+	mockQE.On("GetState", "lscc", ccID).Return(protoutil.MarshalOrPanic(&ccp.ChaincodeData{
+		Name:    ccID,
+		Version: ccVersion,
+		Vscc:    "vscc",
+		Policy:  signedByAnyMember([]string{"SampleOrg"}),
+	}), nil)
+
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ccID, "mycollection", "somekey")
+	rwset, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	rwsetBytes, err := rwset.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	tx := getEnv(ccID, nil, rwsetBytes, t)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+	err = v.Validate(b)
+	require.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+}
+
 func TestInvokeOKMetaUpdateOnly(t *testing.T) {
 	ccID := "mycc"
 
@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/golang/protobuf/proto"
@@ -412,7 +413,42 @@ type TransactionParams struct {
 	HistoryQueryExecutor ledger.HistoryQueryExecutor
 	CollectionStore      privdata.CollectionStore
 	IsInitTransaction    bool
+	CrossChannelWrites   *CrossChannelWrites
 
 	// this is additional data passed to the chaincode
 	ProposalDecorations map[string][]byte
 }
+
+// CrossChannelWrites collects the simulation results of the chaincodes that a transaction
+// invokes on other channels and that modify the state of these channels
+type CrossChannelWrites struct {
+	// ChannelID is the channel of the transaction, which coordinates the cross-channel transaction
+	ChannelID string
+	// TXSimulator is the simulator of the transaction on ChannelID
+	TXSimulator ledger.TxSimulator
+
+	mutex  sync.Mutex
+	rwsets map[string][]byte
+}
+
+// Add records the marshaled public simulation results of the given channel and returns the
+// simulation results of all the channels recorded so far. Only the results of a single chaincode
+// invocation can be recorded for each channel.
+func (c *CrossChannelWrites) Add(channelID string, rwset []byte) (map[string][]byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.rwsets[channelID]; exists {
+		return nil, errors.Errorf("channel %s is already modified by another chaincode invocation of this transaction", channelID)
+	}
+	if c.rwsets == nil {
+		c.rwsets = map[string][]byte{}
+	}
+	c.rwsets[channelID] = rwset
+
+	rwsets := make(map[string][]byte, len(c.rwsets))
+	for ch, rws := range c.rwsets {
+		rwsets[ch] = rws
+	}
+	return rwsets, nil
+}
@@ -14,6 +14,7 @@ import (
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api/policies"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -265,6 +266,14 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 	if err := rwset.FromProtoBytes(rwsetBytes); err != nil {
 		return policyErr(errors.WithMessagef(err, "txRWSet.FromProtoBytes failed on tx (%d,%d)", blockNum, txNum))
 	}
+	// the writes staged by a cross-channel transaction are validated as writes of the transaction
+	staged, err := crosschannel.StagedRwSet(rwset)
+	if err != nil {
+		return policyErr(errors.WithMessagef(err, "invalid staged writes on tx (%d,%d)", blockNum, txNum))
+	}
+	if staged != nil {
+		rwset.NsRwSets = append(rwset.NsRwSets, staged.NsRwSets...)
+	}
 
 	// return the decision of the policy evaluator
 	return policyEvaluator.Evaluate(blockNum, txNum, rwset.NsRwSets, cc, signatureSet)
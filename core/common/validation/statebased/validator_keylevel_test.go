@@ -14,6 +14,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
@@ -344,6 +345,45 @@ func TestCCEPValidation(t *testing.T) {
 	require.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
 }
 
+func TestKeylevelValidationStagedWrites(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: we validate a transaction that stages, in the record
+	// of a cross-channel transaction, a write to a key that contains
+	// key-level validation params. The staged write is validated as
+	// a write of the transaction.
+
+	vpMetadataKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	mr := &mockState{GetStateMetadataRv: map[string][]byte{vpMetadataKey: []byte("EP")}, GetPrivateDataMetadataByHashRv: map[string][]byte{}}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{"CCEP": fmt.Errorf("unexpected check of the chaincode policy")}}
+	validator := NewKeyLevelValidator(NewV13Evaluator(pe, pm), pm)
+
+	rwsbu := rwsetutil.NewRWSetBuilder()
+	rwsbu.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), protoutil.MarshalOrPanic(&crosschannel.Transaction{
+		Id:    "xtx1",
+		State: crosschannel.State_PREPARED,
+		Rwset: rwsetBytes(t, "cc"),
+	}))
+	rws := rwsbu.GetTxReadWriteSet()
+	rwsb, err := rws.ToProtoBytes()
+	require.NoError(t, err)
+	prp := []byte("barf")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsb))
+
+	validator.PreValidate(0, block)
+
+	err = validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	require.NoError(t, err)
+
+	pe.EvaluateResByPolicy["EP"] = fmt.Errorf("policy evaluation error")
+
+	err = validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	require.Error(t, err)
+	require.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+}
+
 func TestCCEPValidationReads(t *testing.T) {
 	t.Parallel()
 
@@ -65,6 +65,13 @@ var _ = Describe("BuildRegistry", func() {
 			Expect(bs.Done()).NotTo(BeClosed())
 			Expect(bs.Err()).To(BeNil())
 		})
+
+		It("can be removed", func() {
+			br.RemoveBuildStatus("ccid")
+			bs, ok := br.BuildStatus("ccid")
+			Expect(ok).To(BeFalse())
+			Expect(bs.Done()).NotTo(BeClosed())
+		})
 	})
 })
 
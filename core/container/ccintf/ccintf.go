@@ -35,6 +35,17 @@ type TLSConfig struct {
 
 // ChaincodeServerInfo provides chaincode connection information
 type ChaincodeServerInfo struct {
-	Address      string
+	Address string
+	// Addresses lists the replicas of the chaincode server, Address included,
+	// when the chaincode is served by more than one replica.
+	Addresses    []string
 	ClientConfig comm.ClientConfig
 }
+
+// ReplicaAddresses returns the addresses of the replicas of the chaincode server.
+func (c *ChaincodeServerInfo) ReplicaAddresses() []string {
+	if len(c.Addresses) == 0 {
+		return []string{c.Address}
+	}
+	return c.Addresses
+}
@@ -34,6 +34,14 @@ type ExternalBuilder interface {
 	Build(ccid string, metadata []byte, codePackageStream io.Reader) (Instance, error)
 }
 
+//go:generate counterfeiter -o mock/purger.go --fake-name Purger . Purger
+
+// Purger is implemented by the builders able to remove the outputs of a
+// chaincode build, such as the build directory or the docker image.
+type Purger interface {
+	Purge(ccid string) error
+}
+
 //go:generate counterfeiter -o mock/instance.go --fake-name Instance . Instance
 
 // Instance represents a built chaincode instance, because of the docker legacy, calling this a
@@ -83,9 +91,9 @@ func (r *Router) getInstance(ccid string) Instance {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	// Note, to resolve the locking problem which existed in the previous code, we never delete
-	// references from the map.  In this way, it is safe to release the lock and operate
-	// on the returned reference
+	// Note, to resolve the locking problem which existed in the previous code, references are
+	// only deleted from the map when the chaincode is purged, and the instance is never modified.
+	// In this way, it is safe to release the lock and operate on the returned reference
 	vm, ok := r.containers[ccid]
 	if !ok {
 		return UninitializedInstance{}
@@ -154,6 +162,32 @@ func (r *Router) Wait(ccid string) (int, error) {
 	return r.getInstance(ccid).Wait()
 }
 
+// Purge stops the chaincode, if it was built, and removes the outputs of its
+// build from the builders, so that it must be built again before it is started.
+func (r *Router) Purge(ccid string) error {
+	r.mutex.Lock()
+	instance, ok := r.containers[ccid]
+	delete(r.containers, ccid)
+	r.mutex.Unlock()
+
+	if ok {
+		// the chaincode is not necessarily running
+		if err := instance.Stop(); err != nil {
+			vmLogger.Debugw("failed to stop chaincode", "ccid", ccid, "error", err)
+		}
+	}
+
+	for _, builder := range []interface{}{r.ExternalBuilder, r.DockerBuilder} {
+		if purger, ok := builder.(Purger); ok {
+			if err := purger.Purge(ccid); err != nil {
+				return errors.WithMessage(err, "failed to purge chaincode build")
+			}
+		}
+	}
+
+	return nil
+}
+
 func (r *Router) Shutdown(timeout time.Duration) {
 	var wg sync.WaitGroup
 	for ccid := range r.containers {
@@ -256,5 +256,49 @@ var _ = Describe("Router", func() {
 				})
 			})
 		})
+
+		Describe("Purge", func() {
+			var fakePurger *mock.Purger
+
+			BeforeEach(func() {
+				fakePurger = &mock.Purger{}
+				router.ExternalBuilder = struct {
+					*mock.ExternalBuilder
+					*mock.Purger
+				}{fakeExternalBuilder, fakePurger}
+				fakeInstance.StopReturns(errors.New("not running"))
+			})
+
+			It("stops the instance and purges the build", func() {
+				err := router.Purge("fake-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeInstance.StopCallCount()).To(Equal(1))
+				Expect(fakePurger.PurgeCallCount()).To(Equal(1))
+				Expect(fakePurger.PurgeArgsForCall(0)).To(Equal("fake-id"))
+
+				err = router.Start("fake-id", &ccintf.PeerConnection{})
+				Expect(err).To(MatchError("instance has not yet been built, cannot be started"))
+			})
+
+			Context("when the chaincode has not yet been built", func() {
+				It("purges the build", func() {
+					err := router.Purge("missing-name")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeInstance.StopCallCount()).To(Equal(0))
+					Expect(fakePurger.PurgeCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the purge fails", func() {
+				BeforeEach(func() {
+					fakePurger.PurgeReturns(errors.New("fake-purge-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Purge("fake-id")
+					Expect(err).To(MatchError("failed to purge chaincode build: fake-purge-error"))
+				})
+			})
+		})
 	})
 })
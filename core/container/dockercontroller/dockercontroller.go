@@ -70,6 +70,8 @@ type dockerClient interface {
 	WaitContainer(containerID string) (int, error)
 	// InspectImage returns an image by its name or ID.
 	InspectImage(imageName string) (*docker.Image, error)
+	// RemoveImage removes an image by its name or ID.
+	RemoveImage(name string) error
 }
 
 type PlatformBuilder interface {
@@ -211,6 +213,21 @@ func (vm *DockerVM) Build(ccid string, metadata *persistence.ChaincodePackageMet
 	}, nil
 }
 
+// Purge removes the image built for the chaincode, if it exists.
+func (vm *DockerVM) Purge(ccid string) error {
+	imageName, err := vm.GetVMNameForDocker(ccid)
+	if err != nil {
+		return err
+	}
+
+	switch err := vm.Client.RemoveImage(imageName); err {
+	case nil, docker.ErrNoSuchImage:
+		return nil
+	default:
+		return errors.Wrap(err, "docker image removal failed")
+	}
+}
+
 // In order to support starting chaincode containers built with Fabric v1.4 and earlier,
 // we must check for the precense of the start.sh script for Node.js chaincode before
 // attempting to call it.
@@ -282,6 +282,28 @@ func Test_Stop(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPurge(t *testing.T) {
+	client := &mock.DockerClient{}
+	dvm := DockerVM{Client: client}
+	imageName, err := dvm.GetVMNameForDocker("chaincode-name:chaincode-version")
+	require.NoError(t, err)
+
+	err = dvm.Purge("chaincode-name:chaincode-version")
+	require.NoError(t, err)
+	require.Equal(t, 1, client.RemoveImageCallCount())
+	require.Equal(t, imageName, client.RemoveImageArgsForCall(0))
+
+	// the image does not exist
+	client.RemoveImageReturns(docker.ErrNoSuchImage)
+	err = dvm.Purge("chaincode-name:chaincode-version")
+	require.NoError(t, err)
+
+	// removal fails
+	client.RemoveImageReturns(errors.New("image-in-use"))
+	err = dvm.Purge("chaincode-name:chaincode-version")
+	require.EqualError(t, err, "docker image removal failed: image-in-use")
+}
+
 func Test_Wait(t *testing.T) {
 	dvm := DockerVM{}
 
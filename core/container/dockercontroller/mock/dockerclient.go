@@ -90,6 +90,17 @@ type DockerClient struct {
 	removeContainerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RemoveImageStub        func(string) error
+	removeImageMutex       sync.RWMutex
+	removeImageArgsForCall []struct {
+		arg1 string
+	}
+	removeImageReturns struct {
+		result1 error
+	}
+	removeImageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartContainerStub        func(string, *docker.HostConfig) error
 	startContainerMutex       sync.RWMutex
 	startContainerArgsForCall []struct {
@@ -569,6 +580,66 @@ func (fake *DockerClient) RemoveContainerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *DockerClient) RemoveImage(arg1 string) error {
+	fake.removeImageMutex.Lock()
+	ret, specificReturn := fake.removeImageReturnsOnCall[len(fake.removeImageArgsForCall)]
+	fake.removeImageArgsForCall = append(fake.removeImageArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RemoveImage", []interface{}{arg1})
+	fake.removeImageMutex.Unlock()
+	if fake.RemoveImageStub != nil {
+		return fake.RemoveImageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeImageReturns
+	return fakeReturns.result1
+}
+
+func (fake *DockerClient) RemoveImageCallCount() int {
+	fake.removeImageMutex.RLock()
+	defer fake.removeImageMutex.RUnlock()
+	return len(fake.removeImageArgsForCall)
+}
+
+func (fake *DockerClient) RemoveImageCalls(stub func(string) error) {
+	fake.removeImageMutex.Lock()
+	defer fake.removeImageMutex.Unlock()
+	fake.RemoveImageStub = stub
+}
+
+func (fake *DockerClient) RemoveImageArgsForCall(i int) string {
+	fake.removeImageMutex.RLock()
+	defer fake.removeImageMutex.RUnlock()
+	argsForCall := fake.removeImageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DockerClient) RemoveImageReturns(result1 error) {
+	fake.removeImageMutex.Lock()
+	defer fake.removeImageMutex.Unlock()
+	fake.RemoveImageStub = nil
+	fake.removeImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DockerClient) RemoveImageReturnsOnCall(i int, result1 error) {
+	fake.removeImageMutex.Lock()
+	defer fake.removeImageMutex.Unlock()
+	fake.RemoveImageStub = nil
+	if fake.removeImageReturnsOnCall == nil {
+		fake.removeImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *DockerClient) StartContainer(arg1 string, arg2 *docker.HostConfig) error {
 	fake.startContainerMutex.Lock()
 	ret, specificReturn := fake.startContainerReturnsOnCall[len(fake.startContainerArgsForCall)]
@@ -832,6 +903,8 @@ func (fake *DockerClient) Invocations() map[string][][]interface{} {
 	defer fake.pingWithContextMutex.RUnlock()
 	fake.removeContainerMutex.RLock()
 	defer fake.removeContainerMutex.RUnlock()
+	fake.removeImageMutex.RLock()
+	defer fake.removeImageMutex.RUnlock()
 	fake.startContainerMutex.RLock()
 	defer fake.startContainerMutex.RUnlock()
 	fake.stopContainerMutex.RLock()
@@ -171,6 +171,15 @@ func (d *Detector) Build(ccid string, mdBytes []byte, codeStream io.Reader) (*In
 	}, nil
 }
 
+// Purge removes the persisted build output of the chaincode, if any.
+func (d *Detector) Purge(ccid string) error {
+	durablePath := filepath.Join(d.DurablePath, SanitizeCCIDPath(ccid))
+	if err := os.RemoveAll(durablePath); err != nil {
+		return errors.WithMessagef(err, "could not remove build output at '%s'", durablePath)
+	}
+	return nil
+}
+
 func (d *Detector) detect(buildContext *BuildContext) *Builder {
 	for _, builder := range d.Builders {
 		if builder.Detect(buildContext) {
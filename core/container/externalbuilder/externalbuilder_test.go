@@ -211,6 +211,31 @@ var _ = Describe("externalbuilder", func() {
 				})
 			})
 		})
+
+		Describe("Purge", func() {
+			BeforeEach(func() {
+				_, err := detector.Build("fake-package-id", md, codePackage)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the build output", func() {
+				err := detector.Purge("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filepath.Join(durablePath, "fake-package-id")).NotTo(BeAnExistingFile())
+
+				i, err := detector.CachedBuild("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(i).To(BeNil())
+			})
+
+			When("the chaincode was not built", func() {
+				It("does nothing", func() {
+					err := detector.Purge("missing-package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(filepath.Join(durablePath, "fake-package-id")).To(BeADirectory())
+				})
+			})
+		})
 	})
 
 	Describe("Builders", func() {
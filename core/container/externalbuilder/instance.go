@@ -66,6 +66,7 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 // ChaincodeServerUserData holds "connection.json" information
 type ChaincodeServerUserData struct {
 	Address            string   `json:"address"`
+	Addresses          []string `json:"addresses"` // addresses of the replicas of the chaincode server
 	DialTimeout        Duration `json:"dial_timeout"`
 	TLSRequired        bool     `json:"tls_required"`
 	ClientAuthRequired bool     `json:"client_auth_required"`
@@ -76,10 +77,14 @@ type ChaincodeServerUserData struct {
 }
 
 func (c *ChaincodeServerUserData) ChaincodeServerInfo(cryptoDir string) (*ccintf.ChaincodeServerInfo, error) {
-	if c.Address == "" {
+	addresses := c.replicaAddresses()
+	if len(addresses) == 0 {
 		return nil, errors.New("chaincode address not provided")
 	}
-	connInfo := &ccintf.ChaincodeServerInfo{Address: c.Address}
+	connInfo := &ccintf.ChaincodeServerInfo{Address: addresses[0]}
+	if len(addresses) > 1 {
+		connInfo.Addresses = addresses
+	}
 
 	connInfo.ClientConfig.Timeout = time.Duration(c.DialTimeout)
 	if connInfo.ClientConfig.Timeout == 0 {
@@ -115,6 +120,21 @@ func (c *ChaincodeServerUserData) ChaincodeServerInfo(cryptoDir string) (*ccintf
 	return connInfo, nil
 }
 
+// replicaAddresses returns the address and the addresses of the replicas,
+// without duplicates.
+func (c *ChaincodeServerUserData) replicaAddresses() []string {
+	var addresses []string
+	seen := map[string]bool{}
+	for _, address := range append([]string{c.Address}, c.Addresses...) {
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
 func (i *Instance) ChaincodeServerReleaseDir() string {
 	return filepath.Join(i.ReleaseDir, CCServerReleaseDir)
 }
@@ -141,6 +141,27 @@ var _ = Describe("Instance", func() {
 			os.RemoveAll(releaseDir)
 		})
 
+		When("chaincode is served by several replicas", func() {
+			It("returns the addresses of the replicas without duplicates", func() {
+				ccuserdata.Addresses = []string{"replica1:12345", "ccaddress:12345", "replica2:12345", ""}
+
+				ccinfo, err := ccuserdata.ChaincodeServerInfo(releaseDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccinfo.Address).To(Equal("ccaddress:12345"))
+				Expect(ccinfo.Addresses).To(Equal([]string{"ccaddress:12345", "replica1:12345", "replica2:12345"}))
+				Expect(ccinfo.ReplicaAddresses()).To(Equal(ccinfo.Addresses))
+			})
+
+			It("does not list the replicas of a single address", func() {
+				ccuserdata.Addresses = []string{"ccaddress:12345"}
+
+				ccinfo, err := ccuserdata.ChaincodeServerInfo(releaseDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccinfo.Addresses).To(BeNil())
+				Expect(ccinfo.ReplicaAddresses()).To(Equal([]string{"ccaddress:12345"}))
+			})
+		})
+
 		When("chaincode does not provide all info", func() {
 			Context("tls is not provided", func() {
 				It("returns TLS without client auth information", func() {
@@ -210,6 +231,19 @@ var _ = Describe("Instance", func() {
 				})
 			})
 
+			Context("only replica addresses are provided", func() {
+				It("uses the first replica as the address", func() {
+					ccuserdata.TLSRequired = false
+					ccuserdata.Address = ""
+					ccuserdata.Addresses = []string{"replica0:12345", "replica1:12345"}
+
+					ccinfo, err := ccuserdata.ChaincodeServerInfo(releaseDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccinfo.Address).To(Equal("replica0:12345"))
+					Expect(ccinfo.Addresses).To(Equal([]string{"replica0:12345", "replica1:12345"}))
+				})
+			})
+
 			Context("key is not provided", func() {
 				It("returns missing key error", func() {
 					ccuserdata.ClientKey = ""
@@ -0,0 +1,303 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	inClusterTokenFile     = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAFile        = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// ClientConfig holds the parameters needed to reach the Kubernetes API server.
+// The parameters left empty are taken from the service account of the pod the
+// peer runs in, if any.
+type ClientConfig struct {
+	// APIServer is the URL of the API server, e.g. https://kubernetes.default.svc
+	APIServer string
+	// Namespace is the namespace the chaincode pods are created in.
+	Namespace string
+	// TokenFile is the path to the bearer token used to authenticate to the API server.
+	TokenFile string
+	// CAFile is the path to the PEM encoded CA certificate of the API server.
+	CAFile string
+	// Timeout bounds the requests that do not stream.
+	Timeout time.Duration
+}
+
+// Client is a minimal client of the Kubernetes REST API, limited to the
+// resources the chaincode runtime manages.
+type Client struct {
+	APIServer  string
+	Namespace  string
+	Token      string
+	HTTPClient *http.Client
+	// StreamClient is used for the requests that stream, which must not time out.
+	StreamClient *http.Client
+}
+
+// NewClient creates a client from the configuration, falling back to the
+// in-cluster service account for the parameters that are not set.
+func NewClient(conf ClientConfig) (*Client, error) {
+	apiServer := conf.APIServer
+	if apiServer == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("API server not set and the peer is not running in a Kubernetes cluster")
+		}
+		apiServer = "https://" + net.JoinHostPort(host, port)
+	}
+	if _, err := url.Parse(apiServer); err != nil {
+		return nil, errors.Wrapf(err, "invalid API server URL %s", apiServer)
+	}
+
+	tokenFile := conf.TokenFile
+	if tokenFile == "" && conf.APIServer == "" {
+		tokenFile = inClusterTokenFile
+	}
+	var token string
+	if tokenFile != "" {
+		raw, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the API server token")
+		}
+		token = strings.TrimSpace(string(raw))
+	}
+
+	caFile := conf.CAFile
+	if caFile == "" && conf.APIServer == "" {
+		caFile = inClusterCAFile
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the API server CA certificate")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificates found in %s", caFile)
+		}
+	}
+
+	namespace := conf.Namespace
+	if namespace == "" {
+		raw, err := ioutil.ReadFile(inClusterNamespaceFile)
+		if err != nil {
+			namespace = "default"
+		} else {
+			namespace = strings.TrimSpace(string(raw))
+		}
+	}
+
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+	}
+
+	return &Client{
+		APIServer:    strings.TrimSuffix(apiServer, "/"),
+		Namespace:    namespace,
+		Token:        token,
+		HTTPClient:   &http.Client{Transport: transport, Timeout: timeout},
+		StreamClient: &http.Client{Transport: transport},
+	}, nil
+}
+
+// StatusError is returned when the API server answers with an error status.
+type StatusError struct {
+	Code    int
+	Reason  string
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API server returned %d", e.Code)
+	}
+	return fmt.Sprintf("API server returned %d: %s", e.Code, e.Message)
+}
+
+// IsNotFound returns true if the error reports that the resource does not exist.
+func IsNotFound(err error) bool {
+	se, ok := errors.Cause(err).(*StatusError)
+	return ok && se.Code == http.StatusNotFound
+}
+
+func (c *Client) path(resource, name string) string {
+	p := fmt.Sprintf("/api/v1/namespaces/%s/%s", url.PathEscape(c.Namespace), resource)
+	if name != "" {
+		p += "/" + url.PathEscape(name)
+	}
+	return p
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	var payload io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal request")
+		}
+		payload = bytes.NewReader(raw)
+	}
+
+	u := c.APIServer + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, payload)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return req, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s failed", method, path)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrapf(err, "failed to decode the response to %s %s", method, path)
+	}
+	return nil
+}
+
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	se := &StatusError{Code: resp.StatusCode}
+	status := &Status{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err == nil {
+		se.Reason = status.Reason
+		se.Message = status.Message
+	}
+	return se
+}
+
+// CreatePod creates the pod in the namespace of the client.
+func (c *Client) CreatePod(ctx context.Context, pod *Pod) error {
+	return c.do(ctx, http.MethodPost, c.path("pods", ""), nil, pod, nil)
+}
+
+// GetPod returns the pod with the given name.
+func (c *Client) GetPod(ctx context.Context, name string) (*Pod, error) {
+	pod := &Pod{}
+	if err := c.do(ctx, http.MethodGet, c.path("pods", name), nil, nil, pod); err != nil {
+		return nil, err
+	}
+	return pod, nil
+}
+
+// ListPods returns the pods matching the label selector.
+func (c *Client) ListPods(ctx context.Context, labelSelector string) ([]Pod, error) {
+	list := &PodList{}
+	query := url.Values{"labelSelector": []string{labelSelector}}
+	if err := c.do(ctx, http.MethodGet, c.path("pods", ""), query, nil, list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// DeletePod deletes the pod with the given name without waiting for it to
+// terminate gracefully.
+func (c *Client) DeletePod(ctx context.Context, name string) error {
+	query := url.Values{"gracePeriodSeconds": []string{"0"}}
+	return c.do(ctx, http.MethodDelete, c.path("pods", name), query, nil, nil)
+}
+
+// CreateService creates the service in the namespace of the client.
+func (c *Client) CreateService(ctx context.Context, svc *Service) error {
+	return c.do(ctx, http.MethodPost, c.path("services", ""), nil, svc, nil)
+}
+
+// DeleteService deletes the service with the given name.
+func (c *Client) DeleteService(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.path("services", name), nil, nil, nil)
+}
+
+// CreateSecret creates the secret in the namespace of the client.
+func (c *Client) CreateSecret(ctx context.Context, secret *Secret) error {
+	return c.do(ctx, http.MethodPost, c.path("secrets", ""), nil, secret, nil)
+}
+
+// DeleteSecret deletes the secret with the given name.
+func (c *Client) DeleteSecret(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, c.path("secrets", name), nil, nil, nil)
+}
+
+// StreamPodLogs follows the output of a container of the pod. The stream ends
+// when the container terminates or the context is canceled.
+func (c *Client) StreamPodLogs(ctx context.Context, name, container string) (io.ReadCloser, error) {
+	query := url.Values{"container": []string{container}, "follow": []string{"true"}}
+	req, err := c.newRequest(ctx, http.MethodGet, c.path("pods", name)+"/log", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.StreamClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to stream the logs of pod %s", name)
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Version returns the version of the API server.
+func (c *Client) Version(ctx context.Context) (*VersionInfo, error) {
+	version := &VersionInfo{}
+	if err := c.do(ctx, http.MethodGet, "/version", nil, nil, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
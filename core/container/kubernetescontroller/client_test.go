@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "kubernetescontroller")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	caFile := filepath.Join(tempDir, "ca.crt")
+	err = ioutil.WriteFile(caFile, ca.CertBytes(), 0600)
+	require.NoError(t, err)
+	tokenFile := filepath.Join(tempDir, "token")
+	err = ioutil.WriteFile(tokenFile, []byte("secret-token\n"), 0600)
+	require.NoError(t, err)
+
+	t.Run("explicit configuration", func(t *testing.T) {
+		client, err := kubernetescontroller.NewClient(kubernetescontroller.ClientConfig{
+			APIServer: "https://kubernetes.example.com:6443/",
+			Namespace: "fabric",
+			TokenFile: tokenFile,
+			CAFile:    caFile,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "https://kubernetes.example.com:6443", client.APIServer)
+		require.Equal(t, "fabric", client.Namespace)
+		require.Equal(t, "secret-token", client.Token)
+		require.NotZero(t, client.HTTPClient.Timeout)
+		require.Zero(t, client.StreamClient.Timeout)
+	})
+
+	t.Run("not in a cluster", func(t *testing.T) {
+		defer os.Setenv("KUBERNETES_SERVICE_HOST", os.Getenv("KUBERNETES_SERVICE_HOST"))
+		os.Unsetenv("KUBERNETES_SERVICE_HOST")
+
+		_, err := kubernetescontroller.NewClient(kubernetescontroller.ClientConfig{})
+		require.EqualError(t, err, "API server not set and the peer is not running in a Kubernetes cluster")
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := kubernetescontroller.NewClient(kubernetescontroller.ClientConfig{
+			APIServer: "https://kubernetes.example.com",
+			TokenFile: filepath.Join(tempDir, "missing"),
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read the API server token")
+	})
+
+	t.Run("bad CA", func(t *testing.T) {
+		_, err := kubernetescontroller.NewClient(kubernetescontroller.ClientConfig{
+			APIServer: "https://kubernetes.example.com",
+			CAFile:    tokenFile,
+		})
+		require.EqualError(t, err, "no certificates found in "+tokenFile)
+	})
+}
+
+func TestClient(t *testing.T) {
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	client := fs.client("fabric")
+	ctx := context.Background()
+
+	version, err := client.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "v1.18.0", version.GitVersion)
+
+	pod := &kubernetescontroller.Pod{
+		Metadata: kubernetescontroller.ObjectMeta{Name: "mycc", Labels: map[string]string{"peer": "peer0"}},
+		Spec: kubernetescontroller.PodSpec{
+			Containers: []kubernetescontroller.Container{{Name: "chaincode", Image: "mycc:latest"}},
+		},
+	}
+	err = client.CreatePod(ctx, pod)
+	require.NoError(t, err)
+
+	err = client.CreatePod(ctx, pod)
+	require.EqualError(t, err, "API server returned 409: alreadyexists")
+
+	got, err := client.GetPod(ctx, "mycc")
+	require.NoError(t, err)
+	require.Equal(t, pod, got)
+
+	pods, err := client.ListPods(ctx, "peer=peer0")
+	require.NoError(t, err)
+	require.Equal(t, []kubernetescontroller.Pod{*pod}, pods)
+	pods, err = client.ListPods(ctx, "peer=peer1")
+	require.NoError(t, err)
+	require.Empty(t, pods)
+
+	fs.setLogs("mycc", "line one\nline two\n")
+	logs, err := client.StreamPodLogs(ctx, "mycc", "chaincode")
+	require.NoError(t, err)
+	output, err := ioutil.ReadAll(logs)
+	require.NoError(t, err)
+	require.Equal(t, "line one\nline two\n", string(output))
+	logs.Close()
+
+	err = client.DeletePod(ctx, "mycc")
+	require.NoError(t, err)
+	_, err = client.GetPod(ctx, "mycc")
+	require.True(t, kubernetescontroller.IsNotFound(err))
+	_, err = client.StreamPodLogs(ctx, "mycc", "chaincode")
+	require.True(t, kubernetescontroller.IsNotFound(err))
+
+	err = client.CreateService(ctx, &kubernetescontroller.Service{Metadata: kubernetescontroller.ObjectMeta{Name: "mycc"}})
+	require.NoError(t, err)
+	err = client.DeleteService(ctx, "mycc")
+	require.NoError(t, err)
+	err = client.DeleteService(ctx, "mycc")
+	require.True(t, kubernetescontroller.IsNotFound(err))
+
+	err = client.CreateSecret(ctx, &kubernetescontroller.Secret{Metadata: kubernetescontroller.ObjectMeta{Name: "mycc"}})
+	require.NoError(t, err)
+	err = client.DeleteSecret(ctx, "mycc")
+	require.NoError(t, err)
+
+	client.Token = "wrong"
+	_, err = client.Version(ctx)
+	require.EqualError(t, err, "API server returned 401: unauthorized")
+	require.False(t, kubernetescontroller.IsNotFound(err))
+
+	fs.Close()
+	_, err = client.Version(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "GET /version failed")
+}
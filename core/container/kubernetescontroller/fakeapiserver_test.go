@@ -0,0 +1,196 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
+)
+
+// fakeAPIServer is an in-memory stand-in for the Kubernetes API server,
+// serving the pods, services and secrets of a single namespace.
+type fakeAPIServer struct {
+	*httptest.Server
+
+	mutex   sync.Mutex
+	token   string
+	objects map[string]map[string][]byte // resource -> name -> object
+	logs    map[string]string
+}
+
+func newFakeAPIServer(namespace, token string) *fakeAPIServer {
+	fs := &fakeAPIServer{
+		token: token,
+		objects: map[string]map[string][]byte{
+			"pods":     {},
+			"services": {},
+			"secrets":  {},
+		},
+		logs: map[string]string{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", fs.handleVersion)
+	mux.HandleFunc("/api/v1/namespaces/"+namespace+"/", fs.handleNamespace)
+	fs.Server = httptest.NewServer(fs.authenticate(mux))
+	return fs
+}
+
+func (fs *fakeAPIServer) client(namespace string) *kubernetescontroller.Client {
+	return &kubernetescontroller.Client{
+		APIServer:    fs.URL,
+		Namespace:    namespace,
+		Token:        fs.token,
+		HTTPClient:   fs.Client(),
+		StreamClient: fs.Client(),
+	}
+}
+
+func (fs *fakeAPIServer) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+fs.token {
+			writeStatus(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (fs *fakeAPIServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(&kubernetescontroller.VersionInfo{Major: "1", Minor: "18", GitVersion: "v1.18.0"})
+}
+
+func (fs *fakeAPIServer) handleNamespace(w http.ResponseWriter, r *http.Request) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	// /api/v1/namespaces/<namespace>/<resource>[/<name>[/log]]
+	parts := strings.Split(r.URL.Path, "/")[5:]
+	resource := parts[0]
+	store, ok := fs.objects[resource]
+	if !ok {
+		writeStatus(w, http.StatusNotFound, "NotFound")
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodPost:
+			fs.create(w, r, store)
+		case http.MethodGet:
+			fs.list(w, store, r.URL.Query().Get("labelSelector"))
+		default:
+			writeStatus(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		}
+		return
+	}
+
+	name := parts[1]
+	object, exists := store[name]
+	if !exists {
+		writeStatus(w, http.StatusNotFound, "NotFound")
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[2] == "log":
+		logs, ok := fs.logs[name]
+		if !ok {
+			writeStatus(w, http.StatusBadRequest, "BadRequest")
+			return
+		}
+		w.Write([]byte(logs))
+	case r.Method == http.MethodGet:
+		w.Write(object)
+	case r.Method == http.MethodDelete:
+		delete(store, name)
+		w.Write([]byte("{}"))
+	default:
+		writeStatus(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+func (fs *fakeAPIServer) create(w http.ResponseWriter, r *http.Request, store map[string][]byte) {
+	raw, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, "BadRequest")
+		return
+	}
+	object := &struct {
+		Metadata kubernetescontroller.ObjectMeta `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(raw, object); err != nil || object.Metadata.Name == "" {
+		writeStatus(w, http.StatusBadRequest, "BadRequest")
+		return
+	}
+	if _, exists := store[object.Metadata.Name]; exists {
+		writeStatus(w, http.StatusConflict, "AlreadyExists")
+		return
+	}
+	store[object.Metadata.Name] = raw
+	w.WriteHeader(http.StatusCreated)
+	w.Write(raw)
+}
+
+func (fs *fakeAPIServer) list(w http.ResponseWriter, store map[string][]byte, selector string) {
+	kv := strings.SplitN(selector, "=", 2)
+	items := []json.RawMessage{}
+	for _, raw := range store {
+		object := &struct {
+			Metadata kubernetescontroller.ObjectMeta `json:"metadata"`
+		}{}
+		json.Unmarshal(raw, object)
+		if len(kv) == 2 && object.Metadata.Labels[kv[0]] != kv[1] {
+			continue
+		}
+		items = append(items, raw)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+}
+
+func writeStatus(w http.ResponseWriter, code int, reason string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(&kubernetescontroller.Status{Code: code, Reason: reason, Message: strings.ToLower(reason)})
+}
+
+// get decodes the object of the resource into out, and returns false if it
+// does not exist.
+func (fs *fakeAPIServer) get(resource, name string, out interface{}) bool {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	raw, ok := fs.objects[resource][name]
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+func (fs *fakeAPIServer) put(resource, name string, object interface{}) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		panic(err)
+	}
+	fs.objects[resource][name] = raw
+}
+
+func (fs *fakeAPIServer) setLogs(name, logs string) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	fs.logs[name] = logs
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller
+
+import (
+	"bytes"
+	"io"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/docker_vm.go --fake-name DockerVM . dockerVM
+
+// dockerVM represents the docker runtime the images are built with.
+type dockerVM interface {
+	// Build builds the image of the chaincode if it does not exist yet.
+	Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackage io.Reader) (container.Instance, error)
+	// GetVMNameForDocker returns the name of the image of the chaincode.
+	GetVMNameForDocker(ccid string) (string, error)
+	// Purge removes the image of the chaincode.
+	Purge(ccid string) error
+}
+
+//go:generate counterfeiter -o mock/registry_client.go --fake-name RegistryClient . registryClient
+
+// registryClient represents the docker client the images are pushed with.
+type registryClient interface {
+	// TagImage adds a tag to the image identified by the given name.
+	TagImage(name string, opts docker.TagImageOptions) error
+	// PushImage pushes an image to a registry.
+	PushImage(opts docker.PushImageOptions, auth docker.AuthConfiguration) error
+}
+
+// DockerImageBuilder builds the chaincode images with docker, the same way
+// the docker runtime does, and pushes them to the registry the nodes of the
+// cluster pull from. When no registry is set, the images are expected to be
+// visible to the nodes as they are built, as with single node clusters
+// sharing the docker daemon of the peer.
+type DockerImageBuilder struct {
+	DockerVM dockerVM
+	Client   registryClient
+	// Registry is the registry the images are pushed to, e.g. registry.example.com/fabric
+	Registry string
+	Auth     docker.AuthConfiguration
+}
+
+// BuildImage builds the image of the chaincode and pushes it to the registry.
+func (b *DockerImageBuilder) BuildImage(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackage io.Reader) (string, error) {
+	if _, err := b.DockerVM.Build(ccid, metadata, codePackage); err != nil {
+		return "", err
+	}
+
+	imageName, err := b.DockerVM.GetVMNameForDocker(ccid)
+	if err != nil {
+		return "", err
+	}
+	if b.Registry == "" {
+		return imageName, nil
+	}
+
+	repository := b.Registry + "/" + imageName
+	err = b.Client.TagImage(imageName, docker.TagImageOptions{Repo: repository, Tag: "latest", Force: true})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to tag image %s", imageName)
+	}
+
+	outputbuf := bytes.NewBuffer(nil)
+	err = b.Client.PushImage(docker.PushImageOptions{Name: repository, Tag: "latest", OutputStream: outputbuf}, b.Auth)
+	if err != nil {
+		kubernetesLogger.Errorf("Push Output:\n********************\n%s\n********************", outputbuf.String())
+		return "", errors.Wrapf(err, "failed to push image %s", repository)
+	}
+
+	kubernetesLogger.Debugf("Pushed image: %s", repository)
+	return repository + ":latest", nil
+}
+
+// Purge removes the local image of the chaincode. The image pushed to the
+// registry is left to the retention policies of the registry.
+func (b *DockerImageBuilder) Purge(ccid string) error {
+	return b.DockerVM.Purge(ccid)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerImageBuilder(t *testing.T) {
+	metadata := &persistence.ChaincodePackageMetadata{Type: "golang", Path: "github.com/mycc"}
+
+	setup := func() (*mock.DockerVM, *mock.RegistryClient, *kubernetescontroller.DockerImageBuilder) {
+		fakeDockerVM := &mock.DockerVM{}
+		fakeDockerVM.GetVMNameForDockerReturns("dev-peer0-mycc-1234-abcdef", nil)
+		fakeClient := &mock.RegistryClient{}
+		builder := &kubernetescontroller.DockerImageBuilder{
+			DockerVM: fakeDockerVM,
+			Client:   fakeClient,
+			Registry: "registry.example.com/fabric",
+			Auth:     docker.AuthConfiguration{Username: "user"},
+		}
+		return fakeDockerVM, fakeClient, builder
+	}
+
+	t.Run("pushes to the registry", func(t *testing.T) {
+		fakeDockerVM, fakeClient, builder := setup()
+
+		image, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.NoError(t, err)
+		require.Equal(t, "registry.example.com/fabric/dev-peer0-mycc-1234-abcdef:latest", image)
+
+		require.Equal(t, 1, fakeDockerVM.BuildCallCount())
+		ccid, md, _ := fakeDockerVM.BuildArgsForCall(0)
+		require.Equal(t, "mycc:1234", ccid)
+		require.Equal(t, metadata, md)
+
+		require.Equal(t, 1, fakeClient.TagImageCallCount())
+		name, tagOpts := fakeClient.TagImageArgsForCall(0)
+		require.Equal(t, "dev-peer0-mycc-1234-abcdef", name)
+		require.Equal(t, docker.TagImageOptions{Repo: "registry.example.com/fabric/dev-peer0-mycc-1234-abcdef", Tag: "latest", Force: true}, tagOpts)
+
+		require.Equal(t, 1, fakeClient.PushImageCallCount())
+		pushOpts, auth := fakeClient.PushImageArgsForCall(0)
+		require.Equal(t, "registry.example.com/fabric/dev-peer0-mycc-1234-abcdef", pushOpts.Name)
+		require.Equal(t, "latest", pushOpts.Tag)
+		require.Equal(t, docker.AuthConfiguration{Username: "user"}, auth)
+	})
+
+	t.Run("no registry", func(t *testing.T) {
+		_, fakeClient, builder := setup()
+		builder.Registry = ""
+
+		image, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.NoError(t, err)
+		require.Equal(t, "dev-peer0-mycc-1234-abcdef", image)
+		require.Zero(t, fakeClient.TagImageCallCount())
+		require.Zero(t, fakeClient.PushImageCallCount())
+	})
+
+	t.Run("build failure", func(t *testing.T) {
+		fakeDockerVM, _, builder := setup()
+		fakeDockerVM.BuildReturns(nil, errors.New("boom"))
+
+		_, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("bad image name", func(t *testing.T) {
+		fakeDockerVM, _, builder := setup()
+		fakeDockerVM.GetVMNameForDockerReturns("", errors.New("bad-name"))
+
+		_, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.EqualError(t, err, "bad-name")
+	})
+
+	t.Run("tag failure", func(t *testing.T) {
+		_, fakeClient, builder := setup()
+		fakeClient.TagImageReturns(errors.New("boom"))
+
+		_, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.EqualError(t, err, "failed to tag image dev-peer0-mycc-1234-abcdef: boom")
+	})
+
+	t.Run("push failure", func(t *testing.T) {
+		_, fakeClient, builder := setup()
+		fakeClient.PushImageReturns(errors.New("boom"))
+
+		_, err := builder.BuildImage("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+		require.EqualError(t, err, "failed to push image registry.example.com/fabric/dev-peer0-mycc-1234-abcdef: boom")
+	})
+}
@@ -0,0 +1,444 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/pkg/errors"
+)
+
+var (
+	kubernetesLogger = flogging.MustGetLogger("kubernetescontroller")
+	nameRegExp       = regexp.MustCompile("[^a-z0-9-]+")
+	labelRegExp      = regexp.MustCompile("[^a-zA-Z0-9-_.]+")
+)
+
+const (
+	// PeerLabel is the label of the chaincode pods holding the peer that launched them.
+	PeerLabel = "fabric.hyperledger.org/peer"
+	// ChaincodeLabel is the label of the chaincode pods holding the name of the pod.
+	ChaincodeLabel = "fabric.hyperledger.org/chaincode"
+
+	chaincodeContainer = "chaincode"
+	tlsVolume          = "tls"
+
+	// DefaultPollInterval is the interval between two checks of the state of a pod.
+	DefaultPollInterval = time.Second
+	// DefaultLogAttachTimeout bounds the time spent waiting for the chaincode
+	// container to start before its output can be streamed.
+	DefaultLogAttachTimeout = 2 * time.Minute
+)
+
+// waitingReasonsUnhealthy are the reasons a container waits for which the pod
+// never starts without an intervention.
+var waitingReasonsUnhealthy = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// kubernetesClient represents the subset of the Kubernetes API used to manage
+// the chaincode pods.
+type kubernetesClient interface {
+	CreatePod(ctx context.Context, pod *Pod) error
+	GetPod(ctx context.Context, name string) (*Pod, error)
+	ListPods(ctx context.Context, labelSelector string) ([]Pod, error)
+	DeletePod(ctx context.Context, name string) error
+	CreateService(ctx context.Context, svc *Service) error
+	DeleteService(ctx context.Context, name string) error
+	CreateSecret(ctx context.Context, secret *Secret) error
+	DeleteSecret(ctx context.Context, name string) error
+	StreamPodLogs(ctx context.Context, name, container string) (io.ReadCloser, error)
+	Version(ctx context.Context) (*VersionInfo, error)
+}
+
+//go:generate counterfeiter -o mock/image_builder.go --fake-name ImageBuilder . ImageBuilder
+
+// ImageBuilder builds the image of a chaincode and makes it available to the
+// nodes of the cluster.
+type ImageBuilder interface {
+	// BuildImage builds the image of the chaincode if it does not exist yet,
+	// and returns the name the pods refer to it by.
+	BuildImage(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackage io.Reader) (string, error)
+}
+
+type ContainerInstance struct {
+	CCID         string
+	Type         string
+	Image        string
+	KubernetesVM *KubernetesVM
+}
+
+func (ci *ContainerInstance) Start(peerConnection *ccintf.PeerConnection) error {
+	return ci.KubernetesVM.Start(ci.CCID, ci.Type, ci.Image, peerConnection)
+}
+
+func (ci *ContainerInstance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
+	return nil, nil
+}
+
+func (ci *ContainerInstance) Stop() error {
+	return ci.KubernetesVM.Stop(ci.CCID)
+}
+
+func (ci *ContainerInstance) Wait() (int, error) {
+	return ci.KubernetesVM.Wait(ci.CCID)
+}
+
+// KubernetesVM runs chaincode in pods of a Kubernetes cluster. The images are
+// built by the ImageBuilder, and are started with the same arguments and
+// environment as the docker containers.
+type KubernetesVM struct {
+	PeerID       string
+	NetworkID    string
+	Client       kubernetesClient
+	ImageBuilder ImageBuilder
+	// ServiceAccount is the service account the chaincode pods run as.
+	ServiceAccount string
+	// ImagePullPolicy is the pull policy of the chaincode images.
+	ImagePullPolicy  string
+	AttachStdOut     bool
+	LoggingEnv       []string
+	MSPID            string
+	PollInterval     time.Duration
+	LogAttachTimeout time.Duration
+}
+
+// HealthCheck checks that the API server is reachable and that none of the
+// chaincode pods of the peer is failing.
+func (vm *KubernetesVM) HealthCheck(ctx context.Context) error {
+	if _, err := vm.Client.Version(ctx); err != nil {
+		return errors.WithMessage(err, "failed to reach the Kubernetes API server")
+	}
+
+	pods, err := vm.Client.ListPods(ctx, PeerLabel+"="+vm.peerLabel())
+	if err != nil {
+		return errors.WithMessage(err, "failed to list chaincode pods")
+	}
+
+	var failing []string
+	for _, pod := range pods {
+		if reason := unhealthyReason(&pod); reason != "" {
+			failing = append(failing, fmt.Sprintf("%s (%s)", pod.Metadata.Name, reason))
+		}
+	}
+	if len(failing) != 0 {
+		sort.Strings(failing)
+		return errors.Errorf("chaincode pods failing: %s", strings.Join(failing, ", "))
+	}
+	return nil
+}
+
+func unhealthyReason(pod *Pod) string {
+	if pod.Status.Phase == PodFailed {
+		if pod.Status.Reason != "" {
+			return pod.Status.Reason
+		}
+		return PodFailed
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if w := cs.State.Waiting; w != nil && waitingReasonsUnhealthy[w.Reason] {
+			return w.Reason
+		}
+	}
+	return ""
+}
+
+// Build is responsible for building the image of the chaincode through the
+// ImageBuilder.
+func (vm *KubernetesVM) Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackage io.Reader) (container.Instance, error) {
+	image, err := vm.ImageBuilder.BuildImage(ccid, metadata, codePackage)
+	if err != nil {
+		return nil, errors.WithMessage(err, "chaincode image build failed")
+	}
+
+	return &ContainerInstance{
+		CCID:         ccid,
+		Type:         strings.ToUpper(metadata.Type),
+		Image:        image,
+		KubernetesVM: vm,
+	}, nil
+}
+
+// Purge removes the image built for the chaincode if the image builder
+// supports it.
+func (vm *KubernetesVM) Purge(ccid string) error {
+	if purger, ok := vm.ImageBuilder.(container.Purger); ok {
+		return purger.Purge(ccid)
+	}
+	return nil
+}
+
+// Start creates the pod running the chaincode, along with the secret holding
+// its TLS credentials and a headless service giving it a stable name.
+func (vm *KubernetesVM) Start(ccid, ccType, image string, peerConnection *ccintf.PeerConnection) error {
+	name := vm.GetPodName(ccid)
+	logger := kubernetesLogger.With("image", image, "pod", name)
+
+	vm.stopInternal(name)
+
+	// the images are the ones the docker platforms build, so they start the same way
+	env := &dockercontroller.DockerVM{LoggingEnv: vm.LoggingEnv, MSPID: vm.MSPID}
+	args, err := env.GetArgs(ccType, peerConnection.Address)
+	if err != nil {
+		return errors.WithMessage(err, "could not get args")
+	}
+	logger.Debugf("start pod with args: %s", strings.Join(args, " "))
+
+	labels := vm.labels(name)
+	pod := &Pod{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata:   ObjectMeta{Name: name, Labels: labels},
+		Spec: PodSpec{
+			RestartPolicy:      "Never",
+			ServiceAccountName: vm.ServiceAccount,
+			Containers: []Container{{
+				Name:            chaincodeContainer,
+				Image:           image,
+				Args:            args,
+				Env:             envVars(env.GetEnv(ccid, peerConnection.TLSConfig)),
+				ImagePullPolicy: vm.ImagePullPolicy,
+			}},
+		},
+	}
+
+	if tlsConfig := peerConnection.TLSConfig; tlsConfig != nil {
+		secretName := name + "-tls"
+		// Note, the key and cert are base64 encoded for the same historical reasons as in docker
+		secret := &Secret{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Metadata:   ObjectMeta{Name: secretName, Labels: labels},
+			Data: map[string][]byte{
+				filepath.Base(dockercontroller.TLSClientKeyPath):      []byte(base64.StdEncoding.EncodeToString(tlsConfig.ClientKey)),
+				filepath.Base(dockercontroller.TLSClientCertPath):     []byte(base64.StdEncoding.EncodeToString(tlsConfig.ClientCert)),
+				filepath.Base(dockercontroller.TLSClientKeyFile):      tlsConfig.ClientKey,
+				filepath.Base(dockercontroller.TLSClientCertFile):     tlsConfig.ClientCert,
+				filepath.Base(dockercontroller.TLSClientRootCertFile): tlsConfig.RootCert,
+			},
+		}
+		if err := vm.Client.CreateSecret(context.Background(), secret); err != nil {
+			logger.Errorf("create secret failed: %s", err)
+			return errors.WithMessagef(err, "failed to create secret %s", secretName)
+		}
+
+		pod.Spec.Volumes = []Volume{{Name: tlsVolume, Secret: &SecretVolume{SecretName: secretName}}}
+		pod.Spec.Containers[0].VolumeMounts = []VolumeMount{{
+			Name:      tlsVolume,
+			MountPath: filepath.Dir(dockercontroller.TLSClientKeyPath),
+			ReadOnly:  true,
+		}}
+	}
+
+	if err := vm.Client.CreatePod(context.Background(), pod); err != nil {
+		logger.Errorf("create pod failed: %s", err)
+		return errors.WithMessagef(err, "failed to create pod %s", name)
+	}
+
+	svc := &Service{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   ObjectMeta{Name: name, Labels: labels},
+		Spec: ServiceSpec{
+			ClusterIP: "None",
+			Selector:  map[string]string{ChaincodeLabel: name},
+		},
+	}
+	if err := vm.Client.CreateService(context.Background(), svc); err != nil {
+		logger.Errorf("create service failed: %s", err)
+		return errors.WithMessagef(err, "failed to create service %s", name)
+	}
+
+	// stream stdout and stderr to chaincode logger
+	if vm.AttachStdOut {
+		podLogger := flogging.MustGetLogger("peer.chaincode." + name)
+		go vm.streamOutput(name, podLogger)
+	}
+
+	logger.Debugf("Started pod %s", name)
+	return nil
+}
+
+// streamOutput mirrors the output of the chaincode container to a fabric
+// logger. The output can only be streamed once the container has started, so
+// the stream is retried until then.
+func (vm *KubernetesVM) streamOutput(name string, podLogger *flogging.FabricLogger) {
+	deadline := time.Now().Add(vm.logAttachTimeout())
+
+	var logs io.ReadCloser
+	for {
+		var err error
+		logs, err = vm.Client.StreamPodLogs(context.Background(), name, chaincodeContainer)
+		if err == nil {
+			break
+		}
+		if IsNotFound(err) || time.Now().After(deadline) {
+			kubernetesLogger.Errorf("Failed to stream the output of pod %s: %s", name, err)
+			return
+		}
+		time.Sleep(vm.pollInterval())
+	}
+	defer logs.Close()
+
+	is := bufio.NewReader(logs)
+	for {
+		// Loop forever dumping lines of text into the podLogger
+		// until the stream is closed
+		line, err := is.ReadString('\n')
+		if len(line) > 0 {
+			podLogger.Info(line)
+		}
+		switch err {
+		case nil:
+		case io.EOF:
+			kubernetesLogger.Infof("Pod %s has closed its output stream", name)
+			return
+		default:
+			kubernetesLogger.Errorf("Error reading pod output: %s", err)
+			return
+		}
+	}
+}
+
+// Stop stops a running chaincode
+func (vm *KubernetesVM) Stop(ccid string) error {
+	return vm.stopInternal(vm.GetPodName(ccid))
+}
+
+func (vm *KubernetesVM) stopInternal(name string) error {
+	logger := kubernetesLogger.With("pod", name)
+	ctx := context.Background()
+
+	logger.Debugw("deleting service")
+	err := vm.Client.DeleteService(ctx, name)
+	logger.Debugw("delete service result", "error", err)
+
+	logger.Debugw("deleting secret")
+	err = vm.Client.DeleteSecret(ctx, name+"-tls")
+	logger.Debugw("delete secret result", "error", err)
+
+	logger.Debugw("deleting pod")
+	err = vm.Client.DeletePod(ctx, name)
+	logger.Debugw("delete pod result", "error", err)
+
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Wait blocks until the chaincode pod terminates and returns the exit code of
+// the chaincode container.
+func (vm *KubernetesVM) Wait(ccid string) (int, error) {
+	name := vm.GetPodName(ccid)
+	for {
+		pod, err := vm.Client.GetPod(context.Background(), name)
+		if err != nil {
+			return 0, errors.WithMessagef(err, "failed to get pod %s", name)
+		}
+
+		if pod.Status.Phase == PodSucceeded || pod.Status.Phase == PodFailed {
+			for _, cs := range pod.Status.ContainerStatuses {
+				if cs.Name == chaincodeContainer && cs.State.Terminated != nil {
+					return cs.State.Terminated.ExitCode, nil
+				}
+			}
+			return 0, errors.Errorf("pod %s terminated without an exit code: %s", name, pod.Status.Reason)
+		}
+
+		time.Sleep(vm.pollInterval())
+	}
+}
+
+// GetPodName returns the name of the pod of the chaincode. Names of Kubernetes
+// objects are limited to 63 lowercase alphanumeric characters or '-', so the
+// name is made of a readable prefix and of a hash that keeps it unique across
+// the networks and peers sharing a namespace.
+func (vm *KubernetesVM) GetPodName(ccid string) string {
+	full := ccid
+	if vm.NetworkID != "" || vm.PeerID != "" {
+		full = fmt.Sprintf("%s-%s-%s", vm.NetworkID, vm.PeerID, ccid)
+	}
+	hash := hex.EncodeToString(util.ComputeSHA256([]byte(full)))[:16]
+
+	label := ccid
+	if i := strings.LastIndex(ccid, ":"); i > 0 {
+		label = ccid[:i]
+	}
+	prefix := nameRegExp.ReplaceAllString(strings.ToLower(label), "-")
+	if len(prefix) > 40 {
+		prefix = prefix[:40]
+	}
+	prefix = strings.Trim(prefix, "-")
+	if prefix == "" {
+		prefix = "cc"
+	}
+
+	return fmt.Sprintf("%s-%s", prefix, hash)
+}
+
+func (vm *KubernetesVM) peerLabel() string {
+	value := labelRegExp.ReplaceAllString(vm.PeerID, "-")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return strings.Trim(value, "-_.")
+}
+
+func (vm *KubernetesVM) labels(name string) map[string]string {
+	return map[string]string{
+		PeerLabel:      vm.peerLabel(),
+		ChaincodeLabel: name,
+	}
+}
+
+func (vm *KubernetesVM) pollInterval() time.Duration {
+	if vm.PollInterval == 0 {
+		return DefaultPollInterval
+	}
+	return vm.PollInterval
+}
+
+func (vm *KubernetesVM) logAttachTimeout() time.Duration {
+	if vm.LogAttachTimeout == 0 {
+		return DefaultLogAttachTimeout
+	}
+	return vm.LogAttachTimeout
+}
+
+func envVars(env []string) []EnvVar {
+	vars := make([]EnvVar, 0, len(env))
+	for _, kv := range env {
+		kv := strings.SplitN(kv, "=", 2)
+		v := EnvVar{Name: kv[0]}
+		if len(kv) == 2 {
+			v.Value = kv[1]
+		}
+		vars = append(vars, v)
+	}
+	return vars
+}
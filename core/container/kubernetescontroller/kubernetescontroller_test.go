@@ -0,0 +1,330 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller/mock"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/stretchr/testify/require"
+)
+
+func newVM(fs *fakeAPIServer) *kubernetescontroller.KubernetesVM {
+	return &kubernetescontroller.KubernetesVM{
+		PeerID:       "peer0.org1.example.com",
+		NetworkID:    "dev",
+		Client:       fs.client("fabric"),
+		ImageBuilder: &mock.ImageBuilder{},
+		LoggingEnv:   []string{"CORE_CHAINCODE_LOGGING_LEVEL=info"},
+		MSPID:        "Org1MSP",
+		PollInterval: 10 * time.Millisecond,
+	}
+}
+
+func TestBuild(t *testing.T) {
+	fakeImageBuilder := &mock.ImageBuilder{}
+	fakeImageBuilder.BuildImageReturns("registry.example.com/mycc:latest", nil)
+	vm := &kubernetescontroller.KubernetesVM{ImageBuilder: fakeImageBuilder}
+
+	metadata := &persistence.ChaincodePackageMetadata{Type: "golang", Path: "github.com/mycc"}
+	instance, err := vm.Build("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+	require.NoError(t, err)
+	require.Equal(t, &kubernetescontroller.ContainerInstance{
+		CCID:         "mycc:1234",
+		Type:         "GOLANG",
+		Image:        "registry.example.com/mycc:latest",
+		KubernetesVM: vm,
+	}, instance)
+
+	require.Equal(t, 1, fakeImageBuilder.BuildImageCallCount())
+	ccid, md, codePackage := fakeImageBuilder.BuildImageArgsForCall(0)
+	require.Equal(t, "mycc:1234", ccid)
+	require.Equal(t, metadata, md)
+	require.Equal(t, bytes.NewBufferString("code-package"), codePackage)
+
+	fakeImageBuilder.BuildImageReturns("", errors.New("boom"))
+	_, err = vm.Build("mycc:1234", metadata, bytes.NewBufferString("code-package"))
+	require.EqualError(t, err, "chaincode image build failed: boom")
+}
+
+func TestPurge(t *testing.T) {
+	vm := &kubernetescontroller.KubernetesVM{ImageBuilder: &mock.ImageBuilder{}}
+	require.NoError(t, vm.Purge("mycc:1234"))
+
+	fakeDockerVM := &mock.DockerVM{}
+	fakeDockerVM.PurgeReturns(errors.New("boom"))
+	vm.ImageBuilder = &kubernetescontroller.DockerImageBuilder{DockerVM: fakeDockerVM}
+	require.EqualError(t, vm.Purge("mycc:1234"), "boom")
+	require.Equal(t, "mycc:1234", fakeDockerVM.PurgeArgsForCall(0))
+}
+
+func TestStart(t *testing.T) {
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	vm := newVM(fs)
+	name := vm.GetPodName("mycc:1234")
+
+	instance := &kubernetescontroller.ContainerInstance{CCID: "mycc:1234", Type: "GOLANG", Image: "mycc-image", KubernetesVM: vm}
+	err := instance.Start(&ccintf.PeerConnection{
+		Address: "peer0:7052",
+		TLSConfig: &ccintf.TLSConfig{
+			ClientCert: []byte("client-cert"),
+			ClientKey:  []byte("client-key"),
+			RootCert:   []byte("root-cert"),
+		},
+	})
+	require.NoError(t, err)
+
+	pod := &kubernetescontroller.Pod{}
+	require.True(t, fs.get("pods", name, pod))
+	labels := map[string]string{
+		kubernetescontroller.PeerLabel:      "peer0.org1.example.com",
+		kubernetescontroller.ChaincodeLabel: name,
+	}
+	require.Equal(t, labels, pod.Metadata.Labels)
+	require.Equal(t, "Never", pod.Spec.RestartPolicy)
+	require.Equal(t, []kubernetescontroller.Volume{{
+		Name:   "tls",
+		Secret: &kubernetescontroller.SecretVolume{SecretName: name + "-tls"},
+	}}, pod.Spec.Volumes)
+	require.Len(t, pod.Spec.Containers, 1)
+	c := pod.Spec.Containers[0]
+	require.Equal(t, "chaincode", c.Name)
+	require.Equal(t, "mycc-image", c.Image)
+	require.Equal(t, []string{"chaincode", "-peer.address=peer0:7052"}, c.Args)
+	require.Contains(t, c.Env, kubernetescontroller.EnvVar{Name: "CORE_CHAINCODE_ID_NAME", Value: "mycc:1234"})
+	require.Contains(t, c.Env, kubernetescontroller.EnvVar{Name: "CORE_PEER_TLS_ENABLED", Value: "true"})
+	require.Contains(t, c.Env, kubernetescontroller.EnvVar{Name: "CORE_PEER_LOCALMSPID", Value: "Org1MSP"})
+	require.Contains(t, c.Env, kubernetescontroller.EnvVar{Name: "CORE_CHAINCODE_LOGGING_LEVEL", Value: "info"})
+	require.Equal(t, []kubernetescontroller.VolumeMount{{Name: "tls", MountPath: "/etc/hyperledger/fabric", ReadOnly: true}}, c.VolumeMounts)
+
+	secret := &kubernetescontroller.Secret{}
+	require.True(t, fs.get("secrets", name+"-tls", secret))
+	require.Equal(t, map[string][]byte{
+		"client.key":     []byte(base64.StdEncoding.EncodeToString([]byte("client-key"))),
+		"client.crt":     []byte(base64.StdEncoding.EncodeToString([]byte("client-cert"))),
+		"client_pem.key": []byte("client-key"),
+		"client_pem.crt": []byte("client-cert"),
+		"peer.crt":       []byte("root-cert"),
+	}, secret.Data)
+
+	svc := &kubernetescontroller.Service{}
+	require.True(t, fs.get("services", name, svc))
+	require.Equal(t, "None", svc.Spec.ClusterIP)
+	require.Equal(t, map[string]string{kubernetescontroller.ChaincodeLabel: name}, svc.Spec.Selector)
+
+	t.Run("restart replaces the pod", func(t *testing.T) {
+		err := instance.Start(&ccintf.PeerConnection{Address: "peer0:7052"})
+		require.NoError(t, err)
+
+		require.False(t, fs.get("secrets", name+"-tls", &kubernetescontroller.Secret{}))
+		pod := &kubernetescontroller.Pod{}
+		require.True(t, fs.get("pods", name, pod))
+		require.Empty(t, pod.Spec.Volumes)
+		require.Contains(t, pod.Spec.Containers[0].Env, kubernetescontroller.EnvVar{Name: "CORE_PEER_TLS_ENABLED", Value: "false"})
+	})
+
+	t.Run("bad chaincode type", func(t *testing.T) {
+		err := vm.Start("mycc:1234", "BADTYPE", "mycc-image", &ccintf.PeerConnection{Address: "peer0:7052"})
+		require.EqualError(t, err, "could not get args: unknown chaincodeType: BADTYPE")
+	})
+
+	t.Run("API server failure", func(t *testing.T) {
+		vm := newVM(fs)
+		vm.Client = fs.client("other")
+		err := vm.Start("mycc:1234", "GOLANG", "mycc-image", &ccintf.PeerConnection{Address: "peer0:7052"})
+		require.EqualError(t, err, "failed to create pod "+name+": API server returned 404")
+	})
+}
+
+func TestStartStreamsOutput(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	buf := gbytes.NewBuffer()
+	defer flogging.Global.SetWriter(flogging.Global.SetWriter(buf))
+
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	vm := newVM(fs)
+	vm.AttachStdOut = true
+	name := vm.GetPodName("mycc:1234")
+
+	err := vm.Start("mycc:1234", "GOLANG", "mycc-image", &ccintf.PeerConnection{Address: "peer0:7052"})
+	require.NoError(t, err)
+
+	// the output can only be streamed once the container has started
+	gt.Consistently(buf, 50*time.Millisecond).ShouldNot(gbytes.Say("message-one"))
+	fs.setLogs(name, "message-one\nmessage-two")
+
+	gt.Eventually(buf).Should(gbytes.Say(`peer.chaincode.` + name + `.*message-one`))
+	gt.Eventually(buf).Should(gbytes.Say(`peer.chaincode.` + name + `.*message-two`))
+	gt.Eventually(buf).Should(gbytes.Say("Pod " + name + " has closed its output stream"))
+}
+
+func TestStop(t *testing.T) {
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	vm := newVM(fs)
+	name := vm.GetPodName("mycc:1234")
+
+	err := vm.Start("mycc:1234", "GOLANG", "mycc-image", &ccintf.PeerConnection{
+		Address:   "peer0:7052",
+		TLSConfig: &ccintf.TLSConfig{},
+	})
+	require.NoError(t, err)
+
+	instance := &kubernetescontroller.ContainerInstance{CCID: "mycc:1234", KubernetesVM: vm}
+	err = instance.Stop()
+	require.NoError(t, err)
+	require.False(t, fs.get("pods", name, &kubernetescontroller.Pod{}))
+	require.False(t, fs.get("services", name, &kubernetescontroller.Service{}))
+	require.False(t, fs.get("secrets", name+"-tls", &kubernetescontroller.Secret{}))
+
+	// stopping a chaincode that is not running is not an error
+	err = instance.Stop()
+	require.NoError(t, err)
+
+	vm.Client = fs.client("other")
+	err = instance.Stop()
+	require.NoError(t, err)
+
+	fs.token = "other"
+	err = instance.Stop()
+	require.EqualError(t, err, "API server returned 401: unauthorized")
+}
+
+func TestWait(t *testing.T) {
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	vm := newVM(fs)
+	name := vm.GetPodName("mycc:1234")
+	instance := &kubernetescontroller.ContainerInstance{CCID: "mycc:1234", KubernetesVM: vm}
+
+	_, err := instance.Wait()
+	require.EqualError(t, err, "failed to get pod "+name+": API server returned 404: notfound")
+
+	pod := &kubernetescontroller.Pod{Metadata: kubernetescontroller.ObjectMeta{Name: name}}
+	pod.Status.Phase = kubernetescontroller.PodRunning
+	fs.put("pods", name, pod)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		pod.Status.Phase = kubernetescontroller.PodFailed
+		pod.Status.ContainerStatuses = []kubernetescontroller.ContainerStatus{{
+			Name:  "chaincode",
+			State: kubernetescontroller.ContainerState{Terminated: &kubernetescontroller.ContainerStateTerminated{ExitCode: 2}},
+		}}
+		fs.put("pods", name, pod)
+	}()
+
+	exitCode, err := instance.Wait()
+	require.NoError(t, err)
+	require.Equal(t, 2, exitCode)
+
+	pod.Status.ContainerStatuses = nil
+	pod.Status.Reason = "Evicted"
+	fs.put("pods", name, pod)
+	_, err = instance.Wait()
+	require.EqualError(t, err, "pod "+name+" terminated without an exit code: Evicted")
+}
+
+func TestHealthCheck(t *testing.T) {
+	fs := newFakeAPIServer("fabric", "token")
+	defer fs.Close()
+	vm := newVM(fs)
+	ctx := context.Background()
+
+	err := vm.HealthCheck(ctx)
+	require.NoError(t, err)
+
+	running := &kubernetescontroller.Pod{Metadata: kubernetescontroller.ObjectMeta{
+		Name:   "running",
+		Labels: map[string]string{kubernetescontroller.PeerLabel: "peer0.org1.example.com"},
+	}}
+	running.Status.Phase = kubernetescontroller.PodRunning
+	fs.put("pods", "running", running)
+
+	otherPeer := &kubernetescontroller.Pod{Metadata: kubernetescontroller.ObjectMeta{
+		Name:   "other-peer",
+		Labels: map[string]string{kubernetescontroller.PeerLabel: "peer1.org1.example.com"},
+	}}
+	otherPeer.Status.Phase = kubernetescontroller.PodFailed
+	fs.put("pods", "other-peer", otherPeer)
+
+	err = vm.HealthCheck(ctx)
+	require.NoError(t, err)
+
+	crashing := &kubernetescontroller.Pod{Metadata: kubernetescontroller.ObjectMeta{
+		Name:   "crashing",
+		Labels: map[string]string{kubernetescontroller.PeerLabel: "peer0.org1.example.com"},
+	}}
+	crashing.Status.Phase = kubernetescontroller.PodPending
+	crashing.Status.ContainerStatuses = []kubernetescontroller.ContainerStatus{{
+		Name:  "chaincode",
+		State: kubernetescontroller.ContainerState{Waiting: &kubernetescontroller.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+	}}
+	fs.put("pods", "crashing", crashing)
+
+	failed := &kubernetescontroller.Pod{Metadata: kubernetescontroller.ObjectMeta{
+		Name:   "failed",
+		Labels: map[string]string{kubernetescontroller.PeerLabel: "peer0.org1.example.com"},
+	}}
+	failed.Status.Phase = kubernetescontroller.PodFailed
+	fs.put("pods", "failed", failed)
+
+	err = vm.HealthCheck(ctx)
+	require.EqualError(t, err, "chaincode pods failing: crashing (ImagePullBackOff), failed (Failed)")
+
+	vm.Client = fs.client("other")
+	err = vm.HealthCheck(ctx)
+	require.EqualError(t, err, "failed to list chaincode pods: API server returned 404")
+
+	fs.Close()
+	err = vm.HealthCheck(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to reach the Kubernetes API server")
+}
+
+func TestGetPodName(t *testing.T) {
+	tests := []struct {
+		name      string
+		networkID string
+		peerID    string
+		ccid      string
+		prefix    string
+	}{
+		{name: "label and hash", ccid: "mycc:1234", prefix: "mycc-"},
+		{name: "network and peer", networkID: "dev", peerID: "peer0", ccid: "mycc:1234", prefix: "mycc-"},
+		{name: "invalid characters", ccid: "My_CC.v1:1234", prefix: "my-cc-v1-"},
+		{name: "long label", ccid: "a-very-long-chaincode-label-that-goes-on-and-on:1234", prefix: "a-very-long-chaincode-label-that-goes-on-"},
+		{name: "no label", ccid: "___:1234", prefix: "cc-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := &kubernetescontroller.KubernetesVM{NetworkID: tt.networkID, PeerID: tt.peerID}
+			name := vm.GetPodName(tt.ccid)
+			require.Regexp(t, "^[a-z0-9]([a-z0-9-]*[a-z0-9])?$", name)
+			require.True(t, len(name) <= 63, "name %s is too long", name)
+			require.Equal(t, tt.prefix, name[:len(tt.prefix)])
+		})
+	}
+
+	vm := &kubernetescontroller.KubernetesVM{NetworkID: "dev", PeerID: "peer0"}
+	require.NotEqual(t, vm.GetPodName("mycc:1234"), (&kubernetescontroller.KubernetesVM{NetworkID: "dev", PeerID: "peer1"}).GetPodName("mycc:1234"))
+	require.NotEqual(t, vm.GetPodName("mycc:1234"), vm.GetPodName("mycc:5678"))
+}
@@ -0,0 +1,270 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+)
+
+type DockerVM struct {
+	BuildStub        func(string, *persistence.ChaincodePackageMetadata, io.Reader) (container.Instance, error)
+	buildMutex       sync.RWMutex
+	buildArgsForCall []struct {
+		arg1 string
+		arg2 *persistence.ChaincodePackageMetadata
+		arg3 io.Reader
+	}
+	buildReturns struct {
+		result1 container.Instance
+		result2 error
+	}
+	buildReturnsOnCall map[int]struct {
+		result1 container.Instance
+		result2 error
+	}
+	GetVMNameForDockerStub        func(string) (string, error)
+	getVMNameForDockerMutex       sync.RWMutex
+	getVMNameForDockerArgsForCall []struct {
+		arg1 string
+	}
+	getVMNameForDockerReturns struct {
+		result1 string
+		result2 error
+	}
+	getVMNameForDockerReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PurgeStub        func(string) error
+	purgeMutex       sync.RWMutex
+	purgeArgsForCall []struct {
+		arg1 string
+	}
+	purgeReturns struct {
+		result1 error
+	}
+	purgeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DockerVM) Build(arg1 string, arg2 *persistence.ChaincodePackageMetadata, arg3 io.Reader) (container.Instance, error) {
+	fake.buildMutex.Lock()
+	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
+	fake.buildArgsForCall = append(fake.buildArgsForCall, struct {
+		arg1 string
+		arg2 *persistence.ChaincodePackageMetadata
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Build", []interface{}{arg1, arg2, arg3})
+	fake.buildMutex.Unlock()
+	if fake.BuildStub != nil {
+		return fake.BuildStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DockerVM) BuildCallCount() int {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	return len(fake.buildArgsForCall)
+}
+
+func (fake *DockerVM) BuildCalls(stub func(string, *persistence.ChaincodePackageMetadata, io.Reader) (container.Instance, error)) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = stub
+}
+
+func (fake *DockerVM) BuildArgsForCall(i int) (string, *persistence.ChaincodePackageMetadata, io.Reader) {
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	argsForCall := fake.buildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *DockerVM) BuildReturns(result1 container.Instance, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	fake.buildReturns = struct {
+		result1 container.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DockerVM) BuildReturnsOnCall(i int, result1 container.Instance, result2 error) {
+	fake.buildMutex.Lock()
+	defer fake.buildMutex.Unlock()
+	fake.BuildStub = nil
+	if fake.buildReturnsOnCall == nil {
+		fake.buildReturnsOnCall = make(map[int]struct {
+			result1 container.Instance
+			result2 error
+		})
+	}
+	fake.buildReturnsOnCall[i] = struct {
+		result1 container.Instance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DockerVM) GetVMNameForDocker(arg1 string) (string, error) {
+	fake.getVMNameForDockerMutex.Lock()
+	ret, specificReturn := fake.getVMNameForDockerReturnsOnCall[len(fake.getVMNameForDockerArgsForCall)]
+	fake.getVMNameForDockerArgsForCall = append(fake.getVMNameForDockerArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetVMNameForDocker", []interface{}{arg1})
+	fake.getVMNameForDockerMutex.Unlock()
+	if fake.GetVMNameForDockerStub != nil {
+		return fake.GetVMNameForDockerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getVMNameForDockerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DockerVM) GetVMNameForDockerCallCount() int {
+	fake.getVMNameForDockerMutex.RLock()
+	defer fake.getVMNameForDockerMutex.RUnlock()
+	return len(fake.getVMNameForDockerArgsForCall)
+}
+
+func (fake *DockerVM) GetVMNameForDockerCalls(stub func(string) (string, error)) {
+	fake.getVMNameForDockerMutex.Lock()
+	defer fake.getVMNameForDockerMutex.Unlock()
+	fake.GetVMNameForDockerStub = stub
+}
+
+func (fake *DockerVM) GetVMNameForDockerArgsForCall(i int) string {
+	fake.getVMNameForDockerMutex.RLock()
+	defer fake.getVMNameForDockerMutex.RUnlock()
+	argsForCall := fake.getVMNameForDockerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DockerVM) GetVMNameForDockerReturns(result1 string, result2 error) {
+	fake.getVMNameForDockerMutex.Lock()
+	defer fake.getVMNameForDockerMutex.Unlock()
+	fake.GetVMNameForDockerStub = nil
+	fake.getVMNameForDockerReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DockerVM) GetVMNameForDockerReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getVMNameForDockerMutex.Lock()
+	defer fake.getVMNameForDockerMutex.Unlock()
+	fake.GetVMNameForDockerStub = nil
+	if fake.getVMNameForDockerReturnsOnCall == nil {
+		fake.getVMNameForDockerReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getVMNameForDockerReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DockerVM) Purge(arg1 string) error {
+	fake.purgeMutex.Lock()
+	ret, specificReturn := fake.purgeReturnsOnCall[len(fake.purgeArgsForCall)]
+	fake.purgeArgsForCall = append(fake.purgeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Purge", []interface{}{arg1})
+	fake.purgeMutex.Unlock()
+	if fake.PurgeStub != nil {
+		return fake.PurgeStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgeReturns
+	return fakeReturns.result1
+}
+
+func (fake *DockerVM) PurgeCallCount() int {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	return len(fake.purgeArgsForCall)
+}
+
+func (fake *DockerVM) PurgeCalls(stub func(string) error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = stub
+}
+
+func (fake *DockerVM) PurgeArgsForCall(i int) string {
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	argsForCall := fake.purgeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DockerVM) PurgeReturns(result1 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	fake.purgeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DockerVM) PurgeReturnsOnCall(i int, result1 error) {
+	fake.purgeMutex.Lock()
+	defer fake.purgeMutex.Unlock()
+	fake.PurgeStub = nil
+	if fake.purgeReturnsOnCall == nil {
+		fake.purgeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DockerVM) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildMutex.RLock()
+	defer fake.buildMutex.RUnlock()
+	fake.getVMNameForDockerMutex.RLock()
+	defer fake.getVMNameForDockerMutex.RUnlock()
+	fake.purgeMutex.RLock()
+	defer fake.purgeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DockerVM) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
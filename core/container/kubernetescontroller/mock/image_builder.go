@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
+)
+
+type ImageBuilder struct {
+	BuildImageStub        func(string, *persistence.ChaincodePackageMetadata, io.Reader) (string, error)
+	buildImageMutex       sync.RWMutex
+	buildImageArgsForCall []struct {
+		arg1 string
+		arg2 *persistence.ChaincodePackageMetadata
+		arg3 io.Reader
+	}
+	buildImageReturns struct {
+		result1 string
+		result2 error
+	}
+	buildImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ImageBuilder) BuildImage(arg1 string, arg2 *persistence.ChaincodePackageMetadata, arg3 io.Reader) (string, error) {
+	fake.buildImageMutex.Lock()
+	ret, specificReturn := fake.buildImageReturnsOnCall[len(fake.buildImageArgsForCall)]
+	fake.buildImageArgsForCall = append(fake.buildImageArgsForCall, struct {
+		arg1 string
+		arg2 *persistence.ChaincodePackageMetadata
+		arg3 io.Reader
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("BuildImage", []interface{}{arg1, arg2, arg3})
+	fake.buildImageMutex.Unlock()
+	if fake.BuildImageStub != nil {
+		return fake.BuildImageStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildImageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageBuilder) BuildImageCallCount() int {
+	fake.buildImageMutex.RLock()
+	defer fake.buildImageMutex.RUnlock()
+	return len(fake.buildImageArgsForCall)
+}
+
+func (fake *ImageBuilder) BuildImageCalls(stub func(string, *persistence.ChaincodePackageMetadata, io.Reader) (string, error)) {
+	fake.buildImageMutex.Lock()
+	defer fake.buildImageMutex.Unlock()
+	fake.BuildImageStub = stub
+}
+
+func (fake *ImageBuilder) BuildImageArgsForCall(i int) (string, *persistence.ChaincodePackageMetadata, io.Reader) {
+	fake.buildImageMutex.RLock()
+	defer fake.buildImageMutex.RUnlock()
+	argsForCall := fake.buildImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImageBuilder) BuildImageReturns(result1 string, result2 error) {
+	fake.buildImageMutex.Lock()
+	defer fake.buildImageMutex.Unlock()
+	fake.BuildImageStub = nil
+	fake.buildImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageBuilder) BuildImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.buildImageMutex.Lock()
+	defer fake.buildImageMutex.Unlock()
+	fake.BuildImageStub = nil
+	if fake.buildImageReturnsOnCall == nil {
+		fake.buildImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.buildImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageBuilder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildImageMutex.RLock()
+	defer fake.buildImageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ImageBuilder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ kubernetescontroller.ImageBuilder = new(ImageBuilder)
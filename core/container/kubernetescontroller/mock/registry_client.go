@@ -0,0 +1,185 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+type RegistryClient struct {
+	PushImageStub        func(docker.PushImageOptions, docker.AuthConfiguration) error
+	pushImageMutex       sync.RWMutex
+	pushImageArgsForCall []struct {
+		arg1 docker.PushImageOptions
+		arg2 docker.AuthConfiguration
+	}
+	pushImageReturns struct {
+		result1 error
+	}
+	pushImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TagImageStub        func(string, docker.TagImageOptions) error
+	tagImageMutex       sync.RWMutex
+	tagImageArgsForCall []struct {
+		arg1 string
+		arg2 docker.TagImageOptions
+	}
+	tagImageReturns struct {
+		result1 error
+	}
+	tagImageReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RegistryClient) PushImage(arg1 docker.PushImageOptions, arg2 docker.AuthConfiguration) error {
+	fake.pushImageMutex.Lock()
+	ret, specificReturn := fake.pushImageReturnsOnCall[len(fake.pushImageArgsForCall)]
+	fake.pushImageArgsForCall = append(fake.pushImageArgsForCall, struct {
+		arg1 docker.PushImageOptions
+		arg2 docker.AuthConfiguration
+	}{arg1, arg2})
+	fake.recordInvocation("PushImage", []interface{}{arg1, arg2})
+	fake.pushImageMutex.Unlock()
+	if fake.PushImageStub != nil {
+		return fake.PushImageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pushImageReturns
+	return fakeReturns.result1
+}
+
+func (fake *RegistryClient) PushImageCallCount() int {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	return len(fake.pushImageArgsForCall)
+}
+
+func (fake *RegistryClient) PushImageCalls(stub func(docker.PushImageOptions, docker.AuthConfiguration) error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = stub
+}
+
+func (fake *RegistryClient) PushImageArgsForCall(i int) (docker.PushImageOptions, docker.AuthConfiguration) {
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	argsForCall := fake.pushImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RegistryClient) PushImageReturns(result1 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	fake.pushImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) PushImageReturnsOnCall(i int, result1 error) {
+	fake.pushImageMutex.Lock()
+	defer fake.pushImageMutex.Unlock()
+	fake.PushImageStub = nil
+	if fake.pushImageReturnsOnCall == nil {
+		fake.pushImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) TagImage(arg1 string, arg2 docker.TagImageOptions) error {
+	fake.tagImageMutex.Lock()
+	ret, specificReturn := fake.tagImageReturnsOnCall[len(fake.tagImageArgsForCall)]
+	fake.tagImageArgsForCall = append(fake.tagImageArgsForCall, struct {
+		arg1 string
+		arg2 docker.TagImageOptions
+	}{arg1, arg2})
+	fake.recordInvocation("TagImage", []interface{}{arg1, arg2})
+	fake.tagImageMutex.Unlock()
+	if fake.TagImageStub != nil {
+		return fake.TagImageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.tagImageReturns
+	return fakeReturns.result1
+}
+
+func (fake *RegistryClient) TagImageCallCount() int {
+	fake.tagImageMutex.RLock()
+	defer fake.tagImageMutex.RUnlock()
+	return len(fake.tagImageArgsForCall)
+}
+
+func (fake *RegistryClient) TagImageCalls(stub func(string, docker.TagImageOptions) error) {
+	fake.tagImageMutex.Lock()
+	defer fake.tagImageMutex.Unlock()
+	fake.TagImageStub = stub
+}
+
+func (fake *RegistryClient) TagImageArgsForCall(i int) (string, docker.TagImageOptions) {
+	fake.tagImageMutex.RLock()
+	defer fake.tagImageMutex.RUnlock()
+	argsForCall := fake.tagImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *RegistryClient) TagImageReturns(result1 error) {
+	fake.tagImageMutex.Lock()
+	defer fake.tagImageMutex.Unlock()
+	fake.TagImageStub = nil
+	fake.tagImageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) TagImageReturnsOnCall(i int, result1 error) {
+	fake.tagImageMutex.Lock()
+	defer fake.tagImageMutex.Unlock()
+	fake.TagImageStub = nil
+	if fake.tagImageReturnsOnCall == nil {
+		fake.tagImageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.tagImageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RegistryClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.pushImageMutex.RLock()
+	defer fake.pushImageMutex.RUnlock()
+	fake.tagImageMutex.RLock()
+	defer fake.tagImageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RegistryClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubernetescontroller
+
+// The types below mirror the subset of the Kubernetes core/v1 API used by the
+// chaincode runtime. Fields that are not needed are left out, and are ignored
+// when decoding the objects returned by the API server.
+
+// ObjectMeta is the metadata common to all the objects.
+type ObjectMeta struct {
+	Name      string            `json:"name,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// Pod is a group of containers scheduled together.
+type Pod struct {
+	APIVersion string     `json:"apiVersion,omitempty"`
+	Kind       string     `json:"kind,omitempty"`
+	Metadata   ObjectMeta `json:"metadata"`
+	Spec       PodSpec    `json:"spec"`
+	Status     PodStatus  `json:"status"`
+}
+
+// PodList is the result of listing pods.
+type PodList struct {
+	Items []Pod `json:"items"`
+}
+
+// PodSpec describes the containers of a pod.
+type PodSpec struct {
+	Containers         []Container `json:"containers"`
+	Volumes            []Volume    `json:"volumes,omitempty"`
+	RestartPolicy      string      `json:"restartPolicy,omitempty"`
+	ServiceAccountName string      `json:"serviceAccountName,omitempty"`
+}
+
+// Container describes a container of a pod.
+type Container struct {
+	Name            string        `json:"name"`
+	Image           string        `json:"image"`
+	Args            []string      `json:"args,omitempty"`
+	Env             []EnvVar      `json:"env,omitempty"`
+	VolumeMounts    []VolumeMount `json:"volumeMounts,omitempty"`
+	ImagePullPolicy string        `json:"imagePullPolicy,omitempty"`
+}
+
+// EnvVar is an environment variable of a container.
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Volume is a volume of a pod.
+type Volume struct {
+	Name   string        `json:"name"`
+	Secret *SecretVolume `json:"secret,omitempty"`
+}
+
+// SecretVolume is a volume populated with the content of a secret.
+type SecretVolume struct {
+	SecretName string `json:"secretName"`
+}
+
+// VolumeMount mounts a volume of the pod in a container.
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// PodStatus is the observed state of a pod.
+type PodStatus struct {
+	Phase             string            `json:"phase,omitempty"`
+	Reason            string            `json:"reason,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty"`
+}
+
+// Pod phases.
+const (
+	PodPending   = "Pending"
+	PodRunning   = "Running"
+	PodSucceeded = "Succeeded"
+	PodFailed    = "Failed"
+)
+
+// ContainerStatus is the observed state of a container.
+type ContainerStatus struct {
+	Name  string         `json:"name"`
+	State ContainerState `json:"state"`
+}
+
+// ContainerState holds the details of the state the container is in. Only
+// one of its fields is set.
+type ContainerState struct {
+	Waiting    *ContainerStateWaiting    `json:"waiting,omitempty"`
+	Running    *ContainerStateRunning    `json:"running,omitempty"`
+	Terminated *ContainerStateTerminated `json:"terminated,omitempty"`
+}
+
+// ContainerStateWaiting is the state of a container that is not running yet.
+type ContainerStateWaiting struct {
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerStateRunning is the state of a running container.
+type ContainerStateRunning struct {
+	StartedAt string `json:"startedAt,omitempty"`
+}
+
+// ContainerStateTerminated is the state of a container that exited.
+type ContainerStateTerminated struct {
+	ExitCode int    `json:"exitCode"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Service exposes a set of pods under a stable name.
+type Service struct {
+	APIVersion string      `json:"apiVersion,omitempty"`
+	Kind       string      `json:"kind,omitempty"`
+	Metadata   ObjectMeta  `json:"metadata"`
+	Spec       ServiceSpec `json:"spec"`
+}
+
+// ServiceSpec describes the pods a service selects.
+type ServiceSpec struct {
+	Selector  map[string]string `json:"selector,omitempty"`
+	ClusterIP string            `json:"clusterIP,omitempty"`
+}
+
+// Secret holds sensitive data mounted in the pods.
+type Secret struct {
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Metadata   ObjectMeta        `json:"metadata"`
+	Data       map[string][]byte `json:"data,omitempty"`
+}
+
+// Status is returned by the API server along with error codes.
+type Status struct {
+	Message string `json:"message,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// VersionInfo is the version of the API server.
+type VersionInfo struct {
+	Major      string `json:"major"`
+	Minor      string `json:"minor"`
+	GitVersion string `json:"gitVersion"`
+}
@@ -23,6 +23,7 @@ const (
 	DefaultReConnectBackoffThreshold   = time.Hour * 1
 	DefaultReConnectTotalTimeThreshold = time.Second * 60 * 60
 	DefaultConnectionTimeout           = time.Second * 3
+	DefaultBlockCensorshipTimeout      = time.Second * 30
 )
 
 // DeliverServiceConfig is the struct that defines the deliverservice configuration.
@@ -36,6 +37,10 @@ type DeliverServiceConfig struct {
 	ReconnectTotalTimeThreshold time.Duration
 	// ConnectionTimeout sets the delivery service <-> ordering service node connection timeout
 	ConnectionTimeout time.Duration
+	// BlockCensorshipTimeout sets the time the delivery service waits for a block that other
+	// ordering service nodes report as committed, before it suspects the node it receives
+	// blocks from of censorship and switches to another one. It applies only to BFT channels.
+	BlockCensorshipTimeout time.Duration
 	// Keepalive option for deliveryservice
 	KeepaliveOptions comm.KeepaliveOptions
 	// SecOpts provides the TLS info for connections
@@ -112,6 +117,11 @@ func (c *DeliverServiceConfig) loadDeliverServiceConfig() {
 		c.ConnectionTimeout = DefaultConnectionTimeout
 	}
 
+	c.BlockCensorshipTimeout = viper.GetDuration("peer.deliveryclient.blockCensorshipTimeout")
+	if c.BlockCensorshipTimeout == 0 {
+		c.BlockCensorshipTimeout = DefaultBlockCensorshipTimeout
+	}
+
 	c.KeepaliveOptions = comm.DefaultKeepaliveOptions
 	if viper.IsSet("peer.keepalive.deliveryClient.interval") {
 		c.KeepaliveOptions.ClientInterval = viper.GetDuration("peer.keepalive.deliveryClient.interval")
@@ -86,6 +86,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.deliveryclient.reConnectBackoffThreshold", "25s")
 	viper.Set("peer.deliveryclient.reconnectTotalTimeThreshold", "20s")
 	viper.Set("peer.deliveryclient.connTimeout", "10s")
+	viper.Set("peer.deliveryclient.blockCensorshipTimeout", "40s")
 	viper.Set("peer.keepalive.deliveryClient.interval", "5s")
 	viper.Set("peer.keepalive.deliveryClient.timeout", "2s")
 
@@ -96,6 +97,7 @@ func TestGlobalConfig(t *testing.T) {
 		ReConnectBackoffThreshold:   25 * time.Second,
 		ReconnectTotalTimeThreshold: 20 * time.Second,
 		ConnectionTimeout:           10 * time.Second,
+		BlockCensorshipTimeout:      40 * time.Second,
 		KeepaliveOptions: comm.KeepaliveOptions{
 			ClientInterval:    time.Second * 5,
 			ClientTimeout:     time.Second * 2,
@@ -122,6 +124,7 @@ func TestGlobalConfigDefault(t *testing.T) {
 		ReConnectBackoffThreshold:   deliverservice.DefaultReConnectBackoffThreshold,
 		ReconnectTotalTimeThreshold: deliverservice.DefaultReConnectTotalTimeThreshold,
 		ConnectionTimeout:           deliverservice.DefaultConnectionTimeout,
+		BlockCensorshipTimeout:      deliverservice.DefaultBlockCensorshipTimeout,
 		KeepaliveOptions:            comm.DefaultKeepaliveOptions,
 	}
 
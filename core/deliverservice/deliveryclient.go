@@ -58,6 +58,9 @@ type deliverServiceImpl struct {
 // and how it disseminates the messages to other peers
 type Config struct {
 	IsStaticLeader bool
+	// IsBFT is true if the channel is ordered by a BFT ordering service, whose nodes are
+	// watched for block censorship.
+	IsBFT bool
 	// CryptoSvc performs cryptographic actions like message verification and signing
 	// and identity validation.
 	CryptoSvc blocksprovider.BlockVerifier
@@ -139,6 +142,10 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		YieldLeadership:   !d.conf.IsStaticLeader,
 	}
 
+	if d.conf.IsBFT {
+		dc.BlockCensorshipTimeout = d.conf.DeliverServiceConfig.BlockCensorshipTimeout
+	}
+
 	if d.conf.DeliverGRPCClient.MutualTLSRequired() {
 		dc.TLSCertHash = util.ComputeSHA256(d.conf.DeliverGRPCClient.Certificate().Certificate[0])
 	}
@@ -113,6 +113,25 @@ eUCutqn1KYDMYh54i6p723cXbdDkmvL2UCciHyHdSWS9lmkKVdyNGIJ6
 		require.Nil(t, bp.TLSCertHash)
 	})
 
+	t.Run("BFT channel", func(t *testing.T) {
+		ds := NewDeliverService(&Config{
+			IsBFT:                true,
+			DeliverGRPCClient:    grpcClient,
+			DeliverServiceConfig: &DeliverServiceConfig{BlockCensorshipTimeout: time.Minute},
+		}).(*deliverServiceImpl)
+
+		finalized := make(chan struct{})
+		err := ds.StartDeliverForChannel("channel-id", fakeLedgerInfo, func() {
+			close(finalized)
+		})
+		require.NoError(t, err)
+		<-finalized
+
+		bp, ok := ds.blockProviders["channel-id"]
+		require.True(t, ok, "map entry must exist")
+		require.Equal(t, time.Minute, bp.BlockCensorshipTimeout)
+	})
+
 	t.Run("Exists", func(t *testing.T) {
 		ds := NewDeliverService(&Config{
 			DeliverGRPCClient:    grpcClient,
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/endorser/endorserpb"
+	"github.com/pkg/errors"
+)
+
+// DefaultBatchParallelism is the number of proposals of a ProcessProposals
+// stream that are processed concurrently when the endorser does not set it.
+const DefaultBatchParallelism = 16
+
+//go:generate counterfeiter -o fake/proposal_processor.go --fake-name ProposalProcessor . ProposalProcessor
+
+// ProposalProcessor processes a single signed proposal.
+type ProposalProcessor interface {
+	ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+}
+
+// ProcessProposals implements the BatchEndorser service. It processes the
+// proposals received on the stream concurrently, at most BatchParallelism at a
+// time, and sends back the response to each of them as soon as it is available. A proposal that fails
+// does not affect the others; its error is reported in its response. The
+// stream ends once the client has closed its side and every proposal has been
+// answered.
+func (e *Endorser) ProcessProposals(stream endorserpb.BatchEndorser_ProcessProposalsServer) error {
+	startTime := time.Now()
+	e.Metrics.ProposalBatchesReceived.Add(1)
+
+	ctx := stream.Context()
+	endorserLogger.Debug("proposal batch from", util.ExtractRemoteAddress(ctx))
+
+	var processor ProposalProcessor = e
+	if e.ProposalProcessor != nil {
+		processor = e.ProposalProcessor
+	}
+	parallelism := e.BatchParallelism
+	if parallelism <= 0 {
+		parallelism = DefaultBatchParallelism
+	}
+	sema := semaphore.New(parallelism)
+
+	var (
+		wg        sync.WaitGroup
+		sendMutex sync.Mutex
+		sendErr   error
+	)
+	send := func(resp *endorserpb.BatchedProposalResponse) {
+		sendMutex.Lock()
+		defer sendMutex.Unlock()
+		if sendErr != nil {
+			return
+		}
+		if err := stream.Send(resp); err != nil {
+			sendErr = errors.Wrapf(err, "failed to send the response to proposal %d", resp.Index)
+		}
+	}
+
+	var recvErr error
+	for index := uint64(0); ; index++ {
+		signedProp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			recvErr = errors.Wrap(err, "failed to receive proposal")
+			break
+		}
+		if err := sema.Acquire(ctx); err != nil {
+			recvErr = errors.Wrapf(err, "aborted before processing proposal %d", index)
+			break
+		}
+
+		e.Metrics.BatchedProposalsReceived.Add(1)
+		wg.Add(1)
+		go func(index uint64, signedProp *pb.SignedProposal) {
+			defer wg.Done()
+			defer sema.Release()
+			send(e.processBatchedProposal(ctx, processor, index, signedProp))
+		}(index, signedProp)
+	}
+	wg.Wait()
+
+	success := recvErr == nil && sendErr == nil
+	e.Metrics.ProposalBatchDuration.With("success", strconv.FormatBool(success)).Observe(time.Since(startTime).Seconds())
+
+	if recvErr != nil {
+		return recvErr
+	}
+	return sendErr
+}
+
+func (e *Endorser) processBatchedProposal(ctx context.Context, processor ProposalProcessor, index uint64, signedProp *pb.SignedProposal) *endorserpb.BatchedProposalResponse {
+	if e.ProposalLimiter != nil {
+		if !e.ProposalLimiter.TryAcquire() {
+			endorserLogger.Errorf("Too many requests for proposal %d of batch, exceeding concurrency limit (%d)", index, cap(e.ProposalLimiter))
+			e.Metrics.BatchedProposalsFailed.Add(1)
+			return &endorserpb.BatchedProposalResponse{
+				Index: index,
+				Error: errors.Errorf("too many requests, exceeding concurrency limit (%d)", cap(e.ProposalLimiter)).Error(),
+			}
+		}
+		defer e.ProposalLimiter.Release()
+	}
+
+	var conflictWarning string
+	pResp, err := processor.ProcessProposal(withConflictWarning(ctx, &conflictWarning), signedProp)
+	if err != nil {
+		endorserLogger.Debugf("proposal %d of batch failed: %s", index, err)
+		e.Metrics.BatchedProposalsFailed.Add(1)
+		return &endorserpb.BatchedProposalResponse{Index: index, Error: err.Error()}
+	}
+	if pResp.GetResponse().GetStatus() >= shim.ERRORTHRESHOLD {
+		e.Metrics.BatchedProposalsFailed.Add(1)
+	}
+	return &endorserpb.BatchedProposalResponse{Index: index, ProposalResponse: pResp, ConflictWarning: conflictWarning}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/endorserpb"
+	"github.com/hyperledger/fabric/core/endorser/fake"
+)
+
+var _ = Describe("ProcessProposals", func() {
+	var (
+		fakeProposalBatchesReceived  *metricsfakes.Counter
+		fakeBatchedProposalsReceived *metricsfakes.Counter
+		fakeBatchedProposalsFailed   *metricsfakes.Counter
+		fakeProposalBatchDuration    *metricsfakes.Histogram
+
+		fakeProposalProcessor *fake.ProposalProcessor
+		fakeStream            *fake.ProcessProposalsServer
+		proposals             []*pb.SignedProposal
+
+		e *endorser.Endorser
+	)
+
+	BeforeEach(func() {
+		fakeProposalBatchesReceived = &metricsfakes.Counter{}
+		fakeBatchedProposalsReceived = &metricsfakes.Counter{}
+		fakeBatchedProposalsFailed = &metricsfakes.Counter{}
+		fakeProposalBatchDuration = &metricsfakes.Histogram{}
+		fakeProposalBatchDuration.WithReturns(fakeProposalBatchDuration)
+
+		fakeProposalProcessor = &fake.ProposalProcessor{}
+		fakeProposalProcessor.ProcessProposalStub = func(_ context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+			return &pb.ProposalResponse{
+				Response: &pb.Response{Status: 200, Message: string(signedProp.ProposalBytes)},
+			}, nil
+		}
+
+		proposals = []*pb.SignedProposal{
+			{ProposalBytes: []byte("proposal-0")},
+			{ProposalBytes: []byte("proposal-1")},
+			{ProposalBytes: []byte("proposal-2")},
+		}
+
+		fakeStream = &fake.ProcessProposalsServer{}
+		fakeStream.ContextReturns(context.Background())
+		var recvMutex sync.Mutex
+		fakeStream.RecvStub = func() (*pb.SignedProposal, error) {
+			recvMutex.Lock()
+			defer recvMutex.Unlock()
+			if len(proposals) == 0 {
+				return nil, io.EOF
+			}
+			signedProp := proposals[0]
+			proposals = proposals[1:]
+			return signedProp, nil
+		}
+
+		e = &endorser.Endorser{
+			Metrics: &endorser.Metrics{
+				ProposalBatchesReceived:  fakeProposalBatchesReceived,
+				BatchedProposalsReceived: fakeBatchedProposalsReceived,
+				BatchedProposalsFailed:   fakeBatchedProposalsFailed,
+				ProposalBatchDuration:    fakeProposalBatchDuration,
+			},
+			ProposalProcessor: fakeProposalProcessor,
+		}
+	})
+
+	sentResponses := func() map[uint64]*endorserpb.BatchedProposalResponse {
+		responses := map[uint64]*endorserpb.BatchedProposalResponse{}
+		for i := 0; i < fakeStream.SendCallCount(); i++ {
+			resp := fakeStream.SendArgsForCall(i)
+			responses[resp.Index] = resp
+		}
+		return responses
+	}
+
+	It("sends back a response for every proposal", func() {
+		err := e.ProcessProposals(fakeStream)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(3))
+		Expect(fakeStream.SendCallCount()).To(Equal(3))
+		responses := sentResponses()
+		for i, message := range []string{"proposal-0", "proposal-1", "proposal-2"} {
+			Expect(responses).To(HaveKey(uint64(i)))
+			Expect(responses[uint64(i)].Error).To(BeEmpty())
+			Expect(responses[uint64(i)].ProposalResponse.Response.Message).To(Equal(message))
+		}
+	})
+
+	It("records the batch metrics", func() {
+		err := e.ProcessProposals(fakeStream)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeProposalBatchesReceived.AddCallCount()).To(Equal(1))
+		Expect(fakeBatchedProposalsReceived.AddCallCount()).To(Equal(3))
+		Expect(fakeBatchedProposalsFailed.AddCallCount()).To(Equal(0))
+		Expect(fakeProposalBatchDuration.WithCallCount()).To(Equal(1))
+		Expect(fakeProposalBatchDuration.WithArgsForCall(0)).To(Equal([]string{"success", "true"}))
+		Expect(fakeProposalBatchDuration.ObserveCallCount()).To(Equal(1))
+	})
+
+	It("does not process more proposals concurrently than allowed", func() {
+		e.BatchParallelism = 2
+		release := make(chan struct{})
+		fakeProposalProcessor.ProcessProposalStub = func(context.Context, *pb.SignedProposal) (*pb.ProposalResponse, error) {
+			<-release
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 200}}, nil
+		}
+
+		errC := make(chan error, 1)
+		go func() { errC <- e.ProcessProposals(fakeStream) }()
+
+		Eventually(fakeProposalProcessor.ProcessProposalCallCount).Should(Equal(2))
+		Consistently(fakeProposalProcessor.ProcessProposalCallCount).Should(Equal(2))
+
+		close(release)
+		Eventually(errC).Should(Receive(BeNil()))
+		Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(3))
+		Expect(fakeStream.SendCallCount()).To(Equal(3))
+	})
+
+	Context("when the endorser service concurrency limit is reached", func() {
+		var limiter semaphore.Semaphore
+
+		BeforeEach(func() {
+			limiter = semaphore.New(1)
+			Expect(limiter.TryAcquire()).To(BeTrue())
+			e.ProposalLimiter = limiter
+		})
+
+		It("rejects the proposals above the limit", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(0))
+			responses := sentResponses()
+			Expect(responses).To(HaveLen(3))
+			for _, resp := range responses {
+				Expect(resp.Error).To(Equal("too many requests, exceeding concurrency limit (1)"))
+			}
+			Expect(fakeBatchedProposalsFailed.AddCallCount()).To(Equal(3))
+		})
+
+		It("counts every proposal in flight against the limit", func() {
+			limiter.Release()
+			release := make(chan struct{})
+			fakeProposalProcessor.ProcessProposalStub = func(context.Context, *pb.SignedProposal) (*pb.ProposalResponse, error) {
+				<-release
+				return &pb.ProposalResponse{Response: &pb.Response{Status: 200}}, nil
+			}
+
+			errC := make(chan error, 1)
+			go func() { errC <- e.ProcessProposals(fakeStream) }()
+
+			Eventually(fakeStream.SendCallCount).Should(Equal(2))
+			Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(1))
+			Expect(limiter.TryAcquire()).To(BeFalse())
+
+			close(release)
+			Eventually(errC).Should(Receive(BeNil()))
+			Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(1))
+			Expect(limiter.TryAcquire()).To(BeTrue())
+		})
+	})
+
+	Context("when a proposal fails", func() {
+		BeforeEach(func() {
+			fakeProposalProcessor.ProcessProposalStub = func(_ context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+				switch string(signedProp.ProposalBytes) {
+				case "proposal-1":
+					return nil, errors.New("kaboom")
+				case "proposal-2":
+					return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}, nil
+				default:
+					return &pb.ProposalResponse{Response: &pb.Response{Status: 200}}, nil
+				}
+			}
+		})
+
+		It("reports the failure in the response to that proposal only", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).NotTo(HaveOccurred())
+
+			responses := sentResponses()
+			Expect(responses).To(HaveLen(3))
+			Expect(responses[0].Error).To(BeEmpty())
+			Expect(responses[0].ProposalResponse.Response.Status).To(Equal(int32(200)))
+			Expect(responses[1].Error).To(Equal("kaboom"))
+			Expect(responses[1].ProposalResponse).To(BeNil())
+			Expect(responses[2].Error).To(BeEmpty())
+			Expect(responses[2].ProposalResponse.Response.Status).To(Equal(int32(500)))
+		})
+
+		It("counts the failed proposals", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBatchedProposalsFailed.AddCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when no proposal processor is set", func() {
+		BeforeEach(func() {
+			e.ProposalProcessor = nil
+			e.Metrics.ProposalsReceived = &metricsfakes.Counter{}
+			e.Metrics.ProposalValidationFailed = &metricsfakes.Counter{}
+		})
+
+		It("processes the proposals itself", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeProposalProcessor.ProcessProposalCallCount()).To(Equal(0))
+			Expect(fakeStream.SendCallCount()).To(Equal(3))
+			for _, resp := range sentResponses() {
+				Expect(resp.Error).To(ContainSubstring("error unmarshaling Proposal"))
+			}
+		})
+	})
+
+	Context("when receiving a proposal fails", func() {
+		BeforeEach(func() {
+			fakeStream.RecvReturnsOnCall(0, proposals[0], nil)
+			fakeStream.RecvReturnsOnCall(1, nil, errors.New("connection reset"))
+			fakeStream.RecvStub = nil
+		})
+
+		It("answers the proposals already received and returns an error", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).To(MatchError("failed to receive proposal: connection reset"))
+
+			Expect(fakeStream.SendCallCount()).To(Equal(1))
+			Expect(fakeStream.SendArgsForCall(0).Index).To(Equal(uint64(0)))
+			Expect(fakeProposalBatchDuration.WithArgsForCall(0)).To(Equal([]string{"success", "false"}))
+		})
+	})
+
+	Context("when sending a response fails", func() {
+		BeforeEach(func() {
+			fakeStream.SendReturns(errors.New("broken pipe"))
+		})
+
+		It("returns an error", func() {
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).To(MatchError(ContainSubstring("failed to send the response to proposal")))
+			Expect(err).To(MatchError(ContainSubstring("broken pipe")))
+			Expect(fakeStream.SendCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the stream is done before a proposal can be processed", func() {
+		It("returns an error", func() {
+			e.BatchParallelism = 1
+			ctx, cancel := context.WithCancel(context.Background())
+			fakeStream.ContextReturns(ctx)
+			fakeProposalProcessor.ProcessProposalStub = func(context.Context, *pb.SignedProposal) (*pb.ProposalResponse, error) {
+				cancel()
+				// hold the only permit until the next proposal gives up waiting for it
+				time.Sleep(100 * time.Millisecond)
+				return &pb.ProposalResponse{Response: &pb.Response{Status: 200}}, nil
+			}
+
+			err := e.ProcessProposals(fakeStream)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("aborted before processing proposal"))
+		})
+	})
+})
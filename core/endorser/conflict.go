@@ -0,0 +1,337 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// The modes of conflict detection.
+const (
+	// ConflictDetectionDisabled disables the detection of likely conflicts.
+	ConflictDetectionDisabled = "disabled"
+	// ConflictDetectionWarn endorses proposals that are likely to conflict,
+	// and flags them with a warning in their response.
+	ConflictDetectionWarn = "warn"
+	// ConflictDetectionReject rejects proposals that are likely to conflict.
+	ConflictDetectionReject = "reject"
+)
+
+// DefaultConflictPendingTimeout is the time after which the writes of an
+// endorsed transaction that has not been committed are forgotten when the
+// conflict detector does not set it.
+const DefaultConflictPendingTimeout = time.Minute
+
+// LikelyConflictStatus is the status of the response to a proposal that is
+// rejected because it is likely to be invalidated with an MVCC read conflict.
+const LikelyConflictStatus = 409
+
+// ConflictWarningHeader is the key of the gRPC response header with which the
+// endorser warns the clients of ProcessProposal that an endorsed proposal is
+// likely to be invalidated with an MVCC read conflict. The clients of
+// ProcessProposals get the warning in the response to the proposal instead.
+const ConflictWarningHeader = "conflict-warning"
+
+// ConflictDetector tracks the keys written by the transactions that the
+// endorser endorsed and that are not committed yet, per channel, to detect
+// the proposals that read them. Such proposals are likely to be invalidated
+// with an MVCC read conflict, as the transactions they read from are likely
+// to be committed first.
+//
+// The detector learns which transactions are committed from the committer of
+// the channels, which passes it every committed block through BlockCommitted.
+// It records how many of the committed transactions were invalidated with an
+// MVCC read conflict, and whether it predicted it.
+type ConflictDetector struct {
+	// Reject is true if the proposals that are likely to conflict are
+	// rejected rather than endorsed with a warning.
+	Reject bool
+	// PendingTimeout is the time after which the writes of an endorsed
+	// transaction that has not been committed are forgotten.
+	// DefaultConflictPendingTimeout is used when it is not set.
+	PendingTimeout time.Duration
+	Metrics        *Metrics
+
+	mutex    sync.RWMutex
+	channels map[string]*pendingWrites
+}
+
+// stateKey identifies a key of the world state. The key of private data is
+// the hex encoded hash of the key.
+type stateKey struct {
+	namespace  string
+	collection string
+	key        string
+}
+
+func (sk stateKey) String() string {
+	if sk.collection == "" {
+		return fmt.Sprintf("key '%s' of namespace '%s'", sk.key, sk.namespace)
+	}
+	return fmt.Sprintf("key with hash %s of collection '%s' of namespace '%s'", sk.key, sk.collection, sk.namespace)
+}
+
+// pendingTx is a transaction that was endorsed and is not committed yet.
+type pendingTx struct {
+	txID      string
+	writes    []stateKey
+	predicted bool
+	expiresAt time.Time
+}
+
+// pendingWrites tracks the keys written by the pending transactions of a
+// channel.
+type pendingWrites struct {
+	mutex   sync.Mutex
+	txs     map[string]*pendingTx
+	expiry  []*pendingTx // in the order the transactions were endorsed
+	writers map[stateKey]map[string]struct{}
+}
+
+// committedTx is a transaction of a committed block.
+type committedTx struct {
+	txID       string
+	conflicted bool
+}
+
+// Check returns a warning describing the likely MVCC read conflict of the
+// transaction with the given read-write set, or an empty string if it does not
+// read any key written by a pending transaction.
+func (cd *ConflictDetector) Check(channelID, txID string, txRWSet *rwsetutil.TxRwSet) string {
+	pw := cd.pendingWrites(channelID)
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	cd.removeExpired(pw)
+	for _, key := range readKeys(txRWSet) {
+		for writer := range pw.writers[key] {
+			if writer == txID {
+				continue
+			}
+			return fmt.Sprintf("likely MVCC read conflict: %s is written by transaction %s, which is not committed yet", key, writer)
+		}
+	}
+	return ""
+}
+
+// Add records the keys written by an endorsed transaction as pending until the
+// transaction is committed or PendingTimeout elapses. Predicted is true if the
+// transaction was endorsed despite a likely conflict.
+func (cd *ConflictDetector) Add(channelID, txID string, txRWSet *rwsetutil.TxRwSet, predicted bool) {
+	writes := writtenKeys(txRWSet)
+	if len(writes) == 0 && !predicted {
+		return
+	}
+
+	pw := cd.pendingWrites(channelID)
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	cd.removeExpired(pw)
+	if _, ok := pw.txs[txID]; ok {
+		return
+	}
+	tx := &pendingTx{
+		txID:      txID,
+		writes:    writes,
+		predicted: predicted,
+		expiresAt: time.Now().Add(cd.pendingTimeout()),
+	}
+	pw.txs[txID] = tx
+	pw.expiry = append(pw.expiry, tx)
+	for _, key := range writes {
+		if pw.writers[key] == nil {
+			pw.writers[key] = map[string]struct{}{}
+		}
+		pw.writers[key][txID] = struct{}{}
+	}
+}
+
+// BlockCommitted removes the pending transactions committed in the block,
+// and records whether the conflicts they were invalidated with were
+// predicted. It is invoked by the committer of the channel once the block is
+// committed to the ledger.
+func (cd *ConflictDetector) BlockCommitted(channelID string, block *cb.Block) {
+	cd.mutex.RLock()
+	pw, ok := cd.channels[channelID]
+	cd.mutex.RUnlock()
+	if !ok {
+		// no transaction of the channel was endorsed
+		return
+	}
+
+	committed := committedTxs(block)
+
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	for _, ct := range committed {
+		tx, ok := pw.txs[ct.txID]
+		if !ok {
+			continue
+		}
+		if ct.conflicted {
+			cd.Metrics.CommittedConflicts.With("channel", channelID, "predicted", strconv.FormatBool(tx.predicted)).Add(1)
+		} else if tx.predicted {
+			cd.Metrics.FalseConflictPredictions.With("channel", channelID).Add(1)
+		}
+		pw.remove(tx)
+	}
+}
+
+// pendingWrites returns the pending writes of the channel.
+func (cd *ConflictDetector) pendingWrites(channelID string) *pendingWrites {
+	cd.mutex.RLock()
+	pw, ok := cd.channels[channelID]
+	cd.mutex.RUnlock()
+	if ok {
+		return pw
+	}
+
+	cd.mutex.Lock()
+	defer cd.mutex.Unlock()
+	if pw, ok := cd.channels[channelID]; ok {
+		return pw
+	}
+	if cd.channels == nil {
+		cd.channels = map[string]*pendingWrites{}
+	}
+	pw = newPendingWrites()
+	cd.channels[channelID] = pw
+	return pw
+}
+
+// committedTxs returns the transactions of the block, along with whether they
+// were invalidated with an MVCC read conflict.
+func committedTxs(block *cb.Block) []committedTx {
+	flags := txflags.ValidationFlags(block.GetMetadata().GetMetadata()[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	var txs []committedTx
+	for i, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			endorserLogger.Debugf("Failed to unmarshal transaction %d of block [%d]: %s", i, block.GetHeader().GetNumber(), err)
+			continue
+		}
+		channelHeader, err := protoutil.ChannelHeader(env)
+		if err != nil {
+			endorserLogger.Debugf("Failed to unmarshal channel header of transaction %d of block [%d]: %s", i, block.GetHeader().GetNumber(), err)
+			continue
+		}
+		txs = append(txs, committedTx{
+			txID:       channelHeader.TxId,
+			conflicted: i < len(flags) && flags.Flag(i) == pb.TxValidationCode_MVCC_READ_CONFLICT,
+		})
+	}
+	return txs
+}
+
+func (cd *ConflictDetector) removeExpired(pw *pendingWrites) {
+	now := time.Now()
+	for len(pw.expiry) > 0 && now.After(pw.expiry[0].expiresAt) {
+		pw.remove(pw.expiry[0])
+		pw.expiry = pw.expiry[1:]
+	}
+}
+
+func (cd *ConflictDetector) pendingTimeout() time.Duration {
+	if cd.PendingTimeout == 0 {
+		return DefaultConflictPendingTimeout
+	}
+	return cd.PendingTimeout
+}
+
+func newPendingWrites() *pendingWrites {
+	return &pendingWrites{
+		txs:     map[string]*pendingTx{},
+		writers: map[stateKey]map[string]struct{}{},
+	}
+}
+
+// remove removes the writes of the transaction. The transaction stays in the
+// expiry queue until it expires.
+func (pw *pendingWrites) remove(tx *pendingTx) {
+	if pw.txs[tx.txID] != tx {
+		return
+	}
+	delete(pw.txs, tx.txID)
+	for _, key := range tx.writes {
+		delete(pw.writers[key], tx.txID)
+		if len(pw.writers[key]) == 0 {
+			delete(pw.writers, key)
+		}
+	}
+}
+
+func readKeys(txRWSet *rwsetutil.TxRwSet) []stateKey {
+	var keys []stateKey
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		for _, read := range nsRWSet.KvRwSet.GetReads() {
+			keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, key: read.Key})
+		}
+		for _, collRWSet := range nsRWSet.CollHashedRwSets {
+			for _, read := range collRWSet.HashedRwSet.GetHashedReads() {
+				keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, collection: collRWSet.CollectionName, key: hex.EncodeToString(read.KeyHash)})
+			}
+		}
+	}
+	return keys
+}
+
+func writtenKeys(txRWSet *rwsetutil.TxRwSet) []stateKey {
+	var keys []stateKey
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		for _, write := range nsRWSet.KvRwSet.GetWrites() {
+			keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, key: write.Key})
+		}
+		for _, write := range nsRWSet.KvRwSet.GetMetadataWrites() {
+			keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, key: write.Key})
+		}
+		for _, collRWSet := range nsRWSet.CollHashedRwSets {
+			for _, write := range collRWSet.HashedRwSet.GetHashedWrites() {
+				keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, collection: collRWSet.CollectionName, key: hex.EncodeToString(write.KeyHash)})
+			}
+			for _, write := range collRWSet.HashedRwSet.GetMetadataWrites() {
+				keys = append(keys, stateKey{namespace: nsRWSet.NameSpace, collection: collRWSet.CollectionName, key: hex.EncodeToString(write.KeyHash)})
+			}
+		}
+	}
+	return keys
+}
+
+type conflictWarningKey struct{}
+
+// withConflictWarning returns a context with which the warning about the
+// likely conflict of a proposal is stored in warning rather than sent in a
+// gRPC response header.
+func withConflictWarning(ctx context.Context, warning *string) context.Context {
+	return context.WithValue(ctx, conflictWarningKey{}, warning)
+}
+
+// reportConflictWarning reports the warning about the likely conflict of the
+// proposal processed with the context.
+func reportConflictWarning(ctx context.Context, warning string) {
+	if dest, ok := ctx.Value(conflictWarningKey{}).(*string); ok {
+		*dest = warning
+		return
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ConflictWarningHeader, warning)); err != nil {
+		endorserLogger.Warningf("Failed to send the conflict warning: %s", err)
+	}
+}
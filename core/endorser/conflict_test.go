@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+var _ = Describe("ConflictDetector", func() {
+	var (
+		fakeCommittedConflicts       *metricsfakes.Counter
+		fakeFalseConflictPredictions *metricsfakes.Counter
+
+		cd *endorser.ConflictDetector
+	)
+
+	kvRWSet := func(reads, writes []string) *rwsetutil.TxRwSet {
+		kvRWSet := &kvrwset.KVRWSet{}
+		for _, key := range reads {
+			kvRWSet.Reads = append(kvRWSet.Reads, &kvrwset.KVRead{Key: key})
+		}
+		for _, key := range writes {
+			kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte("value")})
+		}
+		return &rwsetutil.TxRwSet{
+			NsRwSets: []*rwsetutil.NsRwSet{{NameSpace: "mycc", KvRwSet: kvRWSet}},
+		}
+	}
+
+	block := func(number uint64, codes map[string]pb.TxValidationCode, txIDs ...string) *cb.Block {
+		b := protoutil.NewBlock(number, nil)
+		flags := txflags.New(len(txIDs))
+		for i, txID := range txIDs {
+			b.Data.Data = append(b.Data.Data, protoutil.MarshalOrPanic(&cb.Envelope{
+				Payload: protoutil.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+							Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+							ChannelId: "channel-id",
+							TxId:      txID,
+						}),
+					},
+				}),
+			}))
+			flags.SetFlag(i, codes[txID])
+		}
+		b.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+		return b
+	}
+
+	BeforeEach(func() {
+		fakeCommittedConflicts = &metricsfakes.Counter{}
+		fakeCommittedConflicts.WithReturns(fakeCommittedConflicts)
+		fakeFalseConflictPredictions = &metricsfakes.Counter{}
+		fakeFalseConflictPredictions.WithReturns(fakeFalseConflictPredictions)
+
+		cd = &endorser.ConflictDetector{
+			Metrics: &endorser.Metrics{
+				CommittedConflicts:       fakeCommittedConflicts,
+				FalseConflictPredictions: fakeFalseConflictPredictions,
+			},
+		}
+	})
+
+	It("does not warn about proposals that read keys no pending transaction writes", func() {
+		cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+		Expect(cd.Check("channel-id", "tx2", kvRWSet([]string{"key2"}, []string{"key1"}))).To(BeEmpty())
+	})
+
+	It("warns about proposals that read keys written by pending transactions", func() {
+		cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+		warning := cd.Check("channel-id", "tx2", kvRWSet([]string{"key2", "key1"}, nil))
+		Expect(warning).To(Equal("likely MVCC read conflict: key 'key1' of namespace 'mycc' is written by transaction tx1, which is not committed yet"))
+	})
+
+	It("ignores the writes of the transaction itself", func() {
+		cd.Add("channel-id", "tx1", kvRWSet([]string{"key1"}, []string{"key1"}), false)
+		Expect(cd.Check("channel-id", "tx1", kvRWSet([]string{"key1"}, []string{"key1"}))).To(BeEmpty())
+	})
+
+	It("tracks the pending writes of each channel separately", func() {
+		cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+		Expect(cd.Check("other-channel-id", "tx2", kvRWSet([]string{"key1"}, nil))).To(BeEmpty())
+	})
+
+	It("warns about proposals that read private data written by pending transactions", func() {
+		cd.Add("channel-id", "tx1", &rwsetutil.TxRwSet{
+			NsRwSets: []*rwsetutil.NsRwSet{{
+				NameSpace: "mycc",
+				KvRwSet:   &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{
+					CollectionName: "mycoll",
+					HashedRwSet: &kvrwset.HashedRWSet{
+						HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte{0xca, 0xfe}}},
+					},
+				}},
+			}},
+		}, false)
+		warning := cd.Check("channel-id", "tx2", &rwsetutil.TxRwSet{
+			NsRwSets: []*rwsetutil.NsRwSet{{
+				NameSpace: "mycc",
+				KvRwSet:   &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{
+					CollectionName: "mycoll",
+					HashedRwSet: &kvrwset.HashedRWSet{
+						HashedReads: []*kvrwset.KVReadHash{{KeyHash: []byte{0xca, 0xfe}}},
+					},
+				}},
+			}},
+		})
+		Expect(warning).To(ContainSubstring("key with hash cafe of collection 'mycoll' of namespace 'mycc'"))
+	})
+
+	Context("when the pending transactions are committed", func() {
+		var committedBlock *cb.Block
+
+		BeforeEach(func() {
+			cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+			cd.Add("channel-id", "tx2", kvRWSet(nil, []string{"key2"}), true)
+			cd.Add("channel-id", "tx3", kvRWSet(nil, []string{"key3"}), true)
+			committedBlock = block(5, map[string]pb.TxValidationCode{
+				"tx1": pb.TxValidationCode_MVCC_READ_CONFLICT,
+				"tx2": pb.TxValidationCode_MVCC_READ_CONFLICT,
+				"tx3": pb.TxValidationCode_VALID,
+			}, "tx1", "tx2", "tx3", "other-tx")
+		})
+
+		It("forgets their writes", func() {
+			cd.BlockCommitted("channel-id", committedBlock)
+			Expect(cd.Check("channel-id", "tx4", kvRWSet([]string{"key1", "key2", "key3"}, nil))).To(BeEmpty())
+		})
+
+		It("records whether their conflicts were predicted", func() {
+			cd.BlockCommitted("channel-id", committedBlock)
+
+			Expect(fakeCommittedConflicts.WithCallCount()).To(Equal(2))
+			Expect(fakeCommittedConflicts.WithArgsForCall(0)).To(Equal([]string{"channel", "channel-id", "predicted", "false"}))
+			Expect(fakeCommittedConflicts.WithArgsForCall(1)).To(Equal([]string{"channel", "channel-id", "predicted", "true"}))
+			Expect(fakeCommittedConflicts.AddCallCount()).To(Equal(2))
+
+			Expect(fakeFalseConflictPredictions.WithCallCount()).To(Equal(1))
+			Expect(fakeFalseConflictPredictions.WithArgsForCall(0)).To(Equal([]string{"channel", "channel-id"}))
+			Expect(fakeFalseConflictPredictions.AddCallCount()).To(Equal(1))
+		})
+
+		It("records them only once", func() {
+			cd.BlockCommitted("channel-id", committedBlock)
+			cd.BlockCommitted("channel-id", committedBlock)
+
+			Expect(fakeCommittedConflicts.AddCallCount()).To(Equal(2))
+			Expect(fakeFalseConflictPredictions.AddCallCount()).To(Equal(1))
+		})
+
+		It("keeps their writes when the block is committed on another channel", func() {
+			cd.BlockCommitted("other-channel-id", committedBlock)
+			Expect(cd.Check("channel-id", "tx4", kvRWSet([]string{"key1"}, nil))).NotTo(BeEmpty())
+			Expect(fakeCommittedConflicts.WithCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a block contains transactions that cannot be unmarshaled", func() {
+		It("removes the other committed transactions", func() {
+			cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+			committedBlock := block(5, nil, "tx1")
+			committedBlock.Data.Data = append([][]byte{[]byte("garbage")}, committedBlock.Data.Data...)
+
+			cd.BlockCommitted("channel-id", committedBlock)
+			Expect(cd.Check("channel-id", "tx2", kvRWSet([]string{"key1"}, nil))).To(BeEmpty())
+		})
+	})
+
+	It("tracks the transactions of different channels concurrently", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(channelID string) {
+				defer wg.Done()
+				cd.Add(channelID, "tx1", kvRWSet(nil, []string{"key1"}), false)
+				Expect(cd.Check(channelID, "tx2", kvRWSet([]string{"key1"}, nil))).NotTo(BeEmpty())
+				cd.BlockCommitted(channelID, block(5, nil, "tx1"))
+				Expect(cd.Check(channelID, "tx2", kvRWSet([]string{"key1"}, nil))).To(BeEmpty())
+			}(fmt.Sprintf("channel-%d", i))
+		}
+		wg.Wait()
+	})
+
+	Context("when the pending transactions are not committed in time", func() {
+		BeforeEach(func() {
+			cd.PendingTimeout = 10 * time.Millisecond
+		})
+
+		It("forgets their writes", func() {
+			cd.Add("channel-id", "tx1", kvRWSet(nil, []string{"key1"}), false)
+			Expect(cd.Check("channel-id", "tx2", kvRWSet([]string{"key1"}, nil))).NotTo(BeEmpty())
+
+			time.Sleep(20 * time.Millisecond)
+			Expect(cd.Check("channel-id", "tx2", kvRWSet([]string{"key1"}, nil))).To(BeEmpty())
+		})
+	})
+})
@@ -17,10 +17,12 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/semaphore"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protoutil"
@@ -100,6 +102,23 @@ type Endorser struct {
 	Support                Support
 	PvtRWSetAssembler      PvtRWSetAssembler
 	Metrics                *Metrics
+	// BatchParallelism is the number of proposals of a ProcessProposals
+	// stream that are processed concurrently. DefaultBatchParallelism is used
+	// when it is not set.
+	BatchParallelism int
+	// ProposalProcessor processes the proposals of ProcessProposals streams,
+	// so that they go through the same auth filters as the proposals sent to
+	// ProcessProposal. The endorser processes them itself when it is not set.
+	ProposalProcessor ProposalProcessor
+	// ProposalLimiter bounds the number of proposals of ProcessProposals
+	// streams processed concurrently by the peer. It is shared with the
+	// concurrency limit of the Endorser service, so that a batch counts
+	// every proposal in flight. Proposals are not limited when it is not set.
+	ProposalLimiter semaphore.Semaphore
+	// ConflictDetector detects the proposals that are likely to be
+	// invalidated with an MVCC read conflict. Conflicts are not detected when
+	// it is not set.
+	ConflictDetector *ConflictDetector
 }
 
 // call specified chaincode (system or user)
@@ -337,7 +356,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		e.Metrics.ProposalDuration.With(meterLabels...).Observe(time.Since(startTime).Seconds())
 	}()
 
-	pResp, err := e.ProcessProposalSuccessfullyOrError(up)
+	pResp, err := e.ProcessProposalSuccessfullyOrError(ctx, up)
 	if err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 	}
@@ -354,7 +373,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	return pResp, nil
 }
 
-func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb.ProposalResponse, error) {
+func (e *Endorser) ProcessProposalSuccessfullyOrError(ctx context.Context, up *UnpackedProposal) (*pb.ProposalResponse, error) {
 	txParams := &ccprovider.TransactionParams{
 		ChannelID:  up.ChannelHeader.ChannelId,
 		TxID:       up.ChannelHeader.TxId,
@@ -441,6 +460,29 @@ func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb
 		}, nil
 	}
 
+	// 2 -- detect likely conflicts with the transactions not committed yet
+	var txRWSet *rwsetutil.TxRwSet
+	var conflictWarning string
+	if e.ConflictDetector != nil {
+		txRWSet = &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(simulationResult); err != nil {
+			return nil, errors.WithMessage(err, "failed to unmarshal the simulation results")
+		}
+		conflictWarning = e.ConflictDetector.Check(up.ChannelID(), up.TxID(), txRWSet)
+		if conflictWarning != "" {
+			e.Metrics.PredictedConflicts.With(meterLabels...).Add(1)
+			logger.Debug(conflictWarning)
+			if e.ConflictDetector.Reject {
+				return &pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  LikelyConflictStatus,
+						Message: conflictWarning,
+					},
+				}, nil
+			}
+		}
+	}
+
 	escc := cdLedger.EndorsementPlugin
 
 	logger.Debugf("escc for chaincode %s is %s", up.ChaincodeName, escc)
@@ -453,6 +495,13 @@ func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb
 		return nil, errors.WithMessage(err, "endorsing with plugin failed")
 	}
 
+	if e.ConflictDetector != nil {
+		e.ConflictDetector.Add(up.ChannelID(), up.TxID(), txRWSet, conflictWarning != "")
+		if conflictWarning != "" {
+			reportConflictWarning(ctx, conflictWarning)
+		}
+	}
+
 	return &pb.ProposalResponse{
 		Version:     1,
 		Endorsement: endorsement,
@@ -10,8 +10,10 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/endorserpb"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -42,6 +44,16 @@ type historyQueryExecutor interface {
 	ledger.HistoryQueryExecutor
 }
 
+//go:generate counterfeiter -o fake/process_proposals_server.go --fake-name ProcessProposalsServer . processProposalsServer
+type processProposalsServer interface {
+	endorserpb.BatchEndorser_ProcessProposalsServer
+}
+
+//go:generate counterfeiter -o fake/server_transport_stream.go --fake-name ServerTransportStream . serverTransportStream
+type serverTransportStream interface {
+	grpc.ServerTransportStream
+}
+
 func TestEndorser(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Endorser Suite")
@@ -9,6 +9,7 @@ package endorser_test
 import (
 	"context"
 	"fmt"
+	"io"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,6 +17,7 @@ import (
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	mspproto "github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
@@ -23,7 +25,10 @@ import (
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/endorser/fake"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/golang/protobuf/proto"
 	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
@@ -563,6 +568,138 @@ var _ = Describe("Endorser", func() {
 		}))
 	})
 
+	Context("when conflict detection is enabled", func() {
+		var (
+			fakePredictedConflicts    *metricsfakes.Counter
+			fakeServerTransportStream *fake.ServerTransportStream
+			conflictDetector          *endorser.ConflictDetector
+			ctx                       context.Context
+		)
+
+		BeforeEach(func() {
+			fakePredictedConflicts = &metricsfakes.Counter{}
+			fakePredictedConflicts.WithReturns(fakePredictedConflicts)
+			e.Metrics.PredictedConflicts = fakePredictedConflicts
+
+			conflictDetector = &endorser.ConflictDetector{
+				Metrics: e.Metrics,
+			}
+			e.ConflictDetector = conflictDetector
+
+			fakeServerTransportStream = &fake.ServerTransportStream{}
+			ctx = grpc.NewContextWithServerTransportStream(context.Background(), fakeServerTransportStream)
+
+			fakeTxSimulator.GetTxSimulationResultsReturns(
+				&ledger.TxSimulationResults{
+					PubSimulationResults: &rwset.TxReadWriteSet{
+						DataModel: rwset.TxReadWriteSet_KV,
+						NsRwset: []*rwset.NsReadWriteSet{{
+							Namespace: "chaincode-name",
+							Rwset: protoutil.MarshalOrPanic(&kvrwset.KVRWSet{
+								Reads:  []*kvrwset.KVRead{{Key: "key1"}},
+								Writes: []*kvrwset.KVWrite{{Key: "key2", Value: []byte("value")}},
+							}),
+						}},
+					},
+					PvtSimulationResults: &rwset.TxPvtReadWriteSet{},
+				},
+				nil,
+			)
+		})
+
+		It("endorses the proposal without a warning", func() {
+			proposalResponse, err := e.ProcessProposal(ctx, signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse.Endorsement).NotTo(BeNil())
+			Expect(fakeServerTransportStream.SetHeaderCallCount()).To(Equal(0))
+			Expect(fakePredictedConflicts.AddCallCount()).To(Equal(0))
+		})
+
+		It("records the keys written by the endorsed transaction", func() {
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+
+			warning := conflictDetector.Check("channel-id", "other-txid", &rwsetutil.TxRwSet{
+				NsRwSets: []*rwsetutil.NsRwSet{{
+					NameSpace: "chaincode-name",
+					KvRwSet:   &kvrwset.KVRWSet{Reads: []*kvrwset.KVRead{{Key: "key2"}}},
+				}},
+			})
+			Expect(warning).To(ContainSubstring("6f142589e4ef6a1e62c9c816e2074f70baa9f7cf67c2f0c287d4ef907d6d2015"))
+		})
+
+		Context("when the proposal reads a key written by a pending transaction", func() {
+			BeforeEach(func() {
+				conflictDetector.Add("channel-id", "pending-txid", &rwsetutil.TxRwSet{
+					NsRwSets: []*rwsetutil.NsRwSet{{
+						NameSpace: "chaincode-name",
+						KvRwSet:   &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "key1"}}},
+					}},
+				}, false)
+			})
+
+			It("endorses the proposal with a warning in the response header", func() {
+				proposalResponse, err := e.ProcessProposal(ctx, signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proposalResponse.Endorsement).NotTo(BeNil())
+				Expect(fakeServerTransportStream.SetHeaderCallCount()).To(Equal(1))
+				Expect(fakeServerTransportStream.SetHeaderArgsForCall(0)).To(Equal(metadata.Pairs(
+					endorser.ConflictWarningHeader,
+					"likely MVCC read conflict: key 'key1' of namespace 'chaincode-name' is written by transaction pending-txid, which is not committed yet",
+				)))
+
+				Expect(fakePredictedConflicts.WithCallCount()).To(Equal(1))
+				Expect(fakePredictedConflicts.WithArgsForCall(0)).To(Equal([]string{
+					"channel", "channel-id",
+					"chaincode", "chaincode-name",
+				}))
+				Expect(fakePredictedConflicts.AddCallCount()).To(Equal(1))
+			})
+
+			It("endorses a batched proposal with a warning in its response", func() {
+				fakeProposalBatchDuration := &metricsfakes.Histogram{}
+				fakeProposalBatchDuration.WithReturns(fakeProposalBatchDuration)
+				e.Metrics.ProposalBatchesReceived = &metricsfakes.Counter{}
+				e.Metrics.BatchedProposalsReceived = &metricsfakes.Counter{}
+				e.Metrics.BatchedProposalsFailed = &metricsfakes.Counter{}
+				e.Metrics.ProposalBatchDuration = fakeProposalBatchDuration
+
+				fakeStream := &fake.ProcessProposalsServer{}
+				fakeStream.ContextReturns(ctx)
+				fakeStream.RecvReturnsOnCall(0, signedProposal, nil)
+				fakeStream.RecvReturnsOnCall(1, nil, io.EOF)
+
+				err := e.ProcessProposals(fakeStream)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStream.SendCallCount()).To(Equal(1))
+				resp := fakeStream.SendArgsForCall(0)
+				Expect(resp.ProposalResponse.Endorsement).NotTo(BeNil())
+				Expect(resp.ConflictWarning).To(Equal("likely MVCC read conflict: key 'key1' of namespace 'chaincode-name' is written by transaction pending-txid, which is not committed yet"))
+				Expect(fakeServerTransportStream.SetHeaderCallCount()).To(Equal(0))
+			})
+
+			Context("when the proposals that are likely to conflict are rejected", func() {
+				BeforeEach(func() {
+					conflictDetector.Reject = true
+				})
+
+				It("returns a likely conflict response without an endorsement", func() {
+					proposalResponse, err := e.ProcessProposal(ctx, signedProposal)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(proposalResponse.Endorsement).To(BeNil())
+					Expect(proposalResponse.Response).To(Equal(&pb.Response{
+						Status:  409,
+						Message: "likely MVCC read conflict: key 'key1' of namespace 'chaincode-name' is written by transaction pending-txid, which is not committed yet",
+					}))
+
+					Expect(fakeSupport.EndorseWithPluginCallCount()).To(Equal(0))
+					Expect(fakePredictedConflicts.AddCallCount()).To(Equal(1))
+				})
+			})
+		})
+	})
+
 	Context("when the channel id is empty", func() {
 		BeforeEach(func() {
 			channelID = ""
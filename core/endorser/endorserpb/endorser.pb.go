@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: endorser.proto
+
+package endorserpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// BatchedProposalResponse is the response to a proposal sent on a
+// ProcessProposals stream.
+type BatchedProposalResponse struct {
+	// index is the position of the proposal on the stream, starting at 0.
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// proposal_response is the response of the endorser to the proposal. It is
+	// not set when error is.
+	ProposalResponse *peer.ProposalResponse `protobuf:"bytes,2,opt,name=proposal_response,json=proposalResponse,proto3" json:"proposal_response,omitempty"`
+	// error is the reason why the proposal could not be processed.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// conflict_warning warns that the transaction is likely to be invalidated
+	// with an MVCC read conflict, as the proposal read keys written by
+	// transactions that the endorser endorsed and that are not committed yet.
+	ConflictWarning      string   `protobuf:"bytes,4,opt,name=conflict_warning,json=conflictWarning,proto3" json:"conflict_warning,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchedProposalResponse) Reset()         { *m = BatchedProposalResponse{} }
+func (m *BatchedProposalResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchedProposalResponse) ProtoMessage()    {}
+func (*BatchedProposalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e25c974b8479ebd0, []int{0}
+}
+
+func (m *BatchedProposalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchedProposalResponse.Unmarshal(m, b)
+}
+func (m *BatchedProposalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchedProposalResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchedProposalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchedProposalResponse.Merge(m, src)
+}
+func (m *BatchedProposalResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchedProposalResponse.Size(m)
+}
+func (m *BatchedProposalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchedProposalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchedProposalResponse proto.InternalMessageInfo
+
+func (m *BatchedProposalResponse) GetIndex() uint64 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BatchedProposalResponse) GetProposalResponse() *peer.ProposalResponse {
+	if m != nil {
+		return m.ProposalResponse
+	}
+	return nil
+}
+
+func (m *BatchedProposalResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *BatchedProposalResponse) GetConflictWarning() string {
+	if m != nil {
+		return m.ConflictWarning
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*BatchedProposalResponse)(nil), "endorserpb.BatchedProposalResponse")
+}
+
+func init() { proto.RegisterFile("endorser.proto", fileDescriptor_e25c974b8479ebd0) }
+
+var fileDescriptor_e25c974b8479ebd0 = []byte{
+	// 263 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0x4f, 0x4b, 0xc4, 0x30,
+	0x14, 0xc4, 0x89, 0xae, 0x82, 0x11, 0xb5, 0x46, 0xd1, 0x52, 0x3c, 0x14, 0xbd, 0xd4, 0x4b, 0x23,
+	0x2b, 0x88, 0xe7, 0x85, 0xbd, 0x2f, 0xdd, 0x83, 0xe0, 0x65, 0x69, 0xd3, 0xb7, 0x6d, 0xa0, 0xe6,
+	0x85, 0x97, 0x8a, 0xfa, 0xc9, 0xfc, 0x7a, 0x42, 0xd3, 0x50, 0xfc, 0x77, 0x9c, 0xc9, 0xf0, 0x7b,
+	0x33, 0xe1, 0xc7, 0x60, 0x6a, 0x24, 0x07, 0x94, 0x5b, 0xc2, 0x1e, 0x05, 0x0f, 0xda, 0x56, 0xc9,
+	0x99, 0x05, 0x20, 0x69, 0x09, 0x2d, 0xba, 0xb2, 0xf3, 0x81, 0xe4, 0xea, 0x9b, 0xb9, 0x21, 0x70,
+	0x16, 0x8d, 0x03, 0xff, 0x7a, 0xfd, 0xc9, 0xf8, 0xe5, 0xa2, 0xec, 0x55, 0x0b, 0xf5, 0x6a, 0x8c,
+	0x14, 0x63, 0x42, 0x9c, 0xf3, 0x3d, 0x6d, 0x6a, 0x78, 0x8f, 0x59, 0xca, 0xb2, 0x59, 0xe1, 0x85,
+	0x58, 0xf2, 0xd3, 0x5f, 0xb0, 0x78, 0x27, 0x65, 0xd9, 0xe1, 0x3c, 0xf6, 0x50, 0x97, 0xff, 0x44,
+	0x15, 0x91, 0xfd, 0x03, 0x0e, 0x44, 0x48, 0xf1, 0x6e, 0xca, 0xb2, 0x83, 0xc2, 0x0b, 0x71, 0xcb,
+	0x23, 0x85, 0x66, 0xdb, 0x69, 0xd5, 0x6f, 0xde, 0x4a, 0x32, 0xda, 0x34, 0xf1, 0x6c, 0x08, 0x9c,
+	0x04, 0xff, 0xc9, 0xdb, 0xf3, 0x9a, 0x1f, 0x0d, 0xc5, 0x97, 0xe3, 0x7e, 0xb1, 0xe6, 0xd1, 0x8a,
+	0x50, 0x81, 0x73, 0xe1, 0xbc, 0x13, 0x17, 0xa1, 0xd1, 0x5a, 0x37, 0x66, 0x9a, 0x98, 0xdc, 0xe4,
+	0xd3, 0xb7, 0xe5, 0xff, 0xec, 0xcf, 0xd8, 0x1d, 0x5b, 0x3c, 0x3e, 0x3f, 0x34, 0xba, 0x6f, 0x5f,
+	0xab, 0x5c, 0xe1, 0x8b, 0x6c, 0x3f, 0x2c, 0x50, 0x07, 0x75, 0x03, 0x24, 0xb7, 0x65, 0x45, 0x5a,
+	0x49, 0x85, 0x04, 0x32, 0xc0, 0xe4, 0x44, 0xad, 0xf6, 0x87, 0xcb, 0xf7, 0x5f, 0x03, 0x00, 0x4e,
+	0xa7, 0x16, 0xc5, 0xb1, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BatchEndorserClient is the client API for BatchEndorser service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BatchEndorserClient interface {
+	// ProcessProposals processes the proposals sent on the stream
+	// concurrently and streams back a response for each of them, in the
+	// order in which their processing completes.
+	ProcessProposals(ctx context.Context, opts ...grpc.CallOption) (BatchEndorser_ProcessProposalsClient, error)
+}
+
+type batchEndorserClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBatchEndorserClient(cc grpc.ClientConnInterface) BatchEndorserClient {
+	return &batchEndorserClient{cc}
+}
+
+func (c *batchEndorserClient) ProcessProposals(ctx context.Context, opts ...grpc.CallOption) (BatchEndorser_ProcessProposalsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BatchEndorser_serviceDesc.Streams[0], "/endorserpb.BatchEndorser/ProcessProposals", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &batchEndorserProcessProposalsClient{stream}
+	return x, nil
+}
+
+type BatchEndorser_ProcessProposalsClient interface {
+	Send(*peer.SignedProposal) error
+	Recv() (*BatchedProposalResponse, error)
+	grpc.ClientStream
+}
+
+type batchEndorserProcessProposalsClient struct {
+	grpc.ClientStream
+}
+
+func (x *batchEndorserProcessProposalsClient) Send(m *peer.SignedProposal) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *batchEndorserProcessProposalsClient) Recv() (*BatchedProposalResponse, error) {
+	m := new(BatchedProposalResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BatchEndorserServer is the server API for BatchEndorser service.
+type BatchEndorserServer interface {
+	// ProcessProposals processes the proposals sent on the stream
+	// concurrently and streams back a response for each of them, in the
+	// order in which their processing completes.
+	ProcessProposals(BatchEndorser_ProcessProposalsServer) error
+}
+
+// UnimplementedBatchEndorserServer can be embedded to have forward compatible implementations.
+type UnimplementedBatchEndorserServer struct {
+}
+
+func (*UnimplementedBatchEndorserServer) ProcessProposals(srv BatchEndorser_ProcessProposalsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessProposals not implemented")
+}
+
+func RegisterBatchEndorserServer(s *grpc.Server, srv BatchEndorserServer) {
+	s.RegisterService(&_BatchEndorser_serviceDesc, srv)
+}
+
+func _BatchEndorser_ProcessProposals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BatchEndorserServer).ProcessProposals(&batchEndorserProcessProposalsServer{stream})
+}
+
+type BatchEndorser_ProcessProposalsServer interface {
+	Send(*BatchedProposalResponse) error
+	Recv() (*peer.SignedProposal, error)
+	grpc.ServerStream
+}
+
+type batchEndorserProcessProposalsServer struct {
+	grpc.ServerStream
+}
+
+func (x *batchEndorserProcessProposalsServer) Send(m *BatchedProposalResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *batchEndorserProcessProposalsServer) Recv() (*peer.SignedProposal, error) {
+	m := new(peer.SignedProposal)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _BatchEndorser_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "endorserpb.BatchEndorser",
+	HandlerType: (*BatchEndorserServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessProposals",
+			Handler:       _BatchEndorser_ProcessProposals_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "endorser.proto",
+}
@@ -0,0 +1,318 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type ServerTransportStream struct {
+	MethodStub        func() string
+	methodMutex       sync.RWMutex
+	methodArgsForCall []struct {
+	}
+	methodReturns struct {
+		result1 string
+	}
+	methodReturnsOnCall map[int]struct {
+		result1 string
+	}
+	SendHeaderStub        func(metadata.MD) error
+	sendHeaderMutex       sync.RWMutex
+	sendHeaderArgsForCall []struct {
+		arg1 metadata.MD
+	}
+	sendHeaderReturns struct {
+		result1 error
+	}
+	sendHeaderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetHeaderStub        func(metadata.MD) error
+	setHeaderMutex       sync.RWMutex
+	setHeaderArgsForCall []struct {
+		arg1 metadata.MD
+	}
+	setHeaderReturns struct {
+		result1 error
+	}
+	setHeaderReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetTrailerStub        func(metadata.MD) error
+	setTrailerMutex       sync.RWMutex
+	setTrailerArgsForCall []struct {
+		arg1 metadata.MD
+	}
+	setTrailerReturns struct {
+		result1 error
+	}
+	setTrailerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ServerTransportStream) Method() string {
+	fake.methodMutex.Lock()
+	ret, specificReturn := fake.methodReturnsOnCall[len(fake.methodArgsForCall)]
+	fake.methodArgsForCall = append(fake.methodArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Method", []interface{}{})
+	fake.methodMutex.Unlock()
+	if fake.MethodStub != nil {
+		return fake.MethodStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.methodReturns
+	return fakeReturns.result1
+}
+
+func (fake *ServerTransportStream) MethodCallCount() int {
+	fake.methodMutex.RLock()
+	defer fake.methodMutex.RUnlock()
+	return len(fake.methodArgsForCall)
+}
+
+func (fake *ServerTransportStream) MethodCalls(stub func() string) {
+	fake.methodMutex.Lock()
+	defer fake.methodMutex.Unlock()
+	fake.MethodStub = stub
+}
+
+func (fake *ServerTransportStream) MethodReturns(result1 string) {
+	fake.methodMutex.Lock()
+	defer fake.methodMutex.Unlock()
+	fake.MethodStub = nil
+	fake.methodReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ServerTransportStream) MethodReturnsOnCall(i int, result1 string) {
+	fake.methodMutex.Lock()
+	defer fake.methodMutex.Unlock()
+	fake.MethodStub = nil
+	if fake.methodReturnsOnCall == nil {
+		fake.methodReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.methodReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SendHeader(arg1 metadata.MD) error {
+	fake.sendHeaderMutex.Lock()
+	ret, specificReturn := fake.sendHeaderReturnsOnCall[len(fake.sendHeaderArgsForCall)]
+	fake.sendHeaderArgsForCall = append(fake.sendHeaderArgsForCall, struct {
+		arg1 metadata.MD
+	}{arg1})
+	fake.recordInvocation("SendHeader", []interface{}{arg1})
+	fake.sendHeaderMutex.Unlock()
+	if fake.SendHeaderStub != nil {
+		return fake.SendHeaderStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sendHeaderReturns
+	return fakeReturns.result1
+}
+
+func (fake *ServerTransportStream) SendHeaderCallCount() int {
+	fake.sendHeaderMutex.RLock()
+	defer fake.sendHeaderMutex.RUnlock()
+	return len(fake.sendHeaderArgsForCall)
+}
+
+func (fake *ServerTransportStream) SendHeaderCalls(stub func(metadata.MD) error) {
+	fake.sendHeaderMutex.Lock()
+	defer fake.sendHeaderMutex.Unlock()
+	fake.SendHeaderStub = stub
+}
+
+func (fake *ServerTransportStream) SendHeaderArgsForCall(i int) metadata.MD {
+	fake.sendHeaderMutex.RLock()
+	defer fake.sendHeaderMutex.RUnlock()
+	argsForCall := fake.sendHeaderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ServerTransportStream) SendHeaderReturns(result1 error) {
+	fake.sendHeaderMutex.Lock()
+	defer fake.sendHeaderMutex.Unlock()
+	fake.SendHeaderStub = nil
+	fake.sendHeaderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SendHeaderReturnsOnCall(i int, result1 error) {
+	fake.sendHeaderMutex.Lock()
+	defer fake.sendHeaderMutex.Unlock()
+	fake.SendHeaderStub = nil
+	if fake.sendHeaderReturnsOnCall == nil {
+		fake.sendHeaderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendHeaderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SetHeader(arg1 metadata.MD) error {
+	fake.setHeaderMutex.Lock()
+	ret, specificReturn := fake.setHeaderReturnsOnCall[len(fake.setHeaderArgsForCall)]
+	fake.setHeaderArgsForCall = append(fake.setHeaderArgsForCall, struct {
+		arg1 metadata.MD
+	}{arg1})
+	fake.recordInvocation("SetHeader", []interface{}{arg1})
+	fake.setHeaderMutex.Unlock()
+	if fake.SetHeaderStub != nil {
+		return fake.SetHeaderStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setHeaderReturns
+	return fakeReturns.result1
+}
+
+func (fake *ServerTransportStream) SetHeaderCallCount() int {
+	fake.setHeaderMutex.RLock()
+	defer fake.setHeaderMutex.RUnlock()
+	return len(fake.setHeaderArgsForCall)
+}
+
+func (fake *ServerTransportStream) SetHeaderCalls(stub func(metadata.MD) error) {
+	fake.setHeaderMutex.Lock()
+	defer fake.setHeaderMutex.Unlock()
+	fake.SetHeaderStub = stub
+}
+
+func (fake *ServerTransportStream) SetHeaderArgsForCall(i int) metadata.MD {
+	fake.setHeaderMutex.RLock()
+	defer fake.setHeaderMutex.RUnlock()
+	argsForCall := fake.setHeaderArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ServerTransportStream) SetHeaderReturns(result1 error) {
+	fake.setHeaderMutex.Lock()
+	defer fake.setHeaderMutex.Unlock()
+	fake.SetHeaderStub = nil
+	fake.setHeaderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SetHeaderReturnsOnCall(i int, result1 error) {
+	fake.setHeaderMutex.Lock()
+	defer fake.setHeaderMutex.Unlock()
+	fake.SetHeaderStub = nil
+	if fake.setHeaderReturnsOnCall == nil {
+		fake.setHeaderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setHeaderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SetTrailer(arg1 metadata.MD) error {
+	fake.setTrailerMutex.Lock()
+	ret, specificReturn := fake.setTrailerReturnsOnCall[len(fake.setTrailerArgsForCall)]
+	fake.setTrailerArgsForCall = append(fake.setTrailerArgsForCall, struct {
+		arg1 metadata.MD
+	}{arg1})
+	fake.recordInvocation("SetTrailer", []interface{}{arg1})
+	fake.setTrailerMutex.Unlock()
+	if fake.SetTrailerStub != nil {
+		return fake.SetTrailerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setTrailerReturns
+	return fakeReturns.result1
+}
+
+func (fake *ServerTransportStream) SetTrailerCallCount() int {
+	fake.setTrailerMutex.RLock()
+	defer fake.setTrailerMutex.RUnlock()
+	return len(fake.setTrailerArgsForCall)
+}
+
+func (fake *ServerTransportStream) SetTrailerCalls(stub func(metadata.MD) error) {
+	fake.setTrailerMutex.Lock()
+	defer fake.setTrailerMutex.Unlock()
+	fake.SetTrailerStub = stub
+}
+
+func (fake *ServerTransportStream) SetTrailerArgsForCall(i int) metadata.MD {
+	fake.setTrailerMutex.RLock()
+	defer fake.setTrailerMutex.RUnlock()
+	argsForCall := fake.setTrailerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ServerTransportStream) SetTrailerReturns(result1 error) {
+	fake.setTrailerMutex.Lock()
+	defer fake.setTrailerMutex.Unlock()
+	fake.SetTrailerStub = nil
+	fake.setTrailerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) SetTrailerReturnsOnCall(i int, result1 error) {
+	fake.setTrailerMutex.Lock()
+	defer fake.setTrailerMutex.Unlock()
+	fake.SetTrailerStub = nil
+	if fake.setTrailerReturnsOnCall == nil {
+		fake.setTrailerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTrailerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ServerTransportStream) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.methodMutex.RLock()
+	defer fake.methodMutex.RUnlock()
+	fake.sendHeaderMutex.RLock()
+	defer fake.sendHeaderMutex.RUnlock()
+	fake.setHeaderMutex.RLock()
+	defer fake.setHeaderMutex.RUnlock()
+	fake.setTrailerMutex.RLock()
+	defer fake.setTrailerMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ServerTransportStream) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -74,6 +74,56 @@ var (
 		LabelNames:   []string{"channel", "chaincode"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
 	}
+
+	receivedProposalBatchesCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "proposal_batches_received",
+		Help:      "The number of proposal batches (ProcessProposals streams) received.",
+	}
+
+	receivedBatchedProposalsCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "batched_proposals_received",
+		Help:      "The number of proposals received in batches.",
+	}
+
+	failedBatchedProposalsCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "batched_proposal_failures",
+		Help:      "The number of proposals received in batches that have failed.",
+	}
+
+	proposalBatchDurationHistogramOpts = metrics.HistogramOpts{
+		Namespace:    "endorser",
+		Name:         "proposal_batch_duration",
+		Help:         "The time to complete a proposal batch.",
+		LabelNames:   []string{"success"},
+		StatsdFormat: "%{#fqname}.%{success}",
+	}
+
+	predictedConflictsCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "predicted_conflicts",
+		Help:         "The number of proposals that read keys written by endorsed transactions that are not committed yet.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	committedConflictsCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "committed_conflicts",
+		Help:         "The number of endorsed transactions invalidated with an MVCC read conflict, by whether the conflict was predicted.",
+		LabelNames:   []string{"channel", "predicted"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{predicted}",
+	}
+
+	falseConflictPredictionsCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "false_conflict_predictions",
+		Help:         "The number of transactions endorsed with a likely conflict that were committed without an MVCC read conflict.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
@@ -86,6 +136,13 @@ type Metrics struct {
 	EndorsementsFailed       metrics.Counter
 	DuplicateTxsFailure      metrics.Counter
 	SimulationFailure        metrics.Counter
+	ProposalBatchesReceived  metrics.Counter
+	BatchedProposalsReceived metrics.Counter
+	BatchedProposalsFailed   metrics.Counter
+	ProposalBatchDuration    metrics.Histogram
+	PredictedConflicts       metrics.Counter
+	CommittedConflicts       metrics.Counter
+	FalseConflictPredictions metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -99,5 +156,12 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		EndorsementsFailed:       p.NewCounter(endorsementFailureCounterOpts),
 		DuplicateTxsFailure:      p.NewCounter(duplicateTxsFailureCounterOpts),
 		SimulationFailure:        p.NewCounter(simulationFailureCounterOpts),
+		ProposalBatchesReceived:  p.NewCounter(receivedProposalBatchesCounterOpts),
+		BatchedProposalsReceived: p.NewCounter(receivedBatchedProposalsCounterOpts),
+		BatchedProposalsFailed:   p.NewCounter(failedBatchedProposalsCounterOpts),
+		ProposalBatchDuration:    p.NewHistogram(proposalBatchDurationHistogramOpts),
+		PredictedConflicts:       p.NewCounter(predictedConflictsCounterOpts),
+		CommittedConflicts:       p.NewCounter(committedConflictsCounterOpts),
+		FalseConflictPredictions: p.NewCounter(falseConflictPredictionsCounterOpts),
 	}
 }
@@ -31,14 +31,22 @@ func TestNewMetrics(t *testing.T) {
 		EndorsementsFailed:       &metricsfakes.Counter{},
 		DuplicateTxsFailure:      &metricsfakes.Counter{},
 		SimulationFailure:        &metricsfakes.Counter{},
+		ProposalBatchesReceived:  &metricsfakes.Counter{},
+		BatchedProposalsReceived: &metricsfakes.Counter{},
+		BatchedProposalsFailed:   &metricsfakes.Counter{},
+		ProposalBatchDuration:    &metricsfakes.Histogram{},
+		PredictedConflicts:       &metricsfakes.Counter{},
+		CommittedConflicts:       &metricsfakes.Counter{},
+		FalseConflictPredictions: &metricsfakes.Counter{},
 	}))
 
-	gt.Expect(provider.NewHistogramCallCount()).To(Equal(1))
+	gt.Expect(provider.NewHistogramCallCount()).To(Equal(2))
 	gt.Expect(provider.Invocations()["NewHistogram"]).To(ConsistOf([][]interface{}{
 		{proposalDurationHistogramOpts},
+		{proposalBatchDurationHistogramOpts},
 	}))
 
-	gt.Expect(provider.NewCounterCallCount()).To(Equal(8))
+	gt.Expect(provider.NewCounterCallCount()).To(Equal(14))
 	gt.Expect(provider.Invocations()["NewCounter"]).To(ConsistOf([][]interface{}{
 		{receivedProposalsCounterOpts},
 		{successfulProposalsCounterOpts},
@@ -48,5 +56,11 @@ func TestNewMetrics(t *testing.T) {
 		{endorsementFailureCounterOpts},
 		{duplicateTxsFailureCounterOpts},
 		{simulationFailureCounterOpts},
+		{receivedProposalBatchesCounterOpts},
+		{receivedBatchedProposalsCounterOpts},
+		{failedBatchedProposalsCounterOpts},
+		{predictedConflictsCounterOpts},
+		{committedConflictsCounterOpts},
+		{falseConflictPredictionsCounterOpts},
 	}))
 }
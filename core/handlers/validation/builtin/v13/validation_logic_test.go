@@ -1729,6 +1729,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*protoutil.SignedData) error {
+	return nil
+}
+
 func createCollectionConfig(collectionName string, signaturePolicyEnvelope *common.SignaturePolicyEnvelope,
 	requiredPeerCount int32, maximumPeerCount int32, blockToLive uint64,
 ) *peer.CollectionConfig {
@@ -262,6 +262,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*protoutil.SignedData) error {
+	return nil
+}
+
 func TestMain(m *testing.M) {
 	code := -1
 	defer func() {
@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package crosschannel defines how the state of the cross-channel transactions is
+// kept in the namespace of the xcc system chaincode. A cross-channel transaction is
+// committed in two phases: the writes of every channel are first staged, and the keys
+// they modify locked, by a PREPARED record; they are then applied, or discarded, by
+// a COMMITTED, or ABORTED, record of the same transaction.
+package crosschannel
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/pkg/errors"
+)
+
+// Namespace is the namespace of the xcc system chaincode
+const Namespace = "xcc"
+
+// systemNamespaces are the namespaces of the system chaincodes whose state is only modified by
+// the chaincodes themselves, as their writes are validated by rules that staging would bypass
+var systemNamespaces = map[string]struct{}{
+	"_lifecycle": {},
+	"lscc":       {},
+}
+
+const (
+	txKeyPrefix     = "xtx/"
+	stagedKeyPrefix = "staged/"
+	lockKeyPrefix   = "lock/"
+)
+
+// TxKey returns the key of the record of the cross-channel transaction with the given ID
+func TxKey(txID string) string {
+	return txKeyPrefix + txID
+}
+
+// TxIDFromKey returns the ID of the cross-channel transaction whose record is stored at
+// the given key, or false if the key does not hold a record
+func TxIDFromKey(key string) (string, bool) {
+	if !strings.HasPrefix(key, txKeyPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(key, txKeyPrefix), true
+}
+
+// StagedKey returns the key that holds the writes staged by the cross-channel transaction with the given ID
+func StagedKey(txID string) string {
+	return stagedKeyPrefix + txID
+}
+
+// LockKey returns the key that holds the ID of the cross-channel transaction that locks the
+// given key of the given namespace. The chaincode names cannot contain a '/' hence the key is unique
+func LockKey(namespace, key string) string {
+	return lockKeyPrefix + namespace + "/" + key
+}
+
+// UnmarshalTransaction unmarshals the record of a cross-channel transaction
+func UnmarshalTransaction(b []byte) (*Transaction, error) {
+	tx := &Transaction{}
+	if err := proto.Unmarshal(b, tx); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling cross-channel transaction")
+	}
+	return tx, nil
+}
+
+// CheckStageable returns an error if the writes of the given read-write set cannot be staged by
+// a cross-channel transaction. Only the writes to the public state of the user chaincodes can be staged
+func CheckStageable(txRWSet *rwsetutil.TxRwSet) error {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if _, ok := systemNamespaces[nsRWSet.NameSpace]; ok && len(nsRWSet.KvRwSet.Writes) > 0 {
+			return errors.Errorf("writes to system chaincode namespace %s are not supported in cross-channel transactions", nsRWSet.NameSpace)
+		}
+		if len(nsRWSet.CollHashedRwSets) > 0 {
+			return errors.Errorf("private data is not supported in cross-channel transactions (namespace %s)", nsRWSet.NameSpace)
+		}
+		if len(nsRWSet.KvRwSet.MetadataWrites) > 0 {
+			return errors.Errorf("metadata writes are not supported in cross-channel transactions (namespace %s)", nsRWSet.NameSpace)
+		}
+		if nsRWSet.NameSpace == Namespace && len(nsRWSet.KvRwSet.Writes) > 0 {
+			return errors.New("nested cross-channel transactions are not supported")
+		}
+	}
+	return nil
+}
+
+// StagedRwSet returns the read-write set that the records of cross-channel transactions written by the
+// given read-write set embed for being staged on a participant channel, or nil if there is none. The
+// writes of the embedded read-write set are validated against the policies of their own namespaces
+func StagedRwSet(txRWSet *rwsetutil.TxRwSet) (*rwsetutil.TxRwSet, error) {
+	var staged *rwsetutil.TxRwSet
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != Namespace {
+			continue
+		}
+		for _, w := range nsRWSet.KvRwSet.Writes {
+			if _, ok := TxIDFromKey(w.Key); !ok || w.IsDelete {
+				continue
+			}
+			record, err := UnmarshalTransaction(w.Value)
+			if err != nil {
+				return nil, err
+			}
+			if len(record.Rwset) == 0 {
+				continue
+			}
+			embedded := &rwsetutil.TxRwSet{}
+			if err := embedded.FromProtoBytes(record.Rwset); err != nil {
+				return nil, errors.WithMessagef(err, "invalid read-write set of cross-channel transaction %s", record.Id)
+			}
+			if staged == nil {
+				staged = &rwsetutil.TxRwSet{}
+			}
+			staged.NsRwSets = append(staged.NsRwSets, embedded.NsRwSets...)
+		}
+	}
+	return staged, nil
+}
+
+// HasWrites returns true if the given read-write set writes to the public state of any namespace
+func HasWrites(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if len(nsRWSet.KvRwSet.Writes) > 0 || len(nsRWSet.KvRwSet.MetadataWrites) > 0 || len(nsRWSet.CollHashedRwSets) > 0 {
+			return true
+		}
+	}
+	return false
+}
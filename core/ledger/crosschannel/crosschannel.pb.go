@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: crosschannel.proto
+
+package crosschannel
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// State is the state of a cross-channel transaction
+type State int32
+
+const (
+	State_UNKNOWN   State = 0
+	State_PREPARED  State = 1
+	State_COMMITTED State = 2
+	State_ABORTED   State = 3
+)
+
+var State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PREPARED",
+	2: "COMMITTED",
+	3: "ABORTED",
+}
+
+var State_value = map[string]int32{
+	"UNKNOWN":   0,
+	"PREPARED":  1,
+	"COMMITTED": 2,
+	"ABORTED":   3,
+}
+
+func (x State) String() string {
+	return proto.EnumName(State_name, int32(x))
+}
+
+func (State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e9ca611f2634b8bc, []int{0}
+}
+
+// Transaction is the record of a cross-channel transaction that the xcc system
+// chaincode keeps on every channel that takes part in the transaction
+type Transaction struct {
+	// The ID of the transaction that started the cross-channel transaction on the coordinator channel
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The channel that coordinates the cross-channel transaction
+	Coordinator string `protobuf:"bytes,2,opt,name=coordinator,proto3" json:"coordinator,omitempty"`
+	// The channels, other than the coordinator, whose state is modified by the transaction
+	Participants []string `protobuf:"bytes,3,rep,name=participants,proto3" json:"participants,omitempty"`
+	// The serialized identity of the creator of the transaction
+	Creator []byte `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	// The state of the transaction on the channel the record belongs to
+	State State `protobuf:"varint,6,opt,name=state,proto3,enum=crosschannel.State" json:"state,omitempty"`
+	// The simulation results of each participant channel, only present on the coordinator channel
+	ParticipantRwsets []*ParticipantRwSet `protobuf:"bytes,7,rep,name=participant_rwsets,json=participantRwsets,proto3" json:"participant_rwsets,omitempty"`
+	// The marshaled rwset.TxReadWriteSet staged on a participant channel
+	Rwset []byte `protobuf:"bytes,8,opt,name=rwset,proto3" json:"rwset,omitempty"`
+	// The number of blocks of the coordinator channel, after the block that prepares the transaction
+	// on the coordinator channel, in which the transaction can be committed
+	TimeoutBlocks uint64 `protobuf:"varint,9,opt,name=timeout_blocks,json=timeoutBlocks,proto3" json:"timeout_blocks,omitempty"`
+	// The last block of the coordinator channel in which the transaction can be committed, set when
+	// the transaction is prepared on the coordinator channel. After this block, the transaction can
+	// no longer be committed and can be aborted by anyone
+	Deadline             uint64   `protobuf:"varint,10,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Transaction) Reset()         { *m = Transaction{} }
+func (m *Transaction) String() string { return proto.CompactTextString(m) }
+func (*Transaction) ProtoMessage()    {}
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ca611f2634b8bc, []int{0}
+}
+
+func (m *Transaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Transaction.Unmarshal(m, b)
+}
+func (m *Transaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Transaction.Marshal(b, m, deterministic)
+}
+func (m *Transaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Transaction.Merge(m, src)
+}
+func (m *Transaction) XXX_Size() int {
+	return xxx_messageInfo_Transaction.Size(m)
+}
+func (m *Transaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_Transaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Transaction proto.InternalMessageInfo
+
+func (m *Transaction) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Transaction) GetCoordinator() string {
+	if m != nil {
+		return m.Coordinator
+	}
+	return ""
+}
+
+func (m *Transaction) GetParticipants() []string {
+	if m != nil {
+		return m.Participants
+	}
+	return nil
+}
+
+func (m *Transaction) GetCreator() []byte {
+	if m != nil {
+		return m.Creator
+	}
+	return nil
+}
+
+func (m *Transaction) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State_UNKNOWN
+}
+
+func (m *Transaction) GetParticipantRwsets() []*ParticipantRwSet {
+	if m != nil {
+		return m.ParticipantRwsets
+	}
+	return nil
+}
+
+func (m *Transaction) GetRwset() []byte {
+	if m != nil {
+		return m.Rwset
+	}
+	return nil
+}
+
+func (m *Transaction) GetTimeoutBlocks() uint64 {
+	if m != nil {
+		return m.TimeoutBlocks
+	}
+	return 0
+}
+
+func (m *Transaction) GetDeadline() uint64 {
+	if m != nil {
+		return m.Deadline
+	}
+	return 0
+}
+
+// ParticipantRwSet holds the simulation results of a cross-channel transaction on a participant channel
+type ParticipantRwSet struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// The marshaled rwset.TxReadWriteSet
+	Rwset                []byte   `protobuf:"bytes,2,opt,name=rwset,proto3" json:"rwset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParticipantRwSet) Reset()         { *m = ParticipantRwSet{} }
+func (m *ParticipantRwSet) String() string { return proto.CompactTextString(m) }
+func (*ParticipantRwSet) ProtoMessage()    {}
+func (*ParticipantRwSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e9ca611f2634b8bc, []int{1}
+}
+
+func (m *ParticipantRwSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParticipantRwSet.Unmarshal(m, b)
+}
+func (m *ParticipantRwSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParticipantRwSet.Marshal(b, m, deterministic)
+}
+func (m *ParticipantRwSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParticipantRwSet.Merge(m, src)
+}
+func (m *ParticipantRwSet) XXX_Size() int {
+	return xxx_messageInfo_ParticipantRwSet.Size(m)
+}
+func (m *ParticipantRwSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParticipantRwSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParticipantRwSet proto.InternalMessageInfo
+
+func (m *ParticipantRwSet) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ParticipantRwSet) GetRwset() []byte {
+	if m != nil {
+		return m.Rwset
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("crosschannel.State", State_name, State_value)
+	proto.RegisterType((*Transaction)(nil), "crosschannel.Transaction")
+	proto.RegisterType((*ParticipantRwSet)(nil), "crosschannel.ParticipantRwSet")
+}
+
+func init() { proto.RegisterFile("crosschannel.proto", fileDescriptor_e9ca611f2634b8bc) }
+
+var fileDescriptor_e9ca611f2634b8bc = []byte{
+	// 372 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x92, 0xdf, 0xab, 0xd3, 0x30,
+	0x1c, 0xc5, 0x6d, 0xbb, 0xde, 0xb5, 0xdf, 0xf6, 0x8e, 0x1a, 0x7d, 0x08, 0x3e, 0x48, 0x19, 0x08,
+	0xd5, 0x87, 0x15, 0xae, 0x20, 0x3e, 0x09, 0xb7, 0xde, 0x3d, 0xa8, 0xec, 0x07, 0xb9, 0x13, 0xc1,
+	0x97, 0x91, 0xa6, 0x71, 0x0b, 0x76, 0x4d, 0x49, 0x32, 0x86, 0xff, 0xa8, 0x7f, 0x8f, 0x34, 0xdb,
+	0x5c, 0x77, 0x1f, 0xcf, 0x27, 0xe7, 0x7b, 0x38, 0x1c, 0x02, 0x88, 0x29, 0xa9, 0x35, 0xdb, 0xd2,
+	0xa6, 0xe1, 0xf5, 0xa4, 0x55, 0xd2, 0x48, 0x14, 0xf7, 0xd9, 0xf8, 0xaf, 0x0b, 0xd1, 0x4a, 0xd1,
+	0x46, 0x53, 0x66, 0x84, 0x6c, 0xd0, 0x08, 0x5c, 0x51, 0x61, 0x27, 0x75, 0xb2, 0x90, 0xb8, 0xa2,
+	0x42, 0x29, 0x44, 0x4c, 0x4a, 0x55, 0x89, 0x86, 0x1a, 0xa9, 0xb0, 0x6b, 0x1f, 0xfa, 0x08, 0x8d,
+	0x21, 0x6e, 0xa9, 0x32, 0x82, 0x89, 0x96, 0x36, 0x46, 0x63, 0x2f, 0xf5, 0xb2, 0x90, 0x5c, 0x31,
+	0x84, 0x61, 0xc8, 0x14, 0xb7, 0x09, 0x7e, 0xea, 0x64, 0x31, 0x39, 0x4b, 0xf4, 0x16, 0x7c, 0x6d,
+	0xa8, 0xe1, 0xf8, 0x26, 0x75, 0xb2, 0xd1, 0xdd, 0x8b, 0xc9, 0x55, 0xe3, 0xc7, 0xee, 0x89, 0x1c,
+	0x1d, 0x68, 0x06, 0xa8, 0x17, 0xba, 0x56, 0x07, 0xcd, 0x8d, 0xc6, 0xc3, 0xd4, 0xcb, 0xa2, 0xbb,
+	0xd7, 0xd7, 0x77, 0xcb, 0x8b, 0x8f, 0x1c, 0x1e, 0xb9, 0x21, 0xcf, 0xdb, 0x3e, 0xe9, 0x0e, 0xd1,
+	0x4b, 0xf0, 0x6d, 0x04, 0x0e, 0x6c, 0xa3, 0xa3, 0x40, 0x6f, 0x60, 0x64, 0xc4, 0x8e, 0xcb, 0xbd,
+	0x59, 0x97, 0xb5, 0x64, 0xbf, 0x35, 0x0e, 0x53, 0x27, 0x1b, 0x90, 0xdb, 0x13, 0x2d, 0x2c, 0x44,
+	0xaf, 0x20, 0xa8, 0x38, 0xad, 0x6a, 0xd1, 0x70, 0x0c, 0xd6, 0xf0, 0x5f, 0x7f, 0x1d, 0x04, 0x83,
+	0xc4, 0x1f, 0x17, 0x90, 0x3c, 0x6d, 0x61, 0x67, 0x38, 0x36, 0x3c, 0x2d, 0x7c, 0x96, 0x97, 0x32,
+	0x6e, 0xaf, 0xcc, 0xbb, 0x4f, 0xe0, 0xdb, 0x05, 0x50, 0x04, 0xc3, 0xef, 0xf3, 0x6f, 0xf3, 0xc5,
+	0x8f, 0x79, 0xf2, 0x0c, 0xc5, 0x10, 0x2c, 0xc9, 0x74, 0x79, 0x4f, 0xa6, 0x0f, 0x89, 0x83, 0x6e,
+	0x21, 0xfc, 0xbc, 0x98, 0xcd, 0xbe, 0xac, 0x56, 0xd3, 0x87, 0xc4, 0xed, 0x9c, 0xf7, 0xc5, 0x82,
+	0x74, 0xc2, 0x2b, 0x3e, 0xfe, 0xfc, 0xb0, 0x11, 0x66, 0xbb, 0x2f, 0x27, 0x4c, 0xee, 0xf2, 0xed,
+	0x9f, 0x96, 0xab, 0x9a, 0x57, 0x1b, 0xae, 0xf2, 0x5f, 0xb4, 0x54, 0x82, 0xe5, 0x4c, 0x2a, 0x9e,
+	0x9f, 0x50, 0x7f, 0xc0, 0xf2, 0xc6, 0xfe, 0x95, 0xf7, 0xff, 0x06, 0x00, 0x19, 0x84, 0xee, 0x58,
+	0x41, 0x02, 0x00, 0x00,
+}
@@ -22,6 +22,8 @@ const (
 	MetadataPresenceIndicator
 	// SnapshotRequest maintains the information for snapshot requests
 	SnapshotRequest
+	// HistoryPruning maintains the progress of pruning the history db and the heights below which it is pruned
+	HistoryPruning
 )
 
 // Provider provides db handle to different bookkeepers
@@ -50,7 +52,7 @@ func (p *Provider) Close() {
 
 // Drop drops channel-specific data from the config history db
 func (p *Provider) Drop(ledgerID string) error {
-	for _, cat := range []Category{PvtdataExpiry, MetadataPresenceIndicator, SnapshotRequest} {
+	for _, cat := range []Category{PvtdataExpiry, MetadataPresenceIndicator, SnapshotRequest, HistoryPruning} {
 		if err := p.dbProvider.Drop(dbName(ledgerID, cat)); err != nil {
 			return err
 		}
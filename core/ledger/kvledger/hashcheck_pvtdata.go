@@ -14,12 +14,17 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
 // constructValidAndInvalidPvtData computes the valid pvt data and hash mismatch list
 // from a received pvt data list of old blocks.
-func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore) (
+func constructValidAndInvalidPvtData(
+	reconciledPvtdata []*ledger.ReconciledPvtdata,
+	blockStore *blkstorage.BlockStore,
+	pvtdataStore *pvtdatastorage.Store,
+) (
 	map[uint64][]*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	// for each block, for each transaction, retrieve the txEnvelope to
@@ -30,7 +35,7 @@ func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtda
 	var invalidPvtData []*ledger.PvtdataHashMismatch
 
 	for _, pvtdata := range reconciledPvtdata {
-		validData, invalidData, err := findValidAndInvalidPvtdata(pvtdata, blockStore)
+		validData, invalidData, err := findValidAndInvalidPvtdata(pvtdata, blockStore, pvtdataStore)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -42,7 +47,11 @@ func constructValidAndInvalidPvtData(reconciledPvtdata []*ledger.ReconciledPvtda
 	return validPvtData, invalidPvtData, nil
 }
 
-func findValidAndInvalidPvtdata(reconciledPvtdata *ledger.ReconciledPvtdata, blockStore *blkstorage.BlockStore) (
+func findValidAndInvalidPvtdata(
+	reconciledPvtdata *ledger.ReconciledPvtdata,
+	blockStore *blkstorage.BlockStore,
+	pvtdataStore *pvtdatastorage.Store,
+) (
 	[]*ledger.TxPvtData, []*ledger.PvtdataHashMismatch, error,
 ) {
 	var validPvtData []*ledger.TxPvtData
@@ -58,7 +67,7 @@ func findValidAndInvalidPvtdata(reconciledPvtdata *ledger.ReconciledPvtdata, blo
 		// (2) validate passed pvtData against the pvtData hash in the tx rwset.
 		logger.Debugf("Constructing valid and invalid pvtData using rwset of blockNum:[%d], txNum:[%d]",
 			reconciledPvtdata.BlockNum, txPvtData.SeqInBlock)
-		validData, invalidData := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum)
+		validData, invalidData := findValidAndInvalidTxPvtData(txPvtData, txRWSet, reconciledPvtdata.BlockNum, pvtdataStore)
 
 		// (3) append validData to validPvtDataPvt list of this block and
 		// invalidData to invalidPvtData list
@@ -89,7 +98,12 @@ func retrieveRwsetForTx(blkNum uint64, txNum uint64, blockStore *blkstorage.Bloc
 	return txRWSet, nil
 }
 
-func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetutil.TxRwSet, blkNum uint64) (
+func findValidAndInvalidTxPvtData(
+	txPvtData *ledger.TxPvtData,
+	txRWSet *rwsetutil.TxRwSet,
+	blkNum uint64,
+	pvtdataStore *pvtdatastorage.Store,
+) (
 	*ledger.TxPvtData, []*ledger.PvtdataHashMismatch,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
@@ -98,7 +112,7 @@ func findValidAndInvalidTxPvtData(txPvtData *ledger.TxPvtData, txRWSet *rwsetuti
 	// find valid and invalid pvt data
 	for _, nsRwset := range txPvtData.WriteSet.NsPvtRwset {
 		txNum := txPvtData.SeqInBlock
-		invalidData, invalidNsColl := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum)
+		invalidData, invalidNsColl := findInvalidNsPvtData(nsRwset, txRWSet, blkNum, txNum, pvtdataStore)
 		invalidPvtData = append(invalidPvtData, invalidData...)
 		toDeleteNsColl = append(toDeleteNsColl, invalidNsColl...)
 	}
@@ -144,7 +158,12 @@ type nsColl struct {
 	ns, coll string
 }
 
-func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.TxRwSet, blkNum, txNum uint64) (
+func findInvalidNsPvtData(
+	nsRwset *rwset.NsPvtReadWriteSet,
+	txRWSet *rwsetutil.TxRwSet,
+	blkNum, txNum uint64,
+	pvtdataStore *pvtdatastorage.Store,
+) (
 	[]*ledger.PvtdataHashMismatch, []*nsColl,
 ) {
 	var invalidPvtData []*ledger.PvtdataHashMismatch
@@ -161,7 +180,8 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 			continue
 		}
 
-		if !bytes.Equal(util.ComputeSHA256(collPvtRwset.Rwset), rwsetHash) {
+		if !bytes.Equal(util.ComputeSHA256(collPvtRwset.Rwset), rwsetHash) &&
+			!isTrimmedByPurge(collPvtRwset.Rwset, txRWSet, ns, coll, blkNum, txNum, pvtdataStore) {
 			invalidPvtData = append(invalidPvtData, &ledger.PvtdataHashMismatch{
 				BlockNum:     blkNum,
 				TxNum:        txNum,
@@ -173,3 +193,35 @@ func findInvalidNsPvtData(nsRwset *rwset.NsPvtReadWriteSet, txRWSet *rwsetutil.T
 	}
 	return invalidPvtData, invalidNsColl
 }
+
+// isTrimmedByPurge returns true if the pvt data matches the hashed rwset of the collection except
+// for the writes of the keys that were purged later, as is the case when the pvt data is reconciled
+// from a peer that has applied the purges already
+func isTrimmedByPurge(
+	collPvtRwset []byte,
+	txRWSet *rwsetutil.TxRwSet,
+	ns, coll string,
+	blkNum, txNum uint64,
+	pvtdataStore *pvtdatastorage.Store,
+) bool {
+	hashedRwSet := txRWSet.GetHashedRwSet(ns, coll)
+	if hashedRwSet == nil {
+		return false
+	}
+	trimmedKeyHashes, ok := rwsetutil.TrimmedKeyHashes(collPvtRwset, hashedRwSet)
+	if !ok {
+		return false
+	}
+	for _, keyHash := range trimmedKeyHashes {
+		purged, err := pvtdataStore.IsPurged(ns, coll, keyHash, blkNum, txNum)
+		if err != nil {
+			logger.Warningf("Error while checking whether the key [%x] of namespace: %s collection: %s is purged: %s",
+				keyHash, ns, coll, err)
+			return false
+		}
+		if !purged {
+			return false
+		}
+	}
+	return true
+}
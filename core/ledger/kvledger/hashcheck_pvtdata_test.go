@@ -135,7 +135,7 @@ func TestConstructValidInvalidBlocksPvtData(t *testing.T) {
 		},
 	}
 
-	blocksValidPvtData, hashMismatched, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore)
+	blocksValidPvtData, hashMismatched, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore, lg.(*kvLedger).pvtdataStore)
 	require.NoError(t, err)
 	require.Equal(t, len(expectedValidBlocksPvtData), len(blocksValidPvtData))
 	require.ElementsMatch(t, expectedValidBlocksPvtData[1], blocksValidPvtData[1])
@@ -164,7 +164,7 @@ func TestConstructValidInvalidBlocksPvtData(t *testing.T) {
 		},
 	}
 
-	blocksValidPvtData, hashMismatches, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore)
+	blocksValidPvtData, hashMismatches, err := constructValidAndInvalidPvtData(pvtdata, lg.(*kvLedger).blockStore, lg.(*kvLedger).pvtdataStore)
 	require.NoError(t, err)
 	require.Len(t, blocksValidPvtData, 0)
 
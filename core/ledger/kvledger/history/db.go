@@ -11,44 +11,92 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	protoutil "github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("history")
 
 // DBProvider provides handle to HistoryDB for a given channel
 type DBProvider struct {
-	leveldbProvider *leveldbhelper.Provider
+	leveldbProvider      *leveldbhelper.Provider
+	bookkeepingProvider  *bookkeeping.Provider
+	retentionConf        *ledger.HistoryRetentionConfig
+	prunedEntries        metrics.Counter
+	capabilitiesProvider ledger.ChannelCapabilitiesProvider
 }
 
-// NewDBProvider instantiates DBProvider
-func NewDBProvider(path string) (*DBProvider, error) {
+// NewDBProvider instantiates DBProvider. The history of the keys is pruned as per the
+// supplied retention config, and is retained entirely if the retention config is nil
+func NewDBProvider(
+	path string,
+	bookkeepingProvider *bookkeeping.Provider,
+	metricsProvider metrics.Provider,
+	retentionConf *ledger.HistoryRetentionConfig,
+	encryptor *encryption.Encryptor,
+	capabilitiesProvider ledger.ChannelCapabilitiesProvider,
+) (*DBProvider, error) {
 	logger.Debugf("constructing HistoryDBProvider dbPath=%s", path)
 	levelDBProvider, err := leveldbhelper.NewProvider(
 		&leveldbhelper.Conf{
 			DBPath:         path,
 			ExpectedFormat: dataformat.CurrentFormat,
+			Encryptor:      encryptor,
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 	return &DBProvider{
-		leveldbProvider: levelDBProvider,
+		leveldbProvider:      levelDBProvider,
+		bookkeepingProvider:  bookkeepingProvider,
+		retentionConf:        retentionConf,
+		prunedEntries:        metricsProvider.NewCounter(prunedEntriesOpts),
+		capabilitiesProvider: capabilitiesProvider,
 	}, nil
 }
 
-// GetDBHandle gets the handle to a named database
+// GetDBHandle gets the handle to a named database. If a retention config is supplied to the
+// provider, the pruning of the history is started and is stopped when the handle is closed
 func (p *DBProvider) GetDBHandle(name string) *DB {
-	return &DB{
-		levelDB: p.leveldbProvider.GetDBHandle(name),
-		name:    name,
+	db := &DB{
+		levelDB:              p.leveldbProvider.GetDBHandle(name),
+		bookkeeper:           p.bookkeepingProvider.GetDBHandle(name, bookkeeping.HistoryPruning),
+		name:                 name,
+		capabilitiesProvider: p.capabilitiesProvider,
 	}
+	if p.retentionConf != nil {
+		db.pruner = newPruner(name, db.levelDB, db.bookkeeper, p.retentionConf, p.prunedEntries)
+		db.pruner.start()
+	}
+	return db
+}
+
+// MarkStartingSavepoint sets the savepoint of the history db for a ledger that is being created
+// from a snapshot. The history for the keys is not available prior to this savepoint and the
+// history db starts to accumulate the history from the blocks that are committed after the snapshot
+func (p *DBProvider) MarkStartingSavepoint(name string, savepoint *version.Height) error {
+	db := p.leveldbProvider.GetDBHandle(name)
+	empty, err := db.IsEmpty()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.Errorf(
+			"history db for ledger [%s] is not empty. Bootstrapping from a snapshot requires an empty db",
+			name,
+		)
+	}
+	return db.Put(savePointKey, savepoint.ToBytes(), true)
 }
 
 // Close closes the underlying db
@@ -63,8 +111,11 @@ func (p *DBProvider) Drop(channelName string) error {
 
 // DB maintains and provides access to history data for a particular channel
 type DB struct {
-	levelDB *leveldbhelper.DBHandle
-	name    string
+	levelDB              *leveldbhelper.DBHandle
+	bookkeeper           *leveldbhelper.DBHandle
+	name                 string
+	pruner               *pruner
+	capabilitiesProvider ledger.ChannelCapabilitiesProvider
 }
 
 // Commit implements method in HistoryDB interface
@@ -118,9 +169,15 @@ func (d *DB) Commit(block *common.Block) error {
 			if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
 				return err
 			}
-			// add a history record for each write
+			// add a history record for each write. The writes staged by a cross-channel transaction
+			// are not applied by this transaction, and are not indexed when they are applied later on.
+			// The writes are staged only if the channel supports the cross-channel transactions
+			stagesWrites := d.crossChannelTransactions() && preparesCrossChannelTx(txRWSet)
 			for _, nsRWSet := range txRWSet.NsRwSets {
 				ns := nsRWSet.NameSpace
+				if stagesWrites && ns != crosschannel.Namespace {
+					continue
+				}
 
 				for _, kvWrite := range nsRWSet.KvRwSet.Writes {
 					dataKey := constructDataKey(ns, kvWrite.Key, blockNo, tranNo)
@@ -146,12 +203,45 @@ func (d *DB) Commit(block *common.Block) error {
 	}
 
 	logger.Debugf("Channel [%s]: Updates committed to history database for blockNo [%v]", d.name, blockNo)
+	if d.pruner != nil {
+		d.pruner.notifyBlockCommitted(blockNo)
+	}
 	return nil
 }
 
+func (d *DB) crossChannelTransactions() bool {
+	return d.capabilitiesProvider != nil && d.capabilitiesProvider.CrossChannelTransactions(d.name)
+}
+
+// preparesCrossChannelTx returns true if the transaction writes the PREPARED record of a cross-channel transaction
+func preparesCrossChannelTx(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != crosschannel.Namespace {
+			continue
+		}
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			if _, ok := crosschannel.TxIDFromKey(kvWrite.Key); !ok || kvWrite.IsDelete {
+				continue
+			}
+			record, err := crosschannel.UnmarshalTransaction(kvWrite.Value)
+			if err == nil && record.State == crosschannel.State_PREPARED {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // NewQueryExecutor implements method in HistoryDB interface
 func (d *DB) NewQueryExecutor(blockStore *blkstorage.BlockStore) (ledger.HistoryQueryExecutor, error) {
-	return &QueryExecutor{d.levelDB, blockStore}, nil
+	return &QueryExecutor{d.levelDB, d.bookkeeper, blockStore}, nil
+}
+
+// Close stops the pruning of the history, if it is in progress
+func (d *DB) Close() {
+	if d.pruner != nil {
+		d.pruner.stop()
+	}
 }
 
 // GetLastSavepoint implements returns the height till which the history is present in the db
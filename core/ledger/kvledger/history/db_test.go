@@ -9,6 +9,7 @@ package history
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"testing"
@@ -21,7 +22,10 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -94,6 +98,25 @@ func TestSavepoint(t *testing.T) {
 	require.Equal(t, uint64(3), blockNum)
 }
 
+func TestMarkStartingSavepoint(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	require.NoError(t, env.testHistoryDBProvider.MarkStartingSavepoint("ledger-from-snapshot", version.NewHeight(9, math.MaxUint64)))
+	db := env.testHistoryDBProvider.GetDBHandle("ledger-from-snapshot")
+	savepoint, err := db.GetLastSavepoint()
+	require.NoError(t, err)
+	require.Equal(t, version.NewHeight(9, math.MaxUint64), savepoint)
+
+	status, blockNum, err := db.ShouldRecover(9)
+	require.NoError(t, err)
+	require.False(t, status)
+	require.Equal(t, uint64(10), blockNum)
+
+	err = env.testHistoryDBProvider.MarkStartingSavepoint("ledger-from-snapshot", version.NewHeight(9, math.MaxUint64))
+	require.EqualError(t, err, "history db for ledger [ledger-from-snapshot] is not empty. Bootstrapping from a snapshot requires an empty db")
+}
+
 func TestHistory(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -250,6 +273,73 @@ func TestHistoryForInvalidTran(t *testing.T) {
 	require.Nil(t, kmod)
 }
 
+// TestHistoryForCrossChannelTran tests that the writes staged by a cross-channel transaction
+// are not indexed
+func TestHistoryForCrossChannelTran(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.Open(ledger1id)
+	require.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	require.NoError(t, store1.AddBlock(gb))
+	require.NoError(t, env.testHistoryDB.Commit(gb))
+
+	//block1 prepares a cross-channel transaction, whose writes are only staged
+	txid := util2.GenerateUUID()
+	simulator, _ := env.txmgr.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key7", []byte("value1"))
+	record := protoutil.MarshalOrPanic(&crosschannel.Transaction{Id: txid, State: crosschannel.State_PREPARED})
+	simulator.SetState(crosschannel.Namespace, crosschannel.TxKey(txid), record)
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	require.NoError(t, store1.AddBlock(block1))
+	require.NoError(t, env.testHistoryDB.Commit(block1))
+
+	qhistory, err := env.testHistoryDB.NewQueryExecutor(store1)
+	require.NoError(t, err, "Error upon NewQueryExecutor")
+	testutilVerifyResults(t, qhistory, "ns1", "key7", []string{})
+	testutilVerifyResults(t, qhistory, crosschannel.Namespace, crosschannel.TxKey(txid), []string{string(record)})
+}
+
+// TestHistoryForCrossChannelTranNotSupported tests that the writes of a transaction that records
+// a cross-channel transaction are indexed if the channel does not support the cross-channel transactions
+func TestHistoryForCrossChannelTranNotSupported(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	env.testHistoryDB.capabilitiesProvider = crossChannelSupported(false)
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.Open(ledger1id)
+	require.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	require.NoError(t, store1.AddBlock(gb))
+	require.NoError(t, env.testHistoryDB.Commit(gb))
+
+	txid := util2.GenerateUUID()
+	simulator, _ := env.txmgr.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key7", []byte("value1"))
+	record := protoutil.MarshalOrPanic(&crosschannel.Transaction{Id: txid, State: crosschannel.State_PREPARED})
+	simulator.SetState(crosschannel.Namespace, crosschannel.TxKey(txid), record)
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	require.NoError(t, store1.AddBlock(block1))
+	require.NoError(t, env.testHistoryDB.Commit(block1))
+
+	qhistory, err := env.testHistoryDB.NewQueryExecutor(store1)
+	require.NoError(t, err, "Error upon NewQueryExecutor")
+	testutilVerifyResults(t, qhistory, "ns1", "key7", []string{"value1"})
+}
+
 //TestGenesisBlockNoError tests that Genesis blocks are ignored by history processing
 // since we only persist history of chaincode key writes
 func TestGenesisBlockNoError(t *testing.T) {
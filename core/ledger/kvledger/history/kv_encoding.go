@@ -74,3 +74,26 @@ func (r *rangeScan) decodeBlockNumTranNum(dataKey dataKey) (uint64, uint64, erro
 	}
 	return blockNum, tranNum, nil
 }
+
+// decodeBlockNum returns the block number encoded in the given dataKey of the namespace ns
+func decodeBlockNum(ns string, dataKey dataKey) (uint64, error) {
+	keyLenAndKey := dataKey[len(ns)+len(compositeKeySep):]
+	keyLen, keyLenBytesConsumed, err := util.DecodeOrderPreservingVarUint64(keyLenAndKey)
+	if err != nil {
+		return 0, err
+	}
+	blockNumStart := uint64(keyLenBytesConsumed) + keyLen + uint64(len(compositeKeySep))
+	if blockNumStart >= uint64(len(keyLenAndKey)) {
+		return 0, errors.Errorf("invalid data key [%#v] for namespace [%s]", []byte(dataKey), ns)
+	}
+	blockNum, _, err := util.DecodeOrderPreservingVarUint64(keyLenAndKey[blockNumStart:])
+	return blockNum, err
+}
+
+// constructNamespaceRangeScan returns the range scan that covers the dataKeys of all the keys in the namespace ns
+func constructNamespaceRangeScan(ns string) *rangeScan {
+	return &rangeScan{
+		startKey: append([]byte(ns), compositeKeySep...),
+		endKey:   append([]byte(ns), compositeKeySep[0]+1),
+	}
+}
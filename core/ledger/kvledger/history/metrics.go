@@ -0,0 +1,18 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var prunedEntriesOpts = metrics.CounterOpts{
+	Namespace:    "ledger",
+	Subsystem:    "history",
+	Name:         "pruned_entries",
+	Help:         "Number of history db entries pruned as per the history retention policy.",
+	LabelNames:   []string{"channel", "namespace"},
+	StatsdFormat: "%{#fqname}.%{channel}.%{namespace}",
+}
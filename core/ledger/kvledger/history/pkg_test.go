@@ -13,7 +13,9 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
@@ -43,6 +45,14 @@ type levelDBLockBasedHistoryEnv struct {
 }
 
 func newTestHistoryEnv(t *testing.T) *levelDBLockBasedHistoryEnv {
+	return newTestHistoryEnvWithRetention(t, nil, &disabled.Provider{})
+}
+
+func newTestHistoryEnvWithRetention(
+	t *testing.T,
+	retentionConf *ledger.HistoryRetentionConfig,
+	metricsProvider metrics.Provider,
+) *levelDBLockBasedHistoryEnv {
 	testLedgerID := "TestLedger"
 
 	blockStorageTestEnv := newBlockStorageTestEnv(t)
@@ -70,7 +80,14 @@ func newTestHistoryEnv(t *testing.T) *levelDBLockBasedHistoryEnv {
 	txMgr, err := txmgr.NewLockBasedTxMgr(txmgrInitializer)
 
 	require.NoError(t, err)
-	testHistoryDBProvider, err := NewDBProvider(testHistoryDBPath)
+	testHistoryDBProvider, err := NewDBProvider(
+		testHistoryDBPath,
+		testBookkeepingEnv.TestProvider,
+		metricsProvider,
+		retentionConf,
+		nil,
+		crossChannelSupported(true),
+	)
 	require.NoError(t, err)
 	testHistoryDB := testHistoryDBProvider.GetDBHandle("TestHistoryDB")
 
@@ -88,6 +105,7 @@ func newTestHistoryEnv(t *testing.T) *levelDBLockBasedHistoryEnv {
 
 func (env *levelDBLockBasedHistoryEnv) cleanup() {
 	env.txmgr.Shutdown()
+	env.testHistoryDB.Close()
 	env.testDBEnv.Cleanup()
 	env.testBlockStorageEnv.cleanup()
 	env.testBookkeepingEnv.Cleanup()
@@ -134,3 +152,9 @@ func (env *testBlockStoreEnv) removeFSPath() {
 	fsPath := env.blockStorageDir
 	os.RemoveAll(fsPath)
 }
+
+type crossChannelSupported bool
+
+func (c crossChannelSupported) CrossChannelTransactions(channelName string) bool {
+	return bool(c)
+}
@@ -0,0 +1,335 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultPruneInterval is the number of blocks between two pruning runs, if not configured
+	defaultPruneInterval = 1000
+	// maxPruneBatchSize is the maximum number of history entries that are deleted in a single db batch
+	maxPruneBatchSize = 10000
+)
+
+var (
+	// prunedBelowKeyPrefix is the prefix of the bookkeeping keys that maintain, for a namespace, the block
+	// number below which the history entries are pruned. The key is written before the entries are deleted
+	prunedBelowKeyPrefix = []byte{'p'}
+	// pruneJobKey is the bookkeeping key that maintains the namespace being pruned and the target block number
+	// so that a pruning job that is interrupted by a crash or a shutdown is completed on the next run
+	pruneJobKey = []byte{'j'}
+)
+
+// retentionPolicy is the history retention policy applicable to a channel
+type retentionPolicy struct {
+	channelPolicy     ledger.HistoryRetentionPolicy
+	namespacePolicies map[string]ledger.HistoryRetentionPolicy
+}
+
+func newRetentionPolicy(channel string, conf *ledger.HistoryRetentionConfig) *retentionPolicy {
+	p := &retentionPolicy{
+		channelPolicy: conf.Default,
+	}
+	for _, c := range conf.Channels {
+		if c.Name == channel {
+			p.channelPolicy = c.Policy
+			p.namespacePolicies = c.Namespaces
+		}
+	}
+	return p
+}
+
+// pruneBelow returns the block number below which the history entries of the namespace are to be
+// pruned, when the height of the channel is the given height
+func (p *retentionPolicy) pruneBelow(ns string, height uint64) uint64 {
+	policy, ok := p.namespacePolicies[ns]
+	if !ok {
+		policy = p.channelPolicy
+	}
+	pruneBelow := policy.PruneBelowHeight
+	if policy.RetainBlocks > 0 && height > policy.RetainBlocks && height-policy.RetainBlocks > pruneBelow {
+		pruneBelow = height - policy.RetainBlocks
+	}
+	if pruneBelow > height {
+		pruneBelow = height
+	}
+	return pruneBelow
+}
+
+// pruner prunes, in the background, the history entries of a channel as per its retention policy
+type pruner struct {
+	ledgerID      string
+	levelDB       *leveldbhelper.DBHandle
+	bookkeeper    *leveldbhelper.DBHandle
+	policy        *retentionPolicy
+	pruneInterval uint64
+	prunedEntries metrics.Counter
+
+	trigger  chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newPruner(
+	ledgerID string,
+	levelDB, bookkeeper *leveldbhelper.DBHandle,
+	conf *ledger.HistoryRetentionConfig,
+	prunedEntries metrics.Counter,
+) *pruner {
+	pruneInterval := conf.PruneInterval
+	if pruneInterval == 0 {
+		pruneInterval = defaultPruneInterval
+	}
+	return &pruner{
+		ledgerID:      ledgerID,
+		levelDB:       levelDB,
+		bookkeeper:    bookkeeper,
+		policy:        newRetentionPolicy(ledgerID, conf),
+		pruneInterval: pruneInterval,
+		prunedEntries: prunedEntries,
+		trigger:       make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+// start launches the background pruning, that runs once on start and then
+// every time a block at the prune interval is committed
+func (p *pruner) start() {
+	p.triggerPruning()
+	go func() {
+		defer close(p.stopped)
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-p.trigger:
+				if err := p.prune(); err != nil {
+					logger.Errorf("Channel [%s]: Error while pruning history database: %+v", p.ledgerID, err)
+				}
+			}
+		}
+	}()
+}
+
+func (p *pruner) notifyBlockCommitted(blockNum uint64) {
+	if blockNum%p.pruneInterval == 0 {
+		p.triggerPruning()
+	}
+}
+
+func (p *pruner) triggerPruning() {
+	select {
+	case p.trigger <- struct{}{}:
+	default:
+		// a pruning run is already pending
+	}
+}
+
+// stop stops the background pruning and waits for an ongoing pruning run to be interrupted
+func (p *pruner) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+		<-p.stopped
+	})
+}
+
+func (p *pruner) stopping() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// prune completes an interrupted pruning job, if any, and then prunes the history entries of each
+// of the namespaces up to the block number derived from the retention policy of the namespace
+func (p *pruner) prune() error {
+	savepointBytes, err := p.levelDB.Get(savePointKey)
+	if err != nil || savepointBytes == nil {
+		return err
+	}
+	savepoint, _, err := version.NewHeightFromBytes(savepointBytes)
+	if err != nil {
+		return errors.WithMessage(err, "error while decoding the savepoint of the history db")
+	}
+	height := savepoint.BlockNum + 1
+
+	ns, pruneBelow, err := p.retrievePruneJob()
+	if err != nil {
+		return err
+	}
+	if ns != nil {
+		logger.Infof("Channel [%s]: Resuming the pruning of history entries of namespace [%s] below block [%d]", p.ledgerID, *ns, pruneBelow)
+		if err := p.pruneNamespace(*ns, pruneBelow); err != nil {
+			return err
+		}
+	}
+
+	namespaces, err := p.namespaces()
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		if p.stopping() {
+			return nil
+		}
+		prunedBelow, err := p.prunedBelow(ns)
+		if err != nil {
+			return err
+		}
+		pruneBelow := p.policy.pruneBelow(ns, height)
+		if pruneBelow <= prunedBelow {
+			continue
+		}
+		if err := p.recordPruneJob(ns, pruneBelow); err != nil {
+			return err
+		}
+		logger.Infof("Channel [%s]: Pruning history entries of namespace [%s] below block [%d]", p.ledgerID, ns, pruneBelow)
+		if err := p.pruneNamespace(ns, pruneBelow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneNamespace deletes the history entries of the namespace that are below the given block number and
+// removes the prune job on completion. The pruning stops, leaving the prune job in place, if the pruner is stopped
+func (p *pruner) pruneNamespace(ns string, pruneBelow uint64) error {
+	rangeScan := constructNamespaceRangeScan(ns)
+	itr, err := p.levelDB.GetIterator(rangeScan.startKey, rangeScan.endKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	batch := p.levelDB.NewUpdateBatch()
+	commitBatch := func() error {
+		numEntries := batch.Len()
+		if numEntries == 0 {
+			return nil
+		}
+		if err := p.levelDB.WriteBatch(batch, true); err != nil {
+			return err
+		}
+		p.prunedEntries.With("channel", p.ledgerID, "namespace", ns).Add(float64(numEntries))
+		batch = p.levelDB.NewUpdateBatch()
+		return nil
+	}
+
+	for itr.Next() {
+		k := itr.Key()
+		blockNum, err := decodeBlockNum(ns, k)
+		if err != nil {
+			return err
+		}
+		if blockNum >= pruneBelow {
+			continue
+		}
+		batch.Delete(k)
+		if batch.Len() < maxPruneBatchSize {
+			continue
+		}
+		if err := commitBatch(); err != nil {
+			return err
+		}
+		if p.stopping() {
+			return nil
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while iterating history entries of namespace [%s]", ns)
+	}
+	if err := commitBatch(); err != nil {
+		return err
+	}
+	return p.bookkeeper.Delete(pruneJobKey, true)
+}
+
+// namespaces returns the namespaces that have entries in the history db
+func (p *pruner) namespaces() ([]string, error) {
+	itr, err := p.levelDB.GetIterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	var namespaces []string
+	for ok := itr.Next(); ok; {
+		k := itr.Key()
+		sepIndex := bytes.Index(k, compositeKeySep)
+		if sepIndex < 0 {
+			// not a data key, e.g. the savepoint key
+			ok = itr.Next()
+			continue
+		}
+		ns := string(k[:sepIndex])
+		namespaces = append(namespaces, ns)
+		ok = itr.Seek(constructNamespaceRangeScan(ns).endKey)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "internal leveldb error while iterating history db")
+	}
+	return namespaces, nil
+}
+
+// recordPruneJob records, along with the block number below which the history of the namespace is
+// pruned, the prune job in the bookkeeping db before any of the history entries are deleted
+func (p *pruner) recordPruneJob(ns string, pruneBelow uint64) error {
+	batch := p.bookkeeper.NewUpdateBatch()
+	batch.Put(pruneJobKey, append(util.EncodeOrderPreservingVarUint64(pruneBelow), []byte(ns)...))
+	batch.Put(constructPrunedBelowKey(ns), util.EncodeOrderPreservingVarUint64(pruneBelow))
+	return p.bookkeeper.WriteBatch(batch, true)
+}
+
+// retrievePruneJob returns the namespace and the block number of the pruning job that is not yet
+// completed. The returned namespace is nil if there is no such job
+func (p *pruner) retrievePruneJob() (*string, uint64, error) {
+	v, err := p.bookkeeper.Get(pruneJobKey)
+	if err != nil || v == nil {
+		return nil, 0, err
+	}
+	pruneBelow, n, err := util.DecodeOrderPreservingVarUint64(v)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "error while decoding the history prune job")
+	}
+	ns := string(v[n:])
+	return &ns, pruneBelow, nil
+}
+
+func (p *pruner) prunedBelow(ns string) (uint64, error) {
+	return retrievePrunedBelow(p.bookkeeper, ns)
+}
+
+// retrievePrunedBelow returns the block number below which the history entries of the namespace are pruned
+func retrievePrunedBelow(bookkeeper *leveldbhelper.DBHandle, ns string) (uint64, error) {
+	v, err := bookkeeper.Get(constructPrunedBelowKey(ns))
+	if err != nil || v == nil {
+		return 0, err
+	}
+	prunedBelow, _, err := util.DecodeOrderPreservingVarUint64(v)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while decoding the pruned height of namespace [%s]", ns)
+	}
+	return prunedBelow, nil
+}
+
+func constructPrunedBelowKey(ns string) []byte {
+	return append(append([]byte{}, prunedBelowKeyPrefix...), []byte(ns)...)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package history
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	util2 "github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicy(t *testing.T) {
+	conf := &ledger.HistoryRetentionConfig{
+		Default: ledger.HistoryRetentionPolicy{RetainBlocks: 100},
+		Channels: []*ledger.ChannelHistoryRetentionConfig{
+			{
+				Name:   "ch1",
+				Policy: ledger.HistoryRetentionPolicy{PruneBelowHeight: 50},
+				Namespaces: map[string]ledger.HistoryRetentionPolicy{
+					"ns1": {RetainBlocks: 10, PruneBelowHeight: 50},
+					"ns2": {},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		channel, ns        string
+		height, pruneBelow uint64
+	}{
+		{channel: "ch2", ns: "ns1", height: 100, pruneBelow: 0},
+		{channel: "ch2", ns: "ns1", height: 150, pruneBelow: 50},
+		{channel: "ch1", ns: "ns3", height: 20, pruneBelow: 20},
+		{channel: "ch1", ns: "ns3", height: 150, pruneBelow: 50},
+		{channel: "ch1", ns: "ns1", height: 55, pruneBelow: 50},
+		{channel: "ch1", ns: "ns1", height: 150, pruneBelow: 140},
+		{channel: "ch1", ns: "ns2", height: 150, pruneBelow: 0},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s-%s-%d", tc.channel, tc.ns, tc.height), func(t *testing.T) {
+			p := newRetentionPolicy(tc.channel, conf)
+			require.Equal(t, tc.pruneBelow, p.pruneBelow(tc.ns, tc.height))
+		})
+	}
+}
+
+func TestHistoryPruning(t *testing.T) {
+	metricsProvider := &metricsfakes.Provider{}
+	prunedEntries := &metricsfakes.Counter{}
+	prunedEntries.WithReturns(prunedEntries)
+	metricsProvider.NewCounterReturns(prunedEntries)
+	env := newTestHistoryEnvWithRetention(t,
+		&ledger.HistoryRetentionConfig{
+			Default: ledger.HistoryRetentionPolicy{RetainBlocks: 4},
+			Channels: []*ledger.ChannelHistoryRetentionConfig{
+				{
+					Name: "ledger1",
+					Policy: ledger.HistoryRetentionPolicy{
+						RetainBlocks: 4,
+					},
+					Namespaces: map[string]ledger.HistoryRetentionPolicy{
+						"ns2": {PruneBelowHeight: 3},
+					},
+				},
+			},
+			PruneInterval: 5,
+		},
+		metricsProvider,
+	)
+	defer env.cleanup()
+	store, err := env.testBlockStorageEnv.provider.Open("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	historyDB := env.testHistoryDBProvider.GetDBHandle("ledger1")
+	defer historyDB.Close()
+
+	// blocks 1 to 10 update the key in the namespaces ns1 and ns2 and the
+	// commit of the block 10 prunes the history below the blocks 7 and 3 respectively
+	commitBlocksUpdatingKeys(t, env, store, historyDB, "ledger1", 10, "ns1", "ns2")
+	numPruned := func() float64 {
+		var n float64
+		for i := 0; i < prunedEntries.AddCallCount(); i++ {
+			n += prunedEntries.AddArgsForCall(i)
+		}
+		return n
+	}
+	require.Eventually(t, func() bool {
+		job, err := historyDB.bookkeeper.Get(pruneJobKey)
+		require.NoError(t, err)
+		return numPruned() == 8 && job == nil
+	}, time.Minute, 10*time.Millisecond)
+
+	qe, err := historyDB.NewQueryExecutor(store)
+	require.NoError(t, err)
+	testutilVerifyResults(t, qe, "ns1", "key1", []string{"value10", "value9", "value8", "value7"})
+	testutilVerifyPrunedBelow(t, qe, "ns1", "key1", 7, true)
+	testutilVerifyResults(t, qe, "ns2", "key1", []string{"value10", "value9", "value8", "value7", "value6", "value5", "value4", "value3"})
+	testutilVerifyPrunedBelow(t, qe, "ns2", "key1", 3, true)
+	testutilVerifyPrunedBelow(t, qe, "ns3", "key1", 0, false)
+
+	for i := 0; i < prunedEntries.WithCallCount(); i++ {
+		require.Contains(t, [][]string{
+			{"channel", "ledger1", "namespace", "ns1"},
+			{"channel", "ledger1", "namespace", "ns2"},
+		}, prunedEntries.WithArgsForCall(i))
+	}
+}
+
+func TestHistoryPruningResumesInterruptedJob(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	store, err := env.testBlockStorageEnv.provider.Open("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	historyDB := env.testHistoryDBProvider.GetDBHandle("ledger1")
+	commitBlocksUpdatingKeys(t, env, store, historyDB, "ledger1", 5, "ns1", "ns2")
+
+	p := newPruner("ledger1", historyDB.levelDB, historyDB.bookkeeper,
+		&ledger.HistoryRetentionConfig{}, (&disabled.Provider{}).NewCounter(prunedEntriesOpts))
+	namespaces, err := p.namespaces()
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns1", "ns2"}, namespaces)
+
+	// a job recorded before a crash is completed by the next pruning run,
+	// even though the retention policy does not prune any history
+	require.NoError(t, p.recordPruneJob("ns1", 3))
+	require.NoError(t, p.prune())
+	job, err := historyDB.bookkeeper.Get(pruneJobKey)
+	require.NoError(t, err)
+	require.Nil(t, job)
+
+	qe, err := historyDB.NewQueryExecutor(store)
+	require.NoError(t, err)
+	testutilVerifyResults(t, qe, "ns1", "key1", []string{"value5", "value4", "value3"})
+	testutilVerifyPrunedBelow(t, qe, "ns1", "key1", 3, true)
+	testutilVerifyResults(t, qe, "ns2", "key1", []string{"value5", "value4", "value3", "value2", "value1"})
+	testutilVerifyPrunedBelow(t, qe, "ns2", "key1", 0, false)
+}
+
+// commitBlocksUpdatingKeys commits the genesis block followed by the given number of blocks,
+// each of which sets the key 'key1' in the given namespaces to the value 'value<blockNum>'
+func commitBlocksUpdatingKeys(
+	t *testing.T,
+	env *levelDBLockBasedHistoryEnv,
+	store *blkstorage.BlockStore,
+	historyDB *DB,
+	ledgerID string,
+	numBlocks int,
+	namespaces ...string,
+) {
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	blocks := []*common.Block{gb}
+	for i := 1; i <= numBlocks; i++ {
+		simulator, err := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		require.NoError(t, err)
+		for _, ns := range namespaces {
+			require.NoError(t, simulator.SetState(ns, "key1", []byte(fmt.Sprintf("value%d", i))))
+		}
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		blocks = append(blocks, bg.NextBlock([][]byte{pubSimResBytes}))
+	}
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+		require.NoError(t, historyDB.Commit(b))
+	}
+}
+
+func testutilVerifyPrunedBelow(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, expectedPrunedBelow uint64, expectedPruned bool) {
+	itr, err := hqe.GetHistoryForKey(ns, key)
+	require.NoError(t, err)
+	defer itr.Close()
+	prunedBelow, pruned := itr.(ledger.PrunedHistoryIndicator).HistoryPrunedBelow()
+	require.Equal(t, expectedPrunedBelow, prunedBelow)
+	require.Equal(t, expectedPruned, pruned)
+}
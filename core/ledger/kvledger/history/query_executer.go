@@ -21,11 +21,16 @@ import (
 // QueryExecutor is a query executor against the LevelDB history DB
 type QueryExecutor struct {
 	levelDB    *leveldbhelper.DBHandle
+	bookkeeper *leveldbhelper.DBHandle
 	blockStore *blkstorage.BlockStore
 }
 
 // GetHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
 func (q *QueryExecutor) GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error) {
+	prunedBelow, err := retrievePrunedBelow(q.bookkeeper, namespace)
+	if err != nil {
+		return nil, err
+	}
 	rangeScan := constructRangeScan(namespace, key)
 	dbItr, err := q.levelDB.GetIterator(rangeScan.startKey, rangeScan.endKey)
 	if err != nil {
@@ -38,16 +43,17 @@ func (q *QueryExecutor) GetHistoryForKey(namespace string, key string) (commonle
 	if dbItr.Last() {
 		dbItr.Next()
 	}
-	return &historyScanner{rangeScan, namespace, key, dbItr, q.blockStore}, nil
+	return &historyScanner{rangeScan, namespace, key, dbItr, q.blockStore, prunedBelow}, nil
 }
 
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
-	rangeScan  *rangeScan
-	namespace  string
-	key        string
-	dbItr      iterator.Iterator
-	blockStore *blkstorage.BlockStore
+	rangeScan   *rangeScan
+	namespace   string
+	key         string
+	dbItr       iterator.Iterator
+	blockStore  *blkstorage.BlockStore
+	prunedBelow uint64
 }
 
 // Next iterates to the next key, in the order of newest to oldest, from history scanner.
@@ -92,6 +98,11 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// HistoryPrunedBelow implements method in interface `ledger.PrunedHistoryIndicator`
+func (scanner *historyScanner) HistoryPrunedBelow() (uint64, bool) {
+	return scanner.prunedBelow, scanner.prunedBelow > 0
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran %s:%s", namespace, key)
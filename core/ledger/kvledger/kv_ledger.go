@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validation"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -57,6 +59,9 @@ type kvLedger struct {
 	commitHash             []byte
 	hashProvider           ledger.HashProvider
 	config                 *ledger.Config
+	// bootSnapshotMetadata is nil if the ledger was created from a genesis block
+	bootSnapshotMetadata *snapshotMetadata
+	snapshotMgr          *snapshotMgr
 	// isPvtDataStoreAheadOfBlockStore is read during missing pvtData
 	// reconciliation and may be updated during a regular block commit.
 	// Hence, we use atomic value to ensure consistent read.
@@ -73,25 +78,29 @@ type lgrInitializer struct {
 	stateListeners           []ledger.StateListener
 	bookkeeperProvider       *bookkeeping.Provider
 	ccInfoProvider           ledger.DeployedChaincodeInfoProvider
+	capabilitiesProvider     ledger.ChannelCapabilitiesProvider
 	ccLifecycleEventProvider ledger.ChaincodeLifecycleEventProvider
 	stats                    *ledgerStats
 	customTxProcessors       map[common.HeaderType]ledger.CustomTxProcessor
 	hashProvider             ledger.HashProvider
 	config                   *ledger.Config
+	bootSnapshotMetadata     *snapshotMetadata
 }
 
 func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	ledgerID := initializer.ledgerID
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	l := &kvLedger{
-		ledgerID:            ledgerID,
-		blockStore:          initializer.blockStore,
-		pvtdataStore:        initializer.pvtdataStore,
-		historyDB:           initializer.historyDB,
-		bookkeepingProvider: initializer.bookkeeperProvider,
-		hashProvider:        initializer.hashProvider,
-		config:              initializer.config,
-		blockAPIsRWLock:     &sync.RWMutex{},
+		ledgerID:             ledgerID,
+		blockStore:           initializer.blockStore,
+		pvtdataStore:         initializer.pvtdataStore,
+		historyDB:            initializer.historyDB,
+		bookkeepingProvider:  initializer.bookkeeperProvider,
+		hashProvider:         initializer.hashProvider,
+		config:               initializer.config,
+		bootSnapshotMetadata: initializer.bootSnapshotMetadata,
+		blockAPIsRWLock:      &sync.RWMutex{},
+		snapshotMgr:          newSnapshotMgr(ledgerID, initializer.bookkeeperProvider),
 	}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, initializer.ccInfoProvider})
@@ -116,6 +125,8 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		CCInfoProvider:      initializer.ccInfoProvider,
 		CustomTxProcessors:  initializer.customTxProcessors,
 		HashFunc:            rwsetHashFunc,
+		PurgeChecker:        initializer.pvtdataStore,
+		CrossChannelChecker: &crossChannelChecker{ledgerID, initializer.capabilitiesProvider},
 	}
 	if err := l.initTxMgr(txmgrInitializer); err != nil {
 		return nil, err
@@ -143,8 +154,13 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	ccEventListener := initializer.stateDB.GetChaincodeEventListener()
 	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
 	if ccEventListener != nil {
-		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
-		initializer.ccLifecycleEventProvider.RegisterListener(ledgerID, &ccEventListenerAdaptor{ccEventListener})
+		// the sources of the chaincode events may not be set up when the ledger is used outside of the peer
+		if ccEventMgr := cceventmgmt.GetMgr(); ccEventMgr != nil {
+			ccEventMgr.Register(ledgerID, ccEventListener)
+		}
+		if initializer.ccLifecycleEventProvider != nil {
+			initializer.ccLifecycleEventProvider.RegisterListener(ledgerID, &ccEventListenerAdaptor{ccEventListener})
+		}
 	}
 
 	//Recover both state DB and history DB if they are out of sync with block storage
@@ -153,6 +169,19 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 	}
 	l.configHistoryRetriever = initializer.configHistoryMgr.GetRetriever(ledgerID, l)
 
+	// Generate the snapshot, if one was requested at the current height and the peer
+	// crashed before finishing the snapshot generation
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	l.snapshotMgr.Lock()
+	err = l.processSnapshotRequestAtHeight(bcInfo.Height)
+	l.snapshotMgr.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
 	l.stats = initializer.stats
 	return l, nil
 }
@@ -189,6 +218,18 @@ func (l *kvLedger) lastPersistedCommitHash() ([]byte, error) {
 		return nil, nil
 	}
 
+	if l.bootSnapshotMetadata != nil && bcInfo.Height == l.bootSnapshotMetadata.ChannelHeight {
+		logger.Debugf("No blocks committed after the boot snapshot. Retrieving the commit hash from the snapshot metadata")
+		commitHash, err := hex.DecodeString(l.bootSnapshotMetadata.LastBlockCommitHashInHex)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while decoding last block commit hash from the snapshot metadata")
+		}
+		if len(commitHash) == 0 {
+			return nil, nil
+		}
+		return commitHash, nil
+	}
+
 	logger.Debugf("Fetching block [%d] to retrieve the currentCommitHash", bcInfo.Height-1)
 	block, err := l.GetBlockByNumber(bcInfo.Height - 1)
 	if err != nil {
@@ -443,8 +484,24 @@ func (l *kvLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error
 	return nil, nil
 }
 
-// CommitLegacy commits the block and the corresponding pvt data in an atomic operation
+// CommitLegacy commits the block and the corresponding pvt data in an atomic operation.
+// If a snapshot is requested at the height after this block, the snapshot generation is
+// started and the commit of the next block waits for the snapshot generation to finish
 func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitOpts *ledger.CommitOptions) error {
+	l.snapshotMgr.Lock()
+	defer l.snapshotMgr.Unlock()
+	l.snapshotMgr.waitForSnapshotGeneration()
+
+	if err := l.commit(pvtdataAndBlock, commitOpts); err != nil {
+		return err
+	}
+	if err := l.processSnapshotRequestAtHeight(pvtdataAndBlock.Block.Header.Number + 1); err != nil {
+		logger.Errorw("Failed to process snapshot request", "channel", l.ledgerID, "error", err)
+	}
+	return nil
+}
+
+func (l *kvLedger) commit(pvtdataAndBlock *ledger.BlockAndPvtData, commitOpts *ledger.CommitOptions) error {
 	var err error
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
@@ -547,7 +604,8 @@ func (l *kvLedger) commitToPvtAndBlockStore(blockAndPvtdata *ledger.BlockAndPvtD
 		// too in the pvtdataStore as we do for the publicdata in the case of blockStore.
 		// Hence, we pass all pvtData present in the block to the pvtdataStore committer.
 		pvtData, missingPvtData := constructPvtDataAndMissingData(blockAndPvtdata)
-		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData); err != nil {
+		purgeMarkers := constructPurgeMarkers(blockAndPvtdata.Block)
+		if err := l.pvtdataStore.Commit(blockNum, pvtData, missingPvtData, purgeMarkers); err != nil {
 			return err
 		}
 	} else {
@@ -675,7 +733,7 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(reconciledPvtdata []*ledger.Reconcil
 	logger.Debugf("[%s:] Comparing pvtData of [%d] old blocks against the hashes in transaction's rwset to find valid and invalid data",
 		l.ledgerID, len(reconciledPvtdata))
 
-	hashVerifiedPvtData, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, l.blockStore)
+	hashVerifiedPvtData, hashMismatches, err := constructValidAndInvalidPvtData(reconciledPvtdata, l.blockStore, l.pvtdataStore)
 	if err != nil {
 		return nil, err
 	}
@@ -721,8 +779,14 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	l.snapshotMgr.Lock()
+	l.snapshotMgr.waitForSnapshotGeneration()
+	l.snapshotMgr.Unlock()
 	l.blockStore.Shutdown()
 	l.txmgr.Shutdown()
+	if l.historyDB != nil {
+		l.historyDB.Close()
+	}
 }
 
 type blocksItr struct {
@@ -759,6 +823,15 @@ func (r *collectionInfoRetriever) CollectionInfo(chaincodeName, collectionName s
 	return r.infoProvider.CollectionInfo(r.ledgerID, chaincodeName, collectionName, qe)
 }
 
+type crossChannelChecker struct {
+	ledgerID             string
+	capabilitiesProvider ledger.ChannelCapabilitiesProvider
+}
+
+func (c *crossChannelChecker) CrossChannelTransactions() bool {
+	return c.capabilitiesProvider != nil && c.capabilitiesProvider.CrossChannelTransactions(c.ledgerID)
+}
+
 type ccEventListenerAdaptor struct {
 	legacyEventListener cceventmgmt.ChaincodeLifecycleEventListener
 }
@@ -835,3 +908,70 @@ func constructPvtDataAndMissingData(blockAndPvtData *ledger.BlockAndPvtData) ([]
 	}
 	return pvtData, missingPvtData
 }
+
+// constructPurgeMarkers returns the private data keys that are purged by the valid transactions in the block.
+// The validation flags in the block are expected to be set already
+func constructPurgeMarkers(block *common.Block) []*pvtdatastorage.PurgeMarker {
+	txsFilter := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	var purgeMarkers []*pvtdatastorage.PurgeMarker
+	for txNum, envBytes := range block.Data.Data {
+		if !txsFilter.IsValid(txNum) {
+			continue
+		}
+		txRWSet, err := endorserTxRWSet(envBytes)
+		if err != nil {
+			logger.Debugf("Skipping tx [%d] of block [%d] while looking for purged keys: %s", txNum, block.Header.Number, err)
+			continue
+		}
+		if txRWSet == nil {
+			continue
+		}
+		for _, nsRwSet := range txRWSet.NsRwSets {
+			for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+				for _, hashedWrite := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+					if !collHashedRwSet.IsPurge(hashedWrite) {
+						continue
+					}
+					purgeMarkers = append(purgeMarkers, &pvtdatastorage.PurgeMarker{
+						Namespace:  nsRwSet.NameSpace,
+						Collection: collHashedRwSet.CollectionName,
+						KeyHash:    hashedWrite.KeyHash,
+						TxNum:      uint64(txNum),
+					})
+				}
+			}
+		}
+	}
+	return purgeMarkers
+}
+
+// endorserTxRWSet returns the rwset of an endorser transaction and nil for the other transaction types
+func endorserTxRWSet(envBytes []byte) (*rwsetutil.TxRwSet, error) {
+	env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is nil")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+		return nil, nil
+	}
+	responsePayload, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil, err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(responsePayload.Results); err != nil {
+		return nil, err
+	}
+	return txRWSet, nil
+}
@@ -8,7 +8,9 @@ package kvledger
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"os"
 	"path"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/msgs"
@@ -41,6 +44,8 @@ var (
 	ErrInactiveLedger = errors.New("Ledger is not active")
 
 	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
+	// underConstructionSnapshotLedgerKey is set while a ledger is being created from a snapshot
+	underConstructionSnapshotLedgerKey = []byte("underConstructionSnapshotLedgerKey")
 	// ledgerKeyPrefix is the prefix for each ledger key in idStore db
 	ledgerKeyPrefix = []byte{'l'}
 	// ledgerKeyStop is the end key when querying idStore db by ledger key
@@ -49,6 +54,9 @@ var (
 	metadataKeyPrefix = []byte{'s'}
 	// metadataKeyStop is the end key when querying idStore db by metadata key
 	metadataKeyStop = []byte{'s' + 1}
+	// bootSnapshotMetadataKeyPrefix is the prefix for the key that holds the metadata of the
+	// snapshot from which a ledger was created, if the ledger was not created from a genesis block
+	bootSnapshotMetadataKeyPrefix = []byte{'b'}
 
 	// formatKey
 	formatKey = []byte("f")
@@ -117,6 +125,9 @@ func NewProvider(initializer *ledger.Initializer) (pr *Provider, e error) {
 	if err := p.initPvtDataStoreProvider(); err != nil {
 		return nil, err
 	}
+	if err := p.initBookkeepingProvider(); err != nil {
+		return nil, err
+	}
 	if err := p.initHistoryDBProvider(); err != nil {
 		return nil, err
 	}
@@ -148,9 +159,11 @@ func (p *Provider) initLedgerIDInventory() error {
 func (p *Provider) initBlockStoreProvider() error {
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blkStoreProvider, err := blkstorage.NewProvider(
-		blkstorage.NewConf(
+		blkstorage.NewConfWithEncryption(
 			BlockStorePath(p.initializer.Config.RootFSPath),
 			maxBlockFileSize,
+			blockArchiveConf(p.initializer.Config.BlockArchiveConfig),
+			p.initializer.Encryptor,
 		),
 		indexConfig,
 		p.initializer.MetricsProvider,
@@ -162,10 +175,28 @@ func (p *Provider) initBlockStoreProvider() error {
 	return nil
 }
 
+// blockArchiveConf translates the block archive configuration of the ledger to the one of the block store
+func blockArchiveConf(config *ledger.BlockArchiveConfig) *blkstorage.ArchiveConf {
+	if config == nil {
+		return nil
+	}
+	var archiver blkstorage.Archiver = blkstorage.NewDirArchiver(config.Directory)
+	if config.Endpoint != "" {
+		archiver = blkstorage.NewHTTPArchiver(config.Endpoint, nil)
+	}
+	return &blkstorage.ArchiveConf{
+		Archiver:            archiver,
+		KeepRecentBlocks:    config.KeepRecentBlocks,
+		MinAge:              config.MinAge,
+		MaxCachedBlockfiles: config.MaxCachedBlockFiles,
+	}
+}
+
 func (p *Provider) initPvtDataStoreProvider() error {
 	privateDataConfig := &pvtdatastorage.PrivateDataConfig{
 		PrivateDataConfig: p.initializer.Config.PrivateDataConfig,
 		StorePath:         PvtDataStorePath(p.initializer.Config.RootFSPath),
+		Encryptor:         p.initializer.Encryptor,
 	}
 	pvtdataStoreProvider, err := pvtdatastorage.NewProvider(privateDataConfig)
 	if err != nil {
@@ -182,6 +213,11 @@ func (p *Provider) initHistoryDBProvider() error {
 	// Initialize the history database (index for history of values by key)
 	historydbProvider, err := history.NewDBProvider(
 		HistoryDBPath(p.initializer.Config.RootFSPath),
+		p.bookkeepingProvider,
+		p.initializer.MetricsProvider,
+		p.initializer.Config.HistoryDBConfig.Retention,
+		p.initializer.Encryptor,
+		p.initializer.ChannelCapabilitiesProvider,
 	)
 	if err != nil {
 		return err
@@ -219,17 +255,23 @@ func (p *Provider) initStateListeners() {
 	p.stateListeners = stateListeners
 }
 
-func (p *Provider) initStateDBProvider() error {
-	var err error
-	p.bookkeepingProvider, err = bookkeeping.NewProvider(
+func (p *Provider) initBookkeepingProvider() error {
+	bookkeepingProvider, err := bookkeeping.NewProvider(
 		BookkeeperDBPath(p.initializer.Config.RootFSPath),
 	)
 	if err != nil {
 		return err
 	}
+	p.bookkeepingProvider = bookkeepingProvider
+	return nil
+}
+
+func (p *Provider) initStateDBProvider() error {
+	var err error
 	stateDBConfig := &privacyenabledstate.StateDBConfig{
 		StateDBConfig: p.initializer.Config.StateDBConfig,
 		LevelDBPath:   StateDBPath(p.initializer.Config.RootFSPath),
+		Encryptor:     p.initializer.Encryptor,
 	}
 	sysNamespaces := p.initializer.DeployedChaincodeInfoProvider.Namespaces()
 	p.dbProvider, err = privacyenabledstate.NewDBProvider(
@@ -287,7 +329,7 @@ func (p *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger, error)
 	if err = p.idStore.setUnderConstructionFlag(ledgerID); err != nil {
 		return nil, err
 	}
-	lgr, err := p.open(ledgerID)
+	lgr, err := p.open(ledgerID, nil)
 	if err != nil {
 		logger.Errorf("Error opening a new empty ledger. Unsetting under construction flag. Error: %+v", err)
 		panicOnErr(p.runCleanup(ledgerID), "Error running cleanup for ledger id [%s]", ledgerID)
@@ -302,6 +344,84 @@ func (p *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger, error)
 	return lgr, nil
 }
 
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider
+// This function creates a new ledger from the snapshot files present in the snapshotDir and returns
+// the ledger along with the id of the ledger. Similar to the function `Create`, this function sets an
+// under construction flag before importing any data. If a crash happens before the ledger is marked
+// as created, the function 'recoverUnderConstructionLedger' drops the partially imported data
+func (p *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	metadata, err := p.loadSnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := p.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", ErrLedgerIDExists
+	}
+	if err = p.idStore.setUnderConstructionSnapshotFlag(ledgerID); err != nil {
+		return nil, "", err
+	}
+
+	lgr, err := p.bootstrapFromSnapshot(snapshotDir, metadata)
+	if err != nil {
+		logger.Errorf("Error creating ledger [%s] from snapshot. Dropping the imported data. Error: %+v", ledgerID, err)
+		panicOnErr(p.dataRemover().Drop(ledgerID), "Error while dropping the data for ledger id [%s]", ledgerID)
+		panicOnErr(p.idStore.unsetUnderConstructionSnapshotFlag(), "Error while unsetting under construction flag")
+		return nil, "", err
+	}
+	if err := p.idStore.createLedgerIDFromSnapshot(ledgerID, metadata); err != nil {
+		lgr.Close()
+		return nil, "", err
+	}
+	logger.Infow("Created ledger from snapshot", "channel", ledgerID, "height", metadata.ChannelHeight)
+	return lgr, ledgerID, nil
+}
+
+func (p *Provider) bootstrapFromSnapshot(snapshotDir string, metadata *snapshotMetadata) (ledger.PeerLedger, error) {
+	ledgerID := metadata.ChannelName
+	lastBlockNum := metadata.lastBlockNum()
+	lastBlockHash, err := hex.DecodeString(metadata.LastBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding last block hash from the snapshot metadata")
+	}
+	previousBlockHash, err := hex.DecodeString(metadata.PreviousBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding previous block hash from the snapshot metadata")
+	}
+	savepoint := version.NewHeight(lastBlockNum, math.MaxUint64)
+
+	if err := p.blkStoreProvider.BootstrapFromSnapshottedTxIDs(
+		snapshotDir,
+		&blkstorage.SnapshotInfo{
+			LedgerID:          ledgerID,
+			LastBlockNum:      lastBlockNum,
+			LastBlockHash:     lastBlockHash,
+			PreviousBlockHash: previousBlockHash,
+		},
+	); err != nil {
+		return nil, err
+	}
+	if err := p.configHistoryMgr.ImportConfigHistory(ledgerID, snapshotDir); err != nil {
+		return nil, err
+	}
+	if err := p.dbProvider.BootstapDBFromPubStateAndPvtStateHashes(ledgerID, savepoint, snapshotDir); err != nil {
+		return nil, err
+	}
+	if p.historydbProvider != nil {
+		if err := p.historydbProvider.MarkStartingSavepoint(ledgerID, savepoint); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.pvtdataStoreProvider.BootstrapFromSnapshot(ledgerID, lastBlockNum); err != nil {
+		return nil, err
+	}
+	return p.open(ledgerID, metadata)
+}
+
 // Open implements the corresponding method from interface ledger.PeerLedgerProvider
 func (p *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	logger.Debugf("Open() opening kvledger: %s", ledgerID)
@@ -316,10 +436,14 @@ func (p *Provider) Open(ledgerID string) (ledger.PeerLedger, error) {
 	if !active {
 		return nil, ErrInactiveLedger
 	}
-	return p.open(ledgerID)
+	bootSnapshotMetadata, err := p.idStore.getBootSnapshotMetadata(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	return p.open(ledgerID, bootSnapshotMetadata)
 }
 
-func (p *Provider) open(ledgerID string) (ledger.PeerLedger, error) {
+func (p *Provider) open(ledgerID string, bootSnapshotMetadata *snapshotMetadata) (ledger.PeerLedger, error) {
 	// Get the block store for a chain/ledger
 	blockStore, err := p.blkStoreProvider.Open(ledgerID)
 	if err != nil {
@@ -356,11 +480,13 @@ func (p *Provider) open(ledgerID string) (ledger.PeerLedger, error) {
 		stateListeners:           p.stateListeners,
 		bookkeeperProvider:       p.bookkeepingProvider,
 		ccInfoProvider:           p.initializer.DeployedChaincodeInfoProvider,
+		capabilitiesProvider:     p.initializer.ChannelCapabilitiesProvider,
 		ccLifecycleEventProvider: p.initializer.ChaincodeLifecycleEventProvider,
 		stats:                    p.stats.ledgerStats(ledgerID),
 		customTxProcessors:       p.initializer.CustomTxProcessors,
 		hashProvider:             p.initializer.HashProvider,
 		config:                   p.initializer.Config,
+		bootSnapshotMetadata:     bootSnapshotMetadata,
 	}
 
 	l, err := newKVLedger(initializer)
@@ -414,6 +540,7 @@ func (p *Provider) Close() {
 // the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
 func (p *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
+	p.recoverUnderConstructionSnapshotLedger()
 	ledgerID, err := p.idStore.getUnderConstructionFlag()
 	panicOnErr(err, "Error while checking whether the under construction flag is set")
 	if ledgerID == "" {
@@ -421,7 +548,7 @@ func (p *Provider) recoverUnderConstructionLedger() {
 		return
 	}
 	logger.Infof("ledger [%s] found as under construction", ledgerID)
-	ledger, err := p.open(ledgerID)
+	ledger, err := p.open(ledgerID, nil)
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
 	panicOnErr(err, "Error while getting blockchain info for the under construction ledger [%s]", ledgerID)
@@ -444,6 +571,31 @@ func (p *Provider) recoverUnderConstructionLedger() {
 	}
 }
 
+// recoverUnderConstructionSnapshotLedger checks whether a crash had happened while creating a ledger from a snapshot.
+// As the data imported from a snapshot may be partial, the recovery drops all the data for the ledger and
+// clears the flag. The ledger creation from the snapshot can be retried afterwards
+func (p *Provider) recoverUnderConstructionSnapshotLedger() {
+	ledgerID, err := p.idStore.getUnderConstructionSnapshotFlag()
+	panicOnErr(err, "Error while checking whether the under construction snapshot flag is set")
+	if ledgerID == "" {
+		return
+	}
+	logger.Infof("ledger [%s] found as under construction from a snapshot. Dropping the partially imported data", ledgerID)
+	panicOnErr(p.dataRemover().Drop(ledgerID), "Error while dropping the data for ledger id [%s]", ledgerID)
+	panicOnErr(p.idStore.unsetUnderConstructionSnapshotFlag(), "Error while unsetting under construction snapshot flag")
+}
+
+func (p *Provider) dataRemover() *ledgerDataRemover {
+	return &ledgerDataRemover{
+		blkStoreProvider:     p.blkStoreProvider,
+		statedbProvider:      p.dbProvider,
+		configHistoryMgr:     p.configHistoryMgr,
+		bookkeepingProvider:  p.bookkeepingProvider,
+		historydbProvider:    p.historydbProvider,
+		pvtdataStoreProvider: p.pvtdataStoreProvider,
+	}
+}
+
 // runCleanup cleans up blockstorage, statedb, and historydb for what
 // may have got created during in-complete ledger creation
 func (p *Provider) runCleanup(ledgerID string) error {
@@ -594,6 +746,22 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 	return string(val), nil
 }
 
+func (s *idStore) setUnderConstructionSnapshotFlag(ledgerID string) error {
+	return s.db.Put(underConstructionSnapshotLedgerKey, []byte(ledgerID), true)
+}
+
+func (s *idStore) unsetUnderConstructionSnapshotFlag() error {
+	return s.db.Delete(underConstructionSnapshotLedgerKey, true)
+}
+
+func (s *idStore) getUnderConstructionSnapshotFlag() (string, error) {
+	val, err := s.db.Get(underConstructionSnapshotLedgerKey)
+	if err != nil {
+		return "", err
+	}
+	return string(val), nil
+}
+
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	gbKey := s.encodeLedgerKey(ledgerID, ledgerKeyPrefix)
 	metadataKey := s.encodeLedgerKey(ledgerID, metadataKeyPrefix)
@@ -619,6 +787,46 @@ func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	return s.db.WriteBatch(batch, true)
 }
 
+// createLedgerIDFromSnapshot adds the ledgerID to the list of created ledgers. As there is no genesis block
+// available for a ledger created from a snapshot, the hash of the snapshot is stored against the ledger key
+// and the snapshot metadata is stored separately for the later use by the ledger
+func (s *idStore) createLedgerIDFromSnapshot(ledgerID string, snapshotMetadata *snapshotMetadata) error {
+	ledgerKey := s.encodeLedgerKey(ledgerID, ledgerKeyPrefix)
+	metadataKey := s.encodeLedgerKey(ledgerID, metadataKeyPrefix)
+	bootSnapshotMetadataKey := s.encodeLedgerKey(ledgerID, bootSnapshotMetadataKeyPrefix)
+	val, err := s.db.Get(ledgerKey)
+	if err != nil {
+		return err
+	}
+	if val != nil {
+		return ErrLedgerIDExists
+	}
+	metadata, err := protoutil.Marshal(&msgs.LedgerMetadata{Status: msgs.Status_ACTIVE})
+	if err != nil {
+		return err
+	}
+	bootSnapshotMetadata, err := snapshotMetadata.toJSON()
+	if err != nil {
+		return err
+	}
+	batch := &leveldb.Batch{}
+	batch.Put(ledgerKey, []byte(snapshotMetadata.SnapshotHashInHex))
+	batch.Put(metadataKey, metadata)
+	batch.Put(bootSnapshotMetadataKey, bootSnapshotMetadata)
+	batch.Delete(underConstructionSnapshotLedgerKey)
+	return s.db.WriteBatch(batch, true)
+}
+
+// getBootSnapshotMetadata returns the metadata of the snapshot from which the ledger was created.
+// This returns nil, if the ledger was created from a genesis block
+func (s *idStore) getBootSnapshotMetadata(ledgerID string) (*snapshotMetadata, error) {
+	val, err := s.db.Get(s.encodeLedgerKey(ledgerID, bootSnapshotMetadataKeyPrefix))
+	if val == nil || err != nil {
+		return nil, err
+	}
+	return snapshotMetadataFromJSON(val)
+}
+
 func (s *idStore) updateLedgerStatus(ledgerID string, newStatus msgs.Status) error {
 	metadata, err := s.getLedgerMetadata(ledgerID)
 	if err != nil {
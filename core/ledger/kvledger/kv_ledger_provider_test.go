@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -300,6 +301,29 @@ func TestLedgerProviderHistoryDBDisabled(t *testing.T) {
 
 }
 
+func TestBlockArchiveConf(t *testing.T) {
+	require.Nil(t, blockArchiveConf(nil))
+
+	archiveConf := blockArchiveConf(&lgr.BlockArchiveConfig{
+		Directory:           "/archive",
+		KeepRecentBlocks:    1000,
+		MinAge:              time.Hour,
+		MaxCachedBlockFiles: 8,
+	})
+	require.Equal(t, &blkstorage.ArchiveConf{
+		Archiver:            blkstorage.NewDirArchiver("/archive"),
+		KeepRecentBlocks:    1000,
+		MinAge:              time.Hour,
+		MaxCachedBlockfiles: 8,
+	}, archiveConf)
+
+	archiveConf = blockArchiveConf(&lgr.BlockArchiveConfig{
+		Directory: "/archive",
+		Endpoint:  "https://objectstore.example.com/blocks",
+	})
+	require.Equal(t, blkstorage.NewHTTPArchiver("https://objectstore.example.com/blocks", nil), archiveConf.Archiver)
+}
+
 func TestRecovery(t *testing.T) {
 	conf, cleanup := testConfig(t)
 	defer cleanup()
@@ -308,7 +332,7 @@ func TestRecovery(t *testing.T) {
 
 	// now create the genesis block
 	genesisBlock, _ := configtxtest.MakeGenesisBlock(constructTestLedgerID(1))
-	ledger, err := provider1.open(constructTestLedgerID(1))
+	ledger, err := provider1.open(constructTestLedgerID(1), nil)
 	require.NoError(t, err)
 	ledger.CommitLegacy(&lgr.BlockAndPvtData{Block: genesisBlock}, &lgr.CommitOptions{})
 	ledger.Close()
@@ -351,7 +375,7 @@ func TestRecoveryHistoryDBDisabled(t *testing.T) {
 
 	// now create the genesis block
 	genesisBlock, _ := configtxtest.MakeGenesisBlock(constructTestLedgerID(1))
-	ledger, err := provider1.open(constructTestLedgerID(1))
+	ledger, err := provider1.open(constructTestLedgerID(1), nil)
 	require.NoError(t, err, "Failed to open the ledger")
 	ledger.CommitLegacy(&lgr.BlockAndPvtData{Block: genesisBlock}, &lgr.CommitOptions{})
 	ledger.Close()
@@ -695,7 +695,7 @@ func TestCrashAfterPvtdataStoreCommit(t *testing.T) {
 		pvtdataAtCrash = append(pvtdataAtCrash, p)
 	}
 	// call Commit on pvt data store and mimic a crash before committing the block to block store
-	lgr.(*kvLedger).pvtdataStore.Commit(blockNumAtCrash, pvtdataAtCrash, nil)
+	lgr.(*kvLedger).pvtdataStore.Commit(blockNumAtCrash, pvtdataAtCrash, nil, nil)
 
 	// Now, assume that peer fails here before committing the block to blockstore.
 	lgr.Close()
@@ -824,7 +824,7 @@ func TestPvtStoreAheadOfBlockStore(t *testing.T) {
 	// Add the last block directly to the pvtdataStore but not to blockstore. This would make
 	// the pvtdatastore height greater than the block store height.
 	validTxPvtData, validTxMissingPvtData := constructPvtDataAndMissingData(lastBlkAndPvtData)
-	err = kvlgr.pvtdataStore.Commit(lastBlkAndPvtData.Block.Header.Number, validTxPvtData, validTxMissingPvtData)
+	err = kvlgr.pvtdataStore.Commit(lastBlkAndPvtData.Block.Header.Number, validTxPvtData, validTxMissingPvtData, nil)
 	require.NoError(t, err)
 
 	// close and reopen.
@@ -11,6 +11,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statesql"
 	"github.com/pkg/errors"
 )
 
@@ -31,6 +32,11 @@ func RebuildDBs(config *ledger.Config) error {
 			return err
 		}
 	}
+	if config.StateDBConfig.StateDatabase == ledger.SQL {
+		if err := statesql.DropApplicationDBs(config.StateDBConfig.SQL); err != nil {
+			return err
+		}
+	}
 	if err := dropDBs(rootFSPath); err != nil {
 		return err
 	}
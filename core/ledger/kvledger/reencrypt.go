@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+// ReencryptKVLedgers encrypts the block files and the values in the state, history and private data
+// stores of all the ledgers with the active key of the encryptor. The data that is encrypted with
+// a retired key of the encryptor is re-encrypted, whereas the data in clear is encrypted. Once this
+// function returns, the retired keys are not required anymore. In case of a failure, this function
+// can be invoked again to resume the encryption
+func ReencryptKVLedgers(rootFSPath string, encryptor *encryption.Encryptor) error {
+	fileLockPath := fileLockPath(rootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	blockstorePath := BlockStorePath(rootFSPath)
+	logger.Infof("Encrypting the block files at location [%s]", blockstorePath)
+	if err := blkstorage.ReencryptBlockfiles(blockstorePath, encryptor); err != nil {
+		return err
+	}
+	for _, dbPath := range []string{
+		StateDBPath(rootFSPath),
+		HistoryDBPath(rootFSPath),
+		PvtDataStorePath(rootFSPath),
+	} {
+		exists, err := fileutil.DirExists(dbPath)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		logger.Infof("Encrypting the database at location [%s]", dbPath)
+		if err := leveldbhelper.Reencrypt(dbPath, encryptor); err != nil {
+			return err
+		}
+	}
+	logger.Infof("All channel ledgers have been successfully encrypted with the key [%x]", encryptor.ActiveKeyID())
+	return nil
+}
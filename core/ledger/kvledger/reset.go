@@ -8,12 +8,14 @@ package kvledger
 
 import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/pkg/errors"
 )
 
 // ResetAllKVLedgers resets all ledger to the genesis block.
-func ResetAllKVLedgers(rootFSPath string) error {
+// The encryptor is required if the ledgers are encrypted
+func ResetAllKVLedgers(rootFSPath string, encryptor *encryption.Encryptor) error {
 	fileLockPath := fileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
@@ -27,7 +29,7 @@ func ResetAllKVLedgers(rootFSPath string) error {
 	if err := dropDBs(rootFSPath); err != nil {
 		return err
 	}
-	if err := resetBlockStorage(rootFSPath); err != nil {
+	if err := resetBlockStorage(rootFSPath, encryptor); err != nil {
 		return err
 	}
 	logger.Info("All channel ledgers have been successfully reset to the genesis block")
@@ -48,8 +50,8 @@ func ClearPreResetHeight(rootFSPath string, ledgerIDs []string) error {
 	return blkstorage.ClearPreResetHeight(blockstorePath, ledgerIDs)
 }
 
-func resetBlockStorage(rootFSPath string) error {
+func resetBlockStorage(rootFSPath string, encryptor *encryption.Encryptor) error {
 	blockstorePath := BlockStorePath(rootFSPath)
 	logger.Infof("Resetting BlockStore to genesis block at location [%s]", blockstorePath)
-	return blkstorage.ResetBlockStore(blockstorePath)
+	return blkstorage.ResetBlockStore(blockstorePath, encryptor)
 }
@@ -8,12 +8,14 @@ package kvledger
 
 import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/pkg/errors"
 )
 
-// RollbackKVLedger rollbacks a ledger to a specified block number
-func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
+// RollbackKVLedger rollbacks a ledger to a specified block number.
+// The encryptor is required if the ledger is encrypted
+func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64, encryptor *encryption.Encryptor) error {
 	fileLockPath := fileLockPath(rootFSPath)
 	fileLock := leveldbhelper.NewFileLock(fileLockPath)
 	if err := fileLock.Lock(); err != nil {
@@ -23,7 +25,7 @@ func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
 	defer fileLock.Unlock()
 
 	blockstorePath := BlockStorePath(rootFSPath)
-	if err := blkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum); err != nil {
+	if err := blkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum, encryptor); err != nil {
 		return err
 	}
 
@@ -34,7 +36,7 @@ func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
 
 	logger.Info("Rolling back ledger store")
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	if err := blkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig); err != nil {
+	if err := blkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig, encryptor); err != nil {
 		return err
 	}
 	logger.Infof("The channel [%s] has been successfully rolled back to the block number [%d]", ledgerID, blockNum)
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -44,6 +45,38 @@ type snapshotAdditionalInfo struct {
 	LastBlockCommitHashInHex string `json:"last_block_commit_hash"`
 }
 
+// snapshotMetadata combines the signable metadata and the additional info of a snapshot.
+// For a ledger that is created from a snapshot, this is persisted in the idStore and is used
+// for retrieving the information about the last block in the snapshot, as the blockstore does
+// not contain the blocks prior to the snapshot
+type snapshotMetadata struct {
+	*snapshotSignableMetadata
+	*snapshotAdditionalInfo
+}
+
+func (m *snapshotMetadata) lastBlockNum() uint64 {
+	return m.ChannelHeight - 1
+}
+
+func (m *snapshotMetadata) toJSON() ([]byte, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshalling snapshot metadata to JSON")
+	}
+	return b, nil
+}
+
+func snapshotMetadataFromJSON(b []byte) (*snapshotMetadata, error) {
+	m := &snapshotMetadata{
+		snapshotSignableMetadata: &snapshotSignableMetadata{},
+		snapshotAdditionalInfo:   &snapshotAdditionalInfo{},
+	}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling snapshot metadata from JSON")
+	}
+	return m, nil
+}
+
 // generateSnapshot generates a snapshot. This function should be invoked when commit on the kvledger are paused
 // after committing the last block fully and further the commits should not be resumed till this function finishes
 func (l *kvLedger) generateSnapshot() error {
@@ -163,3 +196,86 @@ func (l *kvLedger) generateSnapshotMetadataFiles(
 	}
 	return fileutil.CreateAndSyncFile(filepath.Join(dir, snapshotMetadataHashFileName), metadataAdditionalInfo, 0444)
 }
+
+// loadSnapshotMetadata reads the metadata files from the snapshot dir and verifies that the
+// snapshot hash recorded in the additional info file matches the hash of the signable metadata
+// and that the hashes of the snapshot files match the ones recorded in the signable metadata
+func (p *Provider) loadSnapshotMetadata(snapshotDir string) (*snapshotMetadata, error) {
+	signableMetadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot metadata file from dir [%s]", snapshotDir)
+	}
+	additionalInfoBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotMetadataHashFileName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot additional info file from dir [%s]", snapshotDir)
+	}
+	signableMetadata := &snapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the snapshot metadata")
+	}
+	additionalInfo := &snapshotAdditionalInfo{}
+	if err := json.Unmarshal(additionalInfoBytes, additionalInfo); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the snapshot additional info")
+	}
+	if signableMetadata.ChannelName == "" {
+		return nil, errors.New("invalid snapshot metadata: channel name is empty")
+	}
+	if signableMetadata.ChannelHeight == 0 {
+		return nil, errors.New("invalid snapshot metadata: channel height is zero")
+	}
+
+	metadataHash, err := p.computeHash(signableMetadataBytes)
+	if err != nil {
+		return nil, err
+	}
+	if hex.EncodeToString(metadataHash) != additionalInfo.SnapshotHashInHex {
+		return nil, errors.Errorf(
+			"hash mismatch for the snapshot metadata file. Expected hash = [%s], actual hash = [%x]",
+			additionalInfo.SnapshotHashInHex, metadataHash,
+		)
+	}
+
+	for fileName, expectedHashInHex := range signableMetadata.FilesAndHashes {
+		fileHash, err := p.computeFileHash(filepath.Join(snapshotDir, fileName))
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(fileHash) != expectedHashInHex {
+			return nil, errors.Errorf(
+				"hash mismatch for the snapshot file [%s]. Expected hash = [%s], actual hash = [%x]",
+				fileName, expectedHashInHex, fileHash,
+			)
+		}
+	}
+	return &snapshotMetadata{
+		snapshotSignableMetadata: signableMetadata,
+		snapshotAdditionalInfo:   additionalInfo,
+	}, nil
+}
+
+func (p *Provider) computeHash(data []byte) ([]byte, error) {
+	hash, err := p.initializer.HashProvider.GetHash(snapshotHashOpts)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hash.Write(data); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+func (p *Provider) computeFileHash(filePath string) ([]byte, error) {
+	hash, err := p.initializer.HashProvider.GetHash(snapshotHashOpts)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file [%s]", filePath)
+	}
+	defer f.Close()
+	if _, err := io.Copy(hash, f); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file [%s]", filePath)
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/pkg/errors"
+)
+
+// snapshotMgr manages the snapshot requests for a ledger and coordinates the generation of
+// the snapshots with the block commits. The mutex is held during the commit of a block and
+// while processing a snapshot request so that the height of the ledger does not change in between.
+// A snapshot is generated in the background after the commit of the block at which the snapshot
+// is requested and the commit of the next block waits for the snapshot generation to finish
+type snapshotMgr struct {
+	sync.Mutex
+	snapshotRequestBookkeeper *snapshotRequestBookkeeper
+	// snapshotInProgress is non-nil if a snapshot generation has been started and it is closed
+	// when the snapshot generation finishes
+	snapshotInProgress       chan struct{}
+	snapshotInProgressHeight uint64
+}
+
+func newSnapshotMgr(ledgerID string, bookkeepingProvider *bookkeeping.Provider) *snapshotMgr {
+	return &snapshotMgr{
+		snapshotRequestBookkeeper: newSnapshotRequestBookkeeper(ledgerID, bookkeepingProvider),
+	}
+}
+
+// waitForSnapshotGeneration blocks till the snapshot generation in progress, if any, finishes.
+// The caller is expected to hold the lock
+func (m *snapshotMgr) waitForSnapshotGeneration() {
+	if m.snapshotInProgress == nil {
+		return
+	}
+	<-m.snapshotInProgress
+	m.snapshotInProgress = nil
+}
+
+// isSnapshotGenerationInProgress returns true if a snapshot for the given height is being generated.
+// The caller is expected to hold the lock
+func (m *snapshotMgr) isSnapshotGenerationInProgress(height uint64) bool {
+	if m.snapshotInProgress == nil || m.snapshotInProgressHeight != height {
+		return false
+	}
+	select {
+	case <-m.snapshotInProgress:
+		return false
+	default:
+		return true
+	}
+}
+
+// SubmitSnapshotRequest submits a snapshot request for the specified height.
+// The request is stored in the ledger until the ledger's block height is equal to
+// the specified height and the snapshot generation is completed.
+// When height is 0, a snapshot is generated at the current block height.
+// It returns an error if the specified height is smaller than the ledger's block height
+// or if a request already exists for the height
+func (l *kvLedger) SubmitSnapshotRequest(height uint64) error {
+	l.snapshotMgr.Lock()
+	defer l.snapshotMgr.Unlock()
+
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if height == 0 {
+		height = bcInfo.Height
+	}
+	if height < bcInfo.Height {
+		return errors.Errorf("requested snapshot height %d cannot be less than the current block height %d", height, bcInfo.Height)
+	}
+	if err := l.snapshotMgr.snapshotRequestBookkeeper.add(height); err != nil {
+		return err
+	}
+	logger.Infow("Submitted snapshot request", "channel", l.ledgerID, "height", height)
+	if height == bcInfo.Height {
+		l.snapshotMgr.waitForSnapshotGeneration()
+		l.generateSnapshotInBackground(height)
+	}
+	return nil
+}
+
+// CancelSnapshotRequest cancels the previously submitted request.
+// It returns an error if such a request does not exist or the snapshot
+// generation for the request is in progress
+func (l *kvLedger) CancelSnapshotRequest(height uint64) error {
+	l.snapshotMgr.Lock()
+	defer l.snapshotMgr.Unlock()
+
+	if l.snapshotMgr.isSnapshotGenerationInProgress(height) {
+		return errors.Errorf("cannot cancel the snapshot request for height %d, the snapshot generation is in progress", height)
+	}
+	if err := l.snapshotMgr.snapshotRequestBookkeeper.delete(height); err != nil {
+		return err
+	}
+	logger.Infow("Cancelled snapshot request", "channel", l.ledgerID, "height", height)
+	return nil
+}
+
+// PendingSnapshotRequests returns the heights of the pending (or under processing) snapshot requests
+// in ascending order
+func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
+	return l.snapshotMgr.snapshotRequestBookkeeper.list()
+}
+
+// processSnapshotRequestAtHeight starts the snapshot generation if a snapshot has been requested
+// at the given height. This function is invoked after a block commit and when a ledger is opened
+// (e.g., for the case when the peer crashed before finishing a snapshot generation).
+// The caller is expected to hold the snapshotMgr lock
+func (l *kvLedger) processSnapshotRequestAtHeight(height uint64) error {
+	exists, err := l.snapshotMgr.snapshotRequestBookkeeper.exist(height)
+	if err != nil || !exists {
+		return err
+	}
+	l.generateSnapshotInBackground(height)
+	return nil
+}
+
+// SnapshotRequestFailures returns the failures of the snapshot generation for the pending
+// requests, by height. A request whose snapshot generation failed is kept until it is cancelled,
+// and its snapshot generation is retried when the ledger is opened at the requested height
+func (l *kvLedger) SnapshotRequestFailures() (map[uint64]string, error) {
+	return l.snapshotMgr.snapshotRequestBookkeeper.failures()
+}
+
+// generateSnapshotInBackground generates the snapshot in a separate goroutine and deletes the
+// corresponding request once done. If the snapshot generation fails, the request is kept and
+// the failure is recorded along with it. The caller is expected to hold the snapshotMgr lock and
+// the commits are expected to be paused till the snapshot generation finishes
+func (l *kvLedger) generateSnapshotInBackground(height uint64) {
+	done := make(chan struct{})
+	l.snapshotMgr.snapshotInProgress = done
+	l.snapshotMgr.snapshotInProgressHeight = height
+
+	go func() {
+		defer close(done)
+		snapshotDir := SnapshotDirForLedgerHeight(l.config.SnapshotsConfig.RootDir, l.ledgerID, height)
+		if _, err := os.Stat(snapshotDir); err == nil {
+			logger.Warnw("Snapshot already exists, skipping the snapshot generation", "channel", l.ledgerID, "height", height)
+		} else {
+			logger.Infow("Generating snapshot", "channel", l.ledgerID, "height", height)
+			if err := l.generateSnapshot(); err != nil {
+				logger.Errorw("Failed to generate snapshot", "channel", l.ledgerID, "height", height, "error", err)
+				if err := l.snapshotMgr.snapshotRequestBookkeeper.recordFailure(height, err.Error()); err != nil {
+					logger.Errorw("Failed to record the failure of the snapshot request", "channel", l.ledgerID, "height", height, "error", err)
+				}
+				return
+			}
+			logger.Infow("Generated snapshot", "channel", l.ledgerID, "height", height)
+		}
+		if err := l.snapshotMgr.snapshotRequestBookkeeper.delete(height); err != nil {
+			logger.Errorw("Failed to delete snapshot request", "channel", l.ledgerID, "height", height, "error", err)
+		}
+	}()
+}
+
+// snapshotRequestBookkeeper persists the pending snapshot requests for a ledger in the bookkeeping db.
+// The key is the order preserving encoding of the requested height and the value is the failure
+// of the snapshot generation for the request, if any
+type snapshotRequestBookkeeper struct {
+	dbHandle *leveldbhelper.DBHandle
+}
+
+func newSnapshotRequestBookkeeper(ledgerID string, bookkeepingProvider *bookkeeping.Provider) *snapshotRequestBookkeeper {
+	return &snapshotRequestBookkeeper{
+		dbHandle: bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.SnapshotRequest),
+	}
+}
+
+func (k *snapshotRequestBookkeeper) add(height uint64) error {
+	exists, err := k.exist(height)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("duplicate snapshot request for height %d", height)
+	}
+	return k.dbHandle.Put(encodeSnapshotRequestKey(height), []byte{}, true)
+}
+
+func (k *snapshotRequestBookkeeper) delete(height uint64) error {
+	exists, err := k.exist(height)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no snapshot request exists for height %d", height)
+	}
+	return k.dbHandle.Delete(encodeSnapshotRequestKey(height), true)
+}
+
+func (k *snapshotRequestBookkeeper) recordFailure(height uint64, failure string) error {
+	exists, err := k.exist(height)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("no snapshot request exists for height %d", height)
+	}
+	return k.dbHandle.Put(encodeSnapshotRequestKey(height), []byte(failure), true)
+}
+
+func (k *snapshotRequestBookkeeper) exist(height uint64) (bool, error) {
+	val, err := k.dbHandle.Get(encodeSnapshotRequestKey(height))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (k *snapshotRequestBookkeeper) list() ([]uint64, error) {
+	itr, err := k.dbHandle.GetIterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	heights := []uint64{}
+	for itr.Next() {
+		height, _, err := util.DecodeOrderPreservingVarUint64(itr.Key())
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while decoding the snapshot request key")
+		}
+		heights = append(heights, height)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "internal leveldb error while iterating over snapshot requests")
+	}
+	return heights, nil
+}
+
+func (k *snapshotRequestBookkeeper) failures() (map[uint64]string, error) {
+	itr, err := k.dbHandle.GetIterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	failures := map[uint64]string{}
+	for itr.Next() {
+		if len(itr.Value()) == 0 {
+			continue
+		}
+		height, _, err := util.DecodeOrderPreservingVarUint64(itr.Key())
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while decoding the snapshot request key")
+		}
+		failures[height] = string(itr.Value())
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "internal leveldb error while iterating over snapshot requests")
+	}
+	return failures, nil
+}
+
+func encodeSnapshotRequestKey(height uint64) []byte {
+	return util.EncodeOrderPreservingVarUint64(height)
+}
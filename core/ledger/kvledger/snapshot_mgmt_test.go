@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRequests(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	snapshotRootDir := conf.SnapshotsConfig.RootDir
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+
+	waitForSnapshotGeneration := func() {
+		kvlgr.snapshotMgr.Lock()
+		defer kvlgr.snapshotMgr.Unlock()
+		kvlgr.snapshotMgr.waitForSnapshotGeneration()
+	}
+
+	commitNextBlock := func(txID string) {
+		blockAndPvtdata := prepareNextBlockForTest(t, kvlgr, blkGenerator, txID,
+			map[string]string{"key1": txID},
+			nil,
+		)
+		require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata, &ledger.CommitOptions{}))
+	}
+
+	// a request with height 0 generates the snapshot at the current height
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(0))
+	waitForSnapshotGeneration()
+	require.DirExists(t, SnapshotDirForLedgerHeight(snapshotRootDir, "testLedgerid", 1))
+	pendingRequests, err := kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pendingRequests)
+
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(5))
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(3))
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(300))
+	require.EqualError(t, kvlgr.SubmitSnapshotRequest(3), "duplicate snapshot request for height 3")
+	pendingRequests, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 5, 300}, pendingRequests)
+
+	require.NoError(t, kvlgr.CancelSnapshotRequest(300))
+	require.EqualError(t, kvlgr.CancelSnapshotRequest(300), "no snapshot request exists for height 300")
+	pendingRequests, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 5}, pendingRequests)
+
+	commitNextBlock("txid-1")
+	commitNextBlock("txid-2")
+	waitForSnapshotGeneration()
+	require.DirExists(t, SnapshotDirForLedgerHeight(snapshotRootDir, "testLedgerid", 3))
+	pendingRequests, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5}, pendingRequests)
+
+	require.EqualError(t, kvlgr.SubmitSnapshotRequest(2), "requested snapshot height 2 cannot be less than the current block height 3")
+}
+
+func TestSnapshotRequestRecovery(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	snapshotRootDir := conf.SnapshotsConfig.RootDir
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	kvlgr := lgr.(*kvLedger)
+
+	// simulate a crash after the request for the current height is recorded but before the snapshot is generated
+	require.NoError(t, kvlgr.snapshotMgr.snapshotRequestBookkeeper.add(1))
+	lgr.Close()
+	provider.Close()
+
+	provider = testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+	lgr, err = provider.Open("testLedgerid")
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr = lgr.(*kvLedger)
+
+	kvlgr.snapshotMgr.Lock()
+	kvlgr.snapshotMgr.waitForSnapshotGeneration()
+	kvlgr.snapshotMgr.Unlock()
+	snapshotDir := SnapshotDirForLedgerHeight(snapshotRootDir, "testLedgerid", 1)
+	require.DirExists(t, snapshotDir)
+	pendingRequests, err := kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pendingRequests)
+
+	// a request for a height for which the snapshot already exists is removed without generating the snapshot again
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(1))
+	kvlgr.snapshotMgr.Lock()
+	kvlgr.snapshotMgr.waitForSnapshotGeneration()
+	kvlgr.snapshotMgr.Unlock()
+	pendingRequests, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pendingRequests)
+}
+
+func TestSnapshotRequestFailure(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	snapshotRootDir := conf.SnapshotsConfig.RootDir
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	kvlgr := lgr.(*kvLedger)
+
+	// the snapshot generation fails as the directory for the snapshots in progress is missing
+	require.NoError(t, os.RemoveAll(InProgressSnapshotsPath(snapshotRootDir)))
+	require.NoError(t, kvlgr.SubmitSnapshotRequest(0))
+	kvlgr.snapshotMgr.Lock()
+	kvlgr.snapshotMgr.waitForSnapshotGeneration()
+	kvlgr.snapshotMgr.Unlock()
+
+	pendingRequests, err := kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, pendingRequests)
+	failures, err := kvlgr.SnapshotRequestFailures()
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	require.Contains(t, failures[1], "error while creating temp dir")
+	require.EqualError(t, kvlgr.SubmitSnapshotRequest(1), "duplicate snapshot request for height 1")
+	lgr.Close()
+	provider.Close()
+
+	// the snapshot generation is retried when the ledger is opened
+	require.NoError(t, os.MkdirAll(InProgressSnapshotsPath(snapshotRootDir), 0755))
+	provider = testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+	lgr, err = provider.Open("testLedgerid")
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr = lgr.(*kvLedger)
+
+	kvlgr.snapshotMgr.Lock()
+	kvlgr.snapshotMgr.waitForSnapshotGeneration()
+	kvlgr.snapshotMgr.Unlock()
+	require.DirExists(t, SnapshotDirForLedgerHeight(snapshotRootDir, "testLedgerid", 1))
+	pendingRequests, err = kvlgr.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Empty(t, pendingRequests)
+	failures, err = kvlgr.SnapshotRequestFailures()
+	require.NoError(t, err)
+	require.Empty(t, failures)
+}
+
+func TestCancelSnapshotRequestInProgress(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+
+	kvlgr.snapshotMgr.snapshotInProgress = make(chan struct{})
+	kvlgr.snapshotMgr.snapshotInProgressHeight = 1
+	require.NoError(t, kvlgr.snapshotMgr.snapshotRequestBookkeeper.add(1))
+	require.EqualError(t, kvlgr.CancelSnapshotRequest(1), "cannot cancel the snapshot request for height 1, the snapshot generation is in progress")
+
+	close(kvlgr.snapshotMgr.snapshotInProgress)
+	require.NoError(t, kvlgr.CancelSnapshotRequest(1))
+}
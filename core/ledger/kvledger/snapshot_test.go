@@ -15,6 +15,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -145,7 +147,7 @@ func TestSnapshotDBTypeCouchDB(t *testing.T) {
 	defer cleanup()
 	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
 	defer provider.Close()
-	lgr, err := provider.open("testLedger")
+	lgr, err := provider.open("testLedger", nil)
 	require.NoError(t, err)
 	kvlgr := lgr.(*kvLedger)
 
@@ -161,6 +163,226 @@ func TestSnapshotDBTypeCouchDB(t *testing.T) {
 	)
 }
 
+func TestCreateFromSnapshot(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	nsCollBtlConfs := []*nsCollBtlConfig{
+		{
+			namespace: "ns",
+			btlConfig: map[string]uint64{"coll": 0},
+		},
+	}
+	provider := testutilNewProviderWithCollectionConfig(
+		t,
+		nsCollBtlConfs,
+		conf,
+	)
+	defer provider.Close()
+
+	blkGenerator, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+
+	blockAndPvtdata1 := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1", "key2": "value2.1"},
+		map[string]string{"key1": "pvtValue1.1", "key2": "pvtValue2.1"},
+	)
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata1, &ledger.CommitOptions{}))
+	require.NoError(t, kvlgr.generateSnapshot())
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, kvlgr.ledgerID, 2)
+
+	destConf, destCleanup := testConfig(t)
+	defer destCleanup()
+	destProvider := testutilNewProviderWithCollectionConfig(
+		t,
+		nsCollBtlConfs,
+		destConf,
+	)
+	destLgr, ledgerID, err := destProvider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, "testLedgerid", ledgerID)
+	destKVLgr := destLgr.(*kvLedger)
+
+	verifyLedgerAgainstSource := func() {
+		srcBCInfo, err := kvlgr.GetBlockchainInfo()
+		require.NoError(t, err)
+		destBCInfo, err := destKVLgr.GetBlockchainInfo()
+		require.NoError(t, err)
+		require.Equal(t, srcBCInfo, destBCInfo)
+		require.Equal(t, kvlgr.commitHash, destKVLgr.commitHash)
+
+		qe, err := destKVLgr.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		val, err := qe.GetState("ns", "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1.1"), val)
+		hash, err := qe.GetPrivateDataHash("ns", "coll", "key1")
+		require.NoError(t, err)
+		require.Equal(t, util.ComputeSHA256([]byte("pvtValue1.1")), hash)
+	}
+	verifyLedgerAgainstSource()
+
+	// the txids from the snapshot are known to the ledger, though the details are not available
+	txID, err := protoutil.GetOrComputeTxIDFromEnvelope(blockAndPvtdata1.Block.Data.Data[0])
+	require.NoError(t, err)
+	_, err = destKVLgr.GetTxValidationCodeByTxID(txID)
+	require.Contains(t, err.Error(), "Ledger bootstrapped from a snapshot")
+
+	// commit the next block on both the ledgers and the commit hashes should match
+	blockAndPvtdata2 := prepareNextBlockForTest(t, kvlgr, blkGenerator, "SimulateForBlk2",
+		map[string]string{"key3": "value3.2"},
+		nil,
+	)
+	destBlockAndPvtdata2 := &ledger.BlockAndPvtData{
+		Block: proto.Clone(blockAndPvtdata2.Block).(*common.Block),
+	}
+	require.NoError(t, kvlgr.CommitLegacy(blockAndPvtdata2, &ledger.CommitOptions{}))
+	require.NoError(t, destKVLgr.CommitLegacy(destBlockAndPvtdata2, &ledger.CommitOptions{}))
+	srcBCInfo, err := kvlgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), srcBCInfo.Height)
+	verifyLedgerAgainstSource()
+
+	// the ledger can be opened after restarting the provider
+	destLgr.Close()
+	destProvider.Close()
+	destProvider = testutilNewProviderWithCollectionConfig(
+		t,
+		nsCollBtlConfs,
+		destConf,
+	)
+	defer destProvider.Close()
+	ledgerIDs, err := destProvider.List()
+	require.NoError(t, err)
+	require.Equal(t, []string{"testLedgerid"}, ledgerIDs)
+	destLgr, err = destProvider.Open("testLedgerid")
+	require.NoError(t, err)
+	defer destLgr.Close()
+	destKVLgr = destLgr.(*kvLedger)
+	verifyLedgerAgainstSource()
+
+	// creating the same ledger again should fail
+	_, _, err = destProvider.CreateFromSnapshot(snapshotDir)
+	require.Equal(t, ErrLedgerIDExists, err)
+}
+
+func TestCreateFromSnapshotErrors(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	require.NoError(t, lgr.(*kvLedger).generateSnapshot())
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, "testLedgerid", 1)
+
+	copySnapshot := func(t *testing.T) string {
+		dir, err := ioutil.TempDir("", "snapshot")
+		require.NoError(t, err)
+		files, err := ioutil.ReadDir(snapshotDir)
+		require.NoError(t, err)
+		for _, f := range files {
+			b, err := ioutil.ReadFile(filepath.Join(snapshotDir, f.Name()))
+			require.NoError(t, err)
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, f.Name()), b, 0644))
+		}
+		return dir
+	}
+
+	t.Run("missing-metadata-file", func(t *testing.T) {
+		dir := copySnapshot(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, os.Remove(filepath.Join(dir, snapshotMetadataFileName)))
+
+		destConf, destCleanup := testConfig(t)
+		defer destCleanup()
+		destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+		defer destProvider.Close()
+		_, _, err := destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "error while reading the snapshot metadata file")
+	})
+
+	t.Run("tampered-data-file", func(t *testing.T) {
+		dir := copySnapshot(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "txids.data"), []byte("tampered-data"), 0644))
+
+		destConf, destCleanup := testConfig(t)
+		defer destCleanup()
+		destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+		defer destProvider.Close()
+		_, _, err := destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot file [txids.data]")
+		ledgerIDs, err := destProvider.List()
+		require.NoError(t, err)
+		require.Len(t, ledgerIDs, 0)
+	})
+
+	t.Run("tampered-metadata-file", func(t *testing.T) {
+		dir := copySnapshot(t)
+		defer os.RemoveAll(dir)
+		metadataFile := filepath.Join(dir, snapshotMetadataFileName)
+		b, err := ioutil.ReadFile(metadataFile)
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(metadataFile, append(b, ' '), 0644))
+
+		destConf, destCleanup := testConfig(t)
+		defer destCleanup()
+		destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+		defer destProvider.Close()
+		_, _, err = destProvider.CreateFromSnapshot(dir)
+		require.Contains(t, err.Error(), "hash mismatch for the snapshot metadata file")
+	})
+
+	t.Run("ledger-exists", func(t *testing.T) {
+		_, _, err := provider.CreateFromSnapshot(snapshotDir)
+		require.Equal(t, ErrLedgerIDExists, err)
+	})
+}
+
+func TestRecoverUnderConstructionSnapshotLedger(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.Create(genesisBlk)
+	require.NoError(t, err)
+	require.NoError(t, lgr.(*kvLedger).generateSnapshot())
+	lgr.Close()
+	provider.Close()
+	snapshotDir := SnapshotDirForLedgerHeight(conf.SnapshotsConfig.RootDir, "testLedgerid", 1)
+
+	destConf, destCleanup := testConfig(t)
+	defer destCleanup()
+	destProvider := testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+	// simulate a crash after importing the data partially
+	metadata, err := destProvider.loadSnapshotMetadata(snapshotDir)
+	require.NoError(t, err)
+	require.NoError(t, destProvider.idStore.setUnderConstructionSnapshotFlag("testLedgerid"))
+	require.NoError(t, destProvider.pvtdataStoreProvider.BootstrapFromSnapshot("testLedgerid", metadata.lastBlockNum()))
+	destProvider.Close()
+
+	// recovery should drop the partially imported data and a fresh attempt should succeed
+	destProvider = testutilNewProvider(destConf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer destProvider.Close()
+	flag, err := destProvider.idStore.getUnderConstructionSnapshotFlag()
+	require.NoError(t, err)
+	require.Equal(t, "", flag)
+	destLgr, ledgerID, err := destProvider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer destLgr.Close()
+	require.Equal(t, "testLedgerid", ledgerID)
+	bcInfo, err := destLgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), bcInfo.Height)
+}
+
 func TestSnapshotDirPaths(t *testing.T) {
 	require.Equal(t, "/peerFSPath/snapshotRootDir/underConstruction", InProgressSnapshotsPath("/peerFSPath/snapshotRootDir"))
 	require.Equal(t, "/peerFSPath/snapshotRootDir/completed", CompletedSnapshotsPath("/peerFSPath/snapshotRootDir"))
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"testing"
+
+	encryptiontestutil "github.com/hyperledger/fabric/common/ledger/encryption/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencryptKVLedgers(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	csp, cleanup := encryptiontestutil.NewCryptoProvider(t)
+	defer cleanup()
+	oldKeyID, newKeyID := encryptiontestutil.GenerateKey(t, csp), encryptiontestutil.GenerateKey(t, csp)
+	rootFSPath := env.initializer.Config.RootFSPath
+
+	// populate the ledger in clear
+	env.initLedgerMgmt()
+	dataHelper := newSampleDataHelper(t)
+	h := env.newTestHelperCreateLgr("testLedger", t)
+	dataHelper.populateLedger(h)
+	dataHelper.verifyLedgerContent(h)
+	env.closeLedgerMgmt()
+
+	// the ledger cannot be opened with the encryption until it is encrypted
+	env.initializer.Encryptor = encryptiontestutil.NewEncryptor(t, csp, oldKeyID)
+	require.Panics(t, env.initLedgerMgmt)
+
+	require.NoError(t, kvledger.ReencryptKVLedgers(rootFSPath, env.initializer.Encryptor))
+	env.initLedgerMgmt()
+	h = env.newTestHelperOpenLgr("testLedger", t)
+	dataHelper.verifyLedgerContent(h)
+	env.closeLedgerMgmt()
+
+	// the encrypted ledger cannot be opened without the encryption
+	env.initializer.Encryptor = nil
+	require.Panics(t, env.initLedgerMgmt)
+
+	// rotate the key and re-encrypt the ledger
+	newEncryptor := encryptiontestutil.NewEncryptor(t, csp, newKeyID, oldKeyID)
+	require.NoError(t, kvledger.ReencryptKVLedgers(rootFSPath, newEncryptor))
+	env.initializer.Encryptor = encryptiontestutil.NewEncryptor(t, csp, newKeyID)
+	env.initLedgerMgmt()
+	h = env.newTestHelperOpenLgr("testLedger", t)
+	dataHelper.verifyLedgerContent(h)
+	env.closeLedgerMgmt()
+
+	// the offline operations work with the encrypted ledger
+	require.NoError(t, kvledger.RollbackKVLedger(rootFSPath, "testLedger", 2, env.initializer.Encryptor))
+	require.NoError(t, kvledger.ResetAllKVLedgers(rootFSPath, env.initializer.Encryptor))
+	env.initLedgerMgmt()
+	h = env.newTestHelperOpenLgr("testLedger", t)
+	h.verifyLedgerHeight(1)
+}
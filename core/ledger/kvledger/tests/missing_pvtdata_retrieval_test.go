@@ -67,7 +67,7 @@ func TestGetMissingPvtDataAfterRollback(t *testing.T) {
 	// rollback ledger to block 2
 	h.verifyLedgerHeight(5)
 	env.closeLedgerMgmt()
-	err := kvledger.RollbackKVLedger(env.initializer.Config.RootFSPath, "ledger1", 2)
+	err := kvledger.RollbackKVLedger(env.initializer.Config.RootFSPath, "ledger1", 2, nil)
 	require.NoError(t, err)
 	env.initLedgerMgmt()
 
@@ -165,3 +165,43 @@ func TestBTL(t *testing.T) {
 		r.pvtdataShouldNotContain("cc1", "coll2")                   // <cc1, coll2> shold have been purged from the pvtdata storage
 	})
 }
+
+func TestPurgePvtdata(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	env.initLedgerMgmt()
+	h := env.newTestHelperCreateLgr("ledger1", t)
+	collConf := []*collConf{{name: "coll1", btl: 0}}
+
+	// deploy cc1 with 'collConf'
+	h.simulateDeployTx("cc1", collConf)
+	h.cutBlockAndCommitLegacy()
+
+	// commit pvtdata writes in block 2.
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key1", "value1")
+		s.setPvtdata("cc1", "coll1", "key2", "value2")
+	})
+	h.cutBlockAndCommitLegacy()
+
+	// purge key1 in block 3
+	h.simulateDataTx("", func(s *simulator) {
+		s.purgePvtdata("cc1", "coll1", "key1")
+	})
+	h.cutBlockAndCommitLegacy()
+
+	verifyPurged := func(h *testhelper) {
+		h.verifyPvtState("cc1", "coll1", "key1", "")       // key1 should have been removed from the state
+		h.verifyPvtState("cc1", "coll1", "key2", "value2") // key2 should still exist in the state
+		h.verifyBlockAndPvtData(2, nil, func(r *retrievedBlockAndPvtdata) {
+			r.pvtdataShouldNotContainKey(0, "cc1", "coll1", "key1")     // key1 should have been purged from the pvtdata storage
+			r.pvtdataShouldContain(0, "cc1", "coll1", "key2", "value2") // key2 should still exist in the pvtdata storage
+		})
+	}
+	verifyPurged(h)
+
+	// the purged data should not reappear when the statedb is rebuilt
+	env.closeAllLedgersAndDrop(rebuildableStatedb)
+	h = env.newTestHelperOpenLgr("ledger1", t)
+	verifyPurged(h)
+}
@@ -86,7 +86,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers/ledgersData.zip",
 			false,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.ResetAllKVLedgers(ledgerFSRoot))
+				require.NoError(t, kvledger.ResetAllKVLedgers(ledgerFSRoot, nil))
 			},
 			true,
 		},
@@ -96,7 +96,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers/ledgersData.zip",
 			false,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, 0))
+				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, 0, nil))
 			},
 			true,
 		},
@@ -106,7 +106,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers/ledgersData.zip",
 			false,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, h.currentHeight()/2+1))
+				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, h.currentHeight()/2+1, nil))
 			},
 			false,
 		},
@@ -116,7 +116,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers_with_commit_hashes/ledgersData.zip",
 			true,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.ResetAllKVLedgers(ledgerFSRoot))
+				require.NoError(t, kvledger.ResetAllKVLedgers(ledgerFSRoot, nil))
 			},
 			true,
 		},
@@ -126,7 +126,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers_with_commit_hashes/ledgersData.zip",
 			true,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, 0))
+				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, 0, nil))
 			},
 			true,
 		},
@@ -136,7 +136,7 @@ func TestV11CommitHashes(t *testing.T) {
 			"testdata/v11/sample_ledgers_with_commit_hashes/ledgersData.zip",
 			true,
 			func(h *testhelper, ledgerFSRoot string) {
-				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, h.currentHeight()/2+1))
+				require.NoError(t, kvledger.RollbackKVLedger(ledgerFSRoot, h.lgrid, h.currentHeight()/2+1, nil))
 			},
 			true,
 		},
@@ -199,6 +199,23 @@ func (r *retrievedBlockAndPvtdata) pvtdataShouldContain(txSeq int, ns, coll, key
 	r.assert.FailNow("Requested kv not found")
 }
 
+func (r *retrievedBlockAndPvtdata) pvtdataShouldNotContainKey(txSeq int, ns, coll, key string) {
+	txPvtData := r.BlockAndPvtData.PvtData[uint64(txSeq)]
+	for _, nsdata := range txPvtData.WriteSet.NsPvtRwset {
+		if nsdata.Namespace == ns {
+			for _, colldata := range nsdata.CollectionPvtRwset {
+				if colldata.CollectionName == coll {
+					rwset := &kvrwset.KVRWSet{}
+					r.assert.NoError(proto.Unmarshal(colldata.Rwset, rwset))
+					for _, w := range rwset.Writes {
+						r.assert.NotEqual(key, w.Key)
+					}
+				}
+			}
+		}
+	}
+}
+
 func (r *retrievedBlockAndPvtdata) pvtdataShouldNotContain(ns, coll string) {
 	allTxPvtData := r.BlockAndPvtData.PvtData
 	for _, txPvtData := range allTxPvtData {
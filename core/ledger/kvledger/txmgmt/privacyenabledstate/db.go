@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric-lib-go/healthz"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statesql"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
 )
@@ -41,6 +43,9 @@ type StateDBConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.StateDBConfig and not exposed to other components.
 	LevelDBPath string
+	// Encryptor encrypts the values in the stateDB when statedb type is "goleveldb".
+	// The values are stored in clear if it is nil.
+	Encryptor *encryption.Encryptor
 }
 
 // DBProvider encapsulates other providers such as VersionedDBProvider and
@@ -63,12 +68,17 @@ func NewDBProvider(
 	var vdbProvider statedb.VersionedDBProvider
 	var err error
 
-	if stateDBConf != nil && stateDBConf.StateDatabase == ledger.CouchDB {
+	switch {
+	case stateDBConf != nil && stateDBConf.StateDatabase == ledger.CouchDB:
 		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(stateDBConf.CouchDB, metricsProvider, sysNamespaces); err != nil {
 			return nil, err
 		}
-	} else {
-		if vdbProvider, err = stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath); err != nil {
+	case stateDBConf != nil && stateDBConf.StateDatabase == ledger.SQL:
+		if vdbProvider, err = statesql.NewVersionedDBProvider(stateDBConf.SQL); err != nil {
+			return nil, err
+		}
+	default:
+		if vdbProvider, err = stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath, stateDBConf.Encryptor); err != nil {
 			return nil, err
 		}
 	}
@@ -303,7 +313,11 @@ func (s *DB) HandleChaincodeDeploy(chaincodeDefinition *cceventmgmt.ChaincodeDef
 	if chaincodeDefinition == nil {
 		return errors.New("chaincode definition not found while creating couchdb index")
 	}
-	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, indexCapable.GetDBType())
+	dbType := indexCapable.GetDBType()
+	if source, ok := indexCapable.(statedb.IndexDefinitionsSource); ok {
+		dbType = source.GetIndexDefinitionsDBType()
+	}
+	dbArtifacts, err := ccprovider.ExtractFileEntries(dbArtifactsTar, dbType)
 	if err != nil {
 		logger.Errorf("Index creation: error extracting db artifacts from tar for chaincode [%s]: %s", chaincodeDefinition.Name, err)
 		return nil
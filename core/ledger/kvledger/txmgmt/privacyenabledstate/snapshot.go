@@ -9,9 +9,11 @@ package privacyenabledstate
 import (
 	"hash"
 	"path/filepath"
+	"strings"
 
 	"github.com/hyperledger/fabric/common/ledger/snapshot"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/internal/fileutil"
 	"github.com/pkg/errors"
@@ -119,7 +121,23 @@ func (p *DBProvider) BootstapDBFromPubStateAndPvtStateHashes(dbname string, save
 	if worldStateSnapshotReader.pubState == nil && worldStateSnapshotReader.pvtStateHashes == nil {
 		return p.VersionedDBProvider.BootstrapDBFromState(dbname, savepoint, nil, byte(0))
 	}
-	return p.VersionedDBProvider.BootstrapDBFromState(dbname, savepoint, worldStateSnapshotReader, dbValueFormat)
+	if err := p.VersionedDBProvider.BootstrapDBFromState(dbname, savepoint, worldStateSnapshotReader, dbValueFormat); err != nil {
+		return err
+	}
+	return p.setMetadataHintForImportedNamespaces(dbname, worldStateSnapshotReader.namespaces())
+}
+
+// setMetadataHintForImportedNamespaces marks all the imported namespaces in the metadata hint bookkeeper.
+// The snapshot files do not carry the information whether any of the keys in a namespace has associated
+// metadata. Hence, we conservatively mark all the namespaces, which only disables the optimization in the
+// function `GetStateMetadata` for these namespaces
+func (p *DBProvider) setMetadataHintForImportedNamespaces(dbname string, namespaces map[string]struct{}) error {
+	bookkeeper := p.bookkeepingProvider.GetDBHandle(dbname, bookkeeping.MetadataPresenceIndicator)
+	batch := bookkeeper.NewUpdateBatch()
+	for ns := range namespaces {
+		batch.Put([]byte(ns), []byte{})
+	}
+	return bookkeeper.WriteBatch(batch, true)
 }
 
 // snapshotWriter generates two files, a data file and a metadata file. The datafile contains a series of tuples <key, dbValue>
@@ -275,6 +293,22 @@ func (r *worldStateSnapshotReader) Next() (*statedb.CompositeKey, []byte, error)
 	return nil, nil, nil
 }
 
+// namespaces returns the chaincode namespaces present in the snapshot files. For the private state hashes,
+// the namespace of the chaincode is derived from the namespace used for the collection
+func (r *worldStateSnapshotReader) namespaces() map[string]struct{} {
+	namespaces := map[string]struct{}{}
+	for _, sr := range []*snapshotReader{r.pubState, r.pvtStateHashes} {
+		if sr == nil {
+			continue
+		}
+		for _, row := range sr.cursor.metadata {
+			ns := strings.Split(row.namespace, nsJoiner)[0]
+			namespaces[ns] = struct{}{}
+		}
+	}
+	return namespaces
+}
+
 func (r *worldStateSnapshotReader) Close() {
 	if r == nil {
 		return
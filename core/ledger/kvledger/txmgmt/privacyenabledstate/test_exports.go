@@ -64,6 +64,7 @@ func (env *LevelDBTestEnv) Init(t testing.TB) {
 		&StateDBConfig{
 			&ledger.StateDBConfig{},
 			dbPath,
+			nil,
 		},
 		[]string{"lscc", "_lifecycle"},
 	)
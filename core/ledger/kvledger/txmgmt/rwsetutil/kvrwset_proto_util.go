@@ -6,7 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 
 package rwsetutil
 
-import "github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger/util"
+)
 
 // SetRawReads sets the 'readsInfo' field to raw KVReads performed by the query
 func SetRawReads(rqi *kvrwset.RangeQueryInfo, kvReads []*kvrwset.KVRead) {
@@ -21,3 +27,37 @@ func SetRawReads(rqi *kvrwset.RangeQueryInfo, kvReads []*kvrwset.KVRead) {
 func SetMerkelSummary(rqi *kvrwset.RangeQueryInfo, merkleSummary *kvrwset.QueryReadsMerkleSummary) {
 	rqi.ReadsInfo = &kvrwset.RangeQueryInfo_ReadsMerkleHashes{ReadsMerkleHashes: merkleSummary}
 }
+
+// TrimmedKeyHashes is used when the hash of the serialized private rwset of a collection does not match
+// the hash present in the hashed rwset. This is expected when the private rwset is retrieved from a private
+// data store from which some of its keys were purged later. If every private write matches a hashed write,
+// this function returns the hashes of the keys of the hashed writes that are missing in the private rwset.
+// Otherwise, the second return value is false
+func TrimmedKeyHashes(collPvtRwSet []byte, hashedRwSet *kvrwset.HashedRWSet) ([][]byte, bool) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtRwSet, kvRWSet); err != nil {
+		return nil, false
+	}
+	hashedWrites := map[string]*kvrwset.KVWriteHash{}
+	for _, kvWriteHash := range hashedRwSet.GetHashedWrites() {
+		hashedWrites[string(kvWriteHash.KeyHash)] = kvWriteHash
+	}
+	for _, kvWrite := range kvRWSet.Writes {
+		keyHash := string(util.ComputeStringHash(kvWrite.Key))
+		kvWriteHash, ok := hashedWrites[keyHash]
+		if !ok || kvWriteHash.IsDelete != kvWrite.IsDelete {
+			return nil, false
+		}
+		if !kvWrite.IsDelete && !bytes.Equal(util.ComputeHash(kvWrite.Value), kvWriteHash.ValueHash) {
+			return nil, false
+		}
+		delete(hashedWrites, keyHash)
+	}
+	var trimmed [][]byte
+	for _, kvWriteHash := range hashedRwSet.GetHashedWrites() {
+		if _, ok := hashedWrites[string(kvWriteHash.KeyHash)]; ok {
+			trimmed = append(trimmed, kvWriteHash.KeyHash)
+		}
+	}
+	return trimmed, true
+}
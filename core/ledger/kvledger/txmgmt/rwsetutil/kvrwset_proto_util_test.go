@@ -9,7 +9,9 @@ package rwsetutil
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,3 +44,33 @@ func TestSetMerkelSummary(t *testing.T) {
 	SetMerkelSummary(rqi, merkleSummary)
 	require.Equal(t, expected, rqi)
 }
+
+func TestTrimmedKeyHashes(t *testing.T) {
+	rwsetBuilder := NewRWSetBuilder()
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key1", []byte("value1"))
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key2", []byte("value2"))
+	rwsetBuilder.AddToPvtAndHashedWriteSet("ns", "coll", "key3", nil)
+	simResults, err := rwsetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	hashedRwSet := &kvrwset.HashedRWSet{}
+	require.NoError(t, proto.Unmarshal(simResults.PubSimulationResults.NsRwset[0].CollectionHashedRwset[0].HashedRwset, hashedRwSet))
+
+	trimmedRwSet := func(writes ...*kvrwset.KVWrite) []byte {
+		b, err := proto.Marshal(&kvrwset.KVRWSet{Writes: writes})
+		require.NoError(t, err)
+		return b
+	}
+
+	trimmed, ok := TrimmedKeyHashes(trimmedRwSet(&kvrwset.KVWrite{Key: "key1", Value: []byte("value1")}), hashedRwSet)
+	require.True(t, ok)
+	require.Equal(t, [][]byte{util.ComputeStringHash("key2"), util.ComputeStringHash("key3")}, trimmed)
+
+	_, ok = TrimmedKeyHashes(trimmedRwSet(&kvrwset.KVWrite{Key: "key1", Value: []byte("tampered")}), hashedRwSet)
+	require.False(t, ok)
+	_, ok = TrimmedKeyHashes(trimmedRwSet(&kvrwset.KVWrite{Key: "key3", Value: []byte("value3")}), hashedRwSet)
+	require.False(t, ok)
+	_, ok = TrimmedKeyHashes(trimmedRwSet(&kvrwset.KVWrite{Key: "key4", IsDelete: true}), hashedRwSet)
+	require.False(t, ok)
+	_, ok = TrimmedKeyHashes([]byte("not a proto"), hashedRwSet)
+	require.False(t, ok)
+}
@@ -0,0 +1,177 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: kv_rwset.proto
+
+package kvrwsetpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	kvrwset "github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// HashedRWSet is a kvrwset.HashedRWSet whose hashed writes may purge the
+// private data keys. It is wire compatible with kvrwset.HashedRWSet.
+type HashedRWSet struct {
+	HashedReads          []*kvrwset.KVReadHash          `protobuf:"bytes,1,rep,name=hashed_reads,json=hashedReads,proto3" json:"hashed_reads,omitempty"`
+	HashedWrites         []*KVWriteHash                 `protobuf:"bytes,2,rep,name=hashed_writes,json=hashedWrites,proto3" json:"hashed_writes,omitempty"`
+	MetadataWrites       []*kvrwset.KVMetadataWriteHash `protobuf:"bytes,3,rep,name=metadata_writes,json=metadataWrites,proto3" json:"metadata_writes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *HashedRWSet) Reset()         { *m = HashedRWSet{} }
+func (m *HashedRWSet) String() string { return proto.CompactTextString(m) }
+func (*HashedRWSet) ProtoMessage()    {}
+func (*HashedRWSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30a199b558b5b72d, []int{0}
+}
+
+func (m *HashedRWSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashedRWSet.Unmarshal(m, b)
+}
+func (m *HashedRWSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashedRWSet.Marshal(b, m, deterministic)
+}
+func (m *HashedRWSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashedRWSet.Merge(m, src)
+}
+func (m *HashedRWSet) XXX_Size() int {
+	return xxx_messageInfo_HashedRWSet.Size(m)
+}
+func (m *HashedRWSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashedRWSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashedRWSet proto.InternalMessageInfo
+
+func (m *HashedRWSet) GetHashedReads() []*kvrwset.KVReadHash {
+	if m != nil {
+		return m.HashedReads
+	}
+	return nil
+}
+
+func (m *HashedRWSet) GetHashedWrites() []*KVWriteHash {
+	if m != nil {
+		return m.HashedWrites
+	}
+	return nil
+}
+
+func (m *HashedRWSet) GetMetadataWrites() []*kvrwset.KVMetadataWriteHash {
+	if m != nil {
+		return m.MetadataWrites
+	}
+	return nil
+}
+
+// KVWriteHash is a kvrwset.KVWriteHash that also records whether the write
+// purges the private data key. It is wire compatible with kvrwset.KVWriteHash.
+type KVWriteHash struct {
+	KeyHash   []byte `protobuf:"bytes,1,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	IsDelete  bool   `protobuf:"varint,2,opt,name=is_delete,json=isDelete,proto3" json:"is_delete,omitempty"`
+	ValueHash []byte `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	// IsPurge is true if, in addition to being deleted, all the historical
+	// versions of the private data key are purged on commit
+	IsPurge              bool     `protobuf:"varint,4,opt,name=is_purge,json=isPurge,proto3" json:"is_purge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVWriteHash) Reset()         { *m = KVWriteHash{} }
+func (m *KVWriteHash) String() string { return proto.CompactTextString(m) }
+func (*KVWriteHash) ProtoMessage()    {}
+func (*KVWriteHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_30a199b558b5b72d, []int{1}
+}
+
+func (m *KVWriteHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVWriteHash.Unmarshal(m, b)
+}
+func (m *KVWriteHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVWriteHash.Marshal(b, m, deterministic)
+}
+func (m *KVWriteHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVWriteHash.Merge(m, src)
+}
+func (m *KVWriteHash) XXX_Size() int {
+	return xxx_messageInfo_KVWriteHash.Size(m)
+}
+func (m *KVWriteHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVWriteHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVWriteHash proto.InternalMessageInfo
+
+func (m *KVWriteHash) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *KVWriteHash) GetIsDelete() bool {
+	if m != nil {
+		return m.IsDelete
+	}
+	return false
+}
+
+func (m *KVWriteHash) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *KVWriteHash) GetIsPurge() bool {
+	if m != nil {
+		return m.IsPurge
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*HashedRWSet)(nil), "kvrwsetpb.HashedRWSet")
+	proto.RegisterType((*KVWriteHash)(nil), "kvrwsetpb.KVWriteHash")
+}
+
+func init() { proto.RegisterFile("kv_rwset.proto", fileDescriptor_30a199b558b5b72d) }
+
+var fileDescriptor_30a199b558b5b72d = []byte{
+	// 316 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xcf, 0x4a, 0xf3, 0x40,
+	0x14, 0xc5, 0x49, 0xfb, 0xf1, 0xb5, 0x9d, 0xd4, 0x0a, 0x23, 0x48, 0xfc, 0x07, 0xa5, 0x6e, 0xba,
+	0x9a, 0x01, 0x05, 0x37, 0xee, 0x44, 0x41, 0x28, 0x45, 0x89, 0xd0, 0x82, 0x9b, 0x30, 0xe9, 0x5c,
+	0x93, 0x21, 0x09, 0x09, 0x33, 0x93, 0xd4, 0xae, 0x7c, 0x3e, 0xdf, 0x4a, 0x72, 0x3b, 0xad, 0x75,
+	0x97, 0x73, 0xee, 0xfd, 0xdd, 0x13, 0xe6, 0x90, 0x51, 0xd6, 0x44, 0x7a, 0x6d, 0xc0, 0xb2, 0x4a,
+	0x97, 0xb6, 0xa4, 0x83, 0xac, 0x41, 0x59, 0xc5, 0xe7, 0xd7, 0x39, 0xc8, 0x04, 0x34, 0x47, 0xcd,
+	0x9d, 0xcf, 0xff, 0xee, 0x4f, 0xbe, 0x3d, 0xe2, 0x3f, 0x0b, 0x93, 0x82, 0x0c, 0x97, 0x6f, 0x60,
+	0xe9, 0x1d, 0x19, 0xa6, 0x28, 0x23, 0x0d, 0x42, 0x9a, 0xc0, 0x1b, 0x77, 0xa7, 0xfe, 0xcd, 0x09,
+	0x73, 0x38, 0x9b, 0x2d, 0x42, 0x10, 0xb2, 0x25, 0x42, 0x7f, 0xbb, 0xd8, 0x6a, 0x43, 0xef, 0xc9,
+	0x91, 0xe3, 0xd6, 0x5a, 0x59, 0x30, 0x41, 0x07, 0xc1, 0x53, 0xb6, 0xff, 0x1f, 0x36, 0x5b, 0x2c,
+	0xdb, 0x11, 0xb2, 0x2e, 0x04, 0x0d, 0x43, 0x9f, 0xc8, 0x71, 0x01, 0x56, 0x48, 0x61, 0xc5, 0x0e,
+	0xef, 0x22, 0x7e, 0x79, 0x90, 0x3b, 0x77, 0x1b, 0xbf, 0x47, 0x46, 0xc5, 0xa1, 0x65, 0x26, 0x5f,
+	0xc4, 0x3f, 0xc8, 0xa0, 0x67, 0xa4, 0x9f, 0xc1, 0x26, 0x6a, 0x93, 0x02, 0x6f, 0xec, 0x4d, 0x87,
+	0x61, 0x2f, 0x83, 0x0d, 0x8e, 0x2e, 0xc8, 0x40, 0x99, 0x48, 0x42, 0x0e, 0x16, 0x82, 0xce, 0xd8,
+	0x9b, 0xf6, 0xc3, 0xbe, 0x32, 0x8f, 0xa8, 0xe9, 0x15, 0x21, 0x8d, 0xc8, 0x6b, 0xd8, 0x92, 0x5d,
+	0x24, 0x07, 0xe8, 0xec, 0xce, 0x2a, 0x13, 0x55, 0xb5, 0x4e, 0x20, 0xf8, 0x87, 0x68, 0x4f, 0x99,
+	0xd7, 0x56, 0x3e, 0xbc, 0xbc, 0xcf, 0x13, 0x65, 0xd3, 0x3a, 0x66, 0xab, 0xb2, 0xe0, 0xe9, 0xa6,
+	0x02, 0xed, 0x3a, 0xf8, 0x10, 0xb1, 0x56, 0x2b, 0xbe, 0x2a, 0x35, 0x70, 0x67, 0x65, 0x8d, 0xfb,
+	0xb0, 0x9f, 0x45, 0x52, 0xd8, 0x6d, 0x4d, 0xb5, 0x55, 0x39, 0xdf, 0x3f, 0x59, 0xfc, 0x1f, 0x4b,
+	0xba, 0xfd, 0x19, 0x00, 0x51, 0xec, 0x23, 0xca, 0xe6, 0x01, 0x00, 0x00,
+}
@@ -48,6 +48,7 @@ type collHashRwBuilder struct {
 	readMap          map[string]*kvrwset.KVReadHash
 	writeMap         map[string]*kvrwset.KVWriteHash
 	metadataWriteMap map[string]*kvrwset.KVMetadataWriteHash
+	purgedKeyHashes  map[string]bool
 	pvtDataHash      []byte
 }
 
@@ -113,7 +114,19 @@ func (b *RWSetBuilder) AddToHashedReadSet(ns string, coll string, key string, ve
 func (b *RWSetBuilder) AddToPvtAndHashedWriteSet(ns string, coll string, key string, value []byte) {
 	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, value)
 	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
-	b.getOrCreateCollHashedRwBuilder(ns, coll).writeMap[key] = kvWriteHash
+	collHashedRwBuilder := b.getOrCreateCollHashedRwBuilder(ns, coll)
+	collHashedRwBuilder.writeMap[key] = kvWriteHash
+	delete(collHashedRwBuilder.purgedKeyHashes, string(kvWriteHash.KeyHash))
+}
+
+// AddToPvtAndHashedWriteSetForPurge adds a key to the private and hashed write-set as a delete
+// that, in addition, causes all the historical versions of the key to be purged on commit
+func (b *RWSetBuilder) AddToPvtAndHashedWriteSetForPurge(ns string, coll string, key string) {
+	kvWrite, kvWriteHash := newPvtKVWriteAndHash(key, nil)
+	b.getOrCreateCollPvtRwBuilder(ns, coll).writeMap[key] = kvWrite
+	collHashedRwBuilder := b.getOrCreateCollHashedRwBuilder(ns, coll)
+	collHashedRwBuilder.writeMap[key] = kvWriteHash
+	collHashedRwBuilder.purgedKeyHashes[string(kvWriteHash.KeyHash)] = true
 }
 
 // AddToHashedMetadataWriteSet adds a metadata to a key in the hashed write-set
@@ -245,6 +258,10 @@ func (b *collHashRwBuilder) build() *CollHashedRwSet {
 	util.GetValuesBySortedKeys(&(b.readMap), &readSet)
 	util.GetValuesBySortedKeys(&(b.writeMap), &writeSet)
 	util.GetValuesBySortedKeys(&(b.metadataWriteMap), &metadataWriteSet)
+	var purgedKeyHashes map[string]bool
+	if len(b.purgedKeyHashes) > 0 {
+		purgedKeyHashes = b.purgedKeyHashes
+	}
 	return &CollHashedRwSet{
 		CollectionName: b.collName,
 		HashedRwSet: &kvrwset.HashedRWSet{
@@ -252,7 +269,8 @@ func (b *collHashRwBuilder) build() *CollHashedRwSet {
 			HashedWrites:   writeSet,
 			MetadataWrites: metadataWriteSet,
 		},
-		PvtRwSetHash: b.pvtDataHash,
+		PvtRwSetHash:    b.pvtDataHash,
+		PurgedKeyHashes: purgedKeyHashes,
 	}
 }
 
@@ -330,6 +348,7 @@ func newCollHashRwBuilder(collName string) *collHashRwBuilder {
 		make(map[string]*kvrwset.KVReadHash),
 		make(map[string]*kvrwset.KVWriteHash),
 		make(map[string]*kvrwset.KVMetadataWriteHash),
+		make(map[string]bool),
 		nil,
 	}
 }
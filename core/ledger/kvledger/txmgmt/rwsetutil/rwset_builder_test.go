@@ -357,3 +357,42 @@ func serializeTestProtoMsg(t *testing.T, protoMsg proto.Message) []byte {
 	require.NoError(t, err)
 	return msgBytes
 }
+
+func TestTxSimulationResultWithPurge(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("pvt-ns1-coll1-key1-value"))
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key2")
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+
+	// the purge is recorded as a delete in the pvt rwset
+	pvtNs1Coll1 := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			newKVWrite("key1", []byte("pvt-ns1-coll1-key1-value")),
+			newKVWrite("key2", nil),
+		},
+	}
+	require.Equal(t, serializeTestProtoMsg(t, pvtNs1Coll1), actualSimRes.PvtSimulationResults.NsPvtRwset[0].CollectionPvtRwset[0].Rwset)
+
+	// and as a delete marked as purge in the hashed rwset
+	txRwSet, err := TxRwSetFromProtoMsg(actualSimRes.PubSimulationResults)
+	require.NoError(t, err)
+	collHashedRwSet := txRwSet.NsRwSets[0].CollHashedRwSets[0]
+	hashedWrites := collHashedRwSet.HashedRwSet.HashedWrites
+	require.Len(t, hashedWrites, 2)
+	require.False(t, collHashedRwSet.IsPurge(hashedWrites[0]))
+	require.True(t, hashedWrites[1].IsDelete)
+	require.True(t, collHashedRwSet.IsPurge(hashedWrites[1]))
+	require.Equal(t, util.ComputeStringHash("key2"), hashedWrites[1].KeyHash)
+
+	// a later write of the key replaces the purge
+	rwSetBuilder = NewRWSetBuilder()
+	rwSetBuilder.AddToPvtAndHashedWriteSetForPurge("ns1", "coll1", "key2")
+	rwSetBuilder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key2", []byte("pvt-ns1-coll1-key2-value"))
+	actualSimRes, err = rwSetBuilder.GetTxSimulationResults()
+	require.NoError(t, err)
+	txRwSet, err = TxRwSetFromProtoMsg(actualSimRes.PubSimulationResults)
+	require.NoError(t, err)
+	require.Nil(t, txRwSet.NsRwSets[0].CollHashedRwSets[0].PurgedKeyHashes)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil/kvrwsetpb"
 	"github.com/hyperledger/fabric/core/ledger/util"
 )
 
@@ -45,6 +46,15 @@ type CollHashedRwSet struct {
 	CollectionName string
 	HashedRwSet    *kvrwset.HashedRWSet
 	PvtRwSetHash   []byte
+	// PurgedKeyHashes contains the hashes of the keys whose hashed writes are purges. It is
+	// carried in the field 'is_purge' of the 'kvrwsetpb.KVWriteHash' proto message
+	PurgedKeyHashes map[string]bool
+}
+
+// IsPurge returns true if the hashed write purges the private data key, i.e., in addition to
+// deleting the key, causes all the historical versions of the key to be purged on commit
+func (collHashedRwSet *CollHashedRwSet) IsPurge(kvWriteHash *kvrwset.KVWriteHash) bool {
+	return collHashedRwSet.PurgedKeyHashes[string(kvWriteHash.KeyHash)]
 }
 
 // GetPvtDataHash returns the PvtRwSetHash for a given namespace and collection
@@ -63,6 +73,21 @@ func (txRwSet *TxRwSet) GetPvtDataHash(ns, coll string) []byte {
 	return nil
 }
 
+// GetHashedRwSet returns the HashedRwSet for a given namespace and collection
+func (txRwSet *TxRwSet) GetHashedRwSet(ns, coll string) *kvrwset.HashedRWSet {
+	for _, nsRwSet := range txRwSet.NsRwSets {
+		if nsRwSet.NameSpace != ns {
+			continue
+		}
+		for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName == coll {
+				return collHashedRwSet.HashedRwSet
+			}
+		}
+	}
+	return nil
+}
+
 func (nsRwSet *NsRwSet) getPvtDataHash(coll string) []byte {
 	for _, collHashedRwSet := range nsRwSet.CollHashedRwSets {
 		if collHashedRwSet.CollectionName != coll {
@@ -214,20 +239,60 @@ func (collHashedRwSet *CollHashedRwSet) toProtoMsg() (*rwset.CollectionHashedRea
 		CollectionName: collHashedRwSet.CollectionName,
 		PvtRwsetHash:   collHashedRwSet.PvtRwSetHash,
 	}
-	if protoMsg.HashedRwset, err = proto.Marshal(collHashedRwSet.HashedRwSet); err != nil {
+	if protoMsg.HashedRwset, err = collHashedRwSet.marshalHashedRwSet(); err != nil {
 		return nil, err
 	}
 	return protoMsg, nil
 }
 
+// marshalHashedRwSet serializes the hashed rwset. When some of the hashed writes are purges, the
+// hashed rwset is serialized as a 'kvrwsetpb.HashedRWSet' proto message, which carries the flag
+func (collHashedRwSet *CollHashedRwSet) marshalHashedRwSet() ([]byte, error) {
+	if len(collHashedRwSet.PurgedKeyHashes) == 0 {
+		return proto.Marshal(collHashedRwSet.HashedRwSet)
+	}
+	hashedRwSet := &kvrwsetpb.HashedRWSet{
+		HashedReads:    collHashedRwSet.HashedRwSet.GetHashedReads(),
+		MetadataWrites: collHashedRwSet.HashedRwSet.GetMetadataWrites(),
+	}
+	for _, kvWriteHash := range collHashedRwSet.HashedRwSet.GetHashedWrites() {
+		hashedRwSet.HashedWrites = append(hashedRwSet.HashedWrites, &kvrwsetpb.KVWriteHash{
+			KeyHash:   kvWriteHash.KeyHash,
+			IsDelete:  kvWriteHash.IsDelete,
+			ValueHash: kvWriteHash.ValueHash,
+			IsPurge:   collHashedRwSet.IsPurge(kvWriteHash),
+		})
+	}
+	return proto.Marshal(hashedRwSet)
+}
+
 func collHashedRwSetFromProtoMsg(protoMsg *rwset.CollectionHashedReadWriteSet) (*CollHashedRwSet, error) {
+	// the hashed rwset is deserialized as a 'kvrwsetpb.HashedRWSet' proto message, which is wire
+	// compatible with 'kvrwset.HashedRWSet' and in addition, carries the purge flag of the hashed writes
+	hashedRwSet := &kvrwsetpb.HashedRWSet{}
+	if err := proto.Unmarshal(protoMsg.HashedRwset, hashedRwSet); err != nil {
+		return nil, err
+	}
 	colHashedRwSet := &CollHashedRwSet{
 		CollectionName: protoMsg.CollectionName,
 		PvtRwSetHash:   protoMsg.PvtRwsetHash,
-		HashedRwSet:    &kvrwset.HashedRWSet{},
+		HashedRwSet: &kvrwset.HashedRWSet{
+			HashedReads:    hashedRwSet.HashedReads,
+			MetadataWrites: hashedRwSet.MetadataWrites,
+		},
 	}
-	if err := proto.Unmarshal(protoMsg.HashedRwset, colHashedRwSet.HashedRwSet); err != nil {
-		return nil, err
+	for _, kvWriteHash := range hashedRwSet.HashedWrites {
+		colHashedRwSet.HashedRwSet.HashedWrites = append(colHashedRwSet.HashedRwSet.HashedWrites, &kvrwset.KVWriteHash{
+			KeyHash:   kvWriteHash.KeyHash,
+			IsDelete:  kvWriteHash.IsDelete,
+			ValueHash: kvWriteHash.ValueHash,
+		})
+		if kvWriteHash.IsPurge {
+			if colHashedRwSet.PurgedKeyHashes == nil {
+				colHashedRwSet.PurgedKeyHashes = map[string]bool{}
+			}
+			colHashedRwSet.PurgedKeyHashes[string(kvWriteHash.KeyHash)] = true
+		}
 	}
 	return colHashedRwSet, nil
 }
@@ -125,6 +125,31 @@ func TestCollHashedRwSetConversion(t *testing.T) {
 	require.Equal(t, collHashedRwSet.PvtRwSetHash, collHashedRwSet1.PvtRwSetHash)
 }
 
+func TestCollHashedRwSetConversionWithPurge(t *testing.T) {
+	collHashedRwSet := sampleCollHashedRwSet("coll-1")
+	protoMsg, err := collHashedRwSet.toProtoMsg()
+	require.NoError(t, err)
+	// without purges, the hashed rwset is serialized as a 'kvrwset.HashedRWSet'
+	require.Equal(t, serializeTestProtoMsg(t, collHashedRwSet.HashedRwSet), protoMsg.HashedRwset)
+
+	collHashedRwSet.PurgedKeyHashes = map[string]bool{"Key-4-hash": true}
+	protoMsg, err = collHashedRwSet.toProtoMsg()
+	require.NoError(t, err)
+	collHashedRwSet1, err := collHashedRwSetFromProtoMsg(protoMsg)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(collHashedRwSet.HashedRwSet, collHashedRwSet1.HashedRwSet), "proto messages are not equal")
+	require.Equal(t, collHashedRwSet.PurgedKeyHashes, collHashedRwSet1.PurgedKeyHashes)
+	require.False(t, collHashedRwSet1.IsPurge(collHashedRwSet1.HashedRwSet.HashedWrites[0]))
+	require.True(t, collHashedRwSet1.IsPurge(collHashedRwSet1.HashedRwSet.HashedWrites[1]))
+
+	// with purges, the hashed rwset can still be deserialized as a 'kvrwset.HashedRWSet'
+	hashedRwSet := &kvrwset.HashedRWSet{}
+	require.NoError(t, proto.Unmarshal(protoMsg.HashedRwset, hashedRwSet))
+	require.Equal(t, collHashedRwSet.HashedRwSet.HashedReads[0].KeyHash, hashedRwSet.HashedReads[0].KeyHash)
+	require.Equal(t, collHashedRwSet.HashedRwSet.HashedWrites[1].KeyHash, hashedRwSet.HashedWrites[1].KeyHash)
+	require.True(t, hashedRwSet.HashedWrites[1].IsDelete)
+}
+
 func TestNumCollections(t *testing.T) {
 	var txRwSet *TxRwSet
 	require.Equal(t, 0, txRwSet.NumCollections())         // nil TxRwSet
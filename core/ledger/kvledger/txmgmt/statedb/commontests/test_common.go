@@ -926,6 +926,63 @@ func TestPaginatedRangeQuery(t *testing.T, dbProvider statedb.VersionedDBProvide
 	require.NoError(t, err)
 }
 
+// TestReverseRangeQuery tests range queries that return the keys in descending order
+func TestReverseRangeQuery(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	db, err := dbProvider.GetDBHandle("testreverserangequery", nil)
+	require.NoError(t, err)
+	db.Open()
+	defer db.Close()
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 9; i++ {
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf(`{"asset_name": "marble%d"}`, i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns2", "key10", []byte(`{"asset_name": "marble10"}`), version.NewHeight(1, 10))
+	db.ApplyUpdates(batch, version.NewHeight(2, 1))
+
+	itr, err := db.GetStateReverseRangeScanIterator("ns1", "", "")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key9", "key8", "key7", "key6", "key5", "key4", "key3", "key2", "key1"})
+
+	// startKey is included and endKey is excluded
+	itr, err = db.GetStateReverseRangeScanIterator("ns1", "key7", "key3")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key7", "key6", "key5", "key4"})
+
+	itr, err = db.GetStateReverseRangeScanIterator("ns1", "key3", "")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key3", "key2", "key1"})
+
+	itr, err = db.GetStateReverseRangeScanIterator("ns1", "", "key7")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key9", "key8"})
+
+	// a start key that does not exist
+	itr, err = db.GetStateReverseRangeScanIterator("ns1", "key55", "key3")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key5", "key4"})
+
+	itr, err = db.GetStateReverseRangeScanIterator("ns2", "", "")
+	require.NoError(t, err)
+	testItr(t, itr, []string{"key10"})
+
+	// pagination, the bookmark is used as the startKey of the next page
+	pageItr, err := db.GetStateReverseRangeScanIteratorWithPagination("ns1", "", "key2", 3)
+	require.NoError(t, err)
+	TestItrWithoutClose(t, pageItr, []string{"key9", "key8", "key7"})
+	bookmark := pageItr.GetBookmarkAndClose()
+	require.Equal(t, "key6", bookmark)
+
+	pageItr, err = db.GetStateReverseRangeScanIteratorWithPagination("ns1", bookmark, "key2", 3)
+	require.NoError(t, err)
+	TestItrWithoutClose(t, pageItr, []string{"key6", "key5", "key4"})
+	bookmark = pageItr.GetBookmarkAndClose()
+	require.Equal(t, "key3", bookmark)
+
+	pageItr, err = db.GetStateReverseRangeScanIteratorWithPagination("ns1", bookmark, "key2", 3)
+	require.NoError(t, err)
+	TestItrWithoutClose(t, pageItr, []string{"key3"})
+}
+
 // TestRangeQuerySpecialCharacters tests range queries for keys with special characters and/or non-English characters
 func TestRangeQuerySpecialCharacters(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	db, err := dbProvider.GetDBHandle("testrangequeryspecialcharacters", nil)
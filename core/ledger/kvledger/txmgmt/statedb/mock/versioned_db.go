@@ -151,42 +151,49 @@ type VersionedDB struct {
 		result1 statedb.QueryResultsIterator
 		result2 error
 	}
-	GetVersionStub        func(string, string) (*version.Height, error)
-	getVersionMutex       sync.RWMutex
-	getVersionArgsForCall []struct {
+	GetStateReverseRangeScanIteratorStub        func(string, string, string) (statedb.ResultsIterator, error)
+	getStateReverseRangeScanIteratorMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorArgsForCall []struct {
 		arg1 string
 		arg2 string
+		arg3 string
 	}
-	getVersionReturns struct {
-		result1 *version.Height
+	getStateReverseRangeScanIteratorReturns struct {
+		result1 statedb.ResultsIterator
 		result2 error
 	}
-	getVersionReturnsOnCall map[int]struct {
-		result1 *version.Height
+	getStateReverseRangeScanIteratorReturnsOnCall map[int]struct {
+		result1 statedb.ResultsIterator
 		result2 error
 	}
-	ImportStateStub        func(statedb.FullScanIterator, byte) error
-	importStateMutex       sync.RWMutex
-	importStateArgsForCall []struct {
-		arg1 statedb.FullScanIterator
-		arg2 byte
+	GetStateReverseRangeScanIteratorWithPaginationStub        func(string, string, string, int32) (statedb.QueryResultsIterator, error)
+	getStateReverseRangeScanIteratorWithPaginationMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
 	}
-	importStateReturns struct {
-		result1 error
+	getStateReverseRangeScanIteratorWithPaginationReturns struct {
+		result1 statedb.QueryResultsIterator
+		result2 error
 	}
-	importStateReturnsOnCall map[int]struct {
-		result1 error
+	getStateReverseRangeScanIteratorWithPaginationReturnsOnCall map[int]struct {
+		result1 statedb.QueryResultsIterator
+		result2 error
 	}
-	IsEmptyStub        func() (bool, error)
-	isEmptyMutex       sync.RWMutex
-	isEmptyArgsForCall []struct {
+	GetVersionStub        func(string, string) (*version.Height, error)
+	getVersionMutex       sync.RWMutex
+	getVersionArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-	isEmptyReturns struct {
-		result1 bool
+	getVersionReturns struct {
+		result1 *version.Height
 		result2 error
 	}
-	isEmptyReturnsOnCall map[int]struct {
-		result1 bool
+	getVersionReturnsOnCall map[int]struct {
+		result1 *version.Height
 		result2 error
 	}
 	OpenStub        func() error
@@ -866,182 +873,197 @@ func (fake *VersionedDB) GetStateRangeScanIteratorWithPaginationReturnsOnCall(i
 	}{result1, result2}
 }
 
-func (fake *VersionedDB) GetVersion(arg1 string, arg2 string) (*version.Height, error) {
-	fake.getVersionMutex.Lock()
-	ret, specificReturn := fake.getVersionReturnsOnCall[len(fake.getVersionArgsForCall)]
-	fake.getVersionArgsForCall = append(fake.getVersionArgsForCall, struct {
+func (fake *VersionedDB) GetStateReverseRangeScanIterator(arg1 string, arg2 string, arg3 string) (statedb.ResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorReturnsOnCall[len(fake.getStateReverseRangeScanIteratorArgsForCall)]
+	fake.getStateReverseRangeScanIteratorArgsForCall = append(fake.getStateReverseRangeScanIteratorArgsForCall, struct {
 		arg1 string
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("GetVersion", []interface{}{arg1, arg2})
-	fake.getVersionMutex.Unlock()
-	if fake.GetVersionStub != nil {
-		return fake.GetVersionStub(arg1, arg2)
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateReverseRangeScanIterator", []interface{}{arg1, arg2, arg3})
+	fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorStub != nil {
+		return fake.GetStateReverseRangeScanIteratorStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getVersionReturns
+	fakeReturns := fake.getStateReverseRangeScanIteratorReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *VersionedDB) GetVersionCallCount() int {
-	fake.getVersionMutex.RLock()
-	defer fake.getVersionMutex.RUnlock()
-	return len(fake.getVersionArgsForCall)
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorCallCount() int {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorArgsForCall)
 }
 
-func (fake *VersionedDB) GetVersionCalls(stub func(string, string) (*version.Height, error)) {
-	fake.getVersionMutex.Lock()
-	defer fake.getVersionMutex.Unlock()
-	fake.GetVersionStub = stub
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorCalls(stub func(string, string, string) (statedb.ResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = stub
 }
 
-func (fake *VersionedDB) GetVersionArgsForCall(i int) (string, string) {
-	fake.getVersionMutex.RLock()
-	defer fake.getVersionMutex.RUnlock()
-	argsForCall := fake.getVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorArgsForCall(i int) (string, string, string) {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *VersionedDB) GetVersionReturns(result1 *version.Height, result2 error) {
-	fake.getVersionMutex.Lock()
-	defer fake.getVersionMutex.Unlock()
-	fake.GetVersionStub = nil
-	fake.getVersionReturns = struct {
-		result1 *version.Height
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorReturns(result1 statedb.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	fake.getStateReverseRangeScanIteratorReturns = struct {
+		result1 statedb.ResultsIterator
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *VersionedDB) GetVersionReturnsOnCall(i int, result1 *version.Height, result2 error) {
-	fake.getVersionMutex.Lock()
-	defer fake.getVersionMutex.Unlock()
-	fake.GetVersionStub = nil
-	if fake.getVersionReturnsOnCall == nil {
-		fake.getVersionReturnsOnCall = make(map[int]struct {
-			result1 *version.Height
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorReturnsOnCall(i int, result1 statedb.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	if fake.getStateReverseRangeScanIteratorReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorReturnsOnCall = make(map[int]struct {
+			result1 statedb.ResultsIterator
 			result2 error
 		})
 	}
-	fake.getVersionReturnsOnCall[i] = struct {
-		result1 *version.Height
+	fake.getStateReverseRangeScanIteratorReturnsOnCall[i] = struct {
+		result1 statedb.ResultsIterator
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *VersionedDB) ImportState(arg1 statedb.FullScanIterator, arg2 byte) error {
-	fake.importStateMutex.Lock()
-	ret, specificReturn := fake.importStateReturnsOnCall[len(fake.importStateArgsForCall)]
-	fake.importStateArgsForCall = append(fake.importStateArgsForCall, struct {
-		arg1 statedb.FullScanIterator
-		arg2 byte
-	}{arg1, arg2})
-	fake.recordInvocation("ImportState", []interface{}{arg1, arg2})
-	fake.importStateMutex.Unlock()
-	if fake.ImportStateStub != nil {
-		return fake.ImportStateStub(arg1, arg2)
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPagination(arg1 string, arg2 string, arg3 string, arg4 int32) (statedb.QueryResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)]
+	fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall = append(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateReverseRangeScanIteratorWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorWithPaginationStub != nil {
+		return fake.GetStateReverseRangeScanIteratorWithPaginationStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.importStateReturns
-	return fakeReturns.result1
+	fakeReturns := fake.getStateReverseRangeScanIteratorWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *VersionedDB) ImportStateCallCount() int {
-	fake.importStateMutex.RLock()
-	defer fake.importStateMutex.RUnlock()
-	return len(fake.importStateArgsForCall)
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPaginationCallCount() int {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)
 }
 
-func (fake *VersionedDB) ImportStateCalls(stub func(statedb.FullScanIterator, byte) error) {
-	fake.importStateMutex.Lock()
-	defer fake.importStateMutex.Unlock()
-	fake.ImportStateStub = stub
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPaginationCalls(stub func(string, string, string, int32) (statedb.QueryResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = stub
 }
 
-func (fake *VersionedDB) ImportStateArgsForCall(i int) (statedb.FullScanIterator, byte) {
-	fake.importStateMutex.RLock()
-	defer fake.importStateMutex.RUnlock()
-	argsForCall := fake.importStateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPaginationArgsForCall(i int) (string, string, string, int32) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *VersionedDB) ImportStateReturns(result1 error) {
-	fake.importStateMutex.Lock()
-	defer fake.importStateMutex.Unlock()
-	fake.ImportStateStub = nil
-	fake.importStateReturns = struct {
-		result1 error
-	}{result1}
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPaginationReturns(result1 statedb.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	fake.getStateReverseRangeScanIteratorWithPaginationReturns = struct {
+		result1 statedb.QueryResultsIterator
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *VersionedDB) ImportStateReturnsOnCall(i int, result1 error) {
-	fake.importStateMutex.Lock()
-	defer fake.importStateMutex.Unlock()
-	fake.ImportStateStub = nil
-	if fake.importStateReturnsOnCall == nil {
-		fake.importStateReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *VersionedDB) GetStateReverseRangeScanIteratorWithPaginationReturnsOnCall(i int, result1 statedb.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	if fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 statedb.QueryResultsIterator
+			result2 error
 		})
 	}
-	fake.importStateReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[i] = struct {
+		result1 statedb.QueryResultsIterator
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *VersionedDB) IsEmpty() (bool, error) {
-	fake.isEmptyMutex.Lock()
-	ret, specificReturn := fake.isEmptyReturnsOnCall[len(fake.isEmptyArgsForCall)]
-	fake.isEmptyArgsForCall = append(fake.isEmptyArgsForCall, struct {
-	}{})
-	fake.recordInvocation("IsEmpty", []interface{}{})
-	fake.isEmptyMutex.Unlock()
-	if fake.IsEmptyStub != nil {
-		return fake.IsEmptyStub()
+func (fake *VersionedDB) GetVersion(arg1 string, arg2 string) (*version.Height, error) {
+	fake.getVersionMutex.Lock()
+	ret, specificReturn := fake.getVersionReturnsOnCall[len(fake.getVersionArgsForCall)]
+	fake.getVersionArgsForCall = append(fake.getVersionArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetVersion", []interface{}{arg1, arg2})
+	fake.getVersionMutex.Unlock()
+	if fake.GetVersionStub != nil {
+		return fake.GetVersionStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.isEmptyReturns
+	fakeReturns := fake.getVersionReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *VersionedDB) IsEmptyCallCount() int {
-	fake.isEmptyMutex.RLock()
-	defer fake.isEmptyMutex.RUnlock()
-	return len(fake.isEmptyArgsForCall)
+func (fake *VersionedDB) GetVersionCallCount() int {
+	fake.getVersionMutex.RLock()
+	defer fake.getVersionMutex.RUnlock()
+	return len(fake.getVersionArgsForCall)
 }
 
-func (fake *VersionedDB) IsEmptyCalls(stub func() (bool, error)) {
-	fake.isEmptyMutex.Lock()
-	defer fake.isEmptyMutex.Unlock()
-	fake.IsEmptyStub = stub
+func (fake *VersionedDB) GetVersionCalls(stub func(string, string) (*version.Height, error)) {
+	fake.getVersionMutex.Lock()
+	defer fake.getVersionMutex.Unlock()
+	fake.GetVersionStub = stub
 }
 
-func (fake *VersionedDB) IsEmptyReturns(result1 bool, result2 error) {
-	fake.isEmptyMutex.Lock()
-	defer fake.isEmptyMutex.Unlock()
-	fake.IsEmptyStub = nil
-	fake.isEmptyReturns = struct {
-		result1 bool
+func (fake *VersionedDB) GetVersionArgsForCall(i int) (string, string) {
+	fake.getVersionMutex.RLock()
+	defer fake.getVersionMutex.RUnlock()
+	argsForCall := fake.getVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *VersionedDB) GetVersionReturns(result1 *version.Height, result2 error) {
+	fake.getVersionMutex.Lock()
+	defer fake.getVersionMutex.Unlock()
+	fake.GetVersionStub = nil
+	fake.getVersionReturns = struct {
+		result1 *version.Height
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *VersionedDB) IsEmptyReturnsOnCall(i int, result1 bool, result2 error) {
-	fake.isEmptyMutex.Lock()
-	defer fake.isEmptyMutex.Unlock()
-	fake.IsEmptyStub = nil
-	if fake.isEmptyReturnsOnCall == nil {
-		fake.isEmptyReturnsOnCall = make(map[int]struct {
-			result1 bool
+func (fake *VersionedDB) GetVersionReturnsOnCall(i int, result1 *version.Height, result2 error) {
+	fake.getVersionMutex.Lock()
+	defer fake.getVersionMutex.Unlock()
+	fake.GetVersionStub = nil
+	if fake.getVersionReturnsOnCall == nil {
+		fake.getVersionReturnsOnCall = make(map[int]struct {
+			result1 *version.Height
 			result2 error
 		})
 	}
-	fake.isEmptyReturnsOnCall[i] = struct {
-		result1 bool
+	fake.getVersionReturnsOnCall[i] = struct {
+		result1 *version.Height
 		result2 error
 	}{result1, result2}
 }
@@ -1189,12 +1211,12 @@ func (fake *VersionedDB) Invocations() map[string][][]interface{} {
 	defer fake.getStateRangeScanIteratorMutex.RUnlock()
 	fake.getStateRangeScanIteratorWithPaginationMutex.RLock()
 	defer fake.getStateRangeScanIteratorWithPaginationMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
 	fake.getVersionMutex.RLock()
 	defer fake.getVersionMutex.RUnlock()
-	fake.importStateMutex.RLock()
-	defer fake.importStateMutex.RUnlock()
-	fake.isEmptyMutex.RLock()
-	defer fake.isEmptyMutex.RUnlock()
 	fake.openMutex.RLock()
 	defer fake.openMutex.RUnlock()
 	fake.validateKeyValueMutex.RLock()
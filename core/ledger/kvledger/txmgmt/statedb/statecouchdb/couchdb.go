@@ -831,10 +831,11 @@ func (dbclient *couchDatabase) readDoc(id string) (*couchDoc, string, error) {
 //readDocRange method provides function to a range of documents based on the start and end keys
 //startKey and endKey can also be empty strings.  If startKey and endKey are empty, all documents are returned
 //This function provides a limit option to specify the max number of entries and is supplied by config.
+//If descending is true, the documents are returned in descending order of the keys, starting at the startKey.
 //Skip is reserved for possible future future use.
-func (dbclient *couchDatabase) readDocRange(startKey, endKey string, limit int32) ([]*queryResult, string, error) {
+func (dbclient *couchDatabase) readDocRange(startKey, endKey string, limit int32, descending bool) ([]*queryResult, string, error) {
 	dbName := dbclient.dbName
-	couchdbLogger.Debugf("[%s] Entering ReadDocRange()  startKey=%s, endKey=%s", dbName, startKey, endKey)
+	couchdbLogger.Debugf("[%s] Entering ReadDocRange()  startKey=%s, endKey=%s, descending=%t", dbName, startKey, endKey, descending)
 
 	var results []*queryResult
 
@@ -850,6 +851,9 @@ func (dbclient *couchDatabase) readDocRange(startKey, endKey string, limit int32
 	queryParms.Add("include_docs", "true")
 	queryParms.Add("inclusive_end", "false") // endkey should be exclusive to be consistent with goleveldb
 	queryParms.Add("attachments", "true")    // get the attachments as well
+	if descending {
+		queryParms.Add("descending", "true")
+	}
 
 	//Append the startKey if provided
 	if startKey != "" {
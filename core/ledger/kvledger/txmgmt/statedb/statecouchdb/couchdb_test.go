@@ -164,7 +164,7 @@ func TestBadCouchDBInstance(t *testing.T) {
 	require.Error(t, err, "Error should have been thrown with deleteDoc and invalid connection")
 
 	//Test readDocRange with bad connection
-	_, _, err = badDB.readDocRange("1", "2", 1000)
+	_, _, err = badDB.readDocRange("1", "2", 1000, false)
 	require.Error(t, err, "Error should have been thrown with readDocRange and invalid connection")
 
 	//Test queryDocuments with bad connection
@@ -723,7 +723,7 @@ func TestPrefixScan(t *testing.T) {
 	_, _, geterr := db.readDoc(endKey)
 	require.NoError(t, geterr, "Error when trying to get lastkey")
 
-	resultsPtr, _, geterr := db.readDocRange(startKey, endKey, 1000)
+	resultsPtr, _, geterr := db.readDocRange(startKey, endKey, 1000, false)
 	require.NoError(t, geterr, "Error when trying to perform a range scan")
 	require.NotNil(t, resultsPtr)
 	results := resultsPtr
@@ -1271,7 +1271,7 @@ func TestRichQuery(t *testing.T) {
 	require.Equal(t, 6, len(queryResult))
 
 	//Test a range query ---------------------------------------------------------------------------------------------
-	queryResult, _, err = db.readDocRange("marble02", "marble06", 10000)
+	queryResult, _, err = db.readDocRange("marble02", "marble06", 10000, false)
 	require.NoError(t, err, "Error when attempting to execute a range query")
 
 	//There should be 4 results
@@ -532,7 +532,28 @@ func (vdb *VersionedDB) GetStateRangeScanIteratorWithPagination(namespace string
 	if err != nil {
 		return nil, err
 	}
-	return newQueryScanner(namespace, db, "", internalQueryLimit, pageSize, "", startKey, endKey)
+	return newQueryScanner(namespace, db, "", internalQueryLimit, pageSize, "", startKey, endKey, false)
+}
+
+// GetStateReverseRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *VersionedDB) GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateReverseRangeScanIteratorWithPagination implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+// pageSize limits the number of results returned
+func (vdb *VersionedDB) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering GetStateReverseRangeScanIteratorWithPagination namespace: %s  startKey: %s  endKey: %s  pageSize: %d", namespace, startKey, endKey, pageSize)
+	internalQueryLimit := vdb.couchInstance.internalQueryLimit()
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return newQueryScanner(namespace, db, "", internalQueryLimit, pageSize, "", startKey, endKey, true)
 }
 
 func (scanner *queryScanner) getNextStateRangeScanResults() error {
@@ -544,7 +565,7 @@ func (scanner *queryScanner) getNextStateRangeScanResults() error {
 		}
 	}
 	queryResult, nextStartKey, err := rangeScanFilterCouchInternalDocs(scanner.db,
-		scanner.queryDefinition.startKey, scanner.queryDefinition.endKey, queryLimit)
+		scanner.queryDefinition.startKey, scanner.queryDefinition.endKey, queryLimit, scanner.queryDefinition.descending)
 	if err != nil {
 		return err
 	}
@@ -561,12 +582,12 @@ func (scanner *queryScanner) getNextStateRangeScanResults() error {
 }
 
 func rangeScanFilterCouchInternalDocs(db *couchDatabase,
-	startKey, endKey string, queryLimit int32,
+	startKey, endKey string, queryLimit int32, descending bool,
 ) ([]*queryResult, string, error) {
 	var finalResults []*queryResult
 	var finalNextStartKey string
 	for {
-		results, nextStartKey, err := db.readDocRange(startKey, endKey, queryLimit)
+		results, nextStartKey, err := db.readDocRange(startKey, endKey, queryLimit, descending)
 		if err != nil {
 			logger.Debugf("Error calling ReadDocRange(): %s\n", err.Error())
 			return nil, "", err
@@ -588,7 +609,7 @@ func rangeScanFilterCouchInternalDocs(db *couchDatabase,
 	}
 	var err error
 	for i := 0; isCouchInternalKey(finalNextStartKey); i++ {
-		_, finalNextStartKey, err = db.readDocRange(finalNextStartKey, endKey, 1)
+		_, finalNextStartKey, err = db.readDocRange(finalNextStartKey, endKey, 1, descending)
 		logger.Debugf("i=%d, finalNextStartKey=%s", i, finalNextStartKey)
 		if err != nil {
 			return nil, "", err
@@ -623,7 +644,7 @@ func (vdb *VersionedDB) ExecuteQueryWithPagination(namespace, query, bookmark st
 	if err != nil {
 		return nil, err
 	}
-	return newQueryScanner(namespace, db, queryString, internalQueryLimit, pageSize, bookmark, "", "")
+	return newQueryScanner(namespace, db, queryString, internalQueryLimit, pageSize, bookmark, "", "", false)
 }
 
 // executeQueryWithBookmark executes a "paging" query with a bookmark, this method allows a
@@ -974,6 +995,7 @@ type queryDefinition struct {
 	endKey             string
 	query              string
 	internalQueryLimit int32
+	descending         bool
 }
 
 type paginationInfo struct {
@@ -988,8 +1010,8 @@ type resultsInfo struct {
 }
 
 func newQueryScanner(namespace string, db *couchDatabase, query string, internalQueryLimit,
-	limit int32, bookmark, startKey, endKey string) (*queryScanner, error) {
-	scanner := &queryScanner{namespace, db, &queryDefinition{startKey, endKey, query, internalQueryLimit}, &paginationInfo{-1, limit, bookmark}, &resultsInfo{0, nil}, false}
+	limit int32, bookmark, startKey, endKey string, descending bool) (*queryScanner, error) {
+	scanner := &queryScanner{namespace, db, &queryDefinition{startKey, endKey, query, internalQueryLimit, descending}, &paginationInfo{-1, limit, bookmark}, &resultsInfo{0, nil}, false}
 	var err error
 	// query is defined, then execute the query and return the records and bookmark
 	if scanner.queryDefinition.query != "" {
@@ -1124,7 +1146,7 @@ func newDBsScanner(dbsToScan []*namespaceDB, prefetchLimit int32, toSkipKeysFrom
 
 func (s *dbsScanner) beginNextDBScan() error {
 	dbUnderScan := s.dbs[s.nextDBToScanIndex]
-	queryScanner, err := newQueryScanner(dbUnderScan.ns, dbUnderScan.db, "", s.prefetchLimit, 0, "", "", "")
+	queryScanner, err := newQueryScanner(dbUnderScan.ns, dbUnderScan.db, "", s.prefetchLimit, 0, "", "", "", false)
 	if err != nil {
 		return errors.WithMessagef(
 			err,
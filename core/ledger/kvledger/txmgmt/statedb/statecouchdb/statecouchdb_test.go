@@ -495,6 +495,13 @@ func TestPaginatedRangeQuery(t *testing.T) {
 	commontests.TestPaginatedRangeQuery(t, vdbEnv.DBProvider)
 }
 
+func TestReverseRangeQuery(t *testing.T) {
+	vdbEnv.init(t, nil)
+	defer vdbEnv.cleanup()
+
+	commontests.TestReverseRangeQuery(t, vdbEnv.DBProvider)
+}
+
 func TestRangeQuerySpecialCharacters(t *testing.T) {
 	vdbEnv.init(t, nil)
 	defer vdbEnv.cleanup()
@@ -1023,17 +1030,17 @@ func TestRangeScanWithCouchInternalDocsPresent(t *testing.T) {
 	// The Keys in db are in this order
 	// Key-1, Key-2, Key-3,_design/indexAssetNam, _design/indexAssetValue, key-1, key-2, key-3
 	// query different ranges and verify results
-	s, err := newQueryScanner("ns", couchDatabse, "", 3, 3, "", "", "")
+	s, err := newQueryScanner("ns", couchDatabse, "", 3, 3, "", "", "", false)
 	require.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2", "Key-3"})
 	require.Equal(t, "key-1", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 4, 4, "", "", "")
+	s, err = newQueryScanner("ns", couchDatabse, "", 4, 4, "", "", "", false)
 	require.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2", "Key-3", "key-1"})
 	require.Equal(t, "key-2", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "", "")
+	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "", "", false)
 	require.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2"})
 	require.Equal(t, "Key-3", s.queryDefinition.startKey)
@@ -1041,7 +1048,7 @@ func TestRangeScanWithCouchInternalDocsPresent(t *testing.T) {
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-3", "key-1"})
 	require.Equal(t, "key-2", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "_", "")
+	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "_", "", false)
 	require.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"key-1", "key-2"})
 	require.Equal(t, "key-3", s.queryDefinition.startKey)
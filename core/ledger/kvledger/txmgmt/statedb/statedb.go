@@ -49,6 +49,20 @@ type VersionedDB interface {
 	// pageSize parameter limits the number of returned results
 	// The returned ResultsIterator contains results of type *VersionedKV
 	GetStateRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (QueryResultsIterator, error)
+	// GetStateReverseRangeScanIterator returns an iterator that contains all the key-values between given key ranges
+	// in descending order of the keys. The iteration starts at the startKey and moves towards the endKey.
+	// startKey is inclusive
+	// endKey is exclusive
+	// An empty startKey refers to the last available key and an empty endKey refers to the first available key.
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (ResultsIterator, error)
+	// GetStateReverseRangeScanIteratorWithPagination returns an iterator that contains all the key-values between
+	// given key ranges in descending order of the keys.
+	// startKey is inclusive
+	// endKey is exclusive
+	// pageSize parameter limits the number of returned results
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (QueryResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type *VersionedKV.
 	ExecuteQuery(namespace, query string) (ResultsIterator, error)
 	// ExecuteQueryWithPagination executes the given query and
@@ -108,6 +122,14 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, indexFilesData map[string][]byte) error
 }
 
+// IndexDefinitionsSource interface is implemented by the IndexCapable databases that
+// create their indexes from the index definitions packaged for another type of database
+type IndexDefinitionsSource interface {
+	// GetIndexDefinitionsDBType returns the type of the database whose index definitions,
+	// packaged with the chaincodes, are used for creating the indexes
+	GetIndexDefinitionsDBType() string
+}
+
 // FullScanIterator provides a mean to iterate over entire statedb. The intended use of this iterator
 // is to generate the snapshot files for the statedb
 type FullScanIterator interface {
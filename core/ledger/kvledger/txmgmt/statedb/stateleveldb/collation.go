@@ -0,0 +1,230 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The type markers used in the order preserving encoding of the JSON scalars in the index entries.
+// The order of the markers follows the collation of couchdb, i.e., null < false < true < numbers < strings
+const (
+	nullMarker   = byte(0x01)
+	falseMarker  = byte(0x02)
+	trueMarker   = byte(0x03)
+	numberMarker = byte(0x04)
+	stringMarker = byte(0x05)
+)
+
+var (
+	// a zero byte in a string is escaped as {0x00, escapedZeroByte} so that the
+	// encoded string can be terminated with stringTerminator without ambiguity
+	escapedZeroByte  = byte(0xff)
+	stringTerminator = []byte{0x00, 0x01}
+)
+
+// decodeJSON decodes the given JSON, retaining the numbers as json.Number so that
+// the numbers are returned in the query results as they appear in the values
+func decodeJSON(jsonBytes []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// encodeIndexValue encodes a JSON scalar such that the byte order of the encoded values is the same as the
+// order of the values. Strings are ordered by their UTF-8 bytes, unlike the unicode collation of couchdb.
+// Arrays, objects, and numbers that cannot be represented as a float64 are not indexable
+func encodeIndexValue(v interface{}) ([]byte, bool) {
+	switch val := v.(type) {
+	case nil:
+		return []byte{nullMarker}, true
+	case bool:
+		if val {
+			return []byte{trueMarker}, true
+		}
+		return []byte{falseMarker}, true
+	case json.Number:
+		f, ok := toFloat(val)
+		if !ok {
+			return nil, false
+		}
+		return encodeNumber(f), true
+	case string:
+		return encodeString(val), true
+	default:
+		return nil, false
+	}
+}
+
+func encodeNumber(f float64) []byte {
+	if f == 0 {
+		// -0 and 0 are the same value
+		f = 0
+	}
+	bits := math.Float64bits(f)
+	if f < 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	encoded := make([]byte, 9)
+	encoded[0] = numberMarker
+	binary.BigEndian.PutUint64(encoded[1:], bits)
+	return encoded
+}
+
+func encodeString(s string) []byte {
+	encoded := make([]byte, 0, len(s)+3)
+	encoded = append(encoded, stringMarker)
+	for i := 0; i < len(s); i++ {
+		encoded = append(encoded, s[i])
+		if s[i] == 0x00 {
+			encoded = append(encoded, escapedZeroByte)
+		}
+	}
+	return append(encoded, stringTerminator...)
+}
+
+// indexValueLength returns the length of the encoded value present at the beginning of the given bytes
+func indexValueLength(encoded []byte) (int, error) {
+	if len(encoded) == 0 {
+		return 0, errors.New("unexpected end of the encoded index value")
+	}
+	switch encoded[0] {
+	case nullMarker, falseMarker, trueMarker:
+		return 1, nil
+	case numberMarker:
+		if len(encoded) < 9 {
+			return 0, errors.New("unexpected end of the encoded index value")
+		}
+		return 9, nil
+	case stringMarker:
+		for i := 1; i < len(encoded)-1; i++ {
+			if encoded[i] != 0x00 {
+				continue
+			}
+			if encoded[i+1] == stringTerminator[1] {
+				return i + 2, nil
+			}
+			// skip the escaped zero byte
+			i++
+		}
+		return 0, errors.New("unexpected end of the encoded index value")
+	default:
+		return 0, errors.Errorf("unexpected type marker [%#x] in the encoded index value", encoded[0])
+	}
+}
+
+// typeRange returns the range [start, end) of the encodings of the values that have the same type as the given value
+func typeRange(encoded []byte) ([]byte, []byte) {
+	return []byte{encoded[0]}, []byte{encoded[0] + 1}
+}
+
+// successor returns the smallest key that is greater than all the keys that have the given prefix
+func successor(prefix []byte) []byte {
+	s := append([]byte{}, prefix...)
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] != 0xff {
+			s[i]++
+			return s[:i+1]
+		}
+	}
+	return nil
+}
+
+func toFloat(n json.Number) (float64, bool) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	return f, err == nil
+}
+
+// compareValues compares two JSON values of the same type, which is either number or string.
+// The returned bool is false if the values are not comparable
+func compareValues(a, b interface{}) (int, bool) {
+	switch aVal := a.(type) {
+	case json.Number:
+		bVal, ok := b.(json.Number)
+		if !ok {
+			return 0, false
+		}
+		af, aOK := toFloat(aVal)
+		bf, bOK := toFloat(bVal)
+		if !aOK || !bOK {
+			return 0, false
+		}
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case string:
+		bVal, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case aVal < bVal:
+			return -1, true
+		case aVal > bVal:
+			return 1, true
+		default:
+			return 0, true
+		}
+	default:
+		return 0, false
+	}
+}
+
+// equalValues returns true if the two JSON values are equal. The numbers are compared by their values
+func equalValues(a, b interface{}) bool {
+	switch aVal := a.(type) {
+	case json.Number:
+		c, ok := compareValues(aVal, b)
+		return ok && c == 0
+	case []interface{}:
+		bVal, ok := b.([]interface{})
+		if !ok || len(aVal) != len(bVal) {
+			return false
+		}
+		for i := range aVal {
+			if !equalValues(aVal[i], bVal[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bVal, ok := b.(map[string]interface{})
+		if !ok || len(aVal) != len(bVal) {
+			return false
+		}
+		for k, v := range aVal {
+			bv, ok := bVal[k]
+			if !ok || !equalValues(v, bv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
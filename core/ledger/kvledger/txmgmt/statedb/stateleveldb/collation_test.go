@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexValueEncodingOrder(t *testing.T) {
+	orderedValues := []interface{}{
+		nil,
+		false,
+		true,
+		json.Number("-1e10"),
+		json.Number("-2.5"),
+		json.Number("-0"),
+		json.Number("0.5"),
+		json.Number("1"),
+		json.Number("1000007"),
+		"",
+		"a",
+		"a\x00",
+		"a\x00b",
+		"ab",
+		"b",
+	}
+	var previous []byte
+	for _, v := range orderedValues {
+		encoded, ok := encodeIndexValue(v)
+		require.True(t, ok)
+		require.True(t, bytes.Compare(previous, encoded) < 0, "encoding of %#v is not greater than that of the previous value", v)
+		previous = encoded
+
+		// the length of the encoded value is recovered even when it is followed by other bytes
+		l, err := indexValueLength(append(encoded, []byte("key")...))
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), l)
+	}
+
+	_, ok := encodeIndexValue([]interface{}{"a"})
+	require.False(t, ok)
+	_, ok = encodeIndexValue(map[string]interface{}{})
+	require.False(t, ok)
+	_, err := indexValueLength([]byte{stringMarker, 'a', 0x00})
+	require.EqualError(t, err, "unexpected end of the encoded index value")
+}
+
+func TestEqualValues(t *testing.T) {
+	require.True(t, equalValues(json.Number("10"), json.Number("1e1")))
+	require.False(t, equalValues(json.Number("10"), "10"))
+	require.True(t, equalValues(
+		map[string]interface{}{"a": []interface{}{json.Number("1"), "x"}},
+		map[string]interface{}{"a": []interface{}{json.Number("1.0"), "x"}},
+	))
+	require.False(t, equalValues([]interface{}{"x"}, []interface{}{"x", "y"}))
+}
@@ -0,0 +1,377 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+var (
+	indexKeyPrefix           = []byte{'i'}
+	indexDefinitionKeyPrefix = []byte{'x'}
+	maxIndexBuildBatchSize   = 4 * 1024 * 1024
+)
+
+// indexDefinition is a secondary index on the fields of the JSON values in a namespace. The indexes are
+// defined via the same index files that are used for creating the json indexes in couchdb, for instance,
+// {"index":{"fields":["docType","owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	DDoc   string   `json:"ddoc,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// couchdbIndexFile is the format of the couchdb index files
+type couchdbIndexFile struct {
+	Index *struct {
+		Fields []json.RawMessage `json:"fields"`
+	} `json:"index"`
+	DDoc string `json:"ddoc"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// parseIndexDefinition parses a couchdb index file. The index takes its name from the file name if the
+// index file does not specify the name. The sort directions of the fields are ignored, as an index is
+// scanned in either direction
+func parseIndexDefinition(fileName string, indexFileContent []byte) (*indexDefinition, error) {
+	indexFile := &couchdbIndexFile{}
+	if err := json.Unmarshal(indexFileContent, indexFile); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the index definition")
+	}
+	if indexFile.Type != "" && indexFile.Type != "json" {
+		return nil, errors.Errorf("unsupported index type [%s]", indexFile.Type)
+	}
+	if indexFile.Index == nil || len(indexFile.Index.Fields) == 0 {
+		return nil, errors.New("the index definition does not specify the fields")
+	}
+
+	def := &indexDefinition{
+		Name: indexFile.Name,
+		DDoc: indexFile.DDoc,
+	}
+	if def.Name == "" {
+		def.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	for _, rawField := range indexFile.Index.Fields {
+		field, _, err := parseFieldWithDirection(rawField)
+		if err != nil {
+			return nil, err
+		}
+		def.Fields = append(def.Fields, field)
+	}
+	return def, nil
+}
+
+// parseFieldWithDirection parses a field in an index definition or a sort specification,
+// which is either a field name or an object of the form {"<field name>": "asc|desc"}
+func parseFieldWithDirection(rawField json.RawMessage) (string, bool, error) {
+	var field string
+	if err := json.Unmarshal(rawField, &field); err == nil {
+		if field == "" {
+			return "", false, errors.New("field name cannot be empty")
+		}
+		return field, false, nil
+	}
+	fieldWithDirection := map[string]string{}
+	if err := json.Unmarshal(rawField, &fieldWithDirection); err != nil || len(fieldWithDirection) != 1 {
+		return "", false, errors.Errorf("invalid field [%s]", rawField)
+	}
+	for field, direction := range fieldWithDirection {
+		switch direction {
+		case "asc":
+			return field, false, nil
+		case "desc":
+			return field, true, nil
+		default:
+			return "", false, errors.Errorf("invalid sort direction [%s] for field [%s]", direction, field)
+		}
+	}
+	return "", false, nil
+}
+
+// keyPrefix returns the prefix of the keys of the entries of the index in the given namespace
+func (d *indexDefinition) keyPrefix(ns string) []byte {
+	k := append(append([]byte{}, indexKeyPrefix...), []byte(ns)...)
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(d.Name)...)
+	return append(k, nsKeySep...)
+}
+
+// entryKey returns the key of the index entry for the given JSON value of the given key. The entry key is
+// made of the encoded values of the indexed fields followed by the key. The returned bool is false if the
+// JSON value is not covered by the index, i.e., any of the indexed fields is missing or is not a scalar
+func (d *indexDefinition) entryKey(ns, key string, doc interface{}) ([]byte, bool) {
+	k := d.keyPrefix(ns)
+	for _, field := range d.Fields {
+		fieldValue, ok := lookupField(doc, strings.Split(field, "."))
+		if !ok {
+			return nil, false
+		}
+		encoded, ok := encodeIndexValue(fieldValue)
+		if !ok {
+			return nil, false
+		}
+		k = append(k, encoded...)
+	}
+	return append(k, []byte(key)...), true
+}
+
+// keyFromEntryKey returns the key to which the given index entry belongs
+func (d *indexDefinition) keyFromEntryKey(ns string, entryKey []byte) (string, error) {
+	remaining := entryKey[len(d.keyPrefix(ns)):]
+	for range d.Fields {
+		l, err := indexValueLength(remaining)
+		if err != nil {
+			return "", errors.WithMessagef(err, "error while decoding entry of index [%s]", d.Name)
+		}
+		remaining = remaining[l:]
+	}
+	return string(remaining), nil
+}
+
+func (d *indexDefinition) sameAs(other *indexDefinition) bool {
+	if d.DDoc != other.DDoc || len(d.Fields) != len(other.Fields) {
+		return false
+	}
+	for i := range d.Fields {
+		if d.Fields[i] != other.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeIndexDefinitionKey(ns, indexName string) []byte {
+	k := append(append([]byte{}, indexDefinitionKeyPrefix...), []byte(ns)...)
+	k = append(k, nsKeySep...)
+	return append(k, []byte(indexName)...)
+}
+
+// indexes maintains the definitions of the indexes of a state db. The definitions are loaded
+// from the db on the first use and are guarded by a lock that also serializes the maintenance
+// of the index entries during the commits with the creation of the indexes
+type indexes struct {
+	sync.RWMutex
+	db          *leveldbhelper.DBHandle
+	loaded      bool
+	definitions map[string][]*indexDefinition
+}
+
+func newIndexes(db *leveldbhelper.DBHandle) *indexes {
+	return &indexes{db: db}
+}
+
+// load loads the index definitions from the db, if not already loaded. The caller is expected to hold the write lock
+func (idx *indexes) load() error {
+	if idx.loaded {
+		return nil
+	}
+	itr, err := idx.db.GetIterator(indexDefinitionKeyPrefix, successor(indexDefinitionKeyPrefix))
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	definitions := map[string][]*indexDefinition{}
+	for itr.Next() {
+		ns := string(itr.Key()[len(indexDefinitionKeyPrefix):])
+		ns = ns[:strings.Index(ns, string(nsKeySep))]
+		def := &indexDefinition{}
+		if err := json.Unmarshal(itr.Value(), def); err != nil {
+			return errors.Wrapf(err, "error while unmarshalling the definition of an index in namespace [%s]", ns)
+		}
+		definitions[ns] = append(definitions[ns], def)
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while loading the index definitions")
+	}
+	idx.definitions = definitions
+	idx.loaded = true
+	return nil
+}
+
+// namespaceIndexes returns the definitions of the indexes in the given namespace
+func (idx *indexes) namespaceIndexes(ns string) ([]*indexDefinition, error) {
+	idx.RLock()
+	loaded := idx.loaded
+	defs := idx.definitions[ns]
+	idx.RUnlock()
+	if loaded {
+		return defs, nil
+	}
+
+	idx.Lock()
+	defer idx.Unlock()
+	if err := idx.load(); err != nil {
+		return nil, err
+	}
+	return idx.definitions[ns], nil
+}
+
+// addEntryUpdates adds to the batch the updates of the entries of the indexes in the namespace for a
+// key whose value is being changed from oldValue to newValue. A nil value denotes an absent key.
+// The caller is expected to hold the write lock
+func (idx *indexes) addEntryUpdates(batch *leveldbhelper.UpdateBatch, ns, key string, oldValue, newValue []byte) {
+	oldDoc, oldIsJSON := decodeJSONValue(oldValue)
+	newDoc, newIsJSON := decodeJSONValue(newValue)
+	for _, def := range idx.definitions[ns] {
+		if oldIsJSON {
+			if entryKey, ok := def.entryKey(ns, key, oldDoc); ok {
+				batch.Delete(entryKey)
+			}
+		}
+		if newIsJSON {
+			if entryKey, ok := def.entryKey(ns, key, newDoc); ok {
+				batch.Put(entryKey, []byte{})
+			}
+		}
+	}
+}
+
+// decodeJSONValue decodes the value if it is a JSON object. The returned bool is false otherwise
+func decodeJSONValue(value []byte) (interface{}, bool) {
+	if value == nil {
+		return nil, false
+	}
+	doc, err := decodeJSON(value)
+	if err != nil {
+		return nil, false
+	}
+	_, isObject := doc.(map[string]interface{})
+	return doc, isObject
+}
+
+// create creates or redefines the indexes present in the given index files for the namespace. The index
+// files are processed in the order of the file names so that all the peers end up with the same indexes.
+// An index file that cannot be processed is skipped after logging the error
+func (idx *indexes) create(ns string, indexFilesData map[string][]byte, dbName string) error {
+	idx.Lock()
+	defer idx.Unlock()
+	if err := idx.load(); err != nil {
+		return err
+	}
+
+	var fileNames []string
+	for fileName := range indexFilesData {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		def, err := parseIndexDefinition(fileName, indexFilesData[fileName])
+		if err == nil {
+			err = idx.createIndex(ns, def)
+		}
+		if err != nil {
+			logger.Errorf("error creating index from file [%s] for chaincode [%s] on channel [%s]: %+v",
+				fileName, ns, dbName, err)
+			continue
+		}
+		logger.Infof("successfully created index present in the file [%s] for chaincode [%s] on channel [%s]",
+			fileName, ns, dbName)
+	}
+	return nil
+}
+
+// createIndex persists the index definition and builds the index entries for the existing keys in
+// the namespace. An existing index with the same name but a different definition is replaced
+func (idx *indexes) createIndex(ns string, def *indexDefinition) error {
+	defs := idx.definitions[ns]
+	for i, existing := range defs {
+		if existing.Name != def.Name {
+			continue
+		}
+		if existing.sameAs(def) {
+			return nil
+		}
+		if err := idx.deleteEntries(ns, existing); err != nil {
+			return err
+		}
+		defs = append(defs[:i:i], defs[i+1:]...)
+		break
+	}
+
+	defBytes, err := json.Marshal(def)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the index definition")
+	}
+	if err := idx.buildEntries(ns, def); err != nil {
+		return err
+	}
+	if err := idx.db.Put(encodeIndexDefinitionKey(ns, def.Name), defBytes, true); err != nil {
+		return err
+	}
+	idx.definitions[ns] = append(defs, def)
+	return nil
+}
+
+func (idx *indexes) buildEntries(ns string, def *indexDefinition) error {
+	dataStartKey := encodeDataKey(ns, "")
+	dataEndKey := dataKeyStarterForNextNamespace(ns)
+	itr, err := idx.db.GetIterator(dataStartKey, dataEndKey)
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	batch := idx.db.NewUpdateBatch()
+	batchSize := 0
+	for itr.Next() {
+		_, key := decodeDataKey(itr.Key())
+		vv, err := decodeValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		doc, isJSON := decodeJSONValue(vv.Value)
+		if !isJSON {
+			continue
+		}
+		entryKey, ok := def.entryKey(ns, key, doc)
+		if !ok {
+			continue
+		}
+		batch.Put(entryKey, []byte{})
+		batchSize += len(entryKey)
+		if batchSize >= maxIndexBuildBatchSize {
+			if err := idx.db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch.Reset()
+			batchSize = 0
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while building index [%s]", def.Name)
+	}
+	return idx.db.WriteBatch(batch, true)
+}
+
+func (idx *indexes) deleteEntries(ns string, def *indexDefinition) error {
+	prefix := def.keyPrefix(ns)
+	itr, err := idx.db.GetIterator(prefix, successor(prefix))
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	batch := idx.db.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(itr.Key())
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while deleting index [%s]", def.Name)
+	}
+	batch.Delete(encodeIndexDefinitionKey(ns, def.Name))
+	return idx.db.WriteBatch(batch, true)
+}
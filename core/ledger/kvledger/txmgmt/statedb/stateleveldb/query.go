@@ -0,0 +1,685 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+// errNoIndexForSort is returned for a query that specifies a sort that none of the indexes can serve.
+// The message is the same as that of the error returned by couchdb in this case
+var errNoIndexForSort = errors.New("No index exists for this sort, try indexing by the sort fields.")
+
+// query is a parsed couchdb style query. The following subset of the couchdb query language is supported
+//   - selector with the combination operators $and, $or, $nor, $not and the condition operators
+//     $eq, $ne, $gt, $gte, $lt, $lte, $exists, $in, $nin, including the implicit $eq and the nested fields
+//   - fields, sort (in a single direction), limit, skip, and use_index
+type query struct {
+	selector   condition
+	fields     [][]string
+	sortFields []string
+	sortDesc   bool
+	limit      int32
+	skip       int32
+	useIndex   *indexReference
+}
+
+type indexReference struct {
+	ddoc string
+	name string
+}
+
+func parseQuery(queryString string) (*query, error) {
+	decoded, err := decodeJSON([]byte(queryString))
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the query")
+	}
+	queryMap, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("the query must be a JSON object")
+	}
+	rawSelector, ok := queryMap["selector"]
+	if !ok {
+		return nil, errors.New("the query does not specify a selector")
+	}
+
+	q := &query{}
+	for k, v := range queryMap {
+		switch k {
+		case "selector":
+			selector, ok := rawSelector.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("the selector must be a JSON object")
+			}
+			conditions, err := parseSelector(selector, nil)
+			if err != nil {
+				return nil, err
+			}
+			q.selector = andCondition(conditions)
+		case "fields":
+			fields, ok := v.([]interface{})
+			if !ok {
+				return nil, errors.New("fields must be an array of field names")
+			}
+			for _, f := range fields {
+				field, ok := f.(string)
+				if !ok || field == "" {
+					return nil, errors.New("fields must be an array of field names")
+				}
+				q.fields = append(q.fields, strings.Split(field, "."))
+			}
+		case "sort":
+			if err := q.parseSort(v); err != nil {
+				return nil, err
+			}
+		case "limit":
+			if q.limit, err = parseNonNegativeInt(k, v); err != nil {
+				return nil, err
+			}
+		case "skip":
+			if q.skip, err = parseNonNegativeInt(k, v); err != nil {
+				return nil, err
+			}
+		case "use_index":
+			if q.useIndex, err = parseIndexReference(v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("unsupported query field [%s]", k)
+		}
+	}
+	return q, nil
+}
+
+func (q *query) parseSort(v interface{}) error {
+	sortSpec, ok := v.([]interface{})
+	if !ok {
+		return errors.New("sort must be an array")
+	}
+	for i, s := range sortSpec {
+		rawField, err := json.Marshal(s)
+		if err != nil {
+			return errors.Wrap(err, "error marshalling the sort field")
+		}
+		field, desc, err := parseFieldWithDirection(rawField)
+		if err != nil {
+			return err
+		}
+		if i > 0 && desc != q.sortDesc {
+			return errors.New("sort fields must all be in the same direction")
+		}
+		q.sortFields = append(q.sortFields, field)
+		q.sortDesc = desc
+	}
+	return nil
+}
+
+func parseNonNegativeInt(name string, v interface{}) (int32, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, errors.Errorf("%s must be a non-negative integer", name)
+	}
+	i, err := n.Int64()
+	if err != nil || i < 0 || i > int64(^uint32(0)>>1) {
+		return 0, errors.Errorf("%s must be a non-negative integer", name)
+	}
+	return int32(i), nil
+}
+
+// parseIndexReference parses the use_index field of a query, which is either the
+// design document name of the index or an array with the design document name and the index name
+func parseIndexReference(v interface{}) (*indexReference, error) {
+	switch val := v.(type) {
+	case string:
+		return &indexReference{ddoc: val}, nil
+	case []interface{}:
+		ref := &indexReference{}
+		if len(val) > 0 {
+			ref.ddoc, _ = val[0].(string)
+		}
+		if len(val) > 1 {
+			ref.name, _ = val[1].(string)
+		}
+		if len(val) > 2 || ref.ddoc == "" || (len(val) == 2 && ref.name == "") {
+			return nil, errors.New("use_index must be a design document name or an array of a design document name and an index name")
+		}
+		return ref, nil
+	default:
+		return nil, errors.New("use_index must be a design document name or an array of a design document name and an index name")
+	}
+}
+
+func (r *indexReference) refersTo(def *indexDefinition) bool {
+	ddoc := strings.TrimPrefix(r.ddoc, "_design/")
+	return ddoc == def.DDoc && (r.name == "" || r.name == def.Name)
+}
+
+// condition is a compiled selector or a part of it
+type condition interface {
+	matches(doc interface{}) bool
+}
+
+type andCondition []condition
+
+func (c andCondition) matches(doc interface{}) bool {
+	for _, sub := range c {
+		if !sub.matches(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+type orCondition []condition
+
+func (c orCondition) matches(doc interface{}) bool {
+	for _, sub := range c {
+		if sub.matches(doc) {
+			return true
+		}
+	}
+	return false
+}
+
+type notCondition struct {
+	condition
+}
+
+func (c notCondition) matches(doc interface{}) bool {
+	return !c.condition.matches(doc)
+}
+
+// fieldCondition is a condition operator applied on a field
+type fieldCondition struct {
+	path     []string
+	operator string
+	operand  interface{}
+}
+
+func (c *fieldCondition) matches(doc interface{}) bool {
+	value, exists := lookupField(doc, c.path)
+	if c.operator == "$exists" {
+		return exists == c.operand.(bool)
+	}
+	if !exists {
+		return false
+	}
+
+	switch c.operator {
+	case "$eq":
+		return equalValues(value, c.operand)
+	case "$ne":
+		return !equalValues(value, c.operand)
+	case "$gt", "$gte", "$lt", "$lte":
+		cmp, ok := compareValues(value, c.operand)
+		if !ok {
+			return false
+		}
+		switch c.operator {
+		case "$gt":
+			return cmp > 0
+		case "$gte":
+			return cmp >= 0
+		case "$lt":
+			return cmp < 0
+		default:
+			return cmp <= 0
+		}
+	case "$in", "$nin":
+		found := false
+		for _, operand := range c.operand.([]interface{}) {
+			if equalValues(value, operand) {
+				found = true
+				break
+			}
+		}
+		return found == (c.operator == "$in")
+	default:
+		return false
+	}
+}
+
+// parseSelector compiles the given selector, or a nested part of it for the fields under the given path
+func parseSelector(selector map[string]interface{}, path []string) ([]condition, error) {
+	var conditions []condition
+	for _, k := range sortedKeys(selector) {
+		v := selector[k]
+		if !strings.HasPrefix(k, "$") {
+			fieldConditions, err := parseFieldSelector(append(append([]string{}, path...), strings.Split(k, ".")...), v)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, fieldConditions...)
+			continue
+		}
+
+		switch k {
+		case "$and", "$or", "$nor":
+			subSelectors, ok := v.([]interface{})
+			if !ok || len(subSelectors) == 0 {
+				return nil, errors.Errorf("operator %s requires a non-empty array of selectors", k)
+			}
+			var subConditions []condition
+			for _, s := range subSelectors {
+				subSelector, ok := s.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("operator %s requires a non-empty array of selectors", k)
+				}
+				parsed, err := parseSelector(subSelector, path)
+				if err != nil {
+					return nil, err
+				}
+				subConditions = append(subConditions, andCondition(parsed))
+			}
+			switch k {
+			case "$and":
+				conditions = append(conditions, andCondition(subConditions))
+			case "$or":
+				conditions = append(conditions, orCondition(subConditions))
+			default:
+				conditions = append(conditions, notCondition{orCondition(subConditions)})
+			}
+		case "$not":
+			subSelector, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("operator $not requires a selector")
+			}
+			parsed, err := parseSelector(subSelector, path)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, notCondition{andCondition(parsed)})
+		default:
+			if len(path) == 0 {
+				return nil, errors.Errorf("operator %s cannot be used without a field", k)
+			}
+			c, err := parseFieldOperator(path, k, v)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, c)
+		}
+	}
+	return conditions, nil
+}
+
+// parseFieldSelector compiles the selector for a field, which is either a value for the implicit
+// $eq operator, an object of operators, or an object of the selectors for the nested fields
+func parseFieldSelector(path []string, v interface{}) ([]condition, error) {
+	subSelector, ok := v.(map[string]interface{})
+	if !ok || len(subSelector) == 0 {
+		return []condition{&fieldCondition{path: path, operator: "$eq", operand: v}}, nil
+	}
+	return parseSelector(subSelector, path)
+}
+
+func parseFieldOperator(path []string, operator string, operand interface{}) (condition, error) {
+	switch operator {
+	case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+	case "$exists":
+		if _, ok := operand.(bool); !ok {
+			return nil, errors.New("operator $exists requires a boolean")
+		}
+	case "$in", "$nin":
+		if _, ok := operand.([]interface{}); !ok {
+			return nil, errors.Errorf("operator %s requires an array", operator)
+		}
+	default:
+		return nil, errors.Errorf("unsupported operator %s", operator)
+	}
+	return &fieldCondition{path: path, operator: operator, operand: operand}, nil
+}
+
+// lookupField returns the value of the field with the given path in the JSON document
+func lookupField(doc interface{}, path []string) (interface{}, bool) {
+	value := doc
+	for _, p := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[p]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// project returns the JSON document that contains only the given fields of the document
+func project(doc interface{}, fields [][]string) ([]byte, error) {
+	projected := map[string]interface{}{}
+	for _, path := range fields {
+		value, ok := lookupField(doc, path)
+		if !ok {
+			continue
+		}
+		m := projected
+		for _, p := range path[:len(path)-1] {
+			nested, ok := m[p].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				m[p] = nested
+			}
+			m = nested
+		}
+		m[path[len(path)-1]] = value
+	}
+	return json.Marshal(projected)
+}
+
+// fieldBounds is the range of the encoded values of a field that the selector can match. The range is
+// derived from the top level conditions of the selector and may include values that the selector does not match
+type fieldBounds struct {
+	eq           []byte
+	lower, upper []byte
+}
+
+// collectBounds derives the bounds of the fields from the conditions that all the matching documents must satisfy
+func collectBounds(c condition, bounds map[string]*fieldBounds) {
+	switch cond := c.(type) {
+	case andCondition:
+		for _, sub := range cond {
+			collectBounds(sub, bounds)
+		}
+	case *fieldCondition:
+		encoded, ok := encodeIndexValue(cond.operand)
+		if !ok {
+			return
+		}
+		var lower, upper []byte
+		typeStart, typeEnd := typeRange(encoded)
+		switch cond.operator {
+		case "$eq":
+			lower, upper = encoded, successor(encoded)
+		case "$gt":
+			lower, upper = successor(encoded), typeEnd
+		case "$gte":
+			lower, upper = encoded, typeEnd
+		case "$lt":
+			lower, upper = typeStart, encoded
+		case "$lte":
+			lower, upper = typeStart, successor(encoded)
+		default:
+			return
+		}
+		if cond.operator != "$eq" && encoded[0] != numberMarker && encoded[0] != stringMarker {
+			// the range operators match only numbers and strings
+			return
+		}
+		field := strings.Join(cond.path, ".")
+		b, ok := bounds[field]
+		if !ok {
+			b = &fieldBounds{lower: lower, upper: upper}
+			bounds[field] = b
+		}
+		if cond.operator == "$eq" {
+			b.eq = encoded
+		}
+		if bytes.Compare(lower, b.lower) > 0 {
+			b.lower = lower
+		}
+		if bytes.Compare(upper, b.upper) < 0 {
+			b.upper = upper
+		}
+	}
+}
+
+// queryPlan describes the iteration of the keys for executing a query. If index is nil,
+// all the keys in the namespace are scanned. Otherwise, the entries of the index in the range
+// [startKey, endKey) are scanned, in the reverse order if reverse is true
+type queryPlan struct {
+	index            *indexDefinition
+	startKey, endKey []byte
+	reverse          bool
+}
+
+// planQuery selects the index for a query. Without a sort, the index that has the most leading fields
+// with an equality condition is preferred, followed by an index that has a range condition on the field
+// next to such leading fields. With a sort, an index is required, whose fields next to the leading fields
+// with an equality condition are the sort fields. Among the indexes that serve the query equally well,
+// the index that comes first by name is chosen so that the same query yields the same results on all peers
+func planQuery(ns string, q *query, defs []*indexDefinition) (*queryPlan, error) {
+	bounds := map[string]*fieldBounds{}
+	collectBounds(q.selector, bounds)
+
+	candidates := defs
+	if q.useIndex != nil {
+		candidates = nil
+		for _, def := range defs {
+			if q.useIndex.refersTo(def) {
+				candidates = append(candidates, def)
+			}
+		}
+		if len(candidates) == 0 {
+			logger.Warningf("The index specified in use_index [%s, %s] for namespace [%s] does not exist, ignoring use_index",
+				q.useIndex.ddoc, q.useIndex.name, ns)
+			candidates = defs
+		}
+	}
+	candidates = append([]*indexDefinition{}, candidates...)
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+
+	var chosen *indexDefinition
+	chosenScore := 0
+	for _, def := range candidates {
+		numEq := 0
+		for numEq < len(def.Fields) && bounds[def.Fields[numEq]] != nil && bounds[def.Fields[numEq]].eq != nil {
+			numEq++
+		}
+		score := 0
+		if len(q.sortFields) > 0 {
+			// the sort fields may themselves have an equality condition
+			for pos := numEq; pos >= 0; pos-- {
+				if hasFieldsAt(def.Fields, pos, q.sortFields) {
+					score = numEq + 1
+					break
+				}
+			}
+		} else {
+			score = 2 * numEq
+			if numEq < len(def.Fields) && bounds[def.Fields[numEq]] != nil {
+				score++
+			}
+		}
+		if score > chosenScore {
+			chosen, chosenScore = def, score
+		}
+	}
+
+	if chosen == nil {
+		if len(q.sortFields) > 0 {
+			return nil, errNoIndexForSort
+		}
+		return &queryPlan{}, nil
+	}
+
+	prefix := chosen.keyPrefix(ns)
+	startKey, endKey := prefix, successor(prefix)
+	for _, field := range chosen.Fields {
+		b := bounds[field]
+		if b == nil {
+			break
+		}
+		if b.eq == nil {
+			startKey = append(append([]byte{}, prefix...), b.lower...)
+			endKey = append(append([]byte{}, prefix...), b.upper...)
+			break
+		}
+		prefix = append(append([]byte{}, prefix...), b.eq...)
+		startKey, endKey = prefix, successor(prefix)
+	}
+	return &queryPlan{
+		index:    chosen,
+		startKey: startKey,
+		endKey:   endKey,
+		reverse:  q.sortDesc,
+	}, nil
+}
+
+func hasFieldsAt(fields []string, pos int, expected []string) bool {
+	if pos+len(expected) > len(fields) {
+		return false
+	}
+	for i, f := range expected {
+		if fields[pos+i] != f {
+			return false
+		}
+	}
+	return true
+}
+
+// queryScanner iterates the keys as per the query plan and returns the key-values that match the selector.
+// The bookmark of a page of the results is the encoded db key of the last key-value returned in the page
+type queryScanner struct {
+	vdb       *versionedDB
+	namespace string
+	query     *query
+	plan      *queryPlan
+	dbItr     iterator.Iterator
+	started   bool
+
+	limit                int32
+	toSkip               int32
+	totalRecordsReturned int32
+	bookmark             string
+	lastDBKey            []byte
+}
+
+func newQueryScanner(vdb *versionedDB, namespace string, q *query, plan *queryPlan, bookmark string, pageSize int32) (*queryScanner, error) {
+	startKey, endKey := plan.startKey, plan.endKey
+	if plan.index == nil {
+		startKey = encodeDataKey(namespace, "")
+		endKey = dataKeyStarterForNextNamespace(namespace)
+	}
+
+	scanner := &queryScanner{
+		vdb:       vdb,
+		namespace: namespace,
+		query:     q,
+		plan:      plan,
+		limit:     q.limit,
+		bookmark:  bookmark,
+	}
+	if pageSize > 0 {
+		scanner.limit = pageSize
+	}
+	if bookmark == "" {
+		scanner.toSkip = q.skip
+	} else {
+		bookmarkKey, err := base64.RawURLEncoding.DecodeString(bookmark)
+		if err != nil || bytes.Compare(bookmarkKey, startKey) < 0 || bytes.Compare(bookmarkKey, endKey) >= 0 {
+			return nil, errors.Errorf("invalid bookmark [%s]", bookmark)
+		}
+		// the scan resumes after the key in the bookmark
+		if plan.reverse {
+			endKey = bookmarkKey
+		} else {
+			startKey = append(bookmarkKey, 0x00)
+		}
+	}
+
+	dbItr, err := vdb.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	scanner.dbItr = dbItr
+	return scanner, nil
+}
+
+// move moves the underlying db iterator to the next key in the order of the scan
+func (scanner *queryScanner) move() bool {
+	if !scanner.plan.reverse {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
+}
+
+// Next returns the next key-value that matches the selector of the query
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	for {
+		if scanner.limit > 0 && scanner.totalRecordsReturned >= scanner.limit {
+			return nil, nil
+		}
+		if !scanner.move() {
+			return nil, errors.Wrap(scanner.dbItr.Error(), "internal leveldb error while executing query")
+		}
+
+		var key string
+		var vv *statedb.VersionedValue
+		var err error
+		if scanner.plan.index == nil {
+			_, key = decodeDataKey(scanner.dbItr.Key())
+			vv, err = decodeValue(append([]byte{}, scanner.dbItr.Value()...))
+		} else {
+			if key, err = scanner.plan.index.keyFromEntryKey(scanner.namespace, scanner.dbItr.Key()); err != nil {
+				return nil, err
+			}
+			vv, err = scanner.vdb.GetState(scanner.namespace, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			continue
+		}
+		doc, isJSON := decodeJSONValue(vv.Value)
+		if !isJSON || !scanner.query.selector.matches(doc) {
+			continue
+		}
+		if scanner.toSkip > 0 {
+			scanner.toSkip--
+			continue
+		}
+		if len(scanner.query.fields) > 0 {
+			if vv.Value, err = project(doc, scanner.query.fields); err != nil {
+				return nil, errors.Wrap(err, "error marshalling the projected fields")
+			}
+		}
+
+		scanner.lastDBKey = append([]byte{}, scanner.dbItr.Key()...)
+		scanner.totalRecordsReturned++
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+}
+
+// Close releases the underlying db iterator
+func (scanner *queryScanner) Close() {
+	scanner.dbItr.Release()
+}
+
+// GetBookmarkAndClose returns the bookmark for the next page of the results
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	if scanner.lastDBKey == nil {
+		return scanner.bookmark
+	}
+	return base64.RawURLEncoding.EncodeToString(scanner.lastDBKey)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ownerIndex     = `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`
+	colorSizeIndex = `{"index":{"fields":["color",{"size":"desc"}]},"ddoc":"indexColorSizeDoc","type":"json"}`
+)
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition("META-INF/statedb/couchdb/indexes/indexColorSize.json", []byte(colorSizeIndex))
+	require.NoError(t, err)
+	require.Equal(t, &indexDefinition{Name: "indexColorSize", DDoc: "indexColorSizeDoc", Fields: []string{"color", "size"}}, def)
+
+	_, err = parseIndexDefinition("bad.json", []byte(`{"index":{"fields":[]}}`))
+	require.EqualError(t, err, "the index definition does not specify the fields")
+	_, err = parseIndexDefinition("bad.json", []byte(`{"index":{"fields":["a"]},"type":"text"}`))
+	require.EqualError(t, err, "unsupported index type [text]")
+	_, err = parseIndexDefinition("bad.json", []byte(`{"index":{"fields":[{"a":"up"}]}}`))
+	require.EqualError(t, err, "invalid sort direction [up] for field [a]")
+}
+
+func TestIndexMaintenance(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmaintenance", nil)
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"tom","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"owner":"jerry","size":2}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"size":3}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte(`not a json`), version.NewHeight(1, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+
+	// the index is built for the existing keys on creation
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{"indexOwner.json": []byte(ownerIndex)}))
+	require.Equal(t, []string{"jerry/key2", "tom/key1"}, indexEntries(t, vdb, "ns1", "indexOwner"))
+
+	// the index entries are maintained on the updates of the keys
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"owner":"mary","size":1}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte(`{"owner":"tom","size":3}`), version.NewHeight(2, 3))
+	batch.Put("ns1", "key5", []byte(`{"owner":{"name":"fred"}}`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	require.Equal(t, []string{"mary/key1", "tom/key3"}, indexEntries(t, vdb, "ns1", "indexOwner"))
+
+	// a new handle loads the index definitions from the db
+	env.DBProvider.indexes = map[string]*indexes{}
+	db, err = env.DBProvider.GetDBHandle("testindexmaintenance", nil)
+	require.NoError(t, err)
+	vdb = db.(*versionedDB)
+	defs, err := vdb.indexes.namespaceIndexes("ns1")
+	require.NoError(t, err)
+	require.Equal(t, []*indexDefinition{{Name: "indexOwner", DDoc: "indexOwnerDoc", Fields: []string{"owner"}}}, defs)
+
+	// redefining the index rebuilds the entries, whereas an invalid index file is skipped
+	require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"indexOwner.json": []byte(`{"index":{"fields":["size"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+		"invalid.json":    []byte(`{"index":`),
+	}))
+	require.Equal(t, []string{"\x04\xbf\xf0\x00\x00\x00\x00\x00\x00key1", "\x04\xc0\x08\x00\x00\x00\x00\x00\x00key3"},
+		indexEntries(t, vdb, "ns1", "indexOwner"))
+}
+
+// indexEntries returns the entries of the index in the form <indexed value>/<key> for the string
+// values, and <encoded value><key> otherwise
+func indexEntries(t *testing.T, vdb *versionedDB, ns, indexName string) []string {
+	prefix := (&indexDefinition{Name: indexName}).keyPrefix(ns)
+	itr, err := vdb.db.GetIterator(prefix, successor(prefix))
+	require.NoError(t, err)
+	defer itr.Release()
+	var entries []string
+	for itr.Next() {
+		entry := itr.Key()[len(prefix):]
+		if entry[0] != stringMarker {
+			entries = append(entries, string(entry))
+			continue
+		}
+		l, err := indexValueLength(entry)
+		require.NoError(t, err)
+		entries = append(entries, fmt.Sprintf("%s/%s", entry[1:l-2], entry[l:]))
+	}
+	return entries
+}
+
+func TestIndexedQueries(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexedqueries", nil)
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	// the same data in a public namespace and a private data collection
+	for _, ns := range []string{"ns1", "ns1$$pcoll1"} {
+		require.NoError(t, vdb.ProcessIndexesForChaincodeDeploy(ns, map[string][]byte{
+			"indexOwner.json":     []byte(ownerIndex),
+			"indexColorSize.json": []byte(colorSizeIndex),
+		}))
+		batch := statedb.NewUpdateBatch()
+		colors := []string{"blue", "green", "red"}
+		for i := 1; i <= 9; i++ {
+			value := fmt.Sprintf(`{"asset_name":"marble%d","color":"%s","size":%d,"owner":"owner%d"}`, i, colors[i%3], 10-i, i%2)
+			batch.Put(ns, fmt.Sprintf("key%d", i), []byte(value), version.NewHeight(1, uint64(i)))
+		}
+		require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 9)))
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedKeys []string
+	}{
+		{
+			name:         "equality",
+			query:        `{"selector":{"owner":"owner0"}}`,
+			expectedKeys: []string{"key2", "key4", "key6", "key8"},
+		},
+		{
+			name:         "equality and range",
+			query:        `{"selector":{"color":"green","size":{"$gte":3,"$lt":9}}}`,
+			expectedKeys: []string{"key7", "key4"},
+		},
+		{
+			name:         "sort ascending",
+			query:        `{"selector":{"color":"blue"},"sort":["color","size"]}`,
+			expectedKeys: []string{"key9", "key6", "key3"},
+		},
+		{
+			name:         "sort descending with limit and skip",
+			query:        `{"selector":{"color":"red","owner":{"$exists":true}},"sort":[{"size":"desc"}],"limit":1,"skip":1}`,
+			expectedKeys: []string{"key5"},
+		},
+		{
+			name:         "combination operators without index",
+			query:        `{"selector":{"$or":[{"size":{"$in":[1,2]}},{"asset_name":"marble5"}],"$not":{"color":"green"}}}`,
+			expectedKeys: []string{"key5", "key8", "key9"},
+		},
+		{
+			name:         "use_index",
+			query:        `{"selector":{"color":"red","owner":"owner1"},"use_index":["_design/indexOwnerDoc","indexOwner"]}`,
+			expectedKeys: []string{"key5"},
+		},
+	}
+	for _, ns := range []string{"ns1", "ns1$$pcoll1"} {
+		for _, tc := range tests {
+			t.Run(ns+"/"+tc.name, func(t *testing.T) {
+				itr, err := db.ExecuteQuery(ns, tc.query)
+				require.NoError(t, err)
+				defer itr.Close()
+				require.Equal(t, tc.expectedKeys, queryResultKeys(t, itr))
+			})
+		}
+	}
+
+	t.Run("projection", func(t *testing.T) {
+		itr, err := db.ExecuteQuery("ns1", `{"selector":{"asset_name":"marble1"},"fields":["owner","size"]}`)
+		require.NoError(t, err)
+		defer itr.Close()
+		result, err := itr.Next()
+		require.NoError(t, err)
+		require.JSONEq(t, `{"owner":"owner1","size":9}`, string(result.(*statedb.VersionedKV).Value))
+	})
+
+	t.Run("sort without index", func(t *testing.T) {
+		_, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"owner1"},"sort":["asset_name"]}`)
+		require.EqualError(t, err, "No index exists for this sort, try indexing by the sort fields.")
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		_, err := db.ExecuteQuery("ns1", `{"selector":{"size":{"$regex":"^1"}}}`)
+		require.EqualError(t, err, "unsupported operator $regex")
+		_, err = db.ExecuteQuery("ns1", `{"selector":{"size":1},"bookmark":"abc"}`)
+		require.EqualError(t, err, "unsupported query field [bookmark]")
+		_, err = db.ExecuteQuery("ns1", `{"sort":["size"]}`)
+		require.EqualError(t, err, "the query does not specify a selector")
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		for _, query := range []string{
+			`{"selector":{"size":{"$gt":0}},"sort":[{"color":"desc"},{"size":"desc"}]}`,
+			`{"selector":{"size":{"$gt":0}}}`,
+		} {
+			var keys []string
+			bookmark := ""
+			for i := 0; i < 4; i++ {
+				itr, err := db.ExecuteQueryWithPagination("ns1$$pcoll1", query, bookmark, 3)
+				require.NoError(t, err)
+				keys = append(keys, queryResultKeys(t, itr)...)
+				bookmark = itr.GetBookmarkAndClose()
+			}
+			require.Len(t, keys, 9)
+		}
+
+		itr, err := db.ExecuteQueryWithPagination("ns1", `{"selector":{"color":"red"},"sort":[{"color":"desc"},{"size":"desc"}]}`, "", 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key2", "key5"}, queryResultKeys(t, itr))
+		bookmark := itr.GetBookmarkAndClose()
+		itr, err = db.ExecuteQueryWithPagination("ns1", `{"selector":{"color":"red"},"sort":[{"color":"desc"},{"size":"desc"}]}`, bookmark, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key8"}, queryResultKeys(t, itr))
+		bookmark = itr.GetBookmarkAndClose()
+
+		// the bookmark is retained if the page is empty
+		itr, err = db.ExecuteQueryWithPagination("ns1", `{"selector":{"color":"red"},"sort":[{"color":"desc"},{"size":"desc"}]}`, bookmark, 2)
+		require.NoError(t, err)
+		require.Empty(t, queryResultKeys(t, itr))
+		require.Equal(t, bookmark, itr.GetBookmarkAndClose())
+
+		_, err = db.ExecuteQueryWithPagination("ns1", `{"selector":{"color":"blue"}}`, bookmark, 2)
+		require.EqualError(t, err, fmt.Sprintf("invalid bookmark [%s]", bookmark))
+	})
+}
+
+func queryResultKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	var keys []string
+	for {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		if result == nil {
+			return keys
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+}
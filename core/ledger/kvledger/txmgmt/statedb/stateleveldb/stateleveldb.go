@@ -8,9 +8,11 @@ package stateleveldb
 
 import (
 	"bytes"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
@@ -33,30 +35,52 @@ var (
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+
+	indexesLock sync.Mutex
+	indexes     map[string]*indexes
 }
 
-// NewVersionedDBProvider instantiates VersionedDBProvider
-func NewVersionedDBProvider(dbPath string) (*VersionedDBProvider, error) {
+// NewVersionedDBProvider instantiates VersionedDBProvider. The values are encrypted
+// with the supplied encryptor, if it is not nil
+func NewVersionedDBProvider(dbPath string, encryptor *encryption.Encryptor) (*VersionedDBProvider, error) {
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider, err := leveldbhelper.NewProvider(
 		&leveldbhelper.Conf{
 			DBPath:         dbPath,
 			ExpectedFormat: dataformat.CurrentFormat,
+			Encryptor:      encryptor,
 		})
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{dbProvider}, nil
+	return &VersionedDBProvider{
+		dbProvider: dbProvider,
+		indexes:    map[string]*indexes{},
+	}, nil
 }
 
 // GetDBHandle gets the handle to a named database
 func (provider *VersionedDBProvider) GetDBHandle(dbName string, namespaceProvider statedb.NamespaceProvider) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	return provider.newVersionedDB(dbName), nil
+}
+
+// newVersionedDB constructs a handle to the named database. All the handles to a
+// database share the indexes so that the index definitions are loaded only once
+func (provider *VersionedDBProvider) newVersionedDB(dbName string) *versionedDB {
+	provider.indexesLock.Lock()
+	defer provider.indexesLock.Unlock()
+	db := provider.dbProvider.GetDBHandle(dbName)
+	idx, ok := provider.indexes[dbName]
+	if !ok {
+		idx = newIndexes(db)
+		provider.indexes[dbName] = idx
+	}
+	return newVersionedDB(db, dbName, idx)
 }
 
 func (provider *VersionedDBProvider) BootstrapDBFromState(
 	dbName string, savepoint *version.Height, itr statedb.FullScanIterator, dbValueFormat byte) error {
-	vdb := newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+	vdb := provider.newVersionedDB(dbName)
 	if err := vdb.importState(itr, savepoint, dbValueFormat); err != nil {
 		return err
 	}
@@ -71,18 +95,22 @@ func (provider *VersionedDBProvider) Close() {
 // Drop drops channel-specific data from the state leveldb.
 // It is not an error if a database does not exist.
 func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.indexesLock.Lock()
+	delete(provider.indexes, dbName)
+	provider.indexesLock.Unlock()
 	return provider.dbProvider.Drop(dbName)
 }
 
 // VersionedDB implements VersionedDB interface
 type versionedDB struct {
-	db     *leveldbhelper.DBHandle
-	dbName string
+	db      *leveldbhelper.DBHandle
+	dbName  string
+	indexes *indexes
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string, indexes *indexes) *versionedDB {
+	return &versionedDB{db, dbName, indexes}
 }
 
 // Open implements method in VersionedDB interface
@@ -163,21 +191,88 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithPagination(namespace string
 	if err != nil {
 		return nil, err
 	}
-	return newKVScanner(namespace, dbItr, pageSize), nil
+	return newKVScanner(namespace, dbItr, pageSize, false), nil
+}
+
+// GetStateReverseRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	// pageSize = 0 denotes unlimited page size
+	return vdb.GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateReverseRangeScanIteratorWithPagination implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	// the keys are iterated backwards from the startKey (inclusive) to the endKey (exclusive). The smallest
+	// key that is greater than a given key is obtained by appending a zero byte to it
+	dataStartKey := encodeDataKey(namespace, endKey)
+	if endKey != "" {
+		dataStartKey = append(dataStartKey, 0x00)
+	}
+	dataEndKey := encodeDataKey(namespace, startKey)
+	if startKey == "" {
+		dataEndKey[len(dataEndKey)-1] = lastKeyIndicator
+	} else {
+		dataEndKey = append(dataEndKey, 0x00)
+	}
+	dbItr, err := vdb.db.GetIterator(dataStartKey, dataEndKey)
+	if err != nil {
+		return nil, err
+	}
+	return newKVScanner(namespace, dbItr, pageSize, true), nil
 }
 
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	// pageSize = 0 denotes unlimited page size
+	return vdb.ExecuteQueryWithPagination(namespace, query, "", 0)
 }
 
-// ExecuteQueryWithPagination implements method in VersionedDB interface
+// ExecuteQueryWithPagination implements method in VersionedDB interface. The query is evaluated
+// using the secondary indexes created from the couchdb index definitions packaged with the chaincode
 func (vdb *versionedDB) ExecuteQueryWithPagination(namespace, query, bookmark string, pageSize int32) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+	logger.Debugf("Entering ExecuteQueryWithPagination namespace: %s,  query: %s,  bookmark: %s, pageSize: %d", namespace, query, bookmark, pageSize)
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	defs, err := vdb.indexes.namespaceIndexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planQuery(namespace, q, defs)
+	if err != nil {
+		return nil, err
+	}
+	return newQueryScanner(vdb, namespace, q, plan, bookmark, pageSize)
+}
+
+// GetDBType returns the hosted stateDB
+func (vdb *versionedDB) GetDBType() string {
+	return "goleveldb"
+}
+
+// GetIndexDefinitionsDBType implements method in IndexDefinitionsSource interface. The secondary indexes
+// in leveldb are created from the same index definitions as the indexes in couchdb
+func (vdb *versionedDB) GetIndexDefinitionsDBType() string {
+	return "couchdb"
+}
+
+// ProcessIndexesForChaincodeDeploy creates the secondary indexes for a specified namespace
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, indexFilesData map[string][]byte) error {
+	return vdb.indexes.create(namespace, indexFilesData, vdb.dbName)
 }
 
 // ApplyUpdates implements method in VersionedDB interface
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	// the lock serializes the maintenance of the index entries with the creation of the indexes
+	vdb.indexes.Lock()
+	defer vdb.indexes.Unlock()
+	if err := vdb.indexes.load(); err != nil {
+		return err
+	}
+
 	dbBatch := vdb.db.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
@@ -186,6 +281,12 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 			dataKey := encodeDataKey(ns, k)
 			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
 
+			if len(vdb.indexes.definitions[ns]) > 0 {
+				if err := vdb.addIndexEntryUpdates(dbBatch, ns, k, vv.Value); err != nil {
+					return err
+				}
+			}
+
 			if vv.Value == nil {
 				dbBatch.Delete(dataKey)
 			} else {
@@ -211,6 +312,20 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	return nil
 }
 
+// addIndexEntryUpdates adds to the batch the updates of the index entries for the key that is being set to the given value
+func (vdb *versionedDB) addIndexEntryUpdates(dbBatch *leveldbhelper.UpdateBatch, ns, key string, value []byte) error {
+	var oldValue []byte
+	oldVV, err := vdb.GetState(ns, key)
+	if err != nil {
+		return err
+	}
+	if oldVV != nil {
+		oldValue = oldVV.Value
+	}
+	vdb.indexes.addEntryUpdates(dbBatch, ns, key, oldValue, value)
+	return nil
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	versionBytes, err := vdb.db.Get(savePointKey)
@@ -298,17 +413,31 @@ type kvScanner struct {
 	dbItr                iterator.Iterator
 	requestedLimit       int32
 	totalRecordsReturned int32
+	reverse              bool
+	started              bool
+}
+
+func newKVScanner(namespace string, dbItr iterator.Iterator, requestedLimit int32, reverse bool) *kvScanner {
+	return &kvScanner{namespace, dbItr, requestedLimit, 0, reverse, false}
 }
 
-func newKVScanner(namespace string, dbItr iterator.Iterator, requestedLimit int32) *kvScanner {
-	return &kvScanner{namespace, dbItr, requestedLimit, 0}
+// move moves the underlying db iterator to the next key in the order of the scan
+func (scanner *kvScanner) move() bool {
+	if !scanner.reverse {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.started {
+		scanner.started = true
+		return scanner.dbItr.Last()
+	}
+	return scanner.dbItr.Prev()
 }
 
 func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
 	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
 		return nil, nil
 	}
-	if !scanner.dbItr.Next() {
+	if !scanner.move() {
 		return nil, nil
 	}
 
@@ -336,7 +465,7 @@ func (scanner *kvScanner) Close() {
 
 func (scanner *kvScanner) GetBookmarkAndClose() string {
 	retval := ""
-	if scanner.dbItr.Next() {
+	if scanner.move() {
 		dbKey := scanner.dbItr.Key()
 		_, key := decodeDataKey(dbKey)
 		retval = key
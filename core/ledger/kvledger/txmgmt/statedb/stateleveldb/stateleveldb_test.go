@@ -65,23 +65,7 @@ func testDataKeyEncoding(t *testing.T, dbName string, ns string, key string) {
 func TestQueryOnLevelDB(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
-	db, err := env.DBProvider.GetDBHandle("testquery", nil)
-	require.NoError(t, err)
-	require.NoError(t, db.Open())
-	defer db.Close()
-	batch := statedb.NewUpdateBatch()
-	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
-
-	savePoint := version.NewHeight(2, 22)
-	require.NoError(t, db.ApplyUpdates(batch, savePoint))
-
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
-	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	require.Error(t, err, "ExecuteQuery not supported for leveldb")
-	require.Nil(t, itr)
+	commontests.TestQuery(t, env.DBProvider)
 }
 
 func TestGetStateMultipleKeys(t *testing.T) {
@@ -109,6 +93,14 @@ func TestUtilityFunctions(t *testing.T) {
 
 	// ValidateKeyValue should return nil for a valid key and value
 	require.NoError(t, db.ValidateKeyValue("testKey", []byte("testValue")), "leveldb should accept all key-values")
+
+	// the indexes are created from the index definitions packaged for couchdb
+	indexCapable, ok := db.(statedb.IndexCapable)
+	require.True(t, ok)
+	require.Equal(t, "goleveldb", indexCapable.GetDBType())
+	source, ok := db.(statedb.IndexDefinitionsSource)
+	require.True(t, ok)
+	require.Equal(t, "couchdb", source.GetIndexDefinitionsDBType())
 }
 
 func TestValueAndMetadataWrites(t *testing.T) {
@@ -123,6 +115,12 @@ func TestPaginatedRangeQuery(t *testing.T) {
 	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
 }
 
+func TestReverseRangeQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestReverseRangeQuery(t, env.DBProvider)
+}
+
 func TestRangeQuerySpecialCharacters(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -0,0 +1,339 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// fakeDriverName is the name of a database/sql driver that keeps the state in memory. It
+// interprets the statements of this package, instead of a PostgreSQL database, in the tests
+const fakeDriverName = "statesql-fake"
+
+func init() {
+	sql.Register(fakeDriverName, &fakeDriver{databases: map[string]*fakeDatabase{}})
+}
+
+type fakeDriver struct {
+	mutex     sync.Mutex
+	databases map[string]*fakeDatabase
+}
+
+// Open returns a connection to the database named after the data source name
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	db, ok := d.databases[name]
+	if !ok {
+		db = &fakeDatabase{}
+		d.databases[name] = db
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeRowID struct {
+	channel, namespace, key string
+}
+
+type fakeRow struct {
+	value, metadata, version []byte
+	json                     interface{}
+}
+
+type fakeDatabase struct {
+	mutex      sync.Mutex
+	rows       map[fakeRowID]*fakeRow
+	savepoints map[string][]byte
+	indexes    []string
+}
+
+type fakeConn struct {
+	db *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c, nil
+}
+
+// Commit and Rollback do nothing, the statements are applied as they are executed
+func (c *fakeConn) Commit() error {
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	return nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, namedArgs []driver.NamedValue) (driver.Result, error) {
+	args := values(namedArgs)
+	db := c.db
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	switch {
+	case query == createStateTableStmt:
+		if db.rows == nil {
+			db.rows = map[fakeRowID]*fakeRow{}
+		}
+	case query == createSavepointTableStmt:
+		if db.savepoints == nil {
+			db.savepoints = map[string][]byte{}
+		}
+	case query == dropStateTableStmt:
+		db.rows = nil
+		db.indexes = nil
+	case query == dropSavepointTableStmt:
+		db.savepoints = nil
+	case query == upsertStateStmt:
+		if err := db.checkTables(); err != nil {
+			return nil, err
+		}
+		var jsonValue interface{}
+		if args[4] != nil {
+			if err := json.Unmarshal([]byte(args[4].(string)), &jsonValue); err != nil {
+				return nil, err
+			}
+		}
+		db.rows[rowID(args)] = &fakeRow{
+			value:    bytesArg(args[3]),
+			json:     jsonValue,
+			metadata: bytesArg(args[5]),
+			version:  bytesArg(args[6]),
+		}
+	case query == deleteStateStmt:
+		if err := db.checkTables(); err != nil {
+			return nil, err
+		}
+		delete(db.rows, rowID(args))
+	case query == upsertSavepointStmt:
+		if err := db.checkTables(); err != nil {
+			return nil, err
+		}
+		db.savepoints[args[0].(string)] = bytesArg(args[1])
+	case query == deleteChannelStateStmt:
+		for id := range db.rows {
+			if id.channel == args[0].(string) {
+				delete(db.rows, id)
+			}
+		}
+	case query == deleteChannelSavepointStmt:
+		delete(db.savepoints, args[0].(string))
+	case strings.HasPrefix(query, "CREATE INDEX IF NOT EXISTS "):
+		db.indexes = append(db.indexes, query)
+	default:
+		return nil, errors.Errorf("unexpected statement: %s", query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, namedArgs []driver.NamedValue) (driver.Rows, error) {
+	args := values(namedArgs)
+	db := c.db
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	if err := db.checkTables(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case query == selectStateStmt:
+		rows := &fakeRows{columns: []string{"value", "metadata", "version"}}
+		if row, ok := db.rows[rowID(args)]; ok {
+			rows.values = append(rows.values, []driver.Value{row.value, row.metadata, row.version})
+		}
+		return rows, nil
+	case query == selectSavepointStmt:
+		rows := &fakeRows{columns: []string{"height"}}
+		if height, ok := db.savepoints[args[0].(string)]; ok {
+			rows.values = append(rows.values, []driver.Value{height})
+		}
+		return rows, nil
+	case query == selectRangeStmt:
+		from, to := string(bytesArg(args[2])), string(bytesArg(args[3]))
+		return db.selectKVs(args, int(args[4].(int64)), false, func(key string, row *fakeRow) (bool, error) {
+			return key >= from && (to == "" || key < to), nil
+		})
+	case query == selectReverseRangeStmt:
+		before, after := string(bytesArg(args[2])), string(bytesArg(args[3]))
+		return db.selectKVs(args, int(args[4].(int64)), true, func(key string, row *fakeRow) (bool, error) {
+			return (before == "" || key < before) && (after == "" || key > after), nil
+		})
+	case query == selectAllStmt, query == selectAllAfterStmt:
+		return db.selectAll(args), nil
+	case strings.HasPrefix(query, richQueryStmtPrefix) && strings.HasSuffix(query, richQueryStmtSuffix):
+		condition := strings.TrimSuffix(strings.TrimPrefix(query, richQueryStmtPrefix), richQueryStmtSuffix)
+		from := string(bytesArg(args[2]))
+		return db.selectKVs(args, int(args[3].(int64)), false, func(key string, row *fakeRow) (bool, error) {
+			if key < from {
+				return false, nil
+			}
+			return evaluateCondition(condition, row.json)
+		})
+	default:
+		return nil, errors.Errorf("unexpected query: %s", query)
+	}
+}
+
+func (db *fakeDatabase) checkTables() error {
+	if db.rows == nil || db.savepoints == nil {
+		return errors.New(`relation "fabric_state" does not exist`)
+	}
+	return nil
+}
+
+// selectKVs returns the key, value, metadata and version of the rows of the channel
+// and namespace given by the first two arguments that match the filter
+func (db *fakeDatabase) selectKVs(args []driver.Value, limit int, reverse bool, filter func(string, *fakeRow) (bool, error)) (driver.Rows, error) {
+	channel, namespace := args[0].(string), string(bytesArg(args[1]))
+	var keys []string
+	for id, row := range db.rows {
+		if id.channel != channel || id.namespace != namespace {
+			continue
+		}
+		ok, err := filter(id.key, row)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			keys = append(keys, id.key)
+		}
+	}
+	sort.Strings(keys)
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	}
+	rows := &fakeRows{columns: []string{"key", "value", "metadata", "version"}}
+	for _, key := range keys {
+		if len(rows.values) == limit {
+			break
+		}
+		row := db.rows[fakeRowID{channel, namespace, key}]
+		rows.values = append(rows.values, []driver.Value{[]byte(key), row.value, row.metadata, row.version})
+	}
+	return rows, nil
+}
+
+func (db *fakeDatabase) selectAll(args []driver.Value) driver.Rows {
+	channel := args[0].(string)
+	var after *fakeRowID
+	limit := int(args[len(args)-1].(int64))
+	if len(args) == 4 {
+		after = &fakeRowID{channel, string(bytesArg(args[1])), string(bytesArg(args[2]))}
+	}
+	var ids []fakeRowID
+	for id := range db.rows {
+		if id.channel != channel {
+			continue
+		}
+		if after != nil && (id.namespace < after.namespace || (id.namespace == after.namespace && id.key <= after.key)) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].namespace != ids[j].namespace {
+			return ids[i].namespace < ids[j].namespace
+		}
+		return ids[i].key < ids[j].key
+	})
+	rows := &fakeRows{columns: []string{"namespace", "key", "value", "metadata", "version"}}
+	for _, id := range ids {
+		if len(rows.values) == limit {
+			break
+		}
+		row := db.rows[id]
+		rows.values = append(rows.values, []driver.Value{[]byte(id.namespace), []byte(id.key), row.value, row.metadata, row.version})
+	}
+	return rows
+}
+
+var fakeConditionTerm = regexp.MustCompile(`^value_json->>'([A-Za-z0-9_]+)' = '([^']*)'$`)
+
+// evaluateCondition evaluates the conditions supported by the fake driver, which
+// are equalities of top-level fields and strings combined with AND
+func evaluateCondition(condition string, jsonValue interface{}) (bool, error) {
+	obj, _ := jsonValue.(map[string]interface{})
+	for _, term := range strings.Split(condition, " AND ") {
+		match := fakeConditionTerm.FindStringSubmatch(term)
+		if match == nil {
+			return false, errors.Errorf("syntax error in condition: %s", term)
+		}
+		if obj == nil {
+			return false, nil
+		}
+		field, ok := obj[match[1]]
+		if !ok || fmt.Sprint(field) != match[2] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func values(namedArgs []driver.NamedValue) []driver.Value {
+	args := make([]driver.Value, len(namedArgs))
+	for i, arg := range namedArgs {
+		args[i] = arg.Value
+	}
+	return args
+}
+
+func rowID(args []driver.Value) fakeRowID {
+	return fakeRowID{args[0].(string), string(bytesArg(args[1])), string(bytesArg(args[2]))}
+}
+
+// bytesArg copies an argument of type BYTEA as the driver must not retain the argument
+func bytesArg(arg driver.Value) []byte {
+	if arg == nil {
+		return nil
+	}
+	return append([]byte{}, arg.([]byte)...)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// richQueryStmtPrefix and richQueryStmtSuffix enclose the condition of a rich query
+const (
+	richQueryStmtPrefix = `SELECT key, value, metadata, version FROM fabric_state
+		WHERE channel = $1 AND namespace = $2 AND key >= $3 AND (`
+	richQueryStmtSuffix = `)
+		ORDER BY key LIMIT $4`
+)
+
+func richQueryStmt(condition string) string {
+	return richQueryStmtPrefix + condition + richQueryStmtSuffix
+}
+
+// allowedWords lists the identifiers and keywords that a rich query may contain. The only
+// column is value_json, the queries cannot refer to any other table or function
+var allowedWords = map[string]bool{
+	"value_json": true,
+	"and":        true, "or": true, "not": true, "is": true, "null": true, "true": true, "false": true,
+	"in": true, "like": true, "ilike": true, "between": true, "cast": true, "as": true,
+	"numeric": true, "integer": true, "bigint": true, "text": true, "boolean": true, "jsonb": true,
+	"lower": true, "upper": true, "length": true, "coalesce": true, "abs": true,
+	"jsonb_typeof": true, "jsonb_array_length": true,
+}
+
+// validateCondition checks that a rich query is a single condition on the column value_json. It
+// rejects anything that could change the meaning of the statement the condition is embedded in,
+// such as unbalanced parentheses, comments, several statements and sub-queries
+func validateCondition(condition string) error {
+	if strings.TrimSpace(condition) == "" {
+		return errors.New("invalid query: the query must be a SQL condition on the column value_json")
+	}
+	depth := 0
+	for i := 0; i < len(condition); i++ {
+		c := condition[i]
+		switch {
+		case c == '\'':
+			// string literal, a quote is escaped by doubling it
+			for i++; ; i++ {
+				if i >= len(condition) {
+					return errors.New("invalid query: unterminated string literal")
+				}
+				if condition[i] == '\'' {
+					if i+1 < len(condition) && condition[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return errors.New("invalid query: unbalanced parentheses")
+			}
+		case c == '_' || isLetter(c):
+			start := i
+			for i+1 < len(condition) && (condition[i+1] == '_' || isLetter(condition[i+1]) || isDigit(condition[i+1])) {
+				i++
+			}
+			word := strings.ToLower(condition[start : i+1])
+			if !allowedWords[word] {
+				return errors.Errorf("invalid query: [%s] is not allowed", condition[start:i+1])
+			}
+		case c == '-' && i+1 < len(condition) && condition[i+1] == '-',
+			c == '/' && i+1 < len(condition) && condition[i+1] == '*':
+			return errors.New("invalid query: comments are not allowed")
+		case isDigit(c), strings.IndexByte(" \t\r\n=<>!+-*/%,.:[]|&~@#?^", c) >= 0:
+		default:
+			return errors.Errorf("invalid query: character [%c] is not allowed", c)
+		}
+	}
+	if depth != 0 {
+		return errors.New("invalid query: unbalanced parentheses")
+	}
+	return nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// jsonValue returns the value to be stored in the column value_json. Only the values that are
+// JSON objects can be queried, nil is returned for any other value
+func jsonValue(value []byte) interface{} {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+		return nil
+	}
+	// the value is normalized so that the numbers keep their precision and the
+	// characters rejected by the JSONB type are not stored
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil
+	}
+	normalized, err := json.Marshal(obj)
+	if err != nil || bytes.Contains(normalized, []byte(`\u0000`)) {
+		return nil
+	}
+	return string(normalized)
+}
+
+var indexFieldPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// indexDefinition is the definition of an index packaged with a chaincode in
+// META-INF/statedb/sql/indexes, for instance
+//
+//	{"name": "indexOwner", "fields": ["docType", "owner"]}
+//
+// The nested fields are separated by dots. A query uses the index if it refers
+// to the fields with the same expressions as the index, that is
+// value_json->>'owner' for a top-level field and value_json#>>'{a,b}' for a nested field
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+func parseIndexDefinition(indexData []byte) (*indexDefinition, error) {
+	def := &indexDefinition{}
+	if err := json.Unmarshal(indexData, def); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if def.Name == "" {
+		return nil, errors.New("invalid index definition: the name is missing")
+	}
+	if len(def.Fields) == 0 {
+		return nil, errors.Errorf("invalid index definition [%s]: the fields are missing", def.Name)
+	}
+	for _, field := range def.Fields {
+		if !indexFieldPattern.MatchString(field) {
+			return nil, errors.Errorf("invalid index definition [%s]: invalid field [%s]", def.Name, field)
+		}
+	}
+	return def, nil
+}
+
+// createStmt returns the statement that creates the index. The name of the index is derived
+// from its expressions so that the same index defined by several chaincodes is created once
+func (def *indexDefinition) createStmt() string {
+	exprs := []string{"namespace"}
+	for _, field := range def.Fields {
+		exprs = append(exprs, fieldExpression(field))
+	}
+	columns := strings.Join(exprs, ", ")
+	hash := sha256.Sum256([]byte(columns))
+	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS fabric_idx_%s ON fabric_state (%s)", hex.EncodeToString(hash[:8]), columns)
+}
+
+func fieldExpression(field string) string {
+	path := strings.Split(field, ".")
+	if len(path) == 1 {
+		return fmt.Sprintf("(value_json->>'%s')", field)
+	}
+	return fmt.Sprintf("(value_json#>>'{%s}')", strings.Join(path, ","))
+}
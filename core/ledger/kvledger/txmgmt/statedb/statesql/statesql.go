@@ -0,0 +1,693 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("statesql")
+
+var (
+	// fullScanIteratorValueFormat is the format of the values returned by the FullScanIterator.
+	// The values are encoded the same way as in stateleveldb so that the snapshots can be
+	// imported in either of the databases
+	fullScanIteratorValueFormat = byte(1)
+	maxDataImportBatchSize      = 1000
+)
+
+// The state of all the channels is kept in a single table. The namespaces and the keys are
+// stored as bytes so that they are ordered the same way as in the other state databases
+const (
+	createStateTableStmt = `CREATE TABLE IF NOT EXISTS fabric_state (
+		channel TEXT NOT NULL,
+		namespace BYTEA NOT NULL,
+		key BYTEA NOT NULL,
+		value BYTEA,
+		value_json JSONB,
+		metadata BYTEA,
+		version BYTEA NOT NULL,
+		PRIMARY KEY (channel, namespace, key))`
+	createSavepointTableStmt = `CREATE TABLE IF NOT EXISTS fabric_savepoints (
+		channel TEXT PRIMARY KEY,
+		height BYTEA NOT NULL)`
+	dropStateTableStmt     = `DROP TABLE IF EXISTS fabric_state`
+	dropSavepointTableStmt = `DROP TABLE IF EXISTS fabric_savepoints`
+
+	selectStateStmt = `SELECT value, metadata, version FROM fabric_state
+		WHERE channel = $1 AND namespace = $2 AND key = $3`
+	selectRangeStmt = `SELECT key, value, metadata, version FROM fabric_state
+		WHERE channel = $1 AND namespace = $2 AND key >= $3 AND (octet_length($4::bytea) = 0 OR key < $4)
+		ORDER BY key LIMIT $5`
+	selectReverseRangeStmt = `SELECT key, value, metadata, version FROM fabric_state
+		WHERE channel = $1 AND namespace = $2 AND (octet_length($3::bytea) = 0 OR key < $3) AND (octet_length($4::bytea) = 0 OR key > $4)
+		ORDER BY key DESC LIMIT $5`
+	selectAllStmt = `SELECT namespace, key, value, metadata, version FROM fabric_state
+		WHERE channel = $1
+		ORDER BY namespace, key LIMIT $2`
+	selectAllAfterStmt = `SELECT namespace, key, value, metadata, version FROM fabric_state
+		WHERE channel = $1 AND (namespace > $2 OR (namespace = $2 AND key > $3))
+		ORDER BY namespace, key LIMIT $4`
+	upsertStateStmt = `INSERT INTO fabric_state (channel, namespace, key, value, value_json, metadata, version)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7)
+		ON CONFLICT (channel, namespace, key) DO UPDATE
+		SET value = EXCLUDED.value, value_json = EXCLUDED.value_json, metadata = EXCLUDED.metadata, version = EXCLUDED.version`
+	deleteStateStmt = `DELETE FROM fabric_state WHERE channel = $1 AND namespace = $2 AND key = $3`
+
+	selectSavepointStmt = `SELECT height FROM fabric_savepoints WHERE channel = $1`
+	upsertSavepointStmt = `INSERT INTO fabric_savepoints (channel, height) VALUES ($1, $2)
+		ON CONFLICT (channel) DO UPDATE SET height = EXCLUDED.height`
+
+	deleteChannelStateStmt     = `DELETE FROM fabric_state WHERE channel = $1`
+	deleteChannelSavepointStmt = `DELETE FROM fabric_savepoints WHERE channel = $1`
+)
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	db     *sql.DB
+	config *ledger.SQLConfig
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider. It connects to the database
+// and creates the tables that hold the state, if they do not exist
+func NewVersionedDBProvider(config *ledger.SQLConfig) (*VersionedDBProvider, error) {
+	logger.Debugf("constructing SQL VersionedDBProvider driver=%s", config.DriverName)
+	db, err := openDB(config)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := requestContext(config)
+	defer cancel()
+	for _, stmt := range []string{createStateTableStmt, createSavepointTableStmt} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, errors.Wrap(err, "error creating the state tables")
+		}
+	}
+	return &VersionedDBProvider{
+		db:     db,
+		config: config,
+	}, nil
+}
+
+func openDB(config *ledger.SQLConfig) (*sql.DB, error) {
+	db, err := sql.Open(config.DriverName, config.DataSourceName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the database with driver [%s]", config.DriverName)
+	}
+	db.SetMaxOpenConns(config.MaxOpenConnections)
+	return db, nil
+}
+
+// DropApplicationDBs drops the state of all the channels. It is used when the state
+// database is rebuilt from the blocks
+func DropApplicationDBs(config *ledger.SQLConfig) error {
+	logger.Info("Dropping the state tables of the SQL database")
+	db, err := openDB(config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ctx, cancel := requestContext(config)
+	defer cancel()
+	for _, stmt := range []string{dropStateTableStmt, dropSavepointTableStmt} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrap(err, "error dropping the state tables")
+		}
+	}
+	return nil
+}
+
+// GetDBHandle gets the handle to the state of a channel
+func (provider *VersionedDBProvider) GetDBHandle(dbName string, namespaceProvider statedb.NamespaceProvider) (statedb.VersionedDB, error) {
+	return provider.newVersionedDB(dbName), nil
+}
+
+func (provider *VersionedDBProvider) newVersionedDB(dbName string) *versionedDB {
+	return &versionedDB{
+		db:      provider.db,
+		channel: dbName,
+		config:  provider.config,
+	}
+}
+
+// BootstrapDBFromState imports the state of a channel from a snapshot
+func (provider *VersionedDBProvider) BootstrapDBFromState(
+	dbName string, savepoint *version.Height, itr statedb.FullScanIterator, dbValueFormat byte) error {
+	return provider.newVersionedDB(dbName).importState(itr, savepoint, dbValueFormat)
+}
+
+// Close closes the connections to the database
+func (provider *VersionedDBProvider) Close() {
+	provider.db.Close()
+}
+
+// Drop drops the state of a channel.
+// It is not an error if the channel does not have any state.
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	ctx, cancel := requestContext(provider.config)
+	defer cancel()
+	tx, err := provider.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error starting a database transaction")
+	}
+	defer tx.Rollback()
+	for _, stmt := range []string{deleteChannelStateStmt, deleteChannelSavepointStmt} {
+		if _, err := tx.ExecContext(ctx, stmt, dbName); err != nil {
+			return errors.Wrapf(err, "error dropping the state of channel [%s]", dbName)
+		}
+	}
+	return errors.Wrap(tx.Commit(), "error committing a database transaction")
+}
+
+// versionedDB implements VersionedDB interface for the state of a channel
+type versionedDB struct {
+	db      *sql.DB
+	channel string
+	config  *ledger.SQLConfig
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because the connections are shared
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because the connections are shared
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	ctx, cancel := requestContext(vdb.config)
+	defer cancel()
+	var value, metadata, versionBytes []byte
+	err := vdb.db.QueryRowContext(ctx, selectStateStmt, vdb.channel, []byte(namespace), []byte(key)).Scan(&value, &metadata, &versionBytes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving key [%s] of namespace [%s]", key, namespace)
+	}
+	return decodeRow(value, metadata, versionBytes)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	vv, err := vdb.GetState(namespace, key)
+	if err != nil || vv == nil {
+		return nil, err
+	}
+	return vv.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	// pageSize = 0 denotes unlimited page size
+	return vdb.GetStateRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateRangeScanIteratorWithPagination implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	fetch := func(lastKey *string, limit int) ([]*statedb.VersionedKV, error) {
+		// the smallest key that is greater than the last returned key is obtained by appending a zero byte to it
+		from := []byte(startKey)
+		if lastKey != nil {
+			from = append([]byte(*lastKey), 0x00)
+		}
+		return vdb.queryKVs(namespace, selectRangeStmt, vdb.channel, []byte(namespace), from, []byte(endKey), limit)
+	}
+	return vdb.newKVScanner(fetch, pageSize)
+}
+
+// GetStateReverseRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	// pageSize = 0 denotes unlimited page size
+	return vdb.GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateReverseRangeScanIteratorWithPagination implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	fetch := func(lastKey *string, limit int) ([]*statedb.VersionedKV, error) {
+		// the keys are fetched below an exclusive upper bound, which is the last returned key or
+		// the smallest key that is greater than the startKey. An empty bound means no bound
+		var before []byte
+		switch {
+		case lastKey != nil:
+			before = []byte(*lastKey)
+			if len(before) == 0 {
+				return nil, nil
+			}
+		case startKey != "":
+			before = append([]byte(startKey), 0x00)
+		}
+		return vdb.queryKVs(namespace, selectReverseRangeStmt, vdb.channel, []byte(namespace), before, []byte(endKey), limit)
+	}
+	return vdb.newKVScanner(fetch, pageSize)
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	// pageSize = 0 denotes unlimited page size
+	return vdb.ExecuteQueryWithPagination(namespace, query, "", 0)
+}
+
+// ExecuteQueryWithPagination implements method in VersionedDB interface. The query is a SQL condition
+// on the column value_json, which holds the values that are JSON objects. The results are returned
+// in the order of the keys and the bookmark is the key of the first result of the next page
+func (vdb *versionedDB) ExecuteQueryWithPagination(namespace, query, bookmark string, pageSize int32) (statedb.QueryResultsIterator, error) {
+	logger.Debugf("Entering ExecuteQueryWithPagination namespace: %s,  query: %s,  bookmark: %s, pageSize: %d", namespace, query, bookmark, pageSize)
+	if err := validateCondition(query); err != nil {
+		return nil, err
+	}
+	stmt := richQueryStmt(query)
+	fetch := func(lastKey *string, limit int) ([]*statedb.VersionedKV, error) {
+		from := []byte(bookmark)
+		if lastKey != nil {
+			from = append([]byte(*lastKey), 0x00)
+		}
+		return vdb.queryKVs(namespace, stmt, vdb.channel, []byte(namespace), from, limit)
+	}
+	return vdb.newKVScanner(fetch, pageSize)
+}
+
+// GetDBType returns the name of the directory of the index definitions packaged with the chaincodes
+func (vdb *versionedDB) GetDBType() string {
+	return "sql"
+}
+
+// ProcessIndexesForChaincodeDeploy creates the indexes defined for a namespace. The indexes are created
+// on the table shared by all the namespaces and channels, an index that already exists is not recreated
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, indexFilesData map[string][]byte) error {
+	ctx, cancel := requestContext(vdb.config)
+	defer cancel()
+	for fileName, indexData := range indexFilesData {
+		def, err := parseIndexDefinition(indexData)
+		if err != nil {
+			return errors.WithMessagef(err, "error processing index file [%s] of namespace [%s]", fileName, namespace)
+		}
+		logger.Infof("Creating index [%s] of namespace [%s] on channel [%s]", def.Name, namespace, vdb.channel)
+		if _, err := vdb.db.ExecContext(ctx, def.createStmt()); err != nil {
+			return errors.Wrapf(err, "error creating index [%s] of namespace [%s]", def.Name, namespace)
+		}
+	}
+	return nil
+}
+
+// ApplyUpdates implements method in VersionedDB interface. The updates and the savepoint
+// are written in a single database transaction
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	ctx, cancel := requestContext(vdb.config)
+	defer cancel()
+	tx, err := vdb.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "error starting a database transaction")
+	}
+	defer tx.Rollback()
+
+	// the rows are written in a deterministic order to avoid deadlocks between the transactions
+	namespaces := batch.GetUpdatedNamespaces()
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		updates := batch.GetUpdates(ns)
+		keys := make([]string, 0, len(updates))
+		for k := range updates {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := writeKV(ctx, tx, vdb.channel, ns, k, updates[k]); err != nil {
+				return err
+			}
+		}
+	}
+	// Record a savepoint at a given height
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+	// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+	// in the pvtstore acts as a savepoint for pvt data.
+	if height != nil {
+		if _, err := tx.ExecContext(ctx, upsertSavepointStmt, vdb.channel, height.ToBytes()); err != nil {
+			return errors.Wrap(err, "error writing the savepoint")
+		}
+	}
+	return errors.Wrap(tx.Commit(), "error committing a database transaction")
+}
+
+func writeKV(ctx context.Context, tx *sql.Tx, channel, ns, key string, vv *statedb.VersionedValue) error {
+	if vv.Value == nil {
+		_, err := tx.ExecContext(ctx, deleteStateStmt, channel, []byte(ns), []byte(key))
+		return errors.Wrapf(err, "error deleting key [%s] of namespace [%s]", key, ns)
+	}
+	_, err := tx.ExecContext(ctx, upsertStateStmt,
+		channel, []byte(ns), []byte(key), vv.Value, jsonValue(vv.Value), vv.Metadata, vv.Version.ToBytes())
+	return errors.Wrapf(err, "error writing key [%s] of namespace [%s]", key, ns)
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	ctx, cancel := requestContext(vdb.config)
+	defer cancel()
+	var heightBytes []byte
+	err := vdb.db.QueryRowContext(ctx, selectSavepointStmt, vdb.channel).Scan(&heightBytes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving the savepoint")
+	}
+	height, _, err := version.NewHeightFromBytes(heightBytes)
+	if err != nil {
+		return nil, err
+	}
+	return height, nil
+}
+
+// GetFullScanIterator implements method in VersionedDB interface. The values are
+// returned in the encoding used by stateleveldb
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.FullScanIterator, byte, error) {
+	return &fullDBScanner{vdb: vdb, toSkip: skipNamespace}, fullScanIteratorValueFormat, nil
+}
+
+// importState imports the state from a previously snapshotted state
+func (vdb *versionedDB) importState(itr statedb.FullScanIterator, savepoint *version.Height, dbValueFormat byte) error {
+	if itr != nil && dbValueFormat != fullScanIteratorValueFormat {
+		return errors.Errorf("value format [%x] not supported. Expected value format [%x]",
+			dbValueFormat, fullScanIteratorValueFormat)
+	}
+	for {
+		batch := statedb.NewUpdateBatch()
+		batchSize := 0
+		for itr != nil && batchSize < maxDataImportBatchSize {
+			compositeKey, dbValue, err := itr.Next()
+			if err != nil {
+				return err
+			}
+			if compositeKey == nil {
+				itr = nil
+				break
+			}
+			vv, err := decodeValue(dbValue)
+			if err != nil {
+				return err
+			}
+			batch.PutValAndMetadata(compositeKey.Namespace, compositeKey.Key, vv.Value, vv.Metadata, vv.Version)
+			batchSize++
+		}
+		if itr == nil {
+			// the savepoint is written with the last batch
+			return vdb.ApplyUpdates(batch, savepoint)
+		}
+		if err := vdb.ApplyUpdates(batch, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// queryKVs executes a statement that returns the columns key, value, metadata and version of the
+// rows of a namespace. The limit is appended to the arguments of the statement
+func (vdb *versionedDB) queryKVs(namespace, stmt string, args ...interface{}) ([]*statedb.VersionedKV, error) {
+	ctx, cancel := requestContext(vdb.config)
+	defer cancel()
+	// the queries are executed in a read-only transaction to make sure that a rich query cannot modify the state
+	tx, err := vdb.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "error starting a database transaction")
+	}
+	defer tx.Rollback()
+	rows, err := tx.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error querying namespace [%s]", namespace)
+	}
+	defer rows.Close()
+
+	var kvs []*statedb.VersionedKV
+	for rows.Next() {
+		var key, value, metadata, versionBytes []byte
+		if err := rows.Scan(&key, &value, &metadata, &versionBytes); err != nil {
+			return nil, errors.Wrapf(err, "error reading the results of a query on namespace [%s]", namespace)
+		}
+		vv, err := decodeRow(value, metadata, versionBytes)
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: string(key)},
+			VersionedValue: *vv,
+		})
+	}
+	return kvs, errors.Wrapf(rows.Err(), "error reading the results of a query on namespace [%s]", namespace)
+}
+
+func requestContext(config *ledger.SQLConfig) (context.Context, context.CancelFunc) {
+	if config.RequestTimeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), config.RequestTimeout)
+}
+
+func decodeRow(value, metadata, versionBytes []byte) (*statedb.VersionedValue, error) {
+	ver, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return &statedb.VersionedValue{Value: value, Metadata: metadata, Version: ver}, nil
+}
+
+// encodeValue encodes the value, version, and metadata the same way as stateleveldb
+func encodeValue(vv *statedb.VersionedValue) ([]byte, error) {
+	return proto.Marshal(
+		&stateleveldb.DBValue{
+			Version:  vv.Version.ToBytes(),
+			Value:    vv.Value,
+			Metadata: vv.Metadata,
+		},
+	)
+}
+
+// decodeValue decodes the value bytes encoded by encodeValue
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	dbValue := &stateleveldb.DBValue{}
+	if err := proto.Unmarshal(encodedValue, dbValue); err != nil {
+		return nil, err
+	}
+	return decodeRow(dbValue.Value, dbValue.Metadata, dbValue.Version)
+}
+
+// pageFetcher returns up to limit results that follow the lastKey, or the first
+// results if the lastKey is nil
+type pageFetcher func(lastKey *string, limit int) ([]*statedb.VersionedKV, error)
+
+// kvScanner fetches the results in pages of the internal query limit
+type kvScanner struct {
+	fetch                pageFetcher
+	internalQueryLimit   int
+	requestedLimit       int32
+	totalRecordsReturned int32
+	buffer               []*statedb.VersionedKV
+	lastKey              *string
+	exhausted            bool
+}
+
+func (vdb *versionedDB) newKVScanner(fetch pageFetcher, requestedLimit int32) (*kvScanner, error) {
+	internalQueryLimit := vdb.config.InternalQueryLimit
+	if internalQueryLimit <= 0 {
+		internalQueryLimit = 1000
+	}
+	scanner := &kvScanner{
+		fetch:              fetch,
+		internalQueryLimit: internalQueryLimit,
+		requestedLimit:     requestedLimit,
+	}
+	// the first page is fetched upfront so that an invalid query is reported when the iterator is created
+	if err := scanner.fetchNextPage(); err != nil {
+		return nil, err
+	}
+	return scanner, nil
+}
+
+func (scanner *kvScanner) fetchNextPage() error {
+	kvs, err := scanner.fetch(scanner.lastKey, scanner.internalQueryLimit)
+	if err != nil {
+		return err
+	}
+	scanner.buffer = kvs
+	scanner.exhausted = len(kvs) < scanner.internalQueryLimit
+	return nil
+}
+
+func (scanner *kvScanner) next() (*statedb.VersionedKV, error) {
+	if len(scanner.buffer) == 0 {
+		if scanner.exhausted {
+			return nil, nil
+		}
+		if err := scanner.fetchNextPage(); err != nil {
+			return nil, err
+		}
+		if len(scanner.buffer) == 0 {
+			return nil, nil
+		}
+	}
+	kv := scanner.buffer[0]
+	scanner.buffer = scanner.buffer[1:]
+	scanner.lastKey = &kv.Key
+	return kv, nil
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if scanner.requestedLimit > 0 && scanner.totalRecordsReturned >= scanner.requestedLimit {
+		return nil, nil
+	}
+	kv, err := scanner.next()
+	if err != nil || kv == nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return kv, nil
+}
+
+func (scanner *kvScanner) Close() {
+	scanner.buffer = nil
+	scanner.exhausted = true
+}
+
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	kv, err := scanner.next()
+	if err != nil {
+		logger.Warningf("Error retrieving the bookmark: %s", err)
+		return ""
+	}
+	if kv == nil {
+		return ""
+	}
+	return kv.Key
+}
+
+// fullDBScanner iterates over the state of a channel in the order of <Namespace, key>
+type fullDBScanner struct {
+	vdb       *versionedDB
+	toSkip    func(namespace string) bool
+	buffer    []*statedb.CompositeKey
+	values    [][]byte
+	last      *statedb.CompositeKey
+	exhausted bool
+}
+
+// Next returns the key-values in the lexical order of <Namespace, key>
+func (s *fullDBScanner) Next() (*statedb.CompositeKey, []byte, error) {
+	for {
+		if len(s.buffer) == 0 {
+			if s.exhausted {
+				return nil, nil, nil
+			}
+			if err := s.fetchNextPage(); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		compositeKey, dbValue := s.buffer[0], s.values[0]
+		s.buffer, s.values = s.buffer[1:], s.values[1:]
+		s.last = compositeKey
+		if !s.toSkip(compositeKey.Namespace) {
+			return compositeKey, dbValue, nil
+		}
+	}
+}
+
+func (s *fullDBScanner) fetchNextPage() error {
+	limit := s.vdb.config.InternalQueryLimit
+	if limit <= 0 {
+		limit = 1000
+	}
+	ctx, cancel := requestContext(s.vdb.config)
+	defer cancel()
+	var rows *sql.Rows
+	var err error
+	if s.last == nil {
+		rows, err = s.vdb.db.QueryContext(ctx, selectAllStmt, s.vdb.channel, limit)
+	} else {
+		rows, err = s.vdb.db.QueryContext(ctx, selectAllAfterStmt, s.vdb.channel, []byte(s.last.Namespace), []byte(s.last.Key), limit)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error scanning the state of channel [%s]", s.vdb.channel)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var ns, key, value, metadata, versionBytes []byte
+		if err := rows.Scan(&ns, &key, &value, &metadata, &versionBytes); err != nil {
+			return errors.Wrapf(err, "error scanning the state of channel [%s]", s.vdb.channel)
+		}
+		vv, err := decodeRow(value, metadata, versionBytes)
+		if err != nil {
+			return err
+		}
+		dbValue, err := encodeValue(vv)
+		if err != nil {
+			return err
+		}
+		s.buffer = append(s.buffer, &statedb.CompositeKey{Namespace: string(ns), Key: string(key)})
+		s.values = append(s.values, dbValue)
+		count++
+	}
+	s.exhausted = count < limit
+	return errors.Wrapf(rows.Err(), "error scanning the state of channel [%s]", s.vdb.channel)
+}
+
+func (s *fullDBScanner) Close() {
+	if s == nil {
+		return
+	}
+	s.buffer, s.values = nil, nil
+	s.exhausted = true
+}
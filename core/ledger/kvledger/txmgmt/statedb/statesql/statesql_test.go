@@ -0,0 +1,352 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/stretchr/testify/require"
+)
+
+var testDataSourceCount int
+
+// testVDBEnv provides a versioned db backed by the fake driver. The internal query
+// limit is small so that the range scans and the queries fetch several pages
+type testVDBEnv struct {
+	config     *ledger.SQLConfig
+	DBProvider *VersionedDBProvider
+}
+
+func newTestVDBEnv(t *testing.T) *testVDBEnv {
+	testDataSourceCount++
+	config := &ledger.SQLConfig{
+		DriverName:         fakeDriverName,
+		DataSourceName:     fmt.Sprintf("%s-%d", t.Name(), testDataSourceCount),
+		RequestTimeout:     10 * time.Second,
+		InternalQueryLimit: 2,
+	}
+	dbProvider, err := NewVersionedDBProvider(config)
+	require.NoError(t, err)
+	return &testVDBEnv{config: config, DBProvider: dbProvider}
+}
+
+func (env *testVDBEnv) cleanup() {
+	env.DBProvider.Close()
+}
+
+func TestBasicRW(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestBasicRW(t, env.DBProvider)
+}
+
+func TestMultiDBBasicRW(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestMultiDBBasicRW(t, env.DBProvider)
+}
+
+func TestDeletes(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestDeletes(t, env.DBProvider)
+}
+
+func TestIterator(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestIterator(t, env.DBProvider)
+}
+
+func TestGetStateMultipleKeys(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestGetStateMultipleKeys(t, env.DBProvider)
+}
+
+func TestGetVersion(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestGetVersion(t, env.DBProvider)
+}
+
+func TestValueAndMetadataWrites(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestValueAndMetadataWrites(t, env.DBProvider)
+}
+
+func TestPaginatedRangeQuery(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestPaginatedRangeQuery(t, env.DBProvider)
+}
+
+func TestReverseRangeQuery(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestReverseRangeQuery(t, env.DBProvider)
+}
+
+func TestRangeQuerySpecialCharacters(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestRangeQuerySpecialCharacters(t, env.DBProvider)
+}
+
+func TestApplyUpdatesWithNilHeight(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
+}
+
+func TestDataExportImport(t *testing.T) {
+	// smaller batch size for testing to cover the boundary case of writing the final batch
+	defer func(size int) { maxDataImportBatchSize = size }(maxDataImportBatchSize)
+	maxDataImportBatchSize = 3
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	commontests.TestDataExportImport(t, env.DBProvider, byte(1))
+}
+
+func TestDataExportImportWithLevelDB(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	levelDBEnv := stateleveldb.NewTestVDBEnv(t)
+	defer levelDBEnv.Cleanup()
+
+	sqlDB, err := env.DBProvider.GetDBHandle("sqlLedger", nil)
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.PutValAndMetadata("ns1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	batch.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	require.NoError(t, sqlDB.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	// the snapshot of the SQL database can be imported in leveldb, and the other way around
+	itr, format, err := sqlDB.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	require.NoError(t, levelDBEnv.DBProvider.BootstrapDBFromState("levelLedger", version.NewHeight(1, 2), itr, format))
+	levelDB, err := levelDBEnv.DBProvider.GetDBHandle("levelLedger", nil)
+	require.NoError(t, err)
+
+	itr, format, err = levelDB.GetFullScanIterator(func(ns string) bool { return ns == "ns2" })
+	require.NoError(t, err)
+	require.NoError(t, env.DBProvider.BootstrapDBFromState("sqlLedger2", version.NewHeight(1, 2), itr, format))
+	sqlDB2, err := env.DBProvider.GetDBHandle("sqlLedger2", nil)
+	require.NoError(t, err)
+
+	vv, err := sqlDB2.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("metadata1"), Version: version.NewHeight(1, 1)}, vv)
+	vv, err = sqlDB2.GetState("ns2", "key2")
+	require.NoError(t, err)
+	require.Nil(t, vv)
+	vv, err = levelDB.GetState("ns2", "key2")
+	require.NoError(t, err)
+	require.Equal(t, &statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}, vv)
+
+	err = env.DBProvider.BootstrapDBFromState("sqlLedger3", version.NewHeight(1, 2), itr, byte(2))
+	require.EqualError(t, err, "value format [2] not supported. Expected value format [1]")
+}
+
+func TestDrop(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+
+	checkDBsAfterDropFunc := func(channelName string) {
+		db, err := env.DBProvider.GetDBHandle(channelName, nil)
+		require.NoError(t, err)
+		savepoint, err := db.GetLatestSavePoint()
+		require.NoError(t, err)
+		require.Nil(t, savepoint)
+		itr, _, err := db.GetFullScanIterator(func(string) bool { return false })
+		require.NoError(t, err)
+		compositeKey, _, err := itr.Next()
+		require.NoError(t, err)
+		require.Nil(t, compositeKey)
+	}
+	commontests.TestDrop(t, env.DBProvider, checkDBsAfterDropFunc)
+}
+
+func TestDropApplicationDBs(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	db, err := env.DBProvider.GetDBHandle("testdropapplicationdbs", nil)
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	require.NoError(t, DropApplicationDBs(env.config))
+	_, err = db.GetState("ns1", "key1")
+	require.EqualError(t, err, `error retrieving key [key1] of namespace [ns1]: relation "fabric_state" does not exist`)
+
+	// the tables are created again when the peer starts
+	env.DBProvider.Close()
+	env.DBProvider, err = NewVersionedDBProvider(env.config)
+	require.NoError(t, err)
+	db, err = env.DBProvider.GetDBHandle("testdropapplicationdbs", nil)
+	require.NoError(t, err)
+	vv, err := db.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.Nil(t, vv)
+}
+
+func TestNewVersionedDBProviderUnknownDriver(t *testing.T) {
+	_, err := NewVersionedDBProvider(&ledger.SQLConfig{DriverName: "unknown"})
+	require.EqualError(t, err, `error opening the database with driver [unknown]: sql: unknown driver "unknown" (forgotten import?)`)
+}
+
+func TestRichQuery(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	db, err := env.DBProvider.GetDBHandle("testrichquery", nil)
+	require.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	for i, owner := range []string{"tom", "jerry", "tom", "tom", "spike", "tom"} {
+		batch.Put("ns1", fmt.Sprintf("asset%d", i), []byte(fmt.Sprintf(`{"owner":"%s","size":%d}`, owner, i)), version.NewHeight(1, uint64(i)))
+	}
+	batch.Put("ns1", "not-json", []byte("owner tom"), version.NewHeight(1, 6))
+	batch.Put("ns2", "asset0", []byte(`{"owner":"tom"}`), version.NewHeight(1, 7))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 7)))
+
+	query := "value_json->>'owner' = 'tom'"
+	itr, err := db.ExecuteQuery("ns1", query)
+	require.NoError(t, err)
+	requireKeys(t, itr, "asset0", "asset2", "asset3", "asset5")
+
+	// the bookmark is the key of the first result of the next page
+	pagedItr, err := db.ExecuteQueryWithPagination("ns1", query, "", 3)
+	require.NoError(t, err)
+	requireKeys(t, pagedItr, "asset0", "asset2", "asset3")
+	require.Equal(t, "asset5", pagedItr.GetBookmarkAndClose())
+
+	pagedItr, err = db.ExecuteQueryWithPagination("ns1", query, "asset5", 3)
+	require.NoError(t, err)
+	requireKeys(t, pagedItr, "asset5")
+	require.Equal(t, "", pagedItr.GetBookmarkAndClose())
+
+	itr, err = db.ExecuteQuery("ns1", "value_json->>'owner' = 'tom' AND value_json->>'size' = '3'")
+	require.NoError(t, err)
+	requireKeys(t, itr, "asset3")
+
+	_, err = db.ExecuteQuery("ns1", "value_json->>'owner' = 'tom') OR (true")
+	require.EqualError(t, err, "invalid query: unbalanced parentheses")
+
+	// an error of the database is reported when the iterator is created
+	_, err = db.ExecuteQuery("ns1", "value_json->>'owner' <> 'tom'")
+	require.EqualError(t, err, "error querying namespace [ns1]: syntax error in condition: value_json->>'owner' <> 'tom'")
+}
+
+func requireKeys(t *testing.T, itr statedb.ResultsIterator, expectedKeys ...string) {
+	var keys []string
+	for {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		if result == nil {
+			break
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+	require.Equal(t, expectedKeys, keys)
+}
+
+func TestValidateCondition(t *testing.T) {
+	tests := []struct {
+		condition   string
+		expectedErr string
+	}{
+		{condition: "value_json->>'owner' = 'tom'"},
+		{condition: "(value_json->>'size')::numeric > 10 AND value_json#>>'{a,b}' IN ('x', 'y')"},
+		{condition: "lower(value_json->>'owner') LIKE 'to%' OR value_json ? 'color'"},
+		{condition: "value_json->>'owner' = 'it''s; select -- /*'"},
+		{condition: "", expectedErr: "invalid query: the query must be a SQL condition on the column value_json"},
+		{condition: "value_json->>'owner' = 'tom'; DROP TABLE fabric_state", expectedErr: "invalid query: character [;] is not allowed"},
+		{condition: "true) OR (true", expectedErr: "invalid query: unbalanced parentheses"},
+		{condition: "(true", expectedErr: "invalid query: unbalanced parentheses"},
+		{condition: "value_json->>'owner' = 'tom", expectedErr: "invalid query: unterminated string literal"},
+		{condition: "true -- comment", expectedErr: "invalid query: comments are not allowed"},
+		{condition: "true /* comment */", expectedErr: "invalid query: comments are not allowed"},
+		{condition: "channel = 'ch2'", expectedErr: "invalid query: [channel] is not allowed"},
+		{condition: "EXISTS (SELECT 1 FROM fabric_state)", expectedErr: "invalid query: [EXISTS] is not allowed"},
+		{condition: "pg_sleep(10) IS NULL", expectedErr: "invalid query: [pg_sleep] is not allowed"},
+		{condition: `value_json->>'owner' = "tom"`, expectedErr: `invalid query: character ["] is not allowed`},
+		{condition: "value_json->>'owner' = $1", expectedErr: "invalid query: character [$] is not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			err := validateCondition(tt.condition)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	require.Equal(t, `{"a":{"b":[1,2]},"n":12345678901234567890}`, jsonValue([]byte(` {"n": 12345678901234567890, "a": {"b": [1, 2]}} `)))
+	require.Nil(t, jsonValue([]byte(`[1,2]`)))
+	require.Nil(t, jsonValue([]byte(`"string"`)))
+	require.Nil(t, jsonValue([]byte(`{"a":`)))
+	require.Nil(t, jsonValue([]byte(`{"a":"\u0000"}`)))
+	require.Nil(t, jsonValue([]byte{}))
+}
+
+func TestProcessIndexesForChaincodeDeploy(t *testing.T) {
+	env := newTestVDBEnv(t)
+	defer env.cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes", nil)
+	require.NoError(t, err)
+	indexCapable, ok := db.(statedb.IndexCapable)
+	require.True(t, ok)
+	require.Equal(t, "sql", indexCapable.GetDBType())
+
+	err = indexCapable.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{
+		"META-INF/statedb/sql/indexes/indexOwner.json": []byte(`{"name":"indexOwner","fields":["docType","owner.name"]}`),
+	})
+	require.NoError(t, err)
+	fakeDB := env.DBProvider.db.Driver().(*fakeDriver).databases[env.config.DataSourceName]
+	require.Equal(t, []string{
+		"CREATE INDEX IF NOT EXISTS fabric_idx_29f78a9c625b51c9 ON fabric_state (namespace, (value_json->>'docType'), (value_json#>>'{owner,name}'))",
+	}, fakeDB.indexes)
+
+	tests := []struct {
+		indexData   string
+		expectedErr string
+	}{
+		{
+			indexData:   `invalid json`,
+			expectedErr: "error processing index file [index.json] of namespace [ns1]: invalid index definition: invalid character 'i' looking for beginning of value",
+		},
+		{
+			indexData:   `{"fields":["owner"]}`,
+			expectedErr: "error processing index file [index.json] of namespace [ns1]: invalid index definition: the name is missing",
+		},
+		{
+			indexData:   `{"name":"indexOwner"}`,
+			expectedErr: "error processing index file [index.json] of namespace [ns1]: invalid index definition [indexOwner]: the fields are missing",
+		},
+		{
+			indexData:   `{"name":"indexOwner","fields":["owner')); DROP TABLE fabric_state; --"]}`,
+			expectedErr: "error processing index file [index.json] of namespace [ns1]: invalid index definition [indexOwner]: invalid field [owner')); DROP TABLE fabric_state; --]",
+		},
+	}
+	for _, tt := range tests {
+		err := indexCapable.ProcessIndexesForChaincodeDeploy("ns1", map[string][]byte{"index.json": []byte(tt.indexData)})
+		require.EqualError(t, err, tt.expectedErr)
+	}
+}
@@ -97,6 +97,8 @@ type Initializer struct {
 	CCInfoProvider      ledger.DeployedChaincodeInfoProvider
 	CustomTxProcessors  map[common.HeaderType]ledger.CustomTxProcessor
 	HashFunc            rwsetutil.HashFunc
+	PurgeChecker        validation.PurgeChecker
+	CrossChannelChecker validation.CrossChannelChecker
 }
 
 // NewLockBasedTxMgr constructs a new instance of NewLockBasedTxMgr
@@ -129,7 +131,9 @@ func NewLockBasedTxMgr(initializer *Initializer) (*LockBasedTxMgr, error) {
 		txmgr,
 		initializer.DB,
 		initializer.CustomTxProcessors,
-		initializer.HashFunc)
+		initializer.HashFunc,
+		initializer.PurgeChecker,
+		initializer.CrossChannelChecker)
 	return txmgr, nil
 }
 
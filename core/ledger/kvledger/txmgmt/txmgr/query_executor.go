@@ -131,6 +131,7 @@ func (q *queryExecutor) GetStateRangeScanIterator(namespace string, startKey str
 		startKey,
 		endKey,
 		0,
+		false,
 		q.txmgr.db,
 		q.rwsetBuilder,
 		queryReadsHashingEnabled,
@@ -153,6 +154,39 @@ func (q *queryExecutor) GetStateRangeScanIteratorWithPagination(namespace string
 		startKey,
 		endKey,
 		pageSize,
+		false,
+		q.txmgr.db,
+		q.rwsetBuilder,
+		queryReadsHashingEnabled,
+		maxDegreeQueryReadsHashing,
+		q.hasher,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q.itrs = append(q.itrs, itr)
+	return itr, nil
+}
+
+// GetStateReverseRangeScanIterator implements method in interface `ledger.QueryExecutor`
+// The keys are returned in descending order, starting at the startKey (included) and moving towards
+// the endKey (excluded). An empty startKey refers to the last available key and an empty endKey refers
+// to the first available key.
+func (q *queryExecutor) GetStateReverseRangeScanIterator(namespace string, startKey string, endKey string) (commonledger.ResultsIterator, error) {
+	return q.GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey, 0)
+}
+
+// GetStateReverseRangeScanIteratorWithPagination implements method in interface `ledger.QueryExecutor`
+func (q *queryExecutor) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string, endKey string, pageSize int32) (ledger.QueryResultsIterator, error) {
+	if err := q.checkDone(); err != nil {
+		return nil, err
+	}
+	itr, err := newResultsItr(
+		namespace,
+		startKey,
+		endKey,
+		pageSize,
+		true,
 		q.txmgr.db,
 		q.rwsetBuilder,
 		queryReadsHashingEnabled,
@@ -389,33 +423,46 @@ func (q *queryExecutor) validateCollName(ns, coll string) error {
 // for performing phantom read validation during commit
 type resultsItr struct {
 	ns                      string
+	startKey                string
 	endKey                  string
+	reverse                 bool
 	dbItr                   statedb.ResultsIterator
 	rwSetBuilder            *rwsetutil.RWSetBuilder
 	rangeQueryInfo          *kvrwset.RangeQueryInfo
 	rangeQueryResultsHelper *rwsetutil.RangeQueryResultsHelper
+	// reverseReads holds the reads of a reverse range scan, in the descending order
+	// in which they were returned to the caller
+	reverseReads []*kvrwset.KVRead
 }
 
-func newResultsItr(ns string, startKey string, endKey string, pageSize int32,
+func newResultsItr(ns string, startKey string, endKey string, pageSize int32, reverse bool,
 	db statedb.VersionedDB, rwsetBuilder *rwsetutil.RWSetBuilder, enableHashing bool,
 	maxDegree uint32, hashFunc rwsetutil.HashFunc) (*resultsItr, error) {
 	var err error
 	var dbItr statedb.ResultsIterator
-	if pageSize == 0 {
+	switch {
+	case reverse && pageSize == 0:
+		dbItr, err = db.GetStateReverseRangeScanIterator(ns, startKey, endKey)
+	case reverse:
+		dbItr, err = db.GetStateReverseRangeScanIteratorWithPagination(ns, startKey, endKey, pageSize)
+	case pageSize == 0:
 		dbItr, err = db.GetStateRangeScanIterator(ns, startKey, endKey)
-	} else {
+	default:
 		dbItr, err = db.GetStateRangeScanIteratorWithPagination(ns, startKey, endKey, pageSize)
 	}
 	if err != nil {
 		return nil, err
 	}
-	itr := &resultsItr{ns: ns, dbItr: dbItr}
+	itr := &resultsItr{ns: ns, reverse: reverse, dbItr: dbItr}
 	// it's a simulation request so, enable capture of range query info
 	if rwsetBuilder != nil {
 		itr.rwSetBuilder = rwsetBuilder
+		itr.startKey = startKey
 		itr.endKey = endKey
-		// just set the StartKey... set the EndKey later below in the Next() method.
-		itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{StartKey: startKey}
+		if !reverse {
+			// just set the StartKey... set the EndKey later below in the Next() method.
+			itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{StartKey: startKey}
+		}
 		resultsHelper, err := rwsetutil.NewRangeQueryResultsHelper(enableHashing, maxDegree, hashFunc)
 		if err != nil {
 			return nil, err
@@ -463,6 +510,10 @@ func (itr *resultsItr) updateRangeQueryInfo(queryResult statedb.QueryResult) {
 	if itr.rwSetBuilder == nil {
 		return
 	}
+	if itr.reverse {
+		itr.updateReverseRangeQueryInfo(queryResult)
+		return
+	}
 
 	if queryResult == nil {
 		// caller scanned till the iterator got exhausted.
@@ -478,6 +529,38 @@ func (itr *resultsItr) updateRangeQueryInfo(queryResult statedb.QueryResult) {
 	itr.rangeQueryInfo.EndKey = versionedKV.Key
 }
 
+// updateReverseRangeQueryInfo captures the range query info for a reverse range scan.
+// The phantom read validation during commit iterates the keys in ascending order. Hence, the range
+// is captured as the equivalent ascending range i.e., the StartKey is set to the latest key retrieved
+// by the caller (or to the key following the endKey supplied in the query, if the iterator is exhausted)
+// and the EndKey is set to the key following the startKey supplied in the query. As the captured
+// range is always read entirely, the ItrExhausted is always set to true. The reads are added to the
+// results helper in ascending order when the simulation is done
+func (itr *resultsItr) updateReverseRangeQueryInfo(queryResult statedb.QueryResult) {
+	if itr.rangeQueryInfo == nil {
+		itr.rangeQueryInfo = &kvrwset.RangeQueryInfo{
+			EndKey:       nextKey(itr.startKey),
+			ItrExhausted: true,
+		}
+	}
+	if queryResult == nil {
+		itr.rangeQueryInfo.StartKey = nextKey(itr.endKey)
+		return
+	}
+	versionedKV := queryResult.(*statedb.VersionedKV)
+	itr.reverseReads = append(itr.reverseReads, rwsetutil.NewKVRead(versionedKV.Key, versionedKV.Version))
+	itr.rangeQueryInfo.StartKey = versionedKV.Key
+}
+
+// nextKey returns the smallest key that is greater than the given key. An empty key
+// denotes an open end of a range and hence, it is returned as is
+func nextKey(key string) string {
+	if key == "" {
+		return key
+	}
+	return key + "\x00"
+}
+
 // Close implements method in interface ledger.ResultsIterator
 func (itr *resultsItr) Close() {
 	itr.dbItr.Close()
@@ -566,6 +649,16 @@ func (q *queryExecutor) addRangeQueryInfo() {
 		return
 	}
 	for _, itr := range q.itrs {
+		if itr.rangeQueryInfo == nil {
+			// a reverse range scan that was never iterated, so nothing was read
+			continue
+		}
+		for i := len(itr.reverseReads) - 1; i >= 0; i-- {
+			if err := itr.rangeQueryResultsHelper.AddResult(itr.reverseReads[i]); err != nil {
+				q.err = err
+				return
+			}
+		}
 		results, hash, err := itr.rangeQueryResultsHelper.Done()
 		if err != nil {
 			q.err = err
@@ -88,6 +88,19 @@ func (s *txSimulator) DeletePrivateData(ns, coll, key string) error {
 	return s.SetPrivateData(ns, coll, key, nil)
 }
 
+// PurgePrivateData implements method in interface `ledger.TxSimulator`
+func (s *txSimulator) PurgePrivateData(ns, coll, key string) error {
+	if err := s.queryExecutor.validateCollName(ns, coll); err != nil {
+		return err
+	}
+	if err := s.checkWritePrecondition(key, nil); err != nil {
+		return err
+	}
+	s.writePerformed = true
+	s.rwsetBuilder.AddToPvtAndHashedWriteSetForPurge(ns, coll, key)
+	return nil
+}
+
 // SetPrivateDataMultipleKeys implements method in interface `ledger.TxSimulator`
 func (s *txSimulator) SetPrivateDataMultipleKeys(ns, coll string, kvs map[string][]byte) error {
 	for k, v := range kvs {
@@ -140,6 +153,15 @@ func (s *txSimulator) GetStateRangeScanIteratorWithPagination(namespace string,
 	return s.queryExecutor.GetStateRangeScanIteratorWithPagination(namespace, startKey, endKey, pageSize)
 }
 
+// GetStateReverseRangeScanIteratorWithPagination implements method in interface `ledger.QueryExecutor`
+func (s *txSimulator) GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey string,
+	endKey string, pageSize int32) (ledger.QueryResultsIterator, error) {
+	if err := s.checkBeforePaginatedQueries(); err != nil {
+		return nil, err
+	}
+	return s.queryExecutor.GetStateReverseRangeScanIteratorWithPagination(namespace, startKey, endKey, pageSize)
+}
+
 // ExecuteQueryWithPagination implements method in interface `ledger.QueryExecutor`
 func (s *txSimulator) ExecuteQueryWithPagination(namespace, query, bookmark string, pageSize int32) (ledger.QueryResultsIterator, error) {
 	if err := s.checkBeforePaginatedQueries(); err != nil {
@@ -320,6 +320,134 @@ func testTxPhantomValidation(t *testing.T, env testEnv) {
 	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
 }
 
+func TestTxPhantomValidationWithReverseItr(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testtxphantomvalidationwithreverseitr"
+		testEnv.init(t, testLedgerID, nil)
+		testTxPhantomValidationWithReverseItr(t, testEnv)
+		testEnv.cleanup()
+	}
+}
+
+func testTxPhantomValidationWithReverseItr(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	for i := 1; i <= 6; i++ {
+		s1.SetState("ns", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)))
+	}
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	s1.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	simulateReverseScan := func(txid, startKey, endKey string, numResults int, expectedKeys []string) *ledger.TxSimulationResults {
+		s, _ := txMgr.NewTxSimulator(txid)
+		itr, err := s.GetStateReverseRangeScanIterator("ns", startKey, endKey)
+		require.NoError(t, err)
+		var keys []string
+		for i := 0; numResults == 0 || i < numResults; i++ {
+			result, err := itr.Next()
+			require.NoError(t, err)
+			if result == nil {
+				break
+			}
+			keys = append(keys, result.(*queryresult.KV).Key)
+		}
+		itr.Close()
+		require.Equal(t, expectedKeys, keys)
+		require.NoError(t, s.SetState("ns1", "key", []byte(txid)))
+		results, err := s.GetTxSimulationResults()
+		require.NoError(t, err)
+		s.Done()
+		return results
+	}
+
+	// tx2 scans the entire range (key2, key5]
+	txRWSet2 := simulateReverseScan("test_tx2", "key5", "key2", 0, []string{"key5", "key4", "key3"})
+	// tx3 stops after retrieving key6 and key5
+	txRWSet3 := simulateReverseScan("test_tx3", "", "", 2, []string{"key6", "key5"})
+	// tx4 scans the range that ends just above the phantom key
+	txRWSet4 := simulateReverseScan("test_tx4", "", "key4", 0, []string{"key6", "key5"})
+	// tx5 scans the range that starts just below the phantom key
+	txRWSet5 := simulateReverseScan("test_tx5", "key3", "", 0, []string{"key3", "key2", "key1"})
+
+	// insert a key between key3 and key4
+	s6, _ := txMgr.NewTxSimulator("test_tx6")
+	s6.SetState("ns", "key3a", []byte("value3a"))
+	txRWSet6, _ := s6.GetTxSimulationResults()
+	s6.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet6.PubSimulationResults)
+
+	// txRWSet2 is invalid as a new key has been inserted in the range
+	txMgrHelper.checkRWsetInvalid(txRWSet2.PubSimulationResults)
+	// txRWSet3, txRWSet4, and txRWSet5 are valid as the new key is outside of the ranges they have read
+	txMgrHelper.validateAndCommitRWSet(txRWSet3.PubSimulationResults)
+	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
+	txMgrHelper.validateAndCommitRWSet(txRWSet5.PubSimulationResults)
+
+	// updating the startKey (included in the range) invalidates the scan, whereas updating
+	// the endKey (excluded from the range) does not
+	txRWSet7 := simulateReverseScan("test_tx7", "key5", "key4", 0, []string{"key5"})
+	txRWSet8 := simulateReverseScan("test_tx8", "key6", "key5", 0, []string{"key6"})
+	s9, _ := txMgr.NewTxSimulator("test_tx9")
+	s9.SetState("ns", "key5", []byte("value5_new"))
+	txRWSet9, _ := s9.GetTxSimulationResults()
+	s9.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet9.PubSimulationResults)
+	txMgrHelper.checkRWsetInvalid(txRWSet7.PubSimulationResults)
+	txMgrHelper.validateAndCommitRWSet(txRWSet8.PubSimulationResults)
+
+	// a scan that reads more keys than the max degree of the merkle tree is validated by hashes
+	s10, _ := txMgr.NewTxSimulator("test_tx10")
+	var largeScanKeys []string
+	for i := 1; i <= 3*int(maxDegreeQueryReadsHashing); i++ {
+		key := createTestKey(i)
+		s10.SetState("ns", key, createTestValue(i))
+		largeScanKeys = append([]string{key}, largeScanKeys...)
+	}
+	txRWSet10, _ := s10.GetTxSimulationResults()
+	s10.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet10.PubSimulationResults)
+
+	txRWSet11 := simulateReverseScan("test_tx11", "key_z", "key_", 0, largeScanKeys)
+	txRWSet12 := simulateReverseScan("test_tx12", "key_z", "key_", 0, largeScanKeys)
+	txMgrHelper.validateAndCommitRWSet(txRWSet11.PubSimulationResults)
+	s13, _ := txMgr.NewTxSimulator("test_tx13")
+	s13.SetState("ns", createTestKey(75)+"a", []byte("value"))
+	txRWSet13, _ := s13.GetTxSimulationResults()
+	s13.Done()
+	txMgrHelper.validateAndCommitRWSet(txRWSet13.PubSimulationResults)
+	txMgrHelper.checkRWsetInvalid(txRWSet12.PubSimulationResults)
+}
+
+func TestReverseIterator(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Logf("Running test for TestEnv = %s", testEnv.getName())
+		testLedgerID := "testreverseiterator"
+		testEnv.init(t, testLedgerID, nil)
+		testIteratorPagingInit(t, testEnv, 10)
+
+		queryExecuter, _ := testEnv.getTxMgr().NewQueryExecutor("test_tx2")
+		itr, err := queryExecuter.GetStateReverseRangeScanIterator("cid", "key_007", "key_003")
+		require.NoError(t, err)
+		testItrWithoutClose(t, itr.(ledger.QueryResultsIterator), []string{"key_007", "key_006", "key_005", "key_004"})
+		itr.Close()
+
+		pageItr, err := queryExecuter.GetStateReverseRangeScanIteratorWithPagination("cid", "", "key_005", 3)
+		require.NoError(t, err)
+		testItrWithoutClose(t, pageItr, []string{"key_010", "key_009", "key_008"})
+		bookmark := pageItr.GetBookmarkAndClose()
+		require.Equal(t, "key_007", bookmark)
+
+		pageItr, err = queryExecuter.GetStateReverseRangeScanIteratorWithPagination("cid", bookmark, "key_005", 3)
+		require.NoError(t, err)
+		testItrWithoutClose(t, pageItr, []string{"key_007", "key_006"})
+		queryExecuter.Done()
+		testEnv.cleanup()
+	}
+}
+
 func TestIterator(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Logf("Running test for TestEnv = %s", testEnv.getName())
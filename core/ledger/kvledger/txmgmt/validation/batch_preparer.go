@@ -31,12 +31,27 @@ type PostOrderSimulatorProvider interface {
 	NewTxSimulator(txid string) (ledger.TxSimulator, error)
 }
 
+// PurgeChecker checks whether the keys of the private data were purged on demand
+type PurgeChecker interface {
+	// IsPurged returns true if the key with the given hash was purged by a transaction
+	// at or after the given height
+	IsPurged(ns, coll string, keyHash []byte, blkNum, txNum uint64) (bool, error)
+}
+
+// CrossChannelChecker checks whether the channel supports the cross-channel transactions
+type CrossChannelChecker interface {
+	// CrossChannelTransactions returns true if the channel supports the transactions that
+	// atomically modify the state of several channels
+	CrossChannelTransactions() bool
+}
+
 // CommitBatchPreparer performs validation and prepares the final batch that is to be committed to the statedb
 type CommitBatchPreparer struct {
 	postOrderSimulatorProvider PostOrderSimulatorProvider
 	db                         *privacyenabledstate.DB
 	validator                  *validator
 	customTxProcessors         map[common.HeaderType]ledger.CustomTxProcessor
+	purgeChecker               PurgeChecker
 }
 
 // TxStatInfo encapsulates information about a transaction
@@ -54,15 +69,19 @@ func NewCommitBatchPreparer(
 	db *privacyenabledstate.DB,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hashFunc rwsetutil.HashFunc,
+	purgeChecker PurgeChecker,
+	crossChannelChecker CrossChannelChecker,
 ) *CommitBatchPreparer {
 	return &CommitBatchPreparer{
 		postOrderSimulatorProvider,
 		db,
 		&validator{
-			db:       db,
-			hashFunc: hashFunc,
+			db:                  db,
+			hashFunc:            hashFunc,
+			crossChannelChecker: crossChannelChecker,
 		},
 		customTxProcessors,
+		purgeChecker,
 	}
 }
 
@@ -98,6 +117,7 @@ func (p *CommitBatchPreparer) ValidateAndPrepareBatch(blockAndPvtdata *ledger.Bl
 		pubAndHashUpdates,
 		blockAndPvtdata.PvtData,
 		p.customTxProcessors,
+		p.purgeChecker,
 	); err != nil {
 		return nil, nil, err
 	}
@@ -125,6 +145,7 @@ func validateAndPreparePvtBatch(
 	pubAndHashUpdates *publicAndHashUpdates,
 	pvtdata map[uint64]*ledger.TxPvtData,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
+	purgeChecker PurgeChecker,
 ) (*privacyenabledstate.PvtUpdateBatch, error) {
 
 	pvtUpdates := privacyenabledstate.NewPvtUpdateBatch()
@@ -141,7 +162,7 @@ func validateAndPreparePvtBatch(
 			continue
 		}
 		if requiresPvtdataValidation(txPvtdata) {
-			if err := validatePvtdata(tx, txPvtdata); err != nil {
+			if err := validatePvtdata(tx, txPvtdata, blk.num, purgeChecker); err != nil {
 				return nil, err
 			}
 		}
@@ -169,7 +190,7 @@ func requiresPvtdataValidation(tx *ledger.TxPvtData) bool {
 
 // validPvtdata returns true if hashes of all the collections writeset present in the pvt data
 // match with the corresponding hashes present in the public read-write set
-func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
+func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData, blkNum uint64, purgeChecker PurgeChecker) error {
 	if pvtdata.WriteSet == nil {
 		return nil
 	}
@@ -179,6 +200,15 @@ func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
 			collPvtdataHash := util.ComputeHash(collPvtdata.Rwset)
 			hashInPubdata := tx.retrieveHash(nsPvtdata.Namespace, collPvtdata.CollectionName)
 			if !bytes.Equal(collPvtdataHash, hashInPubdata) {
+				// the pvt data retrieved from the pvtdata store of this peer (e.g., when recommitting a block)
+				// does not contain the keys that were purged by a later transaction
+				trimmed, err := isTrimmedByPurge(collPvtdata.Rwset, tx, nsPvtdata.Namespace, collPvtdata.CollectionName, blkNum, purgeChecker)
+				if err != nil {
+					return err
+				}
+				if trimmed {
+					continue
+				}
 				return errors.Errorf(`hash of pvt data for collection [%s:%s] does not match with the corresponding hash in the public data. public hash = [%#v], pvt data hash = [%#v]`,
 					nsPvtdata.Namespace, collPvtdata.CollectionName, hashInPubdata, collPvtdataHash)
 			}
@@ -187,6 +217,28 @@ func validatePvtdata(tx *transaction, pvtdata *ledger.TxPvtData) error {
 	return nil
 }
 
+// isTrimmedByPurge returns true if the pvt data matches the hashed rwset of the collection except for
+// the writes of the keys that are recorded as purged, at or after the transaction, by the purge checker
+func isTrimmedByPurge(collPvtRwset []byte, tx *transaction, ns, coll string, blkNum uint64, purgeChecker PurgeChecker) (bool, error) {
+	if purgeChecker == nil {
+		return false, nil
+	}
+	trimmedKeyHashes, ok := rwsetutil.TrimmedKeyHashes(collPvtRwset, tx.retrieveHashedRwSet(ns, coll))
+	if !ok {
+		return false, nil
+	}
+	for _, keyHash := range trimmedKeyHashes {
+		purged, err := purgeChecker.IsPurged(ns, coll, keyHash, blkNum, uint64(tx.indexInBlock))
+		if err != nil {
+			return false, errors.WithMessagef(err, "error while checking whether the key [%x] of collection [%s:%s] is purged", keyHash, ns, coll)
+		}
+		if !purged {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // preprocessProtoBlock parses the proto instance of block into 'Block' structure.
 // The returned 'Block' structure contains only transactions that are endorser transactions and are not already marked as invalid
 func preprocessProtoBlock(postOrderSimulatorProvider PostOrderSimulatorProvider,
@@ -123,7 +123,7 @@ func TestValidateAndPreparePvtBatch(t *testing.T) {
 	require.NoError(t, err)
 	addPvtRWSetToPvtUpdateBatch(tx1TxPvtRWSet, expectedPvtUpdates, version.NewHeight(uint64(10), uint64(0)))
 
-	actualPvtUpdates, err := validateAndPreparePvtBatch(mvccValidatedBlock, testDB, nil, pvtDataMap, nil)
+	actualPvtUpdates, err := validateAndPreparePvtBatch(mvccValidatedBlock, testDB, nil, pvtDataMap, nil, nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedPvtUpdates, actualPvtUpdates)
 
@@ -297,7 +297,7 @@ func TestTxStatsInfoWithConfigTx(t *testing.T) {
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
 
-	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc)
+	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc, nil, nil)
 
 	gb := testutil.ConstructTestBlocks(t, 1)[0]
 	_, txStatsInfo, err := v.ValidateAndPrepareBatch(&ledger.BlockAndPvtData{Block: gb}, true)
@@ -326,7 +326,7 @@ func TestTXMgrContainsPostOrderWrites(t *testing.T) {
 		common.HeaderType_CONFIG: fakeTxProcessor,
 	}
 
-	v := NewCommitBatchPreparer(mockSimulatorProvider, testDB, customTxProcessors, testHashFunc)
+	v := NewCommitBatchPreparer(mockSimulatorProvider, testDB, customTxProcessors, testHashFunc, nil, nil)
 	blocks := testutil.ConstructTestBlocks(t, 2)
 
 	// block with config tx that produces post order writes
@@ -365,7 +365,7 @@ func TestTxStatsInfo(t *testing.T) {
 	defer testDBEnv.Cleanup()
 	testDB := testDBEnv.GetDBHandle("emptydb")
 
-	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc)
+	v := NewCommitBatchPreparer(nil, testDB, nil, testHashFunc, nil, nil)
 
 	// create a block with 4 endorser transactions
 	tx1SimulationResults, _ := testutilGenerateTxSimulationResultsAsBytes(t,
@@ -560,6 +560,89 @@ type postOrderSimulatorProvider interface {
 	PostOrderSimulatorProvider
 }
 
+//go:generate counterfeiter -o mock/purge_checker.go --fake-name PurgeChecker . purgeChecker
+type purgeChecker interface {
+	PurgeChecker
+}
+
+func TestValidatePvtdataTrimmedByPurge(t *testing.T) {
+	kvWrite := func(key string) *kvrwset.KVWrite {
+		return &kvrwset.KVWrite{Key: key, Value: []byte("value-" + key)}
+	}
+	kvWriteHash := func(key string) *kvrwset.KVWriteHash {
+		return &kvrwset.KVWriteHash{KeyHash: lutils.ComputeStringHash(key), ValueHash: lutils.ComputeHash([]byte("value-" + key))}
+	}
+	pvtRwSet := func(keys ...string) []byte {
+		kvRWSet := &kvrwset.KVRWSet{}
+		for _, key := range keys {
+			kvRWSet.Writes = append(kvRWSet.Writes, kvWrite(key))
+		}
+		return protoutil.MarshalOrPanic(kvRWSet)
+	}
+
+	tx := &transaction{
+		indexInBlock: 3,
+		rwset: &rwsetutil.TxRwSet{
+			NsRwSets: []*rwsetutil.NsRwSet{{
+				NameSpace: "ns1",
+				KvRwSet:   &kvrwset.KVRWSet{},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{{
+					CollectionName: "coll1",
+					HashedRwSet: &kvrwset.HashedRWSet{
+						HashedWrites: []*kvrwset.KVWriteHash{kvWriteHash("key1"), kvWriteHash("key2")},
+					},
+					PvtRwSetHash: lutils.ComputeHash(pvtRwSet("key1", "key2")),
+				}},
+			}},
+		},
+	}
+	// the private data lacks key2
+	pvtdata := &ledger.TxPvtData{
+		SeqInBlock: 3,
+		WriteSet: &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{{
+				Namespace: "ns1",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{{
+					CollectionName: "coll1",
+					Rwset:          pvtRwSet("key1"),
+				}},
+			}},
+		},
+	}
+
+	t.Run("key is not purged", func(t *testing.T) {
+		fakePurgeChecker := &mock.PurgeChecker{}
+		fakePurgeChecker.IsPurgedReturns(false, nil)
+		err := validatePvtdata(tx, pvtdata, 7, fakePurgeChecker)
+		require.EqualError(t, err, fmt.Sprintf("hash of pvt data for collection [ns1:coll1] does not match with the corresponding hash in the public data. public hash = [%#v], pvt data hash = [%#v]",
+			lutils.ComputeHash(pvtRwSet("key1", "key2")), lutils.ComputeHash(pvtRwSet("key1"))))
+	})
+
+	t.Run("no purge checker", func(t *testing.T) {
+		require.Error(t, validatePvtdata(tx, pvtdata, 7, nil))
+	})
+
+	t.Run("key is purged", func(t *testing.T) {
+		fakePurgeChecker := &mock.PurgeChecker{}
+		fakePurgeChecker.IsPurgedReturns(true, nil)
+		require.NoError(t, validatePvtdata(tx, pvtdata, 7, fakePurgeChecker))
+		require.Equal(t, 1, fakePurgeChecker.IsPurgedCallCount())
+		ns, coll, keyHash, blkNum, txNum := fakePurgeChecker.IsPurgedArgsForCall(0)
+		require.Equal(t, "ns1", ns)
+		require.Equal(t, "coll1", coll)
+		require.Equal(t, lutils.ComputeStringHash("key2"), keyHash)
+		require.Equal(t, uint64(7), blkNum)
+		require.Equal(t, uint64(3), txNum)
+	})
+
+	t.Run("purge checker fails", func(t *testing.T) {
+		fakePurgeChecker := &mock.PurgeChecker{}
+		fakePurgeChecker.IsPurgedReturns(false, fmt.Errorf("leveldb error"))
+		err := validatePvtdata(tx, pvtdata, 7, fakePurgeChecker)
+		require.EqualError(t, err, fmt.Sprintf("error while checking whether the key [%x] of collection [ns1:coll1] is purged: leveldb error", lutils.ComputeStringHash("key2")))
+	})
+}
+
 // Test for txType != common.HeaderType_ENDORSER_TRANSACTION
 func Test_preprocessProtoBlock_processNonEndorserTx(t *testing.T) {
 	// Register customtx processor
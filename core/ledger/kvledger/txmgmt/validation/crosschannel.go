@@ -0,0 +1,253 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/pkg/errors"
+)
+
+// prepareCrossChannelWriteSet returns the write-set to be applied for a transaction that is valid
+// as per the MVCC validation. For a transaction that writes the record of a cross-channel transaction,
+// this is not the write-set of the transaction:
+//  - the PREPARED record stages the writes, either of the transaction itself on the coordinator channel or
+//    the ones embedded in the record on a participant channel, and locks the keys they modify. On the
+//    coordinator channel, the deadline of the transaction is set from the number of the block
+//  - the COMMITTED record applies the staged writes and releases the locks, and is only valid on the
+//    coordinator channel up to the deadline of the transaction
+//  - the ABORTED record discards the staged writes and releases the locks
+// A validation code other than VALID is returned if the transaction cannot be applied.
+func (v *validator) prepareCrossChannelWriteSet(
+	tx *transaction,
+	blockNum uint64,
+	doMVCCValidation bool,
+	updates *publicAndHashUpdates,
+) (*rwsetutil.TxRwSet, peer.TxValidationCode, error) {
+	var xccRWSet *rwsetutil.NsRwSet
+	for _, nsRWSet := range tx.rwset.NsRwSets {
+		if nsRWSet.NameSpace == crosschannel.Namespace {
+			xccRWSet = nsRWSet
+		}
+	}
+	if xccRWSet == nil || (len(xccRWSet.KvRwSet.Writes) == 0 && len(xccRWSet.KvRwSet.MetadataWrites) == 0 && len(xccRWSet.CollHashedRwSets) == 0) {
+		return tx.rwset, peer.TxValidationCode_VALID, nil
+	}
+
+	if len(xccRWSet.KvRwSet.Writes) != 1 || len(xccRWSet.KvRwSet.MetadataWrites) != 0 || len(xccRWSet.CollHashedRwSets) != 0 {
+		logger.Warningf("Transaction [%s] must write a single cross-channel transaction record", tx.id)
+		return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+	}
+	recordWrite := xccRWSet.KvRwSet.Writes[0]
+	txID, ok := crosschannel.TxIDFromKey(recordWrite.Key)
+	if !ok || recordWrite.IsDelete {
+		logger.Warningf("Transaction [%s] writes the invalid cross-channel key [%s]", tx.id, recordWrite.Key)
+		return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+	}
+	record, err := crosschannel.UnmarshalTransaction(recordWrite.Value)
+	if err != nil || record.Id != txID {
+		logger.Warningf("Transaction [%s] writes an invalid record of cross-channel transaction [%s]", tx.id, txID)
+		return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+	}
+	existing, err := v.retrieveCrossChannelRecord(txID, updates)
+	if err != nil {
+		return nil, peer.TxValidationCode(-1), err
+	}
+
+	writesOutsideXCC := crosschannel.HasWrites(withoutNamespace(tx.rwset, crosschannel.Namespace))
+	switch record.State {
+	case crosschannel.State_PREPARED:
+		if existing != nil {
+			logger.Warningf("Cross-channel transaction [%s] is already %s", txID, existing.State)
+			return nil, peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+		}
+		staged := withoutNamespace(tx.rwset, crosschannel.Namespace)
+		if len(record.Rwset) == 0 {
+			// the transaction is prepared on the coordinator channel, the deadline is derived from
+			// the committed block height so that it is the same on every peer of the channel
+			record.Deadline = blockNum + record.TimeoutBlocks
+			recordBytes, err := proto.Marshal(record)
+			if err != nil {
+				return nil, peer.TxValidationCode(-1), errors.Wrapf(err, "error marshaling the record of cross-channel transaction [%s]", txID)
+			}
+			recordWrite = &kvrwset.KVWrite{Key: recordWrite.Key, Value: recordBytes}
+		} else {
+			// the writes are the ones simulated on this channel when endorsing the transaction
+			// on the coordinator channel, their reads must be still valid
+			if writesOutsideXCC {
+				return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+			}
+			staged = &rwsetutil.TxRwSet{}
+			if err := staged.FromProtoBytes(record.Rwset); err != nil {
+				logger.Warningf("Cross-channel transaction [%s] embeds an invalid read-write set: %s", txID, err)
+				return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+			}
+			if doMVCCValidation {
+				if code, err := v.validateTx(staged, updates); code != peer.TxValidationCode_VALID || err != nil {
+					return nil, code, err
+				}
+			}
+		}
+		if err := crosschannel.CheckStageable(staged); err != nil {
+			logger.Warningf("Writes of cross-channel transaction [%s] cannot be staged: %s", txID, err)
+			return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+		}
+		return v.stageWrites(recordWrite, txID, staged)
+
+	case crosschannel.State_COMMITTED, crosschannel.State_ABORTED:
+		if existing == nil || existing.State != crosschannel.State_PREPARED || writesOutsideXCC {
+			logger.Warningf("Transaction [%s] cannot move cross-channel transaction [%s] to %s", tx.id, txID, record.State)
+			return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+		}
+		if record.State == crosschannel.State_COMMITTED && len(existing.Rwset) == 0 && blockNum > existing.Deadline {
+			logger.Warningf("Transaction [%s] cannot commit cross-channel transaction [%s] in block [%d], after its deadline [%d]", tx.id, txID, blockNum, existing.Deadline)
+			return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+		}
+		return v.releaseWrites(recordWrite, txID, record.State == crosschannel.State_COMMITTED, updates)
+
+	default:
+		logger.Warningf("Transaction [%s] writes cross-channel transaction [%s] in state %s", tx.id, txID, record.State)
+		return nil, peer.TxValidationCode_ILLEGAL_WRITESET, nil
+	}
+}
+
+// stageWrites returns the write-set that records the writes to be staged and locks the keys they modify
+func (v *validator) stageWrites(
+	recordWrite *kvrwset.KVWrite,
+	txID string,
+	staged *rwsetutil.TxRwSet,
+) (*rwsetutil.TxRwSet, peer.TxValidationCode, error) {
+	writes := []*kvrwset.KVWrite{recordWrite}
+	stagedWrites := &rwsetutil.TxRwSet{}
+	for _, nsRWSet := range staged.NsRwSets {
+		if len(nsRWSet.KvRwSet.Writes) == 0 {
+			continue
+		}
+		// the MVCC validation of the staged writes ensures that none of their keys is already locked
+		for _, w := range nsRWSet.KvRwSet.Writes {
+			writes = append(writes, &kvrwset.KVWrite{Key: crosschannel.LockKey(nsRWSet.NameSpace, w.Key), Value: []byte(txID)})
+		}
+		stagedWrites.NsRwSets = append(stagedWrites.NsRwSets, &rwsetutil.NsRwSet{
+			NameSpace: nsRWSet.NameSpace,
+			KvRwSet:   &kvrwset.KVRWSet{Writes: nsRWSet.KvRwSet.Writes},
+		})
+	}
+
+	stagedBytes, err := stagedWrites.ToProtoBytes()
+	if err != nil {
+		return nil, peer.TxValidationCode(-1), errors.WithMessagef(err, "error marshaling the staged writes of cross-channel transaction [%s]", txID)
+	}
+	writes = append(writes, &kvrwset.KVWrite{Key: crosschannel.StagedKey(txID), Value: stagedBytes})
+	return &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{{
+			NameSpace: crosschannel.Namespace,
+			KvRwSet:   &kvrwset.KVRWSet{Writes: writes},
+		}},
+	}, peer.TxValidationCode_VALID, nil
+}
+
+// releaseWrites returns the write-set that releases the locks and discards the staged writes,
+// after applying them if the cross-channel transaction is committed
+func (v *validator) releaseWrites(
+	recordWrite *kvrwset.KVWrite,
+	txID string,
+	apply bool,
+	updates *publicAndHashUpdates,
+) (*rwsetutil.TxRwSet, peer.TxValidationCode, error) {
+	stagedKey := crosschannel.StagedKey(txID)
+	vv, err := retrieveLatestState(crosschannel.Namespace, "", stagedKey, updates, v.db)
+	if err != nil {
+		return nil, peer.TxValidationCode(-1), err
+	}
+	staged := &rwsetutil.TxRwSet{}
+	if vv != nil && vv.Value != nil {
+		if err := staged.FromProtoBytes(vv.Value); err != nil {
+			return nil, peer.TxValidationCode(-1), errors.WithMessagef(err, "error unmarshaling the staged writes of cross-channel transaction [%s]", txID)
+		}
+	}
+
+	writes := []*kvrwset.KVWrite{recordWrite, {Key: stagedKey, IsDelete: true}}
+	for _, nsRWSet := range staged.NsRwSets {
+		for _, w := range nsRWSet.KvRwSet.Writes {
+			writes = append(writes, &kvrwset.KVWrite{Key: crosschannel.LockKey(nsRWSet.NameSpace, w.Key), IsDelete: true})
+		}
+	}
+	writeSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{{
+			NameSpace: crosschannel.Namespace,
+			KvRwSet:   &kvrwset.KVRWSet{Writes: writes},
+		}},
+	}
+	if apply {
+		writeSet.NsRwSets = append(writeSet.NsRwSets, staged.NsRwSets...)
+	}
+	return writeSet, peer.TxValidationCode_VALID, nil
+}
+
+// validateUnlocked checks that the transaction neither reads nor writes the keys locked by a prepared
+// cross-channel transaction. The locked keys can only be modified by committing the cross-channel
+// transaction and their values are not meaningful until it is either committed or aborted.
+func (v *validator) validateUnlocked(txRWSet *rwsetutil.TxRwSet, updates *publicAndHashUpdates) (peer.TxValidationCode, error) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		if ns == crosschannel.Namespace {
+			continue
+		}
+		var keys []string
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			keys = append(keys, kvRead.Key)
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			for _, kvRead := range rqi.GetRawReads().GetKvReads() {
+				keys = append(keys, kvRead.Key)
+			}
+		}
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			keys = append(keys, kvWrite.Key)
+		}
+		for _, kvMetadataWrite := range nsRWSet.KvRwSet.MetadataWrites {
+			keys = append(keys, kvMetadataWrite.Key)
+		}
+		for _, key := range keys {
+			lock, err := retrieveLatestState(crosschannel.Namespace, "", crosschannel.LockKey(ns, key), updates, v.db)
+			if err != nil {
+				return peer.TxValidationCode(-1), err
+			}
+			if lock != nil && lock.Value != nil {
+				logger.Warningf("Key [%s:%s] is locked by cross-channel transaction [%s]", ns, key, lock.Value)
+				return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			}
+		}
+	}
+	return peer.TxValidationCode_VALID, nil
+}
+
+// crossChannelTransactions returns true if the channel supports the cross-channel transactions
+func (v *validator) crossChannelTransactions() bool {
+	return v.crossChannelChecker != nil && v.crossChannelChecker.CrossChannelTransactions()
+}
+
+func (v *validator) retrieveCrossChannelRecord(txID string, updates *publicAndHashUpdates) (*crosschannel.Transaction, error) {
+	vv, err := retrieveLatestState(crosschannel.Namespace, "", crosschannel.TxKey(txID), updates, v.db)
+	if err != nil || vv == nil || vv.Value == nil {
+		return nil, err
+	}
+	return crosschannel.UnmarshalTransaction(vv.Value)
+}
+
+func withoutNamespace(txRWSet *rwsetutil.TxRwSet, ns string) *rwsetutil.TxRwSet {
+	filtered := &rwsetutil.TxRwSet{}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != ns {
+			filtered.NsRwSets = append(filtered.NsRwSets, nsRWSet)
+		}
+	}
+	return filtered
+}
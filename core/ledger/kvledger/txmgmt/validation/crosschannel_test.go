@@ -0,0 +1,275 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossChannelValidation(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1)))
+	testValidator := &validator{db: db, hashFunc: testHashFunc, crossChannelChecker: crossChannelSupported(true)}
+
+	commitBlock := func(blkNum uint64, builders ...*rwsetutil.RWSetBuilder) []peer.TxValidationCode {
+		var txs []*transaction
+		for i, rwset := range getTestPubSimulationRWSet(t, builders...) {
+			txs = append(txs, &transaction{id: fmt.Sprintf("txid-%d-%d", blkNum, i), indexInBlock: i, rwset: rwset})
+		}
+		blk := &block{num: blkNum, txs: txs}
+		updates, err := testValidator.validateAndPrepareBatch(blk, true)
+		require.NoError(t, err)
+		require.NoError(t, db.ApplyPrivacyAwareUpdates(&privacyenabledstate.UpdateBatch{
+			PubUpdates:  updates.publicUpdates,
+			HashUpdates: updates.hashUpdates,
+			PvtUpdates:  privacyenabledstate.NewPvtUpdateBatch(),
+		}, version.NewHeight(blkNum, uint64(len(txs)-1))))
+		var codes []peer.TxValidationCode
+		for _, tx := range blk.txs {
+			codes = append(codes, tx.validationCode)
+		}
+		return codes
+	}
+	getState := func(ns, key string) []byte {
+		vv, err := db.GetState(ns, key)
+		require.NoError(t, err)
+		if vv == nil {
+			return nil
+		}
+		return vv.Value
+	}
+	recordWrite := func(b *rwsetutil.RWSetBuilder, record *crosschannel.Transaction) *rwsetutil.RWSetBuilder {
+		b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey(record.Id), protoutil.MarshalOrPanic(record))
+		return b
+	}
+
+	// the coordinator stages its own writes and locks the keys, a transaction
+	// that stages a write to a locked key in the same block is invalid
+	prepare := rwsetutil.NewRWSetBuilder()
+	prepare.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	prepare.AddToWriteSet("ns1", "key1", []byte("value1_xcc"))
+	recordWrite(prepare, &crosschannel.Transaction{Id: "xtx1", Coordinator: "ch1", State: crosschannel.State_PREPARED, TimeoutBlocks: 2})
+
+	participantRWSet := rwsetutil.NewRWSetBuilder()
+	participantRWSet.AddToWriteSet("ns1", "key1", []byte("value1_other"))
+	participantRWSetBytes, err := participantRWSet.GetTxReadWriteSet().ToProtoBytes()
+	require.NoError(t, err)
+	conflictingPrepare := recordWrite(rwsetutil.NewRWSetBuilder(),
+		&crosschannel.Transaction{Id: "xtx2", Coordinator: "ch2", State: crosschannel.State_PREPARED, Rwset: participantRWSetBytes})
+
+	require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT},
+		commitBlock(2, prepare, conflictingPrepare))
+	require.Equal(t, []byte("value1"), getState("ns1", "key1"))
+	require.Equal(t, []byte("xtx1"), getState(crosschannel.Namespace, crosschannel.LockKey("ns1", "key1")))
+	require.NotNil(t, getState(crosschannel.Namespace, crosschannel.StagedKey("xtx1")))
+	require.Nil(t, getState(crosschannel.Namespace, crosschannel.TxKey("xtx2")))
+	record, err := crosschannel.UnmarshalTransaction(getState(crosschannel.Namespace, crosschannel.TxKey("xtx1")))
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), record.Deadline)
+
+	// a participant stages the writes embedded in its record only if the reads are still valid
+	staleRWSet := rwsetutil.NewRWSetBuilder()
+	staleRWSet.AddToReadSet("ns1", "key2", version.NewHeight(1, 0))
+	staleRWSet.AddToWriteSet("ns1", "key2", []byte("value2_xcc"))
+	staleRWSetBytes, err := staleRWSet.GetTxReadWriteSet().ToProtoBytes()
+	require.NoError(t, err)
+	stalePrepare := recordWrite(rwsetutil.NewRWSetBuilder(),
+		&crosschannel.Transaction{Id: "xtx3", Coordinator: "ch2", State: crosschannel.State_PREPARED, Rwset: staleRWSetBytes})
+
+	validRWSet := rwsetutil.NewRWSetBuilder()
+	validRWSet.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	validRWSet.AddToWriteSet("ns1", "key2", []byte("value2_xcc"))
+	validRWSetBytes, err := validRWSet.GetTxReadWriteSet().ToProtoBytes()
+	require.NoError(t, err)
+	validPrepare := recordWrite(rwsetutil.NewRWSetBuilder(),
+		&crosschannel.Transaction{Id: "xtx4", Coordinator: "ch2", State: crosschannel.State_PREPARED, Rwset: validRWSetBytes})
+
+	// the transactions that read or write a locked key are invalid, including the keys locked
+	// earlier in the same block
+	readLocked := rwsetutil.NewRWSetBuilder()
+	readLocked.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	writeLocked := rwsetutil.NewRWSetBuilder()
+	writeLocked.AddToWriteSet("ns1", "key1", []byte("value1_blind"))
+	writeLockedInBlock := rwsetutil.NewRWSetBuilder()
+	writeLockedInBlock.AddToWriteSet("ns1", "key2", []byte("value2_blind"))
+
+	require.Equal(t, []peer.TxValidationCode{
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+		peer.TxValidationCode_VALID,
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+	}, commitBlock(3, stalePrepare, validPrepare, readLocked, writeLocked, writeLockedInBlock))
+	require.Equal(t, []byte("xtx4"), getState(crosschannel.Namespace, crosschannel.LockKey("ns1", "key2")))
+	require.Equal(t, []byte("value1"), getState("ns1", "key1"))
+	require.Equal(t, []byte("value2"), getState("ns1", "key2"))
+
+	// the commit applies the staged writes at its own height and releases the locks,
+	// the abort discards them
+	commit := recordWrite(rwsetutil.NewRWSetBuilder(), &crosschannel.Transaction{Id: "xtx1", Coordinator: "ch1", State: crosschannel.State_COMMITTED})
+	abort := recordWrite(rwsetutil.NewRWSetBuilder(), &crosschannel.Transaction{Id: "xtx4", Coordinator: "ch2", State: crosschannel.State_ABORTED})
+	require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_VALID, peer.TxValidationCode_VALID},
+		commitBlock(4, commit, abort))
+
+	vv, err := db.GetState("ns1", "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1_xcc"), vv.Value)
+	require.Equal(t, version.NewHeight(4, 0), vv.Version)
+	require.Equal(t, []byte("value2"), getState("ns1", "key2"))
+	for _, key := range []string{
+		crosschannel.LockKey("ns1", "key1"),
+		crosschannel.LockKey("ns1", "key2"),
+		crosschannel.StagedKey("xtx1"),
+		crosschannel.StagedKey("xtx4"),
+	} {
+		require.Nil(t, getState(crosschannel.Namespace, key))
+	}
+	record, err = crosschannel.UnmarshalTransaction(getState(crosschannel.Namespace, crosschannel.TxKey("xtx1")))
+	require.NoError(t, err)
+	require.Equal(t, crosschannel.State_COMMITTED, record.State)
+
+	// the coordinator cannot commit the transaction after its deadline, it can only abort it
+	latePrepare := rwsetutil.NewRWSetBuilder()
+	latePrepare.AddToWriteSet("ns1", "key2", []byte("value2_late"))
+	recordWrite(latePrepare, &crosschannel.Transaction{Id: "xtx5", Coordinator: "ch1", State: crosschannel.State_PREPARED, TimeoutBlocks: 1})
+	require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_VALID}, commitBlock(5, latePrepare))
+
+	lateCommit := recordWrite(rwsetutil.NewRWSetBuilder(), &crosschannel.Transaction{Id: "xtx5", Coordinator: "ch1", State: crosschannel.State_COMMITTED})
+	require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_ILLEGAL_WRITESET}, commitBlock(7, lateCommit))
+	lateAbort := recordWrite(rwsetutil.NewRWSetBuilder(), &crosschannel.Transaction{Id: "xtx5", Coordinator: "ch1", State: crosschannel.State_ABORTED})
+	require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_VALID}, commitBlock(8, lateAbort))
+	require.Equal(t, []byte("value2"), getState("ns1", "key2"))
+	require.Nil(t, getState(crosschannel.Namespace, crosschannel.LockKey("ns1", "key2")))
+}
+
+func TestCrossChannelValidationNotSupported(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	for _, checker := range []CrossChannelChecker{nil, crossChannelSupported(false)} {
+		testValidator := &validator{db: db, hashFunc: testHashFunc, crossChannelChecker: checker}
+		b := rwsetutil.NewRWSetBuilder()
+		b.AddToWriteSet("ns1", "key1", []byte("value1"))
+		b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"),
+			protoutil.MarshalOrPanic(&crosschannel.Transaction{Id: "xtx1", State: crosschannel.State_PREPARED}))
+		tx := &transaction{id: "txid", rwset: getTestPubSimulationRWSet(t, b)[0]}
+		updates, err := testValidator.validateAndPrepareBatch(&block{num: 1, txs: []*transaction{tx}}, true)
+		require.NoError(t, err)
+		require.Equal(t, peer.TxValidationCode_VALID, tx.validationCode)
+
+		// the writes are not staged
+		require.Equal(t, []byte("value1"), updates.publicUpdates.Get("ns1", "key1").Value)
+		require.Nil(t, updates.publicUpdates.Get(crosschannel.Namespace, crosschannel.LockKey("ns1", "key1")))
+		require.Nil(t, updates.publicUpdates.Get(crosschannel.Namespace, crosschannel.StagedKey("xtx1")))
+	}
+}
+
+func TestCrossChannelValidationIllegalWriteSets(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+	testValidator := &validator{db: db, hashFunc: testHashFunc, crossChannelChecker: crossChannelSupported(true)}
+
+	record := func(id string, state crosschannel.State, rwset []byte) []byte {
+		return protoutil.MarshalOrPanic(&crosschannel.Transaction{Id: id, State: state, Rwset: rwset})
+	}
+
+	tests := []struct {
+		name  string
+		setup func(b *rwsetutil.RWSetBuilder)
+	}{
+		{
+			name: "several records",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_PREPARED, nil))
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx2"), record("xtx2", crosschannel.State_PREPARED, nil))
+			},
+		},
+		{
+			name: "lock written directly",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.LockKey("ns1", "key1"), []byte("xtx1"))
+			},
+		},
+		{
+			name: "mismatching ID",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx2", crosschannel.State_PREPARED, nil))
+			},
+		},
+		{
+			name: "commit without prepare",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_COMMITTED, nil))
+			},
+		},
+		{
+			name: "participant record with writes",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_PREPARED, []byte("rwset")))
+				b.AddToWriteSet("ns1", "key1", []byte("value1"))
+			},
+		},
+		{
+			name: "lifecycle namespace",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_PREPARED, nil))
+				b.AddToWriteSet("_lifecycle", "key1", []byte("value1"))
+			},
+		},
+		{
+			name: "legacy lifecycle namespace",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_PREPARED, nil))
+				b.AddToWriteSet("lscc", "key1", []byte("value1"))
+			},
+		},
+		{
+			name: "private data",
+			setup: func(b *rwsetutil.RWSetBuilder) {
+				b.AddToWriteSet(crosschannel.Namespace, crosschannel.TxKey("xtx1"), record("xtx1", crosschannel.State_PREPARED, nil))
+				b.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := rwsetutil.NewRWSetBuilder()
+			tt.setup(b)
+			tx := &transaction{id: "txid", rwset: getTestPubSimulationRWSet(t, b)[0]}
+			blk := &block{num: 1, txs: []*transaction{tx}}
+			_, err := testValidator.validateAndPrepareBatch(blk, true)
+			require.NoError(t, err)
+			require.Equal(t, peer.TxValidationCode_ILLEGAL_WRITESET, tx.validationCode)
+		})
+	}
+}
+
+type crossChannelSupported bool
+
+func (s crossChannelSupported) CrossChannelTransactions() bool {
+	return bool(s)
+}
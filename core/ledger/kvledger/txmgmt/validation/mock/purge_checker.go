@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"sync"
+)
+
+type PurgeChecker struct {
+	IsPurgedStub        func(string, string, []byte, uint64, uint64) (bool, error)
+	isPurgedMutex       sync.RWMutex
+	isPurgedArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+		arg4 uint64
+		arg5 uint64
+	}
+	isPurgedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isPurgedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PurgeChecker) IsPurged(arg1 string, arg2 string, arg3 []byte, arg4 uint64, arg5 uint64) (bool, error) {
+	var arg3Copy []byte
+	if arg3 != nil {
+		arg3Copy = make([]byte, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.isPurgedMutex.Lock()
+	ret, specificReturn := fake.isPurgedReturnsOnCall[len(fake.isPurgedArgsForCall)]
+	fake.isPurgedArgsForCall = append(fake.isPurgedArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []byte
+		arg4 uint64
+		arg5 uint64
+	}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.recordInvocation("IsPurged", []interface{}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.isPurgedMutex.Unlock()
+	if fake.IsPurgedStub != nil {
+		return fake.IsPurgedStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.isPurgedReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PurgeChecker) IsPurgedCallCount() int {
+	fake.isPurgedMutex.RLock()
+	defer fake.isPurgedMutex.RUnlock()
+	return len(fake.isPurgedArgsForCall)
+}
+
+func (fake *PurgeChecker) IsPurgedCalls(stub func(string, string, []byte, uint64, uint64) (bool, error)) {
+	fake.isPurgedMutex.Lock()
+	defer fake.isPurgedMutex.Unlock()
+	fake.IsPurgedStub = stub
+}
+
+func (fake *PurgeChecker) IsPurgedArgsForCall(i int) (string, string, []byte, uint64, uint64) {
+	fake.isPurgedMutex.RLock()
+	defer fake.isPurgedMutex.RUnlock()
+	argsForCall := fake.isPurgedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *PurgeChecker) IsPurgedReturns(result1 bool, result2 error) {
+	fake.isPurgedMutex.Lock()
+	defer fake.isPurgedMutex.Unlock()
+	fake.IsPurgedStub = nil
+	fake.isPurgedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PurgeChecker) IsPurgedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isPurgedMutex.Lock()
+	defer fake.isPurgedMutex.Unlock()
+	fake.IsPurgedStub = nil
+	if fake.isPurgedReturnsOnCall == nil {
+		fake.isPurgedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isPurgedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PurgeChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isPurgedMutex.RLock()
+	defer fake.isPurgedMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PurgeChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
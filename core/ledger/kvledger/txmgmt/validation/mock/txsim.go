@@ -283,6 +283,37 @@ type TxSimulator struct {
 		result1 ledgera.QueryResultsIterator
 		result2 error
 	}
+	GetStateReverseRangeScanIteratorStub        func(string, string, string) (ledger.ResultsIterator, error)
+	getStateReverseRangeScanIteratorMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getStateReverseRangeScanIteratorReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateReverseRangeScanIteratorReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	GetStateReverseRangeScanIteratorWithPaginationStub        func(string, string, string, int32) (ledgera.QueryResultsIterator, error)
+	getStateReverseRangeScanIteratorWithPaginationMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+	}
+	getStateReverseRangeScanIteratorWithPaginationReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getStateReverseRangeScanIteratorWithPaginationReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
 	GetTxSimulationResultsStub        func() (*ledgera.TxSimulationResults, error)
 	getTxSimulationResultsMutex       sync.RWMutex
 	getTxSimulationResultsArgsForCall []struct {
@@ -295,6 +326,19 @@ type TxSimulator struct {
 		result1 *ledgera.TxSimulationResults
 		result2 error
 	}
+	PurgePrivateDataStub        func(string, string, string) error
+	purgePrivateDataMutex       sync.RWMutex
+	purgePrivateDataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	purgePrivateDataReturns struct {
+		result1 error
+	}
+	purgePrivateDataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivateDataStub        func(string, string, string, []byte) error
 	setPrivateDataMutex       sync.RWMutex
 	setPrivateDataArgsForCall []struct {
@@ -1631,6 +1675,137 @@ func (fake *TxSimulator) GetStateRangeScanIteratorWithPaginationReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) GetStateReverseRangeScanIterator(arg1 string, arg2 string, arg3 string) (ledger.ResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorReturnsOnCall[len(fake.getStateReverseRangeScanIteratorArgsForCall)]
+	fake.getStateReverseRangeScanIteratorArgsForCall = append(fake.getStateReverseRangeScanIteratorArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateReverseRangeScanIterator", []interface{}{arg1, arg2, arg3})
+	fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorStub != nil {
+		return fake.GetStateReverseRangeScanIteratorStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateReverseRangeScanIteratorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorCallCount() int {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorArgsForCall)
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorCalls(stub func(string, string, string) (ledger.ResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = stub
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorArgsForCall(i int) (string, string, string) {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	fake.getStateReverseRangeScanIteratorReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	if fake.getStateReverseRangeScanIteratorReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateReverseRangeScanIteratorReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPagination(arg1 string, arg2 string, arg3 string, arg4 int32) (ledgera.QueryResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)]
+	fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall = append(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateReverseRangeScanIteratorWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorWithPaginationStub != nil {
+		return fake.GetStateReverseRangeScanIteratorWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateReverseRangeScanIteratorWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPaginationCallCount() int {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPaginationCalls(stub func(string, string, string, int32) (ledgera.QueryResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = stub
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPaginationArgsForCall(i int) (string, string, string, int32) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPaginationReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	fake.getStateReverseRangeScanIteratorWithPaginationReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetStateReverseRangeScanIteratorWithPaginationReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	if fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TxSimulator) GetTxSimulationResults() (*ledgera.TxSimulationResults, error) {
 	fake.getTxSimulationResultsMutex.Lock()
 	ret, specificReturn := fake.getTxSimulationResultsReturnsOnCall[len(fake.getTxSimulationResultsArgsForCall)]
@@ -1686,6 +1861,68 @@ func (fake *TxSimulator) GetTxSimulationResultsReturnsOnCall(i int, result1 *led
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) PurgePrivateData(arg1 string, arg2 string, arg3 string) error {
+	fake.purgePrivateDataMutex.Lock()
+	ret, specificReturn := fake.purgePrivateDataReturnsOnCall[len(fake.purgePrivateDataArgsForCall)]
+	fake.purgePrivateDataArgsForCall = append(fake.purgePrivateDataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("PurgePrivateData", []interface{}{arg1, arg2, arg3})
+	fake.purgePrivateDataMutex.Unlock()
+	if fake.PurgePrivateDataStub != nil {
+		return fake.PurgePrivateDataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.purgePrivateDataReturns
+	return fakeReturns.result1
+}
+
+func (fake *TxSimulator) PurgePrivateDataCallCount() int {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	return len(fake.purgePrivateDataArgsForCall)
+}
+
+func (fake *TxSimulator) PurgePrivateDataCalls(stub func(string, string, string) error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = stub
+}
+
+func (fake *TxSimulator) PurgePrivateDataArgsForCall(i int) (string, string, string) {
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
+	argsForCall := fake.purgePrivateDataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturns(result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	fake.purgePrivateDataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TxSimulator) PurgePrivateDataReturnsOnCall(i int, result1 error) {
+	fake.purgePrivateDataMutex.Lock()
+	defer fake.purgePrivateDataMutex.Unlock()
+	fake.PurgePrivateDataStub = nil
+	if fake.purgePrivateDataReturnsOnCall == nil {
+		fake.purgePrivateDataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgePrivateDataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TxSimulator) SetPrivateData(arg1 string, arg2 string, arg3 string, arg4 []byte) error {
 	var arg4Copy []byte
 	if arg4 != nil {
@@ -2112,8 +2349,14 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.getStateRangeScanIteratorMutex.RUnlock()
 	fake.getStateRangeScanIteratorWithPaginationMutex.RLock()
 	defer fake.getStateRangeScanIteratorWithPaginationMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
 	fake.getTxSimulationResultsMutex.RLock()
 	defer fake.getTxSimulationResultsMutex.RUnlock()
+	fake.purgePrivateDataMutex.RLock()
+	defer fake.purgePrivateDataMutex.RUnlock()
 	fake.setPrivateDataMutex.RLock()
 	defer fake.setPrivateDataMutex.RUnlock()
 	fake.setPrivateDataMetadataMutex.RLock()
@@ -15,6 +15,10 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 )
 
+// rangeQueryValidator validates the results of a range query captured during simulation against the
+// committed state. The results are always compared in ascending order of the keys; a range query that
+// iterated the keys in descending order (a reverse range scan) is captured during simulation as the
+// equivalent ascending range, with its results in ascending order, and is validated like any other range query.
 type rangeQueryValidator interface {
 	init(rqInfo *kvrwset.RangeQueryInfo, itr statedb.ResultsIterator) error
 	validate() (bool, error)
@@ -72,11 +72,12 @@ func (txops txOps) applyTxRwset(rwset *rwsetutil.TxRwSet) error {
 		for _, collHashRWset := range nsRWSet.CollHashedRwSets {
 			coll := collHashRWset.CollectionName
 			for _, hashedWrite := range collHashRWset.HashedRwSet.HashedWrites {
+				// a purge is applied to the state as a delete
 				txops.applyKVWrite(ns, coll,
 					&kvrwset.KVWrite{
 						Key:      string(hashedWrite.KeyHash),
 						Value:    hashedWrite.ValueHash,
-						IsDelete: hashedWrite.IsDelete,
+						IsDelete: hashedWrite.IsDelete || collHashRWset.IsPurge(hashedWrite),
 					},
 				)
 			}
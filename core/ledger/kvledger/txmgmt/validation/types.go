@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package validation
 
 import (
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
@@ -77,6 +78,13 @@ func (t *transaction) retrieveHash(ns string, coll string) []byte {
 	return nil
 }
 
+func (t *transaction) retrieveHashedRwSet(ns string, coll string) *kvrwset.HashedRWSet {
+	if t.rwset == nil {
+		return nil
+	}
+	return t.rwset.GetHashedRwSet(ns, coll)
+}
+
 // applyWriteSet adds (or deletes) the key/values present in the write set to the publicAndHashUpdates
 func (u *publicAndHashUpdates) applyWriteSet(
 	txRWSet *rwsetutil.TxRwSet,
@@ -18,8 +18,9 @@ import (
 // validator validates a tx against the latest committed state
 // and preceding valid transactions with in the same block
 type validator struct {
-	db       *privacyenabledstate.DB
-	hashFunc rwsetutil.HashFunc
+	db                  *privacyenabledstate.DB
+	hashFunc            rwsetutil.HashFunc
+	crossChannelChecker CrossChannelChecker
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -98,11 +99,18 @@ func (v *validator) validateAndPrepareBatch(blk *block, doMVCCValidation bool) (
 			return nil, err
 		}
 
+		writeSet := tx.rwset
+		if validationCode == peer.TxValidationCode_VALID && v.crossChannelTransactions() {
+			if writeSet, validationCode, err = v.prepareCrossChannelWriteSet(tx, blk.num, doMVCCValidation, updates); err != nil {
+				return nil, err
+			}
+		}
+
 		tx.validationCode = validationCode
 		if validationCode == peer.TxValidationCode_VALID {
 			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator. ContainsPostOrderWrites [%t]", blk.num, tx.indexInBlock, tx.id, tx.containsPostOrderWrites)
 			committingTxHeight := version.NewHeight(blk.num, uint64(tx.indexInBlock))
-			if err := updates.applyWriteSet(tx.rwset, committingTxHeight, v.db, tx.containsPostOrderWrites); err != nil {
+			if err := updates.applyWriteSet(writeSet, committingTxHeight, v.db, tx.containsPostOrderWrites); err != nil {
 				return nil, err
 			}
 		} else {
@@ -155,6 +163,9 @@ func (v *validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *publicAndHas
 			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
 		}
 	}
+	if v.crossChannelTransactions() {
+		return v.validateUnlocked(txRWSet, updates)
+	}
 	return peer.TxValidationCode_VALID, nil
 }
 
@@ -19,12 +19,14 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/metrics"
 )
 
 const (
 	GoLevelDB = "goleveldb"
 	CouchDB   = "CouchDB"
+	SQL       = "SQL"
 )
 
 // Initializer encapsulates dependencies for PeerLedgerProvider
@@ -32,12 +34,16 @@ type Initializer struct {
 	StateListeners                  []StateListener
 	DeployedChaincodeInfoProvider   DeployedChaincodeInfoProvider
 	MembershipInfoProvider          MembershipInfoProvider
+	ChannelCapabilitiesProvider     ChannelCapabilitiesProvider
 	ChaincodeLifecycleEventProvider ChaincodeLifecycleEventProvider
 	MetricsProvider                 metrics.Provider
 	HealthCheckRegistry             HealthCheckRegistry
 	Config                          *Config
 	CustomTxProcessors              map[common.HeaderType]CustomTxProcessor
 	HashProvider                    HashProvider
+	// Encryptor encrypts the block files and the values in the leveldb based stores of the ledger.
+	// The ledger is stored in clear if it is nil.
+	Encryptor *encryption.Encryptor
 }
 
 // Config is a structure used to configure a ledger provider.
@@ -52,16 +58,40 @@ type Config struct {
 	HistoryDBConfig *HistoryDBConfig
 	// SnapshotsConfig holds the configuration parameters for the snapshots.
 	SnapshotsConfig *SnapshotsConfig
+	// BlockArchiveConfig holds the configuration parameters for archiving the block files.
+	// The block files are not archived if it is nil.
+	BlockArchiveConfig *BlockArchiveConfig
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
 type StateDBConfig struct {
 	// StateDatabase is the database to use for storing last known state.  The
-	// two supported options are "goleveldb" and "CouchDB" (captured in the constants GoLevelDB and CouchDB respectively).
+	// supported options are "goleveldb", "CouchDB" and "SQL" (captured in the constants
+	// GoLevelDB, CouchDB and SQL respectively).
 	StateDatabase string
 	// CouchDB is the configuration for CouchDB.  It is used when StateDatabase
 	// is set to "CouchDB".
 	CouchDB *CouchDBConfig
+	// SQL is the configuration for the SQL database.  It is used when StateDatabase
+	// is set to "SQL".
+	SQL *SQLConfig
+}
+
+// SQLConfig is a structure used to configure the connection to a PostgreSQL-compatible database.
+type SQLConfig struct {
+	// DriverName is the name of the database/sql driver used to connect to the database.
+	// The driver must be registered in the peer binary.
+	DriverName string
+	// DataSourceName is the driver-specific connection string of the database.
+	DataSourceName string
+	// MaxOpenConnections is the maximum number of open connections to the database.
+	// A value of 0 means that the number of connections is not limited.
+	MaxOpenConnections int
+	// RequestTimeout is the timeout used for the database operations.
+	RequestTimeout time.Duration
+	// InternalQueryLimit is the maximum number of records to fetch at once
+	// when scanning a range of keys or executing a query.
+	InternalQueryLimit int
 }
 
 // CouchDBConfig is a structure used to configure a CouchInstance.
@@ -118,6 +148,39 @@ type PrivateDataConfig struct {
 // HistoryDBConfig is a structure used to configure the transaction history database.
 type HistoryDBConfig struct {
 	Enabled bool
+	// Retention is the policy for pruning the history of the keys. The entire history is retained if it is nil.
+	Retention *HistoryRetentionConfig
+}
+
+// HistoryRetentionConfig is a structure used to configure the pruning of the transaction history database.
+// The policy of a namespace overrides the policy of its channel, which in turn overrides the default policy.
+type HistoryRetentionConfig struct {
+	// Default is the retention policy for the namespaces that are not covered by any of the channel policies.
+	Default HistoryRetentionPolicy
+	// Channels holds the retention policies of specific channels.
+	Channels []*ChannelHistoryRetentionConfig
+	// PruneInterval is the number of blocks committed to a channel between two pruning runs.
+	PruneInterval uint64
+}
+
+// ChannelHistoryRetentionConfig holds the history retention policy of a channel and of its namespaces.
+type ChannelHistoryRetentionConfig struct {
+	// Name is the name of the channel.
+	Name string
+	// Policy is the retention policy for the namespaces of the channel that have no policy of their own.
+	Policy HistoryRetentionPolicy
+	// Namespaces holds the retention policies of specific namespaces, keyed by the namespace.
+	Namespaces map[string]HistoryRetentionPolicy
+}
+
+// HistoryRetentionPolicy specifies the portion of the history of the keys that is retained.
+// The history entries written by the blocks below the larger of the two heights derived from
+// RetainBlocks and PruneBelowHeight are pruned. A zero value of both retains the entire history.
+type HistoryRetentionPolicy struct {
+	// RetainBlocks is the number of most recent blocks whose history entries are retained.
+	RetainBlocks uint64
+	// PruneBelowHeight is the block height below which the history entries are pruned.
+	PruneBelowHeight uint64
 }
 
 // SnapshotsConfig is a structure used to configure snapshot function
@@ -126,12 +189,30 @@ type SnapshotsConfig struct {
 	RootDir string
 }
 
+// BlockArchiveConfig is a structure used to configure the archiving of the block files to cold storage.
+// The archived block files are transparently retrieved from the archive when their blocks are requested.
+type BlockArchiveConfig struct {
+	// Directory is the directory the block files are archived to. It is used if Endpoint is empty.
+	Directory string
+	// Endpoint is the URL of an S3-compatible object store (including the bucket) the block files are archived to.
+	Endpoint string
+	// KeepRecentBlocks is the number of most recent blocks whose block files are never archived.
+	KeepRecentBlocks uint64
+	// MinAge is the minimum time since a block file was last written before it is archived.
+	MinAge time.Duration
+	// MaxCachedBlockFiles is the maximum number of archived block files kept locally after being retrieved.
+	MaxCachedBlockFiles int
+}
+
 // PeerLedgerProvider provides handle to ledger instances
 type PeerLedgerProvider interface {
 	// Create creates a new ledger with the given genesis block.
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from a snapshot and returns the ledger and channel id.
+	// The snapshot is expected to be generated by a peer and to be present in the snapshotDir
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, string, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -192,6 +273,20 @@ type PeerLedger interface {
 	//     missing info is recorded in the ledger (or)
 	// (3) the block is committed and does not contain any pvtData.
 	DoesPvtDataInfoExist(blockNum uint64) (bool, error)
+	// SubmitSnapshotRequest submits a snapshot request for the specified height.
+	// The request will be stored in the ledger until the ledger's block height is equal to
+	// the specified height and the snapshot generation is completed.
+	// When height is 0, it will generate a snapshot at the current block height.
+	// It returns an error if the specified height is smaller than the ledger's block height.
+	SubmitSnapshotRequest(height uint64) error
+	// CancelSnapshotRequest cancels the previously submitted request.
+	// It returns an error if such a request does not exist or is under processing.
+	CancelSnapshotRequest(height uint64) error
+	// PendingSnapshotRequests returns a list of heights for the pending (or under processing) snapshot requests.
+	PendingSnapshotRequests() ([]uint64, error)
+	// SnapshotRequestFailures returns the failures of the snapshot generation for the pending requests, by height.
+	// A request whose snapshot generation failed stays pending until it is cancelled.
+	SnapshotRequestFailures() (map[uint64]string, error)
 }
 
 // SimpleQueryExecutor encapsulates basic functions
@@ -229,6 +324,17 @@ type QueryExecutor interface {
 	// The page size parameter limits the number of returned results.
 	// The returned ResultsIterator contains results of type *KV which is defined in fabric-protos/ledger/queryresult.
 	GetStateRangeScanIteratorWithPagination(namespace string, startKey, endKey string, pageSize int32) (QueryResultsIterator, error)
+	// GetStateReverseRangeScanIterator returns an iterator that contains all the key-values between given key ranges
+	// in descending order of the keys. The iteration starts at the startKey and moves towards the endKey.
+	// startKey is included in the results and endKey is excluded. An empty startKey refers to the last available key
+	// and an empty endKey refers to the first available key.
+	// The returned ResultsIterator contains results of type *KV which is defined in fabric-protos/ledger/queryresult.
+	GetStateReverseRangeScanIterator(namespace string, startKey, endKey string) (commonledger.ResultsIterator, error)
+	// GetStateReverseRangeScanIteratorWithPagination returns an iterator that contains all the key-values between
+	// given key ranges in descending order of the keys, as GetStateReverseRangeScanIterator does.
+	// The page size parameter limits the number of returned results.
+	// The returned ResultsIterator contains results of type *KV which is defined in fabric-protos/ledger/queryresult.
+	GetStateReverseRangeScanIteratorWithPagination(namespace string, startKey, endKey string, pageSize int32) (QueryResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type specific to the underlying data store.
 	// Only used for state databases that support query
 	// For a chaincode, the namespace corresponds to the chaincodeId
@@ -267,9 +373,19 @@ type QueryExecutor interface {
 type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in fabric-protos/ledger/queryresult.
+	// The returned ResultsIterator may also implement the interface PrunedHistoryIndicator for reporting
+	// a history that is incomplete because of pruning.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
 }
 
+// PrunedHistoryIndicator is implemented by the results iterator of a history query for reporting
+// whether the history of the key is incomplete because of the history retention policy
+type PrunedHistoryIndicator interface {
+	// HistoryPrunedBelow returns true along with the block number below which the history
+	// of the key may have been pruned. It returns false if the entire history is available.
+	HistoryPrunedBelow() (uint64, bool)
+}
+
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
 // Set* methods are for supporting KV-based data model. ExecuteUpdate method is for supporting a rich datamodel and query support
 type TxSimulator interface {
@@ -292,6 +408,9 @@ type TxSimulator interface {
 	SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error
 	// DeletePrivateData deletes the given tuple <namespace, collection, key> from private data
 	DeletePrivateData(namespace, collection, key string) error
+	// PurgePrivateData deletes the given tuple <namespace, collection, key> from private data and, in addition,
+	// causes all the historical versions of the key to be purged from the private data store on commit
+	PurgePrivateData(namespace, collection, key string) error
 	// SetPrivateDataMetadata sets the metadata associated with an existing key-tuple <namespace, collection, key>
 	SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error
 	// DeletePrivateDataMetadata deletes the metadata associated with an existing key-tuple <namespace, collection, key>
@@ -649,6 +768,14 @@ type MembershipInfoProvider interface {
 	AmMemberOf(channelName string, collectionPolicyConfig *peer.CollectionPolicyConfig) (bool, error)
 }
 
+// ChannelCapabilitiesProvider is a dependency that is used by ledger to determine whether a channel
+// supports the features that change how the transactions are committed
+type ChannelCapabilitiesProvider interface {
+	// CrossChannelTransactions returns true if the channel supports the transactions that
+	// atomically modify the state of several channels
+	CrossChannelTransactions(channelName string) bool
+}
+
 type HealthCheckRegistry interface {
 	RegisterChecker(string, healthz.HealthChecker) error
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -47,12 +48,14 @@ type Initializer struct {
 	StateListeners                  []ledger.StateListener
 	DeployedChaincodeInfoProvider   ledger.DeployedChaincodeInfoProvider
 	MembershipInfoProvider          ledger.MembershipInfoProvider
+	ChannelCapabilitiesProvider     ledger.ChannelCapabilitiesProvider
 	ChaincodeLifecycleEventProvider ledger.ChaincodeLifecycleEventProvider
 	MetricsProvider                 metrics.Provider
 	HealthCheckRegistry             ledger.HealthCheckRegistry
 	Config                          *ledger.Config
 	HashProvider                    ledger.HashProvider
 	EbMetadataProvider              MetadataProvider
+	Encryptor                       *encryption.Encryptor
 }
 
 // NewLedgerMgr creates a new LedgerMgr
@@ -67,12 +70,14 @@ func NewLedgerMgr(initializer *Initializer) *LedgerMgr {
 			StateListeners:                  finalStateListeners,
 			DeployedChaincodeInfoProvider:   initializer.DeployedChaincodeInfoProvider,
 			MembershipInfoProvider:          initializer.MembershipInfoProvider,
+			ChannelCapabilitiesProvider:     initializer.ChannelCapabilitiesProvider,
 			ChaincodeLifecycleEventProvider: initializer.ChaincodeLifecycleEventProvider,
 			MetricsProvider:                 initializer.MetricsProvider,
 			HealthCheckRegistry:             initializer.HealthCheckRegistry,
 			Config:                          initializer.Config,
 			CustomTxProcessors:              initializer.CustomTxProcessors,
 			HashProvider:                    initializer.HashProvider,
+			Encryptor:                       initializer.Encryptor,
 		},
 	)
 	if err != nil {
@@ -112,6 +117,25 @@ func (m *LedgerMgr) CreateLedger(id string, genesisBlock *common.Block) (ledger.
 	}, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot present in the snapshotDir
+// and returns the ledger along with the id of the ledger (channel)
+func (m *LedgerMgr) CreateLedgerFromSnapshot(snapshotDir string) (ledger.PeerLedger, string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	logger.Infof("Creating ledger from snapshot at dir [%s]", snapshotDir)
+	l, id, err := m.ledgerProvider.CreateFromSnapshot(snapshotDir)
+	if err != nil {
+		return nil, "", err
+	}
+	m.openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot", id)
+	return &closableLedger{
+		ledgerMgr:  m,
+		id:         id,
+		PeerLedger: l,
+	}, id, nil
+}
+
 // OpenLedger returns a ledger for the given id
 func (m *LedgerMgr) OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
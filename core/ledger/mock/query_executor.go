@@ -222,6 +222,37 @@ type QueryExecutor struct {
 		result1 ledger.QueryResultsIterator
 		result2 error
 	}
+	GetStateReverseRangeScanIteratorStub        func(string, string, string) (ledgera.ResultsIterator, error)
+	getStateReverseRangeScanIteratorMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getStateReverseRangeScanIteratorReturns struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}
+	getStateReverseRangeScanIteratorReturnsOnCall map[int]struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}
+	GetStateReverseRangeScanIteratorWithPaginationStub        func(string, string, string, int32) (ledger.QueryResultsIterator, error)
+	getStateReverseRangeScanIteratorWithPaginationMutex       sync.RWMutex
+	getStateReverseRangeScanIteratorWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+	}
+	getStateReverseRangeScanIteratorWithPaginationReturns struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
+	getStateReverseRangeScanIteratorWithPaginationReturnsOnCall map[int]struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1173,6 +1204,137 @@ func (fake *QueryExecutor) GetStateRangeScanIteratorWithPaginationReturnsOnCall(
 	}{result1, result2}
 }
 
+func (fake *QueryExecutor) GetStateReverseRangeScanIterator(arg1 string, arg2 string, arg3 string) (ledgera.ResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorReturnsOnCall[len(fake.getStateReverseRangeScanIteratorArgsForCall)]
+	fake.getStateReverseRangeScanIteratorArgsForCall = append(fake.getStateReverseRangeScanIteratorArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetStateReverseRangeScanIterator", []interface{}{arg1, arg2, arg3})
+	fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorStub != nil {
+		return fake.GetStateReverseRangeScanIteratorStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateReverseRangeScanIteratorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorCallCount() int {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorArgsForCall)
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorCalls(stub func(string, string, string) (ledgera.ResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = stub
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorArgsForCall(i int) (string, string, string) {
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorReturns(result1 ledgera.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	fake.getStateReverseRangeScanIteratorReturns = struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorReturnsOnCall(i int, result1 ledgera.ResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorStub = nil
+	if fake.getStateReverseRangeScanIteratorReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorReturnsOnCall = make(map[int]struct {
+			result1 ledgera.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateReverseRangeScanIteratorReturnsOnCall[i] = struct {
+		result1 ledgera.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPagination(arg1 string, arg2 string, arg3 string, arg4 int32) (ledger.QueryResultsIterator, error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)]
+	fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall = append(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetStateReverseRangeScanIteratorWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	if fake.GetStateReverseRangeScanIteratorWithPaginationStub != nil {
+		return fake.GetStateReverseRangeScanIteratorWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateReverseRangeScanIteratorWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPaginationCallCount() int {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	return len(fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall)
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPaginationCalls(stub func(string, string, string, int32) (ledger.QueryResultsIterator, error)) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = stub
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPaginationArgsForCall(i int) (string, string, string, int32) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
+	argsForCall := fake.getStateReverseRangeScanIteratorWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPaginationReturns(result1 ledger.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	fake.getStateReverseRangeScanIteratorWithPaginationReturns = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) GetStateReverseRangeScanIteratorWithPaginationReturnsOnCall(i int, result1 ledger.QueryResultsIterator, result2 error) {
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.Lock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.Unlock()
+	fake.GetStateReverseRangeScanIteratorWithPaginationStub = nil
+	if fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall == nil {
+		fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateReverseRangeScanIteratorWithPaginationReturnsOnCall[i] = struct {
+		result1 ledger.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *QueryExecutor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1206,6 +1368,10 @@ func (fake *QueryExecutor) Invocations() map[string][][]interface{} {
 	defer fake.getStateRangeScanIteratorMutex.RUnlock()
 	fake.getStateRangeScanIteratorWithPaginationMutex.RLock()
 	defer fake.getStateRangeScanIteratorWithPaginationMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorMutex.RUnlock()
+	fake.getStateReverseRangeScanIteratorWithPaginationMutex.RLock()
+	defer fake.getStateReverseRangeScanIteratorWithPaginationMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -27,6 +27,9 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	hashedIndexKeyPrefix           = []byte{8}
+	purgeMarkerKeyPrefix           = []byte{9}
+	hashedIndexBuiltKey            = []byte{10}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -107,6 +110,52 @@ func decodeDataValue(datavalueBytes []byte) (*rwset.CollectionPvtReadWriteSet, e
 	return collPvtdata, err
 }
 
+// encodeHashedIndexKeyPrefix returns the common prefix of the hashed index keys of a private data key.
+// The key hash is length prefixed so that the height that follows the prefix can be decoded
+func encodeHashedIndexKeyPrefix(ns, coll string, keyHash []byte) []byte {
+	keyBytes := append(hashedIndexKeyPrefix, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, proto.EncodeVarint(uint64(len(keyHash)))...)
+	return append(keyBytes, keyHash...)
+}
+
+func encodeHashedIndexKey(key *dataKey, keyHash []byte) []byte {
+	keyBytes := encodeHashedIndexKeyPrefix(key.ns, key.coll, keyHash)
+	return append(keyBytes, version.NewHeight(key.blkNum, key.txNum).ToBytes()...)
+}
+
+func decodeHashedIndexKeyHeight(keyBytes []byte, prefixLen int) (*version.Height, error) {
+	height, _, err := version.NewHeightFromBytes(keyBytes[prefixLen:])
+	return height, err
+}
+
+// hashedIndexKeyRange returns the range of the hashed index keys of a private data key that
+// were committed in the blocks before the given block
+func hashedIndexKeyRange(ns, coll string, keyHash []byte, blkNum uint64) (startKey, endKey []byte) {
+	startKey = encodeHashedIndexKeyPrefix(ns, coll, keyHash)
+	endKey = append(encodeHashedIndexKeyPrefix(ns, coll, keyHash), version.NewHeight(blkNum, 0).ToBytes()...)
+	return
+}
+
+func encodePurgeMarkerKey(ns, coll string, keyHash []byte) []byte {
+	keyBytes := append(purgeMarkerKeyPrefix, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	return append(keyBytes, keyHash...)
+}
+
+func encodePurgeMarkerValue(purgedAt *version.Height) []byte {
+	return purgedAt.ToBytes()
+}
+
+func decodePurgeMarkerValue(b []byte) (*version.Height, error) {
+	purgedAt, _, err := version.NewHeightFromBytes(b)
+	return purgedAt, err
+}
+
 func encodeMissingDataKey(key *missingDataKey) []byte {
 	if key.isEligible {
 		// When missing pvtData reconciler asks for missing data info,
@@ -0,0 +1,296 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/pkg/errors"
+)
+
+// PurgeMarker identifies a private data key, by its hash, that is purged by the transaction
+// 'TxNum' of the block being committed. A purge removes the private data of the key committed
+// at the heights lower than or equal to the height of the purging transaction
+type PurgeMarker struct {
+	Namespace  string
+	Collection string
+	KeyHash    []byte
+	TxNum      uint64
+}
+
+type purgedKey struct {
+	ns, coll, keyHash string
+}
+
+// purgedKeys maps the keys purged in a block to the highest transaction in the block that purges them
+type purgedKeys map[purgedKey]uint64
+
+func newPurgedKeys(purgeMarkers []*PurgeMarker) purgedKeys {
+	p := purgedKeys{}
+	for _, m := range purgeMarkers {
+		k := purgedKey{m.Namespace, m.Collection, string(m.KeyHash)}
+		if txNum, ok := p[k]; !ok || m.TxNum > txNum {
+			p[k] = m.TxNum
+		}
+	}
+	return p
+}
+
+// trimDataEntry removes from a data entry of the committing block the writes that are purged by the
+// same or a later transaction in the block
+func (p purgedKeys) trimDataEntry(entry *dataEntry) error {
+	if len(p) == 0 {
+		return nil
+	}
+	trimmed, err := removeWrites(entry.value, func(keyHash []byte) (bool, error) {
+		txNum, ok := p[purgedKey{entry.key.ns, entry.key.coll, string(keyHash)}]
+		return ok && entry.key.txNum <= txNum, nil
+	})
+	if err != nil {
+		return err
+	}
+	entry.value = trimmed
+	return nil
+}
+
+// removeWrites returns the collection private write set without the writes whose key hashes are
+// reported as purged by the given function. The write set is returned as is if no write is removed
+func removeWrites(
+	collPvtdata *rwset.CollectionPvtReadWriteSet,
+	isPurged func(keyHash []byte) (bool, error),
+) (*rwset.CollectionPvtReadWriteSet, error) {
+	kvRWSet, ok := unmarshalKVRWSet(collPvtdata)
+	if !ok {
+		return collPvtdata, nil
+	}
+	var retained []*kvrwset.KVWrite
+	for _, w := range kvRWSet.Writes {
+		purged, err := isPurged(util.ComputeStringHash(w.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !purged {
+			retained = append(retained, w)
+		}
+	}
+	if len(retained) == len(kvRWSet.Writes) {
+		return collPvtdata, nil
+	}
+	kvRWSet.Writes = retained
+	rwsetBytes, err := proto.Marshal(kvRWSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling the private write set")
+	}
+	return &rwset.CollectionPvtReadWriteSet{
+		CollectionName: collPvtdata.CollectionName,
+		Rwset:          rwsetBytes,
+	}, nil
+}
+
+// unmarshalKVRWSet unmarshals the collection private write set. A write set that cannot be unmarshalled
+// is neither indexed nor trimmed, as it does not match any hashed write in the transaction
+func unmarshalKVRWSet(collPvtdata *rwset.CollectionPvtReadWriteSet) (*kvrwset.KVRWSet, bool) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtdata.Rwset, kvRWSet); err != nil {
+		logger.Debugf("Skipping the private write set of collection [%s] that could not be unmarshalled: %s", collPvtdata.CollectionName, err)
+		return nil, false
+	}
+	return kvRWSet, true
+}
+
+// keyHashes returns the hashes of the keys written in the collection private write set
+func keyHashes(collPvtdata *rwset.CollectionPvtReadWriteSet) [][]byte {
+	kvRWSet, ok := unmarshalKVRWSet(collPvtdata)
+	if !ok {
+		return nil
+	}
+	var hashes [][]byte
+	for _, w := range kvRWSet.Writes {
+		hashes = append(hashes, util.ComputeStringHash(w.Key))
+	}
+	return hashes
+}
+
+// addHashedIndexEntries adds to the batch an entry per key written in the data entry so that the data
+// entries that contain a key can be located when the key is purged
+func addHashedIndexEntries(batch *leveldbhelper.UpdateBatch, key *dataKey, collPvtdata *rwset.CollectionPvtReadWriteSet) {
+	for _, keyHash := range keyHashes(collPvtdata) {
+		batch.Put(encodeHashedIndexKey(key, keyHash), emptyValue)
+	}
+}
+
+// buildHashedIndex adds the hashed index entries of the data entries that were committed before the store
+// maintained the hashed index, so that the purges locate the private data of these entries as well. The
+// completion is recorded in the store so that the data entries are scanned only once
+func (s *Store) buildHashedIndex() error {
+	if s.isEmpty {
+		// the first commit records that the store is indexed
+		return nil
+	}
+	built, err := s.db.Get(hashedIndexBuiltKey)
+	if err != nil || built != nil {
+		return err
+	}
+
+	logger.Infof("Building the hashed index of the private data of ledger [%s]", s.ledgerid)
+	itr, err := s.db.GetIterator(pvtDataKeyPrefix, []byte{pvtDataKeyPrefix[0] + 1})
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+
+	batch := s.db.NewUpdateBatch()
+	numIndexed := 0
+	for itr.Next() {
+		v11Fmt, err := v11Format(itr.Key())
+		if err != nil {
+			return err
+		}
+		if v11Fmt {
+			// the data of a v1.1 store is not indexed per collection and cannot be purged
+			continue
+		}
+		key, err := decodeDatakey(itr.Key())
+		if err != nil {
+			return err
+		}
+		collPvtdata, err := decodeDataValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		addHashedIndexEntries(batch, key, collPvtdata)
+		numIndexed++
+		if batch.Len() > s.maxBatchSize {
+			if err := s.db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while iterating the private data")
+	}
+
+	batch.Put(hashedIndexBuiltKey, emptyValue)
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Infof("Built the hashed index of [%d] private data entries of ledger [%s]", numIndexed, s.ledgerid)
+	return nil
+}
+
+// purgeDataOfOlderBlocks removes the writes of the purged keys from the data entries committed in
+// the blocks before the given block. The data entries remain in the store, even if no write remains,
+// so that they are not reported as missing
+func (s *Store) purgeDataOfOlderBlocks(batch *leveldbhelper.UpdateBatch, blockNum uint64, purged purgedKeys) error {
+	rewritten := map[dataKey]*rwset.CollectionPvtReadWriteSet{}
+	for k := range purged {
+		keyHash := []byte(k.keyHash)
+		startKey, endKey := hashedIndexKeyRange(k.ns, k.coll, keyHash, blockNum)
+		itr, err := s.db.GetIterator(startKey, endKey)
+		if err != nil {
+			return err
+		}
+		var indexKeys [][]byte
+		for itr.Next() {
+			indexKeys = append(indexKeys, append([]byte{}, itr.Key()...))
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			return errors.Wrap(err, "internal leveldb error while iterating the hashed index")
+		}
+
+		for _, indexKey := range indexKeys {
+			batch.Delete(indexKey)
+			height, err := decodeHashedIndexKeyHeight(indexKey, len(startKey))
+			if err != nil {
+				return err
+			}
+			dk := dataKey{nsCollBlk{k.ns, k.coll, height.BlockNum}, height.TxNum}
+			collPvtdata, ok := rewritten[dk]
+			if !ok {
+				if collPvtdata, err = s.getDataValue(&dk); err != nil {
+					return err
+				}
+				if collPvtdata == nil {
+					// the data is expired and purged already
+					continue
+				}
+			}
+			if rewritten[dk], err = removeWrites(collPvtdata, func(h []byte) (bool, error) {
+				return bytes.Equal(h, keyHash), nil
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for dk, collPvtdata := range rewritten {
+		dk := dk
+		valBytes, err := encodeDataValue(collPvtdata)
+		if err != nil {
+			return err
+		}
+		batch.Put(encodeDataKey(&dk), valBytes)
+	}
+	return nil
+}
+
+// removePurgedWrites removes from the data entries of the old blocks, which are being reconciled, the
+// writes of the keys that were purged after the data was committed
+func (s *Store) removePurgedWrites(dataEntries []*dataEntry) error {
+	for _, entry := range dataEntries {
+		trimmed, err := removeWrites(entry.value, func(keyHash []byte) (bool, error) {
+			return s.IsPurged(entry.key.ns, entry.key.coll, keyHash, entry.key.blkNum, entry.key.txNum)
+		})
+		if err != nil {
+			return err
+		}
+		entry.value = trimmed
+	}
+	return nil
+}
+
+// deleteHashedIndexEntries adds to the batch the deletes of the hashed index entries of a data entry
+func (s *Store) deleteHashedIndexEntries(batch *leveldbhelper.UpdateBatch, key *dataKey) error {
+	collPvtdata, err := s.getDataValue(key)
+	if err != nil || collPvtdata == nil {
+		return err
+	}
+	for _, keyHash := range keyHashes(collPvtdata) {
+		batch.Delete(encodeHashedIndexKey(key, keyHash))
+	}
+	return nil
+}
+
+// IsPurged returns true if the given key of the collection was purged by a transaction at a height greater
+// than or equal to the given height, i.e., if the private data of the key committed at that height is removed
+func (s *Store) IsPurged(ns, coll string, keyHash []byte, blkNum, txNum uint64) (bool, error) {
+	v, err := s.db.Get(encodePurgeMarkerKey(ns, coll, keyHash))
+	if err != nil || v == nil {
+		return false, err
+	}
+	purgedAt, err := decodePurgeMarkerValue(v)
+	if err != nil {
+		return false, err
+	}
+	return purgedAt.Compare(version.NewHeight(blkNum, txNum)) >= 0, nil
+}
+
+func (s *Store) getDataValue(key *dataKey) (*rwset.CollectionPvtReadWriteSet, error) {
+	v, err := s.db.Get(encodeDataKey(key))
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return decodeDataValue(v)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeKeys(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgeKeys", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	s := env.TestStore
+
+	blk1MissingData := make(ledger.TxMissingPvtDataMap)
+	blk1MissingData.Add(4, "ns-1", "coll-1", true)
+	require.NoError(t, s.Commit(0, nil, nil, nil))
+	require.NoError(t, s.Commit(1, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1", "key2"),
+		producePvtdataWithKeys(t, 3, "ns-1", "coll-1", "key1"),
+		producePvtdataWithKeys(t, 5, "ns-1", "coll-2", "key1"),
+	}, blk1MissingData, nil))
+
+	// the purge by tx 1 of block 2 covers the data of key1 in block 1 and the data committed before it in block 2
+	require.NoError(t, s.Commit(2, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 0, "ns-1", "coll-1", "key1"),
+		producePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1"),
+	}, nil, []*PurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key1"), TxNum: 1},
+	}))
+
+	blk1Pvtdata, err := s.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, blk1Pvtdata, 3)
+	require.Equal(t, []string{"key2"}, pvtdataKeys(t, blk1Pvtdata[0], "ns-1", "coll-1"))
+	// the data entry is retained without any writes so that it is not reported as missing
+	require.Empty(t, pvtdataKeys(t, blk1Pvtdata[1], "ns-1", "coll-1"))
+	// the key in the other collection is not purged
+	require.Equal(t, []string{"key1"}, pvtdataKeys(t, blk1Pvtdata[2], "ns-1", "coll-2"))
+
+	blk2Pvtdata, err := s.GetPvtDataByBlockNum(2, nil)
+	require.NoError(t, err)
+	require.Len(t, blk2Pvtdata, 2)
+	require.Empty(t, pvtdataKeys(t, blk2Pvtdata[0], "ns-1", "coll-1"))
+	require.Equal(t, []string{"key1"}, pvtdataKeys(t, blk2Pvtdata[1], "ns-1", "coll-1"))
+
+	for _, tc := range []struct {
+		key            string
+		blkNum, txNum  uint64
+		expectedPurged bool
+	}{
+		{"key1", 1, 2, true},
+		{"key1", 2, 1, true},
+		{"key1", 2, 2, false},
+		{"key2", 1, 2, false},
+	} {
+		purged, err := s.IsPurged("ns-1", "coll-1", util.ComputeStringHash(tc.key), tc.blkNum, tc.txNum)
+		require.NoError(t, err)
+		require.Equal(t, tc.expectedPurged, purged, "key [%s] at height [%d:%d]", tc.key, tc.blkNum, tc.txNum)
+	}
+
+	// the hashed index retains only the entries of the data that is not purged
+	require.Equal(t, []uint64{2}, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key1"))
+	require.Equal(t, []uint64{1}, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key2"))
+
+	// the purged keys are removed from the reconciled data of the old blocks
+	require.NoError(t, s.CommitPvtDataOfOldBlocks(map[uint64][]*ledger.TxPvtData{
+		1: {producePvtdataWithKeys(t, 4, "ns-1", "coll-1", "key1", "key3")},
+	}))
+	blk1Pvtdata, err = s.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, blk1Pvtdata, 4)
+	require.Equal(t, uint64(4), blk1Pvtdata[2].SeqInBlock)
+	require.Equal(t, []string{"key3"}, pvtdataKeys(t, blk1Pvtdata[2], "ns-1", "coll-1"))
+	require.Equal(t, []uint64{1, 1}, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key2", "key3"))
+}
+
+func TestPurgeKeysCommittedWithoutHashedIndex(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgeKeysCommittedWithoutHashedIndex", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+
+	require.NoError(t, env.TestStore.Commit(0, nil, nil, nil))
+	require.NoError(t, env.TestStore.Commit(1, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 2, "ns-1", "coll-1", "key1", "key2"),
+		producePvtdataWithKeys(t, 3, "ns-1", "coll-1", "key1"),
+	}, nil, nil))
+
+	// remove the hashed index, as for the data committed by a store that did not maintain it
+	s := env.TestStore
+	itr, err := s.db.GetIterator(hashedIndexKeyPrefix, []byte{hashedIndexKeyPrefix[0] + 1})
+	require.NoError(t, err)
+	batch := s.db.NewUpdateBatch()
+	for itr.Next() {
+		batch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	batch.Delete(hashedIndexBuiltKey)
+	require.NoError(t, s.db.WriteBatch(batch, true))
+	require.Empty(t, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key1", "key2"))
+
+	// the hashed index is built when the store is opened
+	env.CloseAndReopen()
+	s = env.TestStore
+	require.Equal(t, []uint64{1, 1}, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key1"))
+	require.Equal(t, []uint64{1}, hashedIndexBlocks(t, s, "ns-1", "coll-1", "key2"))
+
+	require.NoError(t, s.Commit(2, nil, nil, []*PurgeMarker{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: util.ComputeStringHash("key1"), TxNum: 0},
+	}))
+	blk1Pvtdata, err := s.GetPvtDataByBlockNum(1, nil)
+	require.NoError(t, err)
+	require.Len(t, blk1Pvtdata, 2)
+	require.Equal(t, []string{"key2"}, pvtdataKeys(t, blk1Pvtdata[0], "ns-1", "coll-1"))
+	require.Empty(t, pvtdataKeys(t, blk1Pvtdata[1], "ns-1", "coll-1"))
+
+	// the completion of the index is recorded so that it is not built again
+	built, err := s.db.Get(hashedIndexBuiltKey)
+	require.NoError(t, err)
+	require.NotNil(t, built)
+}
+
+func producePvtdataWithKeys(t *testing.T, txNum uint64, ns, coll string, keys ...string) *ledger.TxPvtData {
+	builder := rwsetutil.NewRWSetBuilder()
+	for _, key := range keys {
+		builder.AddToPvtAndHashedWriteSet(ns, coll, key, []byte("value-"+key))
+	}
+	simRes, err := builder.GetTxSimulationResults()
+	require.NoError(t, err)
+	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
+}
+
+func pvtdataKeys(t *testing.T, txPvtdata *ledger.TxPvtData, ns, coll string) []string {
+	for _, nsPvtdata := range txPvtdata.WriteSet.NsPvtRwset {
+		if nsPvtdata.Namespace != ns {
+			continue
+		}
+		for _, collPvtdata := range nsPvtdata.CollectionPvtRwset {
+			if collPvtdata.CollectionName != coll {
+				continue
+			}
+			kvRWSet := &kvrwset.KVRWSet{}
+			require.NoError(t, proto.Unmarshal(collPvtdata.Rwset, kvRWSet))
+			var keys []string
+			for _, w := range kvRWSet.Writes {
+				keys = append(keys, w.Key)
+			}
+			return keys
+		}
+	}
+	t.Fatalf("no private data for [%s:%s] in tx [%d]", ns, coll, txPvtdata.SeqInBlock)
+	return nil
+}
+
+// hashedIndexBlocks returns the block numbers of the hashed index entries of the given keys
+func hashedIndexBlocks(t *testing.T, s *Store, ns, coll string, keys ...string) []uint64 {
+	var blkNums []uint64
+	for _, key := range keys {
+		startKey, endKey := hashedIndexKeyRange(ns, coll, util.ComputeStringHash(key), s.lastCommittedBlock+1)
+		itr, err := s.db.GetIterator(startKey, endKey)
+		require.NoError(t, err)
+		for itr.Next() {
+			height, err := decodeHashedIndexKeyHeight(itr.Key(), len(startKey))
+			require.NoError(t, err)
+			blkNums = append(blkNums, height.BlockNum)
+		}
+		itr.Release()
+	}
+	return blkNums
+}
@@ -15,9 +15,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/internal/version"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/pkg/errors"
 	"github.com/willf/bitset"
 )
 
@@ -38,6 +41,9 @@ type PrivateDataConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.PrivateDataConfig and not exposed to other components.
 	StorePath string
+	// Encryptor encrypts the values in the private data storage.
+	// The values are stored in clear if it is nil.
+	Encryptor *encryption.Encryptor
 }
 
 // Store manages the permanent storage of private write sets for a ledger
@@ -121,7 +127,7 @@ type entriesForPvtDataOfOldBlocks struct {
 
 // NewProvider instantiates a StoreProvider
 func NewProvider(conf *PrivateDataConfig) (*Provider, error) {
-	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.StorePath})
+	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.StorePath, Encryptor: conf.Encryptor})
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +160,24 @@ func (p *Provider) OpenStore(ledgerid string) (*Store, error) {
 	return s, nil
 }
 
+// BootstrapFromSnapshot initializes the pvtdata store for a ledger that is being created from a snapshot.
+// As the snapshot does not carry the private data, this only records the last block in the snapshot as the
+// last committed block so that the store expects the next block to be committed after the snapshot height
+func (p *Provider) BootstrapFromSnapshot(ledgerid string, lastBlockInSnapshot uint64) error {
+	dbHandle := p.dbProvider.GetDBHandle(ledgerid)
+	empty, err := dbHandle.IsEmpty()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return errors.Errorf(
+			"pvtdata store for ledger [%s] is not empty. Bootstrapping from a snapshot requires an empty store",
+			ledgerid,
+		)
+	}
+	return dbHandle.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(lastBlockInSnapshot), true)
+}
+
 // Close closes the store
 func (p *Provider) Close() {
 	p.dbProvider.Close()
@@ -194,6 +218,10 @@ func (s *Store) initState() error {
 		s.lastCommittedBlock = committingBlockNum
 	}
 
+	if err := s.buildHashedIndex(); err != nil {
+		return err
+	}
+
 	if blist, err = s.getLastUpdatedOldBlocksList(); err != nil {
 		return err
 	}
@@ -212,8 +240,11 @@ func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 // Commit commits the pvt data as well as both the eligible and ineligible
 // missing private data --- `eligible` denotes that the missing private data belongs to a collection
 // for which this peer is a member; `ineligible` denotes that the missing private data belong to a
-// collection for which this peer is not a member.
-func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap) error {
+// collection for which this peer is not a member. The purge markers denote the private data keys that are
+// purged by the transactions in the block. The private data of these keys that is committed in the block,
+// at or before the purging transaction, or in the previous blocks is removed from the store.
+func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtData ledger.TxMissingPvtDataMap,
+	purgeMarkers []*PurgeMarker) error {
 	expectedBlockNum := s.nextBlockNum()
 	if expectedBlockNum != blockNum {
 		return &ErrIllegalArgs{fmt.Sprintf("Expected block number=%d, received block number=%d", expectedBlockNum, blockNum)}
@@ -228,12 +259,30 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 		return err
 	}
 
+	purged := newPurgedKeys(purgeMarkers)
 	for _, dataEntry := range storeEntries.dataEntries {
+		if err := purged.trimDataEntry(dataEntry); err != nil {
+			return err
+		}
 		keyBytes = encodeDataKey(dataEntry.key)
 		if valBytes, err = encodeDataValue(dataEntry.value); err != nil {
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		addHashedIndexEntries(batch, dataEntry.key, dataEntry.value)
+	}
+
+	if len(purged) > 0 {
+		// the purger for the expired data is excluded so that it does not delete the
+		// data entries that are being rewritten
+		s.purgerLock.Lock()
+		defer s.purgerLock.Unlock()
+		if err := s.purgeDataOfOlderBlocks(batch, blockNum, purged); err != nil {
+			return err
+		}
+		for k, txNum := range purged {
+			batch.Put(encodePurgeMarkerKey(k.ns, k.coll, []byte(k.keyHash)), encodePurgeMarkerValue(version.NewHeight(blockNum, txNum)))
+		}
 	}
 
 	for _, expiryEntry := range storeEntries.expiryEntries {
@@ -255,6 +304,10 @@ func (s *Store) Commit(blockNum uint64, pvtData []*ledger.TxPvtData, missingPvtD
 	committingBlockNum := s.nextBlockNum()
 	logger.Debugf("Committing private data for block [%d]", committingBlockNum)
 	batch.Put(lastCommittedBlkkey, encodeLastCommittedBlockVal(committingBlockNum))
+	if s.isEmpty {
+		// all the data entries of the store are indexed from the first block onwards
+		batch.Put(hashedIndexBuiltKey, emptyValue)
+	}
 	if err := s.db.WriteBatch(batch, true); err != nil {
 		return err
 	}
@@ -281,8 +334,11 @@ func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 		stateDB may not be in sync with the pvtStore`}
 	}
 
-	// (1) construct dataEntries for all pvtData
+	// (1) construct dataEntries for all pvtData, excluding the keys that were purged after the data was committed
 	dataEntries := constructDataEntriesFromBlocksPvtData(blocksPvtData)
+	if err := s.removePurgedWrites(dataEntries); err != nil {
+		return err
+	}
 
 	// (2) construct update entries (i.e., dataEntries, expiryEntries, missingDataEntries) from the above created data entries
 	logger.Debugf("Constructing pvtdatastore entries for pvtData of [%d] old blocks", len(blocksPvtData))
@@ -466,6 +522,8 @@ func addNewDataEntriesToUpdateBatch(batch *leveldbhelper.UpdateBatch, entries *e
 			return err
 		}
 		batch.Put(keyBytes, valBytes)
+		dataKey := dataKey
+		addHashedIndexEntries(batch, &dataKey, pvtData)
 	}
 	return nil
 }
@@ -776,6 +834,9 @@ func (s *Store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
 		batch.Delete(encodeExpiryKey(expiryEntry.key))
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 		for _, dataKey := range dataKeys {
+			if err := s.deleteHashedIndexEntries(batch, dataKey); err != nil {
+				return err
+			}
 			batch.Delete(encodeDataKey(dataKey))
 		}
 		for _, missingDataKey := range missingDataKeys {
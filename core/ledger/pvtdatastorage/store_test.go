@@ -80,10 +80,10 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// no pvt data with block 0
-	require.NoError(store.Commit(0, nil, nil))
+	require.NoError(store.Commit(0, nil, nil, nil))
 
 	// pvt data with block 1 - commit
-	require.NoError(store.Commit(1, testData, blk1MissingData))
+	require.NoError(store.Commit(1, testData, blk1MissingData, nil))
 
 	// pvt data retrieval for block 0 should return nil
 	var nilFilter ledger.PvtNsCollFilter
@@ -121,7 +121,7 @@ func TestStoreBasicCommitAndRetrieval(t *testing.T) {
 	require.Nil(retrievedData)
 
 	// pvt data with block 2 - commit
-	require.NoError(store.Commit(2, testData, blk2MissingData))
+	require.NoError(store.Commit(2, testData, blk2MissingData, nil))
 
 	// retrieve the stored missing entries using GetMissingPvtDataInfoForMostRecentBlocks
 	// Only the code path of eligible entries would be covered in this unit-test. For
@@ -159,7 +159,7 @@ func TestStoreIteratorError(t *testing.T) {
 	env := NewTestStoreEnv(t, "TestStoreIteratorError", nil, pvtDataConf())
 	defer env.Cleanup()
 	store := env.TestStore
-	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(0, nil, nil, nil))
 	env.TestStoreProvider.Close()
 	errStr := "internal leveldb error while obtaining db iterator: leveldb: closed"
 
@@ -239,13 +239,13 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	blk2MissingData.Add(3, "ns-1", "coll-1", true)
 
 	// COMMIT BLOCK 0 WITH NO DATA
-	require.NoError(store.Commit(0, nil, nil))
+	require.NoError(store.Commit(0, nil, nil, nil))
 
 	// COMMIT BLOCK 1 WITH PVTDATA AND MISSINGDATA
-	require.NoError(store.Commit(1, testData, blk1MissingData))
+	require.NoError(store.Commit(1, testData, blk1MissingData, nil))
 
 	// COMMIT BLOCK 2 WITH PVTDATA AND MISSINGDATA
-	require.NoError(store.Commit(2, nil, blk2MissingData))
+	require.NoError(store.Commit(2, nil, blk2MissingData, nil))
 
 	// CHECK MISSINGDATA ENTRIES ARE CORRECTLY STORED
 	expectedMissingPvtDataInfo := make(ledger.MissingPvtDataInfo)
@@ -324,7 +324,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	require.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// COMMIT BLOCK 3 WITH NO PVTDATA
-	require.NoError(store.Commit(3, nil, nil))
+	require.NoError(store.Commit(3, nil, nil, nil))
 
 	// IN BLOCK 1, NS-1:COLL-2 AND NS-2:COLL-2 SHOULD HAVE EXPIRED BUT NOT PURGED
 	// HENCE, THE FOLLOWING COMMIT SHOULD CREATE ENTRIES IN THE STORE
@@ -352,7 +352,7 @@ func TestCommitPvtDataOfOldBlocks(t *testing.T) {
 	require.True(testDataKeyExists(t, store, ns3Coll2Blk1Tx2))  // never expires
 
 	// COMMIT BLOCK 4 WITH NO PVTDATA
-	require.NoError(store.Commit(4, nil, nil))
+	require.NoError(store.Commit(4, nil, nil, nil))
 
 	testWaitForPurgerRoutineToFinish(store)
 
@@ -413,21 +413,21 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	blk2MissingData.Add(1, "ns-1", "coll-2", true)
 
 	// no pvt data with block 0
-	require.NoError(store.Commit(0, nil, nil))
+	require.NoError(store.Commit(0, nil, nil, nil))
 
 	// write pvt data for block 1
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(store.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(store.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 5, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(store.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(store.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	retrievedData, _ := store.GetPvtDataByBlockNum(1, nil)
 	// block 1 data should still be not expired
@@ -451,7 +451,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 3 with no pvtdata
-	require.NoError(store.Commit(3, nil, nil))
+	require.NoError(store.Commit(3, nil, nil, nil))
 
 	// After committing block 3, the data for "ns-1:coll1" of block 1 should have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 := []*ledger.TxPvtData{
@@ -474,7 +474,7 @@ func TestExpiryDataNotIncluded(t *testing.T) {
 	require.Equal(expectedMissingPvtDataInfo, missingPvtDataInfo)
 
 	// Commit block 4 with no pvtdata
-	require.NoError(store.Commit(4, nil, nil))
+	require.NoError(store.Commit(4, nil, nil, nil))
 
 	// After committing block 4, the data for "ns-2:coll2" of block 1 should also have expired and should not be returned by the store
 	expectedPvtdataFromBlock1 = []*ledger.TxPvtData{
@@ -524,7 +524,7 @@ func TestStorePurge(t *testing.T) {
 	s := env.TestStore
 
 	// no pvt data with block 0
-	require.NoError(s.Commit(0, nil, nil))
+	require.NoError(s.Commit(0, nil, nil, nil))
 
 	// construct missing data for block 1
 	blk1MissingData := make(ledger.TxMissingPvtDataMap)
@@ -540,10 +540,10 @@ func TestStorePurge(t *testing.T) {
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1", "ns-2:coll-2"}),
 	}
-	require.NoError(s.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(s.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// write pvt data for block 2
-	require.NoError(s.Commit(2, nil, nil))
+	require.NoError(s.Commit(2, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store
 	ns1Coll1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}
 	ns2Coll2 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-2", blkNum: 1}, txNum: 2}
@@ -567,7 +567,7 @@ func TestStorePurge(t *testing.T) {
 	require.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 3
-	require.NoError(s.Commit(3, nil, nil))
+	require.NoError(s.Commit(3, nil, nil, nil))
 	// data for ns-1:coll-1 and ns-2:coll-2 should exist in store (because purger should not be launched at block 3)
 	testWaitForPurgerRoutineToFinish(s)
 	require.True(testDataKeyExists(t, s, ns1Coll1))
@@ -580,12 +580,15 @@ func TestStorePurge(t *testing.T) {
 	require.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 4
-	require.NoError(s.Commit(4, nil, nil))
+	require.NoError(s.Commit(4, nil, nil, nil))
 	// data for ns-1:coll-1 should not exist in store (because purger should be launched at block 4)
 	// but ns-2:coll-2 should exist because it expires at block 5
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(testDataKeyExists(t, s, ns1Coll1))
 	require.True(testDataKeyExists(t, s, ns2Coll2))
+	// the hashed index entries of the expired data should be removed as well
+	require.Empty(hashedIndexBlocks(t, s, "ns-1", "coll-1", "key-ns-1-coll-1"))
+	require.Equal([]uint64{1, 1}, hashedIndexBlocks(t, s, "ns-2", "coll-2", "key-ns-2-coll-2"))
 	// eligible missingData entries for ns-1:coll-1 should have expired and ns-1:coll-2 (neverExpires) should exist in store
 	require.False(testMissingDataKeyExists(t, s, ns1Coll1elgMD))
 	require.True(testMissingDataKeyExists(t, s, ns1Coll2elgMD))
@@ -594,14 +597,14 @@ func TestStorePurge(t *testing.T) {
 	require.True(testMissingDataKeyExists(t, s, ns3Coll2inelgMD))
 
 	// write pvt data for block 5
-	require.NoError(s.Commit(5, nil, nil))
+	require.NoError(s.Commit(5, nil, nil, nil))
 	// ns-2:coll-2 should exist because though the data expires at block 5 but purger is launched every second block
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(testDataKeyExists(t, s, ns1Coll1))
 	require.True(testDataKeyExists(t, s, ns2Coll2))
 
 	// write pvt data for block 6
-	require.NoError(s.Commit(6, nil, nil))
+	require.NoError(s.Commit(6, nil, nil, nil))
 	// ns-2:coll-2 should not exists now (because purger should be launched at block 6)
 	testWaitForPurgerRoutineToFinish(s)
 	require.False(testDataKeyExists(t, s, ns1Coll1))
@@ -625,10 +628,31 @@ func TestStoreState(t *testing.T) {
 	testData := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 0, []string{"ns-1:coll-1", "ns-1:coll-2"}),
 	}
-	_, ok := store.Commit(1, testData, nil).(*ErrIllegalArgs)
+	_, ok := store.Commit(1, testData, nil, nil).(*ErrIllegalArgs)
 	require.True(ok)
 }
 
+func TestBootstrapFromSnapshot(t *testing.T) {
+	env := NewTestStoreEnv(t, "TestBootstrapFromSnapshot", nil, pvtDataConf())
+	defer env.Cleanup()
+	require := require.New(t)
+
+	require.NoError(env.TestStoreProvider.BootstrapFromSnapshot("ledger-from-snapshot", 9))
+	store, err := env.TestStoreProvider.OpenStore("ledger-from-snapshot")
+	require.NoError(err)
+	store.Init(nil)
+	height, err := store.LastCommittedBlockHeight()
+	require.NoError(err)
+	require.Equal(uint64(10), height)
+
+	_, ok := store.Commit(9, nil, nil, nil).(*ErrIllegalArgs)
+	require.True(ok)
+	require.NoError(store.Commit(10, nil, nil, nil))
+
+	err = env.TestStoreProvider.BootstrapFromSnapshot("ledger-from-snapshot", 9)
+	require.EqualError(err, "pvtdata store for ledger [ledger-from-snapshot] is not empty. Bootstrapping from a snapshot requires an empty store")
+}
+
 func TestPendingBatch(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -743,10 +767,10 @@ func TestDrop(t *testing.T) {
 	blk1MissingData.Add(1, "ns-2", "coll-2", true)
 
 	// no pvt data with block 0
-	require.NoError(store.Commit(0, nil, nil))
+	require.NoError(store.Commit(0, nil, nil, nil))
 
 	// pvt data with block 1 - commit
-	require.NoError(store.Commit(1, testData, blk1MissingData))
+	require.NoError(store.Commit(1, testData, blk1MissingData, nil))
 
 	// pvt data retrieval for block 0 should return nil
 	var nilFilter ledger.PvtNsCollFilter
@@ -804,7 +828,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	// Initial state: eligible for {ns-1:coll-1 and ns-2:coll-1 }
 
 	// no pvt data with block 0
-	require.NoError(testStore.Commit(0, nil, nil))
+	require.NoError(testStore.Commit(0, nil, nil, nil))
 
 	// construct and commit block 1
 	blk1MissingData := make(ledger.TxMissingPvtDataMap)
@@ -815,7 +839,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk1 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(testStore.Commit(1, testDataForBlk1, blk1MissingData))
+	require.NoError(testStore.Commit(1, testDataForBlk1, blk1MissingData, nil))
 
 	// construct and commit block 2
 	blk2MissingData := make(ledger.TxMissingPvtDataMap)
@@ -825,7 +849,7 @@ func testCollElgEnabled(t *testing.T, conf *PrivateDataConfig) {
 	testDataForBlk2 := []*ledger.TxPvtData{
 		produceSamplePvtdata(t, 3, []string{"ns-1:coll-1"}),
 	}
-	require.NoError(testStore.Commit(2, testDataForBlk2, blk2MissingData))
+	require.NoError(testStore.Commit(2, testDataForBlk2, blk2MissingData, nil))
 
 	// Retrieve and verify missing data reported
 	// Expected missing data should be only blk1-tx1 (because, the other missing data is marked as ineliigible)
@@ -0,0 +1,434 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: snapshot.proto
+
+package snapshotgrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// SnapshotRequest contains information for a submit or cancel snapshot request
+type SnapshotRequest struct {
+	// The marshalled common.SignatureHeader that contains the creator identity and nonce
+	SignatureHeader []byte `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// The channel ID
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The block number at which the snapshot is to be generated
+	BlockNumber          uint64   `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotRequest) Reset()         { *m = SnapshotRequest{} }
+func (m *SnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotRequest) ProtoMessage()    {}
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{0}
+}
+
+func (m *SnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotRequest.Unmarshal(m, b)
+}
+func (m *SnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotRequest.Merge(m, src)
+}
+func (m *SnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotRequest.Size(m)
+}
+func (m *SnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotRequest proto.InternalMessageInfo
+
+func (m *SnapshotRequest) GetSignatureHeader() []byte {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *SnapshotRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SnapshotRequest) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// SnapshotQuery contains information for a query pending snapshot requests request
+type SnapshotQuery struct {
+	// The marshalled common.SignatureHeader that contains the creator identity and nonce
+	SignatureHeader []byte `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	// The channel ID
+	ChannelId            string   `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotQuery) Reset()         { *m = SnapshotQuery{} }
+func (m *SnapshotQuery) String() string { return proto.CompactTextString(m) }
+func (*SnapshotQuery) ProtoMessage()    {}
+func (*SnapshotQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{1}
+}
+
+func (m *SnapshotQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotQuery.Unmarshal(m, b)
+}
+func (m *SnapshotQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotQuery.Marshal(b, m, deterministic)
+}
+func (m *SnapshotQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotQuery.Merge(m, src)
+}
+func (m *SnapshotQuery) XXX_Size() int {
+	return xxx_messageInfo_SnapshotQuery.Size(m)
+}
+func (m *SnapshotQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotQuery proto.InternalMessageInfo
+
+func (m *SnapshotQuery) GetSignatureHeader() []byte {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *SnapshotQuery) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// SignedSnapshotRequest contains the marshalled request bytes and the signature
+type SignedSnapshotRequest struct {
+	// The bytes of SnapshotRequest or SnapshotQuery
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature over the request bytes; this signature is to be verified against the client identity
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedSnapshotRequest) Reset()         { *m = SignedSnapshotRequest{} }
+func (m *SignedSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedSnapshotRequest) ProtoMessage()    {}
+func (*SignedSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{2}
+}
+
+func (m *SignedSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedSnapshotRequest.Unmarshal(m, b)
+}
+func (m *SignedSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedSnapshotRequest.Merge(m, src)
+}
+func (m *SignedSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedSnapshotRequest.Size(m)
+}
+func (m *SignedSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedSnapshotRequest proto.InternalMessageInfo
+
+func (m *SignedSnapshotRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedSnapshotRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// QueryPendingSnapshotsResponse specifies the response payload of a query pending snapshot requests request
+type QueryPendingSnapshotsResponse struct {
+	BlockNumbers []uint64 `protobuf:"varint,1,rep,packed,name=block_numbers,json=blockNumbers,proto3" json:"block_numbers,omitempty"`
+	// The failures of the snapshot generation for the pending requests, by block number.
+	// A request whose snapshot generation failed stays pending until it is cancelled
+	Failures             map[uint64]string `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryPendingSnapshotsResponse) Reset()         { *m = QueryPendingSnapshotsResponse{} }
+func (m *QueryPendingSnapshotsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryPendingSnapshotsResponse) ProtoMessage()    {}
+func (*QueryPendingSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c8aab8e59648e0b, []int{3}
+}
+
+func (m *QueryPendingSnapshotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryPendingSnapshotsResponse.Unmarshal(m, b)
+}
+func (m *QueryPendingSnapshotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryPendingSnapshotsResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryPendingSnapshotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPendingSnapshotsResponse.Merge(m, src)
+}
+func (m *QueryPendingSnapshotsResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryPendingSnapshotsResponse.Size(m)
+}
+func (m *QueryPendingSnapshotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPendingSnapshotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPendingSnapshotsResponse proto.InternalMessageInfo
+
+func (m *QueryPendingSnapshotsResponse) GetBlockNumbers() []uint64 {
+	if m != nil {
+		return m.BlockNumbers
+	}
+	return nil
+}
+
+func (m *QueryPendingSnapshotsResponse) GetFailures() map[uint64]string {
+	if m != nil {
+		return m.Failures
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SnapshotRequest)(nil), "snapshotgrpc.SnapshotRequest")
+	proto.RegisterType((*SnapshotQuery)(nil), "snapshotgrpc.SnapshotQuery")
+	proto.RegisterType((*SignedSnapshotRequest)(nil), "snapshotgrpc.SignedSnapshotRequest")
+	proto.RegisterType((*QueryPendingSnapshotsResponse)(nil), "snapshotgrpc.QueryPendingSnapshotsResponse")
+	proto.RegisterMapType((map[uint64]string)(nil), "snapshotgrpc.QueryPendingSnapshotsResponse.FailuresEntry")
+}
+
+func init() { proto.RegisterFile("snapshot.proto", fileDescriptor_0c8aab8e59648e0b) }
+
+var fileDescriptor_0c8aab8e59648e0b = []byte{
+	// 426 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xad, 0x93, 0x50, 0x92, 0xa9, 0x43, 0xab, 0x15, 0x20, 0x2b, 0x50, 0xc9, 0xb8, 0x17, 0x23,
+	0x24, 0x5b, 0x2a, 0x12, 0x2a, 0x70, 0x03, 0x95, 0xd2, 0x0b, 0x1f, 0x5b, 0x71, 0x80, 0x4b, 0xb4,
+	0xb6, 0x27, 0xb6, 0x55, 0x67, 0xd7, 0xec, 0x07, 0x92, 0x2f, 0x5c, 0xf8, 0x93, 0xfc, 0x1c, 0xd4,
+	0xb5, 0x1d, 0x1c, 0x84, 0x10, 0x88, 0xde, 0x76, 0xde, 0x8c, 0xdf, 0x9b, 0xf7, 0x3c, 0x70, 0x4b,
+	0x71, 0x56, 0xab, 0x42, 0xe8, 0xa8, 0x96, 0x42, 0x0b, 0xe2, 0xf6, 0x75, 0x2e, 0xeb, 0x74, 0x71,
+	0x2f, 0x17, 0x22, 0xaf, 0x30, 0xb6, 0xbd, 0xc4, 0xac, 0x62, 0x5c, 0xd7, 0xba, 0x69, 0x47, 0x83,
+	0xaf, 0xb0, 0x7f, 0xd1, 0x0d, 0x53, 0xfc, 0x6c, 0x50, 0x69, 0xf2, 0x10, 0x0e, 0x54, 0x99, 0x73,
+	0xa6, 0x8d, 0xc4, 0x65, 0x81, 0x2c, 0x43, 0xe9, 0x39, 0xbe, 0x13, 0xba, 0x74, 0x7f, 0x83, 0xbf,
+	0xb6, 0x30, 0x39, 0x04, 0x48, 0x0b, 0xc6, 0x39, 0x56, 0xcb, 0x32, 0xf3, 0x46, 0xbe, 0x13, 0xce,
+	0xe8, 0xac, 0x43, 0xce, 0x33, 0xf2, 0x00, 0xdc, 0xa4, 0x12, 0xe9, 0xe5, 0x92, 0x9b, 0x75, 0x82,
+	0xd2, 0x1b, 0xfb, 0x4e, 0x38, 0xa1, 0x7b, 0x16, 0x7b, 0x63, 0xa1, 0xe0, 0x23, 0xcc, 0x7b, 0xfd,
+	0xf7, 0x06, 0x65, 0x73, 0x7d, 0xea, 0xc1, 0x5b, 0xb8, 0x73, 0x51, 0xe6, 0x1c, 0xb3, 0x5f, 0x0d,
+	0x7a, 0x70, 0x53, 0xb6, 0xcf, 0x8e, 0xb9, 0x2f, 0xc9, 0x7d, 0x98, 0x6d, 0x44, 0x2c, 0xa1, 0x4b,
+	0x7f, 0x02, 0xc1, 0x77, 0x07, 0x0e, 0xed, 0x92, 0xef, 0x90, 0x67, 0x25, 0xcf, 0x7b, 0x5e, 0x45,
+	0x51, 0xd5, 0x82, 0x2b, 0x24, 0x47, 0x30, 0x1f, 0x1a, 0x56, 0x9e, 0xe3, 0x8f, 0xc3, 0x09, 0x75,
+	0x07, 0x8e, 0x15, 0xf9, 0x00, 0xd3, 0x15, 0x2b, 0x2b, 0x23, 0x51, 0x79, 0x23, 0x7f, 0x1c, 0xee,
+	0x1d, 0x3f, 0x8d, 0x86, 0x3f, 0x2c, 0xfa, 0xa3, 0x46, 0xf4, 0xaa, 0xfb, 0xf6, 0x94, 0x6b, 0xd9,
+	0xd0, 0x0d, 0xd5, 0xe2, 0x39, 0xcc, 0xb7, 0x5a, 0xe4, 0x00, 0xc6, 0x97, 0xd8, 0x58, 0x8b, 0x13,
+	0x7a, 0xf5, 0x24, 0xb7, 0xe1, 0xc6, 0x17, 0x56, 0x19, 0xec, 0xb2, 0x6a, 0x8b, 0x67, 0xa3, 0x13,
+	0xe7, 0xf8, 0xdb, 0x08, 0xa6, 0xbd, 0x14, 0x39, 0x87, 0xe9, 0x19, 0x72, 0x94, 0x4c, 0x23, 0x39,
+	0xda, 0x5e, 0xed, 0xb7, 0x81, 0x2e, 0xee, 0x46, 0xed, 0x89, 0x45, 0xfd, 0x89, 0x45, 0xa7, 0x57,
+	0x27, 0x16, 0xec, 0x90, 0x33, 0xd8, 0x7d, 0xc9, 0x78, 0x8a, 0xd5, 0xff, 0x12, 0xa5, 0x30, 0x1f,
+	0xc6, 0xa2, 0xfe, 0x8e, 0xef, 0xd1, 0x3f, 0x04, 0x1b, 0xec, 0xbc, 0x38, 0xf9, 0xf4, 0x24, 0x2f,
+	0x75, 0x61, 0x92, 0x28, 0x15, 0xeb, 0xb8, 0x68, 0x6a, 0x94, 0x15, 0x66, 0x39, 0xca, 0x78, 0xc5,
+	0x12, 0x59, 0xa6, 0x71, 0x2a, 0x24, 0xc6, 0x1d, 0x34, 0x64, 0x4e, 0x76, 0xed, 0xc2, 0x8f, 0x7f,
+	0x0c, 0x00, 0x62, 0x6e, 0x4c, 0x2d, 0x8a, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SnapshotClient is the client API for Snapshot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SnapshotClient interface {
+	// Generate submits a snapshot request. SignedSnapshotRequest contains the marshalled bytes of SnapshotRequest
+	Generate(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Cancel cancels a snapshot request. SignedSnapshotRequest contains the marshalled bytes of SnapshotRequest
+	Cancel(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// QueryPendings queries the pending snapshot requests. SignedSnapshotRequest contains the marshalled bytes of SnapshotQuery
+	QueryPendings(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*QueryPendingSnapshotsResponse, error)
+}
+
+type snapshotClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotClient(cc grpc.ClientConnInterface) SnapshotClient {
+	return &snapshotClient{cc}
+}
+
+func (c *snapshotClient) Generate(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/snapshotgrpc.Snapshot/Generate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotClient) Cancel(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/snapshotgrpc.Snapshot/Cancel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotClient) QueryPendings(ctx context.Context, in *SignedSnapshotRequest, opts ...grpc.CallOption) (*QueryPendingSnapshotsResponse, error) {
+	out := new(QueryPendingSnapshotsResponse)
+	err := c.cc.Invoke(ctx, "/snapshotgrpc.Snapshot/QueryPendings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnapshotServer is the server API for Snapshot service.
+type SnapshotServer interface {
+	// Generate submits a snapshot request. SignedSnapshotRequest contains the marshalled bytes of SnapshotRequest
+	Generate(context.Context, *SignedSnapshotRequest) (*empty.Empty, error)
+	// Cancel cancels a snapshot request. SignedSnapshotRequest contains the marshalled bytes of SnapshotRequest
+	Cancel(context.Context, *SignedSnapshotRequest) (*empty.Empty, error)
+	// QueryPendings queries the pending snapshot requests. SignedSnapshotRequest contains the marshalled bytes of SnapshotQuery
+	QueryPendings(context.Context, *SignedSnapshotRequest) (*QueryPendingSnapshotsResponse, error)
+}
+
+// UnimplementedSnapshotServer can be embedded to have forward compatible implementations.
+type UnimplementedSnapshotServer struct {
+}
+
+func (*UnimplementedSnapshotServer) Generate(ctx context.Context, req *SignedSnapshotRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (*UnimplementedSnapshotServer) Cancel(ctx context.Context, req *SignedSnapshotRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (*UnimplementedSnapshotServer) QueryPendings(ctx context.Context, req *SignedSnapshotRequest) (*QueryPendingSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryPendings not implemented")
+}
+
+func RegisterSnapshotServer(s *grpc.Server, srv SnapshotServer) {
+	s.RegisterService(&_Snapshot_serviceDesc, srv)
+}
+
+func _Snapshot_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/snapshotgrpc.Snapshot/Generate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotServer).Generate(ctx, req.(*SignedSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Snapshot_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/snapshotgrpc.Snapshot/Cancel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotServer).Cancel(ctx, req.(*SignedSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Snapshot_QueryPendings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotServer).QueryPendings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/snapshotgrpc.Snapshot/QueryPendings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotServer).QueryPendings(ctx, req.(*SignedSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Snapshot_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "snapshotgrpc.Snapshot",
+	HandlerType: (*SnapshotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Generate",
+			Handler:    _Snapshot_Generate_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Snapshot_Cancel_Handler,
+		},
+		{
+			MethodName: "QueryPendings",
+			Handler:    _Snapshot_QueryPendings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "snapshot.proto",
+}
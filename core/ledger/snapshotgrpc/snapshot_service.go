@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ACLProvider checks ACL for a resource
+type ACLProvider interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// SnapshotService implements SnapshotServer methods
+type SnapshotService struct {
+	LedgerGetter LedgerGetter
+	ACLProvider  ACLProvider
+}
+
+// Generate submits a snapshot request for the block number specified in the request
+func (s *SnapshotService) Generate(ctx context.Context, signedRequest *SignedSnapshotRequest) (*empty.Empty, error) {
+	request := &SnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_submitrequest, request.ChannelId, request.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lgr.SubmitSnapshotRequest(request.BlockNumber); err != nil {
+		return nil, errors.WithMessagef(err, "error from ledger for channel %s", request.ChannelId)
+	}
+	return &empty.Empty{}, nil
+}
+
+// Cancel cancels the snapshot request for the block number specified in the request
+func (s *SnapshotService) Cancel(ctx context.Context, signedRequest *SignedSnapshotRequest) (*empty.Empty, error) {
+	request := &SnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot request")
+	}
+
+	if err := s.checkACL(resources.Snapshot_cancelrequest, request.ChannelId, request.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(request.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lgr.CancelSnapshotRequest(request.BlockNumber); err != nil {
+		return nil, errors.WithMessagef(err, "error from ledger for channel %s", request.ChannelId)
+	}
+	return &empty.Empty{}, nil
+}
+
+// QueryPendings returns the block numbers of the pending snapshot requests for the channel, along with
+// the failures of their snapshot generation
+func (s *SnapshotService) QueryPendings(ctx context.Context, signedRequest *SignedSnapshotRequest) (*QueryPendingSnapshotsResponse, error) {
+	query := &SnapshotQuery{}
+	if err := proto.Unmarshal(signedRequest.Request, query); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal snapshot query")
+	}
+
+	if err := s.checkACL(resources.Snapshot_listpending, query.ChannelId, query.SignatureHeader, signedRequest); err != nil {
+		return nil, err
+	}
+
+	lgr, err := s.getLedger(query.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := lgr.PendingSnapshotRequests()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error from ledger for channel %s", query.ChannelId)
+	}
+	failures, err := lgr.SnapshotRequestFailures()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error from ledger for channel %s", query.ChannelId)
+	}
+	return &QueryPendingSnapshotsResponse{BlockNumbers: result, Failures: failures}, nil
+}
+
+func (s *SnapshotService) checkACL(resName string, channelID string, signatureHeaderBytes []byte, signedRequest *SignedSnapshotRequest) error {
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signatureHeaderBytes, signatureHeader); err != nil {
+		return errors.Wrap(err, "failed to unmarshal signature header")
+	}
+
+	signedData := []*protoutil.SignedData{{
+		Data:      signedRequest.Request,
+		Identity:  signatureHeader.Creator,
+		Signature: signedRequest.Signature,
+	}}
+	if err := s.ACLProvider.CheckACL(resName, channelID, signedData); err != nil {
+		return errors.WithMessagef(err, "access denied for [%s]", resName)
+	}
+	return nil
+}
+
+func (s *SnapshotService) getLedger(channelID string) (ledger.PeerLedger, error) {
+	if channelID == "" {
+		return nil, errors.New("missing channel ID")
+	}
+	lgr := s.LedgerGetter.GetLedger(channelID)
+	if lgr == nil {
+		return nil, errors.Errorf("cannot find ledger for channel %s", channelID)
+	}
+	return lgr, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/ledger_getter.go -fake-name LedgerGetter . ledgerGetter
+//go:generate counterfeiter -o mock/acl_provider.go -fake-name ACLProvider . aclProvider
+//go:generate counterfeiter -o mock/peer_ledger.go -fake-name PeerLedger . peerLedger
+
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+type aclProvider interface {
+	ACLProvider
+}
+
+type peerLedger interface {
+	ledger.PeerLedger
+}
+
+func TestSnapshotService(t *testing.T) {
+	signatureHeader := protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("creator"), Nonce: []byte("nonce")})
+	signedRequest := func(m proto.Message) *SignedSnapshotRequest {
+		return &SignedSnapshotRequest{
+			Request:   protoutil.MarshalOrPanic(m),
+			Signature: []byte("signature"),
+		}
+	}
+
+	setup := func() (*SnapshotService, *mock.PeerLedger, *mock.ACLProvider) {
+		fakeLedger := &mock.PeerLedger{}
+		fakeLedgerGetter := &mock.LedgerGetter{}
+		fakeLedgerGetter.GetLedgerStub = func(channelID string) ledger.PeerLedger {
+			if channelID == "testchannel" {
+				return fakeLedger
+			}
+			return nil
+		}
+		fakeACLProvider := &mock.ACLProvider{}
+		return &SnapshotService{LedgerGetter: fakeLedgerGetter, ACLProvider: fakeACLProvider}, fakeLedger, fakeACLProvider
+	}
+
+	t.Run("Generate", func(t *testing.T) {
+		snapshotSvc, fakeLedger, fakeACLProvider := setup()
+		request := signedRequest(&SnapshotRequest{SignatureHeader: signatureHeader, ChannelId: "testchannel", BlockNumber: 100})
+		_, err := snapshotSvc.Generate(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, 1, fakeLedger.SubmitSnapshotRequestCallCount())
+		require.Equal(t, uint64(100), fakeLedger.SubmitSnapshotRequestArgsForCall(0))
+
+		require.Equal(t, 1, fakeACLProvider.CheckACLCallCount())
+		resName, channelID, idinfo := fakeACLProvider.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Snapshot_submitrequest, resName)
+		require.Equal(t, "testchannel", channelID)
+		require.Equal(t, []*protoutil.SignedData{{
+			Data:      request.Request,
+			Identity:  []byte("creator"),
+			Signature: []byte("signature"),
+		}}, idinfo)
+
+		fakeLedger.SubmitSnapshotRequestReturns(errors.New("duplicate snapshot request"))
+		_, err = snapshotSvc.Generate(context.Background(), request)
+		require.EqualError(t, err, "error from ledger for channel testchannel: duplicate snapshot request")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		snapshotSvc, fakeLedger, fakeACLProvider := setup()
+		request := signedRequest(&SnapshotRequest{SignatureHeader: signatureHeader, ChannelId: "testchannel", BlockNumber: 100})
+		_, err := snapshotSvc.Cancel(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, 1, fakeLedger.CancelSnapshotRequestCallCount())
+		require.Equal(t, uint64(100), fakeLedger.CancelSnapshotRequestArgsForCall(0))
+		resName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Snapshot_cancelrequest, resName)
+
+		fakeLedger.CancelSnapshotRequestReturns(errors.New("no snapshot request exists"))
+		_, err = snapshotSvc.Cancel(context.Background(), request)
+		require.EqualError(t, err, "error from ledger for channel testchannel: no snapshot request exists")
+	})
+
+	t.Run("QueryPendings", func(t *testing.T) {
+		snapshotSvc, fakeLedger, fakeACLProvider := setup()
+		fakeLedger.PendingSnapshotRequestsReturns([]uint64{100, 1000}, nil)
+		fakeLedger.SnapshotRequestFailuresReturns(map[uint64]string{100: "disk full"}, nil)
+		request := signedRequest(&SnapshotQuery{SignatureHeader: signatureHeader, ChannelId: "testchannel"})
+		resp, err := snapshotSvc.QueryPendings(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{100, 1000}, resp.BlockNumbers)
+		require.Equal(t, map[uint64]string{100: "disk full"}, resp.Failures)
+		resName, _, _ := fakeACLProvider.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Snapshot_listpending, resName)
+
+		fakeLedger.SnapshotRequestFailuresReturns(nil, errors.New("leveldb error"))
+		_, err = snapshotSvc.QueryPendings(context.Background(), request)
+		require.EqualError(t, err, "error from ledger for channel testchannel: leveldb error")
+
+		fakeLedger.PendingSnapshotRequestsReturns(nil, errors.New("leveldb error"))
+		_, err = snapshotSvc.QueryPendings(context.Background(), request)
+		require.EqualError(t, err, "error from ledger for channel testchannel: leveldb error")
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		snapshotSvc, _, _ := setup()
+		request := &SignedSnapshotRequest{Request: []byte("garbage")}
+		_, err := snapshotSvc.Generate(context.Background(), request)
+		require.Contains(t, err.Error(), "failed to unmarshal snapshot request")
+		_, err = snapshotSvc.Cancel(context.Background(), request)
+		require.Contains(t, err.Error(), "failed to unmarshal snapshot request")
+		_, err = snapshotSvc.QueryPendings(context.Background(), request)
+		require.Contains(t, err.Error(), "failed to unmarshal snapshot query")
+
+		request = signedRequest(&SnapshotRequest{SignatureHeader: []byte("garbage"), ChannelId: "testchannel"})
+		_, err = snapshotSvc.Generate(context.Background(), request)
+		require.Contains(t, err.Error(), "failed to unmarshal signature header")
+	})
+
+	t.Run("AccessDenied", func(t *testing.T) {
+		snapshotSvc, fakeLedger, fakeACLProvider := setup()
+		fakeACLProvider.CheckACLReturns(errors.New("failed deserializing identity"))
+		request := signedRequest(&SnapshotRequest{SignatureHeader: signatureHeader, ChannelId: "testchannel", BlockNumber: 100})
+		_, err := snapshotSvc.Generate(context.Background(), request)
+		require.EqualError(t, err, "access denied for [snapshot/submitrequest]: failed deserializing identity")
+		require.Equal(t, 0, fakeLedger.SubmitSnapshotRequestCallCount())
+	})
+
+	t.Run("MissingLedger", func(t *testing.T) {
+		snapshotSvc, _, _ := setup()
+		request := signedRequest(&SnapshotRequest{SignatureHeader: signatureHeader, BlockNumber: 100})
+		_, err := snapshotSvc.Generate(context.Background(), request)
+		require.EqualError(t, err, "missing channel ID")
+
+		request = signedRequest(&SnapshotRequest{SignatureHeader: signatureHeader, ChannelId: "nonexistent", BlockNumber: 100})
+		_, err = snapshotSvc.Generate(context.Background(), request)
+		require.EqualError(t, err, "cannot find ledger for channel nonexistent")
+	})
+}
@@ -119,10 +119,27 @@ type Config struct {
 	// including both user chaincodes and system chaincodes.
 	LimitsConcurrencyEndorserService int
 
+	// LimitsConcurrencyEndorserBatch sets the limits for the proposals of a
+	// batch, sent on a single ProcessProposals stream, that are simulated
+	// concurrently.
+	LimitsConcurrencyEndorserBatch int
+
 	// LimitsConcurrencyDeliverService sets the limits for concurrent event listeners
 	// registered to deliver service for blocks and transaction events.
 	LimitsConcurrencyDeliverService int
 
+	// ----- Conflict detection -----
+	// ConflictDetection is used to configure the detection, at endorsement
+	// time, of the proposals that are likely to be invalidated with an MVCC
+	// read conflict.
+
+	// ConflictDetectionMode is one of "disabled", "warn" or "reject".
+	ConflictDetectionMode string
+
+	// ConflictDetectionPendingTimeout sets the time after which the writes of
+	// an endorsed transaction that has not been committed are forgotten.
+	ConflictDetectionPendingTimeout time.Duration
+
 	// ----- TLS -----
 	// Require server-side TLS.
 	// TODO: create separate sub-struct for PeerTLS config.
@@ -154,6 +171,33 @@ type Config struct {
 	// VMNetworkMode sets the networking mode for the container.
 	VMNetworkMode string
 
+	// ----- vm.kubernetes -----
+
+	// VMKubernetesEnabled enables/disables running chaincode in Kubernetes pods.
+	VMKubernetesEnabled bool
+	// VMKubernetesAPIServer is the URL of the Kubernetes API server. The
+	// service account of the peer is used when it is not set.
+	VMKubernetesAPIServer string
+	// VMKubernetesNamespace is the namespace the chaincode pods are created in.
+	VMKubernetesNamespace string
+	// VMKubernetesTokenFile is the path to the bearer token used to
+	// authenticate to the API server.
+	VMKubernetesTokenFile string
+	// VMKubernetesCAFile is the path to the PEM encoded CA certificate of the
+	// API server.
+	VMKubernetesCAFile string
+	// VMKubernetesServiceAccount is the service account the chaincode pods run as.
+	VMKubernetesServiceAccount string
+	// VMKubernetesImagePullPolicy is the pull policy of the chaincode images.
+	VMKubernetesImagePullPolicy string
+	// VMKubernetesRegistry is the registry the chaincode images are pushed to.
+	VMKubernetesRegistry         string
+	VMKubernetesRegistryUsername string
+	VMKubernetesRegistryPassword string
+	// VMKubernetesAttachStdout enables/disables mirroring the output of the
+	// chaincode pods to the peer log.
+	VMKubernetesAttachStdout bool
+
 	// ChaincodePull enables/disables force pulling of the base docker image.
 	ChaincodePull bool
 	// ExternalBuilders represents the builders and launchers for
@@ -241,7 +285,13 @@ func (c *Config) load() error {
 	c.PeerTLSEnabled = viper.GetBool("peer.tls.enabled")
 	c.NetworkID = viper.GetString("peer.networkId")
 	c.LimitsConcurrencyEndorserService = viper.GetInt("peer.limits.concurrency.endorserService")
+	c.LimitsConcurrencyEndorserBatch = viper.GetInt("peer.limits.concurrency.endorserBatch")
 	c.LimitsConcurrencyDeliverService = viper.GetInt("peer.limits.concurrency.deliverService")
+	c.ConflictDetectionMode = viper.GetString("peer.conflictDetection.mode")
+	if c.ConflictDetectionMode == "" {
+		c.ConflictDetectionMode = "disabled"
+	}
+	c.ConflictDetectionPendingTimeout = viper.GetDuration("peer.conflictDetection.pendingTimeout")
 	c.DiscoveryEnabled = viper.GetBool("peer.discovery.enabled")
 	c.ProfileEnabled = viper.GetBool("peer.profile.enabled")
 	c.ProfileListenAddress = viper.GetString("peer.profile.listenAddress")
@@ -274,6 +324,18 @@ func (c *Config) load() error {
 		c.VMNetworkMode = "host"
 	}
 
+	c.VMKubernetesEnabled = viper.GetBool("vm.kubernetes.enabled")
+	c.VMKubernetesAPIServer = viper.GetString("vm.kubernetes.apiServer")
+	c.VMKubernetesNamespace = viper.GetString("vm.kubernetes.namespace")
+	c.VMKubernetesTokenFile = config.GetPath("vm.kubernetes.tokenFile")
+	c.VMKubernetesCAFile = config.GetPath("vm.kubernetes.caFile")
+	c.VMKubernetesServiceAccount = viper.GetString("vm.kubernetes.serviceAccount")
+	c.VMKubernetesImagePullPolicy = viper.GetString("vm.kubernetes.imagePullPolicy")
+	c.VMKubernetesRegistry = viper.GetString("vm.kubernetes.registry.address")
+	c.VMKubernetesRegistryUsername = viper.GetString("vm.kubernetes.registry.username")
+	c.VMKubernetesRegistryPassword = viper.GetString("vm.kubernetes.registry.password")
+	c.VMKubernetesAttachStdout = viper.GetBool("vm.kubernetes.attachStdout")
+
 	c.ChaincodePull = viper.GetBool("chaincode.pull")
 	var externalBuilders []ExternalBuilder
 	err = viper.UnmarshalKey("chaincode.externalBuilders", &externalBuilders)
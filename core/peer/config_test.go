@@ -226,7 +226,10 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.tls.enabled", "false")
 	viper.Set("peer.networkId", "testNetwork")
 	viper.Set("peer.limits.concurrency.endorserService", 2500)
+	viper.Set("peer.limits.concurrency.endorserBatch", 16)
 	viper.Set("peer.limits.concurrency.deliverService", 2500)
+	viper.Set("peer.conflictDetection.mode", "warn")
+	viper.Set("peer.conflictDetection.pendingTimeout", "30s")
 	viper.Set("peer.discovery.enabled", true)
 	viper.Set("peer.profile.enabled", false)
 	viper.Set("peer.profile.listenAddress", "peer.authentication.timewindow")
@@ -245,6 +248,17 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("vm.docker.tls.cert.file", "test/vm/tls/cert/file")
 	viper.Set("vm.docker.tls.key.file", "test/vm/tls/key/file")
 	viper.Set("vm.docker.tls.ca.file", "test/vm/tls/ca/file")
+	viper.Set("vm.kubernetes.enabled", true)
+	viper.Set("vm.kubernetes.apiServer", "https://kubernetes.example.com:6443")
+	viper.Set("vm.kubernetes.namespace", "fabric")
+	viper.Set("vm.kubernetes.tokenFile", "test/vm/kubernetes/token")
+	viper.Set("vm.kubernetes.caFile", "test/vm/kubernetes/ca.crt")
+	viper.Set("vm.kubernetes.serviceAccount", "chaincode")
+	viper.Set("vm.kubernetes.imagePullPolicy", "Always")
+	viper.Set("vm.kubernetes.registry.address", "registry.example.com/fabric")
+	viper.Set("vm.kubernetes.registry.username", "user")
+	viper.Set("vm.kubernetes.registry.password", "password")
+	viper.Set("vm.kubernetes.attachStdout", true)
 
 	viper.Set("operations.listenAddress", "127.0.0.1:9443")
 	viper.Set("operations.tls.enabled", false)
@@ -283,7 +297,10 @@ func TestGlobalConfig(t *testing.T) {
 		PeerID:                                "testPeerID",
 		NetworkID:                             "testNetwork",
 		LimitsConcurrencyEndorserService:      2500,
+		LimitsConcurrencyEndorserBatch:        16,
 		LimitsConcurrencyDeliverService:       2500,
+		ConflictDetectionMode:                 "warn",
+		ConflictDetectionPendingTimeout:       30 * time.Second,
 		DiscoveryEnabled:                      true,
 		ProfileEnabled:                        false,
 		ProfileListenAddress:                  "peer.authentication.timewindow",
@@ -301,6 +318,18 @@ func TestGlobalConfig(t *testing.T) {
 		VMDockerAttachStdout: false,
 		VMNetworkMode:        "TestingHost",
 
+		VMKubernetesEnabled:          true,
+		VMKubernetesAPIServer:        "https://kubernetes.example.com:6443",
+		VMKubernetesNamespace:        "fabric",
+		VMKubernetesTokenFile:        filepath.Join(cwd, "test/vm/kubernetes/token"),
+		VMKubernetesCAFile:           filepath.Join(cwd, "test/vm/kubernetes/ca.crt"),
+		VMKubernetesServiceAccount:   "chaincode",
+		VMKubernetesImagePullPolicy:  "Always",
+		VMKubernetesRegistry:         "registry.example.com/fabric",
+		VMKubernetesRegistryUsername: "user",
+		VMKubernetesRegistryPassword: "password",
+		VMKubernetesAttachStdout:     true,
+
 		ChaincodePull: false,
 		ExternalBuilders: []ExternalBuilder{
 			{
@@ -347,6 +376,7 @@ func TestGlobalConfigDefault(t *testing.T) {
 		AuthenticationTimeWindow:      15 * time.Minute,
 		PeerAddress:                   "localhost:8080",
 		ValidatorPoolSize:             runtime.NumCPU(),
+		ConflictDetectionMode:         "disabled",
 		VMNetworkMode:                 "host",
 		DeliverClientKeepaliveOptions: comm.DefaultKeepaliveOptions,
 	}
@@ -382,6 +412,7 @@ func TestPropagateEnvironment(t *testing.T) {
 		AuthenticationTimeWindow:      15 * time.Minute,
 		PeerAddress:                   "localhost:8080",
 		ValidatorPoolSize:             runtime.NumCPU(),
+		ConflictDetectionMode:         "disabled",
 		VMNetworkMode:                 "host",
 		DeliverClientKeepaliveOptions: comm.DefaultKeepaliveOptions,
 		ExternalBuilders: []ExternalBuilder{
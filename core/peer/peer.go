@@ -182,6 +182,10 @@ type Peer struct {
 	OrdererEndpointOverrides map[string]*orderers.Endpoint
 	CryptoProvider           bccsp.BCCSP
 
+	// BlockCommitListeners are notified of the blocks committed to the
+	// ledgers of the channels.
+	BlockCommitListeners []committer.BlockCommitListener
+
 	// validationWorkersSemaphore is used to limit the number of concurrent validation
 	// go routines.
 	validationWorkersSemaphore semaphore.Semaphore
@@ -224,6 +228,26 @@ func (p *Peer) CreateChannel(
 	return nil
 }
 
+// CreateChannelFromSnapshot creates a channel from the specified snapshot.
+func (p *Peer) CreateChannelFromSnapshot(
+	snapshotDir string,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	legacyLifecycleValidation plugindispatcher.LifecycleResources,
+	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
+) error {
+	l, cid, err := p.LedgerMgr.CreateLedgerFromSnapshot(snapshotDir)
+	if err != nil {
+		return errors.WithMessage(err, "cannot create ledger from snapshot")
+	}
+
+	if err := p.createChannel(cid, l, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation); err != nil {
+		return err
+	}
+
+	p.initChannel(cid)
+	return nil
+}
+
 // retrievePersistedChannelConfig retrieves the persisted channel config from statedb
 func retrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, error) {
 	qe, err := ledger.NewQueryExecutor()
@@ -324,7 +348,7 @@ func (p *Peer) createChannel(
 		channel.bundleUpdate,
 	)
 
-	committer := committer.NewLedgerCommitter(l)
+	committer := committer.NewLedgerCommitterWithListeners(l, cid, p.BlockCommitListeners...)
 	validator := &txvalidator.ValidationRouter{
 		CapabilityProvider: channel,
 		V14Validator: validatorv14.NewTxValidator(
@@ -360,6 +384,11 @@ func (p *Peer) createChannel(
 	}
 	channel.store = store
 
+	var isBFT bool
+	if oc, ok := bundle.OrdererConfig(); ok {
+		isBFT = oc.ConsensusType() == "BFT"
+	}
+
 	simpleCollectionStore := privdata.NewSimpleCollectionStore(l, deployedCCInfoProvider)
 	p.GossipService.InitializeChannel(bundle.ConfigtxValidator().ChannelID(), ordererSource, store, gossipservice.Support{
 		Validator:       validator,
@@ -369,6 +398,7 @@ func (p *Peer) createChannel(
 			return mspmgmt.GetManagerForChain(chainID)
 		}),
 		CapabilityProvider: channel,
+		IsBFT:              isBFT,
 	})
 
 	p.mutex.Lock()
@@ -473,6 +503,16 @@ func (p *Peer) GetApplicationConfig(cid string) (channelconfig.Application, bool
 	return cc.ApplicationConfig()
 }
 
+// GetOrdererConfig returns the orderer configuration of the channel with channel ID.
+func (p *Peer) GetOrdererConfig(cid string) (channelconfig.Orderer, bool) {
+	cc := p.GetChannelConfig(cid)
+	if cc == nil {
+		return nil, false
+	}
+
+	return cc.OrdererConfig()
+}
+
 // Initialize sets up any channels that the peer has from the persistence. This
 // function should be called at the start up when the ledger and gossip
 // ready
@@ -33,6 +33,10 @@ type PolicyChecker interface {
 	// CheckPolicyNoChannel checks that the passed signed proposal is valid with the respect to
 	// passed policy on the local MSP.
 	CheckPolicyNoChannel(policyName string, signedProp *pb.SignedProposal) error
+
+	// CheckPolicyNoChannelBySignedData checks that the passed signed data is valid with the respect to
+	// passed policy on the local MSP.
+	CheckPolicyNoChannelBySignedData(policyName string, sd []*protoutil.SignedData) error
 }
 
 type policyChecker struct {
@@ -140,6 +144,45 @@ func (p *policyChecker) CheckPolicyNoChannel(policyName string, signedProp *pb.S
 	return id.Verify(signedProp.ProposalBytes, signedProp.Signature)
 }
 
+// CheckPolicyNoChannelBySignedData checks that the passed signed data is valid with the respect to
+// passed policy on the local MSP.
+func (p *policyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*protoutil.SignedData) error {
+	if policyName == "" {
+		return errors.New("Invalid policy name during channelless check policy on signed data. Name must be different from nil.")
+	}
+
+	if len(sd) == 0 {
+		return fmt.Errorf("Invalid signed data during channelless check policy with policy [%s]", policyName)
+	}
+
+	// Load MSPPrincipal for policy
+	principal, err := p.principalGetter.Get(policyName)
+	if err != nil {
+		return fmt.Errorf("Failed getting local MSP principal during channelless check policy with policy [%s]: [%s]", policyName, err)
+	}
+
+	for _, data := range sd {
+		// Deserialize the signer with the local MSP
+		id, err := p.localMSP.DeserializeIdentity(data.Identity)
+		if err != nil {
+			return fmt.Errorf("Failed deserializing signer during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+
+		// Verify that the signer satisfies the principal
+		err = id.SatisfiesPrincipal(principal)
+		if err != nil {
+			return fmt.Errorf("Failed verifying that the signer satisfies local MSP principal during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+
+		// Verify the signature
+		if err := id.Verify(data.Data, data.Signature); err != nil {
+			return fmt.Errorf("Failed verifying the signature during channelless check policy with policy [%s]: [%s]", policyName, err)
+		}
+	}
+
+	return nil
+}
+
 // CheckPolicyBySignedData checks that the passed signed data is valid with the respect to
 // passed policy on the passed channel.
 func (p *policyChecker) CheckPolicyBySignedData(channelID, policyName string, sd []*protoutil.SignedData) error {
@@ -190,3 +190,45 @@ func TestPolicyChecker(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Failed deserializing proposal creator during channelless check policy with policy [Members]: [Invalid Identity]")
 }
+
+func TestPolicyCheckerNoChannelBySignedData(t *testing.T) {
+	identityDeserializer := &mocks.MockIdentityDeserializer{
+		Identity: []byte("Alice"),
+		Msg:      []byte("msg1"),
+	}
+	pc := NewPolicyChecker(
+		&mocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&mocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+
+	err := pc.CheckPolicyNoChannelBySignedData("", []*protoutil.SignedData{{}})
+	require.EqualError(t, err, "Invalid policy name during channelless check policy on signed data. Name must be different from nil.")
+
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Admins, nil)
+	require.EqualError(t, err, "Invalid signed data during channelless check policy with policy [Admins]")
+
+	// Alice is a member of the local MSP and signed the data, policy check must succeed
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Admins, []*protoutil.SignedData{{
+		Data:      []byte("msg1"),
+		Identity:  []byte("Alice"),
+		Signature: []byte("msg1"),
+	}})
+	require.NoError(t, err)
+
+	// Bob is not a member of the local MSP, policy check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Admins, []*protoutil.SignedData{{
+		Data:      []byte("msg1"),
+		Identity:  []byte("Bob"),
+		Signature: []byte("msg1"),
+	}})
+	require.EqualError(t, err, "Failed deserializing signer during channelless check policy with policy [Admins]: [Invalid Identity]")
+
+	// Alice did not sign the data, policy check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Admins, []*protoutil.SignedData{{
+		Data:      []byte("msg2"),
+		Identity:  []byte("Alice"),
+		Signature: []byte("msg2"),
+	}})
+	require.EqualError(t, err, "Failed verifying the signature during channelless check policy with policy [Admins]: [Invalid Signature]")
+}
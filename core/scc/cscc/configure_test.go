@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -249,6 +250,31 @@ func TestConfigerInvokeJoinChainWrongParams(t *testing.T) {
 	)
 }
 
+func TestConfigerInvokeJoinChainBySnapshotWrongParams(t *testing.T) {
+	mockACLProvider := &mocks.ACLProvider{}
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	cscc := &PeerConfiger{
+		aclProvider: mockACLProvider,
+		bccsp:       cryptoProvider,
+	}
+	mockStub := &mocks.ChaincodeStub{}
+	mockStub.GetSignedProposalReturns(validSignedProposal(), nil)
+
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshot"), {}})
+	res := cscc.Invoke(mockStub)
+	require.NotEqual(t, int32(shim.OK), res.Status)
+	require.Equal(t, "Cannot join the channel, no snapshot directory provided", res.Message)
+
+	mockACLProvider.CheckACLReturns(errors.New("Failed authorization"))
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshot"), []byte("snapshotDir")})
+	res = cscc.Invoke(mockStub)
+	require.NotEqual(t, int32(shim.OK), res.Status)
+	require.Equal(t, "access denied for [JoinChainBySnapshot]: [Failed authorization]", res.Message)
+	resource, _, _ := mockACLProvider.CheckACLArgsForCall(0)
+	require.Equal(t, resources.Cscc_JoinChainBySnapshot, resource)
+}
+
 func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	viper.Set("chaincode.executetimeout", "3s")
 
@@ -277,7 +303,7 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 
 	signer := mgmt.GetLocalSigningIdentityOrPanic(cryptoProvider)
 
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), nil, cryptoProvider)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager(cryptoProvider))
 	var defaultSecureDialOpts = func() []grpc.DialOption {
 		return []grpc.DialOption{grpc.WithInsecure()}
@@ -0,0 +1,374 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package xcc implements the system chaincode that commits the cross-channel
+// transactions. A chaincode invoked on another channel than the one of the
+// proposal records its writes in a PREPARED cross-channel transaction on the
+// channel of the proposal, the coordinator. Each participant channel then
+// prepares the transaction with its own writes, and the coordinator commits
+// it once all the participants are prepared. The participants follow the
+// outcome recorded on the coordinator.
+package xcc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ApplicationConfigRetriever gets the application configuration of a channel.
+type ApplicationConfigRetriever interface {
+	GetApplicationConfig(cid string) (channelconfig.Application, bool)
+}
+
+// New returns an instance of XCC.
+// Typically this is called once per peer.
+func New(aclProvider aclmgmt.ACLProvider, ledgers LedgerGetter, appConfig ApplicationConfigRetriever) *SCC {
+	return &SCC{
+		aclProvider: aclProvider,
+		ledgers:     ledgers,
+		appConfig:   appConfig,
+	}
+}
+
+func (s *SCC) Name() string              { return crosschannel.Namespace }
+func (s *SCC) Chaincode() shim.Chaincode { return s }
+
+// SCC implements the functions that drive a cross-channel transaction to its outcome:
+// - Prepare stages the writes of a participant channel
+// - Commit commits the transaction on the coordinator once all the participants are
+//   prepared, and then on each participant
+// - Abort aborts the transaction on the coordinator, and then on each participant
+// - GetTransaction returns the record of a cross-channel transaction
+type SCC struct {
+	aclProvider aclmgmt.ACLProvider
+	ledgers     LedgerGetter
+	appConfig   ApplicationConfigRetriever
+}
+
+var logger = flogging.MustGetLogger("xcc")
+
+// These are function names from Invoke first parameter
+const (
+	Prepare        string = "Prepare"
+	Commit         string = "Commit"
+	Abort          string = "Abort"
+	GetTransaction string = "GetTransaction"
+)
+
+// Init is called once per chain when the chain is created.
+func (s *SCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	logger.Info("Init XCC")
+
+	return shim.Success(nil)
+}
+
+// Invoke is called with args[0] contains the function name and args[1] the ID of the
+// cross-channel transaction. The transaction is driven on the channel of the proposal.
+// # Prepare: args[2] contains the coordinator channel of the transaction
+// # Commit: no additional argument
+// # Abort: no additional argument
+// # GetTransaction: Return the record of the transaction marshalled in bytes
+func (s *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) < 2 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+	}
+
+	fname := string(args[0])
+	txID := string(args[1])
+	channelID := stub.GetChannelID()
+
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed getting signed proposal from stub, %s: %s", channelID, err))
+	}
+
+	name, err := protoutil.InvokedChaincodeName(sp.ProposalBytes)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to identify the called chaincode: %s", err))
+	}
+
+	if name != s.Name() {
+		return shim.Error(fmt.Sprintf("Rejecting invoke of XCC from another chaincode, original invocation for '%s'", name))
+	}
+
+	if !s.crossChannelTransactions(channelID) {
+		return shim.Error(fmt.Sprintf("cross-channel transactions are not enabled on channel %s", channelID))
+	}
+
+	if err = s.aclProvider.CheckACL(resources.Peer_Propose, channelID, sp); err != nil {
+		return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, channelID, err))
+	}
+
+	logger.Debugf("Invoke function: %s of cross-channel transaction %s on channel: %s", fname, txID, channelID)
+
+	switch fname {
+	case Prepare:
+		if len(args) < 3 {
+			return shim.Error(fmt.Sprintf("missing 3rd argument for %s", fname))
+		}
+		err = s.prepare(stub, txID, string(args[2]))
+	case Commit:
+		err = s.commit(stub, txID)
+	case Abort:
+		err = s.abort(stub, txID)
+	case GetTransaction:
+		return s.getTransaction(stub, txID)
+	default:
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
+	}
+
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(nil)
+}
+
+// prepare stages, on the channel of the proposal, the writes recorded for this channel
+// by the coordinator of the transaction
+func (s *SCC) prepare(stub shim.ChaincodeStubInterface, txID, coordinator string) error {
+	channelID := stub.GetChannelID()
+	if coordinator == channelID {
+		return errors.Errorf("cross-channel transaction %s is prepared by its proposal on the coordinator channel %s", txID, coordinator)
+	}
+	record, err := getRecord(stub, txID)
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		return errors.Errorf("cross-channel transaction %s is already %s on channel %s", txID, record.State, channelID)
+	}
+
+	coordinatorRecord, err := s.getRecordOnChannel(coordinator, txID)
+	if err != nil {
+		return err
+	}
+	if coordinatorRecord == nil || coordinatorRecord.State != crosschannel.State_PREPARED || coordinatorRecord.Coordinator != coordinator {
+		return errors.Errorf("cross-channel transaction %s is not prepared on channel %s", txID, coordinator)
+	}
+	if err := s.errorIfPastDeadline(coordinatorRecord); err != nil {
+		return err
+	}
+	var rwset []byte
+	for _, participant := range coordinatorRecord.ParticipantRwsets {
+		if participant.Channel == channelID {
+			rwset = participant.Rwset
+		}
+	}
+	if rwset == nil {
+		return errors.Errorf("channel %s is not a participant of cross-channel transaction %s", channelID, txID)
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(rwset); err != nil {
+		return errors.WithMessagef(err, "invalid writes of cross-channel transaction %s", txID)
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		for _, w := range nsRWSet.KvRwSet.Writes {
+			lock, err := stub.GetState(crosschannel.LockKey(nsRWSet.NameSpace, w.Key))
+			if err != nil {
+				return errors.WithMessage(err, "failed reading the lock of the key")
+			}
+			if lock != nil {
+				return errors.Errorf("key %s of namespace %s is locked by cross-channel transaction %s", w.Key, nsRWSet.NameSpace, lock)
+			}
+		}
+	}
+
+	return putRecord(stub, &crosschannel.Transaction{
+		Id:            txID,
+		Coordinator:   coordinator,
+		Participants:  coordinatorRecord.Participants,
+		TimeoutBlocks: coordinatorRecord.TimeoutBlocks,
+		Deadline:      coordinatorRecord.Deadline,
+		Creator:       coordinatorRecord.Creator,
+		State:         crosschannel.State_PREPARED,
+		Rwset:         rwset,
+	})
+}
+
+// commit commits the transaction on the coordinator once all the participants are prepared,
+// and on a participant once the transaction is committed on the coordinator
+func (s *SCC) commit(stub shim.ChaincodeStubInterface, txID string) error {
+	record, err := getPreparedRecord(stub, txID)
+	if err != nil {
+		return err
+	}
+
+	if record.Coordinator == stub.GetChannelID() {
+		if err := s.errorIfPastDeadline(record); err != nil {
+			return err
+		}
+		for _, participant := range record.Participants {
+			participantRecord, err := s.getRecordOnChannel(participant, txID)
+			if err != nil {
+				return err
+			}
+			if participantRecord == nil || participantRecord.State != crosschannel.State_PREPARED {
+				return errors.Errorf("cross-channel transaction %s is not prepared on channel %s", txID, participant)
+			}
+		}
+	} else {
+		coordinatorRecord, err := s.getRecordOnChannel(record.Coordinator, txID)
+		if err != nil {
+			return err
+		}
+		if coordinatorRecord == nil || coordinatorRecord.State != crosschannel.State_COMMITTED {
+			return errors.Errorf("cross-channel transaction %s is not committed on channel %s", txID, record.Coordinator)
+		}
+	}
+
+	record.State = crosschannel.State_COMMITTED
+	return putRecord(stub, record)
+}
+
+// abort aborts the transaction on the coordinator, on behalf of its creator or once its deadline
+// is passed, and on a participant once the transaction is aborted on the coordinator
+func (s *SCC) abort(stub shim.ChaincodeStubInterface, txID string) error {
+	record, err := getPreparedRecord(stub, txID)
+	if err != nil {
+		return err
+	}
+
+	if record.Coordinator == stub.GetChannelID() {
+		creator, err := stub.GetCreator()
+		if err != nil {
+			return errors.WithMessage(err, "failed getting the creator of the proposal")
+		}
+		passed, err := s.deadlinePassed(record)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(creator, record.Creator) && !passed {
+			return errors.Errorf("cross-channel transaction %s can only be aborted by its creator before its deadline", txID)
+		}
+	} else {
+		coordinatorRecord, err := s.getRecordOnChannel(record.Coordinator, txID)
+		if err != nil {
+			return err
+		}
+		if coordinatorRecord == nil || coordinatorRecord.State != crosschannel.State_ABORTED {
+			return errors.Errorf("cross-channel transaction %s is not aborted on channel %s", txID, record.Coordinator)
+		}
+	}
+
+	record.State = crosschannel.State_ABORTED
+	return putRecord(stub, record)
+}
+
+func (s *SCC) getTransaction(stub shim.ChaincodeStubInterface, txID string) pb.Response {
+	recordBytes, err := stub.GetState(crosschannel.TxKey(txID))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get cross-channel transaction %s: %s", txID, err))
+	}
+	if recordBytes == nil {
+		return shim.Error(fmt.Sprintf("cross-channel transaction %s not found", txID))
+	}
+	return shim.Success(recordBytes)
+}
+
+func (s *SCC) crossChannelTransactions(channelID string) bool {
+	ac, ok := s.appConfig.GetApplicationConfig(channelID)
+	return ok && ac.Capabilities().CrossChannelTransactions()
+}
+
+// getRecordOnChannel returns the committed record of the transaction on another channel
+func (s *SCC) getRecordOnChannel(channelID, txID string) (*crosschannel.Transaction, error) {
+	l := s.ledgers.GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting a query executor for channel %s", channelID)
+	}
+	defer qe.Done()
+	recordBytes, err := qe.GetState(crosschannel.Namespace, crosschannel.TxKey(txID))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting cross-channel transaction %s on channel %s", txID, channelID)
+	}
+	if recordBytes == nil {
+		return nil, nil
+	}
+	return crosschannel.UnmarshalTransaction(recordBytes)
+}
+
+func getRecord(stub shim.ChaincodeStubInterface, txID string) (*crosschannel.Transaction, error) {
+	recordBytes, err := stub.GetState(crosschannel.TxKey(txID))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting cross-channel transaction %s", txID)
+	}
+	if recordBytes == nil {
+		return nil, nil
+	}
+	return crosschannel.UnmarshalTransaction(recordBytes)
+}
+
+func getPreparedRecord(stub shim.ChaincodeStubInterface, txID string) (*crosschannel.Transaction, error) {
+	record, err := getRecord(stub, txID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, errors.Errorf("cross-channel transaction %s not found on channel %s", txID, stub.GetChannelID())
+	}
+	if record.State != crosschannel.State_PREPARED {
+		return nil, errors.Errorf("cross-channel transaction %s is already %s on channel %s", txID, record.State, stub.GetChannelID())
+	}
+	return record, nil
+}
+
+func putRecord(stub shim.ChaincodeStubInterface, record *crosschannel.Transaction) error {
+	recordBytes, err := proto.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed marshaling cross-channel transaction %s", record.Id)
+	}
+	return stub.PutState(crosschannel.TxKey(record.Id), recordBytes)
+}
+
+func (s *SCC) errorIfPastDeadline(record *crosschannel.Transaction) error {
+	passed, err := s.deadlinePassed(record)
+	if err != nil {
+		return err
+	}
+	if passed {
+		return errors.Errorf("the deadline of cross-channel transaction %s is passed", record.Id)
+	}
+	return nil
+}
+
+// deadlinePassed returns true if the next block of the coordinator channel is past the deadline
+// of the transaction. The deadline is also enforced by the validation of the commit on the
+// coordinator channel, this only avoids endorsing a transaction that would be invalid.
+func (s *SCC) deadlinePassed(record *crosschannel.Transaction) (bool, error) {
+	l := s.ledgers.GetLedger(record.Coordinator)
+	if l == nil {
+		return false, errors.Errorf("channel %s not found", record.Coordinator)
+	}
+	info, err := l.GetBlockchainInfo()
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed getting the height of channel %s", record.Coordinator)
+	}
+	return info.Height > record.Deadline, nil
+}
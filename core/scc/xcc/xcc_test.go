@@ -0,0 +1,363 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package xcc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/crosschannel"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/scc/xcc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/acl_provider.go --fake-name ACLProvider . aclProvider
+type aclProvider interface {
+	aclmgmt.ACLProvider
+}
+
+//go:generate counterfeiter -o mock/ledger_getter.go --fake-name LedgerGetter . ledgerGetter
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+//go:generate counterfeiter -o mock/peer_ledger.go --fake-name PeerLedger . peerLedger
+type peerLedger interface {
+	ledger.PeerLedger
+}
+
+//go:generate counterfeiter -o mock/query_executor.go --fake-name QueryExecutor . queryExecutor
+type queryExecutor interface {
+	ledger.QueryExecutor
+}
+
+//go:generate counterfeiter -o mock/application_config_retriever.go --fake-name ApplicationConfigRetriever . applicationConfigRetriever
+type applicationConfigRetriever interface {
+	ApplicationConfigRetriever
+}
+
+//go:generate counterfeiter -o mock/application_config.go --fake-name ApplicationConfig . applicationConfig
+type applicationConfig interface {
+	channelconfig.Application
+}
+
+//go:generate counterfeiter -o mock/application_capabilities.go --fake-name ApplicationCapabilities . applicationCapabilities
+type applicationCapabilities interface {
+	channelconfig.ApplicationCapabilities
+}
+
+type testEnv struct {
+	aclProvider  *mock.ACLProvider
+	capabilities *mock.ApplicationCapabilities
+	stubs        map[string]*shimtest.MockStub
+	heights      map[string]uint64
+}
+
+// newTestEnv returns an XCC deployed on the given channels, the state of each
+// channel is the state of its mock stub and its height is 10 unless set in heights
+func newTestEnv(t *testing.T, channels ...string) *testEnv {
+	env := &testEnv{
+		aclProvider:  &mock.ACLProvider{},
+		capabilities: &mock.ApplicationCapabilities{},
+		stubs:        map[string]*shimtest.MockStub{},
+		heights:      map[string]uint64{},
+	}
+	env.capabilities.CrossChannelTransactionsReturns(true)
+	appConfig := &mock.ApplicationConfig{}
+	appConfig.CapabilitiesReturns(env.capabilities)
+	appConfigRetriever := &mock.ApplicationConfigRetriever{}
+	appConfigRetriever.GetApplicationConfigReturns(appConfig, true)
+
+	ledgers := &mock.LedgerGetter{}
+	ledgers.GetLedgerStub = func(channelID string) ledger.PeerLedger {
+		stub, ok := env.stubs[channelID]
+		if !ok {
+			return nil
+		}
+		qe := &mock.QueryExecutor{}
+		qe.GetStateStub = func(namespace, key string) ([]byte, error) {
+			require.Equal(t, crosschannel.Namespace, namespace)
+			return stub.State[key], nil
+		}
+		l := &mock.PeerLedger{}
+		l.NewQueryExecutorReturns(qe, nil)
+		l.GetBlockchainInfoStub = func() (*cb.BlockchainInfo, error) {
+			height, ok := env.heights[channelID]
+			if !ok {
+				height = 10
+			}
+			return &cb.BlockchainInfo{Height: height}, nil
+		}
+		return l
+	}
+
+	scc := New(env.aclProvider, ledgers, appConfigRetriever)
+	for _, channelID := range channels {
+		stub := shimtest.NewMockStub("xcc", scc)
+		stub.ChannelID = channelID
+		stub.Creator = []byte("creator")
+		env.stubs[channelID] = stub
+	}
+	return env
+}
+
+func (env *testEnv) invoke(channelID string, args ...string) pb.Response {
+	prop, _ := protoutil.MockSignedEndorserProposalOrPanic(
+		channelID,
+		&pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "xcc"}},
+		[]byte("creator"),
+		nil,
+	)
+	var byteArgs [][]byte
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+	return env.stubs[channelID].MockInvokeWithSignedProposal("tx-id", byteArgs, prop)
+}
+
+func (env *testEnv) putRecord(t *testing.T, channelID string, record *crosschannel.Transaction) {
+	env.stubs[channelID].State[crosschannel.TxKey(record.Id)] = protoutil.MarshalOrPanic(record)
+}
+
+func (env *testEnv) getRecord(t *testing.T, channelID, txID string) *crosschannel.Transaction {
+	res := env.invoke(channelID, GetTransaction, txID)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	record, err := crosschannel.UnmarshalTransaction(res.Payload)
+	require.NoError(t, err)
+	return record
+}
+
+func participantRWSet(t *testing.T) []byte {
+	b := rwsetutil.NewRWSetBuilder()
+	b.AddToWriteSet("cc", "key", []byte("value"))
+	rwset, err := b.GetTxReadWriteSet().ToProtoBytes()
+	require.NoError(t, err)
+	return rwset
+}
+
+func coordinatorRecord(t *testing.T, deadline uint64) *crosschannel.Transaction {
+	return &crosschannel.Transaction{
+		Id:                "xtx",
+		Coordinator:       "coordinator",
+		Participants:      []string{"participant"},
+		Deadline:          deadline,
+		Creator:           []byte("creator"),
+		State:             crosschannel.State_PREPARED,
+		ParticipantRwsets: []*crosschannel.ParticipantRwSet{{Channel: "participant", Rwset: participantRWSet(t)}},
+	}
+}
+
+func TestInit(t *testing.T) {
+	env := newTestEnv(t, "coordinator")
+	res := env.stubs["coordinator"].MockInit("1", nil)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+}
+
+func TestCommit(t *testing.T) {
+	env := newTestEnv(t, "coordinator", "participant")
+	env.putRecord(t, "coordinator", coordinatorRecord(t, 100))
+
+	res := env.invoke("coordinator", Commit, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx is not prepared on channel participant", res.Message)
+
+	res = env.invoke("participant", Commit, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx not found on channel participant", res.Message)
+
+	res = env.invoke("participant", Prepare, "xtx", "coordinator")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	record := env.getRecord(t, "participant", "xtx")
+	require.Equal(t, crosschannel.State_PREPARED, record.State)
+	require.Equal(t, "coordinator", record.Coordinator)
+	require.Equal(t, participantRWSet(t), record.Rwset)
+
+	res = env.invoke("participant", Prepare, "xtx", "coordinator")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx is already PREPARED on channel participant", res.Message)
+
+	res = env.invoke("participant", Commit, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx is not committed on channel coordinator", res.Message)
+
+	res = env.invoke("coordinator", Commit, "xtx")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.Equal(t, crosschannel.State_COMMITTED, env.getRecord(t, "coordinator", "xtx").State)
+
+	res = env.invoke("participant", Commit, "xtx")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.Equal(t, crosschannel.State_COMMITTED, env.getRecord(t, "participant", "xtx").State)
+
+	res = env.invoke("participant", Abort, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx is already COMMITTED on channel participant", res.Message)
+}
+
+func TestCommitAfterDeadline(t *testing.T) {
+	env := newTestEnv(t, "coordinator", "participant")
+	record := coordinatorRecord(t, 9)
+	env.putRecord(t, "coordinator", record)
+	record.Rwset = participantRWSet(t)
+	env.putRecord(t, "participant", record)
+
+	res := env.invoke("coordinator", Commit, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "the deadline of cross-channel transaction xtx is passed", res.Message)
+}
+
+func TestAbort(t *testing.T) {
+	env := newTestEnv(t, "coordinator", "participant")
+	env.putRecord(t, "coordinator", coordinatorRecord(t, 100))
+	res := env.invoke("participant", Prepare, "xtx", "coordinator")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	res = env.invoke("participant", Abort, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx is not aborted on channel coordinator", res.Message)
+
+	env.stubs["coordinator"].Creator = []byte("someone-else")
+	res = env.invoke("coordinator", Abort, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx can only be aborted by its creator before its deadline", res.Message)
+
+	env.stubs["coordinator"].Creator = []byte("creator")
+	res = env.invoke("coordinator", Abort, "xtx")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.Equal(t, crosschannel.State_ABORTED, env.getRecord(t, "coordinator", "xtx").State)
+
+	res = env.invoke("participant", Abort, "xtx")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.Equal(t, crosschannel.State_ABORTED, env.getRecord(t, "participant", "xtx").State)
+
+	// anyone can abort once the deadline is passed
+	env = newTestEnv(t, "coordinator")
+	env.putRecord(t, "coordinator", coordinatorRecord(t, 9))
+	env.stubs["coordinator"].Creator = []byte("someone-else")
+	res = env.invoke("coordinator", Abort, "xtx")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+}
+
+func TestPrepareErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(env *testEnv, record *crosschannel.Transaction)
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "missing coordinator",
+			args:        []string{Prepare, "xtx"},
+			expectedErr: "missing 3rd argument for Prepare",
+		},
+		{
+			name:        "prepare on the coordinator",
+			args:        []string{Prepare, "xtx", "participant"},
+			expectedErr: "cross-channel transaction xtx is prepared by its proposal on the coordinator channel participant",
+		},
+		{
+			name:        "unknown coordinator",
+			args:        []string{Prepare, "xtx", "unknown"},
+			expectedErr: "channel unknown not found",
+		},
+		{
+			name: "not prepared on the coordinator",
+			setup: func(env *testEnv, record *crosschannel.Transaction) {
+				record.State = crosschannel.State_ABORTED
+			},
+			expectedErr: "cross-channel transaction xtx is not prepared on channel coordinator",
+		},
+		{
+			name: "deadline passed",
+			setup: func(env *testEnv, record *crosschannel.Transaction) {
+				record.Deadline = 9
+			},
+			expectedErr: "the deadline of cross-channel transaction xtx is passed",
+		},
+		{
+			name: "not a participant",
+			setup: func(env *testEnv, record *crosschannel.Transaction) {
+				record.ParticipantRwsets[0].Channel = "another-channel"
+			},
+			expectedErr: "channel participant is not a participant of cross-channel transaction xtx",
+		},
+		{
+			name: "locked key",
+			setup: func(env *testEnv, record *crosschannel.Transaction) {
+				env.stubs["participant"].State[crosschannel.LockKey("cc", "key")] = []byte("another-xtx")
+			},
+			expectedErr: "key key of namespace cc is locked by cross-channel transaction another-xtx",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, "coordinator", "participant")
+			record := coordinatorRecord(t, 100)
+			if tt.setup != nil {
+				tt.setup(env, record)
+			}
+			env.putRecord(t, "coordinator", record)
+			args := tt.args
+			if args == nil {
+				args = []string{Prepare, "xtx", "coordinator"}
+			}
+
+			res := env.invoke("participant", args...)
+			require.Equal(t, int32(shim.ERROR), res.Status)
+			require.Equal(t, tt.expectedErr, res.Message)
+			require.Nil(t, env.stubs["participant"].State[crosschannel.TxKey("xtx")])
+		})
+	}
+}
+
+func TestInvokeErrors(t *testing.T) {
+	env := newTestEnv(t, "coordinator")
+
+	res := env.invoke("coordinator", GetTransaction)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Incorrect number of arguments, 1", res.Message)
+
+	res = env.invoke("coordinator", "Unknown", "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Requested function Unknown not found.", res.Message)
+
+	res = env.invoke("coordinator", GetTransaction, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transaction xtx not found", res.Message)
+
+	env.aclProvider.CheckACLReturns(errors.New("no-soup-for-you"))
+	res = env.invoke("coordinator", GetTransaction, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "access denied for [GetTransaction][coordinator]: [no-soup-for-you]", res.Message)
+	resource, channelID, _ := env.aclProvider.CheckACLArgsForCall(1)
+	require.Equal(t, resources.Peer_Propose, resource)
+	require.Equal(t, "coordinator", channelID)
+
+	env.capabilities.CrossChannelTransactionsReturns(false)
+	res = env.invoke("coordinator", GetTransaction, "xtx")
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cross-channel transactions are not enabled on channel coordinator", res.Message)
+
+	prop, _ := protoutil.MockSignedEndorserProposalOrPanic(
+		"coordinator",
+		&pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "mycc"}},
+		[]byte("creator"),
+		nil,
+	)
+	res = env.stubs["coordinator"].MockInvokeWithSignedProposal("tx-id", [][]byte{[]byte(GetTransaction), []byte("xtx")}, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Rejecting invoke of XCC from another chaincode, original invocation for 'mycc'", res.Message)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -48,6 +49,13 @@ type RWSetScanner interface {
 	Close()
 }
 
+// PurgedKey identifies, by the hash of the key, a private data key of a collection that is purged
+type PurgedKey struct {
+	Namespace  string
+	Collection string
+	KeyHash    string
+}
+
 // EndorserPvtSimulationResults captures the details of the simulation results specific to an endorser
 type EndorserPvtSimulationResults struct {
 	ReceivedAtBlockHeight          uint64
@@ -80,7 +88,13 @@ type RwsetScanner struct {
 
 // NewStoreProvider instantiates TransientStoreProvider
 func NewStoreProvider(path string) (StoreProvider, error) {
-	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path})
+	return NewStoreProviderWithEncryption(path, nil)
+}
+
+// NewStoreProviderWithEncryption instantiates TransientStoreProvider that encrypts the private write sets
+// with the supplied encryptor. The private write sets are stored in clear if the encryptor is nil
+func NewStoreProviderWithEncryption(path string, encryptor *encryption.Encryptor) (StoreProvider, error) {
+	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: path, Encryptor: encryptor})
 	if err != nil {
 		return nil, err
 	}
@@ -265,6 +279,68 @@ func (s *Store) PurgeBelowHeight(maxBlockNumToRetain uint64) error {
 	return s.db.WriteBatch(dbBatch, true)
 }
 
+// PurgeByKeyHashes removes the collections that contain a write of any of the given private data keys from
+// the private write sets received at block height lesser than or equal to maxBlockHeight. A collection is removed
+// as a whole because a partial write set does not match the hash in the transaction. A private write set left
+// without any collection is removed along with its indexes. PurgeByKeyHashes() is expected to be called by
+// coordinator after committing a block that purges private data keys
+func (s *Store) PurgeByKeyHashes(maxBlockHeight uint64, keys []*PurgedKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	logger.Debugf("Purging [%d] private data keys from transient store received till block [%d]", len(keys), maxBlockHeight)
+
+	purgedKeys := make(map[PurgedKey]struct{})
+	for _, k := range keys {
+		purgedKeys[*k] = struct{}{}
+	}
+	isPurged := func(ns, coll, keyHash string) bool {
+		_, ok := purgedKeys[PurgedKey{Namespace: ns, Collection: coll, KeyHash: keyHash}]
+		return ok
+	}
+
+	startKey := createPurgeIndexByHeightRangeStartKey(0)
+	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockHeight)
+	iter, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	dbBatch := s.db.NewUpdateBatch()
+	for iter.Next() {
+		compositeKeyPurgeIndexByHeight := iter.Key()
+		txid, uuid, blockHeight, err := splitCompositeKeyOfPurgeIndexByHeight(compositeKeyPurgeIndexByHeight)
+		if err != nil {
+			return err
+		}
+		compositeKeyPvtRWSet := createCompositeKeyForPvtRWSet(txid, uuid, blockHeight)
+		dbVal, err := s.db.Get(compositeKeyPvtRWSet)
+		if err != nil {
+			return err
+		}
+		if dbVal == nil {
+			continue
+		}
+		updatedVal, removed, err := removeCollectionsWithPurgedKeys(dbVal, isPurged)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !removed:
+			continue
+		case updatedVal == nil:
+			logger.Debugf("Purging from transient store private data of txid [%s] uuid [%s] as all its collections contain purged keys", txid, uuid)
+			dbBatch.Delete(compositeKeyPvtRWSet)
+			dbBatch.Delete(createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight))
+			dbBatch.Delete(compositeKeyPurgeIndexByHeight)
+		default:
+			dbBatch.Put(compositeKeyPvtRWSet, updatedVal)
+		}
+	}
+	return s.db.WriteBatch(dbBatch, true)
+}
+
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
 func (s *Store) GetMinTransientBlkHt() (uint64, error) {
 	// Current approach performs a range query on purgeIndex with startKey
@@ -10,9 +10,13 @@ import (
 	"bytes"
 	"errors"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	commonutil "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 )
 
@@ -213,6 +217,72 @@ func trimPvtWSet(pvtWSet *rwset.TxPvtReadWriteSet, filter ledger.PvtNsCollFilter
 	return filteredTxPvtRwSet
 }
 
+// removeCollectionsWithPurgedKeys removes from the encoded private write set stored in transient store the
+// collections that contain a write of a purged key. The second return value is false if no collection is
+// removed. A nil value is returned if no collection remains in the private write set
+func removeCollectionsWithPurgedKeys(dbVal []byte, isPurged func(ns, coll, keyHash string) bool) ([]byte, bool, error) {
+	txPvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
+	newProto := dbVal[0] == nilByte
+	if newProto {
+		if err := proto.Unmarshal(dbVal[1:], txPvtRWSetWithConfig); err != nil {
+			return nil, false, err
+		}
+		if txPvtRWSetWithConfig.PvtRwset == nil {
+			return nil, false, nil
+		}
+		txPvtRWSet = txPvtRWSetWithConfig.PvtRwset
+	} else if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
+		return nil, false, err
+	}
+
+	removed := false
+	var retainedNsRwSets []*rwset.NsPvtReadWriteSet
+	for _, ns := range txPvtRWSet.NsPvtRwset {
+		var retainedCollRwSets []*rwset.CollectionPvtReadWriteSet
+		for _, coll := range ns.CollectionPvtRwset {
+			kvRWSet := &kvrwset.KVRWSet{}
+			if err := proto.Unmarshal(coll.Rwset, kvRWSet); err != nil {
+				logger.Warningf("Skipping the private write set of [%s:%s] that could not be unmarshalled: %s", ns.Namespace, coll.CollectionName, err)
+				kvRWSet.Reset()
+			}
+			containsPurgedKey := false
+			for _, w := range kvRWSet.Writes {
+				if isPurged(ns.Namespace, coll.CollectionName, string(commonutil.ComputeSHA256([]byte(w.Key)))) {
+					containsPurgedKey = true
+					break
+				}
+			}
+			if containsPurgedKey {
+				removed = true
+				continue
+			}
+			retainedCollRwSets = append(retainedCollRwSets, coll)
+		}
+		if len(retainedCollRwSets) > 0 {
+			ns.CollectionPvtRwset = retainedCollRwSets
+			retainedNsRwSets = append(retainedNsRwSets, ns)
+		}
+	}
+	if !removed {
+		return nil, false, nil
+	}
+	if len(retainedNsRwSets) == 0 {
+		return nil, true, nil
+	}
+	txPvtRWSet.NsPvtRwset = retainedNsRwSets
+
+	if !newProto {
+		updatedVal, err := proto.Marshal(txPvtRWSet)
+		return updatedVal, true, err
+	}
+	updatedVal, err := proto.Marshal(txPvtRWSetWithConfig)
+	if err != nil {
+		return nil, false, err
+	}
+	return append([]byte{nilByte}, updatedVal...), true, nil
+}
+
 func trimPvtCollectionConfigs(configs map[string]*peer.CollectionConfigPackage,
 	filter ledger.PvtNsCollFilter) (map[string]*peer.CollectionConfigPackage, error) {
 	if filter == nil {
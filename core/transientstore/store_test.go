@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
 	"github.com/hyperledger/fabric/common/policydsl"
@@ -727,3 +728,62 @@ func TestIteratorErrorCases(t *testing.T) {
 	require.EqualError(t, testStore.PurgeBelowHeight(0), errStr)
 	require.EqualError(t, testStore.PurgeByTxids([]string{"tx1"}), errStr)
 }
+
+func TestTransientStorePurgeByKeyHashes(t *testing.T) {
+	env.initTestEnv(t)
+	defer env.cleanup()
+	testStore := env.store
+
+	pvtRWSetWithKeys := func(collKeys map[string]string) *transientstore.TxPvtReadWriteSetWithConfigInfo {
+		nsPvtRWSet := &rwset.NsPvtReadWriteSet{Namespace: "ns-1"}
+		for _, coll := range []string{"coll-1", "coll-2"} {
+			key, ok := collKeys[coll]
+			if !ok {
+				continue
+			}
+			rwsetBytes, err := proto.Marshal(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: key, Value: []byte("value")}}})
+			require.NoError(t, err)
+			nsPvtRWSet.CollectionPvtRwset = append(nsPvtRWSet.CollectionPvtRwset,
+				&rwset.CollectionPvtReadWriteSet{CollectionName: coll, Rwset: rwsetBytes})
+		}
+		return &transientstore.TxPvtReadWriteSetWithConfigInfo{
+			PvtRwset: &rwset.TxPvtReadWriteSet{DataModel: rwset.TxReadWriteSet_KV, NsPvtRwset: []*rwset.NsPvtReadWriteSet{nsPvtRWSet}},
+		}
+	}
+	require.NoError(t, testStore.Persist("txid-1", 5, pvtRWSetWithKeys(map[string]string{"coll-1": "key1", "coll-2": "key2"})))
+	require.NoError(t, testStore.Persist("txid-2", 5, pvtRWSetWithKeys(map[string]string{"coll-1": "key1"})))
+	require.NoError(t, testStore.Persist("txid-3", 8, pvtRWSetWithKeys(map[string]string{"coll-1": "key1"})))
+
+	// the private data received after the purge is retained
+	require.NoError(t, testStore.PurgeByKeyHashes(6, []*PurgedKey{
+		{Namespace: "ns-1", Collection: "coll-1", KeyHash: string(util.ComputeStringHash("key1"))},
+	}))
+
+	collections := func(txid string) []string {
+		iter, err := testStore.GetTxPvtRWSetByTxid(txid, nil)
+		require.NoError(t, err)
+		defer iter.Close()
+		var colls []string
+		for {
+			result, err := iter.Next()
+			require.NoError(t, err)
+			if result == nil {
+				return colls
+			}
+			for _, ns := range result.PvtSimulationResultsWithConfig.PvtRwset.NsPvtRwset {
+				for _, coll := range ns.CollectionPvtRwset {
+					colls = append(colls, ns.Namespace+":"+coll.CollectionName)
+				}
+			}
+		}
+	}
+	require.Equal(t, []string{"ns-1:coll-2"}, collections("txid-1"))
+	require.Empty(t, collections("txid-2"))
+	require.Equal(t, []string{"ns-1:coll-1"}, collections("txid-3"))
+
+	// the indexes of the removed private data are removed as well
+	require.NoError(t, testStore.PurgeByTxids([]string{"txid-1"}))
+	minBlkHt, err := testStore.GetMinTransientBlkHt()
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), minBlkHt)
+}
@@ -37,6 +37,11 @@ type MessageCryptoService interface {
 	// else returns error
 	VerifyBlock(channelID common.ChannelID, seqNum uint64, block *cb.Block) error
 
+	// VerifyHeader returns nil if the header of the block, whose data may be stripped,
+	// is properly signed, and the claimed seqNum is the sequence number that it contains.
+	// else returns error
+	VerifyHeader(channelID common.ChannelID, seqNum uint64, block *cb.Block) error
+
 	// Sign signs msg with this peer's signing key and outputs
 	// the signature if no error occurred.
 	Sign(msg []byte) ([]byte, error)
@@ -98,6 +98,12 @@ func (*naiveSecProvider) VerifyBlock(channelID common.ChannelID, seqNum uint64,
 	return nil
 }
 
+// VerifyHeader returns nil if the header of the block is properly signed,
+// else returns error
+func (*naiveSecProvider) VerifyHeader(channelID common.ChannelID, seqNum uint64, signedBlock *cb.Block) error {
+	return nil
+}
+
 // Sign signs msg with this peer's signing key and outputs
 // the signature if no error occurred.
 func (*naiveSecProvider) Sign(msg []byte) ([]byte, error) {
@@ -176,6 +176,12 @@ func (*naiveCryptoService) VerifyBlock(channelID common.ChannelID, seqNum uint64
 	return nil
 }
 
+// VerifyHeader returns nil if the header of the block is properly signed,
+// else returns error
+func (*naiveCryptoService) VerifyHeader(channelID common.ChannelID, seqNum uint64, signedBlock *cb.Block) error {
+	return nil
+}
+
 // Sign signs msg with this peer's signing key and outputs
 // the signature if no error occurred.
 func (*naiveCryptoService) Sign(msg []byte) ([]byte, error) {
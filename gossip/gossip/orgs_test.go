@@ -81,6 +81,12 @@ func (*configurableCryptoService) VerifyBlock(channelID common.ChannelID, seqNum
 	return nil
 }
 
+// VerifyHeader returns nil if the header of the block is properly signed,
+// else returns error
+func (*configurableCryptoService) VerifyHeader(channelID common.ChannelID, seqNum uint64, signedBlock *cb.Block) error {
+	return nil
+}
+
 // Sign signs msg with this peer's signing key and outputs
 // the signature if no error occurred.
 func (*configurableCryptoService) Sign(msg []byte) ([]byte, error) {
@@ -178,7 +178,11 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	}
 	if exist {
 		commitOpts := &ledger.CommitOptions{FetchPvtDataFromLedger: true}
-		return c.CommitLegacy(blockAndPvtData, commitOpts)
+		if err := c.CommitLegacy(blockAndPvtData, commitOpts); err != nil {
+			return err
+		}
+		c.purgeKeysFromTransientStore(block)
+		return nil
 	}
 
 	listMissingPrivateDataDurationHistogram := c.metrics.ListMissingPrivateDataDuration.With("channel", c.ChainID)
@@ -226,6 +230,7 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	if err != nil {
 		return errors.Wrap(err, "commit failed")
 	}
+	c.purgeKeysFromTransientStore(block)
 
 	// Purge transactions
 	go retrievedPvtdata.Purge()
@@ -233,6 +238,42 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	return nil
 }
 
+// purgeKeysFromTransientStore removes from the transient store the private data, simulated before
+// the block was committed, of the keys that are purged by the valid transactions in the block
+func (c *coordinator) purgeKeysFromTransientStore(block *common.Block) {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return
+	}
+	txsFilter := txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	var purgedKeys []*transientstore.PurgedKey
+	for seqInBlock, txEnvBytes := range block.Data.Data {
+		if seqInBlock >= len(txsFilter) || txsFilter[seqInBlock] != uint8(peer.TxValidationCode_VALID) {
+			continue
+		}
+		txInfo, err := getTxInfoFromTransactionBytes(txEnvBytes)
+		if err != nil {
+			continue
+		}
+		for _, ns := range txInfo.txRWSet.NsRwSets {
+			for _, hashedCollection := range ns.CollHashedRwSets {
+				for _, hashedWrite := range hashedCollection.HashedRwSet.GetHashedWrites() {
+					if !hashedCollection.IsPurge(hashedWrite) {
+						continue
+					}
+					purgedKeys = append(purgedKeys, &transientstore.PurgedKey{
+						Namespace:  ns.NameSpace,
+						Collection: hashedCollection.CollectionName,
+						KeyHash:    string(hashedWrite.KeyHash),
+					})
+				}
+			}
+		}
+	}
+	if err := c.store.PurgeByKeyHashes(block.Header.Number, purgedKeys); err != nil {
+		logger.Errorf("[%s] Failed purging the keys purged by block [%d] from transient store: %s", c.ChainID, block.Header.Number, err)
+	}
+}
+
 // StorePvtData used to persist private date into transient store
 func (c *coordinator) StorePvtData(txID string, privData *protostransientstore.TxPvtReadWriteSetWithConfigInfo, blkHeight uint64) error {
 	return c.store.Persist(txID, blkHeight, privData)
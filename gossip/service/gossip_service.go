@@ -125,7 +125,7 @@ type GossipServiceAdapter interface {
 // DeliveryServiceFactory factory to create and initialize delivery service instance
 type DeliveryServiceFactory interface {
 	// Returns an instance of delivery client
-	Service(g GossipServiceAdapter, ordererSource *orderers.ConnectionSource, msc api.MessageCryptoService, isStaticLead bool) deliverservice.DeliverService
+	Service(g GossipServiceAdapter, ordererSource *orderers.ConnectionSource, msc api.MessageCryptoService, isStaticLead bool, isBFT bool) deliverservice.DeliverService
 }
 
 type deliveryFactoryImpl struct {
@@ -136,9 +136,10 @@ type deliveryFactoryImpl struct {
 }
 
 // Returns an instance of delivery client
-func (df *deliveryFactoryImpl) Service(g GossipServiceAdapter, ordererSource *orderers.ConnectionSource, mcs api.MessageCryptoService, isStaticLeader bool) deliverservice.DeliverService {
+func (df *deliveryFactoryImpl) Service(g GossipServiceAdapter, ordererSource *orderers.ConnectionSource, mcs api.MessageCryptoService, isStaticLeader bool, isBFT bool) deliverservice.DeliverService {
 	return deliverservice.NewDeliverService(&deliverservice.Config{
 		IsStaticLeader:       isStaticLeader,
+		IsBFT:                isBFT,
 		CryptoSvc:            mcs,
 		Gossip:               g,
 		Signer:               df.signer,
@@ -324,6 +325,8 @@ type Support struct {
 	CollectionStore      privdata.CollectionStore
 	IdDeserializeFactory gossipprivdata.IdentityDeserializerFactory
 	CapabilityProvider   gossipprivdata.CapabilityProvider
+	// IsBFT is true if the channel is ordered by a BFT ordering service
+	IsBFT bool
 }
 
 // InitializeChannel allocates the state provider and should be invoked once per channel per execution
@@ -386,7 +389,7 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 		blockingMode,
 		stateConfig)
 	if g.deliveryService[channelID] == nil {
-		g.deliveryService[channelID] = g.deliveryFactory.Service(g, ordererSource, g.mcs, g.serviceConfig.OrgLeader)
+		g.deliveryService[channelID] = g.deliveryFactory.Service(g, ordererSource, g.mcs, g.serviceConfig.OrgLeader, support.IsBFT)
 	}
 
 	// Delivery service might be nil only if it was not able to get connected
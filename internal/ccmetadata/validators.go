@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ccmetadata
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
@@ -25,17 +26,22 @@ type fileValidator func(fileName string, fileBytes []byte) error
 // AllowedCharsCollectionName captures the regex pattern for a valid collection name
 const AllowedCharsCollectionName = "[A-Za-z0-9_-]+"
 
-// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes.
+// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes
+// and META-INF/statedb/sql/indexes.
 var fileValidators = map[*regexp.Regexp]fileValidator{
 	regexp.MustCompile("^META-INF/statedb/couchdb/indexes/.*[.]json"):                                                couchdbIndexFileValidator,
 	regexp.MustCompile("^META-INF/statedb/couchdb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): couchdbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/sql/indexes/.*[.]json"):                                                    sqlIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/sql/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"):     sqlIndexFileValidator,
 }
 
 var collectionNameValid = regexp.MustCompile("^" + AllowedCharsCollectionName)
 
 var fileNameValid = regexp.MustCompile("^.*[.]json")
 
-var validDatabases = []string{"couchdb"}
+var validDatabases = []string{"couchdb", "sql"}
+
+var sqlIndexFieldValid = regexp.MustCompile(`^[A-Za-z0-9_]+([.][A-Za-z0-9_]+)*$`)
 
 // UnhandledDirectoryError is returned for metadata files in unhandled directories
 type UnhandledDirectoryError struct {
@@ -309,3 +315,30 @@ func validateFieldMap(jsonFragment map[string]interface{}) error {
 	return nil
 
 }
+
+// sqlIndexFileValidator implements fileValidator for the index definitions of the SQL state database,
+// which are JSON objects with a name and a list of fields, for instance
+// {"name":"indexOwner","fields":["docType","owner"]}
+func sqlIndexFileValidator(fileName string, fileBytes []byte) error {
+	indexDefinition := struct {
+		Name   string   `json:"name"`
+		Fields []string `json:"fields"`
+	}{}
+	decoder := json.NewDecoder(bytes.NewReader(fileBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&indexDefinition); err != nil {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: %s", fileName, err)}
+	}
+	if indexDefinition.Name == "" {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: the name is missing", fileName)}
+	}
+	if len(indexDefinition.Fields) == 0 {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: the fields are missing", fileName)}
+	}
+	for _, field := range indexDefinition.Fields {
+		if !sqlIndexFieldValid.MatchString(field) {
+			return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: invalid field [%s]", fileName, field)}
+		}
+	}
+	return nil
+}
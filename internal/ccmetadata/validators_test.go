@@ -29,6 +29,68 @@ func TestGoodIndexJSON(t *testing.T) {
 	require.NoError(t, err, "Error validating a good index")
 }
 
+func TestSQLIndexJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		fileBytes   string
+		expectedErr string
+	}{
+		{
+			name:      "valid",
+			fileName:  "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes: `{"name":"indexOwner","fields":["docType","owner.name"]}`,
+		},
+		{
+			name:      "valid collection index",
+			fileName:  "META-INF/statedb/sql/collections/testcoll/indexes/myIndex.json",
+			fileBytes: `{"name":"indexOwner","fields":["owner"]}`,
+		},
+		{
+			name:        "invalid json",
+			fileName:    "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes:   `invalid json`,
+			expectedErr: "Index metadata file [META-INF/statedb/sql/indexes/myIndex.json] is not a valid index definition: invalid character 'i' looking for beginning of value",
+		},
+		{
+			name:        "unknown entry",
+			fileName:    "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes:   `{"name":"indexOwner","fields":["owner"],"type":"json"}`,
+			expectedErr: `Index metadata file [META-INF/statedb/sql/indexes/myIndex.json] is not a valid index definition: json: unknown field "type"`,
+		},
+		{
+			name:        "missing name",
+			fileName:    "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes:   `{"fields":["owner"]}`,
+			expectedErr: "Index metadata file [META-INF/statedb/sql/indexes/myIndex.json] is not a valid index definition: the name is missing",
+		},
+		{
+			name:        "missing fields",
+			fileName:    "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes:   `{"name":"indexOwner"}`,
+			expectedErr: "Index metadata file [META-INF/statedb/sql/indexes/myIndex.json] is not a valid index definition: the fields are missing",
+		},
+		{
+			name:        "invalid field",
+			fileName:    "META-INF/statedb/sql/indexes/myIndex.json",
+			fileBytes:   `{"name":"indexOwner","fields":["owner'); DROP TABLE fabric_state; --"]}`,
+			expectedErr: "Index metadata file [META-INF/statedb/sql/indexes/myIndex.json] is not a valid index definition: invalid field [owner'); DROP TABLE fabric_state; --]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMetadataFile(tt.fileName, []byte(tt.fileBytes))
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expectedErr)
+			require.IsType(t, &InvalidIndexContentError{}, err)
+		})
+	}
+}
+
 func TestBadIndexJSON(t *testing.T) {
 	testDir := filepath.Join(packageTestDir, "BadIndexJSON")
 	cleanupDir(testDir)
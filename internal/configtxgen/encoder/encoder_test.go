@@ -8,6 +8,7 @@ package encoder_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -16,11 +17,13 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder/fakes"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/hyperledger/fabric/protoutil"
 )
 
@@ -383,6 +386,32 @@ var _ = Describe("Encoder", func() {
 			Expect(cg.Values["Capabilities"]).NotTo(BeNil())
 		})
 
+		Context("when the adaptive block cutting is configured", func() {
+			BeforeEach(func() {
+				conf.BatchSize = genesisconfig.BatchSize{
+					MaxMessageCount: 500,
+					Adaptive: &genesisconfig.AdaptiveBatching{
+						MinMessageCount: 10,
+						MinTimeout:      100 * time.Millisecond,
+					},
+				}
+			})
+
+			It("adds the bounds as a separate value", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				batchSize := &ab.BatchSize{}
+				err = proto.Unmarshal(cg.Values["BatchSize"].Value, batchSize)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(batchSize.MaxMessageCount).To(Equal(uint32(500)))
+				adaptive := &ordererpb.AdaptiveBatching{}
+				err = proto.Unmarshal(cg.Values["AdaptiveBatching"].Value, adaptive)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adaptive.MinMessageCount).To(Equal(uint32(10)))
+				Expect(adaptive.MinTimeout).To(Equal("100ms"))
+			})
+		})
+
 		Context("when the policy definition is bad", func() {
 			BeforeEach(func() {
 				conf.Policies["Admins"].Rule = "garbage"
@@ -447,6 +476,46 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the consensus type is BFT", func() {
+			BeforeEach(func() {
+				conf.OrdererType = "BFT"
+				conf.BFT = &bftmsgs.ConfigMetadata{
+					Options: &bftmsgs.Options{
+						RequestTimeout: "10s",
+					},
+				}
+			})
+
+			It("adds the BFT metadata", func() {
+				cg, err := encoder.NewOrdererGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(cg.Values)).To(Equal(5))
+				consensusType := &ab.ConsensusType{}
+				err = proto.Unmarshal(cg.Values["ConsensusType"].Value, consensusType)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(consensusType.Type).To(Equal("BFT"))
+				metadata := &bftmsgs.ConfigMetadata{}
+				err = proto.Unmarshal(consensusType.Metadata, metadata)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata.Options.RequestTimeout).To(Equal("10s"))
+			})
+
+			Context("when the BFT configuration is bad", func() {
+				BeforeEach(func() {
+					conf.BFT = &bftmsgs.ConfigMetadata{
+						Consenters: []*bftmsgs.Consenter{
+							{},
+						},
+					}
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := encoder.NewOrdererGroup(conf)
+					Expect(err).To(MatchError("cannot marshal metadata for orderer type BFT: cannot load client cert for consenter :0: open : no such file or directory"))
+				})
+			})
+		})
+
 		Context("when the consensus type is unknown", func() {
 			BeforeEach(func() {
 				conf.OrdererType = "bad-type"
@@ -18,11 +18,14 @@ import (
 	"github.com/hyperledger/fabric/common/viperutil"
 	cf "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 )
 
 const (
 	// The type key for etcd based RAFT consensus.
 	EtcdRaft = "etcdraft"
+	// The type key for BFT consensus.
+	BFT = "BFT"
 )
 
 var logger = flogging.MustGetLogger("common.tools.configtxgen.localconfig")
@@ -148,6 +151,7 @@ type Orderer struct {
 	BatchSize     BatchSize                `yaml:"BatchSize"`
 	Kafka         Kafka                    `yaml:"Kafka"`
 	EtcdRaft      *etcdraft.ConfigMetadata `yaml:"EtcdRaft"`
+	BFT           *bftmsgs.ConfigMetadata  `yaml:"BFT"`
 	Organizations []*Organization          `yaml:"Organizations"`
 	MaxChannels   uint64                   `yaml:"MaxChannels"`
 	Capabilities  map[string]bool          `yaml:"Capabilities"`
@@ -156,9 +160,16 @@ type Orderer struct {
 
 // BatchSize contains configuration affecting the size of batches.
 type BatchSize struct {
-	MaxMessageCount   uint32 `yaml:"MaxMessageCount"`
-	AbsoluteMaxBytes  uint32 `yaml:"AbsoluteMaxBytes"`
-	PreferredMaxBytes uint32 `yaml:"PreferredMaxBytes"`
+	MaxMessageCount   uint32            `yaml:"MaxMessageCount"`
+	AbsoluteMaxBytes  uint32            `yaml:"AbsoluteMaxBytes"`
+	PreferredMaxBytes uint32            `yaml:"PreferredMaxBytes"`
+	Adaptive          *AdaptiveBatching `yaml:"Adaptive"`
+}
+
+// AdaptiveBatching contains the lower bounds of the adaptive block cutting.
+type AdaptiveBatching struct {
+	MinMessageCount uint32        `yaml:"MinMessageCount"`
+	MinTimeout      time.Duration `yaml:"MinTimeout"`
 }
 
 // Kafka contains configuration for the Kafka-based orderer.
@@ -187,6 +198,12 @@ var genesisDefaults = TopLevel{
 				SnapshotIntervalSize: 16 * 1024 * 1024, // 16 MB
 			},
 		},
+		BFT: &bftmsgs.ConfigMetadata{
+			Options: &bftmsgs.Options{
+				RequestTimeout:    "10s",
+				ViewChangeTimeout: "20s",
+			},
+		},
 	},
 }
 
@@ -397,6 +414,77 @@ loop:
 			cf.TranslatePathInPlace(configDir, &serverCertPath)
 			c.ServerTlsCert = []byte(serverCertPath)
 		}
+	case BFT:
+		if ord.BFT == nil {
+			logger.Panicf("%s configuration missing", BFT)
+		}
+		if ord.BFT.Options == nil {
+			logger.Infof("Orderer.BFT.Options unset, setting to %v", genesisDefaults.Orderer.BFT.Options)
+			ord.BFT.Options = genesisDefaults.Orderer.BFT.Options
+		}
+	bft_loop:
+		for {
+			switch {
+			case ord.BFT.Options.RequestTimeout == "":
+				logger.Infof("Orderer.BFT.Options.RequestTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.RequestTimeout)
+				ord.BFT.Options.RequestTimeout = genesisDefaults.Orderer.BFT.Options.RequestTimeout
+
+			case ord.BFT.Options.ViewChangeTimeout == "":
+				logger.Infof("Orderer.BFT.Options.ViewChangeTimeout unset, setting to %v", genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout)
+				ord.BFT.Options.ViewChangeTimeout = genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout
+
+			case len(ord.BFT.Consenters) == 0:
+				logger.Panicf("%s configuration did not specify any consenter", BFT)
+
+			default:
+				break bft_loop
+			}
+		}
+
+		if _, err := time.ParseDuration(ord.BFT.Options.RequestTimeout); err != nil {
+			logger.Panicf("BFT RequestTimeout (%s) must be in time duration format", ord.BFT.Options.RequestTimeout)
+		}
+		if _, err := time.ParseDuration(ord.BFT.Options.ViewChangeTimeout); err != nil {
+			logger.Panicf("BFT ViewChangeTimeout (%s) must be in time duration format", ord.BFT.Options.ViewChangeTimeout)
+		}
+
+		ids := map[uint64]struct{}{}
+		for _, c := range ord.BFT.GetConsenters() {
+			if c.Id == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify id", BFT)
+			}
+			if _, exists := ids[c.Id]; exists {
+				logger.Panicf("consenter info in %s configuration specified id %d more than once", BFT, c.Id)
+			}
+			ids[c.Id] = struct{}{}
+			if c.Host == "" {
+				logger.Panicf("consenter info in %s configuration did not specify host", BFT)
+			}
+			if c.Port == 0 {
+				logger.Panicf("consenter info in %s configuration did not specify port", BFT)
+			}
+			if c.MspId == "" {
+				logger.Panicf("consenter info in %s configuration did not specify MSP ID", BFT)
+			}
+			if c.ClientTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify client TLS cert", BFT)
+			}
+			if c.ServerTlsCert == nil {
+				logger.Panicf("consenter info in %s configuration did not specify server TLS cert", BFT)
+			}
+			if c.Identity == nil {
+				logger.Panicf("consenter info in %s configuration did not specify identity", BFT)
+			}
+			clientCertPath := string(c.GetClientTlsCert())
+			cf.TranslatePathInPlace(configDir, &clientCertPath)
+			c.ClientTlsCert = []byte(clientCertPath)
+			serverCertPath := string(c.GetServerTlsCert())
+			cf.TranslatePathInPlace(configDir, &serverCertPath)
+			c.ServerTlsCert = []byte(serverCertPath)
+			identityPath := string(c.GetIdentity())
+			cf.TranslatePathInPlace(configDir, &identityPath)
+			c.Identity = []byte(identityPath)
+		}
 	default:
 		logger.Panicf("unknown orderer type: %s", ord.OrdererType)
 	}
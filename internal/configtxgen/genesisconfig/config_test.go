@@ -8,11 +8,13 @@ package genesisconfig
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/stretchr/testify/require"
 )
 
@@ -268,6 +270,107 @@ func TestConsensusSpecificInit(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("bft", func(t *testing.T) {
+		makeProfile := func(consenters []*bftmsgs.Consenter, options *bftmsgs.Options) *Profile {
+			return &Profile{
+				Orderer: &Orderer{
+					OrdererType: "BFT",
+					BFT: &bftmsgs.ConfigMetadata{
+						Consenters: consenters,
+						Options:    options,
+					},
+				},
+			}
+		}
+		newConsenter := func() *bftmsgs.Consenter {
+			return &bftmsgs.Consenter{
+				Id:            1,
+				Host:          "node-1.example.com",
+				Port:          7050,
+				MspId:         "OrdererMSP",
+				ClientTlsCert: []byte("path/to/client/cert"),
+				ServerTlsCert: []byte("path/to/server/cert"),
+				Identity:      []byte("path/to/identity"),
+			}
+		}
+
+		t.Run("BFT section not specified in profile", func(t *testing.T) {
+			profile := &Profile{
+				Orderer: &Orderer{
+					OrdererType: "BFT",
+				},
+			}
+
+			require.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("nil consenter set", func(t *testing.T) {
+			profile := makeProfile(nil, nil)
+
+			require.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("invalid consenters specification", func(t *testing.T) {
+			for _, unset := range []func(c *bftmsgs.Consenter){
+				func(c *bftmsgs.Consenter) { c.Id = 0 },
+				func(c *bftmsgs.Consenter) { c.Host = "" },
+				func(c *bftmsgs.Consenter) { c.Port = 0 },
+				func(c *bftmsgs.Consenter) { c.MspId = "" },
+				func(c *bftmsgs.Consenter) { c.ClientTlsCert = nil },
+				func(c *bftmsgs.Consenter) { c.ServerTlsCert = nil },
+				func(c *bftmsgs.Consenter) { c.Identity = nil },
+			} {
+				consenter := newConsenter()
+				unset(consenter)
+				profile := makeProfile([]*bftmsgs.Consenter{consenter}, nil)
+
+				require.Panics(t, func() {
+					profile.completeInitialization(devConfigDir)
+				})
+			}
+		})
+
+		t.Run("duplicate consenter ids", func(t *testing.T) {
+			profile := makeProfile([]*bftmsgs.Consenter{newConsenter(), newConsenter()}, nil)
+
+			require.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+
+		t.Run("nil Options", func(t *testing.T) {
+			profile := makeProfile([]*bftmsgs.Consenter{newConsenter()}, nil)
+			profile.completeInitialization(devConfigDir)
+
+			require.Equal(t, genesisDefaults.Orderer.BFT.Options, profile.Orderer.BFT.Options,
+				"Options should be set to the default value")
+			require.Equal(t, filepath.Join(devConfigDir, "path/to/identity"), string(profile.Orderer.BFT.Consenters[0].Identity),
+				"Identity path should be translated")
+		})
+
+		t.Run("request timeout specified in Options", func(t *testing.T) {
+			profile := makeProfile([]*bftmsgs.Consenter{newConsenter()}, &bftmsgs.Options{RequestTimeout: "5s"})
+			profile.completeInitialization(devConfigDir)
+
+			require.Equal(t, "5s", profile.Orderer.BFT.Options.RequestTimeout,
+				"RequestTimeout should be set to the specified value")
+			require.Equal(t, genesisDefaults.Orderer.BFT.Options.ViewChangeTimeout, profile.Orderer.BFT.Options.ViewChangeTimeout,
+				"ViewChangeTimeout should be set to the default value")
+		})
+
+		t.Run("panic on invalid RequestTimeout", func(t *testing.T) {
+			profile := makeProfile([]*bftmsgs.Consenter{newConsenter()}, &bftmsgs.Options{RequestTimeout: "500"})
+
+			require.Panics(t, func() {
+				profile.completeInitialization(devConfigDir)
+			})
+		})
+	})
 }
 
 func TestLoadConfigCache(t *testing.T) {
@@ -8,6 +8,7 @@ package ca
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
@@ -38,8 +39,8 @@ type CA struct {
 	SignCert           *x509.Certificate
 }
 
-// NewCA creates an instance of CA and saves the signing key pair in
-// baseDir/name
+// NewCA creates an instance of CA and saves the signing key pair, of the
+// given key algorithm, in baseDir/name
 func NewCA(
 	baseDir,
 	org,
@@ -49,7 +50,8 @@ func NewCA(
 	locality,
 	orgUnit,
 	streetAddress,
-	postalCode string,
+	postalCode,
+	keyAlg string,
 ) (*CA, error) {
 
 	var ca *CA
@@ -59,7 +61,11 @@ func NewCA(
 		return nil, err
 	}
 
-	priv, err := csp.GeneratePrivateKey(baseDir)
+	priv, err := csp.GeneratePrivateKey(baseDir, keyAlg)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := csp.NewSigner(priv)
 	if err != nil {
 		return nil, err
 	}
@@ -83,22 +89,20 @@ func NewCA(
 	template.Subject = subject
 	template.SubjectKeyId = computeSKI(priv)
 
-	x509Cert, err := genCertificateECDSA(
+	x509Cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
 		&template,
-		&priv.PublicKey,
+		signer.Public(),
 		priv,
 	)
 	if err != nil {
 		return nil, err
 	}
 	ca = &CA{
-		Name: name,
-		Signer: &csp.ECDSASigner{
-			PrivateKey: priv,
-		},
+		Name:               name,
+		Signer:             signer,
 		SignCert:           x509Cert,
 		Country:            country,
 		Province:           province,
@@ -118,7 +122,7 @@ func (ca *CA) SignCertificate(
 	name string,
 	orgUnits,
 	alternateNames []string,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	ku x509.KeyUsage,
 	eku []x509.ExtKeyUsage,
 ) (*x509.Certificate, error) {
@@ -151,7 +155,7 @@ func (ca *CA) SignCertificate(
 		}
 	}
 
-	cert, err := genCertificateECDSA(
+	cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
@@ -168,9 +172,15 @@ func (ca *CA) SignCertificate(
 }
 
 // compute Subject Key Identifier
-func computeSKI(privKey *ecdsa.PrivateKey) []byte {
-	// Marshall the public key
-	raw := elliptic.Marshal(privKey.Curve, privKey.PublicKey.X, privKey.PublicKey.Y)
+func computeSKI(privKey crypto.PrivateKey) []byte {
+	var raw []byte
+	switch k := privKey.(type) {
+	case *ecdsa.PrivateKey:
+		// Marshall the public key
+		raw = elliptic.Marshal(k.Curve, k.PublicKey.X, k.PublicKey.Y)
+	case ed25519.PrivateKey:
+		raw = k.Public().(ed25519.PublicKey)
+	}
 
 	// Hash it
 	hash := sha256.Sum256(raw)
@@ -241,13 +251,13 @@ func x509Template() x509.Certificate {
 
 }
 
-// generate a signed X509 certificate using ECDSA
-func genCertificateECDSA(
+// generate a signed X509 certificate
+func genCertificate(
 	baseDir,
 	name string,
 	template,
 	parent *x509.Certificate,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	priv interface{},
 ) (*x509.Certificate, error) {
 
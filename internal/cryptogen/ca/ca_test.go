@@ -7,6 +7,8 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"crypto/x509"
 	"io/ioutil"
 	"net"
@@ -47,7 +49,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -62,6 +64,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -70,7 +73,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testName3,
 		nil,
 		nil,
-		&priv.PublicKey,
+		&priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -135,6 +138,7 @@ func TestNewCA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 	require.NotNil(t, rootCA, "Failed to return CA")
@@ -174,7 +178,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create certs directory: %s", err)
 	}
-	priv, err := csp.GeneratePrivateKey(certDir)
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate signed certificate")
 
 	// create our CA
@@ -189,6 +193,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -197,7 +202,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		&priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageAny},
 	)
@@ -212,7 +217,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testName,
 		nil,
 		nil,
-		&priv.PublicKey,
+		&priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -221,7 +226,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// make sure ous are correctly set
 	ous := []string{"TestOU", "PeerOU"}
-	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, ous, nil, &priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	require.NoError(t, err)
 	require.Contains(t, cert.Subject.OrganizationalUnit, ous[0])
@@ -229,7 +234,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 	// make sure sans are correctly set
 	sans := []string{testName2, testIP}
-	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, &priv.PublicKey,
+	cert, err = rootCA.SignCertificate(certDir, testName, nil, sans, &priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	require.NoError(t, err)
 	require.Contains(t, cert.DNSNames, testName2)
@@ -240,7 +245,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	require.Equal(t, true, checkForFile(pemFile),
 		"Expected to find file "+pemFile)
 
-	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, &priv.PublicKey,
+	_, err = rootCA.SignCertificate(certDir, "empty/CA", nil, nil, &priv.(*ecdsa.PrivateKey).PublicKey,
 		x509.KeyUsageKeyEncipherment, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
 	require.Error(t, err, "Bad name should fail")
 
@@ -255,6 +260,66 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestEd25519CA(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	require.NoError(t, err, "failed to create test directory")
+	defer os.RemoveAll(testDir)
+
+	certDir, err := ioutil.TempDir(testDir, "certs")
+	require.NoError(t, err, "failed to create certs directory")
+	priv, err := csp.GeneratePrivateKey(certDir, csp.ED25519)
+	require.NoError(t, err, "Failed to generate private key")
+
+	caDir := filepath.Join(testDir, "ca")
+	rootCA, err := ca.NewCA(
+		caDir,
+		testCA3Name,
+		testCA3Name,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		csp.ED25519,
+	)
+	require.NoError(t, err, "Error generating CA")
+	require.Equal(t, x509.Ed25519, rootCA.SignCert.PublicKeyAlgorithm)
+	require.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+	caPub := rootCA.SignCert.PublicKey.(ed25519.PublicKey)
+	expectedSKI := sha256.Sum256(caPub)
+	require.Equal(t, expectedSKI[:], rootCA.SignCert.SubjectKeyId)
+
+	cert, err := rootCA.SignCertificate(
+		certDir,
+		testName,
+		nil,
+		nil,
+		priv.(ed25519.PrivateKey).Public(),
+		x509.KeyUsageDigitalSignature,
+		[]x509.ExtKeyUsage{},
+	)
+	require.NoError(t, err, "Failed to generate signed certificate")
+	require.Equal(t, x509.Ed25519, cert.PublicKeyAlgorithm)
+	require.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+
+	// an Ed25519 CA signs EC public keys as well
+	ecPriv, err := csp.GeneratePrivateKey(certDir, csp.ECDSA)
+	require.NoError(t, err, "Failed to generate private key")
+	cert, err = rootCA.SignCertificate(
+		certDir,
+		testName2,
+		nil,
+		nil,
+		&ecPriv.(*ecdsa.PrivateKey).PublicKey,
+		x509.KeyUsageDigitalSignature,
+		[]x509.ExtKeyUsage{},
+	)
+	require.NoError(t, err, "Failed to generate signed certificate")
+	require.Equal(t, x509.ECDSA, cert.PublicKeyAlgorithm)
+	require.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+}
+
 func checkForFile(file string) bool {
 	if _, err := os.Stat(file); os.IsNotExist(err) {
 		return false
@@ -8,6 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -23,10 +24,17 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Algorithms of the keys generated by GeneratePrivateKey
+const (
+	ECDSA   = "ecdsa"
+	ED25519 = "ed25519"
+)
+
 // LoadPrivateKey loads a private key from a file in keystorePath.  It looks
-// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC private key.
-func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-	var priv *ecdsa.PrivateKey
+// for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC or Ed25519
+// private key.
+func LoadPrivateKey(keystorePath string) (crypto.PrivateKey, error) {
+	var priv crypto.PrivateKey
 
 	walkFunc := func(path string, info os.FileInfo, pathErr error) error {
 
@@ -55,7 +63,7 @@ func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
-func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
+func parsePrivateKeyPEM(rawKey []byte) (crypto.PrivateKey, error) {
 	block, _ := pem.Decode(rawKey)
 	if block == nil {
 		return nil, errors.New("bytes are not PEM encoded")
@@ -66,18 +74,27 @@ func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
 		return nil, errors.WithMessage(err, "pem bytes are not PKCS8 encoded ")
 	}
 
-	priv, ok := key.(*ecdsa.PrivateKey)
-	if !ok {
-		return nil, errors.New("pem bytes do not contain an EC private key")
+	switch priv := key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return priv, nil
+	default:
+		return nil, errors.New("pem bytes do not contain an EC or Ed25519 private key")
 	}
-	return priv, nil
 }
 
-// GeneratePrivateKey creates an EC private key using a P-256 curve and stores
-// it in keystorePath.
-func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
-
-	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// GeneratePrivateKey creates a private key of the given algorithm and stores
+// it in keystorePath. EC private keys use a P-256 curve.
+func GeneratePrivateKey(keystorePath, keyAlg string) (crypto.PrivateKey, error) {
+	var priv crypto.PrivateKey
+	var err error
+	switch keyAlg {
+	case ECDSA:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ED25519:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.Errorf("unsupported key algorithm: %s", keyAlg)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to generate private key")
 	}
@@ -98,6 +115,28 @@ func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	return priv, err
 }
 
+// NewSigner returns the crypto.Signer for priv. EC private keys are wrapped
+// in an ECDSASigner so that the signatures use Low S values.
+func NewSigner(priv crypto.PrivateKey) (crypto.Signer, error) {
+	switch priv := priv.(type) {
+	case *ecdsa.PrivateKey:
+		return &ECDSASigner{PrivateKey: priv}, nil
+	case ed25519.PrivateKey:
+		return priv, nil
+	default:
+		return nil, errors.Errorf("unsupported private key type: %T", priv)
+	}
+}
+
+// PublicKey returns the public key of priv.
+func PublicKey(priv crypto.PrivateKey) (crypto.PublicKey, error) {
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported private key type: %T", priv)
+	}
+	return signer.Public(), nil
+}
+
 /**
 ECDSA signer implements the crypto.Signer interface for ECDSA keys.  The
 Sign method ensures signatures are created with Low S values since Fabric
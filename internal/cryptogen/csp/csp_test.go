@@ -7,6 +7,7 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -30,17 +31,19 @@ func TestLoadPrivateKey(t *testing.T) {
 		t.Fatalf("Failed to create test directory: %s", err)
 	}
 	defer os.RemoveAll(testDir)
-	priv, err := csp.GeneratePrivateKey(testDir)
-	if err != nil {
-		t.Fatalf("Failed to generate private key: %s", err)
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		priv, err := csp.GeneratePrivateKey(testDir, keyAlg)
+		if err != nil {
+			t.Fatalf("Failed to generate private key: %s", err)
+		}
+		pkFile := filepath.Join(testDir, "priv_sk")
+		require.Equal(t, true, checkForFile(pkFile),
+			"Expected to find private key file")
+		loadedPriv, err := csp.LoadPrivateKey(testDir)
+		require.NoError(t, err, "Failed to load private key")
+		require.NotNil(t, loadedPriv, "Should have returned a private key")
+		require.Equal(t, priv, loadedPriv, "Expected private keys to match")
 	}
-	pkFile := filepath.Join(testDir, "priv_sk")
-	require.Equal(t, true, checkForFile(pkFile),
-		"Expected to find private key file")
-	loadedPriv, err := csp.LoadPrivateKey(testDir)
-	require.NoError(t, err, "Failed to load private key")
-	require.NotNil(t, loadedPriv, "Should have returned an *ecdsa.PrivateKey")
-	require.Equal(t, priv, loadedPriv, "Expected private keys to match")
 }
 
 func TestLoadPrivateKey_BadPEM(t *testing.T) {
@@ -82,7 +85,7 @@ func TestLoadPrivateKey_BadPEM(t *testing.T) {
 		{
 			name:   "not EC key",
 			data:   pkcs8RSAPem,
-			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC private key", badPEMFile),
+			errMsg: fmt.Sprintf("%s: pem bytes do not contain an EC or Ed25519 private key", badPEMFile),
 		},
 		{
 			name:   "not PKCS8 encoded",
@@ -114,14 +117,46 @@ func TestGeneratePrivateKey(t *testing.T) {
 	defer os.RemoveAll(testDir)
 
 	expectedFile := filepath.Join(testDir, "priv_sk")
-	priv, err := csp.GeneratePrivateKey(testDir)
+	priv, err := csp.GeneratePrivateKey(testDir, csp.ECDSA)
 	require.NoError(t, err, "Failed to generate private key")
-	require.NotNil(t, priv, "Should have returned an *ecdsa.Key")
+	require.IsType(t, &ecdsa.PrivateKey{}, priv, "Should have returned an *ecdsa.Key")
 	require.Equal(t, true, checkForFile(expectedFile),
 		"Expected to find private key file")
 
-	_, err = csp.GeneratePrivateKey("notExist")
+	priv, err = csp.GeneratePrivateKey(testDir, csp.ED25519)
+	require.NoError(t, err, "Failed to generate private key")
+	require.IsType(t, ed25519.PrivateKey{}, priv, "Should have returned an ed25519.PrivateKey")
+
+	_, err = csp.GeneratePrivateKey("notExist", csp.ECDSA)
 	require.Contains(t, err.Error(), "no such file or directory")
+
+	_, err = csp.GeneratePrivateKey(testDir, "rsa")
+	require.EqualError(t, err, "unsupported key algorithm: rsa")
+}
+
+func TestNewSigner(t *testing.T) {
+	ecdsaPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := csp.NewSigner(ecdsaPriv)
+	require.NoError(t, err)
+	require.Equal(t, &csp.ECDSASigner{PrivateKey: ecdsaPriv}, signer)
+	pub, err := csp.PublicKey(ecdsaPriv)
+	require.NoError(t, err)
+	require.Equal(t, &ecdsaPriv.PublicKey, pub)
+
+	_, ed25519Priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err = csp.NewSigner(ed25519Priv)
+	require.NoError(t, err)
+	require.Equal(t, ed25519Priv, signer)
+	pub, err = csp.PublicKey(ed25519Priv)
+	require.NoError(t, err)
+	require.Equal(t, ed25519Priv.Public(), pub)
+
+	_, err = csp.NewSigner("not a key")
+	require.EqualError(t, err, "unsupported private key type: string")
+	_, err = csp.PublicKey("not a key")
+	require.EqualError(t, err, "unsupported private key type: string")
 }
 
 func TestECDSASigner(t *testing.T) {
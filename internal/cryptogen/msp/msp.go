@@ -47,6 +47,7 @@ func GenerateLocalMSP(
 	tlsCA *ca.CA,
 	nodeType int,
 	nodeOUs bool,
+	keyAlg string,
 ) error {
 
 	// create folder structure
@@ -70,7 +71,11 @@ func GenerateLocalMSP(
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, err := csp.GeneratePrivateKey(keystore)
+	priv, err := csp.GeneratePrivateKey(keystore, keyAlg)
+	if err != nil {
+		return err
+	}
+	pub, err := csp.PublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -85,7 +90,7 @@ func GenerateLocalMSP(
 		name,
 		ous,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
@@ -136,8 +141,12 @@ func GenerateLocalMSP(
 		Generate the TLS artifacts in the TLS folder
 	*/
 
-	// generate private key
-	tlsPrivKey, err := csp.GeneratePrivateKey(tlsDir)
+	// generate private key, TLS keys are always EC keys
+	tlsPrivKey, err := csp.GeneratePrivateKey(tlsDir, csp.ECDSA)
+	if err != nil {
+		return err
+	}
+	tlsPubKey, err := csp.PublicKey(tlsPrivKey)
 	if err != nil {
 		return err
 	}
@@ -148,7 +157,7 @@ func GenerateLocalMSP(
 		name,
 		nil,
 		sans,
-		&tlsPrivKey.PublicKey,
+		tlsPubKey,
 		x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
 		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth,
 			x509.ExtKeyUsageClientAuth},
@@ -185,6 +194,7 @@ func GenerateVerifyingMSP(
 	signCA,
 	tlsCA *ca.CA,
 	nodeOUs bool,
+	keyAlg string,
 ) error {
 
 	// create folder structure and write artifacts to proper locations
@@ -229,7 +239,11 @@ func GenerateVerifyingMSP(
 	if err != nil {
 		return errors.WithMessage(err, "failed to create keystore directory")
 	}
-	priv, err := csp.GeneratePrivateKey(ksDir)
+	priv, err := csp.GeneratePrivateKey(ksDir, keyAlg)
+	if err != nil {
+		return err
+	}
+	pub, err := csp.PublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -238,7 +252,7 @@ func GenerateVerifyingMSP(
 		signCA.Name,
 		nil,
 		nil,
-		&priv.PublicKey,
+		pub,
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
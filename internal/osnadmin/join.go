@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/pkg/errors"
+)
+
+// Join joins an OSN to a new or existing channel using the given marshaled config block.
+func Join(osnURL string, blockBytes []byte, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := osnURL + channelparticipation.URLBaseV1Channels
+	req, err := createJoinRequest(url, blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
+
+func createJoinRequest(url string, blockBytes []byte) (*http.Request, error) {
+	joinBody := new(bytes.Buffer)
+	writer := multipart.NewWriter(joinBody)
+	part, err := writer.CreateFormFile(channelparticipation.FormDataConfigBlockKey, "config.block")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create form file")
+	}
+	if _, err := part.Write(blockBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to write config block to form")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close multipart writer")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, joinBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create join request")
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req, nil
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+)
+
+// ListAllChannels lists the channels an OSN is a member of.
+func ListAllChannels(osnURL string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := osnURL + channelparticipation.URLBaseV1Channels
+	return httpGet(url, caCertPool, tlsClientCert)
+}
+
+// ListSingleChannel lists the details of a single channel an OSN is a member of.
+func ListSingleChannel(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := osnURL + channelparticipation.URLBaseV1Channels + "/" + channelID
+	return httpGet(url, caCertPool, tlsClientCert)
+}
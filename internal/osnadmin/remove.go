@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package osnadmin
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/pkg/errors"
+)
+
+// Remove removes an OSN from an existing channel.
+func Remove(osnURL, channelID string, caCertPool *x509.CertPool, tlsClientCert tls.Certificate) (*http.Response, error) {
+	url := osnURL + channelparticipation.URLBaseV1Channels + "/" + channelID
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create remove request")
+	}
+
+	return httpDo(req, caCertPool, tlsClientCert)
+}
@@ -80,7 +80,12 @@ func executeJoin(cf *ChannelCmdFactory) (err error) {
 	if err != nil {
 		return err
 	}
+	return executeJoinProposal(cf, spec)
+}
 
+// executeJoinProposal sends a proposal for the cscc spec to the peer and
+// checks the proposal response
+func executeJoinProposal(cf *ChannelCmdFactory, spec *pb.ChaincodeSpec) (err error) {
 	// Build the ChaincodeInvocationSpec message
 	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
 
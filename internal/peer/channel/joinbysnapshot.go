@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"errors"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/spf13/cobra"
+)
+
+const joinBySnapshotCommandDescription = "Joins the peer to a channel by the specified snapshot."
+
+func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
+	// Set the flags on the channel joinbysnapshot command.
+	joinBySnapshotCmd := &cobra.Command{
+		Use:   "joinbysnapshot",
+		Short: joinBySnapshotCommandDescription,
+		Long:  joinBySnapshotCommandDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return joinBySnapshot(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"snapshotpath",
+	}
+	attachFlags(joinBySnapshotCmd, flagList)
+
+	return joinBySnapshotCmd
+}
+
+// getJoinBySnapshotCCSpec builds the cscc spec for joining a channel by snapshot.
+// The snapshot path refers to a directory on the peer's file system, hence
+// only the path is sent to the peer.
+func getJoinBySnapshotCCSpec() (*pb.ChaincodeSpec, error) {
+	if snapshotPath == common.UndefinedParamValue {
+		return nil, errors.New("Must supply snapshot path")
+	}
+	// Build the spec
+	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}}
+
+	spec := &pb.ChaincodeSpec{
+		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
+		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
+		Input:       input,
+	}
+
+	return spec, nil
+}
+
+func executeJoinBySnapshot(cf *ChannelCmdFactory) error {
+	spec, err := getJoinBySnapshotCCSpec()
+	if err != nil {
+		return err
+	}
+	return executeJoinProposal(cf, spec)
+}
+
+func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if snapshotPath == common.UndefinedParamValue {
+		return errors.New("Must supply snapshot path")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+	return executeJoinBySnapshot(cf)
+}
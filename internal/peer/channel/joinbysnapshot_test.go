@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinBySnapshotMissingSnapshotPath(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+
+	cmd := joinBySnapshotCmd(nil)
+	AddFlags(cmd)
+	args := []string{}
+	cmd.SetArgs(args)
+
+	require.EqualError(t, cmd.Execute(), "Must supply snapshot path")
+}
+
+func TestJoinBySnapshot(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   mockEndorserClient,
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"--snapshotpath", "/peer/snapshots/completed/mychannel/1000"}
+	cmd.SetArgs(args)
+
+	require.NoError(t, cmd.Execute(), "expected joinbysnapshot command to succeed")
+}
+
+func TestJoinBySnapshotBadProposalResponse(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err, "Get default signer error: %v", err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 500, Message: "cannot create ledger from snapshot"},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   mockEndorserClient,
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+
+	args := []string{"--snapshotpath", "/peer/snapshots/completed/mychannel/1000"}
+	cmd.SetArgs(args)
+
+	require.EqualError(t, cmd.Execute(), "proposal failed (err: bad proposal response 500: cannot create ledger from snapshot)")
+}
@@ -112,6 +112,13 @@ func TestPeerClient(t *testing.T) {
 	dClient, err = common.GetDeliverClient("", "")
 	require.NoError(t, err)
 	require.NotNil(t, dClient)
+
+	sClient, err := pClient1.Snapshot()
+	require.NoError(t, err)
+	require.NotNil(t, sClient)
+	sClient, err = common.GetSnapshotClient("", "")
+	require.NoError(t, err)
+	require.NotNil(t, sClient)
 }
 
 func TestPeerClientTimeout(t *testing.T) {
@@ -237,4 +244,8 @@ func TestGetClients_AddressError(t *testing.T) {
 	dClient, err := common.GetDeliverClient("peer0", "")
 	require.Contains(t, err.Error(), "tls root cert file must be set")
 	require.Nil(t, dClient)
+
+	sClient, err := common.GetSnapshotClient("peer0", "")
+	require.Contains(t, err.Error(), "tls root cert file must be set")
+	require.Nil(t, sClient)
 }
@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	pcommon "github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
@@ -21,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -32,6 +36,19 @@ type Hasher interface {
 	Hash(msg []byte, opts bccsp.HashOpts) (hash []byte, err error)
 }
 
+// OrdererConfigGetter gives access to the orderer configuration of the channels.
+type OrdererConfigGetter interface {
+	// OrdererConfig returns the orderer configuration of the given channel, if any.
+	OrdererConfig(channelID string) (channelconfig.Orderer, bool)
+}
+
+// OrdererConfigGetterFunc is a function adapter for OrdererConfigGetter.
+type OrdererConfigGetterFunc func(channelID string) (channelconfig.Orderer, bool)
+
+func (o OrdererConfigGetterFunc) OrdererConfig(channelID string) (channelconfig.Orderer, bool) {
+	return o(channelID)
+}
+
 // MSPMessageCryptoService implements the MessageCryptoService interface
 // using the peer MSPs (local and channel-related)
 //
@@ -43,6 +60,7 @@ type Hasher interface {
 // This implementation assumes that these mechanisms are all in place and working.
 type MSPMessageCryptoService struct {
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter
+	ordererConfigGetter        OrdererConfigGetter
 	localSigner                identity.SignerSerializer
 	deserializer               mgmt.DeserializersManager
 	hasher                     Hasher
@@ -54,14 +72,19 @@ type MSPMessageCryptoService struct {
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of identity.SignerSerializer
 // 3. an identity deserializer manager
+// 4. an OrdererConfigGetter that gives access to the orderer configuration of a given channel, which
+// determines whether the blocks of the channel must be signed by a quorum of BFT consenters.
+// It may be nil, in which case only the block validation policy of the channel is checked.
 func NewMCS(
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter,
 	localSigner identity.SignerSerializer,
 	deserializer mgmt.DeserializersManager,
+	ordererConfigGetter OrdererConfigGetter,
 	hasher Hasher,
 ) *MSPMessageCryptoService {
 	return &MSPMessageCryptoService{
 		channelPolicyManagerGetter: channelPolicyManagerGetter,
+		ordererConfigGetter:        ordererConfigGetter,
 		localSigner:                localSigner,
 		deserializer:               deserializer,
 		hasher:                     hasher,
@@ -141,9 +164,37 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 		return fmt.Errorf("Invalid block's channel id. Expected [%s]. Given [%s]", chainID, channelID)
 	}
 
+	// - Verify that Header.DataHash is equal to the hash of block.Data
+	// This is to ensure that the header is consistent with the data carried by this block
+	if !bytes.Equal(protoutil.BlockDataHash(block.Data), block.Header.DataHash) {
+		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
+	}
+
+	return s.verifyHeaderSignatures(channelID, block)
+}
+
+// VerifyHeader returns nil if the header of the block, whose data may be stripped,
+// is properly signed, and the claimed seqNum is the sequence number that it contains.
+// else returns error
+func (s *MSPMessageCryptoService) VerifyHeader(chainID common.ChannelID, seqNum uint64, block *pcommon.Block) error {
+	if block.Header == nil {
+		return fmt.Errorf("Invalid Block on channel [%s]. Header must be different from nil.", chainID)
+	}
+
+	blockSeqNum := block.Header.Number
+	if seqNum != blockSeqNum {
+		return fmt.Errorf("Claimed seqNum is [%d] but actual seqNum inside block is [%d]", seqNum, blockSeqNum)
+	}
+
+	return s.verifyHeaderSignatures(string(chainID), block)
+}
+
+// verifyHeaderSignatures checks that the signatures of the block over its header
+// satisfy the block validation policy of the channel.
+func (s *MSPMessageCryptoService) verifyHeaderSignatures(channelID string, block *pcommon.Block) error {
 	// - Unmarshal medatada
 	if block.Metadata == nil || len(block.Metadata.Metadata) == 0 {
-		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, chainID)
+		return fmt.Errorf("Block with id [%d] on channel [%s] does not have metadata. Block not valid.", block.Header.Number, channelID)
 	}
 
 	metadata, err := protoutil.GetMetadataFromBlock(block, pcommon.BlockMetadataIndex_SIGNATURES)
@@ -151,12 +202,6 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 		return fmt.Errorf("Failed unmarshalling medatata for signatures [%s]", err)
 	}
 
-	// - Verify that Header.DataHash is equal to the hash of block.Data
-	// This is to ensure that the header is consistent with the data carried by this block
-	if !bytes.Equal(protoutil.BlockDataHash(block.Data), block.Header.DataHash) {
-		return fmt.Errorf("Header.DataHash is different from Hash(block.Data) for block with id [%d] on channel [%s]", block.Header.Number, chainID)
-	}
-
 	// - Get Policy for block validation
 
 	// Get the policy manager for channelID
@@ -176,7 +221,7 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 	for _, metadataSignature := range metadata.Signatures {
 		shdr, err := protoutil.UnmarshalSignatureHeader(metadataSignature.SignatureHeader)
 		if err != nil {
-			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, chainID, err)
+			return fmt.Errorf("Failed unmarshalling signature header for block with id [%d] on channel [%s]: [%s]", block.Header.Number, channelID, err)
 		}
 		signatureSet = append(
 			signatureSet,
@@ -189,7 +234,75 @@ func (s *MSPMessageCryptoService) VerifyBlock(chainID common.ChannelID, seqNum u
 	}
 
 	// - Evaluate policy
-	return policy.EvaluateSignedData(signatureSet)
+	if err := policy.EvaluateSignedData(signatureSet); err != nil {
+		return err
+	}
+
+	return s.verifyConsenterQuorum(channelID, block.Header.Number, signatureSet)
+}
+
+// verifyConsenterQuorum checks that the block of a channel ordered by a BFT ordering service
+// is signed by a quorum of its consenters. A single consenter may satisfy the block validation
+// policy of the channel, but it cannot attest on its own that the block has been committed.
+func (s *MSPMessageCryptoService) verifyConsenterQuorum(channelID string, blockNum uint64, signatureSet []*protoutil.SignedData) error {
+	if s.ordererConfigGetter == nil {
+		return nil
+	}
+	oc, ok := s.ordererConfigGetter.OrdererConfig(channelID)
+	if !ok || oc.ConsensusType() != "BFT" {
+		return nil
+	}
+
+	configMetadata := &bftmsgs.ConfigMetadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), configMetadata); err != nil {
+		return fmt.Errorf("Failed unmarshalling BFT consensus metadata of channel [%s]: [%s]", channelID, err)
+	}
+	deserializer, ok := s.deserializer.GetChannelDeserializers()[channelID]
+	if !ok {
+		return fmt.Errorf("Could not acquire identity deserializer for channel %s", channelID)
+	}
+
+	signers := map[uint64]struct{}{}
+	for _, signedData := range signatureSet {
+		creator := &mspproto.SerializedIdentity{}
+		if err := proto.Unmarshal(signedData.Identity, creator); err != nil {
+			mcsLogger.Debugf("Failed unmarshalling signer of block with id [%d] on channel [%s]: [%s]", blockNum, channelID, err)
+			continue
+		}
+		consenter := findConsenter(configMetadata.Consenters, creator)
+		if consenter == nil {
+			continue
+		}
+		if _, signed := signers[consenter.Id]; signed {
+			continue
+		}
+		identity, err := deserializer.DeserializeIdentity(signedData.Identity)
+		if err != nil {
+			mcsLogger.Debugf("Failed deserializing consenter %d of channel [%s]: [%s]", consenter.Id, channelID, err)
+			continue
+		}
+		if err := identity.Verify(signedData.Data, signedData.Signature); err != nil {
+			mcsLogger.Debugf("Invalid signature of consenter %d over block with id [%d] on channel [%s]: [%s]", consenter.Id, blockNum, channelID, err)
+			continue
+		}
+		signers[consenter.Id] = struct{}{}
+	}
+
+	quorum, _ := bftmsgs.ComputeQuorum(len(configMetadata.Consenters))
+	if len(signers) < quorum {
+		return fmt.Errorf("Block with id [%d] on channel [%s] is signed by %d consenters but %d are required", blockNum, channelID, len(signers), quorum)
+	}
+	return nil
+}
+
+// findConsenter returns the consenter whose signing identity is the given one, or nil.
+func findConsenter(consenters []*bftmsgs.Consenter, identity *mspproto.SerializedIdentity) *bftmsgs.Consenter {
+	for _, consenter := range consenters {
+		if consenter.MspId == identity.Mspid && bytes.Equal(consenter.Identity, identity.IdBytes) {
+			return consenter
+		}
+	}
+	return nil
 }
 
 // Sign signs msg with this peer's signing key and outputs
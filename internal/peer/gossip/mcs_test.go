@@ -9,6 +9,7 @@ package gossip
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -19,6 +20,7 @@ import (
 	pmsp "github.com/hyperledger/fabric-protos-go/msp"
 	protospeer "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -26,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -37,6 +40,12 @@ type policyManager interface {
 	policies.Manager
 }
 
+//go:generate counterfeiter -o mocks/orderer_config.go --fake-name OrdererConfig . ordererConfig
+
+type ordererConfig interface {
+	channelconfig.Orderer
+}
+
 //go:generate counterfeiter -o mocks/signer_serializer.go --fake-name SignerSerializer . signerSerializer
 
 type signerSerializer interface {
@@ -55,6 +64,7 @@ func TestPKIidOfCert(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		signer,
 		deserializersManager,
+		nil,
 		cryptoProvider,
 	)
 
@@ -89,7 +99,7 @@ func TestPKIidOfNil(t *testing.T) {
 	signer := &mocks.SignerSerializer{}
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	require.NoError(t, err)
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), nil, cryptoProvider)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -111,6 +121,7 @@ func TestValidateIdentity(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		signer,
 		deserializersManager,
+		nil,
 		cryptoProvider,
 	)
 
@@ -148,6 +159,7 @@ func TestSign(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetter{},
 		signer,
 		mgmt.NewDeserializersManager(cryptoProvider),
+		nil,
 		cryptoProvider,
 	)
 
@@ -184,6 +196,7 @@ func TestVerify(t *testing.T) {
 				"C": &mocks.IdentityDeserializer{Identity: []byte("Dave"), Msg: []byte("msg4"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 		cryptoProvider,
 	)
 
@@ -242,6 +255,7 @@ func TestVerifyBlock(t *testing.T) {
 				"B": &mocks.IdentityDeserializer{Identity: []byte("Charlie"), Msg: []byte("msg3"), Mock: mock.Mock{}},
 			},
 		},
+		nil,
 		cryptoProvider,
 	)
 
@@ -275,6 +289,140 @@ func TestVerifyBlock(t *testing.T) {
 	require.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, &common.Block{}))
 }
 
+func TestVerifyHeader(t *testing.T) {
+	aliceSigner := &mocks.SignerSerializer{}
+	aliceSigner.SerializeReturns([]byte("Alice"), nil)
+	policyManagerGetter := &mocks.ChannelPolicyManagerGetterWithManager{
+		Managers: map[string]policies.Manager{
+			"A": &mocks.ChannelPolicyManager{
+				Policy: &mocks.Policy{Deserializer: &mocks.IdentityDeserializer{Identity: []byte("Bob"), Msg: []byte("msg2"), Mock: mock.Mock{}}},
+			},
+			"C": &mocks.ChannelPolicyManager{
+				Policy: &mocks.Policy{Deserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}}},
+			},
+		},
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	msgCryptoService := NewMCS(
+		policyManagerGetter,
+		aliceSigner,
+		&mocks.DeserializersManager{
+			LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+			ChannelDeserializers: map[string]msp.IdentityDeserializer{
+				"A": &mocks.IdentityDeserializer{Identity: []byte("Bob"), Msg: []byte("msg2"), Mock: mock.Mock{}},
+			},
+		},
+		nil,
+		cryptoProvider,
+	)
+
+	// - Prepare a block signed by Alice and strip its data
+	blockRaw, msg := mockBlock(t, "C", 42, aliceSigner, nil)
+	policyManagerGetter.Managers["C"].(*mocks.ChannelPolicyManager).Policy.(*mocks.Policy).Deserializer.(*mocks.IdentityDeserializer).Msg = msg
+	header := &common.Block{Header: blockRaw.Header, Metadata: blockRaw.Metadata}
+
+	require.NoError(t, msgCryptoService.VerifyHeader([]byte("C"), 42, header))
+	require.Error(t, msgCryptoService.VerifyBlock([]byte("C"), 42, header))
+	// Wrong sequence number claimed
+	err = msgCryptoService.VerifyHeader([]byte("C"), 43, header)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "but actual seqNum inside block is")
+	// Signed by an identity that does not satisfy the policy of the channel
+	require.Error(t, msgCryptoService.VerifyHeader([]byte("A"), 42, header))
+	err = msgCryptoService.VerifyHeader([]byte("D"), 42, header)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Could not acquire policy manager")
+
+	// Check invalid args
+	require.Error(t, msgCryptoService.VerifyHeader([]byte("C"), 42, &common.Block{}))
+	require.Error(t, msgCryptoService.VerifyHeader([]byte("C"), 42, &common.Block{Header: blockRaw.Header}))
+}
+
+func TestVerifyHeaderBFTQuorum(t *testing.T) {
+	var consenters []*bftmsgs.Consenter
+	var creators [][]byte
+	for i := 1; i <= 4; i++ {
+		consenter := &bftmsgs.Consenter{Id: uint64(i), MspId: "OrdererMSP", Identity: []byte(fmt.Sprintf("consenter-%d", i))}
+		consenters = append(consenters, consenter)
+		creators = append(creators, protoutil.MarshalOrPanic(&pmsp.SerializedIdentity{Mspid: consenter.MspId, IdBytes: consenter.Identity}))
+	}
+	ordererConfig := &mocks.OrdererConfig{}
+	ordererConfig.ConsensusTypeReturns("BFT")
+	ordererConfig.ConsensusMetadataReturns(protoutil.MarshalOrPanic(&bftmsgs.ConfigMetadata{Consenters: consenters}))
+
+	deserializer := consenterDeserializer{}
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	msgCryptoService := NewMCS(
+		&mocks.ChannelPolicyManagerGetterWithManager{
+			Managers: map[string]policies.Manager{
+				"C": &mocks.ChannelPolicyManager{Policy: &mocks.Policy{Deserializer: deserializer}},
+			},
+		},
+		&mocks.SignerSerializer{},
+		&mocks.DeserializersManager{
+			ChannelDeserializers: map[string]msp.IdentityDeserializer{"C": deserializer},
+		},
+		OrdererConfigGetterFunc(func(string) (channelconfig.Orderer, bool) { return ordererConfig, true }),
+		cryptoProvider,
+	)
+
+	// signedHeader returns the header of block 42 signed by the given consenters,
+	// and forged signatures of the given consenters
+	signedHeader := func(signers []int, forgers []int) *common.Block {
+		block := protoutil.NewBlock(42, nil)
+		block.Header.DataHash = []byte("data hash")
+		metadata := &common.Metadata{}
+		for _, i := range append(signers, forgers...) {
+			sigHdr := protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: creators[i]})
+			msg := util.ConcatenateBytes(sigHdr, protoutil.BlockHeaderBytes(block.Header))
+			deserializer[string(creators[i])] = &mocks.Identity{Msg: msg}
+			metadata.Signatures = append(metadata.Signatures, &common.MetadataSignature{SignatureHeader: sigHdr, Signature: msg})
+		}
+		for i := len(signers); i < len(metadata.Signatures); i++ {
+			metadata.Signatures[i].Signature = []byte("forged")
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(metadata)
+		return block
+	}
+
+	// Signed by a quorum of consenters
+	require.NoError(t, msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0, 1, 2}, nil)))
+	require.NoError(t, msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0, 1, 2, 3}, nil)))
+
+	// Signed by a single consenter, which satisfies the block validation policy
+	err = msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0}, nil))
+	require.EqualError(t, err, "Block with id [42] on channel [C] is signed by 1 consenters but 3 are required")
+
+	// The same consenter signing several times is counted once
+	err = msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0, 0, 0}, nil))
+	require.EqualError(t, err, "Block with id [42] on channel [C] is signed by 1 consenters but 3 are required")
+
+	// Forged signatures are not counted
+	err = msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0, 1}, []int{2}))
+	require.EqualError(t, err, "Block with id [42] on channel [C] is signed by 2 consenters but 3 are required")
+
+	// Channels that are not ordered by a BFT ordering service only check the block validation policy
+	ordererConfig.ConsensusTypeReturns("etcdraft")
+	require.NoError(t, msgCryptoService.VerifyHeader([]byte("C"), 42, signedHeader([]int{0}, nil)))
+}
+
+// consenterDeserializer deserializes the identities of the consenters it maps
+type consenterDeserializer map[string]*mocks.Identity
+
+func (d consenterDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	if identity, ok := d[string(serializedIdentity)]; ok {
+		return identity, nil
+	}
+	return nil, errors.New("Invalid Identity")
+}
+
+func (d consenterDeserializer) IsWellFormed(identity *pmsp.SerializedIdentity) error {
+	return nil
+}
+
 func mockBlock(t *testing.T, channel string, seqNum uint64, localSigner *mocks.SignerSerializer, dataHash []byte) (*common.Block, []byte) {
 	block := protoutil.NewBlock(seqNum, nil)
 
@@ -359,6 +507,7 @@ func TestExpiration(t *testing.T) {
 		&mocks.ChannelPolicyManagerGetterWithManager{},
 		&mocks.SignerSerializer{},
 		deserializersManager,
+		nil,
 		cryptoProvider,
 	)
 
@@ -7,10 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"encoding/hex"
 	"path/filepath"
+	"time"
 
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/ledger/encryption"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -65,6 +70,20 @@ func ledgerConfig() *ledger.Config {
 		},
 	}
 
+	if viper.GetBool("ledger.history.retention.enabled") {
+		conf.HistoryDBConfig.Retention = historyRetentionConfig()
+	}
+
+	if viper.GetBool("ledger.blockArchive.enabled") {
+		conf.BlockArchiveConfig = &ledger.BlockArchiveConfig{
+			Directory:           viper.GetString("ledger.blockArchive.directory"),
+			Endpoint:            viper.GetString("ledger.blockArchive.endpoint"),
+			KeepRecentBlocks:    uint64(viper.GetInt64("ledger.blockArchive.keepRecentBlocks")),
+			MinAge:              viper.GetDuration("ledger.blockArchive.minAge"),
+			MaxCachedBlockFiles: viper.GetInt("ledger.blockArchive.maxCachedBlockFiles"),
+		}
+	}
+
 	if conf.StateDBConfig.StateDatabase == ledger.CouchDB {
 		conf.StateDBConfig.CouchDB = &ledger.CouchDBConfig{
 			Address:                 viper.GetString("ledger.state.couchDBConfig.couchDBAddress"),
@@ -81,5 +100,95 @@ func ledgerConfig() *ledger.Config {
 			UserCacheSizeMBs:        viper.GetInt("ledger.state.couchDBConfig.cacheSize"),
 		}
 	}
+
+	if conf.StateDBConfig.StateDatabase == ledger.SQL {
+		sqlInternalQueryLimit := 1000
+		if viper.IsSet("ledger.state.sqlConfig.internalQueryLimit") {
+			sqlInternalQueryLimit = viper.GetInt("ledger.state.sqlConfig.internalQueryLimit")
+		}
+		requestTimeout := 35 * time.Second
+		if viper.IsSet("ledger.state.sqlConfig.requestTimeout") {
+			requestTimeout = viper.GetDuration("ledger.state.sqlConfig.requestTimeout")
+		}
+		conf.StateDBConfig.SQL = &ledger.SQLConfig{
+			DriverName:         viper.GetString("ledger.state.sqlConfig.driverName"),
+			DataSourceName:     viper.GetString("ledger.state.sqlConfig.dataSourceName"),
+			MaxOpenConnections: viper.GetInt("ledger.state.sqlConfig.maxOpenConnections"),
+			RequestTimeout:     requestTimeout,
+			InternalQueryLimit: sqlInternalQueryLimit,
+		}
+	}
 	return conf
 }
+
+// historyRetentionPolicyConfig is the peer configuration of a history retention policy
+type historyRetentionPolicyConfig struct {
+	Name             string
+	RetainBlocks     uint64
+	PruneBelowHeight uint64
+	Namespaces       []historyRetentionPolicyConfig
+}
+
+func historyRetentionConfig() *ledger.HistoryRetentionConfig {
+	pruneInterval := uint64(1000)
+	if viper.IsSet("ledger.history.retention.pruneInterval") {
+		pruneInterval = uint64(viper.GetInt64("ledger.history.retention.pruneInterval"))
+	}
+	var channels []historyRetentionPolicyConfig
+	if err := viper.UnmarshalKey("ledger.history.retention.channels", &channels); err != nil {
+		logger.Panicf("Invalid history retention policies of channels: %s", err)
+	}
+
+	conf := &ledger.HistoryRetentionConfig{
+		Default: ledger.HistoryRetentionPolicy{
+			RetainBlocks:     uint64(viper.GetInt64("ledger.history.retention.retainBlocks")),
+			PruneBelowHeight: uint64(viper.GetInt64("ledger.history.retention.pruneBelowHeight")),
+		},
+		PruneInterval: pruneInterval,
+	}
+	for _, c := range channels {
+		channelConf := &ledger.ChannelHistoryRetentionConfig{
+			Name: c.Name,
+			Policy: ledger.HistoryRetentionPolicy{
+				RetainBlocks:     c.RetainBlocks,
+				PruneBelowHeight: c.PruneBelowHeight,
+			},
+			Namespaces: map[string]ledger.HistoryRetentionPolicy{},
+		}
+		for _, ns := range c.Namespaces {
+			channelConf.Namespaces[ns.Name] = ledger.HistoryRetentionPolicy{
+				RetainBlocks:     ns.RetainBlocks,
+				PruneBelowHeight: ns.PruneBelowHeight,
+			}
+		}
+		conf.Channels = append(conf.Channels, channelConf)
+	}
+	return conf
+}
+
+// transientStorePath returns the path of the transient store of the peer
+func transientStorePath() string {
+	return filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore")
+}
+
+// ledgerEncryptor returns the encryptor for the ledger and the transient store data at rest, as per
+// the peer configuration. The keys are identified by their hex encoded SKIs and are retrieved from
+// the supplied BCCSP. A nil encryptor is returned if the encryption is not enabled
+func ledgerEncryptor(csp bccsp.BCCSP) (*encryption.Encryptor, error) {
+	if !viper.GetBool("ledger.encryption.enabled") {
+		return nil, nil
+	}
+	activeKeyID, err := hex.DecodeString(viper.GetString("ledger.encryption.activeKey"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid ledger encryption active key")
+	}
+	var retiredKeyIDs [][]byte
+	for _, k := range viper.GetStringSlice("ledger.encryption.retiredKeys") {
+		retiredKeyID, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid ledger encryption retired key [%s]", k)
+		}
+		retiredKeyIDs = append(retiredKeyIDs, retiredKeyID)
+	}
+	return encryption.NewEncryptor(csp, activeKeyID, retiredKeyIDs)
+}
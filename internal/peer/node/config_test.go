@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"encoding/hex"
 	"testing"
 	"time"
 
+	encryptiontestutil "github.com/hyperledger/fabric/common/ledger/encryption/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
@@ -147,11 +149,179 @@ func TestLedgerConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SQL Defaults",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                   "/peerfs",
+				"ledger.state.stateDatabase":            "SQL",
+				"ledger.state.sqlConfig.driverName":     "postgres",
+				"ledger.state.sqlConfig.dataSourceName": "postgres://peer@localhost/fabric",
+				"ledger.snapshots.rootDir":              "/peerfs/snapshots",
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "SQL",
+					CouchDB:       &ledger.CouchDBConfig{},
+					SQL: &ledger.SQLConfig{
+						DriverName:         "postgres",
+						DataSourceName:     "postgres://peer@localhost/fabric",
+						RequestTimeout:     35 * time.Second,
+						InternalQueryLimit: 1000,
+					},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    5000,
+					BatchesInterval: 1000,
+					PurgeInterval:   100,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+			},
+		},
+		{
+			name: "SQL Explicit",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                       "/peerfs",
+				"ledger.state.stateDatabase":                "SQL",
+				"ledger.state.sqlConfig.driverName":         "pgx",
+				"ledger.state.sqlConfig.dataSourceName":     "postgres://peer@localhost/fabric",
+				"ledger.state.sqlConfig.maxOpenConnections": 20,
+				"ledger.state.sqlConfig.requestTimeout":     "10s",
+				"ledger.state.sqlConfig.internalQueryLimit": 500,
+				"ledger.snapshots.rootDir":                  "/peerfs/snapshots",
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "SQL",
+					CouchDB:       &ledger.CouchDBConfig{},
+					SQL: &ledger.SQLConfig{
+						DriverName:         "pgx",
+						DataSourceName:     "postgres://peer@localhost/fabric",
+						MaxOpenConnections: 20,
+						RequestTimeout:     10 * time.Second,
+						InternalQueryLimit: 500,
+					},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    5000,
+					BatchesInterval: 1000,
+					PurgeInterval:   100,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/snapshots",
+				},
+			},
+		},
+		{
+			name: "Block Archive",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                     "/peerfs",
+				"ledger.state.stateDatabase":              "goleveldb",
+				"ledger.blockArchive.enabled":             true,
+				"ledger.blockArchive.directory":           "/archive",
+				"ledger.blockArchive.endpoint":            "https://objectstore.example.com/blocks",
+				"ledger.blockArchive.keepRecentBlocks":    1000,
+				"ledger.blockArchive.minAge":              "12h",
+				"ledger.blockArchive.maxCachedBlockFiles": 8,
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "goleveldb",
+					CouchDB:       &ledger.CouchDBConfig{},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    5000,
+					BatchesInterval: 1000,
+					PurgeInterval:   100,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: false,
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/ledgersData/snapshots",
+				},
+				BlockArchiveConfig: &ledger.BlockArchiveConfig{
+					Directory:           "/archive",
+					Endpoint:            "https://objectstore.example.com/blocks",
+					KeepRecentBlocks:    1000,
+					MinAge:              12 * time.Hour,
+					MaxCachedBlockFiles: 8,
+				},
+			},
+		},
+		{
+			name: "History Retention",
+			config: map[string]interface{}{
+				"peer.fileSystemPath":                       "/peerfs",
+				"ledger.state.stateDatabase":                "goleveldb",
+				"ledger.history.enableHistoryDatabase":      true,
+				"ledger.history.retention.enabled":          true,
+				"ledger.history.retention.retainBlocks":     100000,
+				"ledger.history.retention.pruneBelowHeight": 10,
+				"ledger.history.retention.channels": []interface{}{
+					map[string]interface{}{
+						"name":         "mychannel",
+						"retainBlocks": 500,
+						"namespaces": []interface{}{
+							map[string]interface{}{
+								"name":             "MyCC",
+								"pruneBelowHeight": 2000,
+							},
+						},
+					},
+				},
+			},
+			expected: &ledger.Config{
+				RootFSPath: "/peerfs/ledgersData",
+				StateDBConfig: &ledger.StateDBConfig{
+					StateDatabase: "goleveldb",
+					CouchDB:       &ledger.CouchDBConfig{},
+				},
+				PrivateDataConfig: &ledger.PrivateDataConfig{
+					MaxBatchSize:    5000,
+					BatchesInterval: 1000,
+					PurgeInterval:   100,
+				},
+				HistoryDBConfig: &ledger.HistoryDBConfig{
+					Enabled: true,
+					Retention: &ledger.HistoryRetentionConfig{
+						Default: ledger.HistoryRetentionPolicy{
+							RetainBlocks:     100000,
+							PruneBelowHeight: 10,
+						},
+						Channels: []*ledger.ChannelHistoryRetentionConfig{
+							{
+								Name:   "mychannel",
+								Policy: ledger.HistoryRetentionPolicy{RetainBlocks: 500},
+								Namespaces: map[string]ledger.HistoryRetentionPolicy{
+									"MyCC": {PruneBelowHeight: 2000},
+								},
+							},
+						},
+						PruneInterval: 1000,
+					},
+				},
+				SnapshotsConfig: &ledger.SnapshotsConfig{
+					RootDir: "/peerfs/ledgersData/snapshots",
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		_test := test
 		t.Run(_test.name, func(t *testing.T) {
+			viper.Reset()
 			for k, v := range _test.config {
 				viper.Set(k, v)
 			}
@@ -160,3 +330,36 @@ func TestLedgerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLedgerEncryptor(t *testing.T) {
+	defer viper.Reset()
+	csp, cleanup := encryptiontestutil.NewCryptoProvider(t)
+	defer cleanup()
+	activeKeyID, retiredKeyID := encryptiontestutil.GenerateKey(t, csp), encryptiontestutil.GenerateKey(t, csp)
+
+	encryptor, err := ledgerEncryptor(csp)
+	require.NoError(t, err)
+	require.Nil(t, encryptor)
+
+	viper.Set("ledger.encryption.enabled", true)
+	viper.Set("ledger.encryption.activeKey", hex.EncodeToString(activeKeyID))
+	viper.Set("ledger.encryption.retiredKeys", []string{hex.EncodeToString(retiredKeyID)})
+	encryptor, err = ledgerEncryptor(csp)
+	require.NoError(t, err)
+	require.Equal(t, activeKeyID, encryptor.ActiveKeyID())
+	encrypted, err := encryptiontestutil.NewEncryptor(t, csp, retiredKeyID).Encrypt([]byte("value"))
+	require.NoError(t, err)
+	decrypted, err := encryptor.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), decrypted)
+
+	viper.Set("ledger.encryption.retiredKeys", []string{"not-hex"})
+	_, err = ledgerEncryptor(csp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid ledger encryption retired key [not-hex]")
+
+	viper.Set("ledger.encryption.retiredKeys", nil)
+	viper.Set("ledger.encryption.activeKey", "")
+	_, err = ledgerEncryptor(csp)
+	require.EqualError(t, err, "an active key is required for the ledger encryption")
+}
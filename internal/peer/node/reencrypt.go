@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func reencryptCmd() *cobra.Command {
+	return nodeReencryptCmd
+}
+
+var nodeReencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Encrypts the ledger data with the active key.",
+	Long:  `Encrypts the block files, the state, history and private data stores of all channels and the transient store with the active ledger encryption key. The data in clear is encrypted, and the data encrypted with a retired key is re-encrypted, after which the retired keys are not needed anymore. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		encryptor, err := ledgerEncryptor(factory.GetDefault())
+		if err != nil {
+			return err
+		}
+		if encryptor == nil {
+			return errors.New("the ledger encryption is not enabled")
+		}
+		config := ledgerConfig()
+		if err := kvledger.ReencryptKVLedgers(config.RootFSPath, encryptor); err != nil {
+			return err
+		}
+		exists, err := fileutil.DirExists(transientStorePath())
+		if err != nil || !exists {
+			return err
+		}
+		return leveldbhelper.Reencrypt(transientStorePath(), encryptor)
+	},
+}
@@ -0,0 +1,23 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReencryptCmdWithoutEncryption(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("ledger.encryption.enabled", false)
+	cmd := reencryptCmd()
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	require.EqualError(t, cmd.Execute(), "the ledger encryption is not enabled")
+}
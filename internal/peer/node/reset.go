@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +22,10 @@ var nodeResetCmd = &cobra.Command{
 	Long:  `Resets all channels to the genesis block. When the command is executed, the peer must be offline. When the peer starts after the reset, it will receive blocks starting with block number one from an orderer or another peer to rebuild the block store and state database.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		config := ledgerConfig()
-		return kvledger.ResetAllKVLedgers(config.RootFSPath)
+		encryptor, err := ledgerEncryptor(factory.GetDefault())
+		if err != nil {
+			return err
+		}
+		return kvledger.ResetAllKVLedgers(config.RootFSPath, encryptor)
 	},
 }
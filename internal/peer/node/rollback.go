@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/pkg/errors"
@@ -37,6 +38,10 @@ var nodeRollbackCmd = &cobra.Command{
 		}
 
 		config := ledgerConfig()
-		return kvledger.RollbackKVLedger(config.RootFSPath, channelID, blockNumber)
+		encryptor, err := ledgerEncryptor(factory.GetDefault())
+		if err != nil {
+			return err
+		}
+		return kvledger.RollbackKVLedger(config.RootFSPath, channelID, blockNumber, encryptor)
 	},
 }
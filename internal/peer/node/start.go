@@ -55,9 +55,11 @@ import (
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/kubernetescontroller"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/endorserpb"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
@@ -67,6 +69,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -74,6 +77,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/core/scc/xcc"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
@@ -91,8 +95,10 @@ import (
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/internal/pkg/gateway"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
+	gatewayprotos "github.com/hyperledger/fabric/pkg/gateway"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -151,6 +157,10 @@ func (e externalVMAdapter) Build(
 	return i, err
 }
 
+func (e externalVMAdapter) Purge(ccid string) error {
+	return e.detector.Purge(ccid)
+}
+
 type disabledDockerBuilder struct{}
 
 func (disabledDockerBuilder) Build(string, *persistence.ChaincodePackageMetadata, io.Reader) (container.Instance, error) {
@@ -171,6 +181,26 @@ func (e endorserChannelAdapter) Channel(channelID string) *endorser.Channel {
 	return nil
 }
 
+type channelLedgersAdapter struct {
+	peer *peer.Peer
+}
+
+func (c channelLedgersAdapter) ChannelIDs() []string {
+	var channelIDs []string
+	for _, channelInfo := range c.peer.GetChannelsInfo() {
+		channelIDs = append(channelIDs, channelInfo.ChannelId)
+	}
+	return channelIDs
+}
+
+func (c channelLedgersAdapter) NewQueryExecutor(channelID string) (lifecycle.ChannelQueryExecutor, error) {
+	l := c.peer.GetLedger(channelID)
+	if l == nil {
+		return nil, errors.Errorf("channel '%s' not found", channelID)
+	}
+	return l.NewQueryExecutor()
+}
+
 type custodianLauncherAdapter struct {
 	launcher      chaincode.Launcher
 	streamHandler extcc.StreamHandler
@@ -279,8 +309,14 @@ func serve(args []string) error {
 		cs.SetClientCertificate(clientCert)
 	}
 
-	transientStoreProvider, err := transientstore.NewStoreProvider(
-		filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "transientstore"),
+	ledgerEncryptor, err := ledgerEncryptor(factory.GetDefault())
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize the ledger encryption")
+	}
+
+	transientStoreProvider, err := transientstore.NewStoreProviderWithEncryption(
+		transientStorePath(),
+		ledgerEncryptor,
 	)
 	if err != nil {
 		return errors.WithMessage(err, "failed to open transient store")
@@ -428,6 +464,7 @@ func serve(args []string) error {
 			CustomTxProcessors:              txProcessors,
 			DeployedChaincodeInfoProvider:   lifecycleValidatorCommitter,
 			MembershipInfoProvider:          membershipInfoProvider,
+			ChannelCapabilitiesProvider:     lifecycleValidatorCommitter,
 			ChaincodeLifecycleEventProvider: lifecycleCache,
 			MetricsProvider:                 metricsProvider,
 			HealthCheckRegistry:             opsSystem,
@@ -435,6 +472,7 @@ func serve(args []string) error {
 			Config:                          ledgerConfig(),
 			HashProvider:                    factory.GetDefault(),
 			EbMetadataProvider:              ebMetadataProvider,
+			Encryptor:                       ledgerEncryptor,
 		},
 	)
 
@@ -447,6 +485,7 @@ func serve(args []string) error {
 	// of go routines and registration with the grpc server.
 	gossipService, err := initGossipService(
 		policyMgr,
+		peergossip.OrdererConfigGetterFunc(peerInstance.GetOrdererConfig),
 		metricsProvider,
 		peerServer,
 		signingIdentity,
@@ -563,6 +602,20 @@ func serve(args []string) error {
 			logger.Panicf("failed to register docker health check: %s", err)
 		}
 		dockerBuilder = dockerVM
+
+		// the chaincode images are built with docker and run in Kubernetes pods
+		if coreConfig.VMKubernetesEnabled {
+			kubernetesVM, err := createKubernetesVM(coreConfig, client, dockerVM)
+			if err != nil {
+				logger.Panicf("cannot create kubernetes runtime: %s", err)
+			}
+			if err := opsSystem.RegisterChecker("kubernetes", kubernetesVM); err != nil {
+				logger.Panicf("failed to register kubernetes health check: %s", err)
+			}
+			dockerBuilder = kubernetesVM
+		}
+	} else if coreConfig.VMKubernetesEnabled {
+		logger.Panic("vm.kubernetes is enabled but the VMEndpoint the chaincode images are built with is not set")
 	}
 
 	// docker is disabled when we're missing the docker config
@@ -593,6 +646,7 @@ func serve(args []string) error {
 		"qscc":       {},
 		"cscc":       {},
 		"_lifecycle": {},
+		"xcc":        {},
 	}
 
 	lsccInst := &lscc.SCC{
@@ -625,9 +679,13 @@ func serve(args []string) error {
 	lifecycleFunctions := &lifecycle.ExternalFunctions{
 		Resources:                 lifecycleResources,
 		InstallListener:           lifecycleCache,
+		UninstallListener:         lifecycleCache,
 		InstalledChaincodesLister: lifecycleCache,
 		ChaincodeBuilder:          containerRouter,
+		ChaincodePurger:           containerRouter,
 		BuildRegistry:             buildRegistry,
+		ChannelLedgers:            channelLedgersAdapter{peer: peerInstance},
+		OrgMSPID:                  mspID,
 	}
 
 	lifecycleSCC := &lifecycle.SCC{
@@ -650,7 +708,7 @@ func serve(args []string) error {
 		CertGenerator:     authenticator,
 		CACert:            ca.CertBytes(),
 		PeerAddress:       ccEndpoint,
-		ConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+		ConnectionHandler: &extcc.ExternalChaincodeRuntime{Metrics: extcc.NewMetrics(metricsProvider)},
 	}
 
 	// Keep TestQueries working
@@ -659,21 +717,22 @@ func serve(args []string) error {
 	}
 
 	chaincodeSupport := &chaincode.ChaincodeSupport{
-		ACLProvider:            aclProvider,
-		AppConfig:              peerInstance,
-		DeployedCCInfoProvider: lifecycleValidatorCommitter,
-		ExecuteTimeout:         chaincodeConfig.ExecuteTimeout,
-		InstallTimeout:         chaincodeConfig.InstallTimeout,
-		HandlerRegistry:        chaincodeHandlerRegistry,
-		HandlerMetrics:         chaincode.NewHandlerMetrics(opsSystem.Provider),
-		Keepalive:              chaincodeConfig.Keepalive,
-		Launcher:               chaincodeLauncher,
-		Lifecycle:              chaincodeEndorsementInfo,
-		Peer:                   peerInstance,
-		Runtime:                containerRuntime,
-		BuiltinSCCs:            builtinSCCs,
-		TotalQueryLimit:        chaincodeConfig.TotalQueryLimit,
-		UserRunsCC:             userRunsCC,
+		ACLProvider:               aclProvider,
+		AppConfig:                 peerInstance,
+		DeployedCCInfoProvider:    lifecycleValidatorCommitter,
+		ExecuteTimeout:            chaincodeConfig.ExecuteTimeout,
+		InstallTimeout:            chaincodeConfig.InstallTimeout,
+		HandlerRegistry:           chaincodeHandlerRegistry,
+		HandlerMetrics:            chaincode.NewHandlerMetrics(opsSystem.Provider),
+		Keepalive:                 chaincodeConfig.Keepalive,
+		Launcher:                  chaincodeLauncher,
+		Lifecycle:                 chaincodeEndorsementInfo,
+		Peer:                      peerInstance,
+		Runtime:                   containerRuntime,
+		BuiltinSCCs:               builtinSCCs,
+		CrossChannelTimeoutBlocks: chaincodeConfig.CrossChannelTimeoutBlocks,
+		TotalQueryLimit:           chaincodeConfig.TotalQueryLimit,
+		UserRunsCC:                userRunsCC,
 	}
 
 	custodianLauncher := custodianLauncherAdapter{
@@ -697,6 +756,7 @@ func serve(args []string) error {
 		factory.GetDefault(),
 	)
 	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
+	xccInst := xcc.New(aclProvider, peerInstance, peerInstance)
 
 	pb.RegisterChaincodeSupportServer(ccSrv.Server(), ccSupSrv)
 
@@ -741,10 +801,26 @@ func serve(args []string) error {
 		LocalMSP:               localMSP,
 		Support:                endorserSupport,
 		Metrics:                endorser.NewMetrics(metricsProvider),
+		BatchParallelism:       coreConfig.LimitsConcurrencyEndorserBatch,
+		ProposalLimiter:        semaphores["/protos.Endorser"],
+	}
+	switch coreConfig.ConflictDetectionMode {
+	case endorser.ConflictDetectionDisabled:
+	case endorser.ConflictDetectionWarn, endorser.ConflictDetectionReject:
+		serverEndorser.ConflictDetector = &endorser.ConflictDetector{
+			Reject:         coreConfig.ConflictDetectionMode == endorser.ConflictDetectionReject,
+			PendingTimeout: coreConfig.ConflictDetectionPendingTimeout,
+			Metrics:        serverEndorser.Metrics,
+		}
+		// the committed transactions are dropped from the detector as their blocks are committed
+		peerInstance.BlockCommitListeners = append(peerInstance.BlockCommitListeners, serverEndorser.ConflictDetector)
+	default:
+		return errors.Errorf("invalid conflict detection mode '%s', must be one of '%s', '%s' or '%s'", coreConfig.ConflictDetectionMode,
+			endorser.ConflictDetectionDisabled, endorser.ConflictDetectionWarn, endorser.ConflictDetectionReject)
 	}
 
 	// deploy system chaincodes
-	for _, cc := range []scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, lifecycleSCC} {
+	for _, cc := range []scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, lifecycleSCC, xccInst} {
 		if enabled, ok := chaincodeConfig.SCCAllowlist[cc.Name()]; !ok || !enabled {
 			logger.Infof("not deploying chaincode %s as it is not enabled", cc.Name())
 			continue
@@ -798,8 +874,9 @@ func serve(args []string) error {
 		coreConfig.ValidatorPoolSize,
 	)
 
+	var discoverySupport *discsupport.DiscoverySupport
 	if coreConfig.DiscoveryEnabled {
-		registerDiscoveryService(
+		discoverySupport = registerDiscoveryService(
 			coreConfig,
 			peerInstance,
 			peerServer,
@@ -869,8 +946,39 @@ func serve(args []string) error {
 
 	// start the peer server
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
+	serverEndorser.ProposalProcessor = auth
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
+	// Register the batch endorser server, whose proposals go through the auth filters one by one
+	endorserpb.RegisterBatchEndorserServer(peerServer.Server(), serverEndorser)
+
+	// register the snapshot server
+	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
+	snapshotgrpc.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
+
+	// register the gateway server
+	gatewayOptions := gateway.GetOptions(viper.GetViper())
+	if gatewayOptions.Enabled {
+		if discoverySupport == nil {
+			logger.Warning("The gateway service requires the discovery service, which is disabled; the gateway service will not be started")
+		} else {
+			logger.Info("Starting peer with Gateway enabled")
+			gatewayServer := gateway.CreateServer(
+				auth,
+				discoverySupport,
+				peerInstance,
+				aclProvider,
+				comm.ClientConfig{
+					KaOpts:  deliverServiceConfig.KeepaliveOptions,
+					SecOpts: deliverServiceConfig.SecOpts,
+				},
+				gossipService.SelfMembershipInfo().Endpoint,
+				coreConfig.LocalMSPID,
+				gatewayOptions,
+			)
+			gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
+		}
+	}
 
 	go func() {
 		var grpcErr error
@@ -936,7 +1044,7 @@ func registerDiscoveryService(
 	polMgr policies.ChannelPolicyManagerGetter,
 	metadataProvider *lifecycle.MetadataProvider,
 	gossipService *gossipservice.GossipService,
-) {
+) *discsupport.DiscoverySupport {
 	mspID := coreConfig.LocalMSPID
 	localAccessPolicy := localPolicy(policydsl.SignedByAnyAdmin([]string{mspID}))
 	if coreConfig.DiscoveryOrgMembersAllowed {
@@ -968,6 +1076,7 @@ func registerDiscoveryService(
 	}, support)
 	logger.Info("Discovery service activated")
 	discprotos.RegisterDiscoveryServer(peerServer.Server(), svc)
+	return support
 }
 
 // create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
@@ -1120,6 +1229,38 @@ func createDockerClient(coreConfig *peer.Config) (*docker.Client, error) {
 	return docker.NewClient(coreConfig.VMEndpoint)
 }
 
+func createKubernetesVM(coreConfig *peer.Config, client *docker.Client, dockerVM *dockercontroller.DockerVM) (*kubernetescontroller.KubernetesVM, error) {
+	kubernetesClient, err := kubernetescontroller.NewClient(kubernetescontroller.ClientConfig{
+		APIServer: coreConfig.VMKubernetesAPIServer,
+		Namespace: coreConfig.VMKubernetesNamespace,
+		TokenFile: coreConfig.VMKubernetesTokenFile,
+		CAFile:    coreConfig.VMKubernetesCAFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetescontroller.KubernetesVM{
+		PeerID:    coreConfig.PeerID,
+		NetworkID: coreConfig.NetworkID,
+		Client:    kubernetesClient,
+		ImageBuilder: &kubernetescontroller.DockerImageBuilder{
+			DockerVM: dockerVM,
+			Client:   client,
+			Registry: coreConfig.VMKubernetesRegistry,
+			Auth: docker.AuthConfiguration{
+				Username: coreConfig.VMKubernetesRegistryUsername,
+				Password: coreConfig.VMKubernetesRegistryPassword,
+			},
+		},
+		ServiceAccount:  coreConfig.VMKubernetesServiceAccount,
+		ImagePullPolicy: coreConfig.VMKubernetesImagePullPolicy,
+		AttachStdOut:    coreConfig.VMKubernetesAttachStdout,
+		LoggingEnv:      dockerVM.LoggingEnv,
+		MSPID:           dockerVM.MSPID,
+	}, nil
+}
+
 // secureDialOpts is the callback function for secure dial options for gossip service
 func secureDialOpts(credSupport *comm.CredentialSupport) func() []grpc.DialOption {
 	return func() []grpc.DialOption {
@@ -1155,6 +1296,7 @@ func secureDialOpts(credSupport *comm.CredentialSupport) func() []grpc.DialOptio
 // 4. Init gossip related struct.
 func initGossipService(
 	policyMgr policies.ChannelPolicyManagerGetter,
+	ordererConfigGetter peergossip.OrdererConfigGetter,
 	metricsProvider metrics.Provider,
 	peerServer *comm.GRPCServer,
 	signer msp.SigningIdentity,
@@ -1181,6 +1323,7 @@ func initGossipService(
 		policyMgr,
 		signer,
 		mgmt.NewDeserializersManager(factory.GetDefault()),
+		ordererConfigGetter,
 		factory.GetDefault(),
 	)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager(factory.GetDefault()))
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func cancelRequestCmd(cf *SnapshotCmdFactory) *cobra.Command {
+	snapshotCancelRequestCmd := &cobra.Command{
+		Use:   "cancelrequest",
+		Short: "Cancel a request for a snapshot at the specified block.",
+		Long:  "Cancel a pending request for a snapshot at the specified block. A request cannot be cancelled once the snapshot generation for the request has started.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cancelRequest(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"blockNumber",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotCancelRequestCmd, flagList)
+
+	return snapshotCancelRequestCmd
+}
+
+func cancelRequest(cmd *cobra.Command, cf *SnapshotCmdFactory) error {
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	if blockNumber == 0 {
+		return errors.New("the required parameter 'blockNumber' is empty or set to 0. Rerun the command with -b flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	sigHdr, err := signatureHeader(cf.Signer)
+	if err != nil {
+		return err
+	}
+	request := &snapshotgrpc.SnapshotRequest{
+		SignatureHeader: sigHdr,
+		ChannelId:       channelID,
+		BlockNumber:     blockNumber,
+	}
+	signedRequest, err := signSnapshotRequest(cf.Signer, request)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cf.SnapshotClient.Cancel(context.Background(), signedRequest); err != nil {
+		return errors.WithMessage(err, "failed to cancel snapshot request")
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), "Snapshot request cancelled successfully\n")
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelRequest(t *testing.T) {
+	defer resetFlags()
+
+	cf, fakeSnapshotClient, _ := newTestCmdFactory()
+	buf := &bytes.Buffer{}
+	cmd := cancelRequestCmd(cf)
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "100"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Snapshot request cancelled successfully\n", buf.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.CancelCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.CancelArgsForCall(0)
+	request := &snapshotgrpc.SnapshotRequest{}
+	verifySignedRequest(t, signedRequest, request)
+	verifySignatureHeader(t, request.SignatureHeader)
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, uint64(100), request.BlockNumber)
+}
+
+func TestCancelRequestErrors(t *testing.T) {
+	defer resetFlags()
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		resetFlags()
+		cf, _, _ := newTestCmdFactory()
+		cmd := cancelRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-b", "100"})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	})
+
+	t.Run("missing block number", func(t *testing.T) {
+		resetFlags()
+		cf, _, _ := newTestCmdFactory()
+		cmd := cancelRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel"})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'blockNumber' is empty or set to 0. Rerun the command with -b flag")
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		resetFlags()
+		cf, fakeSnapshotClient, _ := newTestCmdFactory()
+		fakeSnapshotClient.CancelReturns(nil, errors.New("no snapshot request exists"))
+		cmd := cancelRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel", "-b", "100"})
+		require.EqualError(t, cmd.Execute(), "failed to cancel snapshot request: no snapshot request exists")
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func listPendingCmd(cf *SnapshotCmdFactory) *cobra.Command {
+	snapshotListPendingCmd := &cobra.Command{
+		Use:   "listpending",
+		Short: "List pending requests for snapshots.",
+		Long:  "List the block numbers of the pending (or under processing) requests for snapshots on the channel.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPending(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotListPendingCmd, flagList)
+
+	return snapshotListPendingCmd
+}
+
+func listPending(cmd *cobra.Command, cf *SnapshotCmdFactory) error {
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	sigHdr, err := signatureHeader(cf.Signer)
+	if err != nil {
+		return err
+	}
+	query := &snapshotgrpc.SnapshotQuery{
+		SignatureHeader: sigHdr,
+		ChannelId:       channelID,
+	}
+	signedRequest, err := signSnapshotRequest(cf.Signer, query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := cf.SnapshotClient.QueryPendings(context.Background(), signedRequest)
+	if err != nil {
+		return errors.WithMessage(err, "failed to list pending snapshot requests")
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Successfully got pending snapshot requests: %v\n", resp.BlockNumbers)
+	for _, blockNumber := range resp.BlockNumbers {
+		if failure, ok := resp.Failures[blockNumber]; ok {
+			fmt.Fprintf(cmd.OutOrStdout(), "Snapshot generation failed for block number %d: %s\n", blockNumber, failure)
+		}
+	}
+	return nil
+}
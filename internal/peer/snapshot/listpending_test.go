@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPending(t *testing.T) {
+	defer resetFlags()
+
+	cf, fakeSnapshotClient, _ := newTestCmdFactory()
+	fakeSnapshotClient.QueryPendingsReturns(&snapshotgrpc.QueryPendingSnapshotsResponse{BlockNumbers: []uint64{100, 1000}}, nil)
+	buf := &bytes.Buffer{}
+	cmd := listPendingCmd(cf)
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Successfully got pending snapshot requests: [100 1000]\n", buf.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.QueryPendingsCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.QueryPendingsArgsForCall(0)
+	query := &snapshotgrpc.SnapshotQuery{}
+	verifySignedRequest(t, signedRequest, query)
+	verifySignatureHeader(t, query.SignatureHeader)
+	require.Equal(t, "mychannel", query.ChannelId)
+}
+
+func TestListPendingFailures(t *testing.T) {
+	defer resetFlags()
+
+	cf, fakeSnapshotClient, _ := newTestCmdFactory()
+	fakeSnapshotClient.QueryPendingsReturns(&snapshotgrpc.QueryPendingSnapshotsResponse{
+		BlockNumbers: []uint64{100, 1000},
+		Failures:     map[uint64]string{100: "disk full"},
+	}, nil)
+	buf := &bytes.Buffer{}
+	cmd := listPendingCmd(cf)
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Successfully got pending snapshot requests: [100 1000]\nSnapshot generation failed for block number 100: disk full\n", buf.String())
+}
+
+func TestListPendingErrors(t *testing.T) {
+	defer resetFlags()
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		resetFlags()
+		cf, _, _ := newTestCmdFactory()
+		cmd := listPendingCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		resetFlags()
+		cf, fakeSnapshotClient, _ := newTestCmdFactory()
+		fakeSnapshotClient.QueryPendingsReturns(nil, errors.New("cannot find ledger for channel mychannel"))
+		cmd := listPendingCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel"})
+		require.EqualError(t, cmd.Execute(), "failed to list pending snapshot requests: cannot find ledger for channel mychannel")
+	})
+}
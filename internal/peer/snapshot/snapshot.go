@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var logger = flogging.MustGetLogger("cli.snapshot")
+
+var (
+	channelID       string
+	blockNumber     uint64
+	peerAddress     string
+	tlsRootCertFile string
+)
+
+// Cmd returns the cobra command for snapshot
+func Cmd(cf *SnapshotCmdFactory) *cobra.Command {
+	snapshotCmd.AddCommand(submitRequestCmd(cf))
+	snapshotCmd.AddCommand(cancelRequestCmd(cf))
+	snapshotCmd.AddCommand(listPendingCmd(cf))
+
+	return snapshotCmd
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage snapshot requests: submitrequest|cancelrequest|listpending.",
+	Long:  "Manage snapshot requests: submitrequest|cancelrequest|listpending.",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		common.InitCmd(cmd, args)
+	},
+}
+
+var flags *pflag.FlagSet
+
+func init() {
+	resetFlags()
+}
+
+// Explicitly define a method to facilitate tests
+func resetFlags() {
+	flags = &pflag.FlagSet{}
+
+	flags.StringVarP(&channelID, "channelID", "c", "", "The channel on which this command should be executed")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "The block number for which a snapshot will be generated")
+	flags.StringVarP(&peerAddress, "peerAddress", "", "", "The address of the peer to connect to")
+	flags.StringVarP(&tlsRootCertFile, "tlsRootCertFile", "", "", "The path to the TLS root cert file of the peer to connect to, required if TLS is enabled and ignored if TLS is disabled.")
+}
+
+func attachFlags(cmd *cobra.Command, names []string) {
+	cmdFlags := cmd.Flags()
+	for _, name := range names {
+		if flag := flags.Lookup(name); flag != nil {
+			cmdFlags.AddFlag(flag)
+		} else {
+			logger.Fatalf("Could not find flag '%s' to attach to command '%s'", name, cmd.Name())
+		}
+	}
+}
+
+// SnapshotCmdFactory holds the clients used by the snapshot commands
+type SnapshotCmdFactory struct {
+	SnapshotClient snapshotgrpc.SnapshotClient
+	Signer         identity.SignerSerializer
+}
+
+// InitCmdFactory creates the snapshot client for the peer and the default signer
+func InitCmdFactory() (*SnapshotCmdFactory, error) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	snapshotClient, err := common.GetSnapshotClient(peerAddress, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve snapshot client")
+	}
+
+	return &SnapshotCmdFactory{
+		SnapshotClient: snapshotClient,
+		Signer:         signer,
+	}, nil
+}
+
+func signatureHeader(signer identity.SignerSerializer) ([]byte, error) {
+	sigHdr, err := protoutil.NewSignatureHeader(signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signature header")
+	}
+	return protoutil.Marshal(sigHdr)
+}
+
+func signSnapshotRequest(signer identity.SignerSerializer, request proto.Message) (*snapshotgrpc.SignedSnapshotRequest, error) {
+	requestBytes, err := protoutil.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.Sign(requestBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign snapshot request")
+	}
+	return &snapshotgrpc.SignedSnapshotRequest{
+		Request:   requestBytes,
+		Signature: signature,
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/hyperledger/fabric/internal/peer/snapshot/mock"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/snapshot_client.go -fake-name SnapshotClient . snapshotClient
+//go:generate counterfeiter -o mock/signer.go -fake-name Signer . signer
+
+type snapshotClient interface {
+	snapshotgrpc.SnapshotClient
+}
+
+type signer interface {
+	identity.SignerSerializer
+}
+
+func newTestCmdFactory() (*SnapshotCmdFactory, *mock.SnapshotClient, *mock.Signer) {
+	fakeSnapshotClient := &mock.SnapshotClient{}
+	fakeSigner := &mock.Signer{}
+	fakeSigner.SerializeReturns([]byte("creator"), nil)
+	fakeSigner.SignReturns([]byte("signature"), nil)
+	return &SnapshotCmdFactory{
+		SnapshotClient: fakeSnapshotClient,
+		Signer:         fakeSigner,
+	}, fakeSnapshotClient, fakeSigner
+}
+
+func verifySignedRequest(t *testing.T, signedRequest *snapshotgrpc.SignedSnapshotRequest, request proto.Message) {
+	require.Equal(t, []byte("signature"), signedRequest.Signature)
+	require.NoError(t, proto.Unmarshal(signedRequest.Request, request))
+}
+
+func verifySignatureHeader(t *testing.T, signatureHeaderBytes []byte) {
+	sigHdr := &cb.SignatureHeader{}
+	require.NoError(t, proto.Unmarshal(signatureHeaderBytes, sigHdr))
+	require.Equal(t, []byte("creator"), sigHdr.Creator)
+	require.NotEmpty(t, sigHdr.Nonce)
+}
+
+func TestSnapshotCmd(t *testing.T) {
+	cmd := Cmd(nil)
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"--help"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, buf.String(), "submitrequest")
+	require.Contains(t, buf.String(), "cancelrequest")
+	require.Contains(t, buf.String(), "listpending")
+}
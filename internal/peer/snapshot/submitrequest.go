@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func submitRequestCmd(cf *SnapshotCmdFactory) *cobra.Command {
+	snapshotSubmitRequestCmd := &cobra.Command{
+		Use:   "submitrequest",
+		Short: "Submit a request for a snapshot at the specified block.",
+		Long:  "Submit a request for a snapshot at the specified block. When the blockNumber parameter is set to 0 or not provided, a snapshot is generated for the last committed block.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitRequest(cmd, cf)
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"blockNumber",
+		"peerAddress",
+		"tlsRootCertFile",
+	}
+	attachFlags(snapshotSubmitRequestCmd, flagList)
+
+	return snapshotSubmitRequestCmd
+}
+
+func submitRequest(cmd *cobra.Command, cf *SnapshotCmdFactory) error {
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	sigHdr, err := signatureHeader(cf.Signer)
+	if err != nil {
+		return err
+	}
+	request := &snapshotgrpc.SnapshotRequest{
+		SignatureHeader: sigHdr,
+		ChannelId:       channelID,
+		BlockNumber:     blockNumber,
+	}
+	signedRequest, err := signSnapshotRequest(cf.Signer, request)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cf.SnapshotClient.Generate(context.Background(), signedRequest); err != nil {
+		return errors.WithMessage(err, "failed to submit snapshot request")
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), "Snapshot request submitted successfully\n")
+	return nil
+}
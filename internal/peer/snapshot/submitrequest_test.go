@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitRequest(t *testing.T) {
+	defer resetFlags()
+
+	cf, fakeSnapshotClient, _ := newTestCmdFactory()
+	buf := &bytes.Buffer{}
+	cmd := submitRequestCmd(cf)
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "100"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "Snapshot request submitted successfully\n", buf.String())
+
+	require.Equal(t, 1, fakeSnapshotClient.GenerateCallCount())
+	_, signedRequest, _ := fakeSnapshotClient.GenerateArgsForCall(0)
+	request := &snapshotgrpc.SnapshotRequest{}
+	verifySignedRequest(t, signedRequest, request)
+	verifySignatureHeader(t, request.SignatureHeader)
+	require.Equal(t, "mychannel", request.ChannelId)
+	require.Equal(t, uint64(100), request.BlockNumber)
+}
+
+func TestSubmitRequestErrors(t *testing.T) {
+	defer resetFlags()
+
+	t.Run("missing channel ID", func(t *testing.T) {
+		resetFlags()
+		cf, _, _ := newTestCmdFactory()
+		cmd := submitRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-b", "100"})
+		require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		resetFlags()
+		cf, _, fakeSigner := newTestCmdFactory()
+		fakeSigner.SignReturns(nil, errors.New("sign error"))
+		cmd := submitRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel"})
+		require.EqualError(t, cmd.Execute(), "failed to sign snapshot request: sign error")
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		resetFlags()
+		cf, fakeSnapshotClient, _ := newTestCmdFactory()
+		fakeSnapshotClient.GenerateReturns(nil, errors.New("duplicate snapshot request"))
+		cmd := submitRequestCmd(cf)
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"-c", "mychannel", "-b", "100"})
+		require.EqualError(t, cmd.Execute(), "failed to submit snapshot request: duplicate snapshot request")
+	})
+}
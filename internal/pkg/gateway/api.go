@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	gp "github.com/hyperledger/fabric/pkg/gateway"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Evaluate will invoke the transaction function as specified in the SignedProposal
+// on a single peer and return its result. No ledger updates are made.
+func (gs *Server) Evaluate(ctx context.Context, request *gp.EvaluateRequest) (*gp.EvaluateResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "an evaluate request is required")
+	}
+	signedProposal := request.GetProposedTransaction()
+	_, chaincodeID, err := getChaincodeID(signedProposal)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	endorsers, err := gs.registry.evaluators(request.ChannelId, chaincodeID, request.TargetOrganizations)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
+	defer cancel()
+
+	var errs []string
+	for _, e := range endorsers {
+		response, err := e.client.ProcessProposal(ctx, signedProposal)
+		if err != nil {
+			// the peer could not be reached, try the next one
+			logger.Warningf("Failed to evaluate transaction %s on peer %s: %s", request.TransactionId, e.address, err)
+			errs = append(errs, fmt.Sprintf("%s (%s): %s", e.address, e.mspid, err))
+			continue
+		}
+		if err := checkResponse(response); err != nil {
+			return nil, status.Errorf(codes.Aborted, "failed to evaluate transaction: %s", err)
+		}
+		return &gp.EvaluateResponse{Result: response.Response}, nil
+	}
+	return nil, status.Errorf(codes.Unavailable, "failed to evaluate transaction: %s", strings.Join(errs, "; "))
+}
+
+// Endorse will collect the endorsements of the proposal from the peers that satisfy the
+// endorsement policy of the chaincode, and return a prepared transaction for the client to sign.
+// The peers that fail to endorse are replaced by other peers of the endorsement plan, possibly
+// of another layout, until no combination of peers can satisfy the policy.
+func (gs *Server) Endorse(ctx context.Context, request *gp.EndorseRequest) (*gp.EndorseResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "an endorse request is required")
+	}
+	signedProposal := request.GetProposedTransaction()
+	proposal, chaincodeID, err := getChaincodeID(signedProposal)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var plan *endorsementPlan
+	if len(request.EndorsingOrganizations) > 0 {
+		plan, err = gs.registry.endorsementPlanForOrgs(request.ChannelId, chaincodeID, request.EndorsingOrganizations)
+	} else {
+		plan, err = gs.registry.endorsementPlan(request.ChannelId, chaincodeID)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gs.options.EndorsementTimeout)
+	defer cancel()
+
+	// the endorsers that fail are replaced according to the plan, and the endorsements
+	// already collected are kept
+	endorsements := map[*endorser]*peer.ProposalResponse{}
+	failed := map[*endorser]bool{}
+	var failures []string
+	var responses []*peer.ProposalResponse
+	for {
+		endorsers, ok := plan.endorsers(failed)
+		if !ok {
+			return nil, status.Errorf(codes.Aborted, "failed to endorse transaction: %s", strings.Join(failures, "; "))
+		}
+
+		errs := endorse(ctx, signedProposal, endorsers, endorsements)
+		if len(errs) == 0 {
+			for _, e := range endorsers {
+				responses = append(responses, endorsements[e])
+			}
+			break
+		}
+		for _, e := range endorsers {
+			if err, ok := errs[e]; ok {
+				logger.Warningf("Failed to endorse transaction %s on peer %s: %s", request.TransactionId, e.address, err)
+				failures = append(failures, fmt.Sprintf("%s (%s): %s", e.address, e.mspid, err))
+				failed[e] = true
+			}
+		}
+	}
+
+	env, err := protoutil.CreateTx(proposal, responses...)
+	if err != nil {
+		return nil, status.Errorf(codes.Aborted, "failed to assemble transaction: %s", err)
+	}
+
+	return &gp.EndorseResponse{Result: responses[0].Response, PreparedTransaction: env}, nil
+}
+
+// endorse collects the endorsements of the proposal from the endorsers that have not endorsed it yet,
+// and returns the errors of the ones that failed.
+func endorse(ctx context.Context, signedProposal *peer.SignedProposal, endorsers []*endorser, endorsements map[*endorser]*peer.ProposalResponse) map[*endorser]error {
+	var pending []*endorser
+	for _, e := range endorsers {
+		if _, ok := endorsements[e]; !ok {
+			pending = append(pending, e)
+		}
+	}
+
+	responses := make([]*peer.ProposalResponse, len(pending))
+	errs := make([]error, len(pending))
+	var wg sync.WaitGroup
+	for i, e := range pending {
+		wg.Add(1)
+		go func(i int, e *endorser) {
+			defer wg.Done()
+			response, err := e.client.ProcessProposal(ctx, signedProposal)
+			if err == nil {
+				err = checkResponse(response)
+			}
+			responses[i], errs[i] = response, err
+		}(i, e)
+	}
+	wg.Wait()
+
+	failures := map[*endorser]error{}
+	for i, e := range pending {
+		if errs[i] != nil {
+			failures[e] = errs[i]
+			continue
+		}
+		endorsements[e] = responses[i]
+	}
+	return failures
+}
+
+// Submit will send the signed transaction to the ordering service. It returns once an
+// ordering service node has accepted the transaction, without waiting for it to be committed.
+func (gs *Server) Submit(ctx context.Context, request *gp.SubmitRequest) (*gp.SubmitResponse, error) {
+	if request == nil {
+		return nil, status.Error(codes.InvalidArgument, "a submit request is required")
+	}
+	txn := request.GetPreparedTransaction()
+	if txn == nil {
+		return nil, status.Error(codes.InvalidArgument, "a prepared transaction is required")
+	}
+
+	orderers, err := gs.registry.orderers(request.ChannelId)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	var errs []string
+	for _, o := range orderers {
+		err := o.broadcast(ctx, txn)
+		if err == nil {
+			return &gp.SubmitResponse{}, nil
+		}
+		logger.Warningf("Failed to submit transaction %s to orderer %s: %s", request.TransactionId, o.address, err)
+		errs = append(errs, fmt.Sprintf("%s: %s", o.address, err))
+	}
+	return nil, status.Errorf(codes.Unavailable, "failed to submit transaction to the ordering service: %s", strings.Join(errs, "; "))
+}
+
+// CommitStatus returns the validation code of a submitted transaction and the number of
+// the block it is committed in. It waits for the transaction to be committed if it has not been already.
+func (gs *Server) CommitStatus(ctx context.Context, signedRequest *gp.SignedCommitStatusRequest) (*gp.CommitStatusResponse, error) {
+	if signedRequest == nil {
+		return nil, status.Error(codes.InvalidArgument, "a commit status request is required")
+	}
+	request := &gp.CommitStatusRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal commit status request: %s", err)
+	}
+
+	signedData := []*protoutil.SignedData{{
+		Data:      signedRequest.Request,
+		Identity:  request.Identity,
+		Signature: signedRequest.Signature,
+	}}
+	if err := gs.aclChecker.CheckACL(resources.Gateway_CommitStatus, request.ChannelId, signedData); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "access denied for [%s]: %s", resources.Gateway_CommitStatus, err)
+	}
+
+	response, err := gs.commitFinder.transactionStatus(ctx, request.ChannelId, request.TransactionId)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return response, nil
+}
+
+// getChaincodeID returns the proposal and the name of the chaincode it invokes.
+func getChaincodeID(signedProposal *peer.SignedProposal) (*peer.Proposal, string, error) {
+	if signedProposal == nil {
+		return nil, "", errors.New("a signed proposal is required")
+	}
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to unpack transaction proposal")
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to unpack transaction proposal")
+	}
+	channelHeader, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to unpack transaction proposal")
+	}
+	extension, err := protoutil.UnmarshalChaincodeHeaderExtension(channelHeader.Extension)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to unpack transaction proposal")
+	}
+	if extension.GetChaincodeId().GetName() == "" {
+		return nil, "", errors.New("no chaincode specified in the transaction proposal")
+	}
+	return proposal, extension.ChaincodeId.Name, nil
+}
+
+// checkResponse returns an error if the proposal was not successfully endorsed.
+func checkResponse(response *peer.ProposalResponse) error {
+	if response == nil || response.Response == nil {
+		return errors.New("missing proposal response")
+	}
+	if response.Response.Status < 200 || response.Response.Status >= 400 {
+		return errors.Errorf("error %d, %s", response.Response.Status, response.Response.Message)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	gp "github.com/hyperledger/fabric/pkg/gateway"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// commitFinder looks up the commit status of transactions in the ledger of the peer.
+type commitFinder struct {
+	ledgerGetter LedgerGetter
+}
+
+// transactionStatus returns the commit status of a transaction. If the transaction
+// has not been committed yet, it waits for the blocks committed to the ledger until
+// the transaction is found or the context is done.
+func (f *commitFinder) transactionStatus(ctx context.Context, channelID, transactionID string) (*gp.CommitStatusResponse, error) {
+	lgr := f.ledgerGetter.GetLedger(channelID)
+	if lgr == nil {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+
+	// the height is read before the look up, so that a block committed in the meantime is not missed
+	info, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the height of the ledger of channel %s", channelID)
+	}
+
+	block, err := lgr.GetBlockByTxID(transactionID)
+	if err == nil {
+		if status, found := findTransaction(block, transactionID); found {
+			return status, nil
+		}
+		return nil, errors.Errorf("transaction %s not found in block [%d]", transactionID, block.GetHeader().GetNumber())
+	}
+	if _, ok := err.(ledger.NotFoundInIndexErr); !ok {
+		return nil, errors.WithMessagef(err, "failed to look up transaction %s", transactionID)
+	}
+
+	itr, err := lgr.GetBlocksIterator(info.Height)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to iterate the blocks of channel %s", channelID)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		itr.Close()
+	}()
+
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read the blocks of channel %s", channelID)
+		}
+		if result == nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, errors.Errorf("the blocks iterator of channel %s has been closed", channelID)
+		}
+		if status, found := findTransaction(result.(*common.Block), transactionID); found {
+			return status, nil
+		}
+	}
+}
+
+// findTransaction returns the validation code of the first transaction of the block with the given ID.
+func findTransaction(block *common.Block, transactionID string) (*gp.CommitStatusResponse, bool) {
+	flags := txflags.ValidationFlags(block.GetMetadata().GetMetadata()[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for i, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			logger.Warningf("Failed to unmarshal transaction %d of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		channelHeader, err := protoutil.ChannelHeader(env)
+		if err != nil {
+			logger.Warningf("Failed to unmarshal channel header of transaction %d of block [%d]: %s", i, block.Header.Number, err)
+			continue
+		}
+		if channelHeader.TxId != transactionID {
+			continue
+		}
+		status := &gp.CommitStatusResponse{
+			Result:      peer.TxValidationCode_NOT_VALIDATED,
+			BlockNumber: block.Header.Number,
+		}
+		if i < len(flags) {
+			status.Result = flags.Flag(i)
+		}
+		return status, true
+	}
+	return nil, false
+}
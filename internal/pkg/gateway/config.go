@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultEndorsementTimeout is used when peer.gateway.endorsementTimeout is not set
+	DefaultEndorsementTimeout = 30 * time.Second
+
+	// DefaultDialTimeout is used when peer.gateway.dialTimeout is not set
+	DefaultDialTimeout = 2 * time.Minute
+)
+
+// Options are the configuration options of the gateway service.
+type Options struct {
+	// Enabled turns the gateway service on or off.
+	Enabled bool
+	// EndorsementTimeout is the duration the gateway waits for a response from other endorsing peers.
+	EndorsementTimeout time.Duration
+	// DialTimeout is the duration the gateway waits for a connection to other network nodes.
+	DialTimeout time.Duration
+}
+
+// GetOptions reads the gateway options from the peer configuration.
+func GetOptions(v *viper.Viper) Options {
+	options := Options{
+		Enabled:            true,
+		EndorsementTimeout: DefaultEndorsementTimeout,
+		DialTimeout:        DefaultDialTimeout,
+	}
+	if v.IsSet("peer.gateway.enabled") {
+		options.Enabled = v.GetBool("peer.gateway.enabled")
+	}
+	if v.IsSet("peer.gateway.endorsementTimeout") {
+		options.EndorsementTimeout = v.GetDuration("peer.gateway.endorsementTimeout")
+	}
+	if v.IsSet("peer.gateway.dialTimeout") {
+		options.DialTimeout = v.GetDuration("peer.gateway.dialTimeout")
+	}
+	return options
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	dp "github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+)
+
+var logger = flogging.MustGetLogger("gateway")
+
+// Discovery provides the endorsement plans and the channel configuration
+// the gateway uses to route requests to the endorsing peers and orderers.
+type Discovery interface {
+	Config(channel string) (*dp.ConfigResult, error)
+	PeersForEndorsement(channel gossipcommon.ChannelID, interest *dp.ChaincodeInterest) (*dp.EndorsementDescriptor, error)
+}
+
+// LedgerGetter gets the PeerLedger associated with a channel.
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// ACLChecker checks ACL for a resource.
+type ACLChecker interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// Server represents the gRPC server for the Gateway.
+type Server struct {
+	registry     *registry
+	commitFinder *commitFinder
+	aclChecker   ACLChecker
+	options      Options
+}
+
+// CreateServer creates an embedded instance of the Gateway. The localEndorser is
+// used for the proposals that are endorsed by this peer, which is known to the
+// discovery service by its localEndpoint and belongs to the localMSPID organization.
+func CreateServer(
+	localEndorser peer.EndorserServer,
+	discovery Discovery,
+	ledgerGetter LedgerGetter,
+	aclChecker ACLChecker,
+	clientConfig comm.ClientConfig,
+	localEndpoint string,
+	localMSPID string,
+	options Options,
+) *Server {
+	clientConfig.Timeout = options.DialTimeout
+
+	return &Server{
+		registry: &registry{
+			localEndorser:    &endorser{client: &localEndorserClient{server: localEndorser}, address: localEndpoint, mspid: localMSPID},
+			discovery:        discovery,
+			endorserFactory:  newEndorserFactory(clientConfig),
+			ordererFactory:   newOrdererFactory(clientConfig),
+			remoteEndorsers:  map[string]*endorser{},
+			broadcastClients: map[string]*orderer{},
+		},
+		commitFinder: &commitFinder{ledgerGetter: ledgerGetter},
+		aclChecker:   aclChecker,
+		options:      options,
+	}
+}
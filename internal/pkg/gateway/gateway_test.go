@@ -0,0 +1,590 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cp "github.com/hyperledger/fabric-protos-go/common"
+	dp "github.com/hyperledger/fabric-protos-go/discovery"
+	gossippb "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/mocks"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	gp "github.com/hyperledger/fabric/pkg/gateway"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate counterfeiter -o mocks/discovery.go --fake-name Discovery . discovery
+type discovery interface {
+	Discovery
+}
+
+//go:generate counterfeiter -o mocks/ledger_getter.go --fake-name LedgerGetter . ledgerGetter
+type ledgerGetter interface {
+	LedgerGetter
+}
+
+//go:generate counterfeiter -o mocks/acl_checker.go --fake-name ACLChecker . aclChecker
+type aclChecker interface {
+	ACLChecker
+}
+
+//go:generate counterfeiter -o mocks/peer_ledger.go --fake-name PeerLedger . peerLedger
+type peerLedger interface {
+	ledger.PeerLedger
+}
+
+//go:generate counterfeiter -o mocks/results_iterator.go --fake-name ResultsIterator . resultsIterator
+type resultsIterator interface {
+	commonledger.ResultsIterator
+}
+
+//go:generate counterfeiter -o mocks/endorser_client.go --fake-name EndorserClient . endorserClient
+type endorserClient interface {
+	peer.EndorserClient
+}
+
+//go:generate counterfeiter -o mocks/broadcast_client.go --fake-name BroadcastClient . broadcastClient
+type broadcastClient interface {
+	ab.AtomicBroadcastClient
+}
+
+//go:generate counterfeiter -o mocks/broadcast_stream.go --fake-name BroadcastStream . broadcastStream
+type broadcastStream interface {
+	ab.AtomicBroadcast_BroadcastClient
+}
+
+const (
+	testChannel   = "test_channel"
+	testChaincode = "test_chaincode"
+)
+
+type testPeer struct {
+	address string
+	mspid   string
+	height  uint64
+	client  *mocks.EndorserClient
+}
+
+type testEnv struct {
+	server    *Server
+	discovery *mocks.Discovery
+	peers     map[string]*testPeer
+	orderers  map[string]*mocks.BroadcastClient
+	ledger    *mocks.PeerLedger
+	acl       *mocks.ACLChecker
+}
+
+// newTestEnv creates a gateway on the local peer localhost:7051 of Org1MSP.
+// The endorsement plan of the test chaincode requires one peer of each organization.
+func newTestEnv(t *testing.T, peers ...*testPeer) *testEnv {
+	env := &testEnv{
+		discovery: &mocks.Discovery{},
+		peers:     map[string]*testPeer{},
+		orderers:  map[string]*mocks.BroadcastClient{},
+		ledger:    &mocks.PeerLedger{},
+		acl:       &mocks.ACLChecker{},
+	}
+
+	groups := map[string]*dp.Peers{}
+	layout := &dp.Layout{QuantitiesByGroup: map[string]uint32{}}
+	for _, p := range peers {
+		p.client = &mocks.EndorserClient{}
+		p.client.ProcessProposalReturns(proposalResponse(200, p.mspid), nil)
+		env.peers[p.address] = p
+		if groups[p.mspid] == nil {
+			groups[p.mspid] = &dp.Peers{}
+			layout.QuantitiesByGroup[p.mspid] = 1
+		}
+		groups[p.mspid].Peers = append(groups[p.mspid].Peers, discoveryPeer(t, p))
+	}
+	env.discovery.PeersForEndorsementReturns(&dp.EndorsementDescriptor{
+		Chaincode:         testChaincode,
+		EndorsersByGroups: groups,
+		Layouts:           []*dp.Layout{layout},
+	}, nil)
+	env.discovery.ConfigReturns(&dp.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{
+			"Org1MSP":     {TlsRootCerts: [][]byte{[]byte("org1-ca")}},
+			"OrdererMSP":  {TlsRootCerts: [][]byte{[]byte("orderer-ca")}},
+			"Org2MSP":     {TlsRootCerts: [][]byte{[]byte("org2-ca")}},
+			"UnknownPeer": {},
+		},
+		Orderers: map[string]*dp.Endpoints{
+			"OrdererMSP": {Endpoint: []*dp.Endpoint{{Host: "orderer1", Port: 7050}, {Host: "orderer2", Port: 7050}}},
+		},
+	}, nil)
+
+	ledgerGetter := &mocks.LedgerGetter{}
+	ledgerGetter.GetLedgerReturns(env.ledger)
+
+	env.server = &Server{
+		registry: &registry{
+			discovery: env.discovery,
+			endorserFactory: func(address, mspid string, tlsRootCerts [][]byte) (*endorser, error) {
+				p, ok := env.peers[address]
+				if !ok {
+					return nil, errors.Errorf("unknown peer %s", address)
+				}
+				require.Equal(t, [][]byte{[]byte(fmt.Sprintf("org%s-ca", mspid[3:4]))}, tlsRootCerts)
+				return &endorser{client: p.client, address: address, mspid: mspid}, nil
+			},
+			ordererFactory: func(address string, tlsRootCerts [][]byte) (*orderer, error) {
+				require.Equal(t, [][]byte{[]byte("orderer-ca")}, tlsRootCerts)
+				client := &mocks.BroadcastClient{}
+				stream := &mocks.BroadcastStream{}
+				stream.RecvReturns(&ab.BroadcastResponse{Status: cp.Status_SUCCESS}, nil)
+				client.BroadcastReturns(stream, nil)
+				env.orderers[address] = client
+				return &orderer{client: client, address: address}, nil
+			},
+			remoteEndorsers:  map[string]*endorser{},
+			broadcastClients: map[string]*orderer{},
+		},
+		commitFinder: &commitFinder{ledgerGetter: ledgerGetter},
+		aclChecker:   env.acl,
+		options: Options{
+			Enabled:            true,
+			EndorsementTimeout: time.Second,
+			DialTimeout:        time.Second,
+		},
+	}
+
+	if local, ok := env.peers["localhost:7051"]; ok {
+		env.server.registry.localEndorser = &endorser{client: local.client, address: local.address, mspid: local.mspid}
+	} else {
+		env.server.registry.localEndorser = &endorser{client: &mocks.EndorserClient{}, address: "localhost:7051", mspid: "Org1MSP"}
+	}
+	return env
+}
+
+func TestGetOptions(t *testing.T) {
+	v := viper.New()
+	require.Equal(t, Options{
+		Enabled:            true,
+		EndorsementTimeout: DefaultEndorsementTimeout,
+		DialTimeout:        DefaultDialTimeout,
+	}, GetOptions(v))
+
+	v.Set("peer.gateway.enabled", false)
+	v.Set("peer.gateway.endorsementTimeout", "5s")
+	v.Set("peer.gateway.dialTimeout", "1m")
+	require.Equal(t, Options{
+		Enabled:            false,
+		EndorsementTimeout: 5 * time.Second,
+		DialTimeout:        time.Minute,
+	}, GetOptions(v))
+}
+
+func TestEvaluate(t *testing.T) {
+	local := &testPeer{address: "localhost:7051", mspid: "Org1MSP", height: 4}
+	peer1 := &testPeer{address: "peer1:8051", mspid: "Org1MSP", height: 5}
+	peer2 := &testPeer{address: "peer2:9051", mspid: "Org2MSP", height: 5}
+
+	t.Run("local peer is preferred", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2)
+		response, err := env.server.Evaluate(context.Background(), evaluateRequest(t, nil))
+		require.NoError(t, err)
+		require.Equal(t, []byte("Org1MSP result"), response.Result.Payload)
+		require.Equal(t, 1, local.client.ProcessProposalCallCount())
+		require.Equal(t, 0, peer1.client.ProcessProposalCallCount())
+	})
+
+	t.Run("target organizations", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2)
+		response, err := env.server.Evaluate(context.Background(), evaluateRequest(t, []string{"Org2MSP"}))
+		require.NoError(t, err)
+		require.Equal(t, []byte("Org2MSP result"), response.Result.Payload)
+		require.Equal(t, 0, local.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer2.client.ProcessProposalCallCount())
+	})
+
+	t.Run("unreachable peer is skipped", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2)
+		local.client.ProcessProposalReturns(nil, errors.New("connection refused"))
+		response, err := env.server.Evaluate(context.Background(), evaluateRequest(t, []string{"Org1MSP"}))
+		require.NoError(t, err)
+		require.Equal(t, []byte("Org1MSP result"), response.Result.Payload)
+		require.Equal(t, 1, peer1.client.ProcessProposalCallCount())
+	})
+
+	t.Run("chaincode error", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2)
+		local.client.ProcessProposalReturns(proposalResponse(500, "Org1MSP"), nil)
+		_, err := env.server.Evaluate(context.Background(), evaluateRequest(t, nil))
+		require.EqualError(t, err, "rpc error: code = Aborted desc = failed to evaluate transaction: error 500, Org1MSP failure")
+		require.Equal(t, 0, peer1.client.ProcessProposalCallCount())
+	})
+
+	t.Run("no peers available", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2)
+		_, err := env.server.Evaluate(context.Background(), evaluateRequest(t, []string{"Org3MSP"}))
+		require.EqualError(t, err, "rpc error: code = Unavailable desc = no peers are available to evaluate transactions of chaincode test_chaincode on channel test_channel")
+	})
+
+	t.Run("invalid proposal", func(t *testing.T) {
+		env := newTestEnv(t, local)
+		_, err := env.server.Evaluate(context.Background(), &gp.EvaluateRequest{ChannelId: testChannel})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a signed proposal is required")
+
+		_, err = env.server.Evaluate(context.Background(), nil)
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = an evaluate request is required")
+	})
+}
+
+func TestEndorse(t *testing.T) {
+	local := &testPeer{address: "localhost:7051", mspid: "Org1MSP", height: 4}
+	peer1 := &testPeer{address: "peer1:8051", mspid: "Org1MSP", height: 5}
+	peer2 := &testPeer{address: "peer2:9051", mspid: "Org2MSP", height: 3}
+	peer3 := &testPeer{address: "peer3:10051", mspid: "Org2MSP", height: 6}
+
+	t.Run("endorsement plan", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		response, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.NoError(t, err)
+		require.Equal(t, 1, local.client.ProcessProposalCallCount())
+		require.Equal(t, 0, peer1.client.ProcessProposalCallCount())
+		require.Equal(t, 0, peer2.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer3.client.ProcessProposalCallCount())
+
+		payload, err := protoutil.UnmarshalPayload(response.PreparedTransaction.Payload)
+		require.NoError(t, err)
+		tx, err := protoutil.UnmarshalTransaction(payload.Data)
+		require.NoError(t, err)
+		actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+		require.NoError(t, err)
+		require.Len(t, actionPayload.Action.Endorsements, 2)
+		require.Nil(t, response.PreparedTransaction.Signature)
+		require.Equal(t, int32(200), response.Result.Status)
+	})
+
+	t.Run("endorsing organizations", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, []string{"Org2MSP"}))
+		require.NoError(t, err)
+		require.Equal(t, 0, local.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer3.client.ProcessProposalCallCount())
+	})
+
+	t.Run("failed endorser is replaced", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		peer3.client.ProcessProposalReturns(nil, errors.New("connection refused"))
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.NoError(t, err)
+		require.Equal(t, 1, local.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer2.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer3.client.ProcessProposalCallCount())
+	})
+
+	t.Run("fallback to the next layout", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		descriptor, _ := env.discovery.PeersForEndorsement(nil, nil)
+		descriptor.Layouts = append(descriptor.Layouts, &dp.Layout{QuantitiesByGroup: map[string]uint32{"Org1MSP": 2}})
+		peer2.client.ProcessProposalReturns(proposalResponse(500, "Org2MSP"), nil)
+		peer3.client.ProcessProposalReturns(proposalResponse(500, "Org2MSP"), nil)
+		response, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.NoError(t, err)
+		require.Equal(t, 1, local.client.ProcessProposalCallCount())
+		require.Equal(t, 1, peer1.client.ProcessProposalCallCount())
+
+		payload, err := protoutil.UnmarshalPayload(response.PreparedTransaction.Payload)
+		require.NoError(t, err)
+		tx, err := protoutil.UnmarshalTransaction(payload.Data)
+		require.NoError(t, err)
+		actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+		require.NoError(t, err)
+		require.Len(t, actionPayload.Action.Endorsements, 2)
+	})
+
+	t.Run("endorsement failure", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		peer2.client.ProcessProposalReturns(proposalResponse(500, "Org2MSP"), nil)
+		peer3.client.ProcessProposalReturns(proposalResponse(500, "Org2MSP"), nil)
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.EqualError(t, err, "rpc error: code = Aborted desc = failed to endorse transaction: peer3:10051 (Org2MSP): error 500, Org2MSP failure; peer2:9051 (Org2MSP): error 500, Org2MSP failure")
+	})
+
+	t.Run("endorsing organization failure", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		local.client.ProcessProposalReturns(nil, errors.New("connection refused"))
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, []string{"Org1MSP"}))
+		require.NoError(t, err)
+		require.Equal(t, 1, peer1.client.ProcessProposalCallCount())
+	})
+
+	t.Run("mismatched responses", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1, peer2, peer3)
+		response := proposalResponse(200, "Org2MSP")
+		response.Payload = []byte("different")
+		peer3.client.ProcessProposalReturns(response, nil)
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.EqualError(t, err, "rpc error: code = Aborted desc = failed to assemble transaction: ProposalResponsePayloads do not match")
+	})
+
+	t.Run("policy cannot be satisfied", func(t *testing.T) {
+		env := newTestEnv(t, local, peer1)
+		descriptor, _ := env.discovery.PeersForEndorsement(nil, nil)
+		descriptor.Layouts[0].QuantitiesByGroup["Org2MSP"] = 1
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.EqualError(t, err, "rpc error: code = Unavailable desc = no combination of peers can be derived which satisfy the endorsement policy of chaincode test_chaincode on channel test_channel")
+	})
+
+	t.Run("discovery failure", func(t *testing.T) {
+		env := newTestEnv(t, local)
+		env.discovery.PeersForEndorsementReturns(nil, errors.New("chaincode not found"))
+		_, err := env.server.Endorse(context.Background(), endorseRequest(t, nil))
+		require.EqualError(t, err, "rpc error: code = Unavailable desc = failed to get the endorsement plan of chaincode test_chaincode on channel test_channel: chaincode not found")
+	})
+}
+
+func TestSubmit(t *testing.T) {
+	request := &gp.SubmitRequest{
+		TransactionId:       "txid",
+		ChannelId:           testChannel,
+		PreparedTransaction: &cp.Envelope{Payload: []byte("payload"), Signature: []byte("signature")},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		env := newTestEnv(t)
+		_, err := env.server.Submit(context.Background(), request)
+		require.NoError(t, err)
+
+		sent := 0
+		for _, client := range env.orderers {
+			if client.BroadcastCallCount() == 1 {
+				stream, _ := client.Broadcast(context.Background())
+				require.True(t, proto.Equal(request.PreparedTransaction, stream.(*mocks.BroadcastStream).SendArgsForCall(0)))
+				sent++
+			}
+		}
+		require.Equal(t, 1, sent)
+	})
+
+	t.Run("orderer failure", func(t *testing.T) {
+		env := newTestEnv(t)
+		orderers, err := env.server.registry.orderers(testChannel)
+		require.NoError(t, err)
+		failing := &mocks.BroadcastStream{}
+		failing.RecvReturns(&ab.BroadcastResponse{Status: cp.Status_SERVICE_UNAVAILABLE, Info: "not leader"}, nil)
+		env.orderers[orderers[0].address].BroadcastReturns(failing, nil)
+
+		_, err = env.server.Submit(context.Background(), request)
+		require.NoError(t, err)
+
+		failing2 := &mocks.BroadcastStream{}
+		failing2.SendReturns(errors.New("connection reset"))
+		env.orderers[orderers[1].address].BroadcastReturns(failing2, nil)
+
+		_, err = env.server.Submit(context.Background(), request)
+		require.Error(t, err)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+		require.Contains(t, err.Error(), "received unsuccessful response from orderer: SERVICE_UNAVAILABLE - not leader")
+		require.Contains(t, err.Error(), "connection reset")
+	})
+
+	t.Run("missing transaction", func(t *testing.T) {
+		env := newTestEnv(t)
+		_, err := env.server.Submit(context.Background(), &gp.SubmitRequest{ChannelId: testChannel})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a prepared transaction is required")
+	})
+}
+
+func TestCommitStatus(t *testing.T) {
+	signedRequest := &gp.SignedCommitStatusRequest{
+		Request: protoutil.MarshalOrPanic(&gp.CommitStatusRequest{
+			TransactionId: "txid2",
+			ChannelId:     testChannel,
+			Identity:      []byte("client"),
+		}),
+		Signature: []byte("signature"),
+	}
+
+	t.Run("committed transaction", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 6}, nil)
+		env.ledger.GetBlockByTxIDReturns(block(5, "txid1", "txid2"), nil)
+
+		response, err := env.server.CommitStatus(context.Background(), signedRequest)
+		require.NoError(t, err)
+		require.Equal(t, &gp.CommitStatusResponse{Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 5}, response)
+
+		resName, channelID, idinfo := env.acl.CheckACLArgsForCall(0)
+		require.Equal(t, resources.Gateway_CommitStatus, resName)
+		require.Equal(t, testChannel, channelID)
+		require.Equal(t, []*protoutil.SignedData{{
+			Data:      signedRequest.Request,
+			Identity:  []byte("client"),
+			Signature: []byte("signature"),
+		}}, idinfo)
+	})
+
+	t.Run("waits for commit", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 6}, nil)
+		env.ledger.GetBlockByTxIDReturns(nil, ledger.NotFoundInIndexErr("not found"))
+		itr := &mocks.ResultsIterator{}
+		itr.NextReturnsOnCall(0, block(6, "txid0"), nil)
+		itr.NextReturnsOnCall(1, block(7, "txid1", "txid2"), nil)
+		env.ledger.GetBlocksIteratorReturns(itr, nil)
+
+		response, err := env.server.CommitStatus(context.Background(), signedRequest)
+		require.NoError(t, err)
+		require.Equal(t, &gp.CommitStatusResponse{Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 7}, response)
+		require.Equal(t, uint64(6), env.ledger.GetBlocksIteratorArgsForCall(0))
+		require.Eventually(t, func() bool { return itr.CloseCallCount() == 1 }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 6}, nil)
+		env.ledger.GetBlockByTxIDReturns(nil, ledger.NotFoundInIndexErr("not found"))
+		closed := make(chan struct{})
+		itr := &mocks.ResultsIterator{}
+		itr.NextStub = func() (commonledger.QueryResult, error) {
+			<-closed
+			return nil, nil
+		}
+		itr.CloseStub = func() { close(closed) }
+		env.ledger.GetBlocksIteratorReturns(itr, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := env.server.CommitStatus(ctx, signedRequest)
+		require.EqualError(t, err, "rpc error: code = DeadlineExceeded desc = context deadline exceeded")
+	})
+
+	t.Run("ledger failure", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.ledger.GetBlockchainInfoReturns(&cp.BlockchainInfo{Height: 6}, nil)
+		env.ledger.GetBlockByTxIDReturns(nil, errors.New("disk failure"))
+		_, err := env.server.CommitStatus(context.Background(), signedRequest)
+		require.EqualError(t, err, "rpc error: code = Unavailable desc = failed to look up transaction txid2: disk failure")
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.acl.CheckACLReturns(errors.New("not a reader"))
+		_, err := env.server.CommitStatus(context.Background(), signedRequest)
+		require.EqualError(t, err, "rpc error: code = PermissionDenied desc = access denied for [gateway/CommitStatus]: not a reader")
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		env := newTestEnv(t)
+		_, err := env.server.CommitStatus(context.Background(), &gp.SignedCommitStatusRequest{Request: []byte("garbage")})
+		require.Error(t, err)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func discoveryPeer(t *testing.T, p *testPeer) *dp.Peer {
+	aliveMsg, err := protoext.NoopSign(&gossippb.GossipMessage{
+		Content: &gossippb.GossipMessage_AliveMsg{
+			AliveMsg: &gossippb.AliveMessage{
+				Membership: &gossippb.Member{Endpoint: p.address},
+			},
+		},
+	})
+	require.NoError(t, err)
+	stateInfoMsg, err := protoext.NoopSign(&gossippb.GossipMessage{
+		Content: &gossippb.GossipMessage_StateInfo{
+			StateInfo: &gossippb.StateInfo{
+				Properties: &gossippb.Properties{LedgerHeight: p.height},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return &dp.Peer{
+		MembershipInfo: aliveMsg.Envelope,
+		StateInfo:      stateInfoMsg.Envelope,
+		Identity:       protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: p.mspid, IdBytes: []byte(p.address)}),
+	}
+}
+
+func signedProposal(t *testing.T) *peer.SignedProposal {
+	proposal, _, err := protoutil.CreateChaincodeProposal(
+		cp.HeaderType_ENDORSER_TRANSACTION,
+		testChannel,
+		&peer.ChaincodeInvocationSpec{
+			ChaincodeSpec: &peer.ChaincodeSpec{
+				ChaincodeId: &peer.ChaincodeID{Name: testChaincode},
+				Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte("invoke")}},
+			},
+		},
+		protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("client")}),
+	)
+	require.NoError(t, err)
+	return &peer.SignedProposal{ProposalBytes: protoutil.MarshalOrPanic(proposal), Signature: []byte("signature")}
+}
+
+func evaluateRequest(t *testing.T, targetOrgs []string) *gp.EvaluateRequest {
+	return &gp.EvaluateRequest{
+		TransactionId:       "txid",
+		ChannelId:           testChannel,
+		ProposedTransaction: signedProposal(t),
+		TargetOrganizations: targetOrgs,
+	}
+}
+
+func endorseRequest(t *testing.T, endorsingOrgs []string) *gp.EndorseRequest {
+	return &gp.EndorseRequest{
+		TransactionId:          "txid",
+		ChannelId:              testChannel,
+		ProposedTransaction:    signedProposal(t),
+		EndorsingOrganizations: endorsingOrgs,
+	}
+}
+
+func proposalResponse(status int32, mspid string) *peer.ProposalResponse {
+	message := ""
+	if status >= 400 {
+		message = mspid + " failure"
+	}
+	return &peer.ProposalResponse{
+		Payload:     []byte("proposal response payload"),
+		Response:    &peer.Response{Status: status, Message: message, Payload: []byte(mspid + " result")},
+		Endorsement: &peer.Endorsement{Endorser: []byte(mspid), Signature: []byte("signature")},
+	}
+}
+
+// block creates a block with the given transactions, with the last one being invalid.
+func block(number uint64, txIDs ...string) *cp.Block {
+	b := protoutil.NewBlock(number, nil)
+	flags := txflags.New(len(txIDs))
+	for i, txID := range txIDs {
+		b.Data.Data = append(b.Data.Data, protoutil.MarshalOrPanic(&cp.Envelope{
+			Payload: protoutil.MarshalOrPanic(&cp.Payload{
+				Header: &cp.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&cp.ChannelHeader{
+						Type:      int32(cp.HeaderType_ENDORSER_TRANSACTION),
+						ChannelId: testChannel,
+						TxId:      txID,
+					}),
+				},
+			}),
+		}))
+		flags.SetFlag(i, peer.TxValidationCode_VALID)
+	}
+	flags.SetFlag(len(txIDs)-1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	b.Metadata.Metadata[cp.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+	return b
+}
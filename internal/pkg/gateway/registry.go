@@ -0,0 +1,427 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	dp "github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// endorser is a peer that the gateway sends proposals to.
+type endorser struct {
+	client  peer.EndorserClient
+	address string
+	mspid   string
+}
+
+// orderer is an ordering service node that the gateway broadcasts transactions to.
+type orderer struct {
+	client  ab.AtomicBroadcastClient
+	address string
+}
+
+type (
+	endorserFactory func(address, mspid string, tlsRootCerts [][]byte) (*endorser, error)
+	ordererFactory  func(address string, tlsRootCerts [][]byte) (*orderer, error)
+)
+
+// candidate is an endorser known to the discovery service along with its ledger height.
+type candidate struct {
+	endorser *endorser
+	height   uint64
+}
+
+// registry selects the endorsers and orderers of requests, and maintains the connections to them.
+type registry struct {
+	localEndorser    *endorser
+	discovery        Discovery
+	endorserFactory  endorserFactory
+	ordererFactory   ordererFactory
+	remoteEndorsers  map[string]*endorser
+	broadcastClients map[string]*orderer
+	lock             sync.Mutex
+}
+
+// endorsementPlan holds the peers of the groups of an endorsement plan in order of preference,
+// and the layouts of groups that satisfy the endorsement policy.
+type endorsementPlan struct {
+	layouts []*dp.Layout
+	groups  map[string][]*candidate
+}
+
+// endorsers returns the endorsers of the first layout that can be satisfied without the excluded peers.
+func (p *endorsementPlan) endorsers(excluded map[*endorser]bool) ([]*endorser, bool) {
+	groups := map[string][]*candidate{}
+	for name, candidates := range p.groups {
+		for _, c := range candidates {
+			if !excluded[c.endorser] {
+				groups[name] = append(groups[name], c)
+			}
+		}
+	}
+	for _, layout := range p.layouts {
+		if endorsers, ok := selectByLayout(layout, groups); ok {
+			return endorsers, true
+		}
+	}
+	return nil, false
+}
+
+// endorsementPlan returns the endorsement plan of the chaincode according to the discovery service.
+// The local peer and the peers with the highest ledger height are preferred.
+func (reg *registry) endorsementPlan(channel, chaincode string) (*endorsementPlan, error) {
+	descriptor, err := reg.endorsementDescriptor(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := reg.candidatesByGroup(channel, descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &endorsementPlan{layouts: descriptor.Layouts, groups: groups}
+	if _, ok := plan.endorsers(nil); !ok {
+		return nil, errors.Errorf("no combination of peers can be derived which satisfy the endorsement policy of chaincode %s on channel %s", chaincode, channel)
+	}
+	return plan, nil
+}
+
+// endorsementPlanForOrgs returns an endorsement plan that requires one endorser of each of
+// the given organizations among the peers of the endorsement plan of the chaincode.
+func (reg *registry) endorsementPlanForOrgs(channel, chaincode string, mspids []string) (*endorsementPlan, error) {
+	candidates, err := reg.candidates(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := &dp.Layout{QuantitiesByGroup: map[string]uint32{}}
+	groups := map[string][]*candidate{}
+	for _, mspid := range mspids {
+		for _, c := range candidates {
+			if c.endorser.mspid == mspid {
+				groups[mspid] = append(groups[mspid], c)
+			}
+		}
+		if len(groups[mspid]) == 0 {
+			return nil, errors.Errorf("no peers of organization %s are available to endorse chaincode %s on channel %s", mspid, chaincode, channel)
+		}
+		layout.QuantitiesByGroup[mspid] = 1
+	}
+	return &endorsementPlan{layouts: []*dp.Layout{layout}, groups: groups}, nil
+}
+
+// evaluators returns the peers, in order of preference, that can evaluate transactions of the chaincode.
+// If organizations are given, only the peers of these organizations are returned.
+func (reg *registry) evaluators(channel, chaincode string, mspids []string) ([]*endorser, error) {
+	candidates, err := reg.candidates(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	var endorsers []*endorser
+	for _, c := range candidates {
+		if len(mspids) == 0 || contains(mspids, c.endorser.mspid) {
+			endorsers = append(endorsers, c.endorser)
+		}
+	}
+	if len(endorsers) == 0 {
+		return nil, errors.Errorf("no peers are available to evaluate transactions of chaincode %s on channel %s", chaincode, channel)
+	}
+	return endorsers, nil
+}
+
+// orderers returns the ordering service nodes of the channel in random order.
+func (reg *registry) orderers(channel string) ([]*orderer, error) {
+	config, err := reg.discovery.Config(channel)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the configuration of channel %s", channel)
+	}
+
+	var orderers []*orderer
+	for mspid, endpoints := range config.Orderers {
+		for _, endpoint := range endpoints.Endpoint {
+			address := fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)
+			o, err := reg.connectOrderer(address, tlsRootCerts(config, mspid))
+			if err != nil {
+				logger.Warningf("Failed to connect to orderer %s: %s", address, err)
+				continue
+			}
+			orderers = append(orderers, o)
+		}
+	}
+	if len(orderers) == 0 {
+		return nil, errors.Errorf("no orderers are available for channel %s", channel)
+	}
+
+	rand.Shuffle(len(orderers), func(i, j int) { orderers[i], orderers[j] = orderers[j], orderers[i] })
+	return orderers, nil
+}
+
+func (reg *registry) endorsementDescriptor(channel, chaincode string) (*dp.EndorsementDescriptor, error) {
+	interest := &dp.ChaincodeInterest{
+		Chaincodes: []*dp.ChaincodeCall{{Name: chaincode}},
+	}
+	descriptor, err := reg.discovery.PeersForEndorsement(gossipcommon.ChannelID(channel), interest)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the endorsement plan of chaincode %s on channel %s", chaincode, channel)
+	}
+	return descriptor, nil
+}
+
+// candidates returns all the distinct peers of the endorsement plan of the chaincode, in order of preference.
+func (reg *registry) candidates(channel, chaincode string) ([]*candidate, error) {
+	descriptor, err := reg.endorsementDescriptor(channel, chaincode)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := reg.candidatesByGroup(channel, descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var candidates []*candidate
+	for _, group := range groups {
+		for _, c := range group {
+			if !seen[c.endorser.address] {
+				seen[c.endorser.address] = true
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	reg.sortCandidates(candidates)
+	return candidates, nil
+}
+
+// candidatesByGroup converts the peers of the endorsement descriptor into endorsers,
+// sorted in order of preference within each group.
+func (reg *registry) candidatesByGroup(channel string, descriptor *dp.EndorsementDescriptor) (map[string][]*candidate, error) {
+	var config *dp.ConfigResult
+	groups := map[string][]*candidate{}
+	for group, peers := range descriptor.EndorsersByGroups {
+		for _, p := range peers.Peers {
+			address, height, mspid, err := peerInfo(p)
+			if err != nil {
+				logger.Warningf("Ignoring peer of group %s of channel %s: %s", group, channel, err)
+				continue
+			}
+
+			e := reg.localEndorser
+			if address != reg.localEndorser.address {
+				if config == nil {
+					if config, err = reg.discovery.Config(channel); err != nil {
+						return nil, errors.WithMessagef(err, "failed to get the configuration of channel %s", channel)
+					}
+				}
+				if e, err = reg.connectEndorser(address, mspid, tlsRootCerts(config, mspid)); err != nil {
+					logger.Warningf("Failed to connect to peer %s: %s", address, err)
+					continue
+				}
+			}
+			groups[group] = append(groups[group], &candidate{endorser: e, height: height})
+		}
+		reg.sortCandidates(groups[group])
+	}
+	return groups, nil
+}
+
+// sortCandidates sorts the endorsers by preference: the local peer first, then by ledger height.
+func (reg *registry) sortCandidates(candidates []*candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		iLocal, jLocal := candidates[i].endorser == reg.localEndorser, candidates[j].endorser == reg.localEndorser
+		if iLocal != jLocal {
+			return iLocal
+		}
+		return candidates[i].height > candidates[j].height
+	})
+}
+
+func (reg *registry) connectEndorser(address, mspid string, tlsRootCerts [][]byte) (*endorser, error) {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	if e, ok := reg.remoteEndorsers[address]; ok {
+		return e, nil
+	}
+	e, err := reg.endorserFactory(address, mspid, tlsRootCerts)
+	if err != nil {
+		return nil, err
+	}
+	reg.remoteEndorsers[address] = e
+	return e, nil
+}
+
+func (reg *registry) connectOrderer(address string, tlsRootCerts [][]byte) (*orderer, error) {
+	reg.lock.Lock()
+	defer reg.lock.Unlock()
+
+	if o, ok := reg.broadcastClients[address]; ok {
+		return o, nil
+	}
+	o, err := reg.ordererFactory(address, tlsRootCerts)
+	if err != nil {
+		return nil, err
+	}
+	reg.broadcastClients[address] = o
+	return o, nil
+}
+
+// selectByLayout picks distinct endorsers from the groups in the quantities required by the layout.
+// The groups are visited in a deterministic order, and the candidates of each group in order of preference.
+func selectByLayout(layout *dp.Layout, groups map[string][]*candidate) ([]*endorser, bool) {
+	var names []string
+	for name := range layout.QuantitiesByGroup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	selected := map[*endorser]bool{}
+	var endorsers []*endorser
+	for _, name := range names {
+		required := int(layout.QuantitiesByGroup[name])
+		for _, c := range groups[name] {
+			if required == 0 {
+				break
+			}
+			if selected[c.endorser] {
+				continue
+			}
+			selected[c.endorser] = true
+			endorsers = append(endorsers, c.endorser)
+			required--
+		}
+		if required > 0 {
+			return nil, false
+		}
+	}
+	return endorsers, true
+}
+
+// peerInfo extracts the endpoint, ledger height and organization of a peer of an endorsement descriptor.
+func peerInfo(p *dp.Peer) (address string, height uint64, mspid string, err error) {
+	if p.MembershipInfo == nil || p.StateInfo == nil {
+		return "", 0, "", errors.New("missing membership or state info")
+	}
+	aliveMsg, err := protoext.EnvelopeToGossipMessage(p.MembershipInfo)
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "failed unmarshaling gossip envelope to alive message")
+	}
+	stateInfoMsg, err := protoext.EnvelopeToGossipMessage(p.StateInfo)
+	if err != nil {
+		return "", 0, "", errors.Wrap(err, "failed unmarshaling gossip envelope to state info message")
+	}
+	if aliveMsg.GetAliveMsg().GetMembership().GetEndpoint() == "" {
+		return "", 0, "", errors.New("missing peer endpoint")
+	}
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(p.Identity, sID); err != nil {
+		return "", 0, "", errors.Wrap(err, "failed unmarshaling peer's identity")
+	}
+	return aliveMsg.GetAliveMsg().GetMembership().GetEndpoint(),
+		stateInfoMsg.GetStateInfo().GetProperties().GetLedgerHeight(),
+		sID.Mspid,
+		nil
+}
+
+// tlsRootCerts returns the TLS root and intermediate CA certificates of an organization of the channel.
+func tlsRootCerts(config *dp.ConfigResult, mspid string) [][]byte {
+	mspConfig, ok := config.Msps[mspid]
+	if !ok {
+		return nil
+	}
+	var certs [][]byte
+	certs = append(certs, mspConfig.TlsRootCerts...)
+	certs = append(certs, mspConfig.TlsIntermediateCerts...)
+	return certs
+}
+
+func contains(list []string, item string) bool {
+	for _, s := range list {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
+
+func newConnection(clientConfig comm.ClientConfig, address string, tlsRootCerts [][]byte) (*grpc.ClientConn, error) {
+	config := clientConfig.Clone()
+	config.SecOpts.ServerRootCAs = tlsRootCerts
+	client, err := comm.NewGRPCClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewConnection(address)
+}
+
+func newEndorserFactory(clientConfig comm.ClientConfig) endorserFactory {
+	return func(address, mspid string, tlsRootCerts [][]byte) (*endorser, error) {
+		conn, err := newConnection(clientConfig, address, tlsRootCerts)
+		if err != nil {
+			return nil, err
+		}
+		return &endorser{client: peer.NewEndorserClient(conn), address: address, mspid: mspid}, nil
+	}
+}
+
+func newOrdererFactory(clientConfig comm.ClientConfig) ordererFactory {
+	return func(address string, tlsRootCerts [][]byte) (*orderer, error) {
+		conn, err := newConnection(clientConfig, address, tlsRootCerts)
+		if err != nil {
+			return nil, err
+		}
+		return &orderer{client: ab.NewAtomicBroadcastClient(conn), address: address}, nil
+	}
+}
+
+// localEndorserClient passes the proposals to the endorser of this peer without a network hop.
+type localEndorserClient struct {
+	server peer.EndorserServer
+}
+
+func (c *localEndorserClient) ProcessProposal(ctx context.Context, signedProposal *peer.SignedProposal, _ ...grpc.CallOption) (*peer.ProposalResponse, error) {
+	return c.server.ProcessProposal(ctx, signedProposal)
+}
+
+// broadcast sends the transaction to the orderer and waits for its response.
+func (o *orderer) broadcast(ctx context.Context, env *common.Envelope) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := o.client.Broadcast(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(env); err != nil {
+		return err
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if response.Status != common.Status_SUCCESS {
+		return errors.Errorf("received unsuccessful response from orderer: %s - %s", response.Status, response.Info)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/x509"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -65,11 +66,13 @@ type GossipServiceAdapter interface {
 //go:generate counterfeiter -o fake/block_verifier.go --fake-name BlockVerifier . BlockVerifier
 type BlockVerifier interface {
 	VerifyBlock(channelID gossipcommon.ChannelID, blockNum uint64, block *common.Block) error
+	VerifyHeader(channelID gossipcommon.ChannelID, blockNum uint64, block *common.Block) error
 }
 
 //go:generate counterfeiter -o fake/orderer_connection_source.go --fake-name OrdererConnectionSource . OrdererConnectionSource
 type OrdererConnectionSource interface {
 	RandomEndpoint() (*orderers.Endpoint, error)
+	Endpoints() []*orderers.Endpoint
 }
 
 //go:generate counterfeiter -o fake/dialer.go --fake-name Dialer . Dialer
@@ -100,6 +103,11 @@ type Deliverer struct {
 	InitialRetryDelay time.Duration
 	MaxRetryDuration  time.Duration
 
+	// BlockCensorshipTimeout enables the detection of block censorship by the ordering service node
+	// blocks are received from, when it is not zero. This is meaningful only for BFT ordering services,
+	// where the other ordering service nodes can attest that a block has been committed.
+	BlockCensorshipTimeout time.Duration
+
 	// TLSCertHash should be nil when TLS is not enabled
 	TLSCertHash []byte // util.ComputeSHA256(b.credSupport.GetClientCertificate().Certificate[0])
 
@@ -119,6 +127,8 @@ func (d *Deliverer) DeliverBlocks() {
 	// n * log(backoffExponentBase) > log(MaxRetryDelay / InitialRetryDelay)
 	// n > log(MaxRetryDelay / InitialRetryDelay) / log(backoffExponentBase)
 	maxFailures := int(math.Log(float64(d.MaxRetryDelay)/float64(d.InitialRetryDelay)) / math.Log(backoffExponentBase))
+	// suspected is the address of the last ordering service node suspected of censorship
+	var suspected string
 	for {
 		select {
 		case <-d.DoneC:
@@ -156,7 +166,7 @@ func (d *Deliverer) DeliverBlocks() {
 			return
 		}
 
-		deliverClient, endpoint, cancel, err := d.connect(seekInfoEnv)
+		deliverClient, endpoint, cancel, err := d.connect(seekInfoEnv, suspected)
 		if err != nil {
 			d.Logger.Warningf("Could not connect to ordering service: %s", err)
 			failureCounter++
@@ -183,12 +193,24 @@ func (d *Deliverer) DeliverBlocks() {
 			}
 		}()
 
+		nextBlock := ledgerHeight
+		var censoredC chan struct{}
+		stopMonitorC := make(chan struct{})
+		if d.BlockCensorshipTimeout > 0 {
+			censoredC = make(chan struct{})
+			go d.monitorCensorship(endpoint, &nextBlock, stopMonitorC, censoredC)
+		}
+
 	RecvLoop: // Loop until the endpoint is refreshed, or there is an error on the connection
 		for {
 			select {
 			case <-endpoint.Refreshed:
 				connLogger.Infof("Ordering endpoints have been refreshed, disconnecting from deliver to reconnect using updated endpoints")
 				break RecvLoop
+			case <-censoredC:
+				connLogger.Warningf("Orderer did not deliver a block committed by the ordering service within %v, suspecting censorship and disconnecting", d.BlockCensorshipTimeout)
+				suspected = endpoint.Address
+				break RecvLoop
 			case response, ok := <-recv:
 				if !ok {
 					connLogger.Warningf("Orderer hung up without sending status")
@@ -201,13 +223,17 @@ func (d *Deliverer) DeliverBlocks() {
 					failureCounter++
 					break RecvLoop
 				}
+				if block := response.GetBlock(); block != nil {
+					atomic.StoreUint64(&nextBlock, block.Header.Number+1)
+				}
 				failureCounter = 0
 			case <-d.DoneC:
 				break RecvLoop
 			}
 		}
 
-		// cancel and wait for our spawned go routine to exit
+		// cancel and wait for our spawned go routines to exit
+		close(stopMonitorC)
 		cancel()
 		<-recv
 	}
@@ -278,11 +304,20 @@ func (d *Deliverer) Stop() {
 	}
 }
 
-func (d *Deliverer) connect(seekInfoEnv *common.Envelope) (orderer.AtomicBroadcast_DeliverClient, *orderers.Endpoint, func(), error) {
+func (d *Deliverer) connect(seekInfoEnv *common.Envelope, suspected string) (orderer.AtomicBroadcast_DeliverClient, *orderers.Endpoint, func(), error) {
 	endpoint, err := d.Orderers.RandomEndpoint()
 	if err != nil {
 		return nil, nil, nil, errors.WithMessage(err, "could not get orderer endpoints")
 	}
+	if endpoint.Address == suspected {
+		// avoid the ordering service node suspected of censorship if there are other ones
+		for _, candidate := range d.Orderers.Endpoints() {
+			if candidate.Address != suspected {
+				endpoint = candidate
+				break
+			}
+		}
+	}
 
 	conn, err := d.Dialer.Dial(endpoint.Address, endpoint.CertPool)
 	if err != nil {
@@ -314,27 +349,31 @@ func (d *Deliverer) connect(seekInfoEnv *common.Envelope) (orderer.AtomicBroadca
 }
 
 func (d *Deliverer) createSeekInfo(ledgerHeight uint64) (*common.Envelope, error) {
-	return protoutil.CreateSignedEnvelopeWithTLSBinding(
-		common.HeaderType_DELIVER_SEEK_INFO,
-		d.ChannelID,
-		d.Signer,
-		&orderer.SeekInfo{
-			Start: &orderer.SeekPosition{
-				Type: &orderer.SeekPosition_Specified{
-					Specified: &orderer.SeekSpecified{
-						Number: ledgerHeight,
-					},
+	return d.signSeekInfo(&orderer.SeekInfo{
+		Start: &orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Specified{
+				Specified: &orderer.SeekSpecified{
+					Number: ledgerHeight,
 				},
 			},
-			Stop: &orderer.SeekPosition{
-				Type: &orderer.SeekPosition_Specified{
-					Specified: &orderer.SeekSpecified{
-						Number: math.MaxUint64,
-					},
+		},
+		Stop: &orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Specified{
+				Specified: &orderer.SeekSpecified{
+					Number: math.MaxUint64,
 				},
 			},
-			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
 		},
+		Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+	})
+}
+
+func (d *Deliverer) signSeekInfo(seekInfo proto.Message) (*common.Envelope, error) {
+	return protoutil.CreateSignedEnvelopeWithTLSBinding(
+		common.HeaderType_DELIVER_SEEK_INFO,
+		d.ChannelID,
+		d.Signer,
+		seekInfo,
 		int32(0),
 		uint64(0),
 		d.TLSCertHash,
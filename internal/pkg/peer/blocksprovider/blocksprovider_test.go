@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package blocksprovider_test
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
 	"sync"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/deliver/deliverpb"
 	"github.com/hyperledger/fabric/common/flogging"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/internal/pkg/peer/blocksprovider"
@@ -578,4 +580,77 @@ var _ = Describe("Blocksprovider", func() {
 			})
 		})
 	})
+
+	When("block censorship detection is enabled", func() {
+		var (
+			monitorDeliverClient *fake.DeliverClient
+			censoredBlock        *common.Block
+		)
+
+		BeforeEach(func() {
+			// appease the race detector
+			fakeDeliverClient := fakeDeliverClient
+
+			d.BlockCensorshipTimeout = 300 * time.Millisecond
+			fakeOrdererConnectionSource.EndpointsReturns([]*orderers.Endpoint{
+				{Address: "orderer-address"},
+				{Address: "other-orderer-address"},
+			})
+
+			censoredBlock = &common.Block{
+				Header: &common.BlockHeader{Number: 7},
+			}
+			monitorDeliverClient = &fake.DeliverClient{}
+			monitorDeliverClient.RecvStub = func() (*orderer.DeliverResponse, error) {
+				return &orderer.DeliverResponse{
+					Type: &orderer.DeliverResponse_Block{Block: censoredBlock},
+				}, nil
+			}
+
+			// the first stream never delivers a block, the other ordering service node returns block 7
+			fakeDeliverStreamer.DeliverStub = func(context.Context, *grpc.ClientConn) (orderer.AtomicBroadcast_DeliverClient, error) {
+				if fakeDeliverStreamer.DeliverCallCount() == 1 {
+					return fakeDeliverClient, nil
+				}
+				return monitorDeliverClient, nil
+			}
+		})
+
+		It("pulls the header of the newest block from the other ordering service nodes and verifies it", func() {
+			Eventually(monitorDeliverClient.SendCallCount).ShouldNot(BeZero())
+			env := monitorDeliverClient.SendArgsForCall(0)
+			payload, err := protoutil.UnmarshalPayload(env.Payload)
+			Expect(err).NotTo(HaveOccurred())
+			seekInfo := &deliverpb.SeekInfo{}
+			Expect(proto.Unmarshal(payload.Data, seekInfo)).To(Succeed())
+			Expect(seekInfo.Start.GetNewest()).NotTo(BeNil())
+			Expect(seekInfo.Behavior).To(Equal(orderer.SeekInfo_FAIL_IF_NOT_READY))
+			Expect(seekInfo.ContentType).To(Equal(deliverpb.SeekInfo_HEADER_WITH_SIG))
+
+			Eventually(fakeBlockVerifier.VerifyHeaderCallCount).ShouldNot(BeZero())
+			_, blockNum, block := fakeBlockVerifier.VerifyHeaderArgsForCall(0)
+			Expect(blockNum).To(Equal(uint64(7)))
+			Expect(proto.Equal(block, censoredBlock)).To(BeTrue())
+
+			addr, _ := fakeDialer.DialArgsForCall(1)
+			Expect(addr).To(Equal("other-orderer-address"))
+		})
+
+		It("disconnects and receives blocks from another ordering service node when the committed block is not delivered", func() {
+			Eventually(fakeOrdererConnectionSource.RandomEndpointCallCount, 5*time.Second).Should(Equal(2))
+			Expect(fakeSleeper.SleepCallCount()).To(Equal(0))
+			Eventually(fakeGossipServiceAdapter.AddPayloadCallCount).ShouldNot(BeZero())
+		})
+
+		When("the header of the newest block cannot be verified", func() {
+			BeforeEach(func() {
+				fakeBlockVerifier.VerifyHeaderReturns(fmt.Errorf("fake-verify-error"))
+			})
+
+			It("does not suspect the ordering service node", func() {
+				Eventually(fakeBlockVerifier.VerifyHeaderCallCount).Should(BeNumerically(">", 3))
+				Expect(fakeOrdererConnectionSource.RandomEndpointCallCount()).To(Equal(1))
+			})
+		})
+	})
 })
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blocksprovider
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/deliver/deliverpb"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/pkg/errors"
+)
+
+// monitorCensorship periodically pulls the header of the newest block from the ordering service nodes
+// other than the one blocks are delivered from. The verification of the header of a block of a BFT ordering
+// service requires the signatures of a quorum of its consenters, so a single node cannot forge a header
+// that passes it: such a block has been committed. If it is not delivered within the BlockCensorshipTimeout,
+// censoredC is closed.
+func (d *Deliverer) monitorCensorship(endpoint *orderers.Endpoint, nextBlock *uint64, stopC <-chan struct{}, censoredC chan<- struct{}) {
+	interval := d.BlockCensorshipTimeout / 3
+	var suspectedBlock uint64
+	var suspectedSince time.Time
+
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-stopC:
+			timer.Stop()
+			return
+		}
+
+		next := atomic.LoadUint64(nextBlock)
+		if !suspectedSince.IsZero() && next > suspectedBlock {
+			// the block has been delivered in the meantime
+			suspectedSince = time.Time{}
+		}
+
+		if suspectedSince.IsZero() {
+			newest, found := d.newestCommittedBlock(endpoint.Address, stopC)
+			if !found || newest < next {
+				continue
+			}
+			d.Logger.Debugf("Block [%d] has been committed by the ordering service but not delivered yet by %s", next, endpoint.Address)
+			suspectedBlock, suspectedSince = next, time.Now()
+			continue
+		}
+
+		if time.Since(suspectedSince) > d.BlockCensorshipTimeout {
+			d.Logger.Warningf("Block [%d] has been committed by the ordering service but %s did not deliver it within %v", suspectedBlock, endpoint.Address, d.BlockCensorshipTimeout)
+			close(censoredC)
+			return
+		}
+	}
+}
+
+// newestCommittedBlock returns the highest number of a verified block header among the headers
+// of the newest blocks of the ordering service nodes, except the given one.
+func (d *Deliverer) newestCommittedBlock(except string, stopC <-chan struct{}) (uint64, bool) {
+	var newest uint64
+	var found bool
+	for _, endpoint := range d.Orderers.Endpoints() {
+		if endpoint.Address == except {
+			continue
+		}
+		select {
+		case <-stopC:
+			return 0, false
+		default:
+		}
+
+		block, err := d.pullNewestHeader(endpoint)
+		if err != nil {
+			d.Logger.Debugf("Could not pull the header of the newest block from %s: %s", endpoint.Address, err)
+			continue
+		}
+		if err := d.BlockVerifier.VerifyHeader(gossipcommon.ChannelID(d.ChannelID), block.Header.Number, block); err != nil {
+			d.Logger.Warningf("Header of newest block [%d] from %s could not be verified: %s", block.Header.Number, endpoint.Address, err)
+			continue
+		}
+		if !found || block.Header.Number > newest {
+			newest, found = block.Header.Number, true
+		}
+	}
+	return newest, found
+}
+
+// pullNewestHeader pulls the newest block of the given endpoint without its data.
+func (d *Deliverer) pullNewestHeader(endpoint *orderers.Endpoint) (*common.Block, error) {
+	seekInfoEnv, err := d.signSeekInfo(&deliverpb.SeekInfo{
+		Start:       &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+		Stop:        &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+		Behavior:    orderer.SeekInfo_FAIL_IF_NOT_READY,
+		ContentType: deliverpb.SeekInfo_HEADER_WITH_SIG,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create a signed Deliver SeekInfo message")
+	}
+
+	conn, err := d.Dialer.Dial(endpoint.Address, endpoint.CertPool)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not dial endpoint '%s'", endpoint.Address)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.BlockCensorshipTimeout/3)
+	defer cancel()
+
+	deliverClient, err := d.DeliverStreamer.Deliver(ctx, conn)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not create deliver client to endpoints '%s'", endpoint.Address)
+	}
+	defer deliverClient.CloseSend()
+
+	if err := deliverClient.Send(seekInfoEnv); err != nil {
+		return nil, errors.WithMessagef(err, "could not send deliver seek info handshake to '%s'", endpoint.Address)
+	}
+
+	response, err := deliverClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+	block := response.GetBlock()
+	if block == nil || block.Header == nil {
+		return nil, errors.Errorf("expected a block but got %v", response)
+	}
+	return block, nil
+}
@@ -23,6 +23,19 @@ type BlockVerifier struct {
 	verifyBlockReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VerifyHeaderStub        func(common.ChannelID, uint64, *commona.Block) error
+	verifyHeaderMutex       sync.RWMutex
+	verifyHeaderArgsForCall []struct {
+		arg1 common.ChannelID
+		arg2 uint64
+		arg3 *commona.Block
+	}
+	verifyHeaderReturns struct {
+		result1 error
+	}
+	verifyHeaderReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -89,11 +102,75 @@ func (fake *BlockVerifier) VerifyBlockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *BlockVerifier) VerifyHeader(arg1 common.ChannelID, arg2 uint64, arg3 *commona.Block) error {
+	fake.verifyHeaderMutex.Lock()
+	ret, specificReturn := fake.verifyHeaderReturnsOnCall[len(fake.verifyHeaderArgsForCall)]
+	fake.verifyHeaderArgsForCall = append(fake.verifyHeaderArgsForCall, struct {
+		arg1 common.ChannelID
+		arg2 uint64
+		arg3 *commona.Block
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("VerifyHeader", []interface{}{arg1, arg2, arg3})
+	fake.verifyHeaderMutex.Unlock()
+	if fake.VerifyHeaderStub != nil {
+		return fake.VerifyHeaderStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.verifyHeaderReturns
+	return fakeReturns.result1
+}
+
+func (fake *BlockVerifier) VerifyHeaderCallCount() int {
+	fake.verifyHeaderMutex.RLock()
+	defer fake.verifyHeaderMutex.RUnlock()
+	return len(fake.verifyHeaderArgsForCall)
+}
+
+func (fake *BlockVerifier) VerifyHeaderCalls(stub func(common.ChannelID, uint64, *commona.Block) error) {
+	fake.verifyHeaderMutex.Lock()
+	defer fake.verifyHeaderMutex.Unlock()
+	fake.VerifyHeaderStub = stub
+}
+
+func (fake *BlockVerifier) VerifyHeaderArgsForCall(i int) (common.ChannelID, uint64, *commona.Block) {
+	fake.verifyHeaderMutex.RLock()
+	defer fake.verifyHeaderMutex.RUnlock()
+	argsForCall := fake.verifyHeaderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *BlockVerifier) VerifyHeaderReturns(result1 error) {
+	fake.verifyHeaderMutex.Lock()
+	defer fake.verifyHeaderMutex.Unlock()
+	fake.VerifyHeaderStub = nil
+	fake.verifyHeaderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *BlockVerifier) VerifyHeaderReturnsOnCall(i int, result1 error) {
+	fake.verifyHeaderMutex.Lock()
+	defer fake.verifyHeaderMutex.Unlock()
+	fake.VerifyHeaderStub = nil
+	if fake.verifyHeaderReturnsOnCall == nil {
+		fake.verifyHeaderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyHeaderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *BlockVerifier) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.verifyBlockMutex.RLock()
 	defer fake.verifyBlockMutex.RUnlock()
+	fake.verifyHeaderMutex.RLock()
+	defer fake.verifyHeaderMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -55,6 +55,15 @@ func (cs *ConnectionSource) RandomEndpoint() (*Endpoint, error) {
 	return cs.allEndpoints[rand.Intn(len(cs.allEndpoints))], nil
 }
 
+// Endpoints returns all the orderer endpoints currently defined.
+func (cs *ConnectionSource) Endpoints() []*Endpoint {
+	cs.mutex.RLock()
+	defer cs.mutex.RUnlock()
+	endpoints := make([]*Endpoint, len(cs.allEndpoints))
+	copy(endpoints, cs.allEndpoints)
+	return endpoints
+}
+
 func (cs *ConnectionSource) Update(globalAddrs []string, orgs map[string]OrdererOrg) {
 	cs.mutex.Lock()
 	defer cs.mutex.Unlock()
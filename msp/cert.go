@@ -69,6 +69,13 @@ func isECDSASignedCert(cert *x509.Certificate) bool {
 		cert.SignatureAlgorithm == x509.ECDSAWithSHA512
 }
 
+// isEd25519Cert returns true if either the public key of the cert
+// or the signature of its issuer is Ed25519
+func isEd25519Cert(cert *x509.Certificate) bool {
+	return cert.PublicKeyAlgorithm == x509.Ed25519 ||
+		cert.SignatureAlgorithm == x509.PureEd25519
+}
+
 // sanitizeECDSASignedCert checks that the signatures signing a cert
 // is in low-S. This is checked against the public key of parentCert.
 // If the signature is not in low-S, then a new certificate is generated
@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/require"
+)
+
+// generateEd25519MSPDir writes the root certificate, the signing certificate
+// and the private key of an MSP made of Ed25519 keys in dir
+func generateEd25519MSPDir(t *testing.T, dir string) []byte {
+	caPub, caPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.ed25519.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caPub, caPriv)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "peer0.ed25519.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, pub, caPriv)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+
+	for _, d := range []string{"cacerts", "signcerts", "admincerts", "keystore"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+	}
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cacerts", "ca.pem"), caPEM, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "signcerts", "cert.pem"), certPEM, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "admincerts", "cert.pem"), certPEM, 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "keystore", "priv_sk"), keyPEM, 0600))
+	return keyPEM
+}
+
+func TestEd25519MSP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	generateEd25519MSPDir(t, dir)
+
+	conf, err := GetLocalMspConfig(dir, nil, "Ed25519MSP")
+	require.NoError(t, err)
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(dir, "keystore"), true)
+	require.NoError(t, err)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(ks)
+	require.NoError(t, err)
+
+	thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv3_0}}, cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, thisMSP.Setup(conf))
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	require.NoError(t, id.Validate())
+
+	msg := []byte("a message signed with Ed25519")
+	sig, err := id.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, ed25519.SignatureSize)
+	require.NoError(t, id.Verify(msg, sig))
+	require.EqualError(t, id.Verify([]byte("another message"), sig), "The signature is invalid")
+
+	serializedID, err := id.Serialize()
+	require.NoError(t, err)
+	deserializedID, err := thisMSP.DeserializeIdentity(serializedID)
+	require.NoError(t, err)
+	require.NoError(t, deserializedID.Validate())
+	require.NoError(t, deserializedID.Verify(msg, sig))
+}
+
+func TestEd25519MSPKeyMaterial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	keyPEM := generateEd25519MSPDir(t, dir)
+
+	conf, err := GetLocalMspConfig(dir, nil, "Ed25519MSP")
+	require.NoError(t, err)
+	fabricConf := &msp.FabricMSPConfig{}
+	require.NoError(t, proto.Unmarshal(conf.Config, fabricConf))
+	fabricConf.SigningIdentity.PrivateSigner = &msp.KeyInfo{KeyIdentifier: "PEM", KeyMaterial: keyPEM}
+	conf.Config, err = proto.Marshal(fabricConf)
+	require.NoError(t, err)
+
+	// the private key is imported from the configuration when it is not in the keystore
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv3_0}}, cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, thisMSP.Setup(conf))
+
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	sig, err := id.Sign([]byte("message"))
+	require.NoError(t, err)
+	require.NoError(t, id.Verify([]byte("message"), sig))
+}
+
+func TestEd25519MSPRequiresV3_0(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ed25519msp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	generateEd25519MSPDir(t, dir)
+
+	conf, err := GetLocalMspConfig(dir, nil, "Ed25519MSP")
+	require.NoError(t, err)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	for _, version := range []MSPVersion{MSPv1_0, MSPv1_1, MSPv1_3, MSPv1_4_3} {
+		thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: version}}, cryptoProvider)
+		require.NoError(t, err)
+		err = thisMSP.Setup(conf)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Ed25519 certificates require the V3_0 channel capability")
+	}
+}
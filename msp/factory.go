@@ -18,6 +18,7 @@ const (
 	MSPv1_1
 	MSPv1_3
 	MSPv1_4_3
+	MSPv3_0
 )
 
 // NewOpts represent
@@ -58,11 +59,15 @@ func New(opts NewOpts, cryptoProvider bccsp.BCCSP) (MSP, error) {
 			return newBccspMsp(MSPv1_3, cryptoProvider)
 		case MSPv1_4_3:
 			return newBccspMsp(MSPv1_4_3, cryptoProvider)
+		case MSPv3_0:
+			return newBccspMsp(MSPv3_0, cryptoProvider)
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
 	case *IdemixNewOpts:
 		switch opts.GetVersion() {
+		case MSPv3_0:
+			fallthrough
 		case MSPv1_4_3:
 			fallthrough
 		case MSPv1_3:
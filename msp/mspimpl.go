@@ -129,7 +129,7 @@ func newBccspMsp(version MSPVersion, defaultBCCSP bccsp.BCCSP) (MSP, error) {
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV13
 		theMsp.internalSetupAdmin = theMsp.setupAdminsPreV142
-	case MSPv1_4_3:
+	case MSPv1_4_3, MSPv3_0:
 		theMsp.internalSetupFunc = theMsp.setupV142
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV142
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV142
@@ -227,9 +227,16 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		if pemKey == nil {
 			return nil, errors.Errorf("%s: wrong PEM encoding", sidInfo.PrivateSigner.KeyIdentifier)
 		}
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
-		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+		if isEd25519Cert(idPub.(*identity).cert) {
+			privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import Ed25519 private key")
+			}
+		} else {
+			privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			}
 		}
 	}
 
@@ -796,6 +803,12 @@ func (msp *bccspmsp) getCertificationChainIdentifierFromChain(chain []*x509.Cert
 // do have signatures in Low-S. If this is not the case, the certificate
 // is regenerated to have a Low-S signature.
 func (msp *bccspmsp) sanitizeCert(cert *x509.Certificate) (*x509.Certificate, error) {
+	// Ed25519 certificates are only accepted once all the members of the
+	// channel support them, as signalled by the V3_0 channel capability
+	if isEd25519Cert(cert) && msp.version < MSPv3_0 {
+		return nil, errors.New("Ed25519 certificates require the V3_0 channel capability")
+	}
+
 	if isECDSASignedCert(cert) {
 		// Lookup for a parent certificate to perform the sanitization
 		var parentCert *x509.Certificate
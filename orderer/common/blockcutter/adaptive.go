@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+)
+
+// smoothingFactor is the weight of a new observation in the moving averages
+// of the time between two messages and of the block commit latency.
+const smoothingFactor = 0.2
+
+// Tuner is implemented by the receivers that tune the batch timeout of the channel.
+type Tuner interface {
+	// BatchTimeout returns the amount of time to wait before cutting a batch.
+	BatchTimeout() time.Duration
+
+	// ObserveBlockCommit records the time it took to commit a block to the ledger.
+	ObserveBlockCommit(latency time.Duration)
+}
+
+// adaptiveBatching tunes the batches of a channel when the adaptive block cutting is enabled.
+//
+// A batch is meant to be cut about as often as the ledger commits a block: cutting
+// faster only queues small blocks behind the commits, cutting slower delays the
+// transactions for nothing. The effective batch timeout is therefore the smoothed
+// commit latency, and the effective max message count is the number of messages
+// expected to arrive within that timeout at the smoothed ingress rate. Both are kept
+// within the bounds of AdaptiveBatching, BatchSize.MaxMessageCount and BatchTimeout.
+// Until the ingress rate or the commit latency is known, the configured values are used.
+type adaptiveBatching struct {
+	mutex         sync.Mutex
+	lastArrival   time.Time
+	interArrival  float64 // smoothed time between two messages in seconds, 0 until known
+	commitLatency float64 // smoothed block commit latency in seconds, 0 until known
+}
+
+// observeArrival records a message arriving at the given time. The time since the previous
+// message is capped by the batch timeout so that an idle channel recovers quickly from a burst.
+func (a *adaptiveBatching) observeArrival(now time.Time, batchTimeout time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.lastArrival.IsZero() {
+		elapsed := now.Sub(a.lastArrival)
+		if elapsed > batchTimeout {
+			elapsed = batchTimeout
+		}
+		a.interArrival = smooth(a.interArrival, elapsed.Seconds())
+	}
+	a.lastArrival = now
+}
+
+func (a *adaptiveBatching) observeCommit(latency time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.commitLatency = smooth(a.commitLatency, latency.Seconds())
+}
+
+// tune returns the effective max message count and batch timeout.
+func (a *adaptiveBatching) tune(batchSize *ab.BatchSize, bounds *ordererpb.AdaptiveBatching, batchTimeout time.Duration) (maxMessageCount uint32, timeout time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	maxMessageCount, timeout = batchSize.MaxMessageCount, batchTimeout
+	minTimeout, err := time.ParseDuration(bounds.MinTimeout)
+	if err != nil || minTimeout > batchTimeout {
+		minTimeout = batchTimeout
+	}
+
+	if a.commitLatency > 0 {
+		timeout = time.Duration(a.commitLatency * float64(time.Second))
+		if timeout < minTimeout {
+			timeout = minTimeout
+		}
+		if timeout > batchTimeout {
+			timeout = batchTimeout
+		}
+	}
+
+	if a.interArrival > 0 {
+		expected := math.Ceil(timeout.Seconds() / a.interArrival)
+		if expected < float64(maxMessageCount) {
+			maxMessageCount = uint32(expected)
+		}
+		if maxMessageCount < bounds.MinMessageCount {
+			maxMessageCount = bounds.MinMessageCount
+		}
+	}
+
+	return maxMessageCount, timeout
+}
+
+// ingressRate returns the smoothed number of messages per second, or 0 if it is not known yet.
+func (a *adaptiveBatching) ingressRate() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.interArrival == 0 {
+		return 0
+	}
+	return 1 / a.interArrival
+}
+
+// smoothedCommitLatency returns the smoothed block commit latency in seconds, or 0 if it is not known yet.
+func (a *adaptiveBatching) smoothedCommitLatency() float64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.commitLatency
+}
+
+func smooth(average, observation float64) float64 {
+	if average == 0 {
+		return observation
+	}
+	return smoothingFactor*observation + (1-smoothingFactor)*average
+}
+
+// adaptiveBlockCutting returns true if the batches of the channel are tuned. This requires
+// both the AdaptiveBatching bounds and the orderer capability V3_0.
+func adaptiveBlockCutting(ordererConfig channelconfig.Orderer) bool {
+	return ordererConfig.AdaptiveBatching() != nil && ordererConfig.Capabilities().AdaptiveBlockCutting()
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"testing"
+	"time"
+
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveBatchingTune(t *testing.T) {
+	batchSize := &ab.BatchSize{MaxMessageCount: 100}
+	bounds := &ordererpb.AdaptiveBatching{
+		MinMessageCount: 5,
+		MinTimeout:      "20ms",
+	}
+	batchTimeout := time.Second
+	start := time.Unix(0, 0)
+
+	arrive := func(a *adaptiveBatching, count int, interval time.Duration) {
+		for i := 0; i < count; i++ {
+			a.observeArrival(start.Add(time.Duration(i)*interval), batchTimeout)
+		}
+	}
+
+	t.Run("NothingObserved", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		maxMessageCount, timeout := a.tune(batchSize, bounds, batchTimeout)
+		require.Equal(t, uint32(100), maxMessageCount)
+		require.Equal(t, time.Second, timeout)
+		require.Zero(t, a.ingressRate())
+		require.Zero(t, a.smoothedCommitLatency())
+	})
+
+	t.Run("HighIngressRate", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		arrive(a, 10, time.Millisecond)
+		a.observeCommit(50 * time.Millisecond)
+		maxMessageCount, timeout := a.tune(batchSize, bounds, batchTimeout)
+		require.Equal(t, uint32(50), maxMessageCount)
+		require.Equal(t, 50*time.Millisecond, timeout)
+		require.InDelta(t, 1000, a.ingressRate(), 0.001)
+	})
+
+	t.Run("CappedByMaxMessageCount", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		arrive(a, 10, 100*time.Microsecond)
+		a.observeCommit(50 * time.Millisecond)
+		maxMessageCount, _ := a.tune(batchSize, bounds, batchTimeout)
+		require.Equal(t, uint32(100), maxMessageCount)
+	})
+
+	t.Run("LowIngressRate", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		arrive(a, 10, 200*time.Millisecond)
+		a.observeCommit(5 * time.Millisecond)
+		maxMessageCount, timeout := a.tune(batchSize, bounds, batchTimeout)
+		require.Equal(t, uint32(5), maxMessageCount)
+		require.Equal(t, 20*time.Millisecond, timeout)
+	})
+
+	t.Run("SlowCommits", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		arrive(a, 10, time.Millisecond)
+		a.observeCommit(3 * time.Second)
+		maxMessageCount, timeout := a.tune(batchSize, bounds, batchTimeout)
+		require.Equal(t, uint32(100), maxMessageCount)
+		require.Equal(t, time.Second, timeout)
+	})
+
+	t.Run("IdleTimeCappedByBatchTimeout", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		a.observeArrival(start, batchTimeout)
+		a.observeArrival(start.Add(time.Hour), batchTimeout)
+		require.Equal(t, float64(1), a.ingressRate())
+	})
+
+	t.Run("Smoothing", func(t *testing.T) {
+		a := &adaptiveBatching{}
+		a.observeCommit(100 * time.Millisecond)
+		a.observeCommit(200 * time.Millisecond)
+		require.InDelta(t, 0.12, a.smoothedCommitLatency(), 1e-9)
+	})
+}
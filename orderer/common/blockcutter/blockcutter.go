@@ -12,7 +12,6 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/orderer/common/prometheus"
 )
 
 var logger = flogging.MustGetLogger("orderer.common.blockcutter")
@@ -36,16 +35,19 @@ type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	adaptive              *adaptiveBatching
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
 	Metrics               *Metrics
 }
 
-// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
+// NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager.
+// The Receiver also implements Tuner.
 func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics) Receiver {
 	return &receiver{
 		sharedConfigFetcher: sharedConfigFetcher,
+		adaptive:            &adaptiveBatching{},
 		Metrics:             metrics,
 		ChannelID:           channelID,
 	}
@@ -58,7 +60,8 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 // messageBatches length: 0, pending: true
 //   - no batch is cut and there are messages pending
 // messageBatches length: 1, pending: false
-//   - the message count reaches BatchSize.MaxMessageCount
+//   - the message count reaches BatchSize.MaxMessageCount, or the max message count
+//     tuned by the adaptive block cutting
 // messageBatches length: 1, pending: true
 //   - the current message will cause the pending batch size in bytes to exceed BatchSize.PreferredMaxBytes.
 // messageBatches length: 2, pending: false
@@ -78,8 +81,12 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
 	}
 
-	prometheus.Main()
-	batchSize := ordererConfig.BatchSize() // TODO: replace the static with a dynamic version (batch, block size)
+	batchSize := ordererConfig.BatchSize()
+	maxMessageCount := batchSize.MaxMessageCount
+	if adaptiveBlockCutting(ordererConfig) {
+		r.adaptive.observeArrival(time.Now(), ordererConfig.BatchTimeout())
+		maxMessageCount, _ = r.tune(ordererConfig)
+	}
 
 	messageSizeBytes := messageSizeBytes(msg)
 	if messageSizeBytes > batchSize.PreferredMaxBytes {
@@ -115,7 +122,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
-	if uint32(len(r.pendingBatch)) >= batchSize.MaxMessageCount {
+	if uint32(len(r.pendingBatch)) >= maxMessageCount {
 		logger.Debugf("Batch size met, cutting batch")
 		messageBatch := r.Cut()
 		messageBatches = append(messageBatches, messageBatch)
@@ -137,6 +144,37 @@ func (r *receiver) Cut() []*cb.Envelope {
 	return batch
 }
 
+// BatchTimeout returns the amount of time to wait before cutting a batch, that is
+// the BatchTimeout of the channel unless the adaptive block cutting is enabled.
+func (r *receiver) BatchTimeout() time.Duration {
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+	if !adaptiveBlockCutting(ordererConfig) {
+		return ordererConfig.BatchTimeout()
+	}
+	_, timeout := r.tune(ordererConfig)
+	return timeout
+}
+
+// ObserveBlockCommit records the time it took to commit a block to the ledger
+func (r *receiver) ObserveBlockCommit(latency time.Duration) {
+	r.adaptive.observeCommit(latency)
+}
+
+// tune returns the max message count and the batch timeout chosen by
+// the adaptive block cutting, and reports them as metrics.
+func (r *receiver) tune(ordererConfig channelconfig.Orderer) (uint32, time.Duration) {
+	maxMessageCount, timeout := r.adaptive.tune(ordererConfig.BatchSize(), ordererConfig.AdaptiveBatching(), ordererConfig.BatchTimeout())
+	r.Metrics.EffectiveMaxMessageCount.With("channel", r.ChannelID).Set(float64(maxMessageCount))
+	r.Metrics.EffectiveBatchTimeout.With("channel", r.ChannelID).Set(timeout.Seconds())
+	r.Metrics.IngressRate.With("channel", r.ChannelID).Set(r.adaptive.ingressRate())
+	r.Metrics.CommitLatency.With("channel", r.ChannelID).Set(r.adaptive.smoothedCommitLatency())
+	logger.Debugf("Adaptive block cutting chose a max message count of %d and a batch timeout of %s", maxMessageCount, timeout)
+	return maxMessageCount, timeout
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
@@ -37,6 +37,16 @@ type ordererConfig interface {
 	channelconfig.Orderer
 }
 
+//go:generate counterfeiter -o mock/metrics_gauge.go --fake-name MetricsGauge . metricsGauge
+type metricsGauge interface {
+	metrics.Gauge
+}
+
+//go:generate counterfeiter -o mock/orderer_capabilities.go --fake-name OrdererCapabilities . ordererCapabilities
+type ordererCapabilities interface {
+	channelconfig.OrdererCapabilities
+}
+
 func TestBlockcutter(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Blockcutter Suite")
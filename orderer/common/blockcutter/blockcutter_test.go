@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
 )
@@ -191,4 +194,119 @@ var _ = Describe("Blockcutter", func() {
 			Expect(fakeBlockFillDuration.ObserveCallCount()).To(Equal(0))
 		})
 	})
+
+	Describe("Adaptive block cutting", func() {
+		var (
+			tuner                        blockcutter.Tuner
+			message                      *cb.Envelope
+			fakeCapabilities             *mock.OrdererCapabilities
+			fakeEffectiveMaxMessageCount *mock.MetricsGauge
+			fakeEffectiveBatchTimeout    *mock.MetricsGauge
+			fakeIngressRate              *mock.MetricsGauge
+			fakeCommitLatency            *mock.MetricsGauge
+		)
+
+		BeforeEach(func() {
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   10,
+				PreferredMaxBytes: 1000,
+			})
+			fakeConfig.AdaptiveBatchingReturns(&ordererpb.AdaptiveBatching{
+				MinMessageCount: 2,
+				MinTimeout:      "10ms",
+			})
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+			fakeCapabilities = &mock.OrdererCapabilities{}
+			fakeCapabilities.AdaptiveBlockCuttingReturns(true)
+			fakeConfig.CapabilitiesReturns(fakeCapabilities)
+
+			newGauge := func() *mock.MetricsGauge {
+				gauge := &mock.MetricsGauge{}
+				gauge.WithReturns(gauge)
+				return gauge
+			}
+			fakeEffectiveMaxMessageCount = newGauge()
+			fakeEffectiveBatchTimeout = newGauge()
+			fakeIngressRate = newGauge()
+			fakeCommitLatency = newGauge()
+			metrics.EffectiveMaxMessageCount = fakeEffectiveMaxMessageCount
+			metrics.EffectiveBatchTimeout = fakeEffectiveBatchTimeout
+			metrics.IngressRate = fakeIngressRate
+			metrics.CommitLatency = fakeCommitLatency
+
+			tuner = bc.(blockcutter.Tuner)
+			message = &cb.Envelope{Payload: []byte("Twenty Bytes of Data"), Signature: []byte("Twenty Bytes of Data")}
+		})
+
+		It("uses the batch timeout until a block is committed", func() {
+			Expect(tuner.BatchTimeout()).To(Equal(2 * time.Second))
+		})
+
+		It("uses the commit latency as the batch timeout, within the bounds", func() {
+			tuner.ObserveBlockCommit(50 * time.Millisecond)
+			Expect(tuner.BatchTimeout()).To(Equal(50 * time.Millisecond))
+
+			bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+			tuner = bc.(blockcutter.Tuner)
+			tuner.ObserveBlockCommit(time.Millisecond)
+			Expect(tuner.BatchTimeout()).To(Equal(10 * time.Millisecond))
+
+			bc = blockcutter.NewReceiverImpl("mychannel", fakeConfigFetcher, metrics)
+			tuner = bc.(blockcutter.Tuner)
+			tuner.ObserveBlockCommit(time.Minute)
+			Expect(tuner.BatchTimeout()).To(Equal(2 * time.Second))
+		})
+
+		It("reports its decisions as metrics", func() {
+			tuner.ObserveBlockCommit(50 * time.Millisecond)
+			tuner.BatchTimeout()
+
+			Expect(fakeEffectiveMaxMessageCount.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			Expect(fakeEffectiveMaxMessageCount.SetArgsForCall(0)).To(Equal(float64(10)))
+			Expect(fakeEffectiveBatchTimeout.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			Expect(fakeEffectiveBatchTimeout.SetArgsForCall(0)).To(Equal(0.05))
+			Expect(fakeIngressRate.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			Expect(fakeIngressRate.SetArgsForCall(0)).To(Equal(float64(0)))
+			Expect(fakeCommitLatency.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			Expect(fakeCommitLatency.SetArgsForCall(0)).To(Equal(0.05))
+		})
+
+		It("cuts smaller batches when the messages arrive slower than the blocks are committed", func() {
+			tuner.ObserveBlockCommit(10 * time.Millisecond)
+
+			batches, pending := bc.Ordered(message)
+			Expect(batches).To(BeEmpty())
+			Expect(pending).To(BeTrue())
+
+			time.Sleep(20 * time.Millisecond)
+			batches, pending = bc.Ordered(message)
+			Expect(len(batches)).To(Equal(1))
+			Expect(len(batches[0])).To(Equal(2))
+			Expect(pending).To(BeFalse())
+
+			Expect(fakeEffectiveMaxMessageCount.SetCallCount()).To(Equal(2))
+			Expect(fakeEffectiveMaxMessageCount.SetArgsForCall(1)).To(Equal(float64(2)))
+			Expect(fakeIngressRate.SetArgsForCall(1)).To(BeNumerically(">", 0))
+			Expect(fakeIngressRate.SetArgsForCall(1)).To(BeNumerically("<=", 50))
+		})
+
+		Context("when the capability is not enabled", func() {
+			BeforeEach(func() {
+				fakeCapabilities.AdaptiveBlockCuttingReturns(false)
+			})
+
+			It("uses the configured batch size and timeout", func() {
+				tuner.ObserveBlockCommit(10 * time.Millisecond)
+				Expect(tuner.BatchTimeout()).To(Equal(2 * time.Second))
+
+				bc.Ordered(message)
+				time.Sleep(20 * time.Millisecond)
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+
+				Expect(fakeEffectiveMaxMessageCount.SetCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -16,14 +16,52 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	effectiveMaxMessageCount = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "effective_max_message_count",
+		Help:         "The max message count of the batches chosen by the adaptive block cutting.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	effectiveBatchTimeout = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "effective_batch_timeout",
+		Help:         "The batch timeout chosen by the adaptive block cutting in seconds.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	ingressRate = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "ingress_rate",
+		Help:         "The smoothed rate of the messages ordered in messages per second, as observed by the adaptive block cutting.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	commitLatency = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "commit_latency",
+		Help:         "The smoothed time to commit a block to the ledger in seconds, as observed by the adaptive block cutting.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
 	BlockFillDuration metrics.Histogram
+
+	// The following metrics are only reported when the adaptive block cutting is enabled
+	EffectiveMaxMessageCount metrics.Gauge
+	EffectiveBatchTimeout    metrics.Gauge
+	IngressRate              metrics.Gauge
+	CommitLatency            metrics.Gauge
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
-		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		BlockFillDuration:        p.NewHistogram(blockFillDuration),
+		EffectiveMaxMessageCount: p.NewGauge(effectiveMaxMessageCount),
+		EffectiveBatchTimeout:    p.NewGauge(effectiveBatchTimeout),
+		IngressRate:              p.NewGauge(ingressRate),
+		CommitLatency:            p.NewGauge(commitLatency),
 	}
 }
@@ -23,6 +23,7 @@ var _ = Describe("Metrics", func() {
 		BeforeEach(func() {
 			fakeProvider = &mock.MetricsProvider{}
 			fakeProvider.NewHistogramReturns(&mock.MetricsHistogram{})
+			fakeProvider.NewGaugeReturns(&mock.MetricsGauge{})
 		})
 
 		It("uses the provider to initialize its field", func() {
@@ -30,7 +31,13 @@ var _ = Describe("Metrics", func() {
 			Expect(metrics).NotTo(BeNil())
 			Expect(metrics.BlockFillDuration).To(Equal(&mock.MetricsHistogram{}))
 
+			Expect(metrics.EffectiveMaxMessageCount).To(Equal(&mock.MetricsGauge{}))
+			Expect(metrics.EffectiveBatchTimeout).To(Equal(&mock.MetricsGauge{}))
+			Expect(metrics.IngressRate).To(Equal(&mock.MetricsGauge{}))
+			Expect(metrics.CommitLatency).To(Equal(&mock.MetricsGauge{}))
+
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(4))
 		})
 	})
 })
@@ -12,6 +12,7 @@ import (
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
@@ -36,6 +37,9 @@ type ChannelSupportRegistrar interface {
 type ChannelSupport interface {
 	msgprocessor.Processor
 	Consenter
+
+	// SharedConfig provides the orderer config of the channel
+	SharedConfig() channelconfig.Orderer
 }
 
 // Consenter provides methods to send messages through consensus
@@ -60,6 +64,8 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Limiter, if set, applies rate limits to the normal messages
+	Limiter *Limiter
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -167,6 +173,17 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 		tracker.EndValidate()
 
+		if bh.Limiter != nil {
+			if err = bh.Limiter.Admit(chdr.ChannelId, processor.SharedConfig().BroadcastLimits(), msg); err != nil {
+				status := cb.Status_BAD_REQUEST
+				if rlErr, ok := err.(*RateLimitError); ok && rlErr.RetryAfter > 0 {
+					status = cb.Status_SERVICE_UNAVAILABLE
+				}
+				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with %s: %s", chdr.ChannelId, addr, status, err)
+				return &ab.BroadcastResponse{Status: status, Info: err.Error()}
+			}
+		}
+
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
@@ -174,6 +191,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 		}
 
 		err = processor.Order(msg, configSeq)
+		if errors.Cause(err) == msgprocessor.ErrDuplicateTxID {
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+		}
 		if err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
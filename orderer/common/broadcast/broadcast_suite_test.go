@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/metrics"
 
 	. "github.com/onsi/ginkgo"
@@ -36,6 +37,11 @@ type metricsProvider interface {
 	metrics.Provider
 }
 
+//go:generate counterfeiter -o mock/orderer_config.go --fake-name OrdererConfig . ordererConfig
+type ordererConfig interface {
+	channelconfig.Orderer
+}
+
 func TestBroadcast(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Broadcast Suite")
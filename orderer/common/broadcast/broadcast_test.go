@@ -16,10 +16,14 @@ import (
 	. "github.com/onsi/gomega"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/protoutil"
 )
 
 var _ = Describe("Broadcast", func() {
@@ -225,6 +229,23 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when the transaction ID of the message was already ordered", func() {
+			BeforeEach(func() {
+				fakeSupport.OrderReturns(msgprocessor.ErrDuplicateTxID)
+			})
+
+			It("returns the error with a bad request status", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeABServer.SendCallCount()).To(Equal(1))
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(0),
+					&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "duplicate transaction ID"}),
+				).To(BeTrue())
+			})
+		})
+
 		Context("when the message processor returns an error", func() {
 			BeforeEach(func() {
 				fakeSupport.ProcessNormalMsgReturns(0, fmt.Errorf("normal-messsage-processing-error"))
@@ -276,6 +297,124 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when a limiter is set", func() {
+			var (
+				fakeOrdererConfig        *mock.OrdererConfig
+				fakeRateLimitedCounter   *mock.MetricsCounter
+				fakeAdmittedBytesCounter *mock.MetricsCounter
+			)
+
+			BeforeEach(func() {
+				fakeMsg = &cb.Envelope{
+					Payload: protoutil.MarshalOrPanic(&cb.Payload{
+						Header: &cb.Header{
+							SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+								Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("client")}),
+							}),
+						},
+					}),
+				}
+				fakeABServer.RecvReturnsOnCall(0, fakeMsg, nil)
+
+				fakeOrdererConfig = &mock.OrdererConfig{}
+				fakeOrdererConfig.BroadcastLimitsReturns(&ordererpb.BroadcastLimits{
+					Identity: &ordererpb.RateLimit{MessagesPerSecond: 1, MessageBurst: 1, BytesPerSecond: 1000, ByteBurst: 1000},
+				})
+				fakeSupport.SharedConfigReturns(fakeOrdererConfig)
+
+				fakeRateLimitedCounter = &mock.MetricsCounter{}
+				fakeRateLimitedCounter.WithReturns(fakeRateLimitedCounter)
+				fakeAdmittedBytesCounter = &mock.MetricsCounter{}
+				fakeAdmittedBytesCounter.WithReturns(fakeAdmittedBytesCounter)
+				handler.Metrics.RateLimitedCount = fakeRateLimitedCounter
+				handler.Metrics.AdmittedBytes = fakeAdmittedBytesCounter
+				handler.Limiter = broadcast.NewLimiter(localconfig.BroadcastLimits{}, handler.Metrics)
+			})
+
+			It("enqueues the messages within the limits of the channel", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				Expect(fakeABServer.SendCallCount()).To(Equal(1))
+				Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
+
+				Expect(fakeAdmittedBytesCounter.WithArgsForCall(0)).To(Equal([]string{"channel", "fake-channel", "mspid", "Org1MSP"}))
+				Expect(fakeAdmittedBytesCounter.AddArgsForCall(0)).To(Equal(float64(len(fakeMsg.Payload))))
+			})
+
+			It("rejects the messages exceeding the limits with a service unavailable status", func() {
+				resp := handler.ProcessMessage(fakeMsg, "addr")
+				Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+
+				resp = handler.ProcessMessage(fakeMsg, "addr")
+				Expect(resp.Status).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				Expect(resp.Info).To(MatchRegexp(`^identity rate limit of Org1MSP exceeded, retry after \d+`))
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+
+				Expect(fakeRateLimitedCounter.WithCallCount()).To(Equal(1))
+				Expect(fakeRateLimitedCounter.WithArgsForCall(0)).To(Equal([]string{"channel", "fake-channel", "mspid", "Org1MSP", "limit", "identity"}))
+				Expect(fakeRateLimitedCounter.AddArgsForCall(0)).To(Equal(float64(1)))
+			})
+
+			Context("when the message is larger than the byte burst", func() {
+				BeforeEach(func() {
+					fakeMsg.Signature = make([]byte, 2000)
+				})
+
+				It("rejects the message with a bad request status", func() {
+					resp := handler.ProcessMessage(fakeMsg, "addr")
+					Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+					Expect(resp.Info).To(Equal("message exceeds the byte burst of the identity rate limit of Org1MSP"))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the creator of the message cannot be determined", func() {
+				BeforeEach(func() {
+					fakeMsg.Payload = []byte("garbage")
+				})
+
+				It("rejects the message with a bad request status", func() {
+					resp := handler.ProcessMessage(fakeMsg, "addr")
+					Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+					Expect(resp.Info).To(HavePrefix("could not determine the creator of the message"))
+				})
+			})
+
+			Context("when the channel does not set limits", func() {
+				BeforeEach(func() {
+					fakeOrdererConfig.BroadcastLimitsReturns(nil)
+				})
+
+				It("does not limit the messages", func() {
+					for i := 0; i < 5; i++ {
+						resp := handler.ProcessMessage(fakeMsg, "addr")
+						Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					}
+					Expect(fakeAdmittedBytesCounter.AddCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the message is a config message", func() {
+				BeforeEach(func() {
+					fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
+						Type:      1,
+						ChannelId: "fake-channel",
+					}, true, fakeSupport, nil)
+					fakeSupport.ProcessConfigUpdateMsgReturns(&cb.Envelope{}, 3, nil)
+				})
+
+				It("does not limit the messages", func() {
+					for i := 0; i < 5; i++ {
+						resp := handler.ProcessMessage(fakeMsg, "addr")
+						Expect(resp.Status).To(Equal(cb.Status_SUCCESS))
+					}
+					Expect(fakeSupport.SharedConfigCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the message is a config message", func() {
 			var (
 				fakeConfig *cb.Envelope
@@ -0,0 +1,283 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	identityLimit     = "identity"
+	organizationLimit = "organization"
+
+	// sweepInterval is how often the buckets that are full, and therefore
+	// equivalent to new buckets, are removed
+	sweepInterval = time.Minute
+)
+
+// RateLimitError is returned when a message exceeds a rate limit or a byte quota.
+type RateLimitError struct {
+	// Limit is the limit exceeded, identity or organization
+	Limit string
+	// MSPID is the MSP ID of the creator of the message
+	MSPID string
+	// RetryAfter is the time after which the message would be admitted, or zero
+	// if the message is larger than the byte burst and can never be admitted
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter == 0 {
+		return fmt.Sprintf("message exceeds the byte burst of the %s rate limit of %s", e.Limit, e.MSPID)
+	}
+	return fmt.Sprintf("%s rate limit of %s exceeded, retry after %s", e.Limit, e.MSPID, e.RetryAfter)
+}
+
+// Limiter applies token bucket rate limits and byte quotas to the messages broadcast
+// by each identity and each organization on each channel. The limits of a channel are
+// the BroadcastLimits of its channel config if set, otherwise the default limits of the
+// local config, so that a config update of the channel changes the limits in place.
+type Limiter struct {
+	defaults *ordererpb.BroadcastLimits
+	metrics  *Metrics
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	channelID string
+	limit     string
+	mspID     string
+	identity  [sha256.Size]byte
+}
+
+// NewLimiter creates a Limiter with the default limits of the local config.
+func NewLimiter(conf localconfig.BroadcastLimits, metrics *Metrics) *Limiter {
+	var defaults *ordererpb.BroadcastLimits
+	if conf.Enabled {
+		defaults = &ordererpb.BroadcastLimits{
+			Identity:     rateLimitFromConfig(conf.Identity),
+			Organization: rateLimitFromConfig(conf.Organization),
+		}
+	}
+	return &Limiter{
+		defaults: defaults,
+		metrics:  metrics,
+		now:      time.Now,
+		buckets:  map[bucketKey]*bucket{},
+	}
+}
+
+func rateLimitFromConfig(conf localconfig.RateLimit) *ordererpb.RateLimit {
+	return &ordererpb.RateLimit{
+		MessagesPerSecond: conf.MessagesPerSecond,
+		MessageBurst:      conf.MessageBurst,
+		BytesPerSecond:    conf.BytesPerSecond,
+		ByteBurst:         conf.ByteBurst,
+	}
+}
+
+// Admit takes the message from the buckets of its creator and of the organization of its
+// creator, or returns a RateLimitError if one of the buckets does not hold enough tokens.
+// The channel limits are those of the channel config, nil if it does not set them.
+func (l *Limiter) Admit(channelID string, channelLimits *ordererpb.BroadcastLimits, msg *cb.Envelope) error {
+	limits := channelLimits
+	if limits == nil {
+		limits = l.defaults
+	}
+	if limits == nil {
+		return nil
+	}
+
+	mspID, creator, err := creatorOf(msg)
+	if err != nil {
+		return errors.WithMessage(err, "could not determine the creator of the message")
+	}
+	size := float64(len(msg.Payload) + len(msg.Signature))
+
+	orgLimit := limits.Organization
+	if limit, ok := limits.Organizations[mspID]; ok {
+		orgLimit = limit
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	var buckets []*bucket
+	var limitNames []string
+	if limited(limits.Identity) {
+		key := bucketKey{channelID: channelID, limit: identityLimit, mspID: mspID, identity: sha256.Sum256(creator)}
+		buckets = append(buckets, l.bucket(key, limits.Identity, now))
+		limitNames = append(limitNames, identityLimit)
+	}
+	if limited(orgLimit) {
+		key := bucketKey{channelID: channelID, limit: organizationLimit, mspID: mspID}
+		buckets = append(buckets, l.bucket(key, orgLimit, now))
+		limitNames = append(limitNames, organizationLimit)
+	}
+
+	// the message is taken from the buckets only if all of them admit it
+	for i, b := range buckets {
+		retryAfter, ok := b.wait(size)
+		if retryAfter == 0 && ok {
+			continue
+		}
+		l.metrics.RateLimitedCount.With("channel", channelID, "mspid", mspID, "limit", limitNames[i]).Add(1)
+		return &RateLimitError{Limit: limitNames[i], MSPID: mspID, RetryAfter: retryAfter}
+	}
+	for _, b := range buckets {
+		b.take(size)
+	}
+	l.metrics.AdmittedBytes.With("channel", channelID, "mspid", mspID).Add(size)
+
+	return nil
+}
+
+// bucket returns the bucket of the key, refilled up to now, with the given limit.
+func (l *Limiter) bucket(key bucketKey, limit *ordererpb.RateLimit, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(limit, now)
+		l.buckets[key] = b
+		return b
+	}
+	b.refill(now)
+	if !proto.Equal(b.limit, limit) {
+		b.setLimit(limit)
+	}
+	return b
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.full() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func limited(limit *ordererpb.RateLimit) bool {
+	return limit != nil && (limit.MessagesPerSecond > 0 || limit.BytesPerSecond > 0)
+}
+
+// creatorOf returns the MSP ID and the serialized identity of the creator of the message.
+func creatorOf(msg *cb.Envelope) (string, []byte, error) {
+	payload, err := protoutil.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return "", nil, err
+	}
+	if payload.Header == nil {
+		return "", nil, errors.New("missing header")
+	}
+	shdr, err := protoutil.UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return "", nil, err
+	}
+	identity, err := protoutil.UnmarshalSerializedIdentity(shdr.Creator)
+	if err != nil {
+		return "", nil, err
+	}
+	return identity.Mspid, shdr.Creator, nil
+}
+
+// bucket holds the message and byte tokens of a rate limit.
+type bucket struct {
+	limit    *ordererpb.RateLimit
+	messages float64
+	bytes    float64
+	last     time.Time
+}
+
+func newBucket(limit *ordererpb.RateLimit, now time.Time) *bucket {
+	b := &bucket{limit: limit, last: now}
+	b.messages, b.bytes = b.messageBurst(), b.byteBurst()
+	return b
+}
+
+func (b *bucket) messageBurst() float64 {
+	if b.limit.MessageBurst > 0 {
+		return float64(b.limit.MessageBurst)
+	}
+	return math.Max(1, math.Ceil(b.limit.MessagesPerSecond))
+}
+
+func (b *bucket) byteBurst() float64 {
+	if b.limit.ByteBurst > 0 {
+		return float64(b.limit.ByteBurst)
+	}
+	return math.Max(1, math.Ceil(b.limit.BytesPerSecond))
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.messages = math.Min(b.messageBurst(), b.messages+elapsed*b.limit.MessagesPerSecond)
+	b.bytes = math.Min(b.byteBurst(), b.bytes+elapsed*b.limit.BytesPerSecond)
+}
+
+func (b *bucket) setLimit(limit *ordererpb.RateLimit) {
+	b.limit = limit
+	b.messages = math.Min(b.messageBurst(), b.messages)
+	b.bytes = math.Min(b.byteBurst(), b.bytes)
+}
+
+// wait returns how long to wait until the bucket holds the tokens of a message of the
+// given size. It returns false if the bucket can never hold them.
+func (b *bucket) wait(size float64) (time.Duration, bool) {
+	var wait float64
+	if b.limit.MessagesPerSecond > 0 && b.messages < 1 {
+		wait = (1 - b.messages) / b.limit.MessagesPerSecond
+	}
+	if b.limit.BytesPerSecond > 0 {
+		if size > b.byteBurst() {
+			return 0, false
+		}
+		if b.bytes < size {
+			wait = math.Max(wait, (size-b.bytes)/b.limit.BytesPerSecond)
+		}
+	}
+	return time.Duration(math.Ceil(wait * float64(time.Second))), true
+}
+
+func (b *bucket) take(size float64) {
+	if b.limit.MessagesPerSecond > 0 {
+		b.messages--
+	}
+	if b.limit.BytesPerSecond > 0 {
+		b.bytes -= size
+	}
+}
+
+func (b *bucket) full() bool {
+	return (b.limit.MessagesPerSecond == 0 || b.messages >= b.messageBurst()) &&
+		(b.limit.BytesPerSecond == 0 || b.bytes >= b.byteBurst())
+}
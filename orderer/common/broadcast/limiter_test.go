@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(conf localconfig.BroadcastLimits) (*Limiter, *time.Time) {
+	l := NewLimiter(conf, NewMetrics(&disabled.Provider{}))
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func envelope(mspID, id string, size int) *cb.Envelope {
+	env := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+					Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(id)}),
+				}),
+			},
+		}),
+	}
+	if size > len(env.Payload) {
+		env.Signature = make([]byte, size-len(env.Payload))
+	}
+	return env
+}
+
+func requireRateLimited(t *testing.T, err error, limit, mspID string, retryAfter time.Duration) {
+	require.IsType(t, &RateLimitError{}, err)
+	require.Equal(t, &RateLimitError{Limit: limit, MSPID: mspID, RetryAfter: retryAfter}, err)
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l, _ := newTestLimiter(localconfig.BroadcastLimits{
+		Identity: localconfig.RateLimit{MessagesPerSecond: 1},
+	})
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client1", 100)))
+	}
+	require.Empty(t, l.buckets)
+}
+
+func TestLimiterIdentity(t *testing.T) {
+	l, now := newTestLimiter(localconfig.BroadcastLimits{
+		Enabled:  true,
+		Identity: localconfig.RateLimit{MessagesPerSecond: 2, MessageBurst: 4},
+	})
+
+	client1 := envelope("Org1MSP", "client1", 100)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, l.Admit("mychannel", nil, client1))
+	}
+	requireRateLimited(t, l.Admit("mychannel", nil, client1), "identity", "Org1MSP", 500*time.Millisecond)
+
+	// the other identities and channels have their own buckets
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client2", 100)))
+	require.NoError(t, l.Admit("otherchannel", nil, client1))
+
+	*now = now.Add(500 * time.Millisecond)
+	require.NoError(t, l.Admit("mychannel", nil, client1))
+	requireRateLimited(t, l.Admit("mychannel", nil, client1), "identity", "Org1MSP", 500*time.Millisecond)
+}
+
+func TestLimiterOrganization(t *testing.T) {
+	l, now := newTestLimiter(localconfig.BroadcastLimits{
+		Enabled:      true,
+		Identity:     localconfig.RateLimit{MessagesPerSecond: 10},
+		Organization: localconfig.RateLimit{BytesPerSecond: 1000, ByteBurst: 2000},
+	})
+
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client1", 800)))
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client2", 800)))
+	requireRateLimited(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client3", 800)), "organization", "Org1MSP", 400*time.Millisecond)
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org2MSP", "client1", 800)))
+
+	// a rejected message does not take tokens from the other buckets
+	require.Equal(t, float64(10), l.buckets[bucketKey{
+		channelID: "mychannel",
+		limit:     identityLimit,
+		mspID:     "Org1MSP",
+		identity:  sha256Of(t, envelope("Org1MSP", "client3", 0)),
+	}].messages)
+
+	*now = now.Add(400 * time.Millisecond)
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client3", 800)))
+
+	// a message larger than the byte burst is never admitted
+	err := l.Admit("mychannel", nil, envelope("Org2MSP", "client1", 3000))
+	requireRateLimited(t, err, "organization", "Org2MSP", 0)
+	require.EqualError(t, err, "message exceeds the byte burst of the organization rate limit of Org2MSP")
+}
+
+func TestLimiterChannelLimits(t *testing.T) {
+	l, now := newTestLimiter(localconfig.BroadcastLimits{
+		Enabled:  true,
+		Identity: localconfig.RateLimit{MessagesPerSecond: 1},
+	})
+	channelLimits := &ordererpb.BroadcastLimits{
+		Organization: &ordererpb.RateLimit{MessagesPerSecond: 100},
+		Organizations: map[string]*ordererpb.RateLimit{
+			"Org2MSP": {MessagesPerSecond: 1, MessageBurst: 2},
+		},
+	}
+
+	// the channel limits take precedence over the local limits
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.Admit("mychannel", channelLimits, envelope("Org1MSP", "client1", 100)))
+	}
+	require.NoError(t, l.Admit("mychannel", channelLimits, envelope("Org2MSP", "client1", 100)))
+	require.NoError(t, l.Admit("mychannel", channelLimits, envelope("Org2MSP", "client1", 100)))
+	requireRateLimited(t, l.Admit("mychannel", channelLimits, envelope("Org2MSP", "client1", 100)), "organization", "Org2MSP", time.Second)
+
+	// a config update changes the limits of the existing buckets
+	channelLimits = &ordererpb.BroadcastLimits{
+		Organization: &ordererpb.RateLimit{MessagesPerSecond: 100},
+		Organizations: map[string]*ordererpb.RateLimit{
+			"Org2MSP": {MessagesPerSecond: 10, MessageBurst: 2},
+		},
+	}
+	requireRateLimited(t, l.Admit("mychannel", channelLimits, envelope("Org2MSP", "client1", 100)), "organization", "Org2MSP", 100*time.Millisecond)
+	*now = now.Add(100 * time.Millisecond)
+	require.NoError(t, l.Admit("mychannel", channelLimits, envelope("Org2MSP", "client1", 100)))
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, now := newTestLimiter(localconfig.BroadcastLimits{
+		Enabled:  true,
+		Identity: localconfig.RateLimit{MessagesPerSecond: 1, MessageBurst: 10},
+	})
+
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client1", 100)))
+	require.Len(t, l.buckets, 1)
+
+	*now = now.Add(sweepInterval)
+	require.NoError(t, l.Admit("mychannel", nil, envelope("Org1MSP", "client2", 100)))
+	require.Len(t, l.buckets, 1)
+}
+
+func TestLimiterBadMessage(t *testing.T) {
+	l, _ := newTestLimiter(localconfig.BroadcastLimits{
+		Enabled:  true,
+		Identity: localconfig.RateLimit{MessagesPerSecond: 1},
+	})
+
+	err := l.Admit("mychannel", nil, &cb.Envelope{Payload: []byte("garbage")})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not determine the creator of the message")
+
+	err = l.Admit("mychannel", nil, &cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{})})
+	require.EqualError(t, err, "could not determine the creator of the message: missing header")
+}
+
+func sha256Of(t *testing.T, env *cb.Envelope) [32]byte {
+	_, creator, err := creatorOf(env)
+	require.NoError(t, err)
+	return sha256.Sum256(creator)
+}
@@ -30,12 +30,28 @@ var (
 		LabelNames:   []string{"channel", "type", "status"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{type}.%{status}",
 	}
+	rateLimitedCount = metrics.CounterOpts{
+		Namespace:    "broadcast",
+		Name:         "rate_limited_count",
+		Help:         "The number of transactions rejected because they exceeded a rate limit.",
+		LabelNames:   []string{"channel", "mspid", "limit"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{mspid}.%{limit}",
+	}
+	admittedBytes = metrics.CounterOpts{
+		Namespace:    "broadcast",
+		Name:         "admitted_bytes",
+		Help:         "The number of bytes of the transactions admitted by the rate limits.",
+		LabelNames:   []string{"channel", "mspid"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{mspid}",
+	}
 )
 
 type Metrics struct {
 	ValidateDuration metrics.Histogram
 	EnqueueDuration  metrics.Histogram
 	ProcessedCount   metrics.Counter
+	RateLimitedCount metrics.Counter
+	AdmittedBytes    metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -43,5 +59,7 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		ValidateDuration: p.NewHistogram(validateDuration),
 		EnqueueDuration:  p.NewHistogram(enqueueDuration),
 		ProcessedCount:   p.NewCounter(processedCount),
+		RateLimitedCount: p.NewCounter(rateLimitedCount),
+		AdmittedBytes:    p.NewCounter(admittedBytes),
 	}
 }
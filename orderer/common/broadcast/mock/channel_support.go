@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 )
@@ -88,6 +89,16 @@ type ChannelSupport struct {
 		result1 uint64
 		result2 error
 	}
+	SharedConfigStub        func() channelconfig.Orderer
+	sharedConfigMutex       sync.RWMutex
+	sharedConfigArgsForCall []struct {
+	}
+	sharedConfigReturns struct {
+		result1 channelconfig.Orderer
+	}
+	sharedConfigReturnsOnCall map[int]struct {
+		result1 channelconfig.Orderer
+	}
 	WaitReadyStub        func() error
 	waitReadyMutex       sync.RWMutex
 	waitReadyArgsForCall []struct {
@@ -479,6 +490,58 @@ func (fake *ChannelSupport) ProcessNormalMsgReturnsOnCall(i int, result1 uint64,
 	}{result1, result2}
 }
 
+func (fake *ChannelSupport) SharedConfig() channelconfig.Orderer {
+	fake.sharedConfigMutex.Lock()
+	ret, specificReturn := fake.sharedConfigReturnsOnCall[len(fake.sharedConfigArgsForCall)]
+	fake.sharedConfigArgsForCall = append(fake.sharedConfigArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SharedConfig", []interface{}{})
+	fake.sharedConfigMutex.Unlock()
+	if fake.SharedConfigStub != nil {
+		return fake.SharedConfigStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sharedConfigReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelSupport) SharedConfigCallCount() int {
+	fake.sharedConfigMutex.RLock()
+	defer fake.sharedConfigMutex.RUnlock()
+	return len(fake.sharedConfigArgsForCall)
+}
+
+func (fake *ChannelSupport) SharedConfigCalls(stub func() channelconfig.Orderer) {
+	fake.sharedConfigMutex.Lock()
+	defer fake.sharedConfigMutex.Unlock()
+	fake.SharedConfigStub = stub
+}
+
+func (fake *ChannelSupport) SharedConfigReturns(result1 channelconfig.Orderer) {
+	fake.sharedConfigMutex.Lock()
+	defer fake.sharedConfigMutex.Unlock()
+	fake.SharedConfigStub = nil
+	fake.sharedConfigReturns = struct {
+		result1 channelconfig.Orderer
+	}{result1}
+}
+
+func (fake *ChannelSupport) SharedConfigReturnsOnCall(i int, result1 channelconfig.Orderer) {
+	fake.sharedConfigMutex.Lock()
+	defer fake.sharedConfigMutex.Unlock()
+	fake.SharedConfigStub = nil
+	if fake.sharedConfigReturnsOnCall == nil {
+		fake.sharedConfigReturnsOnCall = make(map[int]struct {
+			result1 channelconfig.Orderer
+		})
+	}
+	fake.sharedConfigReturnsOnCall[i] = struct {
+		result1 channelconfig.Orderer
+	}{result1}
+}
+
 func (fake *ChannelSupport) WaitReady() error {
 	fake.waitReadyMutex.Lock()
 	ret, specificReturn := fake.waitReadyReturnsOnCall[len(fake.waitReadyArgsForCall)]
@@ -546,6 +609,8 @@ func (fake *ChannelSupport) Invocations() map[string][][]interface{} {
 	defer fake.processConfigUpdateMsgMutex.RUnlock()
 	fake.processNormalMsgMutex.RLock()
 	defer fake.processNormalMsgMutex.RUnlock()
+	fake.sharedConfigMutex.RLock()
+	defer fake.sharedConfigMutex.RUnlock()
 	fake.waitReadyMutex.RLock()
 	defer fake.waitReadyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
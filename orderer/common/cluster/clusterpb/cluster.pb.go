@@ -0,0 +1,309 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: cluster.proto
+
+package clusterpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// StepRequest is an orderer.StepRequest that can also carry the messages of
+// a snapshot transfer. It is wire compatible with orderer.StepRequest, and is
+// sent and received over the orderer.Cluster Step stream.
+type StepRequest struct {
+	// Types that are valid to be assigned to Payload:
+	//	*StepRequest_ConsensusRequest
+	//	*StepRequest_SubmitRequest
+	//	*StepRequest_SnapshotRequest
+	//	*StepRequest_SnapshotChunk
+	Payload              isStepRequest_Payload `protobuf_oneof:"payload"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *StepRequest) Reset()         { *m = StepRequest{} }
+func (m *StepRequest) String() string { return proto.CompactTextString(m) }
+func (*StepRequest) ProtoMessage()    {}
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3cfb3b8ec240c376, []int{0}
+}
+
+func (m *StepRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StepRequest.Unmarshal(m, b)
+}
+func (m *StepRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StepRequest.Marshal(b, m, deterministic)
+}
+func (m *StepRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepRequest.Merge(m, src)
+}
+func (m *StepRequest) XXX_Size() int {
+	return xxx_messageInfo_StepRequest.Size(m)
+}
+func (m *StepRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StepRequest proto.InternalMessageInfo
+
+type isStepRequest_Payload interface {
+	isStepRequest_Payload()
+}
+
+type StepRequest_ConsensusRequest struct {
+	ConsensusRequest *orderer.ConsensusRequest `protobuf:"bytes,1,opt,name=consensus_request,json=consensusRequest,proto3,oneof"`
+}
+
+type StepRequest_SubmitRequest struct {
+	SubmitRequest *orderer.SubmitRequest `protobuf:"bytes,2,opt,name=submit_request,json=submitRequest,proto3,oneof"`
+}
+
+type StepRequest_SnapshotRequest struct {
+	SnapshotRequest *SnapshotRequest `protobuf:"bytes,3,opt,name=snapshot_request,json=snapshotRequest,proto3,oneof"`
+}
+
+type StepRequest_SnapshotChunk struct {
+	SnapshotChunk *SnapshotChunk `protobuf:"bytes,4,opt,name=snapshot_chunk,json=snapshotChunk,proto3,oneof"`
+}
+
+func (*StepRequest_ConsensusRequest) isStepRequest_Payload() {}
+
+func (*StepRequest_SubmitRequest) isStepRequest_Payload() {}
+
+func (*StepRequest_SnapshotRequest) isStepRequest_Payload() {}
+
+func (*StepRequest_SnapshotChunk) isStepRequest_Payload() {}
+
+func (m *StepRequest) GetPayload() isStepRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *StepRequest) GetConsensusRequest() *orderer.ConsensusRequest {
+	if x, ok := m.GetPayload().(*StepRequest_ConsensusRequest); ok {
+		return x.ConsensusRequest
+	}
+	return nil
+}
+
+func (m *StepRequest) GetSubmitRequest() *orderer.SubmitRequest {
+	if x, ok := m.GetPayload().(*StepRequest_SubmitRequest); ok {
+		return x.SubmitRequest
+	}
+	return nil
+}
+
+func (m *StepRequest) GetSnapshotRequest() *SnapshotRequest {
+	if x, ok := m.GetPayload().(*StepRequest_SnapshotRequest); ok {
+		return x.SnapshotRequest
+	}
+	return nil
+}
+
+func (m *StepRequest) GetSnapshotChunk() *SnapshotChunk {
+	if x, ok := m.GetPayload().(*StepRequest_SnapshotChunk); ok {
+		return x.SnapshotChunk
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*StepRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*StepRequest_ConsensusRequest)(nil),
+		(*StepRequest_SubmitRequest)(nil),
+		(*StepRequest_SnapshotRequest)(nil),
+		(*StepRequest_SnapshotChunk)(nil),
+	}
+}
+
+// SnapshotRequest asks a cluster member to send the blocks of a channel
+// in compressed chunks, to a cluster member lagging behind. The requester
+// asks for a window of chunks at a time, and asks for the next window once
+// it has written the blocks it received, so the transfer is flow controlled
+// and can be resumed from any cluster member if it is interrupted.
+type SnapshotRequest struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// start is the number of the first block to send.
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the number of the last block to send.
+	End uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// window is the number of chunks to send for this request.
+	Window               uint32   `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotRequest) Reset()         { *m = SnapshotRequest{} }
+func (m *SnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotRequest) ProtoMessage()    {}
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3cfb3b8ec240c376, []int{1}
+}
+
+func (m *SnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotRequest.Unmarshal(m, b)
+}
+func (m *SnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotRequest.Merge(m, src)
+}
+func (m *SnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotRequest.Size(m)
+}
+func (m *SnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotRequest proto.InternalMessageInfo
+
+func (m *SnapshotRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *SnapshotRequest) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *SnapshotRequest) GetEnd() uint64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *SnapshotRequest) GetWindow() uint32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
+// SnapshotChunk carries consecutive blocks of a channel
+// sent in response to a SnapshotRequest.
+type SnapshotChunk struct {
+	Channel string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	// first is the number of the first block in the chunk.
+	First uint64 `protobuf:"varint,2,opt,name=first,proto3" json:"first,omitempty"`
+	// last is the number of the last block in the chunk.
+	Last uint64 `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`
+	// blocks is a gzip compressed common.BlockData,
+	// whose data are the marshaled blocks.
+	Blocks               []byte   `protobuf:"bytes,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotChunk) Reset()         { *m = SnapshotChunk{} }
+func (m *SnapshotChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotChunk) ProtoMessage()    {}
+func (*SnapshotChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3cfb3b8ec240c376, []int{2}
+}
+
+func (m *SnapshotChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotChunk.Unmarshal(m, b)
+}
+func (m *SnapshotChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotChunk.Marshal(b, m, deterministic)
+}
+func (m *SnapshotChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotChunk.Merge(m, src)
+}
+func (m *SnapshotChunk) XXX_Size() int {
+	return xxx_messageInfo_SnapshotChunk.Size(m)
+}
+func (m *SnapshotChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotChunk proto.InternalMessageInfo
+
+func (m *SnapshotChunk) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *SnapshotChunk) GetFirst() uint64 {
+	if m != nil {
+		return m.First
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetLast() uint64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+func (m *SnapshotChunk) GetBlocks() []byte {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StepRequest)(nil), "clusterpb.StepRequest")
+	proto.RegisterType((*SnapshotRequest)(nil), "clusterpb.SnapshotRequest")
+	proto.RegisterType((*SnapshotChunk)(nil), "clusterpb.SnapshotChunk")
+}
+
+func init() { proto.RegisterFile("cluster.proto", fileDescriptor_3cfb3b8ec240c376) }
+
+var fileDescriptor_3cfb3b8ec240c376 = []byte{
+	// 342 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0x3f, 0x6f, 0xf2, 0x30,
+	0x10, 0xc6, 0x5f, 0x20, 0x2f, 0x88, 0xa3, 0x01, 0x6a, 0xb5, 0x28, 0x65, 0xaa, 0x98, 0x3a, 0x25,
+	0x52, 0xbb, 0xf7, 0x0f, 0x0c, 0x65, 0x36, 0x5b, 0x97, 0xca, 0x71, 0x0c, 0x89, 0x92, 0xd8, 0xa9,
+	0xed, 0x08, 0xf1, 0xa9, 0xfa, 0x15, 0x2b, 0x8c, 0x31, 0x04, 0x55, 0xdd, 0xf2, 0x3c, 0xbe, 0xfc,
+	0x9e, 0xbb, 0xd3, 0x81, 0x4f, 0x8b, 0x5a, 0x69, 0x26, 0xc3, 0x4a, 0x0a, 0x2d, 0x50, 0xdf, 0xca,
+	0x2a, 0x9e, 0xde, 0x0a, 0x99, 0x30, 0xc9, 0x64, 0xd4, 0xa8, 0x98, 0x7d, 0xb7, 0x61, 0xb0, 0xd2,
+	0xac, 0xc2, 0xec, 0xab, 0x66, 0x4a, 0xa3, 0x25, 0x5c, 0x53, 0xc1, 0x15, 0xe3, 0xaa, 0x56, 0x9f,
+	0xf2, 0x60, 0x06, 0xad, 0xfb, 0xd6, 0xc3, 0xe0, 0xf1, 0x2e, 0xb4, 0x88, 0x70, 0x71, 0xac, 0xb0,
+	0x7f, 0x2d, 0xff, 0xe1, 0x31, 0xbd, 0xf0, 0xd0, 0x0b, 0x0c, 0x55, 0x1d, 0x97, 0x99, 0x76, 0x98,
+	0xb6, 0xc1, 0x4c, 0x1c, 0x66, 0x65, 0x9e, 0x4f, 0x0c, 0x5f, 0x9d, 0x1b, 0xe8, 0x1d, 0xc6, 0x8a,
+	0x93, 0x4a, 0xa5, 0xe2, 0x84, 0xe8, 0x18, 0xc4, 0x34, 0x74, 0x73, 0x85, 0x2b, 0x5b, 0x72, 0xc2,
+	0x8c, 0x54, 0xd3, 0x42, 0x6f, 0x30, 0x74, 0x20, 0x9a, 0xd6, 0x3c, 0x0f, 0x3c, 0x83, 0x09, 0x7e,
+	0xc1, 0x2c, 0xf6, 0xef, 0xa6, 0x97, 0x73, 0x63, 0xde, 0x87, 0x5e, 0x45, 0x76, 0x85, 0x20, 0xc9,
+	0x2c, 0x87, 0xd1, 0x45, 0x26, 0x0a, 0xa0, 0x47, 0x53, 0xc2, 0x39, 0x2b, 0xcc, 0xaa, 0xfa, 0xf8,
+	0x28, 0xd1, 0x0d, 0xfc, 0x57, 0x9a, 0xc8, 0xc3, 0xec, 0x1e, 0x3e, 0x08, 0x34, 0x86, 0x0e, 0xe3,
+	0x89, 0x19, 0xc6, 0xc3, 0xfb, 0x4f, 0x34, 0x81, 0xee, 0x36, 0xe3, 0x89, 0xd8, 0x9a, 0xd6, 0x7c,
+	0x6c, 0xd5, 0x2c, 0x07, 0xbf, 0xd1, 0xd9, 0xdf, 0x51, 0xeb, 0x4c, 0x2a, 0x17, 0x65, 0x04, 0x42,
+	0xe0, 0x15, 0xc4, 0x2e, 0xce, 0xc3, 0xe6, 0x7b, 0x1f, 0x16, 0x17, 0x82, 0xe6, 0xca, 0x84, 0x5d,
+	0x61, 0xab, 0xe6, 0xaf, 0x1f, 0xcf, 0x9b, 0x4c, 0xa7, 0x75, 0x1c, 0x52, 0x51, 0x46, 0xe9, 0xae,
+	0x62, 0xb2, 0x60, 0xc9, 0x86, 0xc9, 0x68, 0x4d, 0x62, 0x99, 0xd1, 0xc8, 0x9d, 0x90, 0x28, 0x4b,
+	0xc1, 0x8f, 0x97, 0x14, 0xb9, 0x2d, 0xc6, 0x5d, 0x73, 0x54, 0x4f, 0x3f, 0x03, 0x00, 0x78, 0xaf,
+	0x1c, 0x74, 0x87, 0x02, 0x00, 0x00,
+}
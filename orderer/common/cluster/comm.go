@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -50,6 +51,8 @@ type ChannelExtractor interface {
 type Handler interface {
 	OnConsensus(channel string, sender uint64, req *orderer.ConsensusRequest) error
 	OnSubmit(channel string, sender uint64, req *orderer.SubmitRequest) error
+	OnSnapshotRequest(channel string, sender uint64, req *clusterpb.SnapshotRequest) error
+	OnSnapshotChunk(channel string, sender uint64, chunk *clusterpb.SnapshotChunk) error
 }
 
 // RemoteNode represents a cluster member
@@ -131,6 +134,26 @@ func (c *Comm) DispatchConsensus(ctx context.Context, request *orderer.Consensus
 	return c.H.OnConsensus(reqCtx.channel, reqCtx.sender, request)
 }
 
+// DispatchSnapshotRequest identifies the channel and sender of the snapshot request and passes it
+// to the underlying Handler
+func (c *Comm) DispatchSnapshotRequest(ctx context.Context, request *clusterpb.SnapshotRequest) error {
+	reqCtx, err := c.requestContext(ctx, request)
+	if err != nil {
+		return err
+	}
+	return c.H.OnSnapshotRequest(reqCtx.channel, reqCtx.sender, request)
+}
+
+// DispatchSnapshotChunk identifies the channel and sender of the snapshot chunk and passes it
+// to the underlying Handler
+func (c *Comm) DispatchSnapshotChunk(ctx context.Context, chunk *clusterpb.SnapshotChunk) error {
+	reqCtx, err := c.requestContext(ctx, chunk)
+	if err != nil {
+		return err
+	}
+	return c.H.OnSnapshotChunk(reqCtx.channel, reqCtx.sender, chunk)
+}
+
 // requestContext identifies the sender and channel of the request and returns
 // it wrapped in a requestContext
 func (c *Comm) requestContext(ctx context.Context, msg proto.Message) (*requestContext, error) {
@@ -458,7 +481,7 @@ type RemoteContext struct {
 // Stream is used to send/receive messages to/from the remote cluster member.
 type Stream struct {
 	abortChan    <-chan struct{}
-	sendBuff     chan *orderer.StepRequest
+	sendBuff     chan *clusterpb.StepRequest
 	commShutdown chan struct{}
 	abortReason  *atomic.Value
 	metrics      *Metrics
@@ -483,7 +506,7 @@ func (stream *Stream) Canceled() bool {
 }
 
 // Send sends the given request to the remote cluster member.
-func (stream *Stream) Send(request *orderer.StepRequest) error {
+func (stream *Stream) Send(request *clusterpb.StepRequest) error {
 	if stream.Canceled() {
 		return errors.New(stream.abortReason.Load().(string))
 	}
@@ -499,7 +522,7 @@ func (stream *Stream) Send(request *orderer.StepRequest) error {
 
 // sendOrDrop sends the given request to the remote cluster member, or drops it
 // if it is a consensus request and the queue is full.
-func (stream *Stream) sendOrDrop(request *orderer.StepRequest, allowDrop bool) error {
+func (stream *Stream) sendOrDrop(request *clusterpb.StepRequest, allowDrop bool) error {
 	msgType := "transaction"
 	if allowDrop {
 		msgType = "consensus"
@@ -524,7 +547,7 @@ func (stream *Stream) sendOrDrop(request *orderer.StepRequest, allowDrop bool) e
 }
 
 // sendMessage sends the request down the stream
-func (stream *Stream) sendMessage(request *orderer.StepRequest) {
+func (stream *Stream) sendMessage(request *clusterpb.StepRequest) {
 	start := time.Now()
 	var err error
 	defer func() {
@@ -542,7 +565,7 @@ func (stream *Stream) sendMessage(request *orderer.StepRequest) {
 	f := func() (*orderer.StepResponse, error) {
 		startSend := time.Now()
 		stream.expCheck.checkExpiration(startSend, stream.Channel)
-		err := stream.Cluster_StepClient.Send(request)
+		err := stream.Cluster_StepClient.SendMsg(request)
 		stream.metrics.reportMsgSendTime(stream.Endpoint, stream.Channel, time.Since(startSend))
 		return nil, err
 	}
@@ -620,17 +643,23 @@ func (stream *Stream) operateWithTimeout(invoke StreamOperation) (*orderer.StepR
 	}
 }
 
-func requestAsString(request *orderer.StepRequest) string {
+func requestAsString(request *clusterpb.StepRequest) string {
 	switch t := request.GetPayload().(type) {
-	case *orderer.StepRequest_SubmitRequest:
+	case *clusterpb.StepRequest_SubmitRequest:
 		if t.SubmitRequest == nil || t.SubmitRequest.Payload == nil {
 			return fmt.Sprintf("Empty SubmitRequest: %v", t.SubmitRequest)
 		}
 		return fmt.Sprintf("SubmitRequest for channel %s with payload of size %d",
 			t.SubmitRequest.Channel, len(t.SubmitRequest.Payload.Payload))
-	case *orderer.StepRequest_ConsensusRequest:
+	case *clusterpb.StepRequest_ConsensusRequest:
 		return fmt.Sprintf("ConsensusRequest for channel %s with payload of size %d",
 			t.ConsensusRequest.Channel, len(t.ConsensusRequest.Payload))
+	case *clusterpb.StepRequest_SnapshotRequest:
+		return fmt.Sprintf("SnapshotRequest for channel %s of blocks [%d, %d]",
+			t.SnapshotRequest.Channel, t.SnapshotRequest.Start, t.SnapshotRequest.End)
+	case *clusterpb.StepRequest_SnapshotChunk:
+		return fmt.Sprintf("SnapshotChunk for channel %s of blocks [%d, %d] of size %d",
+			t.SnapshotChunk.Channel, t.SnapshotChunk.First, t.SnapshotChunk.Last, len(t.SnapshotChunk.Blocks))
 	default:
 		return fmt.Sprintf("unknown type: %v", request)
 	}
@@ -681,7 +710,7 @@ func (rc *RemoteContext) NewStream(timeout time.Duration) (*Stream, error) {
 		metrics:            rc.Metrics,
 		abortReason:        abortReason,
 		abortChan:          abortChan,
-		sendBuff:           make(chan *orderer.StepRequest, rc.SendBuffSize),
+		sendBuff:           make(chan *clusterpb.StepRequest, rc.SendBuffSize),
 		commShutdown:       rc.shutdownSignal,
 		NodeName:           nodeName,
 		Logger:             stepLogger,
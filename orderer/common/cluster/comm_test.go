@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	comm_utils "github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/common/cluster/mocks"
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -90,8 +91,8 @@ var (
 		Info: "bar",
 	}
 
-	testConsensusReq = &orderer.StepRequest{
-		Payload: &orderer.StepRequest_ConsensusRequest{
+	testConsensusReq = &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_ConsensusRequest{
 			ConsensusRequest: &orderer.ConsensusRequest{
 				Payload: []byte{1, 2, 3},
 				Channel: testChannel,
@@ -332,8 +333,8 @@ func TestSendBigMessage(t *testing.T) {
 	_, err := rand.Read(bigMsg.Payload)
 	require.NoError(t, err)
 
-	wrappedMsg := &orderer.StepRequest{
-		Payload: &orderer.StepRequest_ConsensusRequest{
+	wrappedMsg := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_ConsensusRequest{
 			ConsensusRequest: bigMsg,
 		},
 	}
@@ -386,7 +387,7 @@ func TestBlockingSend(t *testing.T) {
 
 	for _, testCase := range []struct {
 		description        string
-		messageToSend      *orderer.StepRequest
+		messageToSend      *clusterpb.StepRequest
 		streamUnblocks     bool
 		elapsedGreaterThan time.Duration
 		overflowErr        string
@@ -436,7 +437,7 @@ func TestBlockingSend(t *testing.T) {
 			var sendInvoked sync.WaitGroup
 			sendInvoked.Add(1)
 			var once sync.Once
-			fakeStream.On("Send", mock.Anything).Run(func(_ mock.Arguments) {
+			fakeStream.On("SendMsg", mock.Anything).Run(func(_ mock.Arguments) {
 				once.Do(sendInvoked.Done)
 				<-unBlock
 			}).Return(errors.New("oops"))
@@ -787,7 +788,7 @@ func TestNoTLSCertificate(t *testing.T) {
 	stream, err := echoClient.Step(context.Background())
 	require.NoError(t, err)
 
-	err = stream.Send(wrapSubmitReq(testSubReq))
+	err = stream.SendMsg(wrapSubmitReq(testSubReq))
 	require.NoError(t, err)
 	_, err = stream.Recv()
 	require.EqualError(t, err, "rpc error: code = Unknown desc = no TLS certificate sent")
@@ -1312,7 +1313,7 @@ func TestCertExpirationWarningEgress(t *testing.T) {
 	require.NoError(t, err)
 
 	mockgRPC := &mocks.StepClient{}
-	mockgRPC.On("Send", mock.Anything).Return(nil)
+	mockgRPC.On("SendMsg", mock.Anything).Return(nil)
 	mockgRPC.On("Context").Return(context.Background())
 	mockClient := &mocks.ClusterClient{}
 	mockClient.On("Step", mock.Anything).Return(mockgRPC, nil)
@@ -1403,8 +1404,8 @@ func assertEventualEstablishStream(t *testing.T, rpc *cluster.RemoteContext) *cl
 	return res
 }
 
-func assertEventualSendMessage(t *testing.T, rpc *cluster.RemoteContext, req *orderer.SubmitRequest) orderer.Cluster_StepClient {
-	var res orderer.Cluster_StepClient
+func assertEventualSendMessage(t *testing.T, rpc *cluster.RemoteContext, req *orderer.SubmitRequest) *cluster.Stream {
+	var res *cluster.Stream
 	gt := gomega.NewGomegaWithT(t)
 	gt.Eventually(func() error {
 		stream, err := rpc.NewStream(time.Hour)
@@ -1417,9 +1418,9 @@ func assertEventualSendMessage(t *testing.T, rpc *cluster.RemoteContext, req *or
 	return res
 }
 
-func wrapSubmitReq(req *orderer.SubmitRequest) *orderer.StepRequest {
-	return &orderer.StepRequest{
-		Payload: &orderer.StepRequest_SubmitRequest{
+func wrapSubmitReq(req *orderer.SubmitRequest) *clusterpb.StepRequest {
+	return &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SubmitRequest{
 			SubmitRequest: req,
 		},
 	}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -28,6 +29,34 @@ func (_m *Dispatcher) DispatchConsensus(ctx context.Context, request *orderer.Co
 	return r0
 }
 
+// DispatchSnapshotChunk provides a mock function with given fields: ctx, chunk
+func (_m *Dispatcher) DispatchSnapshotChunk(ctx context.Context, chunk *clusterpb.SnapshotChunk) error {
+	ret := _m.Called(ctx, chunk)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clusterpb.SnapshotChunk) error); ok {
+		r0 = rf(ctx, chunk)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DispatchSnapshotRequest provides a mock function with given fields: ctx, request
+func (_m *Dispatcher) DispatchSnapshotRequest(ctx context.Context, request *clusterpb.SnapshotRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clusterpb.SnapshotRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DispatchSubmit provides a mock function with given fields: ctx, request
 func (_m *Dispatcher) DispatchSubmit(ctx context.Context, request *orderer.SubmitRequest) error {
 	ret := _m.Called(ctx, request)
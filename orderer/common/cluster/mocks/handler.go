@@ -4,6 +4,7 @@ package mocks
 
 import (
 	orderer "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -26,6 +27,34 @@ func (_m *Handler) OnConsensus(channel string, sender uint64, req *orderer.Conse
 	return r0
 }
 
+// OnSnapshotChunk provides a mock function with given fields: channel, sender, chunk
+func (_m *Handler) OnSnapshotChunk(channel string, sender uint64, chunk *clusterpb.SnapshotChunk) error {
+	ret := _m.Called(channel, sender, chunk)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint64, *clusterpb.SnapshotChunk) error); ok {
+		r0 = rf(channel, sender, chunk)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnSnapshotRequest provides a mock function with given fields: channel, sender, req
+func (_m *Handler) OnSnapshotRequest(channel string, sender uint64, req *clusterpb.SnapshotRequest) error {
+	ret := _m.Called(channel, sender, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, uint64, *clusterpb.SnapshotRequest) error); ok {
+		r0 = rf(channel, sender, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // OnSubmit provides a mock function with given fields: channel, sender, req
 func (_m *Handler) OnSubmit(channel string, sender uint64, req *orderer.SubmitRequest) error {
 	ret := _m.Called(channel, sender, req)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
@@ -38,6 +39,7 @@ type ClusterClient interface {
 type RPC struct {
 	consensusLock sync.Mutex
 	submitLock    sync.Mutex
+	snapshotLock  sync.Mutex
 	Logger        *flogging.FabricLogger
 	Timeout       time.Duration
 	Channel       string
@@ -52,6 +54,7 @@ func NewStreamsByType() map[OperationType]map[uint64]*Stream {
 	m := make(map[OperationType]map[uint64]*Stream)
 	m[ConsensusOperation] = make(map[uint64]*Stream)
 	m[SubmitOperation] = make(map[uint64]*Stream)
+	m[SnapshotOperation] = make(map[uint64]*Stream)
 	return m
 }
 
@@ -62,6 +65,7 @@ type OperationType int
 const (
 	ConsensusOperation OperationType = iota
 	SubmitOperation
+	SnapshotOperation
 )
 
 // SendConsensus passes the given ConsensusRequest message to the raft.Node instance.
@@ -75,8 +79,8 @@ func (s *RPC) SendConsensus(destination uint64, msg *orderer.ConsensusRequest) e
 		return err
 	}
 
-	req := &orderer.StepRequest{
-		Payload: &orderer.StepRequest_ConsensusRequest{
+	req := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_ConsensusRequest{
 			ConsensusRequest: msg,
 		},
 	}
@@ -103,8 +107,8 @@ func (s *RPC) SendSubmit(destination uint64, request *orderer.SubmitRequest) err
 		return err
 	}
 
-	req := &orderer.StepRequest{
-		Payload: &orderer.StepRequest_SubmitRequest{
+	req := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SubmitRequest{
 			SubmitRequest: request,
 		},
 	}
@@ -119,6 +123,50 @@ func (s *RPC) SendSubmit(destination uint64, request *orderer.SubmitRequest) err
 	return err
 }
 
+// SendSnapshotRequest sends a SnapshotRequest to the given destination node.
+func (s *RPC) SendSnapshotRequest(destination uint64, request *clusterpb.SnapshotRequest) error {
+	return s.sendSnapshot(destination, &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotRequest{
+			SnapshotRequest: request,
+		},
+	})
+}
+
+// SendSnapshotChunk sends a SnapshotChunk to the given destination node.
+// The snapshot messages have their own stream, so that the transfer of
+// blocks does not hold up the consensus and submit requests.
+func (s *RPC) SendSnapshotChunk(destination uint64, chunk *clusterpb.SnapshotChunk) error {
+	return s.sendSnapshot(destination, &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotChunk{
+			SnapshotChunk: chunk,
+		},
+	})
+}
+
+func (s *RPC) sendSnapshot(destination uint64, req *clusterpb.StepRequest) error {
+	if s.Logger.IsEnabledFor(zapcore.DebugLevel) {
+		defer s.snapshotSent(time.Now(), destination, req)
+	}
+
+	stream, err := s.getOrCreateStream(destination, SnapshotOperation)
+	if err != nil {
+		return err
+	}
+
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
+
+	err = stream.Send(req)
+	if err != nil {
+		s.unMapStream(destination, SnapshotOperation)
+	}
+	return err
+}
+
+func (s *RPC) snapshotSent(start time.Time, to uint64, req *clusterpb.StepRequest) {
+	s.Logger.Debugf("Sending %s to %d took %v", requestAsString(req), to, time.Since(start))
+}
+
 func (s *RPC) submitSent(start time.Time, to uint64, msg *orderer.SubmitRequest) {
 	s.Logger.Debugf("Sending msg of %d bytes to %d on channel %s took %v", submitMsgLength(msg), to, s.Channel, time.Since(start))
 }
@@ -128,7 +176,7 @@ func (s *RPC) consensusSent(start time.Time, to uint64, msg *orderer.ConsensusRe
 }
 
 // getOrCreateStream obtains a Submit stream for the given destination node
-func (s *RPC) getOrCreateStream(destination uint64, operationType OperationType) (orderer.Cluster_StepClient, error) {
+func (s *RPC) getOrCreateStream(destination uint64, operationType OperationType) (*Stream, error) {
 	stream := s.getStream(destination, operationType)
 	if stream != nil {
 		return stream, nil
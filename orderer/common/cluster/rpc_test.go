@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/common/cluster/mocks"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -77,8 +78,8 @@ func TestRPCChangeDestination(t *testing.T) {
 	signalSent := func(_ mock.Arguments) {
 		sent.Done()
 	}
-	streamToNode1.On("Send", mock.Anything).Return(nil).Run(signalSent).Once()
-	streamToNode2.On("Send", mock.Anything).Return(nil).Run(signalSent).Once()
+	streamToNode1.On("SendMsg", mock.Anything).Return(nil).Run(signalSent).Once()
+	streamToNode2.On("SendMsg", mock.Anything).Return(nil).Run(signalSent).Once()
 	streamToNode1.On("Recv").Return(nil, io.EOF)
 	streamToNode2.On("Recv").Return(nil, io.EOF)
 
@@ -86,8 +87,8 @@ func TestRPCChangeDestination(t *testing.T) {
 	rpc.SendSubmit(2, &orderer.SubmitRequest{Channel: "mychannel"})
 
 	sent.Wait()
-	streamToNode1.AssertNumberOfCalls(t, "Send", 1)
-	streamToNode2.AssertNumberOfCalls(t, "Send", 1)
+	streamToNode1.AssertNumberOfCalls(t, "SendMsg", 1)
+	streamToNode2.AssertNumberOfCalls(t, "SendMsg", 1)
 }
 
 func TestSend(t *testing.T) {
@@ -104,17 +105,51 @@ func TestSend(t *testing.T) {
 
 	submitReq := wrapSubmitReq(submitRequest)
 
-	consensusReq := &orderer.StepRequest{
-		Payload: &orderer.StepRequest_ConsensusRequest{
+	consensusReq := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_ConsensusRequest{
 			ConsensusRequest: consensusRequest,
 		},
 	}
 
+	snapshotRequest := &clusterpb.SnapshotRequest{
+		Channel: "mychannel",
+		Start:   1,
+		End:     10,
+		Window:  2,
+	}
+
+	snapshotReq := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotRequest{
+			SnapshotRequest: snapshotRequest,
+		},
+	}
+
+	snapshotChunk := &clusterpb.SnapshotChunk{
+		Channel: "mychannel",
+		First:   1,
+		Last:    5,
+		Blocks:  []byte{1, 2, 3},
+	}
+
+	snapshotChunkReq := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotChunk{
+			SnapshotChunk: snapshotChunk,
+		},
+	}
+
 	submit := func(rpc *cluster.RPC) error {
 		err := rpc.SendSubmit(1, submitRequest)
 		return err
 	}
 
+	requestSnapshot := func(rpc *cluster.RPC) error {
+		return rpc.SendSnapshotRequest(1, snapshotRequest)
+	}
+
+	sendChunk := func(rpc *cluster.RPC) error {
+		return rpc.SendSnapshotChunk(1, snapshotChunk)
+	}
+
 	step := func(rpc *cluster.RPC) error {
 		return rpc.SendConsensus(1, consensusRequest)
 	}
@@ -123,7 +158,7 @@ func TestSend(t *testing.T) {
 		name           string
 		method         func(rpc *cluster.RPC) error
 		sendReturns    error
-		sendCalledWith *orderer.StepRequest
+		sendCalledWith *clusterpb.StepRequest
 		receiveReturns []interface{}
 		stepReturns    []interface{}
 		remoteError    error
@@ -139,7 +174,7 @@ func TestSend(t *testing.T) {
 
 	stream := &mocks.StepClient{}
 	stream.On("Context", mock.Anything).Return(context.Background())
-	stream.On("Send", mock.Anything).Return(func(*orderer.StepRequest) error {
+	stream.On("SendMsg", mock.Anything).Return(func(interface{}) error {
 		l.Lock()
 		defer l.Unlock()
 		atomic.AddUint32(&sendCalls, 1)
@@ -179,6 +214,28 @@ func TestSend(t *testing.T) {
 			sendCalledWith: consensusReq,
 			expectedErr:    "stream is aborted",
 		},
+		{
+			name:           "Send snapshot request succeed",
+			method:         requestSnapshot,
+			sendReturns:    nil,
+			stepReturns:    []interface{}{stream, nil},
+			sendCalledWith: snapshotReq,
+		},
+		{
+			name:           "Send snapshot chunk succeed",
+			method:         sendChunk,
+			sendReturns:    nil,
+			stepReturns:    []interface{}{stream, nil},
+			sendCalledWith: snapshotChunkReq,
+		},
+		{
+			name:           "Send snapshot chunk fails",
+			method:         sendChunk,
+			sendReturns:    errors.New("oops"),
+			stepReturns:    []interface{}{stream, nil},
+			sendCalledWith: snapshotChunkReq,
+			expectedErr:    "stream is aborted",
+		},
 		{
 			name:        "Remote() fails",
 			method:      submit,
@@ -233,7 +290,7 @@ func TestSend(t *testing.T) {
 			}
 
 			if testCase.remoteError == nil && testCase.expectedErr == "" && isSend {
-				stream.AssertCalled(t, "Send", testCase.sendCalledWith)
+				stream.AssertCalled(t, "SendMsg", testCase.sendCalledWith)
 				// Ensure that if we succeeded - only 1 stream was created despite 2 calls
 				// to Send() were made
 				err := testCase.method(rpc)
@@ -273,7 +330,7 @@ func TestRPCGarbageCollection(t *testing.T) {
 		comm.On("Remote", "mychannel", destination).Return(remote, nil)
 		stream.On("Context", mock.Anything).Return(context.Background())
 		client.On("Step", mock.Anything).Return(stream, nil).Once()
-		stream.On("Send", mock.Anything).Return(nil).Once().Run(func(_ mock.Arguments) {
+		stream.On("SendMsg", mock.Anything).Return(nil).Once().Run(func(_ mock.Arguments) {
 			sent.Done()
 		})
 		stream.On("Recv").Return(nil, nil)
@@ -298,7 +355,7 @@ func TestRPCGarbageCollection(t *testing.T) {
 	require.Len(t, mapping[cluster.SubmitOperation], 1)
 	require.Equal(t, uint64(1), mapping[cluster.SubmitOperation][1].ID)
 	// And the underlying gRPC stream indeed had Send invoked on it.
-	stream.AssertNumberOfCalls(t, "Send", 1)
+	stream.AssertNumberOfCalls(t, "SendMsg", 1)
 
 	// Abort all streams we currently have that are associated to the remote.
 	remote.Abort()
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -25,6 +26,8 @@ import (
 type Dispatcher interface {
 	DispatchSubmit(ctx context.Context, request *orderer.SubmitRequest) error
 	DispatchConsensus(ctx context.Context, request *orderer.ConsensusRequest) error
+	DispatchSnapshotRequest(ctx context.Context, request *clusterpb.SnapshotRequest) error
+	DispatchSnapshotChunk(ctx context.Context, chunk *clusterpb.SnapshotChunk) error
 }
 
 //go:generate mockery -dir . -name StepStream -case underscore -output ./mocks/
@@ -71,7 +74,10 @@ func (s *Service) Step(stream orderer.Cluster_StepServer) error {
 }
 
 func (s *Service) handleMessage(stream StepStream, addr string, exp *certificateExpirationCheck) error {
-	request, err := stream.Recv()
+	// The request is received as a clusterpb.StepRequest, which also carries
+	// the messages of the snapshot transfer
+	request := &clusterpb.StepRequest{}
+	err := stream.RecvMsg(request)
 	if err == io.EOF {
 		return err
 	}
@@ -93,6 +99,14 @@ func (s *Service) handleMessage(stream StepStream, addr string, exp *certificate
 		return s.handleSubmit(submitReq, stream, addr)
 	}
 
+	if snapshotReq := request.GetSnapshotRequest(); snapshotReq != nil {
+		return s.Dispatcher.DispatchSnapshotRequest(stream.Context(), snapshotReq)
+	}
+
+	if snapshotChunk := request.GetSnapshotChunk(); snapshotChunk != nil {
+		return s.Dispatcher.DispatchSnapshotChunk(stream.Context(), snapshotChunk)
+	}
+
 	// Else, it's a consensus message.
 	return s.Dispatcher.DispatchConsensus(stream.Context(), request.GetConsensusRequest())
 }
@@ -127,7 +141,7 @@ func expiresAt(stream orderer.Cluster_StepServer) time.Time {
 	return cert.NotAfter
 }
 
-func extractChannel(msg *orderer.StepRequest) string {
+func extractChannel(msg *clusterpb.StepRequest) string {
 	if consReq := msg.GetConsensusRequest(); consReq != nil {
 		return consReq.Channel
 	}
@@ -136,5 +150,13 @@ func extractChannel(msg *orderer.StepRequest) string {
 		return submitReq.Channel
 	}
 
+	if snapshotReq := msg.GetSnapshotRequest(); snapshotReq != nil {
+		return snapshotReq.Channel
+	}
+
+	if snapshotChunk := msg.GetSnapshotChunk(); snapshotChunk != nil {
+		return snapshotChunk.Channel
+	}
+
 	return ""
 }
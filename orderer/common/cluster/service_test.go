@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/common/cluster/mocks"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -28,13 +29,13 @@ import (
 )
 
 var (
-	submitRequest1 = &orderer.StepRequest{
-		Payload: &orderer.StepRequest_SubmitRequest{
+	submitRequest1 = &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SubmitRequest{
 			SubmitRequest: &orderer.SubmitRequest{},
 		},
 	}
-	submitRequest2 = &orderer.StepRequest{
-		Payload: &orderer.StepRequest_SubmitRequest{
+	submitRequest2 = &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SubmitRequest{
 			SubmitRequest: &orderer.SubmitRequest{},
 		},
 	}
@@ -48,8 +49,8 @@ var (
 			SubmitRes: &orderer.SubmitResponse{},
 		},
 	}
-	consensusRequest = &orderer.StepRequest{
-		Payload: &orderer.StepRequest_ConsensusRequest{
+	consensusRequest = &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_ConsensusRequest{
 			ConsensusRequest: &orderer.ConsensusRequest{
 				Payload: []byte{1, 2, 3},
 				Channel: "mychannel",
@@ -73,8 +74,8 @@ func TestStep(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		stream := &mocks.StepStream{}
 		stream.On("Context").Return(context.Background())
-		stream.On("Recv").Return(consensusRequest, nil).Once()
-		stream.On("Recv").Return(consensusRequest, nil).Once()
+		recvMsgReturns(stream, consensusRequest, nil)
+		recvMsgReturns(stream, consensusRequest, nil)
 		dispatcher.On("DispatchConsensus", mock.Anything, consensusRequest.GetConsensusRequest()).Return(nil).Once()
 		dispatcher.On("DispatchConsensus", mock.Anything, consensusRequest.GetConsensusRequest()).Return(io.EOF).Once()
 		err := svc.Step(stream)
@@ -84,26 +85,83 @@ func TestStep(t *testing.T) {
 	t.Run("Failure", func(t *testing.T) {
 		stream := &mocks.StepStream{}
 		stream.On("Context").Return(context.Background())
-		stream.On("Recv").Return(consensusRequest, nil).Once()
+		recvMsgReturns(stream, consensusRequest, nil)
 		dispatcher.On("DispatchConsensus", mock.Anything, consensusRequest.GetConsensusRequest()).Return(errors.New("oops")).Once()
 		err := svc.Step(stream)
 		require.EqualError(t, err, "oops")
 	})
 }
 
+func TestStepSnapshot(t *testing.T) {
+	dispatcher := &mocks.Dispatcher{}
+
+	svc := &cluster.Service{
+		StreamCountReporter: &cluster.StreamCountReporter{
+			Metrics: cluster.NewMetrics(&disabled.Provider{}),
+		},
+		Logger:     flogging.MustGetLogger("test"),
+		StepLogger: flogging.MustGetLogger("test"),
+		Dispatcher: dispatcher,
+	}
+
+	snapshotRequest := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotRequest{
+			SnapshotRequest: &clusterpb.SnapshotRequest{
+				Channel: "mychannel",
+				Start:   1,
+				End:     10,
+			},
+		},
+	}
+
+	snapshotChunk := &clusterpb.StepRequest{
+		Payload: &clusterpb.StepRequest_SnapshotChunk{
+			SnapshotChunk: &clusterpb.SnapshotChunk{
+				Channel: "mychannel",
+				First:   1,
+				Last:    10,
+				Blocks:  []byte{1, 2, 3},
+			},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		stream := &mocks.StepStream{}
+		stream.On("Context").Return(context.Background())
+		recvMsgReturns(stream, snapshotRequest, nil)
+		recvMsgReturns(stream, snapshotChunk, nil)
+		recvMsgReturns(stream, nil, io.EOF)
+		dispatcher.On("DispatchSnapshotRequest", mock.Anything, snapshotRequest.GetSnapshotRequest()).Return(nil).Once()
+		dispatcher.On("DispatchSnapshotChunk", mock.Anything, snapshotChunk.GetSnapshotChunk()).Return(nil).Once()
+		err := svc.Step(stream)
+		require.NoError(t, err)
+		dispatcher.AssertNumberOfCalls(t, "DispatchSnapshotRequest", 1)
+		dispatcher.AssertNumberOfCalls(t, "DispatchSnapshotChunk", 1)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		stream := &mocks.StepStream{}
+		stream.On("Context").Return(context.Background())
+		recvMsgReturns(stream, snapshotChunk, nil)
+		dispatcher.On("DispatchSnapshotChunk", mock.Anything, snapshotChunk.GetSnapshotChunk()).Return(errors.New("oops")).Once()
+		err := svc.Step(stream)
+		require.EqualError(t, err, "oops")
+	})
+}
+
 func TestSubmitSuccess(t *testing.T) {
 	dispatcher := &mocks.Dispatcher{}
 
 	stream := &mocks.StepStream{}
 	stream.On("Context").Return(context.Background())
 	// Send to the stream 2 messages, and afterwards close the stream
-	stream.On("Recv").Return(submitRequest1, nil).Once()
-	stream.On("Recv").Return(submitRequest2, nil).Once()
-	stream.On("Recv").Return(nil, io.EOF).Once()
+	recvMsgReturns(stream, submitRequest1, nil)
+	recvMsgReturns(stream, submitRequest2, nil)
+	recvMsgReturns(stream, nil, io.EOF)
 	// Send should be called for each corresponding receive
 	stream.On("Send", submitResponse1).Return(nil).Twice()
 
-	responses := make(chan *orderer.StepRequest, 2)
+	responses := make(chan *clusterpb.StepRequest, 2)
 	responses <- submitRequest1
 	responses <- submitRequest2
 
@@ -112,7 +170,7 @@ func TestSubmitSuccess(t *testing.T) {
 	// Ensure we pass requests to DispatchSubmit in-order
 	dispatcher.On("DispatchSubmit", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		expectedRequest := <-responses
-		actualRequest := args.Get(1).(*orderer.StepRequest)
+		actualRequest := args.Get(1).(*clusterpb.StepRequest)
 		require.True(t, expectedRequest == actualRequest)
 	})
 
@@ -131,12 +189,18 @@ func TestSubmitSuccess(t *testing.T) {
 }
 
 type tuple struct {
-	msg interface{}
+	msg *clusterpb.StepRequest
 	err error
 }
 
-func (t tuple) asArray() []interface{} {
-	return []interface{}{t.msg, t.err}
+// recvMsgReturns makes the next RecvMsg of the stream receive the given request
+// and return the given error
+func recvMsgReturns(stream *mocks.StepStream, request *clusterpb.StepRequest, err error) {
+	stream.On("RecvMsg", mock.Anything).Run(func(args mock.Arguments) {
+		if request != nil {
+			*args.Get(0).(*clusterpb.StepRequest) = *request
+		}
+	}).Return(err).Once()
 }
 
 func TestSubmitFailure(t *testing.T) {
@@ -171,7 +235,7 @@ func TestSubmitFailure(t *testing.T) {
 			stream := &mocks.StepStream{}
 			stream.On("Context").Return(context.Background())
 			for _, recv := range testCase.receiveReturns {
-				stream.On("Recv").Return(recv.asArray()...).Once()
+				recvMsgReturns(stream, recv.msg, recv.err)
 			}
 			for _, send := range testCase.sendReturns {
 				stream.On("Send", mock.Anything).Return(send).Once()
@@ -216,9 +280,9 @@ func TestIngresStreamsMetrics(t *testing.T) {
 	stream := &mocks.StepStream{}
 	stream.On("Context").Return(context.Background())
 	// Upon first receive, return nil to proceed to the next receive.
-	stream.On("Recv").Return(nil, nil).Once()
+	recvMsgReturns(stream, nil, nil)
 	// Upon the second receive, return EOF to trigger the stream to end
-	stream.On("Recv").Return(nil, io.EOF).Once()
+	recvMsgReturns(stream, nil, io.EOF)
 
 	svc.Step(stream)
 	// The stream started so stream count incremented from 0 to 1
@@ -306,7 +370,7 @@ func TestExpirationWarningIngress(t *testing.T) {
 	stream, err := cl.Step(context.Background())
 	require.NoError(t, err)
 
-	err = stream.Send(consensusRequest)
+	err = stream.SendMsg(consensusRequest)
 	require.NoError(t, err)
 
 	// An alert is logged at the first time.
@@ -316,7 +380,7 @@ func TestExpirationWarningIngress(t *testing.T) {
 		t.Fatal("Should have received an alert")
 	}
 
-	err = stream.Send(consensusRequest)
+	err = stream.SendMsg(consensusRequest)
 	require.NoError(t, err)
 
 	// No alerts in a consecutive time.
@@ -329,7 +393,7 @@ func TestExpirationWarningIngress(t *testing.T) {
 	// Wait for alert expiration interval to expire.
 	time.Sleep(svc.MinimumExpirationWarningInterval + time.Second)
 
-	err = stream.Send(consensusRequest)
+	err = stream.SendMsg(consensusRequest)
 	require.NoError(t, err)
 
 	// An alert should be logged now after the timeout expired.
@@ -48,6 +48,8 @@ type General struct {
 	LocalMSPID        string
 	BCCSP             *bccsp.FactoryOpts
 	Authentication    Authentication
+	TxIDDedup         TxIDDedup
+	BroadcastLimits   BroadcastLimits
 }
 
 type Cluster struct {
@@ -101,6 +103,31 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// TxIDDedup contains configuration parameters related to rejecting the
+// transactions whose transaction IDs were already ordered.
+type TxIDDedup struct {
+	Enabled        bool
+	WindowBlocks   uint64
+	PendingTimeout time.Duration
+}
+
+// BroadcastLimits contains the rate limits and quotas applied to the transactions
+// broadcast to the channels whose channel config does not set its own limits.
+type BroadcastLimits struct {
+	Enabled      bool
+	Identity     RateLimit
+	Organization RateLimit
+}
+
+// RateLimit contains the parameters of a token bucket. A rate of zero means no limit,
+// a burst of zero means the amount added to the bucket in one second.
+type RateLimit struct {
+	MessagesPerSecond float64
+	MessageBurst      uint32
+	BytesPerSecond    float64
+	ByteBurst         uint64
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -232,6 +259,11 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		TxIDDedup: TxIDDedup{
+			Enabled:        false,
+			WindowBlocks:   1000,
+			PendingTimeout: 30 * time.Second,
+		},
 	},
 	FileLedger: FileLedger{
 		Location: "/var/hyperledger/production/orderer",
@@ -430,6 +462,12 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		case c.General.Authentication.TimeWindow == 0:
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
+		case c.General.TxIDDedup.Enabled && c.General.TxIDDedup.WindowBlocks == 0:
+			logger.Infof("General.TxIDDedup.WindowBlocks unset, setting to %d", Defaults.General.TxIDDedup.WindowBlocks)
+			c.General.TxIDDedup.WindowBlocks = Defaults.General.TxIDDedup.WindowBlocks
+		case c.General.TxIDDedup.Enabled && c.General.TxIDDedup.PendingTimeout == 0:
+			logger.Infof("General.TxIDDedup.PendingTimeout unset, setting to %s", Defaults.General.TxIDDedup.PendingTimeout)
+			c.General.TxIDDedup.PendingTimeout = Defaults.General.TxIDDedup.PendingTimeout
 
 		case c.Kafka.Retry.ShortInterval == 0:
 			logger.Infof("Kafka.Retry.ShortInterval unset, setting to %v", Defaults.Kafka.Retry.ShortInterval)
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateTxID is returned by the transaction ID index when a transaction is rejected.
+var ErrDuplicateTxID = errors.New("duplicate transaction ID")
+
+// Accept checks that the transaction ID of the endorser transaction was neither ordered in one of
+// the blocks in the window of the index nor accepted in the last PendingTimeout, and records it as
+// accepted until it is ordered or the PendingTimeout expires. The check depends on what this node has ordered and accepted, hence it must only be
+// applied to the messages broadcast to this node and never when validating the blocks proposed
+// by the other nodes. Malformed envelopes and the other types of messages are left to the filters
+func (i *TxIDIndex) Accept(env *cb.Envelope) error {
+	txID, ok := endorserTxID(env)
+	if !ok {
+		return nil
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if blockNum, ok := i.txIDs[txID]; ok {
+		i.rejected.Add(1)
+		return errors.WithMessagef(ErrDuplicateTxID, "transaction ID [%s] was already ordered in block [%d]", txID, blockNum)
+	}
+	now := i.now()
+	if acceptedAt, ok := i.pending[txID]; ok && !i.pendingExpired(acceptedAt, now) {
+		i.rejected.Add(1)
+		return errors.WithMessagef(ErrDuplicateTxID, "transaction ID [%s] was already accepted for ordering", txID)
+	}
+	i.pending[txID] = now
+	return nil
+}
+
+// Release forgets that the transaction was accepted, e.g., because the consenter did not accept it
+func (i *TxIDIndex) Release(env *cb.Envelope) {
+	txID, ok := endorserTxID(env)
+	if !ok {
+		return
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.pending, txID)
+}
+
+// endorserTxID returns the transaction ID of the envelope if it is an endorser transaction
+func endorserTxID(env *cb.Envelope) (string, bool) {
+	chdr, err := channelHeader(env)
+	if err != nil || chdr.Type != int32(cb.HeaderType_ENDORSER_TRANSACTION) || chdr.TxId == "" {
+		return "", false
+	}
+	return chdr.TxId, true
+}
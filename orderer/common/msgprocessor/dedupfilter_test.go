@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blockledger/fileledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxIDIndexAccept(t *testing.T) {
+	p, dir := newTestTxIDIndexProvider(t, localconfig.TxIDDedup{WindowBlocks: 2, PendingTimeout: time.Minute})
+	defer os.RemoveAll(dir)
+	defer p.Close()
+
+	ledgerFactory, err := fileledger.New(dir, &disabled.Provider{})
+	require.NoError(t, err)
+	defer ledgerFactory.Close()
+	ledger, err := ledgerFactory.GetOrCreate("testchannel")
+	require.NoError(t, err)
+
+	index, err := p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	now := time.Now()
+	index.now = func() time.Time { return now }
+	fakeCounter := &metricsfakes.Counter{}
+	index.rejected = fakeCounter
+	require.NoError(t, index.Record(makeTxBlock(0, "tx1")))
+
+	err = index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx1"))
+	require.EqualError(t, err, "transaction ID [tx1] was already ordered in block [0]: duplicate transaction ID")
+	require.Equal(t, 1, fakeCounter.AddCallCount())
+
+	// the transactions that are accepted but not yet ordered are rejected as well
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2")))
+	err = index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2"))
+	require.EqualError(t, err, "transaction ID [tx2] was already accepted for ordering: duplicate transaction ID")
+	require.Equal(t, 2, fakeCounter.AddCallCount())
+
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_CONFIG_UPDATE, "tx1")))
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "")))
+	require.Equal(t, 2, fakeCounter.AddCallCount())
+
+	// a released transaction can be accepted again
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3")))
+	index.Release(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3"))
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3")))
+
+	// the accepted transactions are ordered
+	require.NoError(t, index.Record(makeTxBlock(1, "tx2")))
+	require.NotContains(t, index.pending, "tx2")
+	err = index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx2"))
+	require.EqualError(t, err, "transaction ID [tx2] was already ordered in block [1]: duplicate transaction ID")
+
+	// the accepted transactions that are not ordered within the pending timeout are forgotten,
+	// as the consenter dropped them
+	now = now.Add(59 * time.Second)
+	require.NoError(t, index.Record(makeTxBlock(2)))
+	require.Contains(t, index.pending, "tx3")
+	err = index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3"))
+	require.EqualError(t, err, "transaction ID [tx3] was already accepted for ordering: duplicate transaction ID")
+	now = now.Add(time.Second)
+	require.NoError(t, index.Record(makeTxBlock(3)))
+	require.NotContains(t, index.pending, "tx3")
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3")))
+
+	// an expired transaction can be accepted again even before the next block is recorded
+	now = now.Add(time.Minute)
+	require.Contains(t, index.pending, "tx3")
+	require.NoError(t, index.Accept(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "tx3")))
+	require.Equal(t, 4, fakeCounter.AddCallCount())
+}
@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	duplicateTxIDsRejected = metrics.CounterOpts{
+		Namespace:    "msgprocessor",
+		Name:         "duplicate_txids_rejected",
+		Help:         "The number of transactions rejected because their transaction IDs were already ordered.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	txIDIndexSize = metrics.GaugeOpts{
+		Namespace:    "msgprocessor",
+		Name:         "txid_index_size",
+		Help:         "The number of transaction IDs in the index used for rejecting duplicate transactions.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// DedupMetrics are the metrics of the rejection of the transactions with duplicate transaction IDs
+type DedupMetrics struct {
+	DuplicateTxIDsRejected metrics.Counter
+	TxIDIndexSize          metrics.Gauge
+}
+
+func NewDedupMetrics(p metrics.Provider) *DedupMetrics {
+	return &DedupMetrics{
+		DuplicateTxIDsRejected: p.NewCounter(duplicateTxIDsRejected),
+		TxIDIndexSize:          p.NewGauge(txIDIndexSize),
+	}
+}
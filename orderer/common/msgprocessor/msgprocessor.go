@@ -52,6 +52,28 @@ const (
 	ConfigMsg
 )
 
+// Lane represents the ordering lanes of the consenters which support them.
+type Lane int
+
+const (
+	// NormalLane is the lane of the normal messages. The consenters may share it
+	// between the organizations submitting the messages.
+	NormalLane Lane = iota
+
+	// PriorityLane is the lane of the config messages, which include the channel
+	// maintenance transactions such as consensus-type migrations. Messages in this
+	// lane bypass the normal messages queued for ordering.
+	PriorityLane
+)
+
+// Lane returns the lane in which the messages of the classification are ordered.
+func (c Classification) Lane() Lane {
+	if c == NormalMsg {
+		return NormalLane
+	}
+	return PriorityLane
+}
+
 // Processor provides the methods necessary to classify and process any message which
 // arrives through the Broadcast interface.
 type Processor interface {
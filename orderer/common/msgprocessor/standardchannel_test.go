@@ -72,6 +72,12 @@ func TestClassifyMsg(t *testing.T) {
 	})
 }
 
+func TestClassificationLane(t *testing.T) {
+	require.Equal(t, NormalLane, NormalMsg.Lane())
+	require.Equal(t, PriorityLane, ConfigUpdateMsg.Lane())
+	require.Equal(t, PriorityLane, ConfigMsg.Lane())
+}
+
 func TestProcessNormalMsg(t *testing.T) {
 	t.Run("Normal", func(t *testing.T) {
 		ms := &mockSystemChannelFilterSupport{
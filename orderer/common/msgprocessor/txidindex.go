@@ -0,0 +1,315 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// blockKeyPrefix is the prefix of the keys in the on-disk index, one per block, whose values
+// contain the transaction IDs in the block
+var blockKeyPrefix = []byte{'b'}
+
+// TxIDIndexProvider provides the indexes of the transaction IDs of the recently ordered
+// transactions of the channels. The indexes are persisted in a single leveldb
+type TxIDIndexProvider struct {
+	dbProvider     *leveldbhelper.Provider
+	windowBlocks   uint64
+	pendingTimeout time.Duration
+	metrics        *DedupMetrics
+}
+
+// NewTxIDIndexProvider constructs a TxIDIndexProvider that persists the indexes in the given directory
+func NewTxIDIndexProvider(dbPath string, conf localconfig.TxIDDedup, metricsProvider metrics.Provider) (*TxIDIndexProvider, error) {
+	if conf.WindowBlocks == 0 {
+		return nil, errors.New("the window of the transaction ID index must span at least one block")
+	}
+	if conf.PendingTimeout <= 0 {
+		return nil, errors.New("the accepted transactions must be kept in the transaction ID index for a positive duration")
+	}
+	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error opening the transaction ID index at [%s]", dbPath)
+	}
+	return &TxIDIndexProvider{
+		dbProvider:     dbProvider,
+		windowBlocks:   conf.WindowBlocks,
+		pendingTimeout: conf.PendingTimeout,
+		metrics:        NewDedupMetrics(metricsProvider),
+	}, nil
+}
+
+// Open opens the index of the channel and indexes the blocks in the ledger that were written
+// after the last indexed block, e.g., because the orderer crashed before indexing them. When the
+// index of a channel is opened for the first time, the last WindowBlocks blocks of the ledger are indexed
+func (p *TxIDIndexProvider) Open(channelID string, reader blockledger.Reader) (*TxIDIndex, error) {
+	index := &TxIDIndex{
+		channelID:      channelID,
+		db:             p.dbProvider.GetDBHandle(channelID),
+		windowBlocks:   p.windowBlocks,
+		pendingTimeout: p.pendingTimeout,
+		now:            time.Now,
+		txIDs:          map[string]uint64{},
+		pending:        map[string]time.Time{},
+		size:           p.metrics.TxIDIndexSize.With("channel", channelID),
+		rejected:       p.metrics.DuplicateTxIDsRejected.With("channel", channelID),
+	}
+	if err := index.load(); err != nil {
+		return nil, err
+	}
+
+	height := reader.Height()
+	from := uint64(0)
+	if height > index.windowBlocks {
+		from = height - index.windowBlocks
+	}
+	if n := len(index.blocks); n > 0 && index.blocks[n-1].number+1 > from {
+		from = index.blocks[n-1].number + 1
+	}
+	for blockNum := from; blockNum < height; blockNum++ {
+		block := blockledger.GetBlock(reader, blockNum)
+		if block == nil {
+			return nil, errors.Errorf("could not retrieve block [%d] of channel [%s] for indexing the transaction IDs", blockNum, channelID)
+		}
+		if err := index.Record(block); err != nil {
+			return nil, err
+		}
+	}
+	logger.Infof("[channel: %s] Opened the transaction ID index with %d transaction IDs", channelID, len(index.txIDs))
+	return index, nil
+}
+
+// Close closes the underlying db
+func (p *TxIDIndexProvider) Close() {
+	p.dbProvider.Close()
+}
+
+// TxIDIndex maintains the transaction IDs of the endorser transactions of a channel that were ordered in a
+// sliding window of the last WindowBlocks blocks, as well as the transaction IDs of the transactions that
+// were accepted for ordering by this node in the last PendingTimeout and are not in a block yet
+type TxIDIndex struct {
+	channelID      string
+	db             *leveldbhelper.DBHandle
+	windowBlocks   uint64
+	pendingTimeout time.Duration
+	now            func() time.Time
+	size           metrics.Gauge
+	rejected       metrics.Counter
+
+	lock   sync.RWMutex
+	txIDs  map[string]uint64
+	blocks []*indexedBlock
+	// pending maps the transaction IDs of the accepted transactions to the time they were accepted
+	pending map[string]time.Time
+}
+
+// indexedBlock is a block in the window of the index
+type indexedBlock struct {
+	number uint64
+	txIDs  []string
+}
+
+// Lookup returns the number of the block in which the transaction with the given ID was ordered, if the
+// block is in the window of the index
+func (i *TxIDIndex) Lookup(txID string) (uint64, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	blockNum, ok := i.txIDs[txID]
+	return blockNum, ok
+}
+
+// Record indexes the transaction IDs of the endorser transactions in the block and evicts the
+// blocks that fall out of the window, along with the accepted transactions that were not ordered
+// within the PendingTimeout. A block that is already indexed is ignored
+func (i *TxIDIndex) Record(block *cb.Block) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	blockNum := block.Header.Number
+	if len(i.blocks) > 0 && blockNum <= i.blocks[len(i.blocks)-1].number {
+		return nil
+	}
+	b := &indexedBlock{
+		number: blockNum,
+		txIDs:  endorserTxIDs(block),
+	}
+	if len(i.blocks) > 0 && blockNum != i.blocks[len(i.blocks)-1].number+1 {
+		// the index does not have gaps, so that a block in the window can be located by its number
+		logger.Warningf("[channel: %s] Block [%d] is not next to the last indexed block [%d], discarding the transaction ID index",
+			i.channelID, blockNum, i.blocks[len(i.blocks)-1].number)
+		if err := i.evict(len(i.blocks)); err != nil {
+			return err
+		}
+	}
+
+	batch := i.db.NewUpdateBatch()
+	batch.Put(encodeBlockKey(blockNum), encodeIndexedBlock(b))
+	numEvicted := i.numToEvict(blockNum)
+	for _, evicted := range i.blocks[:numEvicted] {
+		batch.Delete(encodeBlockKey(evicted.number))
+	}
+	if err := i.db.WriteBatch(batch, true); err != nil {
+		return errors.WithMessagef(err, "error writing the transaction IDs of block [%d] to the index", blockNum)
+	}
+
+	i.removeFromMemory(numEvicted)
+	i.blocks = append(i.blocks, b)
+	for _, txID := range b.txIDs {
+		// a transaction ID that repeats in the window maps to its latest occurrence
+		i.txIDs[txID] = blockNum
+		delete(i.pending, txID)
+	}
+	now := i.now()
+	for txID, acceptedAt := range i.pending {
+		if i.pendingExpired(acceptedAt, now) {
+			delete(i.pending, txID)
+		}
+	}
+	i.size.Set(float64(len(i.txIDs)))
+	return nil
+}
+
+// pendingExpired returns whether the transaction accepted at the given time was not ordered within
+// the PendingTimeout, which means that it was dropped by the consenter, e.g., because the leader
+// changed or because it was invalidated by a config update, and may be submitted again
+func (i *TxIDIndex) pendingExpired(acceptedAt, now time.Time) bool {
+	return now.Sub(acceptedAt) >= i.pendingTimeout
+}
+
+// numToEvict returns the number of the oldest blocks that fall out of the window when the given block is added
+func (i *TxIDIndex) numToEvict(blockNum uint64) int {
+	n := 0
+	for n < len(i.blocks) && blockNum-i.blocks[n].number >= i.windowBlocks {
+		n++
+	}
+	return n
+}
+
+func (i *TxIDIndex) evict(n int) error {
+	batch := i.db.NewUpdateBatch()
+	for _, b := range i.blocks[:n] {
+		batch.Delete(encodeBlockKey(b.number))
+	}
+	if err := i.db.WriteBatch(batch, true); err != nil {
+		return errors.WithMessage(err, "error evicting blocks from the transaction ID index")
+	}
+	i.removeFromMemory(n)
+	return nil
+}
+
+func (i *TxIDIndex) removeFromMemory(n int) {
+	for _, b := range i.blocks[:n] {
+		for _, txID := range b.txIDs {
+			if i.txIDs[txID] == b.number {
+				delete(i.txIDs, txID)
+			}
+		}
+	}
+	i.blocks = i.blocks[n:]
+}
+
+// load loads the indexed blocks from the db
+func (i *TxIDIndex) load() error {
+	itr, err := i.db.GetIterator(blockKeyPrefix, []byte{blockKeyPrefix[0] + 1})
+	if err != nil {
+		return err
+	}
+	defer itr.Release()
+	for itr.Next() {
+		blockNum, _, err := util.DecodeOrderPreservingVarUint64(itr.Key()[len(blockKeyPrefix):])
+		if err != nil {
+			return errors.Wrap(err, "error decoding the block number in the transaction ID index")
+		}
+		b, err := decodeIndexedBlock(blockNum, itr.Value())
+		if err != nil {
+			return err
+		}
+		if len(i.blocks) > 0 && blockNum != i.blocks[len(i.blocks)-1].number+1 {
+			return errors.Errorf("the transaction ID index has a gap before block [%d]", blockNum)
+		}
+		i.blocks = append(i.blocks, b)
+		for _, txID := range b.txIDs {
+			i.txIDs[txID] = blockNum
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "internal leveldb error while loading the transaction ID index")
+	}
+	i.size.Set(float64(len(i.txIDs)))
+	return nil
+}
+
+// endorserTxIDs returns the transaction IDs of the endorser transactions in the block
+func endorserTxIDs(block *cb.Block) []string {
+	var txIDs []string
+	for _, envBytes := range block.GetData().GetData() {
+		env, err := protoutil.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		if txID, ok := endorserTxID(env); ok {
+			txIDs = append(txIDs, txID)
+		}
+	}
+	return txIDs
+}
+
+func channelHeader(env *cb.Envelope) (*cb.ChannelHeader, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	return protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
+
+func encodeBlockKey(blockNum uint64) []byte {
+	return append(append([]byte{}, blockKeyPrefix...), util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
+
+// encodeIndexedBlock encodes the length prefixed transaction IDs of the block
+func encodeIndexedBlock(b *indexedBlock) []byte {
+	buf := make([]byte, 0, len(b.txIDs)*66)
+	for _, txID := range b.txIDs {
+		buf = appendUvarint(buf, uint64(len(txID)))
+		buf = append(buf, txID...)
+	}
+	return buf
+}
+
+func decodeIndexedBlock(blockNum uint64, encoded []byte) (*indexedBlock, error) {
+	errCorrupted := errors.Errorf("the transaction ID index entry of block [%d] is corrupted", blockNum)
+	b := &indexedBlock{number: blockNum}
+	for remaining := encoded; len(remaining) > 0; {
+		l, n := binary.Uvarint(remaining)
+		if n <= 0 || uint64(len(remaining)-n) < l {
+			return nil, errCorrupted
+		}
+		b.txIDs = append(b.txIDs, string(remaining[n:n+int(l)]))
+		remaining = remaining[n+int(l):]
+	}
+	return b, nil
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/blockledger/fileledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTxEnvelope(headerType cb.HeaderType, txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: "testchannel",
+					TxId:      txID,
+				}),
+			},
+		}),
+	}
+}
+
+func makeTxBlock(number uint64, txIDs ...string) *cb.Block {
+	block := protoutil.NewBlock(number, nil)
+	for _, txID := range txIDs {
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(makeTxEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, txID)))
+	}
+	// the transaction IDs of the config transactions are not indexed
+	block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(makeTxEnvelope(cb.HeaderType_CONFIG, "config")))
+	return block
+}
+
+// appendTxBlock appends a block with the given transactions to the ledger
+func appendTxBlock(t *testing.T, ledger blockledger.ReadWriter, txIDs ...string) *cb.Block {
+	block := makeTxBlock(ledger.Height(), txIDs...)
+	if block.Header.Number > 0 {
+		block.Header.PreviousHash = protoutil.BlockHeaderHash(blockledger.GetBlock(ledger, block.Header.Number-1).Header)
+	}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	require.NoError(t, ledger.Append(block))
+	return block
+}
+
+func newTestTxIDIndexProvider(t *testing.T, conf localconfig.TxIDDedup) (*TxIDIndexProvider, string) {
+	dir, err := ioutil.TempDir("", "txidindex")
+	require.NoError(t, err)
+	p, err := NewTxIDIndexProvider(dir, conf, &disabled.Provider{})
+	require.NoError(t, err)
+	return p, dir
+}
+
+func TestTxIDIndexBlockWindow(t *testing.T) {
+	p, dir := newTestTxIDIndexProvider(t, localconfig.TxIDDedup{WindowBlocks: 2, PendingTimeout: time.Minute})
+	defer os.RemoveAll(dir)
+	defer p.Close()
+
+	ledgerFactory, err := fileledger.New(dir, &disabled.Provider{})
+	require.NoError(t, err)
+	defer ledgerFactory.Close()
+	ledger, err := ledgerFactory.GetOrCreate("testchannel")
+	require.NoError(t, err)
+
+	index, err := p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	for _, txIDs := range [][]string{nil, {"tx1", "tx2"}, {"tx3", "tx1"}} {
+		require.NoError(t, index.Record(appendTxBlock(t, ledger, txIDs...)))
+	}
+
+	blockNum, ok := index.Lookup("tx1")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), blockNum)
+	_, ok = index.Lookup("config")
+	require.False(t, ok)
+
+	// block 1 falls out of the window
+	require.NoError(t, index.Record(makeTxBlock(3)))
+	_, ok = index.Lookup("tx2")
+	require.False(t, ok)
+	_, ok = index.Lookup("tx1")
+	require.True(t, ok)
+
+	// a block that is already indexed is ignored
+	require.NoError(t, index.Record(makeTxBlock(3, "tx4")))
+	_, ok = index.Lookup("tx4")
+	require.False(t, ok)
+
+	// the index is loaded from the db when reopened
+	index, err = p.Open("testchannel2", ledger)
+	require.NoError(t, err)
+	_, ok = index.Lookup("tx3")
+	require.True(t, ok, "the index of the ledger was built on open")
+	index, err = p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	require.Len(t, index.blocks, 2)
+	blockNum, ok = index.Lookup("tx3")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), blockNum)
+
+	// a block that is not next to the last indexed block discards the index
+	require.NoError(t, index.Record(makeTxBlock(6, "tx6")))
+	_, ok = index.Lookup("tx3")
+	require.False(t, ok)
+	_, ok = index.Lookup("tx6")
+	require.True(t, ok)
+	index, err = p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	require.Len(t, index.blocks, 1)
+}
+
+func TestTxIDIndexCatchUp(t *testing.T) {
+	p, dir := newTestTxIDIndexProvider(t, localconfig.TxIDDedup{WindowBlocks: 10, PendingTimeout: time.Minute})
+	defer os.RemoveAll(dir)
+	defer p.Close()
+
+	ledgerFactory, err := fileledger.New(dir, &disabled.Provider{})
+	require.NoError(t, err)
+	defer ledgerFactory.Close()
+	ledger, err := ledgerFactory.GetOrCreate("testchannel")
+	require.NoError(t, err)
+	appendTxBlock(t, ledger, "tx0")
+
+	index, err := p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	_, ok := index.Lookup("tx0")
+	require.True(t, ok)
+
+	// the blocks that were appended to the ledger but not indexed are indexed on open
+	appendTxBlock(t, ledger, "tx1")
+	appendTxBlock(t, ledger, "tx2")
+	index, err = p.Open("testchannel", ledger)
+	require.NoError(t, err)
+	require.Len(t, index.blocks, 3)
+	blockNum, ok := index.Lookup("tx2")
+	require.True(t, ok)
+	require.Equal(t, uint64(2), blockNum)
+}
+
+func TestTxIDIndexWindowRequired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txidindex")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewTxIDIndexProvider(dir, localconfig.TxIDDedup{}, &disabled.Provider{})
+	require.EqualError(t, err, "the window of the transaction ID index must span at least one block")
+
+	_, err = NewTxIDIndexProvider(dir, localconfig.TxIDDedup{WindowBlocks: 10}, &disabled.Provider{})
+	require.EqualError(t, err, "the accepted transactions must be kept in the transaction ID index for a positive duration")
+}
+
+func TestIndexedBlockEncoding(t *testing.T) {
+	b := &indexedBlock{
+		number: 5,
+		txIDs:  []string{"tx1", "", "tx3"},
+	}
+	decoded, err := decodeIndexedBlock(5, encodeIndexedBlock(b))
+	require.NoError(t, err)
+	require.Equal(t, b.txIDs, decoded.txIDs)
+
+	decoded, err = decodeIndexedBlock(5, encodeIndexedBlock(&indexedBlock{number: 5}))
+	require.NoError(t, err)
+	require.Empty(t, decoded.txIDs)
+
+	_, err = decodeIndexedBlock(5, append(encodeIndexedBlock(b), 10))
+	require.EqualError(t, err, "the transaction ID index entry of block [5] is corrupted")
+}
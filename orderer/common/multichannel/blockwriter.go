@@ -90,6 +90,39 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 // This call will block until the new config has taken effect, then will return
 // while the block is written asynchronously to disk.
 func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte) {
+	if isMigration := bw.applyConfigBlock(block); isMigration {
+		encodedMetadataValue = nil
+	}
+
+	bw.WriteBlock(block, encodedMetadataValue)
+}
+
+// WriteSignedBlock should be invoked for blocks whose signatures have already been
+// added by the consenters that ordered them, e.g. a quorum of BFT nodes. Unlike
+// WriteBlock, it preserves the signatures in the block metadata. If the block
+// contains a config transaction, it blocks until the new config has taken effect.
+// The block is written asynchronously to disk.
+func (bw *BlockWriter) WriteSignedBlock(block *cb.Block) {
+	if protoutil.IsConfigBlock(block) {
+		bw.applyConfigBlock(block)
+	}
+
+	bw.committingBlock.Lock()
+	bw.lastBlock = block
+
+	go func() {
+		defer bw.committingBlock.Unlock()
+		bw.addLastConfig(bw.lastBlock)
+		if err := bw.support.Append(bw.lastBlock); err != nil {
+			logger.Panicf("[channel: %s] Could not append block: %s", bw.support.ChannelID(), err)
+		}
+		logger.Debugf("[channel: %s] Wrote signed block [%d]", bw.support.ChannelID(), bw.lastBlock.GetHeader().Number)
+	}()
+}
+
+// applyConfigBlock applies the config transaction inside the given block and reports
+// whether the new config migrates the channel to a different consensus type.
+func (bw *BlockWriter) applyConfigBlock(block *cb.Block) bool {
 	ctx, err := protoutil.ExtractEnvelope(block, 0)
 	if err != nil {
 		logger.Panicf("Told to write a config block, but could not get configtx: %s", err)
@@ -109,6 +142,7 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		logger.Panicf("Told to write a config block with an invalid channel header: %s", err)
 	}
 
+	var isMigration bool
 	switch chdr.Type {
 	case int32(cb.HeaderType_ORDERER_TRANSACTION):
 		newChannelConfig, err := protoutil.UnmarshalEnvelope(payload.Data)
@@ -141,7 +175,7 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		currentType := bw.support.SharedConfig().ConsensusType()
 		nextType := oc.ConsensusType()
 		if currentType != nextType {
-			encodedMetadataValue = nil
+			isMigration = true
 			logger.Debugf("[channel: %s] Consensus-type migration: maintenance mode, change from %s to %s, setting metadata to nil",
 				bw.support.ChannelID(), currentType, nextType)
 		}
@@ -156,7 +190,7 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		logger.Panicf("Told to write a config block with unknown header type: %v", chdr.Type)
 	}
 
-	bw.WriteBlock(block, encodedMetadataValue)
+	return isMigration
 }
 
 // WriteBlock should be invoked for blocks which contain normal transactions.
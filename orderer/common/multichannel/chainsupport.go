@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package multichannel
 
 import (
+	"time"
+
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -40,6 +42,9 @@ type ChainSupport struct {
 	// Therefore, we let each chain report its cluster relation and status through this interface. Non cluster
 	// type chains (solo, kafka) are assigned a static reporter.
 	consensus.StatusReporter
+
+	// txIDIndex is nil unless the rejection of the duplicate transaction IDs is enabled.
+	txIDIndex *msgprocessor.TxIDIndex
 }
 
 func newChainSupport(
@@ -71,6 +76,14 @@ func newChainSupport(
 		BCCSP: bccsp,
 	}
 
+	// Set up the index of the transaction IDs of the recently ordered transactions
+	if registrar.txIDIndexProvider != nil {
+		cs.txIDIndex, err = registrar.txIDIndexProvider.Open(cs.ChannelID(), ledgerResources)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error opening the transaction ID index for channel: %s", cs.ChannelID())
+		}
+	}
+
 	// Set up the msgprocessor
 	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar.config), bccsp)
 
@@ -193,10 +206,62 @@ func (cs *ChainSupport) Sequence() uint64 {
 	return cs.ConfigtxValidator().Sequence()
 }
 
+// SharedConfig returns the orderer config of the channel. Its batch timeout is
+// the one tuned by the block cutter, if the adaptive block cutting is enabled.
+func (cs *ChainSupport) SharedConfig() channelconfig.Orderer {
+	config := cs.ledgerResources.SharedConfig()
+	if tuner, ok := cs.cutter.(blockcutter.Tuner); ok {
+		return &tunedOrdererConfig{Orderer: config, tuner: tuner}
+	}
+	return config
+}
+
+// tunedOrdererConfig is an orderer config whose batch timeout is given by a blockcutter.Tuner.
+type tunedOrdererConfig struct {
+	channelconfig.Orderer
+	tuner blockcutter.Tuner
+}
+
+func (c *tunedOrdererConfig) BatchTimeout() time.Duration {
+	return c.tuner.BatchTimeout()
+}
+
 // Append appends a new block to the ledger in its raw form,
-// unlike WriteBlock that also mutates its metadata.
+// unlike WriteBlock that also mutates its metadata. The transaction
+// IDs of the block are recorded in the transaction ID index, if enabled.
+// The time it takes is reported to the block cutter.
 func (cs *ChainSupport) Append(block *cb.Block) error {
-	return cs.ledgerResources.ReadWriter.Append(block)
+	start := time.Now()
+	if err := cs.ledgerResources.ReadWriter.Append(block); err != nil {
+		return err
+	}
+	if tuner, ok := cs.cutter.(blockcutter.Tuner); ok {
+		tuner.ObserveBlockCommit(time.Since(start))
+	}
+	if cs.txIDIndex != nil {
+		if err := cs.txIDIndex.Record(block); err != nil {
+			logger.Errorf("[channel: %s] Failed to record the transaction IDs of block [%d]: %s", cs.ChannelID(), block.Header.Number, err)
+		}
+	}
+	return nil
+}
+
+// Order rejects the endorser transactions whose transaction IDs were recently ordered or already
+// accepted by this node, if enabled, and passes the other messages to the consenter. The rejection
+// is not one of the filters of the msgprocessor, as the consenters apply the filters again when
+// they validate the blocks proposed by the other nodes.
+func (cs *ChainSupport) Order(env *cb.Envelope, configSeq uint64) error {
+	if cs.txIDIndex == nil {
+		return cs.Chain.Order(env, configSeq)
+	}
+	if err := cs.txIDIndex.Accept(env); err != nil {
+		return err
+	}
+	if err := cs.Chain.Order(env, configSeq); err != nil {
+		cs.txIDIndex.Release(env)
+		return err
+	}
+	return nil
 }
 
 // TODO Move this method and associated test to ledgerResources struct, to it can be shared with the FollowerResources struct.
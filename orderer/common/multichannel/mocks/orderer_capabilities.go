@@ -6,6 +6,16 @@ import (
 )
 
 type OrdererCapabilities struct {
+	AdaptiveBlockCuttingStub        func() bool
+	adaptiveBlockCuttingMutex       sync.RWMutex
+	adaptiveBlockCuttingArgsForCall []struct {
+	}
+	adaptiveBlockCuttingReturns struct {
+		result1 bool
+	}
+	adaptiveBlockCuttingReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ConsensusTypeMigrationStub        func() bool
 	consensusTypeMigrationMutex       sync.RWMutex
 	consensusTypeMigrationArgsForCall []struct {
@@ -70,6 +80,58 @@ type OrdererCapabilities struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *OrdererCapabilities) AdaptiveBlockCutting() bool {
+	fake.adaptiveBlockCuttingMutex.Lock()
+	ret, specificReturn := fake.adaptiveBlockCuttingReturnsOnCall[len(fake.adaptiveBlockCuttingArgsForCall)]
+	fake.adaptiveBlockCuttingArgsForCall = append(fake.adaptiveBlockCuttingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AdaptiveBlockCutting", []interface{}{})
+	fake.adaptiveBlockCuttingMutex.Unlock()
+	if fake.AdaptiveBlockCuttingStub != nil {
+		return fake.AdaptiveBlockCuttingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.adaptiveBlockCuttingReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererCapabilities) AdaptiveBlockCuttingCallCount() int {
+	fake.adaptiveBlockCuttingMutex.RLock()
+	defer fake.adaptiveBlockCuttingMutex.RUnlock()
+	return len(fake.adaptiveBlockCuttingArgsForCall)
+}
+
+func (fake *OrdererCapabilities) AdaptiveBlockCuttingCalls(stub func() bool) {
+	fake.adaptiveBlockCuttingMutex.Lock()
+	defer fake.adaptiveBlockCuttingMutex.Unlock()
+	fake.AdaptiveBlockCuttingStub = stub
+}
+
+func (fake *OrdererCapabilities) AdaptiveBlockCuttingReturns(result1 bool) {
+	fake.adaptiveBlockCuttingMutex.Lock()
+	defer fake.adaptiveBlockCuttingMutex.Unlock()
+	fake.AdaptiveBlockCuttingStub = nil
+	fake.adaptiveBlockCuttingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *OrdererCapabilities) AdaptiveBlockCuttingReturnsOnCall(i int, result1 bool) {
+	fake.adaptiveBlockCuttingMutex.Lock()
+	defer fake.adaptiveBlockCuttingMutex.Unlock()
+	fake.AdaptiveBlockCuttingStub = nil
+	if fake.adaptiveBlockCuttingReturnsOnCall == nil {
+		fake.adaptiveBlockCuttingReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.adaptiveBlockCuttingReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *OrdererCapabilities) ConsensusTypeMigration() bool {
 	fake.consensusTypeMigrationMutex.Lock()
 	ret, specificReturn := fake.consensusTypeMigrationReturnsOnCall[len(fake.consensusTypeMigrationArgsForCall)]
@@ -385,6 +447,8 @@ func (fake *OrdererCapabilities) UseChannelCreationPolicyAsAdminsReturnsOnCall(i
 func (fake *OrdererCapabilities) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adaptiveBlockCuttingMutex.RLock()
+	defer fake.adaptiveBlockCuttingMutex.RUnlock()
 	fake.consensusTypeMigrationMutex.RLock()
 	defer fake.consensusTypeMigrationMutex.RUnlock()
 	fake.expirationCheckMutex.RLock()
@@ -7,9 +7,20 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/channelconfig/ordererpb"
 )
 
 type OrdererConfig struct {
+	AdaptiveBatchingStub        func() *ordererpb.AdaptiveBatching
+	adaptiveBatchingMutex       sync.RWMutex
+	adaptiveBatchingArgsForCall []struct {
+	}
+	adaptiveBatchingReturns struct {
+		result1 *ordererpb.AdaptiveBatching
+	}
+	adaptiveBatchingReturnsOnCall map[int]struct {
+		result1 *ordererpb.AdaptiveBatching
+	}
 	BatchSizeStub        func() *orderer.BatchSize
 	batchSizeMutex       sync.RWMutex
 	batchSizeArgsForCall []struct {
@@ -30,6 +41,16 @@ type OrdererConfig struct {
 	batchTimeoutReturnsOnCall map[int]struct {
 		result1 time.Duration
 	}
+	BroadcastLimitsStub        func() *ordererpb.BroadcastLimits
+	broadcastLimitsMutex       sync.RWMutex
+	broadcastLimitsArgsForCall []struct {
+	}
+	broadcastLimitsReturns struct {
+		result1 *ordererpb.BroadcastLimits
+	}
+	broadcastLimitsReturnsOnCall map[int]struct {
+		result1 *ordererpb.BroadcastLimits
+	}
 	CapabilitiesStub        func() channelconfig.OrdererCapabilities
 	capabilitiesMutex       sync.RWMutex
 	capabilitiesArgsForCall []struct {
@@ -104,6 +125,58 @@ type OrdererConfig struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *OrdererConfig) AdaptiveBatching() *ordererpb.AdaptiveBatching {
+	fake.adaptiveBatchingMutex.Lock()
+	ret, specificReturn := fake.adaptiveBatchingReturnsOnCall[len(fake.adaptiveBatchingArgsForCall)]
+	fake.adaptiveBatchingArgsForCall = append(fake.adaptiveBatchingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AdaptiveBatching", []interface{}{})
+	fake.adaptiveBatchingMutex.Unlock()
+	if fake.AdaptiveBatchingStub != nil {
+		return fake.AdaptiveBatchingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.adaptiveBatchingReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingCallCount() int {
+	fake.adaptiveBatchingMutex.RLock()
+	defer fake.adaptiveBatchingMutex.RUnlock()
+	return len(fake.adaptiveBatchingArgsForCall)
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingCalls(stub func() *ordererpb.AdaptiveBatching) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = stub
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingReturns(result1 *ordererpb.AdaptiveBatching) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = nil
+	fake.adaptiveBatchingReturns = struct {
+		result1 *ordererpb.AdaptiveBatching
+	}{result1}
+}
+
+func (fake *OrdererConfig) AdaptiveBatchingReturnsOnCall(i int, result1 *ordererpb.AdaptiveBatching) {
+	fake.adaptiveBatchingMutex.Lock()
+	defer fake.adaptiveBatchingMutex.Unlock()
+	fake.AdaptiveBatchingStub = nil
+	if fake.adaptiveBatchingReturnsOnCall == nil {
+		fake.adaptiveBatchingReturnsOnCall = make(map[int]struct {
+			result1 *ordererpb.AdaptiveBatching
+		})
+	}
+	fake.adaptiveBatchingReturnsOnCall[i] = struct {
+		result1 *ordererpb.AdaptiveBatching
+	}{result1}
+}
+
 func (fake *OrdererConfig) BatchSize() *orderer.BatchSize {
 	fake.batchSizeMutex.Lock()
 	ret, specificReturn := fake.batchSizeReturnsOnCall[len(fake.batchSizeArgsForCall)]
@@ -208,6 +281,58 @@ func (fake *OrdererConfig) BatchTimeoutReturnsOnCall(i int, result1 time.Duratio
 	}{result1}
 }
 
+func (fake *OrdererConfig) BroadcastLimits() *ordererpb.BroadcastLimits {
+	fake.broadcastLimitsMutex.Lock()
+	ret, specificReturn := fake.broadcastLimitsReturnsOnCall[len(fake.broadcastLimitsArgsForCall)]
+	fake.broadcastLimitsArgsForCall = append(fake.broadcastLimitsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("BroadcastLimits", []interface{}{})
+	fake.broadcastLimitsMutex.Unlock()
+	if fake.BroadcastLimitsStub != nil {
+		return fake.BroadcastLimitsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.broadcastLimitsReturns
+	return fakeReturns.result1
+}
+
+func (fake *OrdererConfig) BroadcastLimitsCallCount() int {
+	fake.broadcastLimitsMutex.RLock()
+	defer fake.broadcastLimitsMutex.RUnlock()
+	return len(fake.broadcastLimitsArgsForCall)
+}
+
+func (fake *OrdererConfig) BroadcastLimitsCalls(stub func() *ordererpb.BroadcastLimits) {
+	fake.broadcastLimitsMutex.Lock()
+	defer fake.broadcastLimitsMutex.Unlock()
+	fake.BroadcastLimitsStub = stub
+}
+
+func (fake *OrdererConfig) BroadcastLimitsReturns(result1 *ordererpb.BroadcastLimits) {
+	fake.broadcastLimitsMutex.Lock()
+	defer fake.broadcastLimitsMutex.Unlock()
+	fake.BroadcastLimitsStub = nil
+	fake.broadcastLimitsReturns = struct {
+		result1 *ordererpb.BroadcastLimits
+	}{result1}
+}
+
+func (fake *OrdererConfig) BroadcastLimitsReturnsOnCall(i int, result1 *ordererpb.BroadcastLimits) {
+	fake.broadcastLimitsMutex.Lock()
+	defer fake.broadcastLimitsMutex.Unlock()
+	fake.BroadcastLimitsStub = nil
+	if fake.broadcastLimitsReturnsOnCall == nil {
+		fake.broadcastLimitsReturnsOnCall = make(map[int]struct {
+			result1 *ordererpb.BroadcastLimits
+		})
+	}
+	fake.broadcastLimitsReturnsOnCall[i] = struct {
+		result1 *ordererpb.BroadcastLimits
+	}{result1}
+}
+
 func (fake *OrdererConfig) Capabilities() channelconfig.OrdererCapabilities {
 	fake.capabilitiesMutex.Lock()
 	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
@@ -575,10 +700,14 @@ func (fake *OrdererConfig) OrganizationsReturnsOnCall(i int, result1 map[string]
 func (fake *OrdererConfig) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.adaptiveBatchingMutex.RLock()
+	defer fake.adaptiveBatchingMutex.RUnlock()
 	fake.batchSizeMutex.RLock()
 	defer fake.batchSizeMutex.RUnlock()
 	fake.batchTimeoutMutex.RLock()
 	defer fake.batchTimeoutMutex.RUnlock()
+	fake.broadcastLimitsMutex.RLock()
+	defer fake.broadcastLimitsMutex.RUnlock()
 	fake.capabilitiesMutex.RLock()
 	defer fake.capabilitiesMutex.RUnlock()
 	fake.consensusMetadataMutex.RLock()
@@ -11,8 +11,7 @@ package multichannel
 
 import (
 	"fmt"
-	"github.com/hyperledger/fabric/orderer/common/cluster"
-	"github.com/hyperledger/fabric/orderer/common/follower"
+	"path/filepath"
 	"sync"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -25,6 +24,8 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/orderer/common/blockcutter"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/follower"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/types"
@@ -113,6 +114,7 @@ type Registrar struct {
 	callbacks          []channelconfig.BundleActor
 	bccsp              bccsp.BCCSP
 	clusterDialer      *cluster.PredicateDialer
+	txIDIndexProvider  *msgprocessor.TxIDIndexProvider
 }
 
 // ConfigBlock retrieves the last configuration block from the given ledger.
@@ -156,6 +158,18 @@ func NewRegistrar(
 		clusterDialer:      clusterDialer,
 	}
 
+	if config.General.TxIDDedup.Enabled {
+		var err error
+		r.txIDIndexProvider, err = msgprocessor.NewTxIDIndexProvider(
+			filepath.Join(config.FileLedger.Location, "txiddedup"),
+			config.General.TxIDDedup,
+			metricsProvider,
+		)
+		if err != nil {
+			logger.Panicf("Failed to create the transaction ID index: %s", err)
+		}
+	}
+
 	return r
 }
 
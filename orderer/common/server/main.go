@@ -47,6 +47,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/common/onboarding"
 	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
@@ -65,7 +66,7 @@ var (
 	_       = app.Command("start", "X - Start the orderer node").Default() // preserved for cli compatibility
 	version = app.Command("version", "X - Show version information!!!")
 
-	clusterTypes = map[string]struct{}{"etcdraft": {}}
+	clusterTypes = map[string]struct{}{"etcdraft": {}, "BFT": {}}
 )
 
 // Main is the entry point of orderer process
@@ -254,6 +255,7 @@ func Main() {
 		conf.General.Authentication.TimeWindow,
 		mutualTLS,
 		conf.General.Authentication.NoExpirationChecks,
+		conf.General.BroadcastLimits,
 	)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
@@ -724,7 +726,9 @@ func initializeMultichannelRegistrar(
 			icr = etcdConsenter.InactiveChainRegistry
 		} else if bootstrapBlock == nil {
 			// without a system channel: assume cluster type, InactiveChainRegistry == nil, no go-routine.
-			consenters["etcdraft"] = etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, nil, metricsProvider, bccsp)
+			raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, nil, metricsProvider, bccsp)
+			consenters["etcdraft"] = raftConsenter
+			consenters["BFT"] = bft.New(clusterDialer, raftConsenter.Communication, conf, srvConf.SecOpts.Certificate, bccsp)
 		}
 	}
 
@@ -775,6 +779,8 @@ func initializeEtcdraftConsenter(
 	go icr.Run()
 	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider, bccsp)
 	consenters["etcdraft"] = raftConsenter
+	// The BFT consenter shares the cluster communication of the etcdraft consenter
+	consenters["BFT"] = bft.New(clusterDialer, raftConsenter.Communication, conf, srvConf.SecOpts.Certificate, bccsp)
 	return raftConsenter
 }
 
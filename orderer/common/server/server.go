@@ -90,12 +90,15 @@ func NewServer(
 	timeWindow time.Duration,
 	mutualTLS bool,
 	expirationCheckDisabled bool,
+	broadcastLimits localconfig.BroadcastLimits,
 ) ab.AtomicBroadcastServer {
+	broadcastMetrics := broadcast.NewMetrics(metricsProvider)
 	s := &server{
 		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider), expirationCheckDisabled),
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
-			Metrics:          broadcast.NewMetrics(metricsProvider),
+			Metrics:          broadcastMetrics,
+			Limiter:          broadcast.NewLimiter(broadcastLimits, broadcastMetrics),
 		},
 		debug:     debug,
 		Registrar: r,
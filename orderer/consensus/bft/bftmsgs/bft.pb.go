@@ -0,0 +1,771 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bft.proto
+
+package bftmsgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ConfigMetadata is serialized and set as the value of ConsensusType.Metadata in
+// a channel configuration when the ConsensusType.Type is set to "BFT".
+type ConfigMetadata struct {
+	Consenters           []*Consenter `protobuf:"bytes,1,rep,name=consenters,proto3" json:"consenters,omitempty"`
+	Options              *Options     `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ConfigMetadata) Reset()         { *m = ConfigMetadata{} }
+func (m *ConfigMetadata) String() string { return proto.CompactTextString(m) }
+func (*ConfigMetadata) ProtoMessage()    {}
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{0}
+}
+
+func (m *ConfigMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigMetadata.Unmarshal(m, b)
+}
+func (m *ConfigMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigMetadata.Marshal(b, m, deterministic)
+}
+func (m *ConfigMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigMetadata.Merge(m, src)
+}
+func (m *ConfigMetadata) XXX_Size() int {
+	return xxx_messageInfo_ConfigMetadata.Size(m)
+}
+func (m *ConfigMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigMetadata proto.InternalMessageInfo
+
+func (m *ConfigMetadata) GetConsenters() []*Consenter {
+	if m != nil {
+		return m.Consenters
+	}
+	return nil
+}
+
+func (m *ConfigMetadata) GetOptions() *Options {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// Consenter represents a consenting node (i.e. replica).
+type Consenter struct {
+	// The identifier of the consenter, unique within the channel. The leader of
+	// a view is chosen by the order of the identifiers.
+	Id            uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Host          string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	ClientTlsCert []byte `protobuf:"bytes,4,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
+	ServerTlsCert []byte `protobuf:"bytes,5,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	// The MSP ID of the organization of the consenter
+	MspId string `protobuf:"bytes,6,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	// The PEM encoded signing certificate the consenter signs blocks with
+	Identity             []byte   `protobuf:"bytes,7,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Consenter) Reset()         { *m = Consenter{} }
+func (m *Consenter) String() string { return proto.CompactTextString(m) }
+func (*Consenter) ProtoMessage()    {}
+func (*Consenter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{1}
+}
+
+func (m *Consenter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consenter.Unmarshal(m, b)
+}
+func (m *Consenter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consenter.Marshal(b, m, deterministic)
+}
+func (m *Consenter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consenter.Merge(m, src)
+}
+func (m *Consenter) XXX_Size() int {
+	return xxx_messageInfo_Consenter.Size(m)
+}
+func (m *Consenter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consenter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consenter proto.InternalMessageInfo
+
+func (m *Consenter) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *Consenter) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *Consenter) GetPort() uint32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Consenter) GetClientTlsCert() []byte {
+	if m != nil {
+		return m.ClientTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetServerTlsCert() []byte {
+	if m != nil {
+		return m.ServerTlsCert
+	}
+	return nil
+}
+
+func (m *Consenter) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func (m *Consenter) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+// Options to be specified for all the BFT nodes. These can be modified on a
+// per-channel basis.
+type Options struct {
+	// The duration a forwarded request may stay unordered before the leader is suspected
+	RequestTimeout string `protobuf:"bytes,1,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	// The duration a view change may take before moving on to the next view
+	ViewChangeTimeout    string   `protobuf:"bytes,2,opt,name=view_change_timeout,json=viewChangeTimeout,proto3" json:"view_change_timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Options) Reset()         { *m = Options{} }
+func (m *Options) String() string { return proto.CompactTextString(m) }
+func (*Options) ProtoMessage()    {}
+func (*Options) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{2}
+}
+
+func (m *Options) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Options.Unmarshal(m, b)
+}
+func (m *Options) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Options.Marshal(b, m, deterministic)
+}
+func (m *Options) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Options.Merge(m, src)
+}
+func (m *Options) XXX_Size() int {
+	return xxx_messageInfo_Options.Size(m)
+}
+func (m *Options) XXX_DiscardUnknown() {
+	xxx_messageInfo_Options.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Options proto.InternalMessageInfo
+
+func (m *Options) GetRequestTimeout() string {
+	if m != nil {
+		return m.RequestTimeout
+	}
+	return ""
+}
+
+func (m *Options) GetViewChangeTimeout() string {
+	if m != nil {
+		return m.ViewChangeTimeout
+	}
+	return ""
+}
+
+// BlockMetadata stores data used by the BFT chains. It is encoded and stored in
+// the consenter metadata of every block.
+type BlockMetadata struct {
+	// The view in which the block was ordered
+	View                 uint64   `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
+func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
+func (*BlockMetadata) ProtoMessage()    {}
+func (*BlockMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{3}
+}
+
+func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
+}
+func (m *BlockMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockMetadata.Marshal(b, m, deterministic)
+}
+func (m *BlockMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockMetadata.Merge(m, src)
+}
+func (m *BlockMetadata) XXX_Size() int {
+	return xxx_messageInfo_BlockMetadata.Size(m)
+}
+func (m *BlockMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockMetadata proto.InternalMessageInfo
+
+func (m *BlockMetadata) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+// Message is the payload of the consensus requests exchanged by the BFT nodes.
+type Message struct {
+	// Types that are valid to be assigned to Content:
+	//	*Message_PrePrepare
+	//	*Message_Prepare
+	//	*Message_Commit
+	//	*Message_ViewChange
+	//	*Message_NewView
+	Content              isMessage_Content `protobuf_oneof:"content"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{4}
+}
+
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Message.Unmarshal(m, b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return xxx_messageInfo_Message.Size(m)
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+type isMessage_Content interface {
+	isMessage_Content()
+}
+
+type Message_PrePrepare struct {
+	PrePrepare *PrePrepare `protobuf:"bytes,1,opt,name=pre_prepare,json=prePrepare,proto3,oneof"`
+}
+
+type Message_Prepare struct {
+	Prepare *Prepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof"`
+}
+
+type Message_Commit struct {
+	Commit *Commit `protobuf:"bytes,3,opt,name=commit,proto3,oneof"`
+}
+
+type Message_ViewChange struct {
+	ViewChange *ViewChange `protobuf:"bytes,4,opt,name=view_change,json=viewChange,proto3,oneof"`
+}
+
+type Message_NewView struct {
+	NewView *NewView `protobuf:"bytes,5,opt,name=new_view,json=newView,proto3,oneof"`
+}
+
+func (*Message_PrePrepare) isMessage_Content() {}
+
+func (*Message_Prepare) isMessage_Content() {}
+
+func (*Message_Commit) isMessage_Content() {}
+
+func (*Message_ViewChange) isMessage_Content() {}
+
+func (*Message_NewView) isMessage_Content() {}
+
+func (m *Message) GetContent() isMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *Message) GetPrePrepare() *PrePrepare {
+	if x, ok := m.GetContent().(*Message_PrePrepare); ok {
+		return x.PrePrepare
+	}
+	return nil
+}
+
+func (m *Message) GetPrepare() *Prepare {
+	if x, ok := m.GetContent().(*Message_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *Commit {
+	if x, ok := m.GetContent().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetViewChange() *ViewChange {
+	if x, ok := m.GetContent().(*Message_ViewChange); ok {
+		return x.ViewChange
+	}
+	return nil
+}
+
+func (m *Message) GetNewView() *NewView {
+	if x, ok := m.GetContent().(*Message_NewView); ok {
+		return x.NewView
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Message) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Message_PrePrepare)(nil),
+		(*Message_Prepare)(nil),
+		(*Message_Commit)(nil),
+		(*Message_ViewChange)(nil),
+		(*Message_NewView)(nil),
+	}
+}
+
+// PrePrepare is sent by the leader of a view to propose the next block.
+type PrePrepare struct {
+	View uint64 `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	// The marshaled common.Block, without its signatures
+	Block                []byte   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrePrepare) Reset()         { *m = PrePrepare{} }
+func (m *PrePrepare) String() string { return proto.CompactTextString(m) }
+func (*PrePrepare) ProtoMessage()    {}
+func (*PrePrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{5}
+}
+
+func (m *PrePrepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrePrepare.Unmarshal(m, b)
+}
+func (m *PrePrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrePrepare.Marshal(b, m, deterministic)
+}
+func (m *PrePrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrePrepare.Merge(m, src)
+}
+func (m *PrePrepare) XXX_Size() int {
+	return xxx_messageInfo_PrePrepare.Size(m)
+}
+func (m *PrePrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrePrepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrePrepare proto.InternalMessageInfo
+
+func (m *PrePrepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *PrePrepare) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// Prepare is sent by every node that accepted the proposal of the leader.
+// A quorum of prepares for the same block forms a prepare certificate, which
+// proves that the block may have been committed.
+type Prepare struct {
+	View uint64 `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq  uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	// The hash of the header of the proposed block
+	Digest []byte `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// The identifier of the consenter that sent the prepare
+	Sender uint64 `protobuf:"varint,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	// The marshaled common.MetadataSignature of the sender over the prepare
+	// without its signature
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Prepare) Reset()         { *m = Prepare{} }
+func (m *Prepare) String() string { return proto.CompactTextString(m) }
+func (*Prepare) ProtoMessage()    {}
+func (*Prepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{6}
+}
+
+func (m *Prepare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Prepare.Unmarshal(m, b)
+}
+func (m *Prepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Prepare.Marshal(b, m, deterministic)
+}
+func (m *Prepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Prepare.Merge(m, src)
+}
+func (m *Prepare) XXX_Size() int {
+	return xxx_messageInfo_Prepare.Size(m)
+}
+func (m *Prepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_Prepare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Prepare proto.InternalMessageInfo
+
+func (m *Prepare) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Prepare) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Prepare) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Prepare) GetSender() uint64 {
+	if m != nil {
+		return m.Sender
+	}
+	return 0
+}
+
+func (m *Prepare) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Commit is sent by every node that collected a quorum of prepares and it carries
+// the signature of the node over the proposed block.
+type Commit struct {
+	View   uint64 `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Seq    uint64 `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	Digest []byte `protobuf:"bytes,3,opt,name=digest,proto3" json:"digest,omitempty"`
+	// The marshaled common.MetadataSignature of the node over the block
+	Signature            []byte   `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Commit) Reset()         { *m = Commit{} }
+func (m *Commit) String() string { return proto.CompactTextString(m) }
+func (*Commit) ProtoMessage()    {}
+func (*Commit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{7}
+}
+
+func (m *Commit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Commit.Unmarshal(m, b)
+}
+func (m *Commit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Commit.Marshal(b, m, deterministic)
+}
+func (m *Commit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Commit.Merge(m, src)
+}
+func (m *Commit) XXX_Size() int {
+	return xxx_messageInfo_Commit.Size(m)
+}
+func (m *Commit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Commit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Commit proto.InternalMessageInfo
+
+func (m *Commit) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *Commit) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *Commit) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *Commit) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ViewChange is sent by a node that suspects the leader of the current view.
+type ViewChange struct {
+	NextView uint64 `protobuf:"varint,1,opt,name=next_view,json=nextView,proto3" json:"next_view,omitempty"`
+	// The view in which the node committed to the block it has not written yet, if any
+	PreparedView uint64 `protobuf:"varint,2,opt,name=prepared_view,json=preparedView,proto3" json:"prepared_view,omitempty"`
+	// The marshaled common.Block the node committed to, if any
+	PreparedBlock []byte `protobuf:"bytes,3,opt,name=prepared_block,json=preparedBlock,proto3" json:"prepared_block,omitempty"`
+	// The quorum of signed prepares of the prepared block
+	PreparedCertificate []*Prepare `protobuf:"bytes,4,rep,name=prepared_certificate,json=preparedCertificate,proto3" json:"prepared_certificate,omitempty"`
+	// The identifier of the consenter that sent the view change
+	Sender uint64 `protobuf:"varint,5,opt,name=sender,proto3" json:"sender,omitempty"`
+	// The marshaled common.MetadataSignature of the sender over the view change
+	// without its signature
+	Signature            []byte   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ViewChange) Reset()         { *m = ViewChange{} }
+func (m *ViewChange) String() string { return proto.CompactTextString(m) }
+func (*ViewChange) ProtoMessage()    {}
+func (*ViewChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{8}
+}
+
+func (m *ViewChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewChange.Unmarshal(m, b)
+}
+func (m *ViewChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewChange.Marshal(b, m, deterministic)
+}
+func (m *ViewChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewChange.Merge(m, src)
+}
+func (m *ViewChange) XXX_Size() int {
+	return xxx_messageInfo_ViewChange.Size(m)
+}
+func (m *ViewChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewChange proto.InternalMessageInfo
+
+func (m *ViewChange) GetNextView() uint64 {
+	if m != nil {
+		return m.NextView
+	}
+	return 0
+}
+
+func (m *ViewChange) GetPreparedView() uint64 {
+	if m != nil {
+		return m.PreparedView
+	}
+	return 0
+}
+
+func (m *ViewChange) GetPreparedBlock() []byte {
+	if m != nil {
+		return m.PreparedBlock
+	}
+	return nil
+}
+
+func (m *ViewChange) GetPreparedCertificate() []*Prepare {
+	if m != nil {
+		return m.PreparedCertificate
+	}
+	return nil
+}
+
+func (m *ViewChange) GetSender() uint64 {
+	if m != nil {
+		return m.Sender
+	}
+	return 0
+}
+
+func (m *ViewChange) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// NewView is sent by the leader of a view once a quorum of nodes moved to it.
+// It carries their view changes, from which every node computes the block the
+// leader must propose again.
+type NewView struct {
+	View                 uint64        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	ViewChanges          []*ViewChange `protobuf:"bytes,2,rep,name=view_changes,json=viewChanges,proto3" json:"view_changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NewView) Reset()         { *m = NewView{} }
+func (m *NewView) String() string { return proto.CompactTextString(m) }
+func (*NewView) ProtoMessage()    {}
+func (*NewView) Descriptor() ([]byte, []int) {
+	return fileDescriptor_69dca6b485e5c1d2, []int{9}
+}
+
+func (m *NewView) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewView.Unmarshal(m, b)
+}
+func (m *NewView) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewView.Marshal(b, m, deterministic)
+}
+func (m *NewView) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewView.Merge(m, src)
+}
+func (m *NewView) XXX_Size() int {
+	return xxx_messageInfo_NewView.Size(m)
+}
+func (m *NewView) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewView.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewView proto.InternalMessageInfo
+
+func (m *NewView) GetView() uint64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
+func (m *NewView) GetViewChanges() []*ViewChange {
+	if m != nil {
+		return m.ViewChanges
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ConfigMetadata)(nil), "bftmsgs.ConfigMetadata")
+	proto.RegisterType((*Consenter)(nil), "bftmsgs.Consenter")
+	proto.RegisterType((*Options)(nil), "bftmsgs.Options")
+	proto.RegisterType((*BlockMetadata)(nil), "bftmsgs.BlockMetadata")
+	proto.RegisterType((*Message)(nil), "bftmsgs.Message")
+	proto.RegisterType((*PrePrepare)(nil), "bftmsgs.PrePrepare")
+	proto.RegisterType((*Prepare)(nil), "bftmsgs.Prepare")
+	proto.RegisterType((*Commit)(nil), "bftmsgs.Commit")
+	proto.RegisterType((*ViewChange)(nil), "bftmsgs.ViewChange")
+	proto.RegisterType((*NewView)(nil), "bftmsgs.NewView")
+}
+
+func init() { proto.RegisterFile("bft.proto", fileDescriptor_69dca6b485e5c1d2) }
+
+var fileDescriptor_69dca6b485e5c1d2 = []byte{
+	// 669 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xc7, 0xeb, 0xc4, 0x89, 0x9b, 0x93, 0x8f, 0xf6, 0x4e, 0x7b, 0xaf, 0xac, 0x0b, 0x8b, 0xc8,
+	0x15, 0x10, 0x10, 0x24, 0x52, 0x90, 0xba, 0x41, 0x6c, 0x9a, 0x4d, 0x59, 0x14, 0xaa, 0x51, 0x61,
+	0xc1, 0xc6, 0xf2, 0xc7, 0x89, 0x33, 0x22, 0xf1, 0xb8, 0x33, 0x93, 0x96, 0x2e, 0x78, 0x03, 0x9e,
+	0x8b, 0xe7, 0xe1, 0x11, 0xd0, 0xcc, 0x38, 0x76, 0x88, 0x5a, 0x36, 0xec, 0x66, 0xfe, 0xf3, 0x3b,
+	0x3e, 0xe7, 0xfc, 0xe7, 0x78, 0xa0, 0x13, 0xcf, 0xd5, 0xb8, 0x10, 0x5c, 0x71, 0xe2, 0xc5, 0x73,
+	0xb5, 0x92, 0x99, 0x0c, 0x0a, 0x18, 0xcc, 0x78, 0x3e, 0x67, 0xd9, 0x05, 0xaa, 0x28, 0x8d, 0x54,
+	0x44, 0xa6, 0x00, 0x09, 0xcf, 0x25, 0xe6, 0x0a, 0x85, 0xf4, 0x9d, 0x61, 0x73, 0xd4, 0x9d, 0x92,
+	0x71, 0xc9, 0x8f, 0x67, 0x9b, 0x23, 0xba, 0x45, 0x91, 0x17, 0xe0, 0xf1, 0x42, 0x31, 0x9e, 0x4b,
+	0xbf, 0x31, 0x74, 0x46, 0xdd, 0xe9, 0x61, 0x15, 0xf0, 0xc1, 0xea, 0x74, 0x03, 0x04, 0x3f, 0x1c,
+	0xe8, 0x54, 0x5f, 0x21, 0x03, 0x68, 0xb0, 0xd4, 0x77, 0x86, 0xce, 0xc8, 0xa5, 0x0d, 0x96, 0x12,
+	0x02, 0xee, 0x82, 0x4b, 0x65, 0x3e, 0xd3, 0xa1, 0x66, 0xad, 0xb5, 0x82, 0x0b, 0xe5, 0x37, 0x87,
+	0xce, 0xa8, 0x4f, 0xcd, 0x9a, 0x3c, 0x85, 0x83, 0x64, 0xc9, 0x30, 0x57, 0xa1, 0x5a, 0xca, 0x30,
+	0x41, 0xa1, 0x7c, 0x77, 0xe8, 0x8c, 0x7a, 0xb4, 0x6f, 0xe5, 0xab, 0xa5, 0x9c, 0xa1, 0xe5, 0x24,
+	0x8a, 0x1b, 0x14, 0x35, 0xd7, 0xb2, 0x9c, 0x95, 0x37, 0xdc, 0xbf, 0xd0, 0x5e, 0xc9, 0x22, 0x64,
+	0xa9, 0xdf, 0x36, 0x99, 0x5b, 0x2b, 0x59, 0xbc, 0x4b, 0xc9, 0xff, 0xb0, 0xcf, 0x52, 0xcc, 0x15,
+	0x53, 0x77, 0xbe, 0x67, 0xe2, 0xaa, 0x7d, 0x10, 0x83, 0x57, 0x36, 0x47, 0x9e, 0xc1, 0x81, 0xc0,
+	0xeb, 0x35, 0x4a, 0x15, 0x2a, 0xb6, 0x42, 0xbe, 0x56, 0xa6, 0xa5, 0x0e, 0x1d, 0x94, 0xf2, 0x95,
+	0x55, 0xc9, 0x18, 0x8e, 0x6e, 0x18, 0xde, 0x86, 0xc9, 0x22, 0xca, 0x33, 0xac, 0x60, 0xdb, 0xed,
+	0x3f, 0xfa, 0x68, 0x66, 0x4e, 0x4a, 0x3e, 0x38, 0x81, 0xfe, 0xd9, 0x92, 0x27, 0x5f, 0xaa, 0xdb,
+	0x21, 0xe0, 0x6a, 0xaa, 0x74, 0xcc, 0xac, 0x83, 0xef, 0x0d, 0xf0, 0x2e, 0x50, 0xca, 0x28, 0x43,
+	0x72, 0x0a, 0xdd, 0x42, 0x60, 0x58, 0x08, 0x2c, 0x22, 0x81, 0x06, 0xeb, 0x4e, 0x8f, 0xaa, 0xdb,
+	0xb8, 0x14, 0x78, 0x69, 0x8f, 0xce, 0xf7, 0x28, 0x14, 0xd5, 0x8e, 0xbc, 0x04, 0x6f, 0x13, 0xb3,
+	0x7b, 0x83, 0x75, 0xc0, 0x06, 0x21, 0xcf, 0xa1, 0x9d, 0xf0, 0xd5, 0x8a, 0xd9, 0x3b, 0xe9, 0x4e,
+	0x0f, 0xb6, 0xe6, 0x43, 0xcb, 0xe7, 0x7b, 0xb4, 0x04, 0x74, 0x41, 0x5b, 0x1d, 0xfb, 0xee, 0x4e,
+	0x41, 0x9f, 0xaa, 0x96, 0x75, 0x41, 0xb5, 0x01, 0xe4, 0x15, 0xec, 0xe7, 0x78, 0x1b, 0x9a, 0x66,
+	0x5b, 0x3b, 0x15, 0xbd, 0xc7, 0x5b, 0x1d, 0xa7, 0x2b, 0xca, 0xed, 0xf2, 0xac, 0x03, 0x5e, 0xc2,
+	0x73, 0x85, 0xb9, 0x0a, 0x4e, 0x01, 0xea, 0x36, 0xef, 0x33, 0x8c, 0x1c, 0x43, 0x2b, 0xd6, 0xae,
+	0x9a, 0x56, 0x7b, 0xd4, 0x6e, 0x82, 0x6f, 0xe0, 0xfd, 0x29, 0xe8, 0x10, 0x9a, 0x12, 0xaf, 0x4d,
+	0x88, 0x4b, 0xf5, 0x92, 0xfc, 0x07, 0xed, 0x94, 0x65, 0x28, 0xad, 0x0b, 0x3d, 0x5a, 0xee, 0xb4,
+	0x2e, 0x31, 0x4f, 0x51, 0x98, 0x6e, 0x5d, 0x5a, 0xee, 0xc8, 0x63, 0xe8, 0x48, 0x96, 0xe5, 0x91,
+	0x5a, 0x0b, 0x2c, 0xa7, 0xb0, 0x16, 0x82, 0x14, 0xda, 0xd6, 0xbc, 0xbf, 0xcc, 0xfe, 0x5b, 0x16,
+	0x77, 0x37, 0xcb, 0x4f, 0x07, 0xa0, 0xf6, 0x9c, 0x3c, 0x82, 0x4e, 0x8e, 0x5f, 0x55, 0xb8, 0x95,
+	0x6f, 0x5f, 0x0b, 0x1a, 0x21, 0x27, 0xd0, 0x2f, 0x2f, 0x3c, 0xb5, 0x80, 0xcd, 0xde, 0xdb, 0x88,
+	0x06, 0x7a, 0x02, 0x83, 0x0a, 0xb2, 0xa6, 0xda, 0x72, 0xaa, 0x50, 0x33, 0xbf, 0x64, 0x06, 0xc7,
+	0x15, 0xa6, 0xff, 0x42, 0x36, 0x67, 0x49, 0xa4, 0x74, 0x81, 0xcd, 0xfb, 0x86, 0x8d, 0x1e, 0x6d,
+	0xe8, 0x59, 0x0d, 0x6f, 0x19, 0xdb, 0x7a, 0xd8, 0xd8, 0xf6, 0x6e, 0xcb, 0x1f, 0xc1, 0x2b, 0x07,
+	0xe6, 0x5e, 0x67, 0x4f, 0xa1, 0xb7, 0x35, 0xa0, 0xfa, 0x01, 0x6b, 0x3e, 0x30, 0xa1, 0xb4, 0x5b,
+	0xcf, 0xa7, 0x3c, 0x7b, 0xfb, 0xf9, 0x4d, 0xc6, 0xd4, 0x62, 0x1d, 0x8f, 0x13, 0xbe, 0x9a, 0x2c,
+	0xee, 0x0a, 0x14, 0x4b, 0x4c, 0x33, 0x14, 0x93, 0x79, 0x14, 0x0b, 0x96, 0x4c, 0xb8, 0x48, 0x51,
+	0xa0, 0x98, 0xd8, 0x77, 0x52, 0xae, 0xe5, 0x24, 0x9e, 0xab, 0x49, 0xf9, 0xd9, 0xb8, 0x6d, 0x1e,
+	0xe2, 0xd7, 0xbf, 0x06, 0x00, 0x51, 0x85, 0xf0, 0xf2, 0x95, 0x05, 0x00, 0x00,
+}
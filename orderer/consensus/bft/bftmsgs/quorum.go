@@ -0,0 +1,18 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bftmsgs
+
+import "math"
+
+// ComputeQuorum returns the number of nodes that constitute a quorum and the number of
+// Byzantine faults tolerated out of the given number of nodes.
+// Any two quorums intersect in at least f+1 nodes, hence in at least one correct node.
+func ComputeQuorum(n int) (q int, f int) {
+	f = (n - 1) / 3
+	q = int(math.Ceil((float64(n) + float64(f) + 1) / 2.0))
+	return q, f
+}
@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bftmsgs_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeQuorum(t *testing.T) {
+	for _, testCase := range []struct {
+		n, q, f int
+	}{
+		{n: 1, q: 1, f: 0},
+		{n: 3, q: 2, f: 0},
+		{n: 4, q: 3, f: 1},
+		{n: 5, q: 4, f: 1},
+		{n: 7, q: 5, f: 2},
+		{n: 10, q: 7, f: 3},
+	} {
+		q, f := bftmsgs.ComputeQuorum(testCase.n)
+		require.Equal(t, testCase.q, q, "quorum of %d nodes", testCase.n)
+		require.Equal(t, testCase.f, f, "faults tolerated by %d nodes", testCase.n)
+	}
+}
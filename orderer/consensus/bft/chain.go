@@ -0,0 +1,1568 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/types"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxBufferedMessages is the maximum number of messages of future views or
+	// sequences that are kept until the chain is ready to process them.
+	maxBufferedMessages = 1000
+
+	// recentBlocks is the number of latest blocks whose transactions are remembered,
+	// in order not to order again requests that are submitted to several nodes.
+	recentBlocks = 100
+)
+
+//go:generate counterfeiter -o mocks/configurator.go --fake-name Configurator . Configurator
+
+// Configurator is used to configure the communication layer
+// when the chain starts and when the consenter set changes.
+type Configurator interface {
+	Configure(channel string, newNodes []cluster.RemoteNode)
+}
+
+//go:generate counterfeiter -o mocks/rpc.go --fake-name RPC . RPC
+
+// RPC is used to mock the transport layer in tests.
+type RPC interface {
+	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
+	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+}
+
+//go:generate counterfeiter -o mocks/block_puller.go --fake-name BlockPuller . BlockPuller
+
+// BlockPuller is used to pull blocks from other OSNs when the chain falls behind.
+type BlockPuller interface {
+	PullBlock(seq uint64) *common.Block
+	Close()
+}
+
+// CreateBlockPuller is a function to create BlockPuller on demand.
+// It is passed into chain initializer so that tests could mock this.
+type CreateBlockPuller func() (BlockPuller, error)
+
+// Options contains all the configurations relevant to the chain.
+type Options struct {
+	SelfID        uint64
+	Consenters    []*bftmsgs.Consenter
+	BlockMetadata *bftmsgs.BlockMetadata
+
+	// RequestTimeout is the duration after which a follower suspects the leader
+	// of the current view, if a request it forwarded is still not ordered
+	// or if the proposal of the leader is not committed.
+	RequestTimeout time.Duration
+	// ViewChangeTimeout is the duration after which a view change that has not
+	// been completed is abandoned in favor of the next view.
+	ViewChangeTimeout time.Duration
+	// CheckInterval is the interval the timeouts are checked at.
+	// It defaults to a quarter of the RequestTimeout.
+	CheckInterval time.Duration
+
+	Clock  clock.Clock
+	Logger *flogging.FabricLogger
+}
+
+type submit struct {
+	req    *orderer.SubmitRequest
+	sender uint64
+	// forwardTo receives the node the request should be forwarded to, or 0 if none
+	forwardTo chan uint64
+}
+
+type message struct {
+	sender uint64
+	msg    *bftmsgs.Message
+}
+
+type batch struct {
+	envs      []*common.Envelope
+	configSeq uint64
+}
+
+type pendingRequest struct {
+	req         *orderer.SubmitRequest
+	submittedAt time.Time
+	// relayed is true if the request has been relayed to all the consenters
+	relayed bool
+}
+
+// round holds the state of the agreement on the block with the next sequence
+// in the current view.
+type round struct {
+	view uint64
+	seq  uint64
+
+	block                *common.Block
+	digest               []byte
+	ordererBlockMetadata []byte
+	startedAt            time.Time
+
+	prepares   map[uint64]*bftmsgs.Prepare
+	sentCommit bool
+	commits    map[uint64]*common.MetadataSignature
+	unverified map[uint64]*bftmsgs.Commit
+}
+
+func newRound(view, seq uint64) *round {
+	return &round{
+		view:       view,
+		seq:        seq,
+		prepares:   map[uint64]*bftmsgs.Prepare{},
+		commits:    map[uint64]*common.MetadataSignature{},
+		unverified: map[uint64]*bftmsgs.Commit{},
+	}
+}
+
+type viewChange struct {
+	nextView  uint64
+	startedAt time.Time
+}
+
+type progress struct {
+	view uint64
+	seq  uint64
+}
+
+// Chain implements consensus.Chain interface with a leader based Byzantine fault
+// tolerant protocol in the spirit of PBFT. The leader of a view proposes blocks
+// that the other nodes validate, and a block is written once a quorum of nodes
+// committed to it. Blocks carry the signatures of the quorum that committed to them.
+// Followers that suspect the leader of censoring their requests or of stalling
+// trigger a view change, which rotates the leader.
+type Chain struct {
+	support      consensus.ConsenterSupport
+	configurator Configurator
+	rpc          RPC
+	createPuller CreateBlockPuller
+	verifier     *signatureVerifier
+
+	channelID string
+	selfID    uint64
+	opts      Options
+	clock     clock.Clock
+	logger    *flogging.FabricLogger
+
+	submitC chan *submit
+	msgC    chan *message
+	haltC   chan struct{}
+	doneC   chan struct{}
+	startC  chan struct{}
+
+	// The fields below are accessed only by the go routine of run
+	consenters      *consenterSet
+	view            uint64
+	lastBlock       *common.Block
+	lastConfigIndex uint64
+	round           *round
+	// prepared is the ViewChange that reports the block this node committed to, if any,
+	// along with its prepare certificate
+	prepared   *bftmsgs.ViewChange
+	viewChange *viewChange
+	// awaitingNewView is true if this node follows a view whose NewView has not been received yet
+	awaitingNewView bool
+	// reproposal is the block the leader of the view must propose again, if any
+	reproposal      *common.Block
+	viewChangeVotes map[uint64]map[uint64]*bftmsgs.ViewChange
+	progress        map[uint64]progress
+	buffered        []*message
+	pendingBatches  []*batch
+	batchTimer      clock.Timer
+	pendingRequests map[string]*pendingRequest
+	// accepted holds the requests the leader has accepted for ordering but are not written yet
+	accepted map[string]struct{}
+	// recent holds the requests written in the latest blocks
+	recent        map[string]struct{}
+	recentByBlock [][]string
+}
+
+// NewChain constructs a chain object.
+func NewChain(
+	support consensus.ConsenterSupport,
+	opts Options,
+	conf Configurator,
+	rpc RPC,
+	cryptoProvider bccsp.BCCSP,
+	createPuller CreateBlockPuller,
+) (*Chain, error) {
+	lastBlock := support.Block(support.Height() - 1)
+	if lastBlock == nil {
+		return nil, errors.Errorf("failed to retrieve block [%d]", support.Height()-1)
+	}
+	var lastConfigIndex uint64
+	if lastBlock.Header.Number != 0 {
+		var err error
+		lastConfigIndex, err = protoutil.GetLastConfigIndexFromBlock(lastBlock)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to retrieve the last config index")
+		}
+	}
+
+	if opts.CheckInterval == 0 {
+		opts.CheckInterval = opts.RequestTimeout / 4
+	}
+
+	c := &Chain{
+		support:         support,
+		configurator:    conf,
+		rpc:             rpc,
+		createPuller:    createPuller,
+		verifier:        &signatureVerifier{bccsp: cryptoProvider},
+		channelID:       support.ChannelID(),
+		selfID:          opts.SelfID,
+		opts:            opts,
+		clock:           opts.Clock,
+		logger:          opts.Logger.With("channel", support.ChannelID(), "node", opts.SelfID),
+		submitC:         make(chan *submit),
+		msgC:            make(chan *message),
+		haltC:           make(chan struct{}),
+		doneC:           make(chan struct{}),
+		startC:          make(chan struct{}),
+		consenters:      newConsenterSet(opts.Consenters),
+		view:            opts.BlockMetadata.View,
+		lastBlock:       lastBlock,
+		lastConfigIndex: lastConfigIndex,
+		viewChangeVotes: map[uint64]map[uint64]*bftmsgs.ViewChange{},
+		progress:        map[uint64]progress{},
+		pendingRequests: map[string]*pendingRequest{},
+		accepted:        map[string]struct{}{},
+		recent:          map[string]struct{}{},
+	}
+	c.round = newRound(c.view, lastBlock.Header.Number+1)
+	return c, nil
+}
+
+// Start instructs the chain to begin serving requests.
+func (c *Chain) Start() {
+	c.logger.Infof("Starting BFT node in view %d, the leader is %d", c.view, c.consenters.leader(c.view))
+
+	if err := c.configureComm(); err != nil {
+		c.logger.Errorf("Failed to start chain, aborting: %+v", err)
+		close(c.doneC)
+		close(c.startC)
+		return
+	}
+
+	close(c.startC)
+	go c.run()
+}
+
+// Order submits normal type transactions for ordering.
+func (c *Chain) Order(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// Configure submits config type transactions for ordering.
+func (c *Chain) Configure(env *common.Envelope, configSeq uint64) error {
+	return c.Submit(&orderer.SubmitRequest{LastValidationSeq: configSeq, Payload: env, Channel: c.channelID}, 0)
+}
+
+// WaitReady returns right away if the chain is running.
+func (c *Chain) WaitReady() error {
+	return c.isRunning()
+}
+
+// Errored returns a channel that closes when the chain stops.
+func (c *Chain) Errored() <-chan struct{} {
+	return c.doneC
+}
+
+// Halt stops the chain.
+func (c *Chain) Halt() {
+	select {
+	case <-c.startC:
+	default:
+		c.logger.Warnf("Attempted to halt a chain that has not started")
+		return
+	}
+
+	select {
+	case c.haltC <- struct{}{}:
+	case <-c.doneC:
+		return
+	}
+	<-c.doneC
+}
+
+// StatusReport returns the ClusterRelation & Status
+func (c *Chain) StatusReport() (types.ClusterRelation, types.Status) {
+	return types.ClusterRelationMember, types.StatusActive
+}
+
+// ValidateConsensusMetadata determines the validity of a ConsensusMetadata update
+// during config updates on the channel.
+func (c *Chain) ValidateConsensusMetadata(oldMetadataBytes, newMetadataBytes []byte, newChannel bool) error {
+	if len(newMetadataBytes) == 0 {
+		return errors.New("missing new metadata")
+	}
+	if _, err := ReadConfigMetadata(newMetadataBytes); err != nil {
+		return errors.WithMessage(err, "invalid new config metadata")
+	}
+	if newChannel {
+		return nil
+	}
+	if _, err := ReadConfigMetadata(oldMetadataBytes); err != nil {
+		return errors.WithMessage(err, "invalid old config metadata")
+	}
+	return nil
+}
+
+func (c *Chain) isRunning() error {
+	select {
+	case <-c.startC:
+	default:
+		return errors.Errorf("chain is not started")
+	}
+
+	select {
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	default:
+	}
+
+	return nil
+}
+
+// Consensus passes the given ConsensusRequest message to the chain.
+func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	msg := &bftmsgs.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return errors.Wrap(err, "failed to unmarshal ConsensusRequest payload to BFT message")
+	}
+
+	select {
+	case c.msgC <- &message{sender: sender, msg: msg}:
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+	return nil
+}
+
+// Submit forwards the incoming request to:
+// - the local run goroutine if this is the leader
+// - the actual leader via the transport mechanism
+// Requests that are forwarded to the leader are tracked until they
+// are ordered, and a leader that does not order them is suspected.
+func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	forwardC := make(chan uint64, 1)
+	select {
+	case c.submitC <- &submit{req: req, sender: sender, forwardTo: forwardC}:
+		if leader := <-forwardC; leader != 0 {
+			if err := c.rpc.SendSubmit(leader, req); err != nil {
+				// the request is re-submitted once the leader is replaced
+				c.logger.Warnf("Failed to forward request to leader %d: %s", leader, err)
+			}
+		}
+	case <-c.doneC:
+		return errors.Errorf("chain is stopped")
+	}
+	return nil
+}
+
+func (c *Chain) run() {
+	ticker := c.clock.NewTicker(c.opts.CheckInterval)
+	defer func() {
+		ticker.Stop()
+		c.stopBatchTimer()
+		close(c.doneC)
+	}()
+
+	for {
+		var batchTimeout <-chan time.Time
+		if c.batchTimer != nil {
+			batchTimeout = c.batchTimer.C()
+		}
+
+		select {
+		case s := <-c.submitC:
+			s.forwardTo <- c.handleSubmit(s.req, s.sender)
+
+		case m := <-c.msgC:
+			if stop := c.handleMessage(m); stop {
+				return
+			}
+
+		case <-batchTimeout:
+			c.batchTimer = nil
+			if envs := c.support.BlockCutter().Cut(); len(envs) != 0 {
+				c.pendingBatches = append(c.pendingBatches, &batch{envs: envs, configSeq: c.support.Sequence()})
+				c.proposeNext()
+			}
+
+		case <-ticker.C():
+			c.checkTimeouts()
+
+		case <-c.haltC:
+			c.logger.Infof("Stopped BFT node")
+			return
+		}
+	}
+}
+
+func (c *Chain) isLeader() bool {
+	return c.viewChange == nil && c.consenters.leader(c.view) == c.selfID
+}
+
+// handleSubmit orders the request if this node is the leader, and otherwise returns
+// the node the request should be forwarded to, if any.
+func (c *Chain) handleSubmit(req *orderer.SubmitRequest, sender uint64) uint64 {
+	if c.isOrdered(requestID(req.Payload)) {
+		c.logger.Debugf("Discarding request submitted by %d, it has already been ordered", sender)
+		return 0
+	}
+
+	if c.isLeader() {
+		c.order(req)
+		return 0
+	}
+
+	if !c.isConfig(req.Payload) {
+		id := requestID(req.Payload)
+		if _, exists := c.pendingRequests[id]; exists && sender != 0 {
+			return 0
+		}
+		// requests relayed by other nodes are tracked as well, so that the leader
+		// is suspected by enough nodes if it censors them
+		c.pendingRequests[id] = &pendingRequest{req: req, submittedAt: c.clock.Now(), relayed: sender != 0}
+	} else if sender != 0 {
+		c.logger.Debugf("Discarding config request forwarded by %d, this node is not the leader of view %d", sender, c.view)
+		return 0
+	}
+	if c.viewChange != nil {
+		// the request is forwarded to the leader of the next view once it is installed
+		return 0
+	}
+	return c.consenters.leader(c.view)
+}
+
+func (c *Chain) isOrdered(id string) bool {
+	_, accepted := c.accepted[id]
+	_, recent := c.recent[id]
+	return accepted || recent
+}
+
+// order passes the request to the block cutter and proposes the batches cut.
+// It takes care of config messages as well as the revalidation of messages if the config sequence has advanced.
+func (c *Chain) order(req *orderer.SubmitRequest) {
+	seq := c.support.Sequence()
+
+	if c.isConfig(req.Payload) {
+		if req.LastValidationSeq < seq {
+			c.logger.Warnf("Config message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+			var err error
+			req.Payload, _, err = c.support.ProcessConfigMsg(req.Payload)
+			if err != nil {
+				c.logger.Warnf("Discarding bad config message: %s", err)
+				return
+			}
+		}
+		if pending := c.support.BlockCutter().Cut(); len(pending) != 0 {
+			c.pendingBatches = append(c.pendingBatches, &batch{envs: pending, configSeq: seq})
+		}
+		c.accepted[requestID(req.Payload)] = struct{}{}
+		c.pendingBatches = append(c.pendingBatches, &batch{envs: []*common.Envelope{req.Payload}, configSeq: seq})
+		c.stopBatchTimer()
+		c.proposeNext()
+		return
+	}
+
+	if req.LastValidationSeq < seq {
+		c.logger.Warnf("Normal message was validated against %d, although current config seq has advanced (%d)", req.LastValidationSeq, seq)
+		if _, err := c.support.ProcessNormalMsg(req.Payload); err != nil {
+			c.logger.Warnf("Discarding bad normal message: %s", err)
+			return
+		}
+	}
+
+	c.accepted[requestID(req.Payload)] = struct{}{}
+	batches, pending := c.support.BlockCutter().Ordered(req.Payload)
+	for _, envs := range batches {
+		c.pendingBatches = append(c.pendingBatches, &batch{envs: envs, configSeq: seq})
+	}
+	switch {
+	case !pending:
+		c.stopBatchTimer()
+	case c.batchTimer == nil:
+		c.batchTimer = c.clock.NewTimer(c.support.SharedConfig().BatchTimeout())
+	}
+	c.proposeNext()
+}
+
+func (c *Chain) stopBatchTimer() {
+	if c.batchTimer != nil {
+		c.batchTimer.Stop()
+		c.batchTimer = nil
+	}
+}
+
+// proposeNext proposes the next pending batch if this node is the leader
+// and the block of the previous proposal has been written.
+func (c *Chain) proposeNext() {
+	for c.isLeader() && c.round.block == nil && len(c.pendingBatches) != 0 {
+		b := c.pendingBatches[0]
+		c.pendingBatches = c.pendingBatches[1:]
+
+		envs := c.revalidate(b)
+		if len(envs) == 0 {
+			continue
+		}
+		c.propose(c.createNextBlock(envs))
+	}
+}
+
+// revalidate validates the messages of the batch again if the config sequence has advanced
+// since they were cut, and returns the ones that are still valid.
+func (c *Chain) revalidate(b *batch) []*common.Envelope {
+	seq := c.support.Sequence()
+	if b.configSeq == seq {
+		return b.envs
+	}
+
+	var envs []*common.Envelope
+	for _, env := range b.envs {
+		if c.isConfig(env) {
+			delete(c.accepted, requestID(env))
+			configEnv, _, err := c.support.ProcessConfigMsg(env)
+			if err != nil {
+				c.logger.Warnf("Discarding bad config message: %s", err)
+				continue
+			}
+			c.accepted[requestID(configEnv)] = struct{}{}
+			envs = append(envs, configEnv)
+			continue
+		}
+		if _, err := c.support.ProcessNormalMsg(env); err != nil {
+			c.logger.Warnf("Discarding bad normal message: %s", err)
+			delete(c.accepted, requestID(env))
+			continue
+		}
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+func (c *Chain) createNextBlock(envs []*common.Envelope) *common.Block {
+	data := &common.BlockData{
+		Data: make([][]byte, len(envs)),
+	}
+	for i, env := range envs {
+		data.Data[i] = protoutil.MarshalOrPanic(env)
+	}
+
+	block := protoutil.NewBlock(c.lastBlock.Header.Number+1, protoutil.BlockHeaderHash(c.lastBlock.Header))
+	block.Header.DataHash = protoutil.BlockDataHash(data)
+	block.Data = data
+	return block
+}
+
+func (c *Chain) propose(block *common.Block) {
+	c.logger.Infof("Proposing block [%d] with %d transactions in view %d", block.Header.Number, len(block.Data.Data), c.view)
+	for _, data := range block.Data.Data {
+		c.accepted[hex.EncodeToString(util.ComputeSHA256(data))] = struct{}{}
+	}
+	c.broadcast(&bftmsgs.Message{
+		Content: &bftmsgs.Message_PrePrepare{
+			PrePrepare: &bftmsgs.PrePrepare{
+				View:  c.view,
+				Block: protoutil.MarshalOrPanic(block),
+			},
+		},
+	})
+	c.acceptProposal(block)
+}
+
+// handleMessage processes a message received from another node and returns true
+// if the chain should stop.
+func (c *Chain) handleMessage(m *message) bool {
+	if _, exists := c.consenters.consenters[m.sender]; !exists || m.sender == c.selfID {
+		c.logger.Warnf("Discarding message from %d, which is not a remote consenter", m.sender)
+		return false
+	}
+
+	if vc := m.msg.GetViewChange(); vc != nil {
+		c.handleViewChange(m.sender, vc)
+		return false
+	}
+	if nv := m.msg.GetNewView(); nv != nil {
+		c.handleNewView(m.sender, nv)
+		return false
+	}
+
+	view, seq, err := messageViewAndSeq(m.msg)
+	if err != nil {
+		c.logger.Warnf("Discarding invalid message from %d: %s", m.sender, err)
+		return false
+	}
+
+	if stop := c.observe(m.sender, view, seq); stop {
+		return true
+	}
+
+	switch {
+	case seq < c.round.seq || view < c.view:
+		c.logger.Debugf("Discarding message from %d of view %d and sequence %d, which is stale", m.sender, view, seq)
+		return false
+	case view > c.view || seq > c.round.seq:
+		c.buffer(m)
+		return false
+	case c.viewChange != nil:
+		c.logger.Debugf("Discarding message from %d of view %d, a view change is in progress", m.sender, view)
+		return false
+	}
+
+	switch content := m.msg.Content.(type) {
+	case *bftmsgs.Message_PrePrepare:
+		if c.awaitingNewView {
+			// the proposal is checked against the NewView of the leader
+			c.buffer(m)
+			return false
+		}
+		c.handlePrePrepare(m.sender, content.PrePrepare)
+	case *bftmsgs.Message_Prepare:
+		if err := c.verifyPrepare(m.sender, content.Prepare); err != nil {
+			c.logger.Warnf("Discarding prepare from %d: %s", m.sender, err)
+			return false
+		}
+		c.round.prepares[m.sender] = content.Prepare
+		c.maybeCommit()
+	case *bftmsgs.Message_Commit:
+		c.round.unverified[m.sender] = content.Commit
+		c.verifyCommits()
+		return c.maybeDeliver()
+	}
+	return false
+}
+
+func messageViewAndSeq(msg *bftmsgs.Message) (view uint64, seq uint64, err error) {
+	switch content := msg.Content.(type) {
+	case *bftmsgs.Message_PrePrepare:
+		block, err := protoutil.UnmarshalBlock(content.PrePrepare.Block)
+		if err != nil {
+			return 0, 0, err
+		}
+		if block.Header == nil || block.Data == nil {
+			return 0, 0, errors.New("proposed block is missing the header or the data")
+		}
+		return content.PrePrepare.View, block.Header.Number, nil
+	case *bftmsgs.Message_Prepare:
+		return content.Prepare.View, content.Prepare.Seq, nil
+	case *bftmsgs.Message_Commit:
+		return content.Commit.View, content.Commit.Seq, nil
+	default:
+		return 0, 0, errors.Errorf("unknown message type %T", msg.Content)
+	}
+}
+
+func (c *Chain) buffer(m *message) {
+	if len(c.buffered) == maxBufferedMessages {
+		c.buffered = c.buffered[1:]
+	}
+	c.buffered = append(c.buffered, m)
+}
+
+// replayBuffered processes again the buffered messages, which may have become
+// processable after a block was written or a view was installed.
+func (c *Chain) replayBuffered() bool {
+	buffered := c.buffered
+	c.buffered = nil
+	for _, m := range buffered {
+		if stop := c.handleMessage(m); stop {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Chain) handlePrePrepare(sender uint64, prePrepare *bftmsgs.PrePrepare) {
+	if leader := c.consenters.leader(c.view); sender != leader {
+		c.logger.Warnf("Discarding proposal from %d, the leader of view %d is %d", sender, c.view, leader)
+		return
+	}
+	if c.round.block != nil {
+		c.logger.Warnf("Discarding proposal from %d, a proposal for block [%d] has already been received in view %d", sender, c.round.seq, c.view)
+		return
+	}
+
+	block, err := protoutil.UnmarshalBlock(prePrepare.Block)
+	if err != nil {
+		c.logger.Warnf("Discarding proposal from %d: %s", sender, err)
+		return
+	}
+	if c.reproposal != nil && c.reproposal.Header.Number == block.Header.Number {
+		// the block may have been committed in a previous view, no other block can be proposed instead
+		if !bytes.Equal(protoutil.BlockHeaderHash(block.Header), protoutil.BlockHeaderHash(c.reproposal.Header)) {
+			c.logger.Warnf("Leader %d proposed block [%d] instead of the one prepared in a previous view", sender, block.Header.Number)
+			c.startViewChange(c.view + 1)
+			return
+		}
+		c.acceptProposal(c.reproposal)
+		return
+	}
+	if err := c.validateProposal(block); err != nil {
+		c.logger.Warnf("Leader %d proposed an invalid block [%d]: %s", sender, block.Header.Number, err)
+		c.startViewChange(c.view + 1)
+		return
+	}
+	c.acceptProposal(block)
+}
+
+// validateProposal checks that the block extends the chain and that all its transactions are valid.
+func (c *Chain) validateProposal(block *common.Block) error {
+	if block.Header.Number != c.lastBlock.Header.Number+1 {
+		return errors.Errorf("expected block number %d but got %d", c.lastBlock.Header.Number+1, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(c.lastBlock.Header)) {
+		return errors.New("previous hash does not match the hash of the last block")
+	}
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return errors.New("data hash does not match the hash of the block data")
+	}
+	if len(block.Data.Data) == 0 {
+		return errors.New("empty block")
+	}
+
+	for i, data := range block.Data.Data {
+		env, err := protoutil.UnmarshalEnvelope(data)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid transaction %d", i)
+		}
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil {
+			return errors.WithMessagef(err, "invalid transaction %d", i)
+		}
+		if chdr.ChannelId != c.channelID {
+			return errors.Errorf("transaction %d is for channel %s", i, chdr.ChannelId)
+		}
+
+		if chdr.Type != int32(common.HeaderType_CONFIG) && chdr.Type != int32(common.HeaderType_ORDERER_TRANSACTION) {
+			if _, err := c.support.ProcessNormalMsg(env); err != nil {
+				return errors.WithMessagef(err, "invalid transaction %d", i)
+			}
+			continue
+		}
+
+		if len(block.Data.Data) != 1 {
+			return errors.New("config transaction must be the only transaction in a block")
+		}
+		configEnv, _, err := c.support.ProcessConfigMsg(env)
+		if err != nil {
+			return errors.WithMessage(err, "invalid config transaction")
+		}
+		if chdr.Type == int32(common.HeaderType_CONFIG) {
+			proposed, err := configFromEnvelope(env)
+			if err != nil {
+				return err
+			}
+			expected, err := configFromEnvelope(configEnv)
+			if err != nil {
+				return err
+			}
+			if !proto.Equal(proposed, expected) {
+				return errors.New("config transaction does not match the config computed from its update")
+			}
+		}
+	}
+	return nil
+}
+
+func configFromEnvelope(env *common.Envelope) (*common.Config, error) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config envelope")
+	}
+	return configEnv.Config, nil
+}
+
+// acceptProposal records the block as the proposal of the current round and prepares it.
+func (c *Chain) acceptProposal(block *common.Block) {
+	lastConfigIndex := c.lastConfigIndex
+	if isConfigTx(block) {
+		lastConfigIndex = block.Header.Number
+	}
+
+	c.round.block = block
+	c.round.digest = protoutil.BlockHeaderHash(block.Header)
+	c.round.startedAt = c.clock.Now()
+	c.round.ordererBlockMetadata = protoutil.MarshalOrPanic(&common.OrdererBlockMetadata{
+		LastConfig: &common.LastConfig{Index: lastConfigIndex},
+		ConsenterMetadata: protoutil.MarshalOrPanic(&common.Metadata{
+			Value: protoutil.MarshalOrPanic(&bftmsgs.BlockMetadata{View: c.view}),
+		}),
+	})
+
+	prepare := &bftmsgs.Prepare{View: c.view, Seq: c.round.seq, Digest: c.round.digest, Sender: c.selfID}
+	signature, err := c.signMessage(prepareBytes(prepare))
+	if err != nil {
+		c.logger.Panicf("Failed to sign prepare of block [%d]: %s", c.round.seq, err)
+	}
+	prepare.Signature = protoutil.MarshalOrPanic(signature)
+	c.broadcast(&bftmsgs.Message{
+		Content: &bftmsgs.Message_Prepare{Prepare: prepare},
+	})
+	c.round.prepares[c.selfID] = prepare
+
+	c.verifyCommits()
+	c.maybeCommit()
+}
+
+// maybeCommit signs the proposal and sends a commit once a quorum prepared it.
+func (c *Chain) maybeCommit() {
+	if c.round.block == nil || c.round.sentCommit || c.countPrepares() < c.consenters.quorum {
+		return
+	}
+
+	signature, err := c.sign(c.round.block.Header, c.round.ordererBlockMetadata)
+	if err != nil {
+		c.logger.Panicf("Failed to sign block [%d]: %s", c.round.seq, err)
+	}
+
+	c.broadcast(&bftmsgs.Message{
+		Content: &bftmsgs.Message_Commit{
+			Commit: &bftmsgs.Commit{
+				View:      c.view,
+				Seq:       c.round.seq,
+				Digest:    c.round.digest,
+				Signature: protoutil.MarshalOrPanic(signature),
+			},
+		},
+	})
+	c.round.sentCommit = true
+	c.round.commits[c.selfID] = signature
+
+	var certificate []*bftmsgs.Prepare
+	for _, id := range c.consenters.ids {
+		if prepare, exists := c.round.prepares[id]; exists && bytes.Equal(prepare.Digest, c.round.digest) {
+			certificate = append(certificate, prepare)
+		}
+	}
+	c.prepared = &bftmsgs.ViewChange{
+		PreparedView:        c.view,
+		PreparedBlock:       protoutil.MarshalOrPanic(c.round.block),
+		PreparedCertificate: certificate,
+	}
+
+	c.maybeDeliver()
+}
+
+func (c *Chain) countPrepares() int {
+	count := 0
+	for _, prepare := range c.round.prepares {
+		if bytes.Equal(prepare.Digest, c.round.digest) {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *Chain) sign(header *common.BlockHeader, ordererBlockMetadata []byte) (*common.MetadataSignature, error) {
+	return c.signWith(func(sigHdr []byte) []byte {
+		return util.ConcatenateBytes(ordererBlockMetadata, sigHdr, protoutil.BlockHeaderBytes(header))
+	})
+}
+
+// signMessage signs a message sent to the other nodes, so that they can relay it as a proof.
+func (c *Chain) signMessage(msg []byte) (*common.MetadataSignature, error) {
+	return c.signWith(func(sigHdr []byte) []byte {
+		return util.ConcatenateBytes(sigHdr, msg)
+	})
+}
+
+func (c *Chain) signWith(signedData func(sigHdr []byte) []byte) (*common.MetadataSignature, error) {
+	sigHdr, err := protoutil.NewSignatureHeader(c.support)
+	if err != nil {
+		return nil, err
+	}
+	signature := &common.MetadataSignature{
+		SignatureHeader: protoutil.MarshalOrPanic(sigHdr),
+	}
+	signature.Signature, err = c.support.Sign(signedData(signature.SignatureHeader))
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// verifyPrepare checks that the prepare was signed by the given consenter.
+func (c *Chain) verifyPrepare(sender uint64, prepare *bftmsgs.Prepare) error {
+	if prepare.Sender != sender {
+		return errors.Errorf("prepare was sent by %d", prepare.Sender)
+	}
+	consenter, exists := c.consenters.consenters[sender]
+	if !exists {
+		return errors.Errorf("%d is not a consenter", sender)
+	}
+	return c.verifier.verifyMessage(consenter, prepareBytes(prepare), prepare.Signature)
+}
+
+// verifyCommits verifies the signatures of the commits received for the proposal of the round.
+func (c *Chain) verifyCommits() {
+	if c.round.block == nil {
+		return
+	}
+	for sender, commit := range c.round.unverified {
+		delete(c.round.unverified, sender)
+		if !bytes.Equal(commit.Digest, c.round.digest) {
+			c.logger.Warnf("Node %d committed to a different block [%d] than the one proposed in view %d", sender, commit.Seq, commit.View)
+			continue
+		}
+		signature := &common.MetadataSignature{}
+		if err := proto.Unmarshal(commit.Signature, signature); err != nil {
+			c.logger.Warnf("Discarding commit from %d: failed to unmarshal signature: %s", sender, err)
+			continue
+		}
+		if err := c.verifier.verify(c.consenters.consenters[sender], c.round.block.Header, c.round.ordererBlockMetadata, signature); err != nil {
+			c.logger.Warnf("Discarding commit from %d: %s", sender, err)
+			continue
+		}
+		c.round.commits[sender] = signature
+	}
+}
+
+// maybeDeliver writes the block of the round once a quorum committed to it.
+func (c *Chain) maybeDeliver() bool {
+	if c.round.block == nil || !c.round.sentCommit || len(c.round.commits) < c.consenters.quorum {
+		return false
+	}
+
+	signers := make([]uint64, 0, len(c.round.commits))
+	for id := range c.round.commits {
+		signers = append(signers, id)
+	}
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	signatures := make([]*common.MetadataSignature, 0, len(signers))
+	for _, id := range signers {
+		signatures = append(signatures, c.round.commits[id])
+	}
+
+	block := c.round.block
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&common.Metadata{
+		Value:      c.round.ordererBlockMetadata,
+		Signatures: signatures,
+	})
+
+	c.logger.Infof("Writing block [%d] committed by %v in view %d", block.Header.Number, signers, c.view)
+	return c.writeBlock(block)
+}
+
+// writeBlock writes a block committed by a quorum and proposes the next one.
+// It returns true if the chain should stop.
+func (c *Chain) writeBlock(block *common.Block) bool {
+	if stop := c.commit(block); stop {
+		return true
+	}
+	if stop := c.replayBuffered(); stop {
+		return true
+	}
+	c.proposeNext()
+	return false
+}
+
+// commit writes a block that carries the signatures of a quorum to the ledger,
+// and moves to the next sequence. It returns true if the chain should stop.
+func (c *Chain) commit(block *common.Block) bool {
+	c.support.WriteSignedBlock(block)
+	c.lastBlock = block
+	c.prepared = nil
+	c.round = newRound(c.view, block.Header.Number+1)
+	if c.reproposal != nil && c.reproposal.Header.Number <= block.Header.Number {
+		c.reproposal = nil
+	}
+	// a block signed by a quorum settles the proposal the NewView of the leader constrains
+	c.awaitingNewView = false
+
+	ids := make([]string, 0, len(block.Data.Data))
+	for _, data := range block.Data.Data {
+		id := hex.EncodeToString(util.ComputeSHA256(data))
+		delete(c.pendingRequests, id)
+		delete(c.accepted, id)
+		c.recent[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	c.recentByBlock = append(c.recentByBlock, ids)
+	if len(c.recentByBlock) > recentBlocks {
+		for _, id := range c.recentByBlock[0] {
+			delete(c.recent, id)
+		}
+		c.recentByBlock = c.recentByBlock[1:]
+	}
+
+	if protoutil.IsConfigBlock(block) {
+		if isConfigTx(block) {
+			c.lastConfigIndex = block.Header.Number
+		}
+		return c.reconfigure()
+	}
+	return false
+}
+
+// reconfigure applies the consenter set of the latest config and returns true if
+// this node is no longer a consenter.
+func (c *Chain) reconfigure() bool {
+	metadata, err := ReadConfigMetadata(c.support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		c.logger.Panicf("Failed to read the BFT metadata of the latest config: %s", err)
+	}
+
+	// requests that were valid under the previous config may be discarded by the leader
+	for id, pending := range c.pendingRequests {
+		if _, err := c.support.ProcessNormalMsg(pending.req.Payload); err != nil {
+			delete(c.pendingRequests, id)
+		}
+	}
+
+	consenters := newConsenterSet(metadata.Consenters)
+	if consenters.equal(c.consenters) {
+		return false
+	}
+	if _, exists := consenters.consenters[c.selfID]; !exists {
+		c.logger.Warningf("This node was removed from the consenter set, halting")
+		return true
+	}
+
+	c.logger.Infof("Consenter set changed from %v to %v", c.consenters.ids, consenters.ids)
+	c.consenters = consenters
+	c.progress = map[uint64]progress{}
+	if err := c.configureComm(); err != nil {
+		c.logger.Panicf("Failed to configure communication: %s", err)
+	}
+	return false
+}
+
+func (c *Chain) configureComm() error {
+	nodes, err := c.consenters.remoteNodes(c.selfID)
+	if err != nil {
+		return err
+	}
+	c.configurator.Configure(c.channelID, nodes)
+	return nil
+}
+
+// observe records the view and sequence another node is working on. If f+1 nodes,
+// hence at least one correct node, are ahead of this node, it catches up with them.
+func (c *Chain) observe(sender, view, seq uint64) bool {
+	p := c.progress[sender]
+	if view > p.view {
+		p.view = view
+	}
+	if seq > p.seq {
+		p.seq = seq
+	}
+	c.progress[sender] = p
+
+	var views, seqs []uint64
+	for _, p := range c.progress {
+		views = append(views, p.view)
+		seqs = append(seqs, p.seq)
+	}
+	if len(views) < c.consenters.f+1 {
+		return false
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i] > views[j] })
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] > seqs[j] })
+
+	if seq := seqs[c.consenters.f]; seq > c.round.seq {
+		// the blocks preceding the ones correct nodes are working on have been committed by a quorum
+		if stop := c.catchUp(seq - 1); stop {
+			return true
+		}
+		if c.viewChange != nil && c.round.seq >= seq && views[c.consenters.f] <= c.view {
+			// the leader is making progress with the other nodes, the suspicion of this node was wrong
+			c.logger.Infof("Nodes made progress in view %d, abandoning the view change to view %d", c.view, c.viewChange.nextView)
+			c.viewChange = nil
+			now := c.clock.Now()
+			for _, pending := range c.pendingRequests {
+				pending.submittedAt = now
+			}
+		}
+	}
+	if view := views[c.consenters.f]; view > c.view {
+		c.logger.Infof("Nodes moved to view %d, syncing with them", view)
+		c.installView(view)
+	}
+	return false
+}
+
+// catchUp pulls the blocks up to and including the given sequence from other nodes.
+func (c *Chain) catchUp(seq uint64) bool {
+	if seq <= c.lastBlock.Header.Number {
+		return false
+	}
+
+	puller, err := c.createPuller()
+	if err != nil {
+		c.logger.Errorf("Failed to create block puller: %s", err)
+		return false
+	}
+	defer puller.Close()
+
+	c.logger.Infof("Catching up from block [%d] to block [%d]", c.lastBlock.Header.Number+1, seq)
+	for next := c.lastBlock.Header.Number + 1; next <= seq; next++ {
+		block := puller.PullBlock(next)
+		if block == nil {
+			c.logger.Errorf("Failed to fetch block [%d] from cluster", next)
+			return false
+		}
+		if err := c.verifyPulledBlock(block); err != nil {
+			c.logger.Errorf("Failed to verify block [%d] pulled from cluster: %s", next, err)
+			return false
+		}
+
+		if stop := c.commit(block); stop {
+			return true
+		}
+	}
+	c.logger.Infof("Finished catching up to block [%d]", seq)
+
+	return c.replayBuffered()
+}
+
+// verifyPulledBlock checks that the block extends the chain and that a quorum of consenters signed it.
+func (c *Chain) verifyPulledBlock(block *common.Block) error {
+	if block.Header == nil || block.Data == nil {
+		return errors.New("block is missing the header or the data")
+	}
+	if block.Header.Number != c.lastBlock.Header.Number+1 {
+		return errors.Errorf("expected block number %d but got %d", c.lastBlock.Header.Number+1, block.Header.Number)
+	}
+	if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(c.lastBlock.Header)) {
+		return errors.New("previous hash does not match the hash of the last block")
+	}
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return errors.New("data hash does not match the hash of the block data")
+	}
+
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return err
+	}
+	signers := map[uint64]struct{}{}
+	for _, signature := range md.Signatures {
+		for _, id := range c.consenters.ids {
+			if _, signed := signers[id]; signed {
+				continue
+			}
+			if err := c.verifier.verify(c.consenters.consenters[id], block.Header, md.Value, signature); err == nil {
+				signers[id] = struct{}{}
+				break
+			}
+		}
+	}
+	if len(signers) < c.consenters.quorum {
+		return errors.Errorf("block is signed by %d consenters but %d are required", len(signers), c.consenters.quorum)
+	}
+	return nil
+}
+
+// checkTimeouts suspects the leader if it does not order the forwarded requests or if its
+// proposal is not committed in time, and moves on to the next view if a view change takes too long.
+func (c *Chain) checkTimeouts() {
+	now := c.clock.Now()
+
+	if c.viewChange != nil {
+		if now.Sub(c.viewChange.startedAt) > c.opts.ViewChangeTimeout {
+			c.logger.Warnf("View change to view %d timed out", c.viewChange.nextView)
+			c.startViewChange(c.viewChange.nextView + 1)
+		}
+		return
+	}
+
+	if c.consenters.leader(c.view) == c.selfID {
+		return
+	}
+
+	if c.round.block != nil && now.Sub(c.round.startedAt) > c.opts.RequestTimeout {
+		c.logger.Warnf("Block [%d] proposed by leader %d was not committed within %v", c.round.seq, c.consenters.leader(c.view), c.opts.RequestTimeout)
+		c.startViewChange(c.view + 1)
+		return
+	}
+
+	var relay []*orderer.SubmitRequest
+	for _, pending := range c.pendingRequests {
+		if now.Sub(pending.submittedAt) <= c.opts.RequestTimeout {
+			continue
+		}
+		if pending.relayed {
+			c.logger.Warnf("Leader %d did not order a request within %v, suspecting censorship", c.consenters.leader(c.view), c.opts.RequestTimeout)
+			c.startViewChange(c.view + 1)
+			return
+		}
+		// give the leader another chance, and let the other nodes watch the request as well
+		pending.relayed = true
+		pending.submittedAt = now
+		relay = append(relay, pending.req)
+	}
+	if len(relay) != 0 {
+		c.logger.Infof("Relaying %d requests that were not ordered within %v to all consenters", len(relay), c.opts.RequestTimeout)
+		c.relay(relay)
+	}
+}
+
+// relay sends the requests to all the other consenters.
+func (c *Chain) relay(reqs []*orderer.SubmitRequest) {
+	var dests []uint64
+	for _, id := range c.consenters.ids {
+		if id != c.selfID {
+			dests = append(dests, id)
+		}
+	}
+	go func() {
+		for _, dest := range dests {
+			for _, req := range reqs {
+				if err := c.rpc.SendSubmit(dest, req); err != nil {
+					c.logger.Debugf("Failed to relay request to %d: %s", dest, err)
+					break
+				}
+			}
+		}
+	}()
+}
+
+func (c *Chain) startViewChange(nextView uint64) {
+	if c.viewChange != nil && c.viewChange.nextView >= nextView {
+		return
+	}
+	c.logger.Infof("Starting view change to view %d", nextView)
+
+	if c.viewChange == nil && c.consenters.leader(c.view) == c.selfID {
+		c.stepDown()
+	}
+	c.viewChange = &viewChange{nextView: nextView, startedAt: c.clock.Now()}
+
+	vc := &bftmsgs.ViewChange{NextView: nextView, Sender: c.selfID}
+	if c.prepared != nil {
+		vc.PreparedView = c.prepared.PreparedView
+		vc.PreparedBlock = c.prepared.PreparedBlock
+		vc.PreparedCertificate = c.prepared.PreparedCertificate
+	}
+	signature, err := c.signMessage(viewChangeBytes(vc))
+	if err != nil {
+		c.logger.Panicf("Failed to sign view change to view %d: %s", nextView, err)
+	}
+	vc.Signature = protoutil.MarshalOrPanic(signature)
+	c.broadcast(&bftmsgs.Message{
+		Content: &bftmsgs.Message_ViewChange{ViewChange: vc},
+	})
+	c.handleViewChange(c.selfID, vc)
+}
+
+// stepDown turns the messages the leader has not proposed yet into pending requests,
+// which are forwarded to the next leader.
+func (c *Chain) stepDown() {
+	c.stopBatchTimer()
+	if pending := c.support.BlockCutter().Cut(); len(pending) != 0 {
+		c.pendingBatches = append(c.pendingBatches, &batch{envs: pending, configSeq: c.support.Sequence()})
+	}
+	c.accepted = map[string]struct{}{}
+	for _, b := range c.pendingBatches {
+		for _, env := range b.envs {
+			req := &orderer.SubmitRequest{Channel: c.channelID, LastValidationSeq: b.configSeq, Payload: env}
+			c.pendingRequests[requestID(env)] = &pendingRequest{req: req, relayed: true}
+		}
+	}
+	c.pendingBatches = nil
+}
+
+func (c *Chain) handleViewChange(sender uint64, vc *bftmsgs.ViewChange) {
+	if vc.NextView <= c.view {
+		return
+	}
+	if sender != c.selfID {
+		if vc.Sender != sender {
+			c.logger.Warnf("Discarding view change from %d, it was sent by %d", sender, vc.Sender)
+			return
+		}
+		if err := c.verifyViewChange(vc); err != nil {
+			c.logger.Warnf("Discarding view change from %d: %s", sender, err)
+			return
+		}
+	}
+
+	votes, exists := c.viewChangeVotes[vc.NextView]
+	if !exists {
+		votes = map[uint64]*bftmsgs.ViewChange{}
+		c.viewChangeVotes[vc.NextView] = votes
+	}
+	votes[sender] = vc
+
+	// f+1 nodes, hence at least one correct node, want to change the view, join them
+	myView := c.view
+	if c.viewChange != nil {
+		myView = c.viewChange.nextView
+	}
+	if vc.NextView > myView && len(votes) >= c.consenters.f+1 {
+		c.startViewChange(vc.NextView)
+		return
+	}
+
+	if len(votes) >= c.consenters.quorum {
+		c.installView(vc.NextView)
+	}
+}
+
+// verifyViewChange checks that the view change was signed by its sender, and that
+// the block it reports as prepared carries a valid prepare certificate.
+func (c *Chain) verifyViewChange(vc *bftmsgs.ViewChange) error {
+	consenter, exists := c.consenters.consenters[vc.Sender]
+	if !exists {
+		return errors.Errorf("%d is not a consenter", vc.Sender)
+	}
+	if err := c.verifier.verifyMessage(consenter, viewChangeBytes(vc), vc.Signature); err != nil {
+		return err
+	}
+	if len(vc.PreparedBlock) == 0 {
+		return nil
+	}
+
+	if vc.PreparedView >= vc.NextView {
+		return errors.Errorf("block was prepared in view %d, which is not prior to view %d", vc.PreparedView, vc.NextView)
+	}
+	block, err := protoutil.UnmarshalBlock(vc.PreparedBlock)
+	if err != nil {
+		return err
+	}
+	if block.Header == nil || block.Data == nil {
+		return errors.New("prepared block is missing the header or the data")
+	}
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return errors.New("data hash of the prepared block does not match the hash of its data")
+	}
+	digest := protoutil.BlockHeaderHash(block.Header)
+	signers := map[uint64]struct{}{}
+	for _, prepare := range vc.PreparedCertificate {
+		if prepare.View != vc.PreparedView || prepare.Seq != block.Header.Number || !bytes.Equal(prepare.Digest, digest) {
+			return errors.Errorf("prepare of %d is not for block [%d] of view %d", prepare.Sender, block.Header.Number, vc.PreparedView)
+		}
+		if err := c.verifyPrepare(prepare.Sender, prepare); err != nil {
+			return errors.WithMessagef(err, "invalid prepare of %d", prepare.Sender)
+		}
+		signers[prepare.Sender] = struct{}{}
+	}
+	if len(signers) < c.consenters.quorum {
+		return errors.Errorf("block [%d] is prepared by %d consenters but %d are required", block.Header.Number, len(signers), c.consenters.quorum)
+	}
+	return nil
+}
+
+// installView moves to the given view. The leader of the new view sends the view changes
+// of the quorum that moved to it and proposes again the block with the highest prepared view
+// they report, since it may have been committed. The other nodes wait for these view changes
+// before accepting any proposal.
+func (c *Chain) installView(view uint64) {
+	c.logger.Infof("Installing view %d, the leader is %d", view, c.consenters.leader(view))
+
+	wasLeader := c.viewChange == nil && c.consenters.leader(c.view) == c.selfID
+	if wasLeader {
+		c.stepDown()
+	}
+
+	var viewChanges []*bftmsgs.ViewChange
+	for _, id := range c.consenters.ids {
+		if vc, exists := c.viewChangeVotes[view][id]; exists {
+			viewChanges = append(viewChanges, vc)
+		}
+	}
+
+	c.view = view
+	c.viewChange = nil
+	for v := range c.viewChangeVotes {
+		if v <= view {
+			delete(c.viewChangeVotes, v)
+		}
+	}
+	c.round = newRound(view, c.lastBlock.Header.Number+1)
+	c.reproposal = nil
+	c.awaitingNewView = false
+
+	now := c.clock.Now()
+	for _, pending := range c.pendingRequests {
+		pending.submittedAt = now
+	}
+
+	if c.consenters.leader(view) != c.selfID {
+		c.awaitingNewView = true
+		c.resubmit(c.consenters.leader(view))
+		c.replayBuffered()
+		return
+	}
+
+	if len(viewChanges) < c.consenters.quorum {
+		c.logger.Warnf("Cannot lead view %d, only %d view changes were received", view, len(viewChanges))
+		c.startViewChange(view + 1)
+		return
+	}
+	c.broadcast(&bftmsgs.Message{
+		Content: &bftmsgs.Message_NewView{
+			NewView: &bftmsgs.NewView{View: view, ViewChanges: viewChanges},
+		},
+	})
+	c.reproposal = c.preparedBlock(viewChanges)
+	if c.reproposal != nil {
+		c.logger.Infof("Proposing again block [%d] prepared in a previous view", c.reproposal.Header.Number)
+		c.propose(c.reproposal)
+	}
+	pending := c.pendingRequests
+	c.pendingRequests = map[string]*pendingRequest{}
+	for _, p := range pending {
+		c.order(p.req)
+	}
+
+	c.replayBuffered()
+}
+
+// handleNewView checks the view changes the leader of a view sent, and computes from
+// them the block the leader must propose again.
+func (c *Chain) handleNewView(sender uint64, nv *bftmsgs.NewView) {
+	if nv.View < c.view || (nv.View == c.view && !c.awaitingNewView) {
+		c.logger.Debugf("Discarding new view %d from %d, which is stale", nv.View, sender)
+		return
+	}
+	if leader := c.consenters.leader(nv.View); sender != leader {
+		c.logger.Warnf("Discarding new view %d from %d, the leader of the view is %d", nv.View, sender, leader)
+		return
+	}
+
+	senders := map[uint64]struct{}{}
+	for _, vc := range nv.ViewChanges {
+		if vc.NextView != nv.View {
+			c.logger.Warnf("Discarding new view %d from %d: view change of %d is for view %d", nv.View, sender, vc.Sender, vc.NextView)
+			return
+		}
+		if err := c.verifyViewChange(vc); err != nil {
+			c.logger.Warnf("Discarding new view %d from %d: invalid view change of %d: %s", nv.View, sender, vc.Sender, err)
+			return
+		}
+		senders[vc.Sender] = struct{}{}
+	}
+	if len(senders) < c.consenters.quorum {
+		c.logger.Warnf("Discarding new view %d from %d: it carries the view changes of %d consenters but %d are required", nv.View, sender, len(senders), c.consenters.quorum)
+		return
+	}
+
+	if nv.View > c.view {
+		c.installView(nv.View)
+	}
+	c.awaitingNewView = false
+	c.reproposal = c.preparedBlock(nv.ViewChanges)
+	c.replayBuffered()
+}
+
+// preparedBlock returns the block of the next sequence with the highest prepared view
+// reported by the view changes, if any.
+func (c *Chain) preparedBlock(viewChanges []*bftmsgs.ViewChange) *common.Block {
+	var prepared *common.Block
+	var highestView uint64
+	for _, vc := range viewChanges {
+		if len(vc.PreparedBlock) == 0 {
+			continue
+		}
+		block, err := protoutil.UnmarshalBlock(vc.PreparedBlock)
+		if err != nil || block.Header == nil || block.Header.Number != c.round.seq {
+			continue
+		}
+		if prepared == nil || vc.PreparedView > highestView {
+			prepared, highestView = block, vc.PreparedView
+		}
+	}
+	if prepared == nil {
+		return nil
+	}
+	prepared.Metadata = protoutil.NewBlock(0, nil).Metadata
+	return prepared
+}
+
+// resubmit forwards the pending requests to the given leader.
+func (c *Chain) resubmit(leader uint64) {
+	var reqs []*orderer.SubmitRequest
+	for _, pending := range c.pendingRequests {
+		reqs = append(reqs, pending.req)
+	}
+	if len(reqs) == 0 {
+		return
+	}
+	go func() {
+		for _, req := range reqs {
+			if err := c.rpc.SendSubmit(leader, req); err != nil {
+				c.logger.Warnf("Failed to forward request to leader %d: %s", leader, err)
+				return
+			}
+		}
+	}()
+}
+
+func (c *Chain) broadcast(msg *bftmsgs.Message) {
+	req := &orderer.ConsensusRequest{
+		Channel: c.channelID,
+		Payload: protoutil.MarshalOrPanic(msg),
+	}
+	for _, id := range c.consenters.ids {
+		if id == c.selfID {
+			continue
+		}
+		if err := c.rpc.SendConsensus(id, req); err != nil {
+			c.logger.Debugf("Failed to send message to %d: %s", id, err)
+		}
+	}
+}
+
+func (c *Chain) isConfig(env *common.Envelope) bool {
+	h, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		c.logger.Panicf("failed to extract channel header from envelope")
+	}
+
+	return h.Type == int32(common.HeaderType_CONFIG) || h.Type == int32(common.HeaderType_ORDERER_TRANSACTION)
+}
+
+// isConfigTx returns true if the block contains a CONFIG transaction, which makes it the last config block.
+func isConfigTx(block *common.Block) bool {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return false
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return false
+	}
+	return chdr.Type == int32(common.HeaderType_CONFIG)
+}
+
+func requestID(env *common.Envelope) string {
+	hash := sha256.Sum256(protoutil.MarshalOrPanic(env))
+	return hex.EncodeToString(hash[:])
+}
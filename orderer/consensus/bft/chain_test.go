@@ -0,0 +1,504 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	bcmock "github.com/hyperledger/fabric/orderer/common/blockcutter/mock"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
+	bftmocks "github.com/hyperledger/fabric/orderer/consensus/bft/mocks"
+	"github.com/hyperledger/fabric/orderer/consensus/mocks"
+	mockblockcutter "github.com/hyperledger/fabric/orderer/mocks/common/blockcutter"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	channelID      = "testchannel"
+	requestTimeout = 10 * time.Second
+)
+
+type node struct {
+	id      uint64
+	chain   *bft.Chain
+	support *mocks.FakeConsenterSupport
+	clock   *fakeclock.FakeClock
+
+	// rejectNormalMsgs makes the node reject normal messages when set to 1
+	rejectNormalMsgs uint32
+
+	lock   sync.Mutex
+	ledger []*common.Block
+}
+
+func (n *node) height() uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return uint64(len(n.ledger))
+}
+
+func (n *node) block(number uint64) *common.Block {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if number >= uint64(len(n.ledger)) {
+		return nil
+	}
+	return n.ledger[number]
+}
+
+type network struct {
+	lock         sync.Mutex
+	nodes        map[uint64]*node
+	disconnected map[uint64]bool
+	// intercept returns the message delivered instead of the one sent, or nil to drop it
+	intercept func(from, to uint64, msg *bftmsgs.Message) *bftmsgs.Message
+}
+
+func (net *network) isConnected(from, to uint64) bool {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	return !net.disconnected[from] && !net.disconnected[to]
+}
+
+// deliver returns the consensus request delivered to the destination, or nil if it is dropped.
+func (net *network) deliver(from, to uint64, req *orderer.ConsensusRequest) *orderer.ConsensusRequest {
+	net.lock.Lock()
+	intercept := net.intercept
+	net.lock.Unlock()
+	if intercept == nil {
+		return req
+	}
+
+	msg := &bftmsgs.Message{}
+	if err := proto.Unmarshal(req.Payload, msg); err != nil {
+		return req
+	}
+	msg = intercept(from, to, msg)
+	if msg == nil {
+		return nil
+	}
+	return &orderer.ConsensusRequest{Channel: req.Channel, Payload: protoutil.MarshalOrPanic(msg)}
+}
+
+func (net *network) disconnect(id uint64) {
+	net.lock.Lock()
+	defer net.lock.Unlock()
+	net.disconnected[id] = true
+}
+
+// tick advances the clocks of all the nodes.
+func (net *network) tick(d time.Duration) {
+	for _, n := range net.nodes {
+		n.clock.Increment(d)
+	}
+}
+
+func newNetwork(t *testing.T, size int) *network {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	var consenters []*bftmsgs.Consenter
+	keyPairs := map[uint64]*tlsgen.CertKeyPair{}
+	for id := uint64(1); id <= uint64(size); id++ {
+		kp, err := ca.NewServerCertKeyPair(fmt.Sprintf("orderer%d", id))
+		require.NoError(t, err)
+		keyPairs[id] = kp
+		consenters = append(consenters, &bftmsgs.Consenter{
+			Id:            id,
+			Host:          fmt.Sprintf("orderer%d", id),
+			Port:          7050,
+			ClientTlsCert: kp.Cert,
+			ServerTlsCert: kp.Cert,
+			MspId:         fmt.Sprintf("Org%dMSP", id),
+			Identity:      kp.Cert,
+		})
+	}
+	metadata := protoutil.MarshalOrPanic(&bftmsgs.ConfigMetadata{Consenters: consenters})
+
+	genesis := protoutil.NewBlock(0, nil)
+	genesis.Data.Data = [][]byte{[]byte("genesis")}
+	genesis.Header.DataHash = protoutil.BlockDataHash(genesis.Data)
+
+	net := &network{
+		nodes:        map[uint64]*node{},
+		disconnected: map[uint64]bool{},
+	}
+	for id := uint64(1); id <= uint64(size); id++ {
+		n := &node{
+			id:     id,
+			clock:  fakeclock.NewFakeClock(time.Now()),
+			ledger: []*common.Block{genesis},
+		}
+		n.support = newSupport(t, n, keyPairs[id], consenters[id-1].MspId, metadata)
+
+		rpc := &bftmocks.RPC{}
+		from := id
+		rpc.SendConsensusStub = func(dest uint64, req *orderer.ConsensusRequest) error {
+			if !net.isConnected(from, dest) {
+				return errors.Errorf("node %d is unreachable", dest)
+			}
+			if req = net.deliver(from, dest, req); req != nil {
+				go net.nodes[dest].chain.Consensus(req, from)
+			}
+			return nil
+		}
+		rpc.SendSubmitStub = func(dest uint64, req *orderer.SubmitRequest) error {
+			if !net.isConnected(from, dest) {
+				return errors.Errorf("node %d is unreachable", dest)
+			}
+			go net.nodes[dest].chain.Submit(req, from)
+			return nil
+		}
+
+		puller := &bftmocks.BlockPuller{}
+		puller.PullBlockStub = func(seq uint64) *common.Block {
+			for _, other := range net.nodes {
+				if other.id != from && net.isConnected(from, other.id) {
+					if block := other.block(seq); block != nil {
+						return proto.Clone(block).(*common.Block)
+					}
+				}
+			}
+			return nil
+		}
+
+		n.chain, err = bft.NewChain(
+			n.support,
+			bft.Options{
+				SelfID:            id,
+				Consenters:        consenters,
+				BlockMetadata:     &bftmsgs.BlockMetadata{},
+				RequestTimeout:    requestTimeout,
+				ViewChangeTimeout: 2 * requestTimeout,
+				Clock:             n.clock,
+				Logger:            flogging.MustGetLogger("orderer.consensus.bft.test"),
+			},
+			&bftmocks.Configurator{},
+			rpc,
+			cryptoProvider,
+			func() (bft.BlockPuller, error) { return puller, nil },
+		)
+		require.NoError(t, err)
+		net.nodes[id] = n
+	}
+
+	for _, n := range net.nodes {
+		n.chain.Start()
+	}
+	t.Cleanup(func() {
+		for _, n := range net.nodes {
+			n.chain.Halt()
+		}
+	})
+	return net
+}
+
+func newSupport(t *testing.T, n *node, kp *tlsgen.CertKeyPair, mspID string, consensusMetadata []byte) *mocks.FakeConsenterSupport {
+	ordererConfig := &bcmock.OrdererConfig{}
+	ordererConfig.BatchSizeReturns(&orderer.BatchSize{MaxMessageCount: 1, AbsoluteMaxBytes: 10 * 1024 * 1024, PreferredMaxBytes: 10 * 1024 * 1024})
+	ordererConfig.BatchTimeoutReturns(time.Hour)
+	ordererConfig.ConsensusMetadataReturns(consensusMetadata)
+	blockCutter := mockblockcutter.NewReceiver()
+	blockCutter.CutNext = true
+	close(blockCutter.Block)
+
+	creator := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID, IdBytes: kp.Cert})
+
+	support := &mocks.FakeConsenterSupport{}
+	support.ChannelIDReturns(channelID)
+	support.SharedConfigReturns(ordererConfig)
+	support.BlockCutterReturns(blockCutter)
+	support.HeightStub = n.height
+	support.BlockStub = n.block
+	support.SerializeReturns(creator, nil)
+	support.SignStub = func(msg []byte) ([]byte, error) {
+		digest := sha256.Sum256(msg)
+		signature, err := kp.Signer.Sign(rand.Reader, digest[:], nil)
+		if err != nil {
+			return nil, err
+		}
+		return utils.SignatureToLowS(kp.Signer.Public().(*ecdsa.PublicKey), signature)
+	}
+	support.ProcessNormalMsgStub = func(*common.Envelope) (uint64, error) {
+		if atomic.LoadUint32(&n.rejectNormalMsgs) == 1 {
+			return 0, errors.New("bad transaction")
+		}
+		return 0, nil
+	}
+	support.WriteSignedBlockStub = func(block *common.Block) {
+		n.lock.Lock()
+		defer n.lock.Unlock()
+		require.Equal(t, uint64(len(n.ledger)), block.Header.Number)
+		n.ledger = append(n.ledger, block)
+	}
+	return support
+}
+
+func envelope(data string) *common.Envelope {
+	return &common.Envelope{
+		Payload: protoutil.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+					Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: channelID,
+				}),
+			},
+			Data: []byte(data),
+		}),
+	}
+}
+
+func blockView(t *testing.T, block *common.Block) uint64 {
+	ordererBlockMetadata, err := protoutil.GetConsenterMetadataFromBlock(block)
+	require.NoError(t, err)
+	blockMetadata, err := bft.ReadBlockMetadata(ordererBlockMetadata)
+	require.NoError(t, err)
+	return blockMetadata.View
+}
+
+func requireSignedByQuorum(t *testing.T, block *common.Block) {
+	md, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	require.NoError(t, err)
+	signers := map[string]struct{}{}
+	for _, signature := range md.Signatures {
+		sigHdr, err := protoutil.UnmarshalSignatureHeader(signature.SignatureHeader)
+		require.NoError(t, err)
+		signers[string(sigHdr.Creator)] = struct{}{}
+	}
+	require.GreaterOrEqual(t, len(signers), 3)
+}
+
+func TestOrder(t *testing.T) {
+	net := newNetwork(t, 4)
+
+	// the request is forwarded by the follower to the leader of view 0
+	require.NoError(t, net.nodes[3].chain.Order(envelope("tx1"), 0))
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx2"), 0))
+
+	for _, n := range net.nodes {
+		require.Eventually(t, func() bool { return n.height() == 3 }, 10*time.Second, 10*time.Millisecond)
+	}
+
+	for number := uint64(1); number <= 2; number++ {
+		block := net.nodes[1].block(number)
+		requireSignedByQuorum(t, block)
+		require.Equal(t, uint64(0), blockView(t, block))
+		for _, n := range net.nodes {
+			require.Equal(t, protoutil.BlockHeaderHash(block.Header), protoutil.BlockHeaderHash(n.block(number).Header))
+		}
+	}
+}
+
+func TestLeaderCrash(t *testing.T) {
+	net := newNetwork(t, 4)
+
+	net.disconnect(1)
+	require.NoError(t, net.nodes[2].chain.Order(envelope("tx1"), 0))
+
+	// the followers suspect the leader once the request is not ordered in time
+	require.Eventually(t, func() bool {
+		net.tick(requestTimeout / 2)
+		return net.nodes[2].height() == 2 && net.nodes[3].height() == 2 && net.nodes[4].height() == 2
+	}, 10*time.Second, 50*time.Millisecond)
+
+	block := net.nodes[3].block(1)
+	requireSignedByQuorum(t, block)
+	require.Equal(t, uint64(1), blockView(t, block))
+	require.Equal(t, uint64(1), net.nodes[1].height())
+}
+
+func TestInvalidProposal(t *testing.T) {
+	net := newNetwork(t, 4)
+
+	for _, id := range []uint64{2, 3, 4} {
+		atomic.StoreUint32(&net.nodes[id].rejectNormalMsgs, 1)
+	}
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx1"), 0))
+
+	// the followers reject the proposal and replace the leader
+	require.Never(t, func() bool { return net.nodes[1].height() != 1 }, time.Second, 10*time.Millisecond)
+
+	for _, id := range []uint64{2, 3, 4} {
+		atomic.StoreUint32(&net.nodes[id].rejectNormalMsgs, 0)
+	}
+	require.NoError(t, net.nodes[3].chain.Order(envelope("tx2"), 0))
+	for _, n := range net.nodes {
+		require.Eventually(t, func() bool { return n.height() == 2 }, 10*time.Second, 10*time.Millisecond)
+	}
+
+	block := net.nodes[1].block(1)
+	require.Equal(t, uint64(1), blockView(t, block))
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(envelope("tx2"), env))
+}
+
+func TestReproposal(t *testing.T) {
+	net := newNetwork(t, 4)
+
+	genesis := net.nodes[1].block(0)
+	forged := protoutil.NewBlock(1, protoutil.BlockHeaderHash(genesis.Header))
+	forged.Data.Data = [][]byte{protoutil.MarshalOrPanic(envelope("tx2"))}
+	forged.Header.DataHash = protoutil.BlockDataHash(forged.Data)
+
+	var forgedProposals uint32
+	net.lock.Lock()
+	net.intercept = func(from, to uint64, msg *bftmsgs.Message) *bftmsgs.Message {
+		// the block of view 0 is prepared but not committed
+		if commit := msg.GetCommit(); commit != nil && commit.View == 0 {
+			return nil
+		}
+		// the leader of view 1 proposes another block instead of the prepared one
+		if prePrepare := msg.GetPrePrepare(); prePrepare != nil && prePrepare.View == 1 {
+			atomic.AddUint32(&forgedProposals, 1)
+			return &bftmsgs.Message{
+				Content: &bftmsgs.Message_PrePrepare{
+					PrePrepare: &bftmsgs.PrePrepare{View: 1, Block: protoutil.MarshalOrPanic(forged)},
+				},
+			}
+		}
+		return msg
+	}
+	net.lock.Unlock()
+
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx1"), 0))
+
+	// the followers reject the forged proposal and the leader of view 2 proposes the prepared block again
+	require.Eventually(t, func() bool {
+		net.tick(requestTimeout / 2)
+		for _, n := range net.nodes {
+			if n.height() != 2 {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 50*time.Millisecond)
+
+	require.NotZero(t, atomic.LoadUint32(&forgedProposals))
+	for _, n := range net.nodes {
+		block := n.block(1)
+		require.Equal(t, uint64(2), blockView(t, block))
+		env, err := protoutil.ExtractEnvelope(block, 0)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(envelope("tx1"), env))
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	net := newNetwork(t, 4)
+
+	net.disconnect(4)
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx1"), 0))
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx2"), 0))
+	for _, id := range []uint64{1, 2, 3} {
+		n := net.nodes[id]
+		require.Eventually(t, func() bool { return n.height() == 3 }, 10*time.Second, 10*time.Millisecond)
+	}
+	require.Equal(t, uint64(1), net.nodes[4].height())
+
+	net.lock.Lock()
+	delete(net.disconnected, 4)
+	net.lock.Unlock()
+
+	// the lagging node pulls the blocks it missed once it learns the others moved on
+	require.NoError(t, net.nodes[1].chain.Order(envelope("tx3"), 0))
+	for _, n := range net.nodes {
+		require.Eventually(t, func() bool { return n.height() == 4 }, 10*time.Second, 10*time.Millisecond)
+	}
+	requireSignedByQuorum(t, net.nodes[4].block(1))
+}
+
+func TestValidateConsensusMetadata(t *testing.T) {
+	net := newNetwork(t, 4)
+	chain := net.nodes[1].chain
+
+	metadata := net.nodes[1].support.SharedConfig().ConsensusMetadata()
+	require.NoError(t, chain.ValidateConsensusMetadata(metadata, metadata, false))
+	require.NoError(t, chain.ValidateConsensusMetadata(nil, metadata, true))
+	require.EqualError(t, chain.ValidateConsensusMetadata(metadata, nil, false), "missing new metadata")
+	require.EqualError(t, chain.ValidateConsensusMetadata(nil, metadata, false), "invalid old config metadata: empty consenter set")
+
+	m := &bftmsgs.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(metadata, m))
+	m.Consenters[1].Id = m.Consenters[0].Id
+	require.EqualError(t, chain.ValidateConsensusMetadata(metadata, protoutil.MarshalOrPanic(m), false), "invalid new config metadata: duplicate consenter id 1")
+}
+
+func TestHandleChainBadOptions(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	kp, err := ca.NewServerCertKeyPair("orderer1")
+	require.NoError(t, err)
+
+	consenters := []*bftmsgs.Consenter{{
+		Id:            1,
+		Host:          "orderer1",
+		Port:          7050,
+		ClientTlsCert: kp.Cert,
+		ServerTlsCert: kp.Cert,
+		MspId:         "Org1MSP",
+		Identity:      kp.Cert,
+	}}
+	consenter := &bft.Consenter{
+		Cert:   kp.Cert,
+		Logger: flogging.MustGetLogger("orderer.consensus.bft.test"),
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		options       *bftmsgs.Options
+		expectedError string
+	}{
+		{
+			name:          "bad request timeout",
+			options:       &bftmsgs.Options{RequestTimeout: "ten seconds"},
+			expectedError: "failed to parse RequestTimeout (ten seconds) to time duration: ",
+		},
+		{
+			name:          "bad view change timeout",
+			options:       &bftmsgs.Options{ViewChangeTimeout: "20"},
+			expectedError: "failed to parse ViewChangeTimeout (20) to time duration: ",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ordererConfig := &bcmock.OrdererConfig{}
+			ordererConfig.ConsensusMetadataReturns(protoutil.MarshalOrPanic(&bftmsgs.ConfigMetadata{
+				Consenters: consenters,
+				Options:    testCase.options,
+			}))
+			support := &mocks.FakeConsenterSupport{}
+			support.ChannelIDReturns(channelID)
+			support.SharedConfigReturns(ordererConfig)
+
+			chain, err := consenter.HandleChain(support, nil)
+			require.Nil(t, chain)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), testCase.expectedError)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Consenter implements the BFT consenter.
+// It shares the cluster communication layer with the etcdraft consenter,
+// which dispatches the messages of BFT channels to the BFT chains.
+type Consenter struct {
+	Dialer        *cluster.PredicateDialer
+	Communication cluster.Communicator
+	OrdererConfig localconfig.TopLevel
+	Cert          []byte
+	Logger        *flogging.FabricLogger
+	BCCSP         bccsp.BCCSP
+}
+
+// New creates a BFT Consenter that communicates through the given communicator.
+func New(
+	clusterDialer *cluster.PredicateDialer,
+	communication cluster.Communicator,
+	conf *localconfig.TopLevel,
+	cert []byte,
+	bccsp bccsp.BCCSP,
+) *Consenter {
+	return &Consenter{
+		Dialer:        clusterDialer,
+		Communication: communication,
+		OrdererConfig: *conf,
+		Cert:          cert,
+		Logger:        flogging.MustGetLogger("orderer.consensus.bft"),
+		BCCSP:         bccsp,
+	}
+}
+
+// HandleChain returns a new Chain instance or an error upon failure
+func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *common.Metadata) (consensus.Chain, error) {
+	m, err := ReadConfigMetadata(support.SharedConfig().ConsensusMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := detectSelfID(m.Consenters, c.Cert)
+	if err != nil {
+		if err == cluster.ErrNotInChannel {
+			c.Logger.Warningf("This node is not a consenter of channel %s", support.ChannelID())
+			return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChannelID())}, nil
+		}
+		return nil, err
+	}
+
+	blockMetadata, err := ReadBlockMetadata(metadata)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read BFT metadata")
+	}
+
+	requestTimeout, viewChangeTimeout := DefaultRequestTimeout, DefaultViewChangeTimeout
+	if m.Options != nil && m.Options.RequestTimeout != "" {
+		if requestTimeout, err = time.ParseDuration(m.Options.RequestTimeout); err != nil {
+			return nil, errors.Wrap(err, "failed to parse RequestTimeout value in BFT options")
+		}
+	}
+	if m.Options != nil && m.Options.ViewChangeTimeout != "" {
+		if viewChangeTimeout, err = time.ParseDuration(m.Options.ViewChangeTimeout); err != nil {
+			return nil, errors.Wrap(err, "failed to parse ViewChangeTimeout value in BFT options")
+		}
+	}
+
+	opts := Options{
+		SelfID:            id,
+		Consenters:        m.Consenters,
+		BlockMetadata:     blockMetadata,
+		RequestTimeout:    requestTimeout,
+		ViewChangeTimeout: viewChangeTimeout,
+		Clock:             clock.NewClock(),
+		Logger:            c.Logger,
+	}
+
+	rpc := &cluster.RPC{
+		Timeout:       c.OrdererConfig.General.Cluster.RPCTimeout,
+		Logger:        c.Logger,
+		Channel:       support.ChannelID(),
+		Comm:          c.Communication,
+		StreamsByType: cluster.NewStreamsByType(),
+	}
+
+	return NewChain(
+		support,
+		opts,
+		c.Communication,
+		rpc,
+		c.BCCSP,
+		func() (BlockPuller, error) {
+			return etcdraft.NewBlockPuller(support, c.Dialer, c.OrdererConfig.General.Cluster, c.BCCSP)
+		},
+	)
+}
+
+// JoinChain is not supported by the BFT consenter.
+func (c *Consenter) JoinChain(support consensus.ConsenterSupport, joinBlock *common.Block) (consensus.Chain, error) {
+	return nil, errors.New("the BFT orderer does not support JoinChain")
+}
+
+// IsChannelMember returns true if this node is a consenter of the channel defined by the join block.
+func (c *Consenter) IsChannelMember(joinBlock *common.Block) (bool, error) {
+	if joinBlock == nil {
+		return false, errors.New("nil block")
+	}
+	envelopeConfig, err := protoutil.ExtractEnvelope(joinBlock, 0)
+	if err != nil {
+		return false, err
+	}
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelopeConfig, c.BCCSP)
+	if err != nil {
+		return false, err
+	}
+	oc, exists := bundle.OrdererConfig()
+	if !exists {
+		return false, errors.New("no orderer config in bundle")
+	}
+	configMetadata, err := ReadConfigMetadata(oc.ConsensusMetadata())
+	if err != nil {
+		return false, err
+	}
+
+	for _, consenter := range configMetadata.Consenters {
+		if bytes.Equal(c.Cert, consenter.ServerTlsCert) || bytes.Equal(c.Cert, consenter.ClientTlsCert) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
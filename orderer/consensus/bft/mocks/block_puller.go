@@ -0,0 +1,140 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/orderer/consensus/bft"
+)
+
+type BlockPuller struct {
+	CloseStub        func()
+	closeMutex       sync.RWMutex
+	closeArgsForCall []struct {
+	}
+	PullBlockStub        func(uint64) *common.Block
+	pullBlockMutex       sync.RWMutex
+	pullBlockArgsForCall []struct {
+		arg1 uint64
+	}
+	pullBlockReturns struct {
+		result1 *common.Block
+	}
+	pullBlockReturnsOnCall map[int]struct {
+		result1 *common.Block
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BlockPuller) Close() {
+	fake.closeMutex.Lock()
+	fake.closeArgsForCall = append(fake.closeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Close", []interface{}{})
+	fake.closeMutex.Unlock()
+	if fake.CloseStub != nil {
+		fake.CloseStub()
+	}
+}
+
+func (fake *BlockPuller) CloseCallCount() int {
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	return len(fake.closeArgsForCall)
+}
+
+func (fake *BlockPuller) CloseCalls(stub func()) {
+	fake.closeMutex.Lock()
+	defer fake.closeMutex.Unlock()
+	fake.CloseStub = stub
+}
+
+func (fake *BlockPuller) PullBlock(arg1 uint64) *common.Block {
+	fake.pullBlockMutex.Lock()
+	ret, specificReturn := fake.pullBlockReturnsOnCall[len(fake.pullBlockArgsForCall)]
+	fake.pullBlockArgsForCall = append(fake.pullBlockArgsForCall, struct {
+		arg1 uint64
+	}{arg1})
+	fake.recordInvocation("PullBlock", []interface{}{arg1})
+	fake.pullBlockMutex.Unlock()
+	if fake.PullBlockStub != nil {
+		return fake.PullBlockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pullBlockReturns
+	return fakeReturns.result1
+}
+
+func (fake *BlockPuller) PullBlockCallCount() int {
+	fake.pullBlockMutex.RLock()
+	defer fake.pullBlockMutex.RUnlock()
+	return len(fake.pullBlockArgsForCall)
+}
+
+func (fake *BlockPuller) PullBlockCalls(stub func(uint64) *common.Block) {
+	fake.pullBlockMutex.Lock()
+	defer fake.pullBlockMutex.Unlock()
+	fake.PullBlockStub = stub
+}
+
+func (fake *BlockPuller) PullBlockArgsForCall(i int) uint64 {
+	fake.pullBlockMutex.RLock()
+	defer fake.pullBlockMutex.RUnlock()
+	argsForCall := fake.pullBlockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BlockPuller) PullBlockReturns(result1 *common.Block) {
+	fake.pullBlockMutex.Lock()
+	defer fake.pullBlockMutex.Unlock()
+	fake.PullBlockStub = nil
+	fake.pullBlockReturns = struct {
+		result1 *common.Block
+	}{result1}
+}
+
+func (fake *BlockPuller) PullBlockReturnsOnCall(i int, result1 *common.Block) {
+	fake.pullBlockMutex.Lock()
+	defer fake.pullBlockMutex.Unlock()
+	fake.PullBlockStub = nil
+	if fake.pullBlockReturnsOnCall == nil {
+		fake.pullBlockReturnsOnCall = make(map[int]struct {
+			result1 *common.Block
+		})
+	}
+	fake.pullBlockReturnsOnCall[i] = struct {
+		result1 *common.Block
+	}{result1}
+}
+
+func (fake *BlockPuller) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.closeMutex.RLock()
+	defer fake.closeMutex.RUnlock()
+	fake.pullBlockMutex.RLock()
+	defer fake.pullBlockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BlockPuller) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ bft.BlockPuller = new(BlockPuller)
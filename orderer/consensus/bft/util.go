@@ -0,0 +1,280 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bft
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/consensus/bft/bftmsgs"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRequestTimeout is used when the request timeout is not provided in the channel config options
+	DefaultRequestTimeout = 10 * time.Second
+
+	// DefaultViewChangeTimeout is used when the view change timeout is not provided in the channel config options
+	DefaultViewChangeTimeout = 20 * time.Second
+)
+
+// ReadConfigMetadata unmarshals and validates the BFT metadata of the consensus type.
+func ReadConfigMetadata(metadata []byte) (*bftmsgs.ConfigMetadata, error) {
+	m := &bftmsgs.ConfigMetadata{}
+	if err := proto.Unmarshal(metadata, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus metadata")
+	}
+	if err := CheckConfigMetadata(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CheckConfigMetadata validates the BFT config metadata.
+func CheckConfigMetadata(metadata *bftmsgs.ConfigMetadata) error {
+	if metadata == nil {
+		return errors.New("nil BFT config metadata")
+	}
+	if len(metadata.Consenters) == 0 {
+		return errors.New("empty consenter set")
+	}
+
+	ids := map[uint64]struct{}{}
+	for _, consenter := range metadata.Consenters {
+		if consenter == nil {
+			return errors.New("nil consenter")
+		}
+		if consenter.Id == 0 {
+			return errors.Errorf("consenter %s:%d has an invalid id 0", consenter.Host, consenter.Port)
+		}
+		if _, exists := ids[consenter.Id]; exists {
+			return errors.Errorf("duplicate consenter id %d", consenter.Id)
+		}
+		ids[consenter.Id] = struct{}{}
+		if consenter.MspId == "" {
+			return errors.Errorf("consenter %d has no MSP ID", consenter.Id)
+		}
+		for certType, cert := range map[string][]byte{
+			"server TLS": consenter.ServerTlsCert,
+			"client TLS": consenter.ClientTlsCert,
+			"identity":   consenter.Identity,
+		} {
+			if _, err := pemToCert(cert); err != nil {
+				return errors.WithMessagef(err, "invalid %s certificate of consenter %d", certType, consenter.Id)
+			}
+		}
+	}
+
+	if metadata.Options == nil {
+		return nil
+	}
+	for name, duration := range map[string]string{
+		"RequestTimeout":    metadata.Options.RequestTimeout,
+		"ViewChangeTimeout": metadata.Options.ViewChangeTimeout,
+	} {
+		if duration == "" {
+			continue
+		}
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s (%s) to time duration", name, duration)
+		}
+		if d <= 0 {
+			return errors.Errorf("%s (%s) must be positive", name, duration)
+		}
+	}
+	return nil
+}
+
+// ReadBlockMetadata reads the BFT metadata from the consenter metadata of a block.
+// It returns metadata for the first view if there is no prior metadata, e.g. for a new chain.
+func ReadBlockMetadata(metadata *common.Metadata) (*bftmsgs.BlockMetadata, error) {
+	m := &bftmsgs.BlockMetadata{}
+	if metadata == nil || len(metadata.Value) == 0 {
+		return m, nil
+	}
+	if err := proto.Unmarshal(metadata.Value, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block's metadata")
+	}
+	return m, nil
+}
+
+// consenterSet is an immutable view of the consenters of a channel, ordered by their identifiers.
+type consenterSet struct {
+	ids        []uint64
+	consenters map[uint64]*bftmsgs.Consenter
+	quorum     int
+	f          int
+}
+
+func newConsenterSet(consenters []*bftmsgs.Consenter) *consenterSet {
+	cs := &consenterSet{
+		consenters: map[uint64]*bftmsgs.Consenter{},
+	}
+	for _, consenter := range consenters {
+		cs.ids = append(cs.ids, consenter.Id)
+		cs.consenters[consenter.Id] = consenter
+	}
+	sort.Slice(cs.ids, func(i, j int) bool { return cs.ids[i] < cs.ids[j] })
+	cs.quorum, cs.f = bftmsgs.ComputeQuorum(len(cs.ids))
+	return cs
+}
+
+// leader returns the identifier of the leader of the given view.
+func (cs *consenterSet) leader(view uint64) uint64 {
+	return cs.ids[view%uint64(len(cs.ids))]
+}
+
+func (cs *consenterSet) remoteNodes(selfID uint64) ([]cluster.RemoteNode, error) {
+	var nodes []cluster.RemoteNode
+	for _, id := range cs.ids {
+		if id == selfID {
+			continue
+		}
+		consenter := cs.consenters[id]
+		serverCert, err := pemToCert(consenter.ServerTlsCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid server TLS certificate of consenter %d", id)
+		}
+		clientCert, err := pemToCert(consenter.ClientTlsCert)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid client TLS certificate of consenter %d", id)
+		}
+		nodes = append(nodes, cluster.RemoteNode{
+			ID:            id,
+			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			ServerTLSCert: serverCert.Raw,
+			ClientTLSCert: clientCert.Raw,
+		})
+	}
+	return nodes, nil
+}
+
+func (cs *consenterSet) equal(other *consenterSet) bool {
+	if len(cs.ids) != len(other.ids) {
+		return false
+	}
+	for _, id := range cs.ids {
+		otherConsenter, exists := other.consenters[id]
+		if !exists || !proto.Equal(cs.consenters[id], otherConsenter) {
+			return false
+		}
+	}
+	return true
+}
+
+// detectSelfID returns the identifier of the consenter whose server TLS certificate is the given one.
+func detectSelfID(consenters []*bftmsgs.Consenter, serverCert []byte) (uint64, error) {
+	cert, err := pemToCert(serverCert)
+	if err != nil {
+		return 0, errors.WithMessage(err, "invalid server TLS certificate")
+	}
+	for _, consenter := range consenters {
+		consenterCert, err := pemToCert(consenter.ServerTlsCert)
+		if err != nil {
+			return 0, errors.WithMessagef(err, "invalid server TLS certificate of consenter %d", consenter.Id)
+		}
+		if bytes.Equal(cert.Raw, consenterCert.Raw) {
+			return consenter.Id, nil
+		}
+	}
+	return 0, cluster.ErrNotInChannel
+}
+
+func pemToCert(pemBytes []byte) (*x509.Certificate, error) {
+	bl, _ := pem.Decode(pemBytes)
+	if bl == nil {
+		return nil, errors.New("invalid PEM block")
+	}
+	return x509.ParseCertificate(bl.Bytes)
+}
+
+// signatureVerifier verifies the signatures of the consenters over blocks.
+type signatureVerifier struct {
+	bccsp bccsp.BCCSP
+}
+
+// verify checks that the given signature over the block was created by the given consenter.
+func (v *signatureVerifier) verify(consenter *bftmsgs.Consenter, header *common.BlockHeader, ordererBlockMetadata []byte, signature *common.MetadataSignature) error {
+	return v.verifySignature(consenter, util.ConcatenateBytes(ordererBlockMetadata, signature.SignatureHeader, protoutil.BlockHeaderBytes(header)), signature)
+}
+
+// verifyMessage checks that the given marshaled signature over the message was created by the given consenter.
+func (v *signatureVerifier) verifyMessage(consenter *bftmsgs.Consenter, msg []byte, signatureBytes []byte) error {
+	signature := &common.MetadataSignature{}
+	if err := proto.Unmarshal(signatureBytes, signature); err != nil {
+		return errors.Wrap(err, "failed to unmarshal signature")
+	}
+	return v.verifySignature(consenter, util.ConcatenateBytes(signature.SignatureHeader, msg), signature)
+}
+
+func (v *signatureVerifier) verifySignature(consenter *bftmsgs.Consenter, signedData []byte, signature *common.MetadataSignature) error {
+	sigHdr, err := protoutil.UnmarshalSignatureHeader(signature.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	creator := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(sigHdr.Creator, creator); err != nil {
+		return errors.Wrap(err, "failed to unmarshal creator of signature")
+	}
+	if creator.Mspid != consenter.MspId || !bytes.Equal(creator.IdBytes, consenter.Identity) {
+		return errors.Errorf("signature was not created by the identity of consenter %d", consenter.Id)
+	}
+
+	cert, err := pemToCert(consenter.Identity)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid identity of consenter %d", consenter.Id)
+	}
+	key, err := v.bccsp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return errors.WithMessagef(err, "failed to import public key of consenter %d", consenter.Id)
+	}
+	digest, err := v.bccsp.Hash(signedData, &bccsp.SHA256Opts{})
+	if err != nil {
+		return err
+	}
+	valid, err := v.bccsp.Verify(key, signature.Signature, digest, nil)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to verify signature of consenter %d", consenter.Id)
+	}
+	if !valid {
+		return errors.Errorf("invalid signature of consenter %d", consenter.Id)
+	}
+	return nil
+}
+
+// prepareBytes returns the marshaled prepare without its signature, which is what its sender signs.
+func prepareBytes(prepare *bftmsgs.Prepare) []byte {
+	return protoutil.MarshalOrPanic(&bftmsgs.Prepare{
+		View:   prepare.View,
+		Seq:    prepare.Seq,
+		Digest: prepare.Digest,
+		Sender: prepare.Sender,
+	})
+}
+
+// viewChangeBytes returns the marshaled view change without its signature, which is what its sender signs.
+func viewChangeBytes(vc *bftmsgs.ViewChange) []byte {
+	return protoutil.MarshalOrPanic(&bftmsgs.ViewChange{
+		NextView:            vc.NextView,
+		PreparedView:        vc.PreparedView,
+		PreparedBlock:       vc.PreparedBlock,
+		PreparedCertificate: vc.PreparedCertificate,
+		Sender:              vc.Sender,
+	})
+}
@@ -128,6 +128,10 @@ type ConsenterSupport interface {
 	// WriteConfigBlock commits a block to the ledger, and applies the config update inside.
 	WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte)
 
+	// WriteSignedBlock commits a block that already carries the signatures of the consenters
+	// that ordered it to the ledger, and applies the config update inside, if any.
+	WriteSignedBlock(block *cb.Block)
+
 	// Sequence returns the current config sequence.
 	Sequence() uint64
 
@@ -22,6 +22,8 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protoutil"
@@ -58,6 +60,19 @@ const (
 	// DefaultLeaderlessCheckInterval is the interval that a chain checks
 	// its own leadership status.
 	DefaultLeaderlessCheckInterval = time.Second * 10
+
+	// DefaultSnapshotChunkSize is the default size of the blocks packed
+	// in a chunk of a snapshot transfer, before compression.
+	DefaultSnapshotChunkSize = 1 * MEGABYTE
+
+	// DefaultSnapshotWindow is the default number of chunks a follower
+	// requests at once during a snapshot transfer.
+	DefaultSnapshotWindow = uint32(8)
+
+	// DefaultSnapshotTransferTimeout is the default time a follower waits
+	// for the next chunk of a snapshot transfer, before it pulls the
+	// remaining blocks from the cluster instead.
+	DefaultSnapshotTransferTimeout = time.Second * 30
 )
 
 //go:generate counterfeiter -o mocks/configurator.go . Configurator
@@ -74,6 +89,8 @@ type Configurator interface {
 type RPC interface {
 	SendConsensus(dest uint64, msg *orderer.ConsensusRequest) error
 	SendSubmit(dest uint64, request *orderer.SubmitRequest) error
+	SendSnapshotRequest(dest uint64, request *clusterpb.SnapshotRequest) error
+	SendSnapshotChunk(dest uint64, chunk *clusterpb.SnapshotChunk) error
 }
 
 //go:generate counterfeiter -o mocks/mock_blockpuller.go . BlockPuller
@@ -125,6 +142,18 @@ type Options struct {
 
 	EvictionSuspicion   time.Duration
 	LeaderCheckInterval time.Duration
+
+	// FairShare enables the scheduling of the normal transactions submitted
+	// concurrently by the organizations, in proportion to FairShareWeights.
+	FairShare        bool
+	FairShareWeights map[string]uint32
+
+	// SnapshotTransfer enables the leader to stream the blocks of a snapshot
+	// to the followers catching up with it, in compressed chunks.
+	SnapshotTransfer        bool
+	SnapshotChunkSize       uint32
+	SnapshotWindow          uint32
+	SnapshotTransferTimeout time.Duration
 }
 
 type submit struct {
@@ -150,14 +179,15 @@ type Chain struct {
 	lastKnownLeader uint64
 	ActiveNodes     atomic.Value
 
-	submitC  chan *submit
-	applyC   chan apply
-	observeC chan<- raft.SoftState // Notifies external observer on leader change (passed in optionally as an argument for tests)
-	haltC    chan struct{}         // Signals to goroutines that the chain is halting
-	doneC    chan struct{}         // Closes when the chain halts
-	startC   chan struct{}         // Closes when the node is started
-	snapC    chan *raftpb.Snapshot // Signal to catch up with snapshot
-	gcC      chan *gc              // Signal to take snapshot
+	submitC         chan *submit
+	prioritySubmitC chan *submit // Submits config transactions ahead of the normal ones
+	applyC          chan apply
+	observeC        chan<- raft.SoftState // Notifies external observer on leader change (passed in optionally as an argument for tests)
+	haltC           chan struct{}         // Signals to goroutines that the chain is halting
+	doneC           chan struct{}         // Closes when the chain halts
+	startC          chan struct{}         // Closes when the node is started
+	snapC           chan *raftpb.Snapshot // Signal to catch up with snapshot
+	gcC             chan *gc              // Signal to take snapshot
 
 	errorCLock sync.RWMutex
 	errorC     chan struct{} // returned by Errored()
@@ -194,6 +224,9 @@ type Chain struct {
 
 	periodicChecker *PeriodicCheck
 
+	fairShare        *fairShare        // nil unless Options.FairShare is set
+	snapshotTransfer *snapshotTransfer // nil unless Options.SnapshotTransfer is set
+
 	haltCallback func()
 	// BCCSP instane
 	CryptoProvider bccsp.BCCSP
@@ -250,6 +283,7 @@ func NewChain(
 		channelID:        support.ChannelID(),
 		raftID:           opts.RaftID,
 		submitC:          make(chan *submit),
+		prioritySubmitC:  make(chan *submit),
 		applyC:           make(chan apply),
 		haltC:            make(chan struct{}),
 		doneC:            make(chan struct{}),
@@ -292,6 +326,14 @@ func NewChain(
 	c.Metrics.CommittedBlockNumber.Set(float64(c.lastBlock.Header.Number))
 	c.Metrics.SnapshotBlockNumber.Set(float64(c.lastSnapBlockNum))
 
+	if c.opts.FairShare {
+		c.fairShare = newFairShare(c.opts.FairShareWeights)
+	}
+
+	if c.opts.SnapshotTransfer {
+		c.snapshotTransfer = newSnapshotTransfer(c.opts.SnapshotChunkSize, c.opts.SnapshotWindow, c.opts.SnapshotTransferTimeout)
+	}
+
 	// DO NOT use Applied option in config, see https://github.com/etcd-io/etcd/issues/10217
 	// We guard against replay of written blocks with `appliedIndex` instead.
 	config := &raft.Config{
@@ -490,15 +532,29 @@ func (c *Chain) Consensus(req *orderer.ConsensusRequest, sender uint64) error {
 // - the local run goroutine if this is leader
 // - the actual leader via the transport mechanism
 // The call fails if there's no leader elected yet.
+// Config transactions are submitted in the priority lane, ahead of the normal
+// transactions, which are scheduled fairly between organizations if enabled.
 func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
 	if err := c.isRunning(); err != nil {
 		c.Metrics.ProposalFailures.Add(1)
 		return err
 	}
 
+	submitC, release := c.submitC, func() {}
+	if c.lane(req.Payload) == msgprocessor.PriorityLane {
+		submitC = c.prioritySubmitC
+	} else if c.fairShare != nil {
+		if !c.fairShare.acquire(creatorMSPID(req.Payload), c.doneC) {
+			c.Metrics.ProposalFailures.Add(1)
+			return errors.Errorf("chain is stopped")
+		}
+		release = c.fairShare.release
+	}
+
 	leadC := make(chan uint64, 1)
 	select {
-	case c.submitC <- &submit{req, leadC}:
+	case submitC <- &submit{req, leadC}:
+		release()
 		lead := <-leadC
 		if lead == raft.None {
 			c.Metrics.ProposalFailures.Add(1)
@@ -513,6 +569,7 @@ func (c *Chain) Submit(req *orderer.SubmitRequest, sender uint64) error {
 		}
 
 	case <-c.doneC:
+		release()
 		c.Metrics.ProposalFailures.Add(1)
 		return errors.Errorf("chain is stopped")
 	}
@@ -555,7 +612,7 @@ func (c *Chain) run() {
 	}
 
 	var soft raft.SoftState
-	submitC := c.submitC
+	submitC, prioritySubmitC := c.submitC, c.prioritySubmitC
 	var bc *blockCreator
 
 	var propC chan<- *common.Block
@@ -567,8 +624,10 @@ func (c *Chain) run() {
 
 		c.blockInflight = 0
 		c.justElected = true
-		submitC = nil
-		ch := make(chan *common.Block, c.opts.MaxInflightBlocks)
+		submitC, prioritySubmitC = nil, nil
+		// a config transaction may still be ordered once the limit of in-flight blocks
+		// is reached, and cut both the pending batch and the config block
+		ch := make(chan *common.Block, c.opts.MaxInflightBlocks+2)
 
 		// if there is unfinished ConfChange, we should resume the effort to propose it as
 		// new leader, and wait for it to be committed before start serving new requests.
@@ -614,50 +673,65 @@ func (c *Chain) run() {
 		c.blockInflight = 0
 		_ = c.support.BlockCutter().Cut()
 		stopTimer()
-		submitC = c.submitC
+		submitC, prioritySubmitC = c.submitC, c.prioritySubmitC
 		bc = nil
 		c.Metrics.IsLeader.Set(0)
 	}
 
-	for {
-		select {
-		case s := <-submitC:
-			if s == nil {
-				// polled by `WaitReady`
-				continue
-			}
+	serve := func(s *submit) {
+		if s == nil {
+			// polled by `WaitReady`
+			return
+		}
 
-			if soft.RaftState == raft.StatePreCandidate || soft.RaftState == raft.StateCandidate {
-				s.leader <- raft.None
-				continue
-			}
+		if soft.RaftState == raft.StatePreCandidate || soft.RaftState == raft.StateCandidate {
+			s.leader <- raft.None
+			return
+		}
 
-			s.leader <- soft.Lead
-			if soft.Lead != c.raftID {
-				continue
-			}
+		s.leader <- soft.Lead
+		if soft.Lead != c.raftID {
+			return
+		}
 
-			batches, pending, err := c.ordered(s.req)
-			if err != nil {
-				c.logger.Errorf("Failed to order message: %s", err)
-				continue
-			}
-			if pending {
-				startTimer() // no-op if timer is already started
-			} else {
-				stopTimer()
-			}
+		batches, pending, err := c.ordered(s.req)
+		if err != nil {
+			c.logger.Errorf("Failed to order message: %s", err)
+			return
+		}
+		if pending {
+			startTimer() // no-op if timer is already started
+		} else {
+			stopTimer()
+		}
 
-			c.propose(propC, bc, batches...)
+		c.propose(propC, bc, batches...)
 
-			if c.configInflight {
-				c.logger.Info("Received config transaction, pause accepting transaction till it is committed")
-				submitC = nil
-			} else if c.blockInflight >= c.opts.MaxInflightBlocks {
-				c.logger.Debugf("Number of in-flight blocks (%d) reaches limit (%d), pause accepting transaction",
-					c.blockInflight, c.opts.MaxInflightBlocks)
-				submitC = nil
-			}
+		if c.configInflight {
+			c.logger.Info("Received config transaction, pause accepting transaction till it is committed")
+			submitC, prioritySubmitC = nil, nil
+		} else if c.blockInflight >= c.opts.MaxInflightBlocks {
+			c.logger.Debugf("Number of in-flight blocks (%d) reaches limit (%d), pause accepting normal transaction",
+				c.blockInflight, c.opts.MaxInflightBlocks)
+			submitC = nil
+		}
+	}
+
+	for {
+		// config transactions bypass the normal transactions waiting to be submitted
+		select {
+		case s := <-prioritySubmitC:
+			serve(s)
+			continue
+		default:
+		}
+
+		select {
+		case s := <-prioritySubmitC:
+			serve(s)
+
+		case s := <-submitC:
+			serve(s)
 
 		case app := <-c.applyC:
 			if app.soft != nil {
@@ -731,13 +805,16 @@ func (c *Chain) run() {
 					number: c.lastBlock.Header.Number,
 					logger: c.logger,
 				}
-				submitC = c.submitC
+				submitC, prioritySubmitC = c.submitC, c.prioritySubmitC
 				c.justElected = false
 			} else if c.configInflight {
 				c.logger.Info("Config block or ConfChange in flight, pause accepting transaction")
-				submitC = nil
-			} else if c.blockInflight < c.opts.MaxInflightBlocks {
-				submitC = c.submitC
+				submitC, prioritySubmitC = nil, nil
+			} else {
+				prioritySubmitC = c.prioritySubmitC
+				if c.blockInflight < c.opts.MaxInflightBlocks {
+					submitC = c.submitC
+				}
 			}
 
 		case <-timer.C():
@@ -817,9 +894,11 @@ func (c *Chain) writeBlock(block *common.Block, index uint64) {
 
 // Orders the envelope in the `msg` content. SubmitRequest.
 // Returns
-//   -- batches [][]*common.Envelope; the batches cut,
-//   -- pending bool; if there are envelopes pending to be ordered,
-//   -- err error; the error encountered, if any.
+//
+//	-- batches [][]*common.Envelope; the batches cut,
+//	-- pending bool; if there are envelopes pending to be ordered,
+//	-- err error; the error encountered, if any.
+//
 // It takes care of config messages as well as the revalidation of messages if the config sequence has advanced.
 func (c *Chain) ordered(msg *orderer.SubmitRequest) (batches [][]*common.Envelope, pending bool, err error) {
 	seq := c.support.Sequence()
@@ -887,6 +966,16 @@ func (c *Chain) catchUp(snap *raftpb.Snapshot) error {
 		return nil
 	}
 
+	c.logger.Infof("Catching up with snapshot taken at block [%d], starting from block [%d]", b.Header.Number, c.lastBlock.Header.Number+1)
+
+	if c.snapshotTransfer != nil {
+		c.fetchSnapshot(b)
+		if c.lastBlock.Header.Number >= b.Header.Number {
+			c.logger.Infof("Finished syncing with leader up to and including block [%d]", b.Header.Number)
+			return nil
+		}
+	}
+
 	puller, err := c.createPuller()
 	if err != nil {
 		return errors.Errorf("failed to create block puller: %s", err)
@@ -895,40 +984,43 @@ func (c *Chain) catchUp(snap *raftpb.Snapshot) error {
 
 	next := c.lastBlock.Header.Number + 1
 
-	c.logger.Infof("Catching up with snapshot taken at block [%d], starting from block [%d]", b.Header.Number, next)
-
 	for next <= b.Header.Number {
 		block := puller.PullBlock(next)
 		if block == nil {
 			return errors.Errorf("failed to fetch block [%d] from cluster", next)
 		}
-		if protoutil.IsConfigBlock(block) {
-			c.support.WriteConfigBlock(block, nil)
+		c.writeCatchUpBlock(block)
+		next++
+	}
+
+	c.logger.Infof("Finished syncing with cluster up to and including block [%d]", b.Header.Number)
+	return nil
+}
 
-			configMembership := c.detectConfChange(block)
+// writeCatchUpBlock writes a block fetched while catching up with a snapshot.
+func (c *Chain) writeCatchUpBlock(block *common.Block) {
+	if protoutil.IsConfigBlock(block) {
+		c.support.WriteConfigBlock(block, nil)
 
-			if configMembership != nil && configMembership.Changed() {
-				c.logger.Infof("Config block [%d] changes consenter set, communication should be reconfigured", block.Header.Number)
+		configMembership := c.detectConfChange(block)
 
-				c.raftMetadataLock.Lock()
-				c.opts.BlockMetadata = configMembership.NewBlockMetadata
-				c.opts.Consenters = configMembership.NewConsenters
-				c.raftMetadataLock.Unlock()
+		if configMembership != nil && configMembership.Changed() {
+			c.logger.Infof("Config block [%d] changes consenter set, communication should be reconfigured", block.Header.Number)
 
-				if err := c.configureComm(); err != nil {
-					c.logger.Panicf("Failed to configure communication: %s", err)
-				}
+			c.raftMetadataLock.Lock()
+			c.opts.BlockMetadata = configMembership.NewBlockMetadata
+			c.opts.Consenters = configMembership.NewConsenters
+			c.raftMetadataLock.Unlock()
+
+			if err := c.configureComm(); err != nil {
+				c.logger.Panicf("Failed to configure communication: %s", err)
 			}
-		} else {
-			c.support.WriteBlock(block, nil)
 		}
-
-		c.lastBlock = block
-		next++
+	} else {
+		c.support.WriteBlock(block, nil)
 	}
 
-	c.logger.Infof("Finished syncing with cluster up to and including block [%d]", b.Header.Number)
-	return nil
+	c.lastBlock = block
 }
 
 func (c *Chain) detectConfChange(block *common.Block) *MembershipChanges {
@@ -1091,6 +1183,15 @@ func (c *Chain) isConfig(env *common.Envelope) bool {
 	return h.Type == int32(common.HeaderType_CONFIG) || h.Type == int32(common.HeaderType_ORDERER_TRANSACTION)
 }
 
+// lane returns the lane of the envelope, which the broadcast handler has
+// classified either as a config message or as a normal message.
+func (c *Chain) lane(env *common.Envelope) msgprocessor.Lane {
+	if c.isConfig(env) {
+		return msgprocessor.ConfigMsg.Lane()
+	}
+	return msgprocessor.NormalMsg.Lane()
+}
+
 func (c *Chain) configureComm() error {
 	// Reset unreachable map when communication is reconfigured
 	c.Node.unreachableLock.Lock()
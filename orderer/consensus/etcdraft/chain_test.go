@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	orderer_types "github.com/hyperledger/fabric/orderer/common/types"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
@@ -318,6 +319,31 @@ var _ = Describe("Chain", func() {
 				Expect(fakeFields.fakeProposalFailures.AddArgsForCall(0)).To(Equal(float64(1)))
 			})
 
+			Context("when fair share is enabled", func() {
+				BeforeEach(func() {
+					opts.FairShare = true
+					opts.FairShareWeights = map[string]uint32{"SampleOrg": 2}
+				})
+
+				It("orders the envelopes submitted concurrently", func() {
+					close(cutter.Block)
+					cutter.CutNext = true
+
+					var wg sync.WaitGroup
+					for i := 0; i < 5; i++ {
+						wg.Add(1)
+						go func() {
+							defer GinkgoRecover()
+							defer wg.Done()
+							Expect(chain.Order(env, 0)).To(Succeed())
+						}()
+					}
+					wg.Wait()
+
+					Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(5))
+				})
+			})
+
 			It("produces blocks following batch rules", func() {
 				close(cutter.Block)
 
@@ -2508,6 +2534,56 @@ var _ = Describe("Chain", func() {
 					})
 				})
 
+				It("orders config transactions ahead of the waiting normal transactions", func() {
+					configEnv := newConfigEnv(channelID,
+						common.HeaderType_CONFIG,
+						newConfigUpdateEnv(channelID, nil, map[string]*common.ConfigValue{
+							"BatchTimeout": {
+								Version: 1,
+								Value: marshalOrPanic(&orderer.BatchTimeout{
+									Timeout: "3ms",
+								}),
+							},
+						}),
+					)
+
+					c1.cutter.CutNext = true
+					// disconnect c1 to disrupt consensus
+					network.disconnect(1)
+
+					Expect(c1.Order(env, 0)).To(Succeed())
+
+					doneProp := make(chan struct{})
+					go func() {
+						defer GinkgoRecover()
+						Expect(c1.Order(env, 0)).To(Succeed())
+						close(doneProp)
+					}()
+					// expect second `Order` to block
+					Consistently(doneProp).ShouldNot(BeClosed())
+
+					By("submitting a config transaction in the priority lane")
+					doneConfig := make(chan struct{})
+					go func() {
+						defer GinkgoRecover()
+						Expect(c1.Configure(configEnv, 0)).To(Succeed())
+						close(doneConfig)
+					}()
+					Eventually(doneConfig, LongEventualTimeout).Should(BeClosed())
+					Consistently(doneProp).ShouldNot(BeClosed())
+
+					network.connect(1)
+					c1.clock.Increment(interval)
+
+					Eventually(doneProp, LongEventualTimeout).Should(BeClosed())
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, LongEventualTimeout).Should(Equal(1))
+						Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(2))
+						block, _ := c.support.WriteConfigBlockArgsForCall(0)
+						Expect(block.Header.Number).To(Equal(uint64(2)))
+					})
+				})
+
 				It("resets block in flight when steps down from leader", func() {
 					c1.cutter.CutNext = true
 					c2.cutter.CutNext = true
@@ -2959,6 +3035,64 @@ var _ = Describe("Chain", func() {
 							Eventually(func() int { return c.support.WriteBlockCallCount() }, LongEventualTimeout).Should(Equal(blockCnt + 1))
 						})
 				})
+
+				When("snapshot transfer is enabled", func() {
+					BeforeEach(func() {
+						network.exec(func(c *chain) {
+							c.opts.SnapshotTransfer = true
+							c.opts.SnapshotChunkSize = 1 // a block per chunk
+							c.opts.SnapshotWindow = 1    // a request per chunk
+						})
+					})
+
+					It("lagged node can catch up using the blocks streamed by the leader", func() {
+						network.disconnect(2)
+						c1.cutter.CutNext = true
+
+						c2Lasti, _ := c2.opts.MemoryStorage.LastIndex()
+						var blockCnt int
+						// Order blocks until first index of c1 memory is greater than last index of c2,
+						// so a snapshot will be sent to c2 when it rejoins network
+						Eventually(func() bool {
+							c1Firsti, _ := c1.opts.MemoryStorage.FirstIndex()
+							if c1Firsti > c2Lasti+1 {
+								return true
+							}
+
+							Expect(c1.Order(env, 0)).To(Succeed())
+							blockCnt++
+							Eventually(c1.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(blockCnt))
+							Eventually(c3.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(blockCnt))
+							return false
+						}, LongEventualTimeout).Should(BeTrue())
+
+						Eventually(c2.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(0))
+
+						network.join(2, false)
+
+						Eventually(c2.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(blockCnt))
+						Expect(c2.rpc.SendSnapshotRequestCallCount()).To(BeNumerically(">", 0))
+						Expect(c1.rpc.SendSnapshotChunkCallCount()).To(Equal(c2.rpc.SendSnapshotRequestCallCount()))
+
+						dest, req := c2.rpc.SendSnapshotRequestArgsForCall(0)
+						Expect(dest).To(Equal(uint64(1)))
+						Expect(req.Start).To(Equal(uint64(1)))
+						Expect(req.Window).To(Equal(uint32(1)))
+
+						for i := 0; i < c2.support.WriteBlockCallCount(); i++ {
+							block, _ := c2.support.WriteBlockArgsForCall(i)
+							Expect(block.Header.Number).To(Equal(uint64(i + 1)))
+						}
+
+						// chain should keeps functioning
+						Expect(c2.Order(env, 0)).To(Succeed())
+
+						network.exec(
+							func(c *chain) {
+								Eventually(func() int { return c.support.WriteBlockCallCount() }, LongEventualTimeout).Should(Equal(blockCnt + 1))
+							})
+					})
+				})
 			})
 
 			Context("failover", func() {
@@ -3542,6 +3676,39 @@ func (n *network) addChain(c *chain) {
 		return nil
 	}
 
+	// snapshot messages are delivered in order, as over a stream
+	c.rpc.SendSnapshotRequestStub = func(dest uint64, msg *clusterpb.SnapshotRequest) error {
+		if !n.linked(c.id, dest) {
+			return errors.Errorf("connection refused")
+		}
+
+		if !n.connected(c.id) || !n.connected(dest) {
+			return errors.Errorf("connection lost")
+		}
+
+		n.RLock()
+		target := n.chains[dest]
+		n.RUnlock()
+		target.SnapshotRequest(msg, c.id)
+		return nil
+	}
+
+	c.rpc.SendSnapshotChunkStub = func(dest uint64, msg *clusterpb.SnapshotChunk) error {
+		if !n.linked(c.id, dest) {
+			return errors.Errorf("connection refused")
+		}
+
+		if !n.connected(c.id) || !n.connected(dest) {
+			return errors.Errorf("connection lost")
+		}
+
+		n.RLock()
+		target := n.chains[dest]
+		n.RUnlock()
+		target.SnapshotChunk(msg, c.id)
+		return nil
+	}
+
 	c.puller.PullBlockStub = func(i uint64) *common.Block {
 		n.RLock()
 		leaderChain := n.chains[n.leader]
@@ -3685,9 +3852,9 @@ func (n *network) exec(f func(c *chain), ids ...uint64) {
 //
 // expectLeaderChange controls whether leader change should
 // be observed on newly joined node.
-// - it should be true if newly joined node was leader
-// - it should be false if newly joined node was follower, and
-//   already knows the leader.
+//   - it should be true if newly joined node was leader
+//   - it should be false if newly joined node was follower, and
+//     already knows the leader.
 func (n *network) join(id uint64, expectLeaderChange bool) {
 	n.connect(id)
 
@@ -8,12 +8,13 @@ package etcdraft
 
 import (
 	"bytes"
-	"github.com/hyperledger/fabric/common/channelconfig"
-	"github.com/hyperledger/fabric/protoutil"
 	"path"
 	"reflect"
 	"time"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/protoutil"
+
 	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -24,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus"
@@ -54,9 +56,35 @@ type ChainGetter interface {
 
 // Config contains etcdraft configurations
 type Config struct {
-	WALDir            string // WAL data of <my-channel> is stored in WALDir/<my-channel>
-	SnapDir           string // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
-	EvictionSuspicion string // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	WALDir            string           // WAL data of <my-channel> is stored in WALDir/<my-channel>
+	SnapDir           string           // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
+	EvictionSuspicion string           // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	FairShare         FairShare        // Scheduling of the normal transactions submitted concurrently by the organizations.
+	SnapshotTransfer  SnapshotTransfer // Streaming of the blocks of snapshots from the leader to the followers catching up.
+}
+
+// SnapshotTransfer configures the streaming of the blocks of a snapshot from the leader
+// to the followers catching up with it. Unset values default to DefaultSnapshotChunkSize,
+// DefaultSnapshotWindow and DefaultSnapshotTransferTimeout.
+type SnapshotTransfer struct {
+	Enabled   bool
+	ChunkSize uint32 // Size of the blocks packed in a chunk, before compression.
+	Window    uint32 // Number of chunks a follower requests at once.
+	Timeout   string // Duration a follower waits for a chunk before it pulls the remaining blocks from the cluster.
+}
+
+// FairShare configures the scheduling of the normal transactions submitted concurrently
+// by the organizations, in proportion to their weights.
+type FairShare struct {
+	Enabled bool
+	Weights []FairShareWeight // Organizations without a weight have a weight of 1.
+}
+
+// FairShareWeight is the weight of an organization. The MSP IDs are listed rather than
+// used as keys, as the keys of the local config are not case sensitive.
+type FairShareWeight struct {
+	MSPID  string
+	Weight uint32
 }
 
 // Consenter implements etcdraft consenter
@@ -83,6 +111,10 @@ func (c *Consenter) TargetChannel(message proto.Message) string {
 		return req.Channel
 	case *orderer.SubmitRequest:
 		return req.Channel
+	case *clusterpb.SnapshotRequest:
+		return req.Channel
+	case *clusterpb.SnapshotChunk:
+		return req.Channel
 	default:
 		return ""
 	}
@@ -98,10 +130,11 @@ func (c *Consenter) ReceiverByChain(channelID string) MessageReceiver {
 	if cs.Chain == nil {
 		c.Logger.Panicf("Programming error - Chain %s is nil although it exists in the mapping", channelID)
 	}
-	if etcdRaftChain, isEtcdRaftChain := cs.Chain.(*Chain); isEtcdRaftChain {
-		return etcdRaftChain
+	// Other cluster based chains (e.g. BFT) share the communication layer of etcdraft
+	if receiver, isMessageReceiver := cs.Chain.(MessageReceiver); isMessageReceiver {
+		return receiver
 	}
-	c.Logger.Warningf("Chain %s is of type %v and not etcdraft.Chain", channelID, reflect.TypeOf(cs.Chain))
+	c.Logger.Warningf("Chain %s is of type %v and not a MessageReceiver", channelID, reflect.TypeOf(cs.Chain))
 	return nil
 }
 
@@ -182,6 +215,14 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		}
 	}
 
+	var snapshotTransferTimeout time.Duration
+	if c.EtcdRaftConfig.SnapshotTransfer.Timeout != "" {
+		snapshotTransferTimeout, err = time.ParseDuration(c.EtcdRaftConfig.SnapshotTransfer.Timeout)
+		if err != nil {
+			c.Logger.Panicf("Failed parsing Consensus.SnapshotTransfer.Timeout: %s: %v", c.EtcdRaftConfig.SnapshotTransfer.Timeout, err)
+		}
+	}
+
 	tickInterval, err := time.ParseDuration(m.Options.TickInterval)
 	if err != nil {
 		return nil, errors.Errorf("failed to parse TickInterval (%s) to time duration", m.Options.TickInterval)
@@ -210,6 +251,14 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		EvictionSuspicion: evictionSuspicion,
 		Cert:              c.Cert,
 		Metrics:           c.Metrics,
+
+		FairShare:        c.EtcdRaftConfig.FairShare.Enabled,
+		FairShareWeights: fairShareWeights(c.EtcdRaftConfig.FairShare.Weights),
+
+		SnapshotTransfer:        c.EtcdRaftConfig.SnapshotTransfer.Enabled,
+		SnapshotChunkSize:       c.EtcdRaftConfig.SnapshotTransfer.ChunkSize,
+		SnapshotWindow:          c.EtcdRaftConfig.SnapshotTransfer.Window,
+		SnapshotTransferTimeout: snapshotTransferTimeout,
 	}
 
 	rpc := &cluster.RPC{
@@ -9,14 +9,15 @@ package etcdraft_test
 import (
 	"encoding/pem"
 	"fmt"
-	"github.com/hyperledger/fabric/core/config/configtest"
-	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
-	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
 	"io/ioutil"
 	"os"
 	"path"
 	"strings"
 
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	clustermocks "github.com/hyperledger/fabric/orderer/common/cluster/mocks"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
@@ -114,6 +116,13 @@ var _ = Describe("Consenter", func() {
 			ch := consenter.TargetChannel(&orderer.SubmitRequest{Channel: "mychannel"})
 			Expect(ch).To(BeIdenticalTo("mychannel"))
 		})
+		It("extracts successfully from snapshot transfers", func() {
+			consenter := newConsenter(chainGetter)
+			ch := consenter.TargetChannel(&clusterpb.SnapshotRequest{Channel: "mychannel"})
+			Expect(ch).To(BeIdenticalTo("mychannel"))
+			ch = consenter.TargetChannel(&clusterpb.SnapshotChunk{Channel: "mychannel"})
+			Expect(ch).To(BeIdenticalTo("mychannel"))
+		})
 		It("returns an empty string for the rest of the messages", func() {
 			consenter := newConsenter(chainGetter)
 			ch := consenter.TargetChannel(&common.Block{})
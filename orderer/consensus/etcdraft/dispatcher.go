@@ -9,6 +9,7 @@ package etcdraft
 import (
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/pkg/errors"
 )
 
@@ -23,6 +24,17 @@ type MessageReceiver interface {
 	Submit(req *orderer.SubmitRequest, sender uint64) error
 }
 
+//go:generate mockery -dir . -name SnapshotReceiver -case underscore -output mocks
+
+// SnapshotReceiver receives the messages of snapshot transfers
+type SnapshotReceiver interface {
+	// SnapshotRequest passes the given SnapshotRequest message to the SnapshotReceiver
+	SnapshotRequest(req *clusterpb.SnapshotRequest, sender uint64) error
+
+	// SnapshotChunk passes the given SnapshotChunk message to the SnapshotReceiver
+	SnapshotChunk(chunk *clusterpb.SnapshotChunk, sender uint64) error
+}
+
 //go:generate mockery -dir . -name ReceiverGetter -case underscore -output mocks
 
 // ReceiverGetter obtains instances of MessageReceiver given a channel ID
@@ -56,3 +68,34 @@ func (d *Dispatcher) OnSubmit(channel string, sender uint64, request *orderer.Su
 	}
 	return receiver.Submit(request, sender)
 }
+
+// OnSnapshotRequest notifies the Dispatcher for a reception of a SnapshotRequest from a given sender on a given channel
+func (d *Dispatcher) OnSnapshotRequest(channel string, sender uint64, request *clusterpb.SnapshotRequest) error {
+	receiver, err := d.snapshotReceiver(channel, sender)
+	if err != nil {
+		return err
+	}
+	return receiver.SnapshotRequest(request, sender)
+}
+
+// OnSnapshotChunk notifies the Dispatcher for a reception of a SnapshotChunk from a given sender on a given channel
+func (d *Dispatcher) OnSnapshotChunk(channel string, sender uint64, chunk *clusterpb.SnapshotChunk) error {
+	receiver, err := d.snapshotReceiver(channel, sender)
+	if err != nil {
+		return err
+	}
+	return receiver.SnapshotChunk(chunk, sender)
+}
+
+func (d *Dispatcher) snapshotReceiver(channel string, sender uint64) (SnapshotReceiver, error) {
+	receiver := d.ChainSelector.ReceiverByChain(channel)
+	if receiver == nil {
+		d.Logger.Warningf("An attempt to transfer a snapshot on a non existing channel (%s) was made by %d", channel, sender)
+		return nil, errors.Errorf("channel %s doesn't exist", channel)
+	}
+	snapshotReceiver, isSnapshotReceiver := receiver.(SnapshotReceiver)
+	if !isSnapshotReceiver {
+		return nil, errors.Errorf("channel %s doesn't support snapshot transfer", channel)
+	}
+	return snapshotReceiver, nil
+}
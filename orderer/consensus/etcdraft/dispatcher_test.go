@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
 	"github.com/pkg/errors"
@@ -71,3 +72,45 @@ func TestDispatchSubmit(t *testing.T) {
 		require.EqualError(t, err, "channel notmychannel doesn't exist")
 	})
 }
+
+func TestDispatchSnapshot(t *testing.T) {
+	expectedRequest := &clusterpb.SnapshotRequest{
+		Channel: "ignored value",
+	}
+
+	expectedChunk := &clusterpb.SnapshotChunk{
+		Channel: "ignored value",
+	}
+
+	sr := &mocks.SnapshotReceiver{}
+	sr.On("SnapshotRequest", expectedRequest, uint64(1)).Return(nil).Once()
+	sr.On("SnapshotChunk", expectedChunk, uint64(1)).Return(errors.New("backend error")).Once()
+
+	rg := &mocks.ReceiverGetter{}
+	rg.On("ReceiverByChain", "mychannel").Return(&struct {
+		*mocks.MessageReceiver
+		*mocks.SnapshotReceiver
+	}{&mocks.MessageReceiver{}, sr}).Twice()
+	rg.On("ReceiverByChain", "notmychannel").Return(nil).Once()
+	rg.On("ReceiverByChain", "bftchannel").Return(&mocks.MessageReceiver{}).Once()
+
+	disp := &etcdraft.Dispatcher{ChainSelector: rg, Logger: flogging.MustGetLogger("test")}
+
+	t.Run("Channel exists", func(t *testing.T) {
+		err := disp.OnSnapshotRequest("mychannel", 1, expectedRequest)
+		require.NoError(t, err)
+
+		err = disp.OnSnapshotChunk("mychannel", 1, expectedChunk)
+		require.EqualError(t, err, "backend error")
+	})
+
+	t.Run("Channel does not exist", func(t *testing.T) {
+		err := disp.OnSnapshotRequest("notmychannel", 1, expectedRequest)
+		require.EqualError(t, err, "channel notmychannel doesn't exist")
+	})
+
+	t.Run("Channel does not support snapshot transfer", func(t *testing.T) {
+		err := disp.OnSnapshotChunk("bftchannel", 1, expectedChunk)
+		require.EqualError(t, err, "channel bftchannel doesn't support snapshot transfer")
+	})
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// fairShare schedules the normal transactions submitted concurrently to the chain. While
+// the chain is congested, the organizations take turns to submit, each for as many
+// transactions as its weight, so that the organizations submitting the most transactions
+// do not crowd out the others. An uncongested chain serves the transactions as they come.
+type fairShare struct {
+	weights map[string]uint32
+
+	mutex  sync.Mutex
+	busy   bool                       // true while a transaction holds the turn
+	queues map[string][]chan struct{} // transactions waiting for their turn by MSP ID
+	ring   []string                   // organizations with waiting transactions, in round-robin order
+	served uint32                     // transactions of ring[0] served in its current turn
+}
+
+// newFairShare creates a fairShare with the given weights by MSP ID.
+// Organizations without a weight have a weight of 1.
+func newFairShare(weights map[string]uint32) *fairShare {
+	return &fairShare{
+		weights: weights,
+		queues:  map[string][]chan struct{}{},
+	}
+}
+
+// fairShareWeights returns the configured weights by MSP ID.
+func fairShareWeights(weights []FairShareWeight) map[string]uint32 {
+	byMSPID := map[string]uint32{}
+	for _, w := range weights {
+		byMSPID[w.MSPID] = w.Weight
+	}
+	return byMSPID
+}
+
+// acquire blocks until it is the turn of a transaction of the organization, and returns
+// true, or until the done channel closes, and returns false. The turn must be released
+// once the transaction has been submitted.
+func (f *fairShare) acquire(mspID string, doneC <-chan struct{}) bool {
+	f.mutex.Lock()
+	if !f.busy {
+		f.busy = true
+		f.mutex.Unlock()
+		return true
+	}
+
+	turnC := make(chan struct{})
+	if len(f.queues[mspID]) == 0 {
+		f.ring = append(f.ring, mspID)
+	}
+	f.queues[mspID] = append(f.queues[mspID], turnC)
+	f.mutex.Unlock()
+
+	select {
+	case <-turnC:
+		return true
+	case <-doneC:
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	select {
+	case <-turnC:
+		// the turn was handed over concurrently, pass it on
+		f.handOver()
+	default:
+		f.remove(mspID, turnC)
+	}
+	return false
+}
+
+// release hands the turn over to the next transaction.
+func (f *fairShare) release() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.handOver()
+}
+
+func (f *fairShare) handOver() {
+	if len(f.ring) == 0 {
+		f.busy = false
+		return
+	}
+
+	mspID := f.ring[0]
+	queue := f.queues[mspID]
+	turnC := queue[0]
+	f.served++
+
+	switch {
+	case len(queue) == 1:
+		delete(f.queues, mspID)
+		f.ring = f.ring[1:]
+		f.served = 0
+	case f.served >= f.weight(mspID):
+		f.queues[mspID] = queue[1:]
+		f.ring = append(f.ring[1:], mspID)
+		f.served = 0
+	default:
+		f.queues[mspID] = queue[1:]
+	}
+
+	close(turnC)
+}
+
+func (f *fairShare) remove(mspID string, turnC chan struct{}) {
+	queue := f.queues[mspID]
+	for i, c := range queue {
+		if c != turnC {
+			continue
+		}
+		f.queues[mspID] = append(queue[:i:i], queue[i+1:]...)
+		break
+	}
+	if len(f.queues[mspID]) != 0 {
+		return
+	}
+
+	delete(f.queues, mspID)
+	for i, id := range f.ring {
+		if id != mspID {
+			continue
+		}
+		f.ring = append(f.ring[:i:i], f.ring[i+1:]...)
+		if i == 0 {
+			f.served = 0
+		}
+		break
+	}
+}
+
+func (f *fairShare) weight(mspID string) uint32 {
+	if w, ok := f.weights[mspID]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// creatorMSPID returns the MSP ID of the creator of the envelope, or an empty
+// string if the envelope is malformed.
+func creatorMSPID(env *common.Envelope) string {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	shdr, err := protoutil.UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return ""
+	}
+	identity, err := protoutil.UnmarshalSerializedIdentity(shdr.Creator)
+	if err != nil {
+		return ""
+	}
+	return identity.Mspid
+}
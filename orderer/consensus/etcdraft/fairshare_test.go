@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShare(t *testing.T) {
+	doneC := make(chan struct{})
+	f := newFairShare(map[string]uint32{"Org1MSP": 2})
+
+	// the first transaction takes the turn right away
+	require.True(t, f.acquire("Org1MSP", doneC))
+
+	var order []string
+	served := make(chan string)
+	wait := func(mspID string) {
+		n := len(f.queues[mspID])
+		go func() {
+			require.True(t, f.acquire(mspID, doneC))
+			served <- mspID
+		}()
+		require.Eventually(t, func() bool {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			return len(f.queues[mspID]) == n+1
+		}, time.Minute, time.Millisecond)
+	}
+
+	// Org1MSP floods the chain before Org2MSP and Org3MSP submit
+	for i := 0; i < 5; i++ {
+		wait("Org1MSP")
+	}
+	wait("Org2MSP")
+	wait("Org2MSP")
+	wait("Org3MSP")
+	require.Equal(t, []string{"Org1MSP", "Org2MSP", "Org3MSP"}, f.ring)
+
+	for i := 0; i < 8; i++ {
+		f.release()
+		order = append(order, <-served)
+	}
+	require.Equal(t, []string{
+		"Org1MSP", "Org1MSP",
+		"Org2MSP",
+		"Org3MSP",
+		"Org1MSP", "Org1MSP",
+		"Org2MSP",
+		"Org1MSP",
+	}, order)
+
+	f.release()
+	require.False(t, f.busy)
+	require.Empty(t, f.queues)
+	require.Empty(t, f.ring)
+}
+
+func TestFairShareDone(t *testing.T) {
+	doneC := make(chan struct{})
+	f := newFairShare(nil)
+
+	require.True(t, f.acquire("Org1MSP", doneC))
+
+	result := make(chan bool)
+	for i := 0; i < 2; i++ {
+		go func() {
+			result <- f.acquire("Org2MSP", doneC)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		return len(f.queues["Org2MSP"]) == 2
+	}, time.Minute, time.Millisecond)
+
+	close(doneC)
+	require.False(t, <-result)
+	require.False(t, <-result)
+	require.Empty(t, f.queues)
+	require.Empty(t, f.ring)
+
+	f.release()
+	require.False(t, f.busy)
+}
+
+func TestFairShareWeights(t *testing.T) {
+	weights := fairShareWeights([]FairShareWeight{
+		{MSPID: "Org1MSP", Weight: 3},
+		{MSPID: "Org2MSP"},
+	})
+	require.Equal(t, map[string]uint32{"Org1MSP": 3, "Org2MSP": 0}, weights)
+
+	f := newFairShare(weights)
+	require.Equal(t, uint32(3), f.weight("Org1MSP"))
+	require.Equal(t, uint32(1), f.weight("Org2MSP"))
+	require.Equal(t, uint32(1), f.weight("Org3MSP"))
+}
+
+func TestCreatorMSPID(t *testing.T) {
+	env := &common.Envelope{
+		Payload: protoutil.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{
+					Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"}),
+				}),
+			},
+		}),
+	}
+	require.Equal(t, "Org1MSP", creatorMSPID(env))
+	require.Equal(t, "", creatorMSPID(&common.Envelope{Payload: []byte("garbage")}))
+	require.Equal(t, "", creatorMSPID(&common.Envelope{}))
+}
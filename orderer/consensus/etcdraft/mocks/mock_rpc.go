@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 )
 
@@ -21,6 +22,30 @@ type FakeRPC struct {
 	sendConsensusReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SendSnapshotChunkStub        func(uint64, *clusterpb.SnapshotChunk) error
+	sendSnapshotChunkMutex       sync.RWMutex
+	sendSnapshotChunkArgsForCall []struct {
+		arg1 uint64
+		arg2 *clusterpb.SnapshotChunk
+	}
+	sendSnapshotChunkReturns struct {
+		result1 error
+	}
+	sendSnapshotChunkReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SendSnapshotRequestStub        func(uint64, *clusterpb.SnapshotRequest) error
+	sendSnapshotRequestMutex       sync.RWMutex
+	sendSnapshotRequestArgsForCall []struct {
+		arg1 uint64
+		arg2 *clusterpb.SnapshotRequest
+	}
+	sendSnapshotRequestReturns struct {
+		result1 error
+	}
+	sendSnapshotRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SendSubmitStub        func(uint64, *orderer.SubmitRequest) error
 	sendSubmitMutex       sync.RWMutex
 	sendSubmitArgsForCall []struct {
@@ -98,6 +123,128 @@ func (fake *FakeRPC) SendConsensusReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRPC) SendSnapshotChunk(arg1 uint64, arg2 *clusterpb.SnapshotChunk) error {
+	fake.sendSnapshotChunkMutex.Lock()
+	ret, specificReturn := fake.sendSnapshotChunkReturnsOnCall[len(fake.sendSnapshotChunkArgsForCall)]
+	fake.sendSnapshotChunkArgsForCall = append(fake.sendSnapshotChunkArgsForCall, struct {
+		arg1 uint64
+		arg2 *clusterpb.SnapshotChunk
+	}{arg1, arg2})
+	fake.recordInvocation("SendSnapshotChunk", []interface{}{arg1, arg2})
+	fake.sendSnapshotChunkMutex.Unlock()
+	if fake.SendSnapshotChunkStub != nil {
+		return fake.SendSnapshotChunkStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sendSnapshotChunkReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRPC) SendSnapshotChunkCallCount() int {
+	fake.sendSnapshotChunkMutex.RLock()
+	defer fake.sendSnapshotChunkMutex.RUnlock()
+	return len(fake.sendSnapshotChunkArgsForCall)
+}
+
+func (fake *FakeRPC) SendSnapshotChunkCalls(stub func(uint64, *clusterpb.SnapshotChunk) error) {
+	fake.sendSnapshotChunkMutex.Lock()
+	defer fake.sendSnapshotChunkMutex.Unlock()
+	fake.SendSnapshotChunkStub = stub
+}
+
+func (fake *FakeRPC) SendSnapshotChunkArgsForCall(i int) (uint64, *clusterpb.SnapshotChunk) {
+	fake.sendSnapshotChunkMutex.RLock()
+	defer fake.sendSnapshotChunkMutex.RUnlock()
+	argsForCall := fake.sendSnapshotChunkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRPC) SendSnapshotChunkReturns(result1 error) {
+	fake.sendSnapshotChunkMutex.Lock()
+	defer fake.sendSnapshotChunkMutex.Unlock()
+	fake.SendSnapshotChunkStub = nil
+	fake.sendSnapshotChunkReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRPC) SendSnapshotChunkReturnsOnCall(i int, result1 error) {
+	fake.sendSnapshotChunkMutex.Lock()
+	defer fake.sendSnapshotChunkMutex.Unlock()
+	fake.SendSnapshotChunkStub = nil
+	if fake.sendSnapshotChunkReturnsOnCall == nil {
+		fake.sendSnapshotChunkReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendSnapshotChunkReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRPC) SendSnapshotRequest(arg1 uint64, arg2 *clusterpb.SnapshotRequest) error {
+	fake.sendSnapshotRequestMutex.Lock()
+	ret, specificReturn := fake.sendSnapshotRequestReturnsOnCall[len(fake.sendSnapshotRequestArgsForCall)]
+	fake.sendSnapshotRequestArgsForCall = append(fake.sendSnapshotRequestArgsForCall, struct {
+		arg1 uint64
+		arg2 *clusterpb.SnapshotRequest
+	}{arg1, arg2})
+	fake.recordInvocation("SendSnapshotRequest", []interface{}{arg1, arg2})
+	fake.sendSnapshotRequestMutex.Unlock()
+	if fake.SendSnapshotRequestStub != nil {
+		return fake.SendSnapshotRequestStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sendSnapshotRequestReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRPC) SendSnapshotRequestCallCount() int {
+	fake.sendSnapshotRequestMutex.RLock()
+	defer fake.sendSnapshotRequestMutex.RUnlock()
+	return len(fake.sendSnapshotRequestArgsForCall)
+}
+
+func (fake *FakeRPC) SendSnapshotRequestCalls(stub func(uint64, *clusterpb.SnapshotRequest) error) {
+	fake.sendSnapshotRequestMutex.Lock()
+	defer fake.sendSnapshotRequestMutex.Unlock()
+	fake.SendSnapshotRequestStub = stub
+}
+
+func (fake *FakeRPC) SendSnapshotRequestArgsForCall(i int) (uint64, *clusterpb.SnapshotRequest) {
+	fake.sendSnapshotRequestMutex.RLock()
+	defer fake.sendSnapshotRequestMutex.RUnlock()
+	argsForCall := fake.sendSnapshotRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRPC) SendSnapshotRequestReturns(result1 error) {
+	fake.sendSnapshotRequestMutex.Lock()
+	defer fake.sendSnapshotRequestMutex.Unlock()
+	fake.SendSnapshotRequestStub = nil
+	fake.sendSnapshotRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRPC) SendSnapshotRequestReturnsOnCall(i int, result1 error) {
+	fake.sendSnapshotRequestMutex.Lock()
+	defer fake.sendSnapshotRequestMutex.Unlock()
+	fake.SendSnapshotRequestStub = nil
+	if fake.sendSnapshotRequestReturnsOnCall == nil {
+		fake.sendSnapshotRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.sendSnapshotRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRPC) SendSubmit(arg1 uint64, arg2 *orderer.SubmitRequest) error {
 	fake.sendSubmitMutex.Lock()
 	ret, specificReturn := fake.sendSubmitReturnsOnCall[len(fake.sendSubmitArgsForCall)]
@@ -164,6 +311,10 @@ func (fake *FakeRPC) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.sendConsensusMutex.RLock()
 	defer fake.sendConsensusMutex.RUnlock()
+	fake.sendSnapshotChunkMutex.RLock()
+	defer fake.sendSnapshotChunkMutex.RUnlock()
+	fake.sendSnapshotRequestMutex.RLock()
+	defer fake.sendSnapshotRequestMutex.RUnlock()
 	fake.sendSubmitMutex.RLock()
 	defer fake.sendSubmitMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
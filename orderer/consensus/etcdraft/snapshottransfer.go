@@ -0,0 +1,349 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft"
+)
+
+// snapshotTransfer streams the blocks of a raft snapshot from the leader to the followers
+// catching up with it, instead of having the followers pull the blocks one by one from the
+// cluster. The blocks are packed in compressed chunks, and a follower requests a window
+// of chunks at a time, starting from its ledger height, so that a transfer that is cut
+// short resumes where it stopped.
+type snapshotTransfer struct {
+	chunkSize uint32
+	window    uint32
+	timeout   time.Duration
+
+	lock    sync.Mutex
+	serving map[uint64]chan struct{} // stops the transfer in progress to each follower
+
+	chunkC chan *receivedChunk // chunks received by the follower
+}
+
+type receivedChunk struct {
+	sender uint64
+	chunk  *clusterpb.SnapshotChunk
+}
+
+// newSnapshotTransfer creates a snapshotTransfer, using the defaults for the unset parameters.
+func newSnapshotTransfer(chunkSize, window uint32, timeout time.Duration) *snapshotTransfer {
+	if chunkSize == 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+	if window == 0 {
+		window = DefaultSnapshotWindow
+	}
+	if timeout == 0 {
+		timeout = DefaultSnapshotTransferTimeout
+	}
+	return &snapshotTransfer{
+		chunkSize: chunkSize,
+		window:    window,
+		timeout:   timeout,
+		serving:   map[uint64]chan struct{}{},
+		chunkC:    make(chan *receivedChunk, window),
+	}
+}
+
+// startServing stops the transfer in progress to the follower, if any, and returns
+// the channel that stops the new one.
+func (st *snapshotTransfer) startServing(follower uint64) chan struct{} {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if stopC, exists := st.serving[follower]; exists {
+		close(stopC)
+	}
+	stopC := make(chan struct{})
+	st.serving[follower] = stopC
+	return stopC
+}
+
+func (st *snapshotTransfer) stopServing(follower uint64, stopC chan struct{}) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+
+	if st.serving[follower] == stopC {
+		delete(st.serving, follower)
+	}
+}
+
+// drain discards the chunks left over from a previous request.
+func (st *snapshotTransfer) drain() {
+	for {
+		select {
+		case <-st.chunkC:
+		default:
+			return
+		}
+	}
+}
+
+// packSnapshotChunk packs the blocks from first onwards, up to end, into a chunk of about
+// chunkSize bytes before compression. A chunk always carries at least one block.
+func packSnapshotChunk(channel string, first, end uint64, chunkSize uint32, block func(uint64) *common.Block) (*clusterpb.SnapshotChunk, error) {
+	data := &common.BlockData{}
+	size := 0
+	last := first
+	for n := first; n <= end && (n == first || size < int(chunkSize)); n++ {
+		b := block(n)
+		if b == nil {
+			return nil, errors.Errorf("block [%d] not found", n)
+		}
+		raw, err := proto.Marshal(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal block [%d]", n)
+		}
+		data.Data = append(data.Data, raw)
+		size += len(raw)
+		last = n
+	}
+
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(protoutil.MarshalOrPanic(data)); err != nil {
+		return nil, errors.Wrap(err, "failed to compress blocks")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to compress blocks")
+	}
+
+	return &clusterpb.SnapshotChunk{
+		Channel: channel,
+		First:   first,
+		Last:    last,
+		Blocks:  buf.Bytes(),
+	}, nil
+}
+
+// unpackSnapshotChunk returns the blocks carried by the chunk.
+func unpackSnapshotChunk(chunk *clusterpb.SnapshotChunk) ([]*common.Block, error) {
+	if chunk.Last < chunk.First {
+		return nil, errors.Errorf("chunk of blocks [%d, %d] is empty", chunk.First, chunk.Last)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(chunk.Blocks))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress blocks")
+	}
+	raw, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decompress blocks")
+	}
+
+	data := &common.BlockData{}
+	if err := proto.Unmarshal(raw, data); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal blocks")
+	}
+	if uint64(len(data.Data)) != chunk.Last-chunk.First+1 {
+		return nil, errors.Errorf("chunk of blocks [%d, %d] carries %d blocks", chunk.First, chunk.Last, len(data.Data))
+	}
+
+	blocks := make([]*common.Block, 0, len(data.Data))
+	for i, raw := range data.Data {
+		block, err := protoutil.UnmarshalBlock(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal block")
+		}
+		if block.Header == nil || block.Data == nil || block.Header.Number != chunk.First+uint64(i) {
+			return nil, errors.Errorf("chunk of blocks [%d, %d] carries an unexpected block at position %d", chunk.First, chunk.Last, i)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// SnapshotRequest serves the blocks requested by a follower catching up with a snapshot.
+func (c *Chain) SnapshotRequest(req *clusterpb.SnapshotRequest, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	if c.snapshotTransfer == nil {
+		return errors.Errorf("snapshot transfer is disabled")
+	}
+
+	height := c.support.Height()
+	if req.Start > req.End || req.End >= height {
+		return errors.Errorf("invalid request for blocks [%d, %d], ledger height is %d", req.Start, req.End, height)
+	}
+
+	stopC := c.snapshotTransfer.startServing(sender)
+	go c.serveSnapshot(req, sender, stopC)
+	return nil
+}
+
+func (c *Chain) serveSnapshot(req *clusterpb.SnapshotRequest, follower uint64, stopC chan struct{}) {
+	defer c.snapshotTransfer.stopServing(follower, stopC)
+
+	window := req.Window
+	if window == 0 || window > c.snapshotTransfer.window {
+		window = c.snapshotTransfer.window
+	}
+
+	c.logger.Debugf("Sending blocks [%d, %d] to %d in up to %d chunks", req.Start, req.End, follower, window)
+
+	next := req.Start
+	for sent := uint32(0); sent < window && next <= req.End; sent++ {
+		select {
+		case <-stopC:
+			return
+		case <-c.doneC:
+			return
+		default:
+		}
+
+		chunk, err := packSnapshotChunk(c.channelID, next, req.End, c.snapshotTransfer.chunkSize, c.support.Block)
+		if err != nil {
+			c.logger.Warnf("Failed to pack blocks for %d: %s", follower, err)
+			return
+		}
+
+		// The send blocks while the stream to the follower is full, which paces the transfer
+		if err := c.rpc.SendSnapshotChunk(follower, chunk); err != nil {
+			c.logger.Warnf("Failed to send blocks [%d, %d] to %d: %s", chunk.First, chunk.Last, follower, err)
+			return
+		}
+		next = chunk.Last + 1
+	}
+}
+
+// SnapshotChunk passes a chunk of blocks to the snapshot transfer in progress.
+func (c *Chain) SnapshotChunk(chunk *clusterpb.SnapshotChunk, sender uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	if c.snapshotTransfer == nil {
+		return errors.Errorf("snapshot transfer is disabled")
+	}
+
+	select {
+	case c.snapshotTransfer.chunkC <- &receivedChunk{sender: sender, chunk: chunk}:
+	default:
+		c.logger.Warnf("Dropping blocks [%d, %d] from %d, no snapshot transfer expects them", chunk.First, chunk.Last, sender)
+	}
+	return nil
+}
+
+// fetchSnapshot writes the blocks up to the snapshot streamed by the leader. The blocks are
+// buffered until the whole range is received and verified against the snapshot, as the hash
+// chain of a block can only be trusted once it leads to the block of the snapshot. It stops
+// once a request makes no progress, leaving the remaining blocks to the block puller.
+func (c *Chain) fetchSnapshot(snapshot *common.Block) {
+	st := c.snapshotTransfer
+	end := snapshot.Header.Number
+
+	var blocks []*common.Block
+	for next := c.lastBlock.Header.Number + 1; next <= end; next = blocks[len(blocks)-1].Header.Number + 1 {
+		leader := c.Node.Status().Lead
+		if leader == raft.None || leader == c.raftID {
+			c.logger.Infof("No leader to transfer the snapshot from")
+			return
+		}
+
+		st.drain()
+
+		req := &clusterpb.SnapshotRequest{
+			Channel: c.channelID,
+			Start:   next,
+			End:     end,
+			Window:  st.window,
+		}
+		if err := c.rpc.SendSnapshotRequest(leader, req); err != nil {
+			c.logger.Warnf("Failed to request blocks [%d, %d] from %d: %s", next, end, leader, err)
+			return
+		}
+
+		received, ok := c.receiveSnapshotChunks(leader, next, end)
+		if !ok {
+			return
+		}
+		if len(received) == 0 {
+			c.logger.Warnf("Received no blocks from %d, stopping the snapshot transfer", leader)
+			return
+		}
+		blocks = append(blocks, received...)
+	}
+
+	if err := c.verifySnapshotBlocks(blocks, snapshot); err != nil {
+		c.logger.Warnf("Received invalid blocks [%d, %d]: %s", c.lastBlock.Header.Number+1, end, err)
+		return
+	}
+	for _, block := range blocks {
+		c.writeCatchUpBlock(block)
+	}
+}
+
+// receiveSnapshotChunks returns the blocks of a window of chunks received from the leader,
+// from the given block up to the given end at most. It returns false if the transfer cannot go on.
+func (c *Chain) receiveSnapshotChunks(leader uint64, next, end uint64) ([]*common.Block, bool) {
+	st := c.snapshotTransfer
+
+	var blocks []*common.Block
+	for received := uint32(0); received < st.window && next <= end; {
+		select {
+		case rc := <-st.chunkC:
+			if rc.sender != leader || rc.chunk.First != next {
+				c.logger.Debugf("Ignoring blocks [%d, %d] from %d", rc.chunk.First, rc.chunk.Last, rc.sender)
+				continue
+			}
+			received++
+
+			if rc.chunk.Last > end {
+				c.logger.Warnf("Received blocks [%d, %d] from %d beyond the snapshot taken at block [%d]", rc.chunk.First, rc.chunk.Last, leader, end)
+				return nil, false
+			}
+			chunkBlocks, err := unpackSnapshotChunk(rc.chunk)
+			if err != nil {
+				c.logger.Warnf("Received invalid blocks from %d: %s", leader, err)
+				return nil, false
+			}
+			blocks = append(blocks, chunkBlocks...)
+			next = rc.chunk.Last + 1
+
+		case <-time.After(st.timeout):
+			c.logger.Warnf("Timed out waiting for blocks from %d, resuming from block [%d]", leader, next)
+			return blocks, true
+
+		case <-c.doneC:
+			return nil, false
+		}
+	}
+	return blocks, true
+}
+
+// verifySnapshotBlocks checks that the blocks follow the last block of the ledger and end with
+// the block of the snapshot, that they form a hash chain, and that they are properly signed.
+func (c *Chain) verifySnapshotBlocks(blocks []*common.Block, snapshot *common.Block) error {
+	if len(blocks) == 0 {
+		return errors.New("no blocks")
+	}
+	if !bytes.Equal(blocks[0].Header.PreviousHash, protoutil.BlockHeaderHash(c.lastBlock.Header)) {
+		return errors.Errorf("previous hash of block [%d] does not match block [%d]", blocks[0].Header.Number, c.lastBlock.Header.Number)
+	}
+	last := blocks[len(blocks)-1]
+	if last.Header.Number != snapshot.Header.Number ||
+		!bytes.Equal(protoutil.BlockHeaderHash(last.Header), protoutil.BlockHeaderHash(snapshot.Header)) {
+		return errors.Errorf("block [%d] does not match the snapshot taken at block [%d]", last.Header.Number, snapshot.Header.Number)
+	}
+	return cluster.VerifyBlocks(blocks, c.support)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/orderer/common/cluster/clusterpb"
+	consensusmocks "github.com/hyperledger/fabric/orderer/consensus/mocks"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotChunk(t *testing.T) {
+	blocks := map[uint64]*common.Block{}
+	for n := uint64(1); n <= 5; n++ {
+		blocks[n] = protoutil.NewBlock(n, []byte{byte(n)})
+		blocks[n].Data.Data = [][]byte{make([]byte, 100)}
+	}
+	block := func(n uint64) *common.Block { return blocks[n] }
+
+	t.Run("one block per chunk", func(t *testing.T) {
+		chunk, err := packSnapshotChunk("mychannel", 2, 5, 1, block)
+		require.NoError(t, err)
+		require.Equal(t, "mychannel", chunk.Channel)
+		require.Equal(t, uint64(2), chunk.First)
+		require.Equal(t, uint64(2), chunk.Last)
+
+		unpacked, err := unpackSnapshotChunk(chunk)
+		require.NoError(t, err)
+		require.Len(t, unpacked, 1)
+		require.True(t, proto.Equal(blocks[2], unpacked[0]))
+	})
+
+	t.Run("several blocks per chunk", func(t *testing.T) {
+		chunk, err := packSnapshotChunk("mychannel", 1, 5, 250, block)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), chunk.First)
+		require.Equal(t, uint64(3), chunk.Last)
+
+		unpacked, err := unpackSnapshotChunk(chunk)
+		require.NoError(t, err)
+		require.Len(t, unpacked, 3)
+		for i, b := range unpacked {
+			require.True(t, proto.Equal(blocks[uint64(i+1)], b))
+		}
+	})
+
+	t.Run("up to the end", func(t *testing.T) {
+		chunk, err := packSnapshotChunk("mychannel", 4, 5, 1024*1024, block)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), chunk.First)
+		require.Equal(t, uint64(5), chunk.Last)
+	})
+
+	t.Run("missing block", func(t *testing.T) {
+		_, err := packSnapshotChunk("mychannel", 5, 6, 1024*1024, block)
+		require.EqualError(t, err, "block [6] not found")
+	})
+
+	t.Run("bad compression", func(t *testing.T) {
+		_, err := unpackSnapshotChunk(&clusterpb.SnapshotChunk{First: 1, Last: 1, Blocks: []byte("garbage")})
+		require.EqualError(t, err, "failed to decompress blocks: unexpected EOF")
+	})
+
+	t.Run("empty chunk", func(t *testing.T) {
+		_, err := unpackSnapshotChunk(&clusterpb.SnapshotChunk{First: 2, Last: 1})
+		require.EqualError(t, err, "chunk of blocks [2, 1] is empty")
+	})
+
+	t.Run("wrong range", func(t *testing.T) {
+		chunk, err := packSnapshotChunk("mychannel", 1, 5, 250, block)
+		require.NoError(t, err)
+
+		chunk.Last = 4
+		_, err = unpackSnapshotChunk(chunk)
+		require.EqualError(t, err, "chunk of blocks [1, 4] carries 3 blocks")
+
+		chunk.First, chunk.Last = 2, 4
+		_, err = unpackSnapshotChunk(chunk)
+		require.EqualError(t, err, "chunk of blocks [2, 4] carries an unexpected block at position 0")
+	})
+}
+
+func TestSnapshotTransferServing(t *testing.T) {
+	st := newSnapshotTransfer(0, 0, 0)
+	require.Equal(t, uint32(DefaultSnapshotChunkSize), st.chunkSize)
+	require.Equal(t, DefaultSnapshotWindow, st.window)
+	require.Equal(t, DefaultSnapshotTransferTimeout, st.timeout)
+
+	stopC1 := st.startServing(2)
+	stopC2 := st.startServing(3)
+
+	// a new request of the follower stops the transfer in progress
+	stopC3 := st.startServing(2)
+	require.True(t, isClosed(stopC1))
+	require.False(t, isClosed(stopC2))
+
+	// the stopped transfer does not unregister the new one
+	st.stopServing(2, stopC1)
+	require.Equal(t, stopC3, st.serving[2])
+
+	st.stopServing(2, stopC3)
+	st.stopServing(3, stopC2)
+	require.Empty(t, st.serving)
+}
+
+func TestSnapshotTransferDrain(t *testing.T) {
+	st := newSnapshotTransfer(1, 2, 0)
+	st.chunkC <- &receivedChunk{sender: 1, chunk: &clusterpb.SnapshotChunk{}}
+	st.chunkC <- &receivedChunk{sender: 1, chunk: &clusterpb.SnapshotChunk{}}
+
+	st.drain()
+	require.Empty(t, st.chunkC)
+}
+
+func TestVerifySnapshotBlocks(t *testing.T) {
+	newChain := func(length uint64) []*common.Block {
+		var blocks []*common.Block
+		var prevHash []byte
+		for n := uint64(0); n < length; n++ {
+			block := protoutil.NewBlock(n, prevHash)
+			block.Data.Data = [][]byte{protoutil.MarshalOrPanic(&common.Envelope{
+				Payload: protoutil.MarshalOrPanic(&common.Payload{
+					Header: &common.Header{
+						ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+							Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+							TxId: fmt.Sprintf("tx%d", n),
+						}),
+					},
+				}),
+			})}
+			block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+			prevHash = protoutil.BlockHeaderHash(block.Header)
+			blocks = append(blocks, block)
+		}
+		return blocks
+	}
+
+	setup := func() (*Chain, *consensusmocks.FakeConsenterSupport, []*common.Block) {
+		blocks := newChain(5)
+		support := &consensusmocks.FakeConsenterSupport{}
+		return &Chain{lastBlock: blocks[0], support: support}, support, blocks
+	}
+
+	t.Run("valid range", func(t *testing.T) {
+		c, support, blocks := setup()
+		require.NoError(t, c.verifySnapshotBlocks(blocks[1:], blocks[4]))
+		require.Equal(t, 1, support.VerifyBlockSignatureCallCount())
+	})
+
+	t.Run("no blocks", func(t *testing.T) {
+		c, _, blocks := setup()
+		require.EqualError(t, c.verifySnapshotBlocks(nil, blocks[4]), "no blocks")
+	})
+
+	t.Run("not following the ledger", func(t *testing.T) {
+		c, _, blocks := setup()
+		c.lastBlock = newChain(1)[0]
+		c.lastBlock.Header.DataHash = []byte("other")
+		require.EqualError(t, c.verifySnapshotBlocks(blocks[1:], blocks[4]), "previous hash of block [1] does not match block [0]")
+	})
+
+	t.Run("not ending with the snapshot", func(t *testing.T) {
+		c, _, blocks := setup()
+		require.EqualError(t, c.verifySnapshotBlocks(blocks[1:4], blocks[4]), "block [3] does not match the snapshot taken at block [4]")
+
+		forged := proto.Clone(blocks[4]).(*common.Block)
+		forged.Header.DataHash = []byte("forged")
+		require.EqualError(t, c.verifySnapshotBlocks(blocks[1:], forged), "block [4] does not match the snapshot taken at block [4]")
+	})
+
+	t.Run("forged block within the range", func(t *testing.T) {
+		c, _, blocks := setup()
+		blocks[2].Data.Data = blocks[3].Data.Data
+		err := c.verifySnapshotBlocks(blocks[1:], blocks[4])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "computed hash of block (2)")
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		c, support, blocks := setup()
+		support.VerifyBlockSignatureReturns(errors.New("bad signature"))
+		require.EqualError(t, c.verifySnapshotBlocks(blocks[1:], blocks[4]), "bad signature")
+	})
+}
+
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
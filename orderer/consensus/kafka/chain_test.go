@@ -3762,6 +3762,10 @@ func (c *mockConsenterSupport) WriteConfigBlock(block *cb.Block, encodedMetadata
 	c.Called(block, encodedMetadataValue)
 }
 
+func (c *mockConsenterSupport) WriteSignedBlock(block *cb.Block) {
+	c.Called(block)
+}
+
 func (c *mockConsenterSupport) Sequence() uint64 {
 	args := c.Called()
 	return args.Get(0).(uint64)
@@ -115,6 +115,11 @@ func (mcs *ConsenterSupport) WriteConfigBlock(block *cb.Block, encodedMetadataVa
 	mcs.WriteBlock(block, encodedMetadataValue)
 }
 
+// WriteSignedBlock calls WriteBlock
+func (mcs *ConsenterSupport) WriteSignedBlock(block *cb.Block) {
+	mcs.WriteBlock(block, nil)
+}
+
 // ChannelID returns the channel ID this specific consenter instance is associated with
 func (mcs *ConsenterSupport) ChannelID() string {
 	return mcs.ChannelIDVal
@@ -0,0 +1,789 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: gateway.proto
+
+package gateway
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// EndorseRequest contains the details required to obtain sufficient endorsements for a
+// transaction to be committed to the ledger.
+type EndorseRequest struct {
+	// The unique identifier for the transaction.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The signed proposal ready for endorsement.
+	ProposedTransaction *peer.SignedProposal `protobuf:"bytes,3,opt,name=proposed_transaction,json=proposedTransaction,proto3" json:"proposed_transaction,omitempty"`
+	// If targeting the peers of specific organizations (e.g. for private data scenarios),
+	// the list of organizations' MSPIDs should be supplied here.
+	EndorsingOrganizations []string `protobuf:"bytes,4,rep,name=endorsing_organizations,json=endorsingOrganizations,proto3" json:"endorsing_organizations,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *EndorseRequest) Reset()         { *m = EndorseRequest{} }
+func (m *EndorseRequest) String() string { return proto.CompactTextString(m) }
+func (*EndorseRequest) ProtoMessage()    {}
+func (*EndorseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{0}
+}
+
+func (m *EndorseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseRequest.Unmarshal(m, b)
+}
+func (m *EndorseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseRequest.Marshal(b, m, deterministic)
+}
+func (m *EndorseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseRequest.Merge(m, src)
+}
+func (m *EndorseRequest) XXX_Size() int {
+	return xxx_messageInfo_EndorseRequest.Size(m)
+}
+func (m *EndorseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseRequest proto.InternalMessageInfo
+
+func (m *EndorseRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *EndorseRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *EndorseRequest) GetProposedTransaction() *peer.SignedProposal {
+	if m != nil {
+		return m.ProposedTransaction
+	}
+	return nil
+}
+
+func (m *EndorseRequest) GetEndorsingOrganizations() []string {
+	if m != nil {
+		return m.EndorsingOrganizations
+	}
+	return nil
+}
+
+// EndorseResponse returns the result of endorsing a transaction.
+type EndorseResponse struct {
+	// The response that is returned by the transaction function, as defined
+	// in peer/proposal_response.proto.
+	Result *peer.Response `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// The unsigned set of transaction responses from the endorsing peers for signing by the client
+	// before submitting to ordering service (via gateway).
+	PreparedTransaction  *common.Envelope `protobuf:"bytes,2,opt,name=prepared_transaction,json=preparedTransaction,proto3" json:"prepared_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *EndorseResponse) Reset()         { *m = EndorseResponse{} }
+func (m *EndorseResponse) String() string { return proto.CompactTextString(m) }
+func (*EndorseResponse) ProtoMessage()    {}
+func (*EndorseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{1}
+}
+
+func (m *EndorseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorseResponse.Unmarshal(m, b)
+}
+func (m *EndorseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorseResponse.Marshal(b, m, deterministic)
+}
+func (m *EndorseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorseResponse.Merge(m, src)
+}
+func (m *EndorseResponse) XXX_Size() int {
+	return xxx_messageInfo_EndorseResponse.Size(m)
+}
+func (m *EndorseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorseResponse proto.InternalMessageInfo
+
+func (m *EndorseResponse) GetResult() *peer.Response {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *EndorseResponse) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+// SubmitRequest contains the details required to submit a transaction (update the ledger).
+type SubmitRequest struct {
+	// Identifier of the transaction to submit.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The signed set of endorsed transaction responses to submit.
+	PreparedTransaction  *common.Envelope `protobuf:"bytes,3,opt,name=prepared_transaction,json=preparedTransaction,proto3" json:"prepared_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *SubmitRequest) Reset()         { *m = SubmitRequest{} }
+func (m *SubmitRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitRequest) ProtoMessage()    {}
+func (*SubmitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{2}
+}
+
+func (m *SubmitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitRequest.Unmarshal(m, b)
+}
+func (m *SubmitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitRequest.Marshal(b, m, deterministic)
+}
+func (m *SubmitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitRequest.Merge(m, src)
+}
+func (m *SubmitRequest) XXX_Size() int {
+	return xxx_messageInfo_SubmitRequest.Size(m)
+}
+func (m *SubmitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitRequest proto.InternalMessageInfo
+
+func (m *SubmitRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *SubmitRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *SubmitRequest) GetPreparedTransaction() *common.Envelope {
+	if m != nil {
+		return m.PreparedTransaction
+	}
+	return nil
+}
+
+// SubmitResponse returns the result of submitting a transaction.
+type SubmitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubmitResponse) Reset()         { *m = SubmitResponse{} }
+func (m *SubmitResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitResponse) ProtoMessage()    {}
+func (*SubmitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{3}
+}
+
+func (m *SubmitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubmitResponse.Unmarshal(m, b)
+}
+func (m *SubmitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubmitResponse.Marshal(b, m, deterministic)
+}
+func (m *SubmitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitResponse.Merge(m, src)
+}
+func (m *SubmitResponse) XXX_Size() int {
+	return xxx_messageInfo_SubmitResponse.Size(m)
+}
+func (m *SubmitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitResponse proto.InternalMessageInfo
+
+// SignedCommitStatusRequest contains a serialized CommitStatusRequest message, and a digital signature for the
+// serialized request message.
+type SignedCommitStatusRequest struct {
+	// Serialized CommitStatusRequest message.
+	Request []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Signature for request message.
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedCommitStatusRequest) Reset()         { *m = SignedCommitStatusRequest{} }
+func (m *SignedCommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedCommitStatusRequest) ProtoMessage()    {}
+func (*SignedCommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{4}
+}
+
+func (m *SignedCommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedCommitStatusRequest.Unmarshal(m, b)
+}
+func (m *SignedCommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedCommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedCommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedCommitStatusRequest.Merge(m, src)
+}
+func (m *SignedCommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedCommitStatusRequest.Size(m)
+}
+func (m *SignedCommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedCommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedCommitStatusRequest proto.InternalMessageInfo
+
+func (m *SignedCommitStatusRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedCommitStatusRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CommitStatusRequest contains the details required to check whether a transaction has been
+// successfully committed.
+type CommitStatusRequest struct {
+	// Identifier of the transaction to check.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Client requestor identity.
+	Identity             []byte   `protobuf:"bytes,3,opt,name=identity,proto3" json:"identity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitStatusRequest) Reset()         { *m = CommitStatusRequest{} }
+func (m *CommitStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusRequest) ProtoMessage()    {}
+func (*CommitStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{5}
+}
+
+func (m *CommitStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitStatusRequest.Unmarshal(m, b)
+}
+func (m *CommitStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *CommitStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitStatusRequest.Merge(m, src)
+}
+func (m *CommitStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_CommitStatusRequest.Size(m)
+}
+func (m *CommitStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitStatusRequest proto.InternalMessageInfo
+
+func (m *CommitStatusRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *CommitStatusRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *CommitStatusRequest) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+// CommitStatusResponse returns the result of committing a transaction.
+type CommitStatusResponse struct {
+	// The result of the transaction commit, as defined in peer/transaction.proto.
+	Result peer.TxValidationCode `protobuf:"varint,1,opt,name=result,proto3,enum=protos.TxValidationCode" json:"result,omitempty"`
+	// Block number that contains the transaction.
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitStatusResponse) Reset()         { *m = CommitStatusResponse{} }
+func (m *CommitStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*CommitStatusResponse) ProtoMessage()    {}
+func (*CommitStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{6}
+}
+
+func (m *CommitStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitStatusResponse.Unmarshal(m, b)
+}
+func (m *CommitStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *CommitStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitStatusResponse.Merge(m, src)
+}
+func (m *CommitStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_CommitStatusResponse.Size(m)
+}
+func (m *CommitStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitStatusResponse proto.InternalMessageInfo
+
+func (m *CommitStatusResponse) GetResult() peer.TxValidationCode {
+	if m != nil {
+		return m.Result
+	}
+	return peer.TxValidationCode_VALID
+}
+
+func (m *CommitStatusResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// EvaluateRequest contains the details required to evaluate a transaction (query the ledger).
+type EvaluateRequest struct {
+	// Identifier of the transaction to evaluate.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Identifier of the channel this request is bound for.
+	ChannelId string `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// The signed proposal ready for evaluation.
+	ProposedTransaction *peer.SignedProposal `protobuf:"bytes,3,opt,name=proposed_transaction,json=proposedTransaction,proto3" json:"proposed_transaction,omitempty"`
+	// If targeting the peers of specific organizations (e.g. for private data scenarios),
+	// the list of organizations' MSPIDs should be supplied here.
+	TargetOrganizations  []string `protobuf:"bytes,4,rep,name=target_organizations,json=targetOrganizations,proto3" json:"target_organizations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{7}
+}
+
+func (m *EvaluateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateRequest.Unmarshal(m, b)
+}
+func (m *EvaluateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateRequest.Merge(m, src)
+}
+func (m *EvaluateRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateRequest.Size(m)
+}
+func (m *EvaluateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateRequest proto.InternalMessageInfo
+
+func (m *EvaluateRequest) GetTransactionId() string {
+	if m != nil {
+		return m.TransactionId
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetProposedTransaction() *peer.SignedProposal {
+	if m != nil {
+		return m.ProposedTransaction
+	}
+	return nil
+}
+
+func (m *EvaluateRequest) GetTargetOrganizations() []string {
+	if m != nil {
+		return m.TargetOrganizations
+	}
+	return nil
+}
+
+// EvaluateResponse returns the result of evaluating a transaction.
+type EvaluateResponse struct {
+	// The response that is returned by the transaction function, as defined
+	// in peer/proposal_response.proto.
+	Result               *peer.Response `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f1a937782ebbded5, []int{8}
+}
+
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (m *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(m, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResult() *peer.Response {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*EndorseRequest)(nil), "gateway.EndorseRequest")
+	proto.RegisterType((*EndorseResponse)(nil), "gateway.EndorseResponse")
+	proto.RegisterType((*SubmitRequest)(nil), "gateway.SubmitRequest")
+	proto.RegisterType((*SubmitResponse)(nil), "gateway.SubmitResponse")
+	proto.RegisterType((*SignedCommitStatusRequest)(nil), "gateway.SignedCommitStatusRequest")
+	proto.RegisterType((*CommitStatusRequest)(nil), "gateway.CommitStatusRequest")
+	proto.RegisterType((*CommitStatusResponse)(nil), "gateway.CommitStatusResponse")
+	proto.RegisterType((*EvaluateRequest)(nil), "gateway.EvaluateRequest")
+	proto.RegisterType((*EvaluateResponse)(nil), "gateway.EvaluateResponse")
+}
+
+func init() { proto.RegisterFile("gateway.proto", fileDescriptor_f1a937782ebbded5) }
+
+var fileDescriptor_f1a937782ebbded5 = []byte{
+	// 586 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0x4f, 0x6b, 0xdb, 0x3e,
+	0x18, 0xc6, 0x6d, 0x69, 0x9a, 0xb7, 0x69, 0x7e, 0x45, 0x2e, 0x89, 0x6b, 0x5a, 0xc8, 0xcf, 0x30,
+	0xc8, 0x18, 0xc4, 0x5b, 0x76, 0x18, 0x83, 0xc2, 0x60, 0xa1, 0x8c, 0x5c, 0xf6, 0xc7, 0x29, 0x3b,
+	0xec, 0x12, 0xe4, 0x58, 0x73, 0x44, 0x6c, 0xc9, 0x93, 0xe5, 0x76, 0xd9, 0x69, 0x9f, 0x62, 0x87,
+	0x7d, 0xaf, 0x5d, 0xf6, 0x69, 0x46, 0x24, 0x39, 0xb1, 0xd7, 0xf4, 0x50, 0xe8, 0x61, 0x27, 0x5b,
+	0xef, 0xf3, 0xbc, 0xd2, 0xf3, 0xbe, 0x7a, 0x5e, 0xc1, 0x51, 0x8c, 0x25, 0xb9, 0xc1, 0xcb, 0x41,
+	0x26, 0xb8, 0xe4, 0xa8, 0x61, 0x96, 0xae, 0x3d, 0xe3, 0x69, 0xca, 0x99, 0xaf, 0x3f, 0x1a, 0x75,
+	0xed, 0x8c, 0x10, 0xe1, 0x67, 0x82, 0x67, 0x3c, 0xc7, 0x89, 0x09, 0x9e, 0xd5, 0x82, 0x53, 0x41,
+	0xf2, 0x8c, 0xb3, 0x9c, 0x18, 0xb4, 0xa3, 0x50, 0x29, 0x30, 0xcb, 0xf1, 0x4c, 0xd2, 0x72, 0x2b,
+	0xef, 0xb7, 0x05, 0xed, 0x4b, 0x16, 0x71, 0x91, 0x93, 0x80, 0x7c, 0x29, 0x48, 0x2e, 0xd1, 0x23,
+	0x68, 0x57, 0x78, 0x53, 0x1a, 0x39, 0x56, 0xcf, 0xea, 0x37, 0x83, 0xa3, 0x4a, 0x74, 0x1c, 0xa1,
+	0x73, 0x80, 0xd9, 0x1c, 0x33, 0x46, 0x92, 0x15, 0x65, 0x47, 0x51, 0x9a, 0x26, 0x32, 0x8e, 0xd0,
+	0x18, 0x4e, 0xb4, 0x16, 0x12, 0x4d, 0x2b, 0x89, 0xce, 0x6e, 0xcf, 0xea, 0x1f, 0x0e, 0x3b, 0xfa,
+	0xf8, 0x7c, 0x30, 0xa1, 0x31, 0x23, 0xd1, 0x7b, 0xa3, 0x3a, 0xb0, 0xcb, 0x9c, 0xab, 0x4d, 0x0a,
+	0x7a, 0x01, 0x5d, 0xa2, 0x24, 0x52, 0x16, 0x4f, 0xb9, 0x88, 0x31, 0xa3, 0xdf, 0xf0, 0x0a, 0xc9,
+	0x9d, 0xbd, 0xde, 0x6e, 0xbf, 0x19, 0x74, 0xd6, 0xf0, 0xbb, 0x2a, 0xea, 0x7d, 0xb7, 0xe0, 0xbf,
+	0x75, 0x71, 0xba, 0x1d, 0xa8, 0x0f, 0xfb, 0x82, 0xe4, 0x45, 0x22, 0x55, 0x55, 0x87, 0xc3, 0xe3,
+	0x52, 0x49, 0xc9, 0x08, 0x0c, 0x8e, 0x46, 0xab, 0x0a, 0x48, 0x86, 0xc5, 0x5f, 0x15, 0xec, 0x98,
+	0x3c, 0x73, 0x25, 0x97, 0xec, 0x9a, 0x24, 0x3c, 0x23, 0x81, 0x5d, 0xb2, 0x2b, 0xda, 0xbd, 0x9f,
+	0x16, 0x1c, 0x4d, 0x8a, 0x30, 0xa5, 0xf2, 0x61, 0xdb, 0x7b, 0x97, 0xb8, 0xdd, 0xfb, 0x88, 0x3b,
+	0x86, 0x76, 0xa9, 0x4d, 0xd7, 0xee, 0x4d, 0xe0, 0x54, 0xdf, 0xc8, 0x88, 0xa7, 0x29, 0x95, 0x13,
+	0x89, 0x65, 0x91, 0x97, 0xca, 0x1d, 0x68, 0x08, 0xfd, 0xab, 0x24, 0xb7, 0x82, 0x72, 0x89, 0xce,
+	0xa0, 0x99, 0xd3, 0x98, 0x61, 0x59, 0x08, 0xa2, 0xb4, 0xb6, 0x82, 0x4d, 0xc0, 0xbb, 0x01, 0x7b,
+	0xdb, 0x76, 0x0f, 0xd3, 0x08, 0x17, 0x0e, 0x68, 0x44, 0x98, 0xa4, 0x72, 0xa9, 0x8a, 0x6f, 0x05,
+	0xeb, 0xb5, 0xb7, 0x80, 0x93, 0xfa, 0xc1, 0xc6, 0x03, 0x4f, 0x6b, 0x1e, 0x68, 0x0f, 0x9d, 0xd2,
+	0x03, 0x57, 0x5f, 0x3f, 0xe2, 0x84, 0x46, 0xca, 0x3e, 0x23, 0x1e, 0x6d, 0xbc, 0xf0, 0x3f, 0xb4,
+	0xc2, 0x84, 0xcf, 0x16, 0x53, 0x56, 0xa4, 0x21, 0x11, 0x4a, 0xc6, 0x5e, 0x70, 0xa8, 0x62, 0x6f,
+	0x55, 0xc8, 0xfb, 0xb5, 0x32, 0xdb, 0x35, 0x4e, 0x0a, 0x2c, 0xff, 0xdd, 0x51, 0x7a, 0x06, 0x27,
+	0x12, 0x8b, 0x98, 0xc8, 0xad, 0x73, 0x64, 0x6b, 0xac, 0x3e, 0x44, 0x17, 0x70, 0xbc, 0x29, 0xeb,
+	0xbe, 0x43, 0x34, 0xfc, 0xb1, 0x03, 0x8d, 0x37, 0xfa, 0x2d, 0x43, 0x17, 0xd0, 0x30, 0xd3, 0x88,
+	0xba, 0x83, 0xf2, 0xbd, 0xab, 0x3f, 0x3e, 0xae, 0x73, 0x1b, 0x30, 0x67, 0xbe, 0x84, 0x7d, 0x6d,
+	0x56, 0xd4, 0x59, 0x73, 0x6a, 0x93, 0xe5, 0x76, 0x6f, 0xc5, 0x4d, 0xea, 0x07, 0x68, 0x55, 0x7d,
+	0x80, 0xbc, 0x0d, 0xf1, 0x2e, 0xb3, 0xbb, 0xe7, 0x6b, 0xce, 0x56, 0x0b, 0xbd, 0x82, 0x83, 0xb2,
+	0x2b, 0xa8, 0xa2, 0xb9, 0x7e, 0xff, 0xee, 0xe9, 0x16, 0x44, 0x6f, 0xf0, 0xfa, 0xc9, 0xa7, 0xc7,
+	0x31, 0x95, 0xf3, 0x22, 0x5c, 0x8d, 0xaa, 0x3f, 0x5f, 0x66, 0x44, 0x24, 0x24, 0x8a, 0x89, 0xf0,
+	0x3f, 0xe3, 0x50, 0xd0, 0x99, 0x9f, 0x2d, 0x62, 0xdf, 0x64, 0x87, 0xfb, 0xaa, 0xbd, 0xcf, 0xff,
+	0x0c, 0x00, 0xf5, 0xbf, 0x24, 0x76, 0x26, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// GatewayClient is the client API for Gateway service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type GatewayClient interface {
+	// The Endorse service passes a proposed transaction to the gateway in order to
+	// obtain sufficient endorsement.
+	// The gateway will determine the endorsement plan for the requested chaincode and
+	// forward to the appropriate peers for endorsement. It will return to the client a
+	// prepared transaction in the form of an Envelope message as defined
+	// in common/common.proto. The client must sign the contents of this envelope
+	// before invoking the Submit service
+	Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error)
+	// The Submit service will process the prepared transaction returned from Endorse service
+	// once it has been signed by the client. It will wait for the transaction to be submitted to the
+	// ordering service but the client must invoke the CommitStatus service to wait for the transaction
+	// to be committed.
+	Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error)
+	// The CommitStatus service will indicate whether a prepared transaction previously submitted to
+	// the Submit service has been committed. It will wait for the commit to occur if it hasn’t already
+	// committed.
+	CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error)
+	// The Evaluate service passes a proposed transaction to the gateway in order to invoke the
+	// transaction function and return the result to the client. No ledger updates are made.
+	// The gateway will select an appropriate peer to query based on block height and load.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+}
+
+type gatewayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGatewayClient(cc grpc.ClientConnInterface) GatewayClient {
+	return &gatewayClient{cc}
+}
+
+func (c *gatewayClient) Endorse(ctx context.Context, in *EndorseRequest, opts ...grpc.CallOption) (*EndorseResponse, error) {
+	out := new(EndorseResponse)
+	err := c.cc.Invoke(ctx, "/gateway.Gateway/Endorse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayClient) Submit(ctx context.Context, in *SubmitRequest, opts ...grpc.CallOption) (*SubmitResponse, error) {
+	out := new(SubmitResponse)
+	err := c.cc.Invoke(ctx, "/gateway.Gateway/Submit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayClient) CommitStatus(ctx context.Context, in *SignedCommitStatusRequest, opts ...grpc.CallOption) (*CommitStatusResponse, error) {
+	out := new(CommitStatusResponse)
+	err := c.cc.Invoke(ctx, "/gateway.Gateway/CommitStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/gateway.Gateway/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GatewayServer is the server API for Gateway service.
+type GatewayServer interface {
+	// The Endorse service passes a proposed transaction to the gateway in order to
+	// obtain sufficient endorsement.
+	// The gateway will determine the endorsement plan for the requested chaincode and
+	// forward to the appropriate peers for endorsement. It will return to the client a
+	// prepared transaction in the form of an Envelope message as defined
+	// in common/common.proto. The client must sign the contents of this envelope
+	// before invoking the Submit service
+	Endorse(context.Context, *EndorseRequest) (*EndorseResponse, error)
+	// The Submit service will process the prepared transaction returned from Endorse service
+	// once it has been signed by the client. It will wait for the transaction to be submitted to the
+	// ordering service but the client must invoke the CommitStatus service to wait for the transaction
+	// to be committed.
+	Submit(context.Context, *SubmitRequest) (*SubmitResponse, error)
+	// The CommitStatus service will indicate whether a prepared transaction previously submitted to
+	// the Submit service has been committed. It will wait for the commit to occur if it hasn’t already
+	// committed.
+	CommitStatus(context.Context, *SignedCommitStatusRequest) (*CommitStatusResponse, error)
+	// The Evaluate service passes a proposed transaction to the gateway in order to invoke the
+	// transaction function and return the result to the client. No ledger updates are made.
+	// The gateway will select an appropriate peer to query based on block height and load.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+}
+
+// UnimplementedGatewayServer can be embedded to have forward compatible implementations.
+type UnimplementedGatewayServer struct {
+}
+
+func (*UnimplementedGatewayServer) Endorse(ctx context.Context, req *EndorseRequest) (*EndorseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Endorse not implemented")
+}
+func (*UnimplementedGatewayServer) Submit(ctx context.Context, req *SubmitRequest) (*SubmitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (*UnimplementedGatewayServer) CommitStatus(ctx context.Context, req *SignedCommitStatusRequest) (*CommitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitStatus not implemented")
+}
+func (*UnimplementedGatewayServer) Evaluate(ctx context.Context, req *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+
+func RegisterGatewayServer(s *grpc.Server, srv GatewayServer) {
+	s.RegisterService(&_Gateway_serviceDesc, srv)
+}
+
+func _Gateway_Endorse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndorseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).Endorse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/Endorse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).Endorse(ctx, req.(*EndorseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gateway_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/Submit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).Submit(ctx, req.(*SubmitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gateway_CommitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignedCommitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).CommitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/CommitStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).CommitStatus(ctx, req.(*SignedCommitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gateway_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gateway.Gateway/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Gateway_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gateway.Gateway",
+	HandlerType: (*GatewayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Endorse",
+			Handler:    _Gateway_Endorse_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _Gateway_Submit_Handler,
+		},
+		{
+			MethodName: "CommitStatus",
+			Handler:    _Gateway_CommitStatus_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _Gateway_Evaluate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gateway.proto",
+}
@@ -147,8 +147,7 @@ func CreateSignedTx(
 	}
 
 	// the original payload
-	pPayl, err := UnmarshalChaincodeProposalPayload(proposal.Payload)
-	if err != nil {
+	if _, err := UnmarshalChaincodeProposalPayload(proposal.Payload); err != nil {
 		return nil, err
 	}
 
@@ -168,6 +167,44 @@ func CreateSignedTx(
 		return nil, errors.New("signer must be the same as the one referenced in the header")
 	}
 
+	env, err := CreateTx(proposal, resps...)
+	if err != nil {
+		return nil, err
+	}
+
+	// sign the payload
+	sig, err := signer.Sign(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	env.Signature = sig
+
+	return env, nil
+}
+
+// CreateTx assembles an unsigned Envelope message from a proposal and its
+// endorsements. The payload of the returned envelope must be signed by the
+// creator of the proposal before the transaction is submitted for ordering.
+func CreateTx(
+	proposal *peer.Proposal,
+	resps ...*peer.ProposalResponse,
+) (*common.Envelope, error) {
+	if len(resps) == 0 {
+		return nil, errors.New("at least one proposal response is required")
+	}
+
+	// the original header
+	hdr, err := UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	// the original payload
+	pPayl, err := UnmarshalChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, err
+	}
+
 	// ensure that all actions are bitwise equal and that they are successful
 	var a1 []byte
 	for n, r := range resps {
@@ -226,14 +263,8 @@ func CreateSignedTx(
 		return nil, err
 	}
 
-	// sign the payload
-	sig, err := signer.Sign(paylBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	// here's the envelope
-	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
+	// here's the unsigned envelope
+	return &common.Envelope{Payload: paylBytes}, nil
 }
 
 // CreateProposalResponse creates a proposal response.
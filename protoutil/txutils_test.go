@@ -295,6 +295,44 @@ func TestCreateSignedTxStatus(t *testing.T) {
 	}
 }
 
+func TestCreateTx(t *testing.T) {
+	proposal := &pb.Proposal{
+		Header: protoutil.MarshalOrPanic(&cb.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+				Extension: protoutil.MarshalOrPanic(&pb.ChaincodeHeaderExtension{}),
+			}),
+			SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+				Creator: []byte("creator"),
+			}),
+		}),
+	}
+
+	_, err := protoutil.CreateTx(proposal)
+	require.EqualError(t, err, "at least one proposal response is required")
+
+	responses := []*pb.ProposalResponse{
+		{Payload: []byte("payload"), Endorsement: &pb.Endorsement{Endorser: []byte("endorser1")}, Response: &pb.Response{Status: int32(200)}},
+		{Payload: []byte("payload"), Endorsement: &pb.Endorsement{Endorser: []byte("endorser2")}, Response: &pb.Response{Status: int32(200)}},
+	}
+	env, err := protoutil.CreateTx(proposal, responses...)
+	require.NoError(t, err)
+	require.Nil(t, env.Signature)
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	tx, err := protoutil.UnmarshalTransaction(payload.Data)
+	require.NoError(t, err)
+	require.Len(t, tx.Actions, 1)
+	cap, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+	require.Equal(t, []byte("payload"), cap.Action.ProposalResponsePayload)
+	require.Len(t, cap.Action.Endorsements, 2)
+
+	responses[1].Payload = []byte("payload2")
+	_, err = protoutil.CreateTx(proposal, responses...)
+	require.EqualError(t, err, "ProposalResponsePayloads do not match")
+}
+
 func TestCreateSignedEnvelope(t *testing.T) {
 	var env *cb.Envelope
 	channelID := "mychannelID"
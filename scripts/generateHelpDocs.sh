@@ -51,7 +51,7 @@ generateHelpText \
         docs/wrappers/peer_chaincode_postscript.md \
         "${commands[@]}"
 
-commands=("peer lifecycle" "peer lifecycle chaincode" "peer lifecycle chaincode package" "peer lifecycle chaincode install" "peer lifecycle chaincode queryinstalled" "peer lifecycle chaincode getinstalledpackage" "peer lifecycle chaincode approveformyorg" "peer lifecycle chaincode queryapproved" "peer lifecycle chaincode checkcommitreadiness" "peer lifecycle chaincode commit" "peer lifecycle chaincode querycommitted")
+commands=("peer lifecycle" "peer lifecycle chaincode" "peer lifecycle chaincode package" "peer lifecycle chaincode install" "peer lifecycle chaincode queryinstalled" "peer lifecycle chaincode getinstalledpackage" "peer lifecycle chaincode uninstall" "peer lifecycle chaincode approveformyorg" "peer lifecycle chaincode queryapproved" "peer lifecycle chaincode checkcommitreadiness" "peer lifecycle chaincode commit" "peer lifecycle chaincode querycommitted")
 generateHelpText \
         docs/source/commands/peerlifecycle.md \
         docs/wrappers/peer_lifecycle_chaincode_preamble.md \
@@ -72,6 +72,13 @@ generateHelpText \
         docs/wrappers/peer_node_postscript.md \
         "${commands[@]}"
 
+commands=("peer snapshot cancelrequest" "peer snapshot listpending" "peer snapshot submitrequest")
+generateHelpText \
+        docs/source/commands/peersnapshot.md \
+        docs/wrappers/peer_snapshot_preamble.md \
+        docs/wrappers/peer_snapshot_postscript.md \
+        "${commands[@]}"
+
 commands=("configtxgen")
 generateHelpText \
         docs/source/commands/configtxgen.md \